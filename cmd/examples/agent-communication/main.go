@@ -29,11 +29,11 @@ import (
     "log"
     "net/http"
     "net/http/httptest"
-    "time"
 
 	stdcrypto "crypto"
 
 	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
 	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
 	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
@@ -94,40 +94,6 @@ func min(a, b int) int {
 	return b
 }
 
-// mockEthereumClient simulates blockchain DID resolution
-type mockEthereumClient struct {
-    publicKeys map[did.AgentDID]*ecdsa.PublicKey
-}
-
-func (m *mockEthereumClient) ResolveAllPublicKeys(ctx context.Context, agentDID did.AgentDID) ([]did.AgentKey, error) {
-	// Return empty list for simplicity in this example
-	return []did.AgentKey{}, nil
-}
-
-func (m *mockEthereumClient) ResolvePublicKeyByType(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) (interface{}, error) {
-    if pubKey, found := m.publicKeys[agentDID]; found {
-        return pubKey, nil
-    }
-    return nil, fmt.Errorf("DID not found: %s", agentDID)
-}
-
-// Satisfy DIDResolver used by DefaultKeySelector
-func (m *mockEthereumClient) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
-    d := did.AgentDID(didStr)
-    meta := &did.AgentMetadataV4{DID: d, IsActive: true}
-    if pk, ok := m.publicKeys[d]; ok {
-        if keyData, err := did.MarshalPublicKey(pk); err == nil {
-            meta.Keys = append(meta.Keys, did.AgentKey{
-                Type:      did.KeyTypeECDSA,
-                KeyData:   keyData,
-                Verified:  true,
-                CreatedAt: time.Now(),
-            })
-        }
-    }
-    return meta, nil
-}
-
 // This example demonstrates agent-to-agent communication with DID-based authentication
 func main() {
 	fmt.Println("=== Agent-to-Agent Communication Example ===")
@@ -175,11 +141,12 @@ func main() {
 	// Step 3: Set up mock blockchain client for DID resolution
 	fmt.Println("Step 3: Setting up DID resolution (mock blockchain)...")
 
-	mockClient := &mockEthereumClient{
-		publicKeys: map[did.AgentDID]*ecdsa.PublicKey{
-			agentADID: &privKeyA.PublicKey,
-			agentBDID: &privKeyB.PublicKey,
-		},
+	mockChain := registry.NewMemoryRegistry()
+	if err := mockChain.RegisterKeys(ctx, agentADID, &privKeyA.PublicKey); err != nil {
+		log.Fatal(err)
+	}
+	if err := mockChain.RegisterKeys(ctx, agentBDID, &privKeyB.PublicKey); err != nil {
+		log.Fatal(err)
 	}
 
 	fmt.Println("  ✓ Mock blockchain configured with agent public keys")
@@ -221,9 +188,9 @@ func main() {
 	// Create a test server for Agent B
 	serverB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify the signature
-		selector := verifier.NewDefaultKeySelector(mockClient)
+		selector := verifier.NewDefaultKeySelector(mockChain)
 		sigVerifier := verifier.NewRFC9421Verifier()
-		didVerifier := verifier.NewDefaultDIDVerifier(mockClient, selector, sigVerifier)
+		didVerifier := verifier.NewDefaultDIDVerifier(mockChain, selector, sigVerifier)
 
 		fmt.Println("  Verifying HTTP signature...")
 		if err := didVerifier.VerifyHTTPSignature(ctx, r, agentADID); err != nil {
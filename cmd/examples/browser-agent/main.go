@@ -0,0 +1,143 @@
+//go:build js && wasm
+
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command browser-agent is a WebAssembly module letting a browser page act
+// as a SAGE agent. It registers a global "sage" object with two functions:
+//
+//	sage.init(did, algorithm)            // Promise<{did, algorithm, publicKey}>
+//	sage.sign(method, url, body, type)   // Promise<{header: value, ...}>
+//
+// init generates a WebCrypto key pair ("Ed25519" or "P-256") held in memory
+// for the page's lifetime; publicKey is the base64 SubjectPublicKeyInfo to
+// register for the DID. sign returns the headers to send with fetch,
+// including Signature, Signature-Input and Content-Digest.
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall/js"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// agent is the identity set up by sage.init
+type agent struct {
+	did     did.AgentDID
+	keyPair sagecrypto.KeyPair
+	signer  signer.A2ASigner
+}
+
+var current *agent
+
+func main() {
+	js.Global().Set("sage", js.ValueOf(map[string]any{
+		"init": asyncFunc(initAgent),
+		"sign": asyncFunc(signRequest),
+	}))
+
+	// Keep the module alive so the page can keep calling it
+	select {}
+}
+
+// initAgent implements sage.init(did, algorithm)
+func initAgent(args []js.Value) (any, error) {
+	if len(args) < 2 {
+		return nil, errors.New("usage: sage.init(did, algorithm)")
+	}
+	agentDID := did.AgentDID(args[0].String())
+	algorithm := args[1].String()
+
+	keyPair, err := signer.GenerateWebCryptoKeyPair(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	spki, err := x509.MarshalPKIXPublicKey(keyPair.PublicKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	current = &agent{did: agentDID, keyPair: keyPair, signer: signer.NewDefaultA2ASigner()}
+	return map[string]any{
+		"did":       string(agentDID),
+		"algorithm": algorithm,
+		"publicKey": base64.StdEncoding.EncodeToString(spki),
+	}, nil
+}
+
+// signRequest implements sage.sign(method, url, body, contentType)
+func signRequest(args []js.Value) (any, error) {
+	if current == nil {
+		return nil, errors.New("sage.init must be called first")
+	}
+	if len(args) < 2 {
+		return nil, errors.New("usage: sage.sign(method, url, body, contentType)")
+	}
+	var body []byte
+	if len(args) > 2 && args[2].Type() == js.TypeString {
+		body = []byte(args[2].String())
+	}
+
+	req, err := http.NewRequest(args[0].String(), args[1].String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 3 && args[3].Type() == js.TypeString {
+		req.Header.Set("Content-Type", args[3].String())
+	}
+	if err := current.signer.SignRequest(context.Background(), req, current.did, current.keyPair); err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]any, len(req.Header))
+	for name := range req.Header {
+		headers[name] = req.Header.Get(name)
+	}
+	return headers, nil
+}
+
+// asyncFunc exposes fn to JavaScript as a function returning a Promise.
+// fn runs on its own goroutine, since WebCrypto calls block on promises
+// that only settle once the calling JavaScript code returns.
+func asyncFunc(fn func(args []js.Value) (any, error)) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		var executor js.Func
+		executor = js.FuncOf(func(_ js.Value, settle []js.Value) any {
+			resolve, reject := settle[0], settle[1]
+			go func() {
+				defer executor.Release()
+				result, err := fn(args)
+				if err != nil {
+					reject.Invoke(js.Global().Get("Error").New(err.Error()))
+					return
+				}
+				resolve.Invoke(js.ValueOf(result))
+			}()
+			return nil
+		})
+		return js.Global().Get("Promise").New(executor)
+	})
+}
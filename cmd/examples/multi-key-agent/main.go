@@ -29,59 +29,11 @@ import (
 	"time"
 
 	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
 	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
-// mockEthereumClient simulates blockchain with multi-key support
-type mockEthereumClient struct {
-    publicKeys map[did.AgentDID]map[did.KeyType]interface{}
-}
-
-func (m *mockEthereumClient) ResolveAllPublicKeys(ctx context.Context, agentDID did.AgentDID) ([]did.AgentKey, error) {
-	keys := []did.AgentKey{}
-	if keyMap, found := m.publicKeys[agentDID]; found {
-		for keyType, pubKey := range keyMap {
-			keyData, _ := did.MarshalPublicKey(pubKey)
-			keys = append(keys, did.AgentKey{
-				Type:      keyType,
-				KeyData:   keyData,
-				Verified:  true,
-				CreatedAt: time.Now(),
-			})
-		}
-	}
-	return keys, nil
-}
-
-func (m *mockEthereumClient) ResolvePublicKeyByType(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) (interface{}, error) {
-    if keyMap, found := m.publicKeys[agentDID]; found {
-        if pubKey, found := keyMap[keyType]; found {
-            return pubKey, nil
-        }
-    }
-    return nil, fmt.Errorf("key type %s not found for DID %s", keyType, agentDID)
-}
-
-// Satisfy DIDResolver for key selection
-func (m *mockEthereumClient) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
-    d := did.AgentDID(didStr)
-    meta := &did.AgentMetadataV4{DID: d, IsActive: true}
-    if keyMap, ok := m.publicKeys[d]; ok {
-        for kt, pk := range keyMap {
-            if keyData, err := did.MarshalPublicKey(pk); err == nil {
-                meta.Keys = append(meta.Keys, did.AgentKey{
-                    Type:      kt,
-                    KeyData:   keyData,
-                    Verified:  true,
-                    CreatedAt: time.Now(),
-                })
-            }
-        }
-    }
-    return meta, nil
-}
-
 // This example demonstrates managing an agent with multiple cryptographic keys
 func main() {
 	fmt.Println("=== Multi-Key Agent Example ===")
@@ -167,13 +119,9 @@ func main() {
 	// Step 5: Set up mock blockchain with both keys
 	fmt.Println("Step 5: Registering keys on blockchain (mock)...")
 
-	mockClient := &mockEthereumClient{
-		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{
-			agentDID: {
-				did.KeyTypeECDSA:   ecdsaPubKey,
-				did.KeyTypeEd25519: ed25519PubKey,
-			},
-		},
+	mockChain := registry.NewMemoryRegistry()
+	if err := mockChain.RegisterKeys(ctx, agentDID, ecdsaPubKey, ed25519PubKey); err != nil {
+		log.Fatal(err)
 	}
 
 	fmt.Println("  ✓ ECDSA key registered for Ethereum protocol")
@@ -182,7 +130,7 @@ func main() {
 	// Step 6: Demonstrate protocol-based key selection
 	fmt.Println("Step 6: Protocol-based key selection...")
 
-	selector := verifier.NewDefaultKeySelector(mockClient)
+	selector := verifier.NewDefaultKeySelector(mockChain)
 
 	// Select key for Ethereum
 	fmt.Println("\n  Scenario 1: Communicating with Ethereum network")
@@ -23,32 +23,13 @@ import (
 	"log"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	_ "github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
 	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
-func init() {
-	// Initialize crypto package
-	crypto.SetKeyGenerators(
-		func() (crypto.KeyPair, error) { return keys.GenerateEd25519KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateSecp256k1KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateP256KeyPair() },
-	)
-	crypto.SetStorageConstructors(
-		func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() },
-	)
-	crypto.SetFormatConstructors(
-		func() crypto.KeyExporter { return formats.NewJWKExporter() },
-		func() crypto.KeyExporter { return formats.NewPEMExporter() },
-		func() crypto.KeyImporter { return formats.NewJWKImporter() },
-		func() crypto.KeyImporter { return formats.NewPEMImporter() },
-	)
-}
-
 func main() {
 	fmt.Println("SAGE A2A Go - Simple Client Example")
 	fmt.Println("=====================================")
@@ -63,7 +44,11 @@ func main() {
 		log.Fatalf("Failed to generate key pair: %v", err)
 	}
 	clientDID := did.AgentDID("did:sage:ethereum:0x1234567890abcdef1234567890abcdef12345678")
-	fmt.Printf("   Client DID: %s\n", clientDID)
+	clientIdentity, err := identity.NewIdentity(clientDID, keyPair)
+	if err != nil {
+		log.Fatalf("Failed to create identity: %v", err)
+	}
+	fmt.Printf("   Client DID: %s\n", clientIdentity.DID)
 
 	// Create agent card for target agent
 	fmt.Println("\n2. Creating agent card for target agent...")
@@ -84,10 +69,9 @@ func main() {
 
 	// Create DID-authenticated client
 	fmt.Println("\n3. Creating DID-authenticated A2A client...")
-	client, err := transport.NewDIDAuthenticatedClient(
+	client, err := transport.NewDIDAuthenticatedClientFromIdentity(
 		ctx,
-		clientDID,
-		keyPair,
+		clientIdentity,
 		targetCard,
 	)
 	if err != nil {
@@ -35,34 +35,12 @@ import (
 	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	_ "github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
 	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
-func init() {
-	// Initialize crypto subsystem with all key generators
-	crypto.SetKeyGenerators(
-		func() (crypto.KeyPair, error) { return keys.GenerateEd25519KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateSecp256k1KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateP256KeyPair() },
-	)
-
-	crypto.SetStorageConstructors(
-		func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() },
-	)
-
-	crypto.SetFormatConstructors(
-		func() crypto.KeyExporter { return formats.NewJWKExporter() },
-		func() crypto.KeyExporter { return formats.NewPEMExporter() },
-		func() crypto.KeyImporter { return formats.NewJWKImporter() },
-		func() crypto.KeyImporter { return formats.NewPEMImporter() },
-	)
-}
-
 // ProgressTracker tracks streaming progress
 type ProgressTracker struct {
 	startTime time.Time
@@ -0,0 +1,80 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+// Command gen-vectors writes the cross-language test vector corpus used by
+// the Python and TypeScript SAGE implementations.
+//
+// Usage:
+//
+//	gen-vectors [-o vectors.json] [-created 1700000000] [-check vectors.json]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/vectors"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default stdout)")
+	created := flag.Int64("created", 0, "signature creation time as Unix seconds (default now)")
+	check := flag.String("check", "", "check an existing corpus instead of generating one")
+	flag.Parse()
+
+	if *check != "" {
+		corpus, err := vectors.Load(*check)
+		if err != nil {
+			log.Fatal(err)
+		}
+		failures := vectors.Check(context.Background(), corpus)
+		for _, failure := range failures {
+			fmt.Fprintln(os.Stderr, failure)
+		}
+		if len(failures) > 0 {
+			os.Exit(1)
+		}
+		fmt.Printf("%d requests, %d cards, %d streams OK\n", len(corpus.Requests), len(corpus.Cards), len(corpus.Streams))
+		return
+	}
+
+	opts := vectors.Options{}
+	if *created != 0 {
+		opts.Created = time.Unix(*created, 0)
+	}
+	corpus, err := vectors.Generate(opts)
+	if err != nil {
+		log.Fatalf("Failed to generate vectors: %v", err)
+	}
+	data, err := json.MarshalIndent(corpus, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode vectors: %v", err)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatalf("Failed to write vectors: %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command loadgen spins up simulated DID agents with generated keys and an
+// in-memory registry, drives a weighted mix of message/send,
+// message/stream and tasks/get requests against a target agent, and
+// reports signing throughput, verification failure rates and latency
+// percentiles.
+//
+// Without -target, requests go to a built-in agent verifying signatures
+// against the simulated agents' registry. Rogue agents sign with keys the
+// target does not know, so their requests must be rejected. An external
+// target must accept the simulated DIDs, e.g. by running in dev mode.
+//
+// Usage:
+//
+//	loadgen [-target http://localhost:8080] [-agents 10] [-rogue 0] [-concurrency 16]
+//	    [-duration 10s] [-requests 0] [-mix send=70,stream=10,get=20] [-sign-iterations 1000]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/sage-x-project/sage-a2a-go/pkg/devmode"
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
+)
+
+// maxKnownTasks bounds the task IDs kept for tasks/get requests
+const maxKnownTasks = 1024
+
+func main() {
+	targetURL := flag.String("target", "", "base URL of the target agent (default built-in agent)")
+	agents := flag.Int("agents", 10, "number of registered simulated agents")
+	rogue := flag.Int("rogue", 0, "number of unregistered agents whose requests must be rejected")
+	concurrency := flag.Int("concurrency", 16, "number of concurrent requests")
+	duration := flag.Duration("duration", 10*time.Second, "how long to generate load")
+	requests := flag.Int("requests", 0, "stop after this many requests (default run for -duration)")
+	mixFlag := flag.String("mix", "send=70,stream=10,get=20", "weighted request mix")
+	signIterations := flag.Int("sign-iterations", 1000, "signatures measured for signing throughput (0 to skip)")
+	flag.Parse()
+
+	if *agents+*rogue <= 0 || *concurrency <= 0 {
+		log.Fatal("-agents plus -rogue and -concurrency must be positive")
+	}
+	m, err := parseMix(*mixFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Dev mode logs every rejection; keep only its warning
+	quiet := devmode.WithLogger(func(string, ...any) {})
+	fmt.Fprintln(os.Stderr, devmode.Warning)
+	env := devmode.New(quiet)
+	rogueEnv := devmode.New(quiet)
+
+	ctx := context.Background()
+	ids := make([]*identity.Identity, 0, *agents+*rogue)
+	for i := 0; i < *agents; i++ {
+		id, err := env.NewIdentity(ctx, fmt.Sprintf("loadgen-agent-%d", i))
+		if err != nil {
+			log.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	for i := 0; i < *rogue; i++ {
+		id, err := rogueEnv.NewIdentity(ctx, fmt.Sprintf("loadgen-rogue-%d", i))
+		if err != nil {
+			log.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if *targetURL == "" {
+		url, stop, err := serveTarget(env)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer stop()
+		*targetURL = url
+	}
+
+	if *signIterations > 0 {
+		id := ids[0]
+		result, err := signer.Bench(ctx, signer.NewDefaultA2ASigner(), id.DID, id.KeyPair, signer.BenchOptions{
+			Iterations:  *signIterations,
+			Warmup:      *signIterations / 10,
+			Concurrency: *concurrency,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("signing: %s\n\n", result)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{MaxIdleConnsPerHost: *concurrency},
+		Timeout:   30 * time.Second,
+	}
+	transports := make([]a2aclient.Transport, len(ids))
+	for i, id := range ids {
+		transports[i] = transport.NewDIDHTTPTransportFromIdentity(*targetURL, id, client)
+	}
+
+	fmt.Printf("%d agents (%d rogue) -> %s, concurrency %d, mix %s\n\n",
+		len(ids), *rogue, *targetURL, *concurrency, *mixFlag)
+	g := &generator{transports: transports, mix: m, stats: newStats(), limit: int64(*requests)}
+	runCtx, cancel := context.WithTimeout(ctx, *duration)
+	defer cancel()
+	start := time.Now()
+	g.run(runCtx, *concurrency)
+	g.stats.report(os.Stdout, time.Since(start))
+}
+
+// serveTarget starts the built-in target agent on a loopback port,
+// verifying requests against env, and returns its URL
+func serveTarget(env *devmode.Environment) (string, func(), error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           env.Middleware().Wrap(newTarget()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+	return "http://" + ln.Addr().String(), func() { _ = srv.Close() }, nil
+}
+
+// generator issues requests from random simulated agents
+type generator struct {
+	transports []a2aclient.Transport
+	mix        *mix
+	stats      *stats
+	limit      int64 // total requests, 0 for no limit
+	issued     atomic.Int64
+
+	mu    sync.Mutex
+	tasks []a2a.TaskID
+	added int
+}
+
+// run issues requests from concurrency workers until ctx is done or the
+// request limit is reached
+func (g *generator) run(ctx context.Context, concurrency int) {
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for ctx.Err() == nil {
+				if g.limit > 0 && g.issued.Add(1) > g.limit {
+					return
+				}
+				t := g.transports[rng.Intn(len(g.transports))]
+				kind := g.mix.pick(rng)
+				start := time.Now()
+				kind, err := g.do(ctx, t, kind, rng)
+				latency := time.Since(start)
+				if ctx.Err() != nil {
+					// Cut short by the end of the run
+					return
+				}
+				g.stats.record(kind, latency, err)
+			}
+		}(time.Now().UnixNano() + int64(w))
+	}
+	wg.Wait()
+}
+
+// do issues one request, returning the op actually performed: a get falls
+// back to a send until some task is known
+func (g *generator) do(ctx context.Context, t a2aclient.Transport, kind op, rng *rand.Rand) (op, error) {
+	if kind == opGet {
+		if taskID, ok := g.knownTask(rng); ok {
+			_, err := t.GetTask(ctx, &a2a.TaskQueryParams{ID: taskID})
+			return opGet, err
+		}
+		kind = opSend
+	}
+
+	params := &a2a.MessageSendParams{
+		Message: a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "loadgen"}),
+	}
+	if kind == opStream {
+		for event, err := range t.SendStreamingMessage(ctx, params) {
+			if err != nil {
+				return opStream, err
+			}
+			if task, ok := event.(*a2a.Task); ok {
+				g.addTask(task.ID)
+			}
+		}
+		return opStream, nil
+	}
+
+	result, err := t.SendMessage(ctx, params)
+	if task, ok := result.(*a2a.Task); ok && err == nil {
+		g.addTask(task.ID)
+	}
+	return opSend, err
+}
+
+func (g *generator) addTask(id a2a.TaskID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.tasks) < maxKnownTasks {
+		g.tasks = append(g.tasks, id)
+	} else {
+		g.tasks[g.added%maxKnownTasks] = id
+	}
+	g.added++
+}
+
+func (g *generator) knownTask(rng *rand.Rand) (a2a.TaskID, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.tasks) == 0 {
+		return "", false
+	}
+	return g.tasks[rng.Intn(len(g.tasks))], true
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/internal/percentile"
+	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
+)
+
+// op is a kind of request issued by simulated agents
+type op string
+
+const (
+	opSend   op = "send"
+	opStream op = "stream"
+	opGet    op = "get"
+)
+
+var ops = []op{opSend, opStream, opGet}
+
+// mix is a weighted request mix
+type mix struct {
+	weights map[op]int
+	total   int
+}
+
+// parseMix parses a request mix such as "send=70,stream=10,get=20"
+func parseMix(s string) (*mix, error) {
+	m := &mix{weights: make(map[op]int)}
+	for _, entry := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mix entry %q: expected op=weight", entry)
+		}
+		kind := op(strings.TrimSpace(name))
+		if kind != opSend && kind != opStream && kind != opGet {
+			return nil, fmt.Errorf("unknown op %q in mix", kind)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q for %s", value, kind)
+		}
+		m.weights[kind] += weight
+		m.total += weight
+	}
+	if m.total == 0 {
+		return nil, fmt.Errorf("mix %q has no weight", s)
+	}
+	return m, nil
+}
+
+// pick returns a random op according to the mix weights
+func (m *mix) pick(rng *rand.Rand) op {
+	n := rng.Intn(m.total)
+	for _, kind := range ops {
+		if n < m.weights[kind] {
+			return kind
+		}
+		n -= m.weights[kind]
+	}
+	return opSend
+}
+
+// stats collects request outcomes per op
+type stats struct {
+	mu        sync.Mutex
+	latencies map[op][]time.Duration
+	errors    map[op]int
+	rejected  map[op]int
+}
+
+func newStats() *stats {
+	return &stats{
+		latencies: make(map[op][]time.Duration),
+		errors:    make(map[op]int),
+		rejected:  make(map[op]int),
+	}
+}
+
+// record adds the outcome of one request. Requests the target refused
+// with 401 count as verification failures.
+func (s *stats) record(kind op, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[kind] = append(s.latencies[kind], latency)
+	if err == nil {
+		return
+	}
+	s.errors[kind]++
+	var httpErr *transport.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized {
+		s.rejected[kind]++
+	}
+}
+
+// report writes a table of request counts, error and verification failure
+// rates and latency percentiles per op, followed by the totals
+func (s *stats) report(w io.Writer, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(w, "%-7s %8s %8s %9s %10s %10s %10s %10s\n",
+		"op", "requests", "errors", "verifyErr", "p50", "p90", "p99", "max")
+	var requests, errs, rejected int
+	for _, kind := range ops {
+		latencies := s.latencies[kind]
+		if len(latencies) == 0 {
+			continue
+		}
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		n := len(latencies)
+		fmt.Fprintf(w, "%-7s %8d %8d %8.2f%% %10s %10s %10s %10s\n",
+			kind, n, s.errors[kind], percent(s.rejected[kind], n),
+			round(latencies[percentile.Index(n, 50)]), round(latencies[percentile.Index(n, 90)]),
+			round(latencies[percentile.Index(n, 99)]), round(latencies[n-1]))
+		requests += n
+		errs += s.errors[kind]
+		rejected += s.rejected[kind]
+	}
+	fmt.Fprintf(w, "\n%d requests in %s (%.0f req/s): %.2f%% errors, %.2f%% verification failures\n",
+		requests, elapsed.Round(time.Millisecond), float64(requests)/elapsed.Seconds(),
+		percent(errs, requests), percent(rejected, requests))
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// target is the built-in target agent: a JSON-RPC endpoint answering
+// message/send, message/stream and tasks/get from memory, so load runs
+// measure the SAGE layers rather than agent logic
+type target struct {
+	mu    sync.Mutex
+	tasks map[a2a.TaskID]*a2a.Task
+}
+
+func newTarget() *target {
+	return &target{tasks: make(map[a2a.TaskID]*a2a.Task)}
+}
+
+// rpcRequest is an incoming JSON-RPC request
+type rpcRequest struct {
+	ID     any             `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+func (t *target) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON-RPC request", http.StatusBadRequest)
+		return
+	}
+
+	switch req.Method {
+	case "message/send":
+		task, err := t.complete(req.Params)
+		if err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		writeRPCResult(w, req.ID, task)
+	case "message/stream":
+		task, err := t.complete(req.Params)
+		if err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		submitted := *task
+		submitted.Status = a2a.TaskStatus{State: a2a.TaskStateSubmitted}
+		done := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)
+		done.Final = true
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeEvent(w, req.ID, map[string]any{"task": &submitted})
+		writeEvent(w, req.ID, map[string]any{"statusUpdate": done})
+	case "tasks/get":
+		var params a2a.TaskQueryParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		t.mu.Lock()
+		task, ok := t.tasks[params.ID]
+		t.mu.Unlock()
+		if !ok {
+			writeRPCError(w, req.ID, -32001, "task not found")
+			return
+		}
+		writeRPCResult(w, req.ID, task)
+	default:
+		writeRPCError(w, req.ID, -32601, fmt.Sprintf("method %q not found", req.Method))
+	}
+}
+
+// complete records a completed task answering the message in params
+func (t *target) complete(params json.RawMessage) (*a2a.Task, error) {
+	var p a2a.MessageSendParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Message == nil {
+		return nil, fmt.Errorf("message is required")
+	}
+	task := &a2a.Task{
+		ID:        a2a.NewTaskID(),
+		ContextID: a2a.NewContextID(),
+		Status:    a2a.TaskStatus{State: a2a.TaskStateCompleted},
+		History:   []*a2a.Message{p.Message},
+	}
+	t.mu.Lock()
+	t.tasks[task.ID] = task
+	t.mu.Unlock()
+	return task, nil
+}
+
+func writeRPCResult(w http.ResponseWriter, id, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+}
+
+func writeRPCError(w http.ResponseWriter, id any, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error":   map[string]any{"code": code, "message": message},
+	})
+}
+
+// writeEvent writes one SSE event carrying a JSON-RPC result
+func writeEvent(w http.ResponseWriter, id, result any) {
+	data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
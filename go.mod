@@ -4,8 +4,12 @@ go 1.24.4
 
 require (
 	github.com/a2aproject/a2a-go v0.0.0-20251023091533-c732060cb007 // A2A Protocol Go SDK
+	github.com/ethereum/go-ethereum v1.16.5
+	github.com/gorilla/websocket v1.5.3
+	github.com/quic-go/quic-go v0.59.1
 	github.com/sage-x-project/sage v1.3.1
 	github.com/stretchr/testify v1.11.1
+	go.uber.org/mock v0.5.2
 )
 
 require (
@@ -21,15 +25,14 @@ require (
 	github.com/deckarep/golang-set/v2 v2.6.0 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
 	github.com/ethereum/c-kzg-4844/v2 v2.1.3 // indirect
-	github.com/ethereum/go-ethereum v1.16.5 // indirect
 	github.com/ethereum/go-verkle v0.2.2 // indirect
 	github.com/fsnotify/fsnotify v1.6.0 // indirect
 	github.com/go-ole/go-ole v1.3.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
-	github.com/gorilla/websocket v1.5.3 // indirect
 	github.com/holiman/uint256 v1.3.2 // indirect
 	github.com/mr-tron/base58 v1.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/quic-go/qpack v0.6.0 // indirect
 	github.com/shirou/gopsutil v3.21.4-0.20210419000835-c7a38de76ee5+incompatible // indirect
 	github.com/supranational/blst v0.3.16-0.20250831170142-f48500c1fdbe // indirect
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
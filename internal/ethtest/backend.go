@@ -0,0 +1,512 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package ethtest fakes an Ethereum node running sage's SageRegistryV4
+// contract, for tests of the on-chain registry adapters. Transactions are
+// mined as soon as they are sent and are checked the way the contract
+// checks them, including the ECDSA key ownership proofs.
+package ethtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/sage-x-project/sage/pkg/blockchain/ethereum/contracts/registryv4"
+)
+
+// EstimatedGas is the gas the fake estimates for every transaction
+const EstimatedGas = 250_000
+
+// ChainID is the chain ID of the fake node
+var ChainID = big.NewInt(1337)
+
+// Contract key types
+const (
+	keyTypeEd25519 = 0
+	keyTypeECDSA   = 1
+)
+
+// Backend is a fake node with a SageRegistryV4 contract at every address.
+// It is safe for concurrent use.
+type Backend struct {
+	contract *abi.ABI
+
+	mu       sync.Mutex
+	block    uint64
+	nonces   map[common.Address]uint64
+	receipts map[common.Hash]*types.Receipt
+	sent     []*types.Transaction
+
+	agents      map[[32]byte]*registryv4.ISageRegistryV4AgentMetadata
+	agentNonces map[[32]byte]int64
+	didToAgent  map[string][32]byte
+	keys        map[[32]byte]*registryv4.ISageRegistryV4AgentKey
+}
+
+// NewBackend returns a fake node with an empty registry
+func NewBackend() (*Backend, error) {
+	contract, err := registryv4.SageRegistryV4MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{
+		contract:    contract,
+		nonces:      make(map[common.Address]uint64),
+		receipts:    make(map[common.Hash]*types.Receipt),
+		agents:      make(map[[32]byte]*registryv4.ISageRegistryV4AgentMetadata),
+		agentNonces: make(map[[32]byte]int64),
+		didToAgent:  make(map[string][32]byte),
+		keys:        make(map[[32]byte]*registryv4.ISageRegistryV4AgentKey),
+	}, nil
+}
+
+// Sent returns the transactions sent so far, in order
+func (b *Backend) Sent() []*types.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.sent)
+}
+
+// ApproveKey marks the registered key with keyData verified, as the
+// contract owner's approveEd25519Key does
+func (b *Backend) ApproveKey(keyData []byte) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, key := range b.keys {
+		if bytes.Equal(key.KeyData, keyData) {
+			key.Verified = true
+			return true
+		}
+	}
+	return false
+}
+
+// CodeAt implements bind.ContractCaller
+func (b *Backend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60}, nil
+}
+
+// PendingCodeAt implements bind.ContractTransactor
+func (b *Backend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return []byte{0x60}, nil
+}
+
+// CallContract implements bind.ContractCaller for the registry's view
+// functions
+func (b *Backend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, args, err := b.decode(call.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch method.Name {
+	case "getAgentByDID":
+		id, ok := b.didToAgent[args[0].(string)]
+		if !ok {
+			return nil, revert("Agent not found")
+		}
+		return method.Outputs.Pack(*b.agents[id])
+	case "getAgent":
+		agent, ok := b.agents[args[0].([32]byte)]
+		if !ok {
+			return nil, revert("Agent not found")
+		}
+		return method.Outputs.Pack(*agent)
+	case "getAgentKeys":
+		agent, ok := b.agents[args[0].([32]byte)]
+		if !ok {
+			return nil, revert("Agent not found")
+		}
+		return method.Outputs.Pack(agent.KeyHashes)
+	case "getAgentsByOwner":
+		owner := args[0].(common.Address)
+		ids := [][32]byte{}
+		for id, agent := range b.agents {
+			if agent.Owner == owner {
+				ids = append(ids, id)
+			}
+		}
+		return method.Outputs.Pack(ids)
+	case "getKey":
+		key, ok := b.keys[args[0].([32]byte)]
+		if !ok {
+			return nil, revert("Key not found")
+		}
+		return method.Outputs.Pack(*key)
+	case "getNonce":
+		id := args[0].([32]byte)
+		if _, ok := b.agents[id]; !ok {
+			return nil, revert("Agent not found")
+		}
+		return method.Outputs.Pack(big.NewInt(b.agentNonces[id]))
+	default:
+		return nil, fmt.Errorf("ethtest: unsupported call %s", method.Name)
+	}
+}
+
+// EstimateGas implements bind.ContractTransactor, failing for calls the
+// contract would revert
+func (b *Backend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.execute(call.From, call.Data, false); err != nil {
+		return 0, err
+	}
+	return EstimatedGas, nil
+}
+
+// SendTransaction implements bind.ContractTransactor. The transaction is
+// mined into a new block right away; a reverted call gets a failed
+// receipt.
+func (b *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(ChainID), tx)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if tx.Nonce() != b.nonces[from] {
+		return fmt.Errorf("nonce too low: have %d, want %d", tx.Nonce(), b.nonces[from])
+	}
+	b.nonces[from]++
+	b.block++
+	status := types.ReceiptStatusSuccessful
+	if b.execute(from, tx.Data(), true) != nil {
+		status = types.ReceiptStatusFailed
+	}
+	b.sent = append(b.sent, tx)
+	b.receipts[tx.Hash()] = &types.Receipt{
+		Status:      status,
+		TxHash:      tx.Hash(),
+		GasUsed:     tx.Gas(),
+		BlockNumber: new(big.Int).SetUint64(b.block),
+	}
+	return nil
+}
+
+// TransactionReceipt implements bind.DeployBackend
+func (b *Backend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// HeaderByNumber implements bind.ContractTransactor. Headers have no base
+// fee, so transactors send legacy transactions.
+func (b *Backend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &types.Header{Number: new(big.Int).SetUint64(b.block)}, nil
+}
+
+// PendingNonceAt implements bind.ContractTransactor
+func (b *Backend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.nonces[account], nil
+}
+
+// SuggestGasPrice implements bind.ContractTransactor
+func (b *Backend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1_000_000_000), nil
+}
+
+// SuggestGasTipCap implements bind.ContractTransactor
+func (b *Backend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1_000_000_000), nil
+}
+
+// FilterLogs implements bind.ContractFilterer
+func (b *Backend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+// SubscribeFilterLogs implements bind.ContractFilterer
+func (b *Backend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("ethtest: subscriptions are not supported")
+}
+
+// BlockNumber returns the number of the latest block
+func (b *Backend) BlockNumber(ctx context.Context) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.block, nil
+}
+
+// ChainID returns ChainID
+func (b *Backend) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(ChainID), nil
+}
+
+func (b *Backend) decode(data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("ethtest: call data too short")
+	}
+	method, err := b.contract.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return method, args, nil
+}
+
+// execute runs a state-changing call from sender, applying it only when
+// commit is set. The caller holds b.mu.
+func (b *Backend) execute(sender common.Address, data []byte, commit bool) error {
+	method, args, err := b.decode(data)
+	if err != nil {
+		return err
+	}
+	switch method.Name {
+	case "registerAgent":
+		params := abi.ConvertType(args[0], new(registryv4.ISageRegistryV4RegistrationParams)).(*registryv4.ISageRegistryV4RegistrationParams)
+		return b.registerAgent(sender, *params, commit)
+	case "addKey":
+		return b.addKey(sender, args[0].([32]byte), args[1].(uint8), args[2].([]byte), args[3].([]byte), commit)
+	case "revokeKey":
+		return b.revokeKey(sender, args[0].([32]byte), args[1].([32]byte), commit)
+	default:
+		return fmt.Errorf("ethtest: unsupported transaction %s", method.Name)
+	}
+}
+
+func (b *Backend) registerAgent(sender common.Address, params registryv4.ISageRegistryV4RegistrationParams, commit bool) error {
+	switch {
+	case len(params.KeyTypes) != len(params.KeyData) || len(params.KeyTypes) != len(params.Signatures):
+		return revert("Key arrays length mismatch")
+	case len(params.KeyTypes) == 0:
+		return revert("Invalid key count")
+	case params.Did == "":
+		return revert("DID required")
+	case params.Name == "":
+		return revert("Name required")
+	}
+	if _, ok := b.didToAgent[params.Did]; ok {
+		return revert("DID already registered")
+	}
+
+	agentID := ethcrypto.Keccak256Hash(pack(params.Did, params.KeyData[0]))
+	keys := make(map[[32]byte]*registryv4.ISageRegistryV4AgentKey)
+	var hashes [][32]byte
+	for i := range params.KeyTypes {
+		hash, key, err := b.checkKey(sender, agentID, params.KeyTypes[i], params.KeyData[i], params.Signatures[i], 0)
+		if err != nil {
+			return err
+		}
+		if _, ok := keys[hash]; ok {
+			return revert("Key already registered")
+		}
+		keys[hash] = key
+		hashes = append(hashes, hash)
+	}
+	if !commit {
+		return nil
+	}
+
+	now := big.NewInt(time.Now().Unix())
+	b.agents[agentID] = &registryv4.ISageRegistryV4AgentMetadata{
+		Did:          params.Did,
+		Name:         params.Name,
+		Description:  params.Description,
+		Endpoint:     params.Endpoint,
+		KeyHashes:    hashes,
+		Capabilities: params.Capabilities,
+		Owner:        sender,
+		RegisteredAt: now,
+		UpdatedAt:    now,
+		Active:       true,
+	}
+	b.didToAgent[params.Did] = agentID
+	for hash, key := range keys {
+		b.keys[hash] = key
+	}
+	return nil
+}
+
+func (b *Backend) addKey(sender common.Address, agentID [32]byte, keyType uint8, keyData, signature []byte, commit bool) error {
+	agent, ok := b.agents[agentID]
+	if !ok || agent.Owner != sender {
+		return revert("Not agent owner")
+	}
+	hash, key, err := b.checkKey(sender, agentID, keyType, keyData, signature, b.agentNonces[agentID])
+	if err != nil {
+		return err
+	}
+	if !commit {
+		return nil
+	}
+	b.keys[hash] = key
+	agent.KeyHashes = append(agent.KeyHashes, hash)
+	b.agentNonces[agentID]++
+	return nil
+}
+
+func (b *Backend) revokeKey(sender common.Address, agentID, keyHash [32]byte, commit bool) error {
+	agent, ok := b.agents[agentID]
+	switch {
+	case !ok || agent.Owner != sender:
+		return revert("Not agent owner")
+	case b.keys[keyHash] == nil:
+		return revert("Key not found")
+	case !slices.Contains(agent.KeyHashes, keyHash):
+		return revert("Key not in agent")
+	case len(agent.KeyHashes) < 2:
+		return revert("Cannot revoke last key")
+	}
+	if !commit {
+		return nil
+	}
+	agent.KeyHashes = slices.DeleteFunc(agent.KeyHashes, func(h [32]byte) bool { return h == keyHash })
+	delete(b.keys, keyHash)
+	b.agentNonces[agentID]++
+	return nil
+}
+
+// checkKey validates a key as the contract's _processKey does
+func (b *Backend) checkKey(sender common.Address, agentID [32]byte, keyType uint8, keyData, signature []byte, nonce int64) ([32]byte, *registryv4.ISageRegistryV4AgentKey, error) {
+	hash := ethcrypto.Keccak256Hash(pack(agentID, keyType, keyData))
+	if _, ok := b.keys[hash]; ok {
+		return hash, nil, revert("Key already registered")
+	}
+	key := &registryv4.ISageRegistryV4AgentKey{
+		KeyType:      keyType,
+		KeyData:      keyData,
+		Signature:    signature,
+		RegisteredAt: big.NewInt(time.Now().Unix()),
+	}
+	switch keyType {
+	case keyTypeEd25519:
+		if len(keyData) != 32 {
+			return hash, nil, revert("Invalid Ed25519 key length")
+		}
+	case keyTypeECDSA:
+		message := ethcrypto.Keccak256(pack(agentID, keyData, sender, big.NewInt(nonce)))
+		if err := checkOwnership(sender, keyData, message, signature); err != nil {
+			return hash, nil, err
+		}
+		key.Verified = true
+	default:
+		return hash, nil, revert("Unsupported key type")
+	}
+	return hash, key, nil
+}
+
+// checkOwnership requires signature to be sender's signature of message
+// and keyData to be sender's public key
+func checkOwnership(sender common.Address, keyData, message, signature []byte) error {
+	if len(signature) != 65 {
+		return revert("Invalid signature length")
+	}
+	sig := slices.Clone(signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	digest := ethcrypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), message)
+	pub, err := ethcrypto.SigToPub(digest, sig)
+	if err != nil || ethcrypto.PubkeyToAddress(*pub) != sender {
+		return revert("Invalid signature")
+	}
+
+	raw := keyData
+	if len(raw) == 65 && raw[0] == 0x04 {
+		raw = raw[1:]
+	}
+	if len(raw) != 64 {
+		return revert("Invalid public key length")
+	}
+	if common.BytesToAddress(ethcrypto.Keccak256(raw)[12:]) != sender {
+		return revert("Public key does not match signer")
+	}
+	return nil
+}
+
+// pack ABI-encodes values as abi.encode does
+func pack(values ...interface{}) []byte {
+	args := make(abi.Arguments, len(values))
+	for i, v := range values {
+		var name string
+		switch v.(type) {
+		case string:
+			name = "string"
+		case []byte:
+			name = "bytes"
+		case [32]byte, common.Hash:
+			name = "bytes32"
+		case uint8:
+			name = "uint8"
+		case common.Address:
+			name = "address"
+		case *big.Int:
+			name = "uint256"
+		default:
+			panic(fmt.Sprintf("ethtest: cannot encode %T", v))
+		}
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		args[i] = abi.Argument{Type: typ}
+	}
+	data, err := args.Pack(values...)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// revertError is a reverted call as a node reports it, with the revert
+// data carried in the error
+type revertError struct {
+	reason string
+}
+
+func revert(reason string) error {
+	return &revertError{reason: reason}
+}
+
+func (e *revertError) Error() string { return "execution reverted: " + e.reason }
+
+// ErrorCode implements rpc.Error
+func (e *revertError) ErrorCode() int { return 3 }
+
+// ErrorData implements rpc.DataError, encoding the reason as Error(string)
+func (e *revertError) ErrorData() interface{} {
+	selector := ethcrypto.Keccak256([]byte("Error(string)"))[:4]
+	return hexutil.Encode(append(selector, pack(e.reason)...))
+}
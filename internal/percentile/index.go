@@ -0,0 +1,30 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package percentile holds the rank helpers shared by the load generator and
+// the signer benchmark
+package percentile
+
+// Index returns the index of the p-th percentile in a sorted
+// slice of n, using the nearest-rank method
+func Index(n, p int) int {
+	i := (n*p+99)/100 - 1
+	if i < 0 {
+		return 0
+	}
+	return i
+}
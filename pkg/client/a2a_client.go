@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
 	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
 	"github.com/sage-x-project/sage/pkg/agent/did"
@@ -34,6 +36,7 @@ type A2AClient struct {
 	keyPair    crypto.KeyPair
 	signer     signer.A2ASigner
 	httpClient *http.Client
+	requestID  uint64 // last JSON-RPC request ID, see Call
 }
 
 // NewA2AClient creates a new A2A client with automatic DID signing
@@ -51,6 +54,31 @@ func NewA2AClient(agentDID did.AgentDID, keyPair crypto.KeyPair, httpClient *htt
 	}
 }
 
+// NewA2AClientFromIdentity creates a new A2A client signing as id
+func NewA2AClientFromIdentity(id *identity.Identity, httpClient *http.Client) *A2AClient {
+	return NewA2AClient(id.DID, id.KeyPair, httpClient)
+}
+
+// NewA2AClientForIdentity creates a new A2A client signing as the identity
+// stored under name. It fails with cryptoinit.ErrNotInitialized if the sage
+// crypto constructors are not registered.
+func NewA2AClientForIdentity(store *identity.Store, name string, httpClient *http.Client) (*A2AClient, error) {
+	if err := cryptoinit.Check(); err != nil {
+		return nil, err
+	}
+	id, err := store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewA2AClientFromIdentity(id, httpClient), nil
+}
+
+// SetSigner replaces the default RFC 9421 signer, e.g. with a mock in
+// unit tests
+func (c *A2AClient) SetSigner(s signer.A2ASigner) {
+	c.signer = s
+}
+
 // Do executes an HTTP request with automatic DID signature
 func (c *A2AClient) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	// Check context first
@@ -30,7 +30,9 @@ import (
 
 	stdcrypto "crypto"
 
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -83,6 +85,26 @@ func TestNewA2AClient(t *testing.T) {
 	assert.NotNil(t, client.httpClient)
 }
 
+// Test client constructors report missing crypto registration
+func TestNewA2AClient_NotInitialized(t *testing.T) {
+	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keyPair := &mockKeyPair{pubKey: &privKey.PublicKey, privKey: privKey}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unsigned request sent")
+	}))
+	defer server.Close()
+
+	crypto.SetStorageConstructors(nil)
+	defer crypto.SetStorageConstructors(func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() })
+
+	_, err := NewA2AClientForIdentity(nil, "agent", nil)
+	assert.ErrorIs(t, err, cryptoinit.ErrNotInitialized)
+
+	client := NewA2AClient("did:sage:ethereum:0xtest", keyPair, nil)
+	_, err = client.Get(context.Background(), server.URL)
+	assert.ErrorIs(t, err, cryptoinit.ErrNotInitialized)
+}
+
 // Test NewA2AClient with custom HTTP client
 func TestNewA2AClientWithCustomHTTPClient(t *testing.T) {
 	testDID := did.AgentDID("did:sage:ethereum:0xtest")
@@ -0,0 +1,87 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// FetchArtifactFile returns the content of an artifact file part, downloading
+// it with a signed GET when it is referenced by URI. The content is checked
+// against the digest the producing agent recorded on the part and
+// protocol.ErrArtifactDigestMismatch is returned when it does not match.
+// Parts without a recorded digest fail with protocol.ErrArtifactDigestMissing.
+func (c *A2AClient) FetchArtifactFile(ctx context.Context, part a2a.Part) ([]byte, error) {
+	var fp *a2a.FilePart
+	switch p := part.(type) {
+	case *a2a.FilePart:
+		fp = p
+	case a2a.FilePart:
+		fp = &p
+	default:
+		return nil, fmt.Errorf("not a file part: %T", part)
+	}
+
+	var content []byte
+	switch f := fp.File.(type) {
+	case a2a.FileBytes:
+		decoded, err := base64.StdEncoding.DecodeString(f.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode file bytes: %w", err)
+		}
+		content = decoded
+	case a2a.FileURI:
+		downloaded, err := c.download(ctx, f.URI)
+		if err != nil {
+			return nil, err
+		}
+		content = downloaded
+	default:
+		return nil, fmt.Errorf("unsupported file content: %T", fp.File)
+	}
+
+	if err := protocol.VerifyFileDigest(fp, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// download fetches url with a signed GET
+func (c *A2AClient) download(ctx context.Context, url string) ([]byte, error) {
+	resp, err := c.Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download artifact: HTTP %d", resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestA2AClient_FetchArtifactFile(t *testing.T) {
+	content := []byte("artifact content")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Signature"))
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	client := NewA2AClient("did:sage:ethereum:0xabc", &mockKeyPair{pubKey: &privKey.PublicKey, privKey: privKey}, nil)
+
+	part := &a2a.FilePart{File: a2a.FileURI{URI: server.URL + "/files/1"}}
+	require.NoError(t, protocol.SetFileDigest(part, content, ""))
+
+	got, err := client.FetchArtifactFile(context.Background(), part)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	// Content changed after the digest was recorded
+	require.NoError(t, protocol.SetFileDigest(part, []byte("original"), ""))
+	_, err = client.FetchArtifactFile(context.Background(), part)
+	assert.ErrorIs(t, err, protocol.ErrArtifactDigestMismatch)
+
+	_, err = client.FetchArtifactFile(context.Background(), a2a.TextPart{Text: "x"})
+	assert.Error(t, err)
+}
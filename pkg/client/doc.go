@@ -33,6 +33,12 @@
 //
 // # Basic Usage
 //
+// The sage crypto constructors (crypto.GenerateSecp256k1KeyPair, ...) are
+// registered by importing this package, through
+// github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit. If a custom
+// registration removes one, NewA2AClientForIdentity and signing fail with
+// cryptoinit.ErrNotInitialized.
+//
 //	// Create A2A client with DID and key pair
 //	agentDID := did.AgentDID("did:sage:ethereum:0x...")
 //	keyPair, _ := crypto.GenerateSecp256k1KeyPair()
@@ -79,6 +85,27 @@
 // from the server package, which resolves the public key from the DID and
 // validates the signature.
 //
+// # Endpoint Routing
+//
+// Call sends a JSON-RPC request to the endpoint of a protocol.AgentCard
+// serving the method (see AgentCard.EndpointFor), so agents splitting
+// streaming or task traffic across endpoints are reached correctly.
+// GetArtifact fetches paths relative to the card's artifacts endpoint:
+//
+//	resp, err := client.Call(ctx, card, "message/stream", params)
+//	resp, err = client.GetArtifact(ctx, card, "reports/1.pdf")
+//
+// # Artifact Files
+//
+// FetchArtifactFile returns the content of an artifact file part, fetching
+// URI files with a signed GET, and checks it against the digest recorded by
+// the producing agent:
+//
+//	content, err := client.FetchArtifactFile(ctx, part)
+//	if errors.Is(err, protocol.ErrArtifactDigestMismatch) {
+//	    // The file was modified after the artifact was emitted
+//	}
+//
 // # Error Handling
 //
 //	resp, err := client.Post(ctx, url, body)
@@ -0,0 +1,80 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// rpcRequest is a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+	ID      uint64 `json:"id"`
+}
+
+// Call sends a signed JSON-RPC request for method to the endpoint of card
+// serving it (see protocol.AgentCard.EndpointForMethod): streaming methods
+// go to an sse endpoint and accept an event stream, others to an rpc
+// endpoint. The caller must close the response body.
+func (c *A2AClient) Call(ctx context.Context, card *protocol.AgentCard, method string, params any) (*http.Response, error) {
+	url := card.EndpointForMethod(method)
+	if url == "" {
+		return nil, fmt.Errorf("agent card has no endpoint for %s", method)
+	}
+
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      atomic.AddUint64(&c.requestID, 1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JSON-RPC request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if protocol.EndpointKindForMethod(method) == protocol.EndpointSSE {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+	return c.Do(ctx, req)
+}
+
+// GetArtifact downloads path, relative to the artifacts endpoint of card
+// (or its base Endpoint if it declares none), with a signed GET. The
+// caller must close the response body.
+func (c *A2AClient) GetArtifact(ctx context.Context, card *protocol.AgentCard, path string) (*http.Response, error) {
+	base := card.EndpointFor(protocol.EndpointArtifacts, "")
+	if base == "" {
+		return nil, fmt.Errorf("agent card has no artifacts endpoint")
+	}
+	return c.Get(ctx, strings.TrimSuffix(base, "/")+"/"+strings.TrimPrefix(path, "/"))
+}
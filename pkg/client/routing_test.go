@@ -0,0 +1,72 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestA2AClient_CallRoutesByEndpoint(t *testing.T) {
+	var hits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Signature"))
+		var req rpcRequest
+		if r.Method == "POST" {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		}
+		hits = append(hits, r.URL.Path+" "+req.Method+" "+r.Header.Get("Accept"))
+	}))
+	defer server.Close()
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	client := NewA2AClient("did:sage:ethereum:0xabc", &mockKeyPair{pubKey: &privKey.PublicKey, privKey: privKey}, nil)
+
+	card := protocol.NewAgentCardBuilder("did:sage:ethereum:0xdef", "Agent", server.URL+"/base").
+		WithEndpoint(protocol.EndpointRPC, server.URL+"/rpc").
+		WithEndpoint(protocol.EndpointSSE, server.URL+"/stream").
+		WithEndpoint(protocol.EndpointArtifacts, server.URL+"/files/").
+		Build()
+
+	ctx := context.Background()
+	for _, method := range []string{"message/send", "message/stream"} {
+		resp, err := client.Call(ctx, card, method, map[string]any{})
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	resp, err := client.GetArtifact(ctx, card, "/a/1")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{
+		"/rpc message/send ",
+		"/stream message/stream text/event-stream",
+		"/files/a/1  ",
+	}, hits)
+}
@@ -0,0 +1,515 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrTooLarge is returned when an encoded stream event exceeds the size
+// limit of its reader
+var ErrTooLarge = errors.New("encoded event too large")
+
+// maxDepth bounds the nesting of arrays and maps, so hostile payloads
+// cannot exhaust the stack
+const maxDepth = 512
+
+// CBOR major types
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// infoIndefinite marks indefinite-length strings, arrays and maps, and
+// the break that ends them
+const infoIndefinite = 31
+
+type cborCodec struct{}
+
+func (cborCodec) ContentType() string       { return ContentTypeCBOR }
+func (cborCodec) StreamContentType() string { return ContentTypeCBORSeq }
+
+// FromJSON converts a JSON document to CBOR. Object members keep their
+// order, integers become CBOR integers and other numbers the shortest
+// float that represents them exactly.
+func (cborCodec) FromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := parseJSON(dec, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: trailing data")
+	}
+	var buf bytes.Buffer
+	if err := encodeCBOR(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToJSON converts a CBOR data item to JSON. Byte strings become base64
+// strings, tags are dropped and undefined becomes null; map keys must be
+// text strings.
+func (cborCodec) ToJSON(data []byte) ([]byte, error) {
+	d := &cborDecoder{r: bufio.NewReader(bytes.NewReader(data))}
+	var out bytes.Buffer
+	if err := d.value(&out, 0); err != nil {
+		return nil, fmt.Errorf("invalid CBOR: %w", err)
+	}
+	if _, err := d.r.ReadByte(); err != io.EOF {
+		return nil, fmt.Errorf("invalid CBOR: trailing data")
+	}
+	return out.Bytes(), nil
+}
+
+// NewStreamReader reads a CBOR sequence, one data item per event
+func (cborCodec) NewStreamReader(r io.Reader, maxEventSize int64) StreamReader {
+	return &cborSeqReader{r: bufio.NewReader(r), max: maxEventSize}
+}
+
+type cborSeqReader struct {
+	r   *bufio.Reader
+	max int64
+}
+
+func (s *cborSeqReader) Next() ([]byte, error) {
+	if _, err := s.r.Peek(1); err != nil {
+		return nil, err
+	}
+	d := &cborDecoder{r: s.r, max: s.max}
+	var out bytes.Buffer
+	if err := d.value(&out, 0); err != nil {
+		if errors.Is(err, ErrTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid CBOR: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// member is an object member, kept in document order
+type member struct {
+	name  string
+	value any
+}
+
+// parseJSON reads one JSON value as nil, bool, json.Number, string, []any
+// or []member
+func parseJSON(dec *json.Decoder, depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("nesting deeper than %d", maxDepth)
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			v, err := parseJSON(dec, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		_, err := dec.Token()
+		return arr, err
+	case json.Delim('{'):
+		obj := []member{}
+		for dec.More() {
+			name, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := parseJSON(dec, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, member{name: name.(string), value: v})
+		}
+		_, err := dec.Token()
+		return obj, err
+	}
+	return tok, nil
+}
+
+func encodeCBOR(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(majorSimple<<5 | 22)
+	case bool:
+		if v {
+			buf.WriteByte(majorSimple<<5 | 21)
+		} else {
+			buf.WriteByte(majorSimple<<5 | 20)
+		}
+	case string:
+		writeHead(buf, majorText, uint64(len(v)))
+		buf.WriteString(v)
+	case json.Number:
+		return encodeNumber(buf, v.String())
+	case []any:
+		writeHead(buf, majorArray, uint64(len(v)))
+		for _, elem := range v {
+			if err := encodeCBOR(buf, elem); err != nil {
+				return err
+			}
+		}
+	case []member:
+		writeHead(buf, majorMap, uint64(len(v)))
+		for _, m := range v {
+			writeHead(buf, majorText, uint64(len(m.name)))
+			buf.WriteString(m.name)
+			if err := encodeCBOR(buf, m.value); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected JSON value %T", value)
+	}
+	return nil
+}
+
+func encodeNumber(buf *bytes.Buffer, s string) error {
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			if i >= 0 {
+				writeHead(buf, majorUint, uint64(i))
+			} else {
+				writeHead(buf, majorNegInt, uint64(-1-i))
+			}
+			return nil
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			writeHead(buf, majorUint, u)
+			return nil
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("number %s cannot be encoded: %w", s, err)
+	}
+	if float64(float32(f)) == f {
+		buf.WriteByte(majorSimple<<5 | 26)
+		buf.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(float32(f))))
+		return nil
+	}
+	buf.WriteByte(majorSimple<<5 | 27)
+	buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(f)))
+	return nil
+}
+
+// writeHead writes the initial bytes of a data item of major type major
+// with argument n
+func writeHead(buf *bytes.Buffer, major byte, n uint64) {
+	switch {
+	case n < 24:
+		buf.WriteByte(major<<5 | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(major<<5 | 25)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	case n <= math.MaxUint32:
+		buf.WriteByte(major<<5 | 26)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	default:
+		buf.WriteByte(major<<5 | 27)
+		buf.Write(binary.BigEndian.AppendUint64(nil, n))
+	}
+}
+
+// cborDecoder converts CBOR data items to JSON, reading at most max bytes
+// per item when max is positive
+type cborDecoder struct {
+	r    *bufio.Reader
+	max  int64
+	read int64
+}
+
+func (d *cborDecoder) count(n uint64) error {
+	d.read += int64(min(n, math.MaxInt64/2))
+	if d.max > 0 && d.read > d.max {
+		return fmt.Errorf("%w: exceeds %d bytes", ErrTooLarge, d.max)
+	}
+	return nil
+}
+
+func (d *cborDecoder) readByte() (byte, error) {
+	if err := d.count(1); err != nil {
+		return 0, err
+	}
+	b, err := d.r.ReadByte()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return b, err
+}
+
+// readHead reads the initial bytes of a data item
+func (d *cborDecoder) readHead() (major, info byte, arg uint64, err error) {
+	b, err := d.readByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info = b>>5, b&0x1f
+	var size int
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		size = 1 << (info - 24)
+	case info == infoIndefinite:
+		return major, info, 0, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("reserved additional information %d", info)
+	}
+	for i := 0; i < size; i++ {
+		b, err := d.readByte()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		arg = arg<<8 | uint64(b)
+	}
+	return major, info, arg, nil
+}
+
+// readString reads the content of a byte or text string whose head was
+// already read
+func (d *cborDecoder) readString(major, info byte, arg uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	if info != infoIndefinite {
+		if err := d.count(arg); err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(&buf, d.r, int64(arg)); err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return buf.Bytes(), nil
+	}
+	for {
+		chunkMajor, chunkInfo, chunkArg, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor == majorSimple && chunkInfo == infoIndefinite {
+			return buf.Bytes(), nil
+		}
+		if chunkMajor != major || chunkInfo == infoIndefinite {
+			return nil, fmt.Errorf("invalid chunk in indefinite-length string")
+		}
+		chunk, err := d.readString(chunkMajor, chunkInfo, chunkArg)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(chunk)
+	}
+}
+
+// items calls each for the elements of an array or map with the given
+// head; each is called once per pair for maps
+func (d *cborDecoder) items(info byte, arg uint64, each func(first bool) error) error {
+	if info != infoIndefinite {
+		for i := uint64(0); i < arg; i++ {
+			if err := each(i == 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for first := true; ; first = false {
+		b, err := d.r.Peek(1)
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		if b[0] == majorSimple<<5|infoIndefinite {
+			_, err := d.readByte()
+			return err
+		}
+		if err := each(first); err != nil {
+			return err
+		}
+	}
+}
+
+// value converts one data item to JSON
+func (d *cborDecoder) value(out *bytes.Buffer, depth int) error {
+	if depth > maxDepth {
+		return fmt.Errorf("nesting deeper than %d", maxDepth)
+	}
+	major, info, arg, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	if info == infoIndefinite && (major < majorBytes || major == majorTag) {
+		return fmt.Errorf("indefinite length for major type %d", major)
+	}
+
+	switch major {
+	case majorUint:
+		out.WriteString(strconv.FormatUint(arg, 10))
+	case majorNegInt:
+		if arg == math.MaxUint64 {
+			out.WriteString("-18446744073709551616")
+		} else {
+			out.WriteString("-" + strconv.FormatUint(arg+1, 10))
+		}
+	case majorBytes:
+		data, err := d.readString(major, info, arg)
+		if err != nil {
+			return err
+		}
+		return writeJSON(out, base64.StdEncoding.EncodeToString(data))
+	case majorText:
+		data, err := d.readString(major, info, arg)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(data) {
+			return fmt.Errorf("text string is not valid UTF-8")
+		}
+		return writeJSON(out, string(data))
+	case majorArray:
+		out.WriteByte('[')
+		err := d.items(info, arg, func(first bool) error {
+			if !first {
+				out.WriteByte(',')
+			}
+			return d.value(out, depth+1)
+		})
+		if err != nil {
+			return err
+		}
+		out.WriteByte(']')
+	case majorMap:
+		out.WriteByte('{')
+		err := d.items(info, arg, func(first bool) error {
+			if !first {
+				out.WriteByte(',')
+			}
+			keyMajor, keyInfo, keyArg, err := d.readHead()
+			if err != nil {
+				return err
+			}
+			if keyMajor != majorText {
+				return fmt.Errorf("map key of major type %d is not a text string", keyMajor)
+			}
+			key, err := d.readString(keyMajor, keyInfo, keyArg)
+			if err != nil {
+				return err
+			}
+			if err := writeJSON(out, string(key)); err != nil {
+				return err
+			}
+			out.WriteByte(':')
+			return d.value(out, depth+1)
+		})
+		if err != nil {
+			return err
+		}
+		out.WriteByte('}')
+	case majorTag:
+		return d.value(out, depth+1)
+	default:
+		return d.simple(out, info, arg)
+	}
+	return nil
+}
+
+// simple converts a simple value or float to JSON
+func (d *cborDecoder) simple(out *bytes.Buffer, info byte, arg uint64) error {
+	var f float64
+	switch info {
+	case 20:
+		out.WriteString("false")
+		return nil
+	case 21:
+		out.WriteString("true")
+		return nil
+	case 22, 23:
+		out.WriteString("null")
+		return nil
+	case 25:
+		f = halfToFloat(uint16(arg))
+	case 26:
+		f = float64(math.Float32frombits(uint32(arg)))
+	case 27:
+		f = math.Float64frombits(arg)
+	case infoIndefinite:
+		return fmt.Errorf("unexpected break")
+	default:
+		return fmt.Errorf("unsupported simple value %d", arg)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("number %v cannot be represented in JSON", f)
+	}
+	return writeJSON(out, f)
+}
+
+// halfToFloat converts an IEEE 754 half-precision float
+func halfToFloat(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// writeJSON writes v as encoding/json does
+func writeJSON(out *bytes.Buffer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	out.Write(data)
+	return nil
+}
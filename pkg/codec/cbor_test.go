@@ -0,0 +1,181 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBORFromJSON(t *testing.T) {
+	// Examples from RFC 8949 Appendix A
+	tests := []struct {
+		json string
+		cbor string
+	}{
+		{`0`, "00"},
+		{`23`, "17"},
+		{`24`, "1818"},
+		{`1000000`, "1a000f4240"},
+		{`18446744073709551615`, "1bffffffffffffffff"},
+		{`-1`, "20"},
+		{`-1000`, "3903e7"},
+		{`1.5`, "fa3fc00000"},
+		{`1.1`, "fb3ff199999999999a"},
+		{`false`, "f4"},
+		{`true`, "f5"},
+		{`null`, "f6"},
+		{`""`, "60"},
+		{`"IETF"`, "6449455446"},
+		{`"ü"`, "62c3bc"},
+		{`[]`, "80"},
+		{`[1,[2,3],[4,5]]`, "8301820203820405"},
+		{`{}`, "a0"},
+		{`{"a":1,"b":[2,3]}`, "a26161016162820203"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			got, err := CBOR.FromJSON([]byte(tt.json))
+			require.NoError(t, err)
+			assert.Equal(t, tt.cbor, hex.EncodeToString(got))
+
+			back, err := CBOR.ToJSON(got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.json, string(back))
+		})
+	}
+
+	_, err := CBOR.FromJSON([]byte(`{"a":`))
+	assert.Error(t, err)
+	_, err = CBOR.FromJSON([]byte(`1 2`))
+	assert.Error(t, err)
+}
+
+func TestCBORToJSON(t *testing.T) {
+	tests := []struct {
+		cbor string
+		json string
+	}{
+		{"f93c00", `1`},  // half float
+		{"f9c400", `-4`}, // half float
+		{"3bffffffffffffffff", `-18446744073709551616`}, // smallest negative integer
+		{"4401020304", `"AQIDBA=="`},                    // byte string
+		{"c11a514b67b0", `1363896240`},                  // tag dropped
+		{"f7", `null`},                                  // undefined
+		{"7f657374726561646d696e67ff", `"streaming"`},   // indefinite text
+		{"9f018202039f0405ffff", `[1,[2,3],[4,5]]`},     // indefinite arrays
+		{"bf61610161629f0203ffff", `{"a":1,"b":[2,3]}`}, // indefinite map
+		{"a1613c613e", `{"\u003c":"\u003e"}`},           // escaped like encoding/json
+	}
+	for _, tt := range tests {
+		t.Run(tt.cbor, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.cbor)
+			require.NoError(t, err)
+			got, err := CBOR.ToJSON(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.json, string(got))
+		})
+	}
+
+	for _, invalid := range []string{
+		"",               // empty
+		"1c",             // reserved additional information
+		"62c3",           // truncated string
+		"a10102",         // integer map key
+		"f97e00",         // NaN
+		"ff",             // lone break
+		"0001",           // trailing data
+		"62c328",         // invalid UTF-8
+		"5f4101610262ff", // text chunk in byte string
+	} {
+		data, err := hex.DecodeString(invalid)
+		require.NoError(t, err)
+		_, err = CBOR.ToJSON(data)
+		assert.Error(t, err, invalid)
+	}
+
+	// Deep nesting is rejected
+	_, err := CBOR.ToJSON(bytes.Repeat([]byte{0x81}, maxDepth+2))
+	assert.Error(t, err)
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	doc := `{"jsonrpc":"2.0","id":7,"method":"message/send","params":{"message":{"kind":"message","messageId":"m1","parts":[{"kind":"text","text":"a \u003c b \u0026 c"}],"role":"user","metadata":{"priority":2,"ratio":0.25,"big":1e+21,"small":1e-7}}}}`
+	encoded, err := CBOR.FromJSON([]byte(doc))
+	require.NoError(t, err)
+	assert.Less(t, len(encoded), len(doc))
+
+	back, err := CBOR.ToJSON(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, doc, string(back))
+
+	var v struct {
+		ID     int `json:"id"`
+		Params struct {
+			Message struct {
+				Metadata map[string]float64 `json:"metadata"`
+			} `json:"message"`
+		} `json:"params"`
+	}
+	require.NoError(t, Unmarshal(CBOR, encoded, &v))
+	assert.Equal(t, 7, v.ID)
+	assert.Equal(t, 0.25, v.Params.Message.Metadata["ratio"])
+
+	marshaled, err := Marshal(CBOR, v)
+	require.NoError(t, err)
+	var again struct {
+		ID int `json:"id"`
+	}
+	require.NoError(t, Unmarshal(CBOR, marshaled, &again))
+	assert.Equal(t, 7, again.ID)
+}
+
+func TestCBORStreamReader(t *testing.T) {
+	var stream bytes.Buffer
+	for _, event := range []string{`{"id":1,"result":{"kind":"task"}}`, `{"id":1,"result":{"final":true}}`} {
+		data, err := CBOR.FromJSON([]byte(event))
+		require.NoError(t, err)
+		stream.Write(data)
+	}
+	data := stream.Bytes()
+
+	r := CBOR.NewStreamReader(bytes.NewReader(data), 0)
+	first, err := r.Next()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"result":{"kind":"task"}}`, string(first))
+	second, err := r.Next()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"result":{"final":true}}`, string(second))
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+
+	// Events over the limit
+	_, err = CBOR.NewStreamReader(bytes.NewReader(data), 8).Next()
+	assert.True(t, errors.Is(err, ErrTooLarge))
+
+	// Truncated events
+	_, err = CBOR.NewStreamReader(bytes.NewReader(data[:5]), 0).Next()
+	assert.Error(t, err)
+	assert.NotEqual(t, io.EOF, err)
+}
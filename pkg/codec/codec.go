@@ -0,0 +1,205 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// ContentTypeJSON is the media type of JSON payloads
+	ContentTypeJSON = "application/json"
+
+	// ContentTypeCBOR is the media type of CBOR payloads (RFC 8949)
+	ContentTypeCBOR = "application/cbor"
+
+	// ContentTypeCBORSeq is the media type of CBOR sequences (RFC 8742),
+	// used for event streams in place of text/event-stream
+	ContentTypeCBORSeq = "application/cbor-seq"
+)
+
+// Codec is a payload serialization. Payloads are converted from and to
+// JSON, so the typed JSON APIs of a2a-go and of handlers keep working
+// whatever travels on the wire.
+type Codec interface {
+	// ContentType returns the media type of encoded payloads
+	ContentType() string
+
+	// FromJSON encodes a JSON document
+	FromJSON(data []byte) ([]byte, error)
+
+	// ToJSON decodes a payload into a JSON document
+	ToJSON(data []byte) ([]byte, error)
+}
+
+// StreamCodec is a Codec that also encodes event streams, as a sequence of
+// encoded JSON-RPC responses
+type StreamCodec interface {
+	Codec
+
+	// StreamContentType returns the media type of encoded streams
+	StreamContentType() string
+
+	// NewStreamReader returns a reader of the events of an encoded stream,
+	// each decoded to JSON. A positive maxEventSize bounds one event.
+	NewStreamReader(r io.Reader, maxEventSize int64) StreamReader
+}
+
+// StreamReader reads the events of an encoded stream
+type StreamReader interface {
+	// Next returns the next event as JSON, or io.EOF at the end of the
+	// stream
+	Next() ([]byte, error)
+}
+
+var (
+	// JSON is the identity codec
+	JSON Codec = jsonCodec{}
+
+	// CBOR encodes payloads as CBOR and streams as CBOR sequences
+	CBOR StreamCodec = cborCodec{}
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Codec{
+		ContentTypeJSON: JSON,
+		ContentTypeCBOR: CBOR,
+	}
+)
+
+// Register makes c available to ForContentType and Negotiate, replacing
+// any codec registered for the same media type
+func Register(c Codec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(c.ContentType())] = c
+}
+
+// ForContentType returns the codec for a Content-Type header value.
+// Parameters such as charset are ignored; an empty value means JSON.
+func ForContentType(contentType string) (Codec, bool) {
+	if strings.TrimSpace(contentType) == "" {
+		return JSON, true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	c, ok := registry[mediaType]
+	return c, ok
+}
+
+// IsJSON reports whether c is nil or the JSON codec
+func IsJSON(c Codec) bool {
+	return c == nil || c.ContentType() == ContentTypeJSON
+}
+
+// Negotiate picks the registered codec preferred by an Accept header
+// value. It returns nil when no registered media type is acceptable,
+// leaving the response as is. When several share the highest weight, JSON
+// wins.
+func Negotiate(accept string) Codec {
+	var best Codec
+	bestQ := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		c, ok := ForContentType(mediaType)
+		if !ok || q <= 0 || mediaType == "" {
+			continue
+		}
+		if q > bestQ || (q == bestQ && IsJSON(c)) {
+			best, bestQ = c, q
+		}
+	}
+	return best
+}
+
+// NegotiateStream picks the registered stream codec accepted by an
+// Accept header value, or nil to stream text/event-stream
+func NegotiateStream(accept string) StreamCodec {
+	for _, part := range strings.Split(accept, ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		if sc, ok := ForStreamContentType(part); ok {
+			return sc
+		}
+	}
+	return nil
+}
+
+// ForStreamContentType returns the stream codec for a Content-Type header
+// value
+func ForStreamContentType(contentType string) (StreamCodec, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, c := range registry {
+		if sc, ok := c.(StreamCodec); ok && sc.StreamContentType() == mediaType {
+			return sc, true
+		}
+	}
+	return nil, false
+}
+
+// Marshal encodes v as JSON, then with c
+func Marshal(c Codec, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || IsJSON(c) {
+		return data, err
+	}
+	return c.FromJSON(data)
+}
+
+// Unmarshal decodes data with c, then as JSON into v
+func Unmarshal(c Codec, data []byte, v any) error {
+	if !IsJSON(c) {
+		var err error
+		if data, err = c.ToJSON(data); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string                  { return ContentTypeJSON }
+func (jsonCodec) FromJSON(data []byte) ([]byte, error) { return data, nil }
+func (jsonCodec) ToJSON(data []byte) ([]byte, error)   { return data, nil }
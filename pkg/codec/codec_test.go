@@ -0,0 +1,73 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForContentType(t *testing.T) {
+	c, ok := ForContentType("application/cbor")
+	assert.True(t, ok)
+	assert.Equal(t, CBOR, c)
+
+	c, ok = ForContentType("application/json; charset=utf-8")
+	assert.True(t, ok)
+	assert.True(t, IsJSON(c))
+
+	c, ok = ForContentType("")
+	assert.True(t, ok)
+	assert.True(t, IsJSON(c))
+
+	_, ok = ForContentType("application/xml")
+	assert.False(t, ok)
+}
+
+func TestNegotiate(t *testing.T) {
+	assert.Equal(t, CBOR, Negotiate("application/cbor, application/json;q=0.5"))
+	assert.True(t, IsJSON(Negotiate("application/cbor;q=0.5, application/json")))
+	assert.True(t, IsJSON(Negotiate("application/json, application/cbor")))
+	assert.Nil(t, Negotiate("*/*"))
+	assert.Nil(t, Negotiate("application/cbor;q=0"))
+
+	assert.Equal(t, CBOR, NegotiateStream("application/cbor-seq, text/event-stream"))
+	assert.Nil(t, NegotiateStream("text/event-stream"))
+	assert.Nil(t, NegotiateStream("application/cbor-seq;q=0"))
+}
+
+type testCodec struct{ jsonCodec }
+
+func (testCodec) ContentType() string { return "application/x-test" }
+
+func TestRegister(t *testing.T) {
+	Register(testCodec{})
+	c, ok := ForContentType("application/x-test")
+	assert.True(t, ok)
+	assert.Equal(t, "application/x-test", c.ContentType())
+	assert.False(t, IsJSON(c))
+}
+
+func TestMarshalJSON(t *testing.T) {
+	data, err := Marshal(JSON, map[string]int{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(data))
+	assert.True(t, json.Valid(data))
+}
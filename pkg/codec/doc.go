@@ -0,0 +1,45 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package codec provides pluggable payload serializations for JSON-RPC
+// requests, responses and event streams, shared by the transport and
+// server packages.
+//
+// A Codec converts payloads from and to JSON, so typed APIs and handlers
+// keep working with JSON while a more compact encoding travels on the
+// wire. CBOR (RFC 8949) is built in; streams encoded with it are CBOR
+// sequences (RFC 8742, application/cbor-seq) of JSON-RPC responses rather
+// than text/event-stream. Other codecs can be added with Register.
+//
+// # Negotiation
+//
+// Clients send requests with the codec's Content-Type and list it in
+// Accept; servers answer with the codec the client prefers (Negotiate,
+// NegotiateStream) and JSON otherwise:
+//
+//	Content-Type: application/cbor
+//	Accept: application/cbor, application/json;q=0.5
+//
+// # Signatures
+//
+// Encoding is applied before compression and signing, so the
+// Content-Digest covers the encoded bytes that travel on the wire, and
+// Content-Type is covered by the signature so the encoding cannot be
+// swapped in transit:
+//
+//	body (JSON) → encode → compress → Content-Digest → sign
+package codec
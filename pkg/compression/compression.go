@@ -0,0 +1,195 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package compression
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EncodingGzip is the gzip content coding (RFC 9110 §8.4.1.3)
+	EncodingGzip = "gzip"
+
+	// EncodingDeflate is the deflate content coding (RFC 9110 §8.4.1.2)
+	EncodingDeflate = "deflate"
+
+	// EncodingIdentity means no transformation
+	EncodingIdentity = "identity"
+
+	// DefaultMinSize is the default threshold below which payloads are sent uncompressed
+	DefaultMinSize = 1024
+)
+
+// Config controls when and how payloads are compressed
+type Config struct {
+	// Encoding is the content coding used for outgoing payloads ("gzip" or "deflate").
+	// Defaults to gzip if empty.
+	Encoding string
+
+	// MinSize is the minimum payload size in bytes that will be compressed.
+	// Smaller payloads are sent as-is, since compression overhead outweighs the gain.
+	MinSize int
+
+	// Disabled turns off compression of outgoing payloads entirely.
+	// Incoming compressed payloads are still decoded.
+	Disabled bool
+}
+
+// DefaultConfig returns a Config using gzip with DefaultMinSize
+func DefaultConfig() *Config {
+	return &Config{
+		Encoding: EncodingGzip,
+		MinSize:  DefaultMinSize,
+	}
+}
+
+// ShouldCompress reports whether a payload of the given size should be compressed
+func (c *Config) ShouldCompress(size int) bool {
+	if c == nil || c.Disabled {
+		return false
+	}
+	return size >= c.MinSize
+}
+
+// EncodingName returns the content coding used for outgoing payloads, defaulting to gzip
+func (c *Config) EncodingName() string {
+	if c == nil || c.Encoding == "" {
+		return EncodingGzip
+	}
+	return strings.ToLower(c.Encoding)
+}
+
+// IsSupported reports whether the given content coding can be encoded and decoded
+func IsSupported(encoding string) bool {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case EncodingGzip, EncodingDeflate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Compress encodes data using the given content coding
+func Compress(encoding string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewWriter(encoding, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress payload: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish compression: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decodes data encoded with the given content coding.
+// Empty or "identity" encodings return data unchanged.
+func Decompress(encoding string, data []byte) ([]byte, error) {
+	r, err := NewReader(encoding, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	return out, nil
+}
+
+// NewWriter returns a compressing writer for the given content coding
+func NewWriter(encoding string, w io.Writer) (io.WriteCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case EncodingGzip:
+		return gzip.NewWriter(w), nil
+	case EncodingDeflate:
+		return flate.NewWriter(w, flate.DefaultCompression)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+}
+
+// NewReader returns a decompressing reader for the given content coding.
+// Empty or "identity" encodings return r unchanged.
+func NewReader(encoding string, r io.Reader) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", EncodingIdentity:
+		return io.NopCloser(r), nil
+	case EncodingGzip:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		return gr, nil
+	case EncodingDeflate:
+		return flate.NewReader(r), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+}
+
+// Negotiate picks the best supported content coding from an Accept-Encoding
+// header value. It returns an empty string when no supported coding is acceptable.
+// When several codings share the highest weight, preferred wins.
+func Negotiate(acceptEncoding, preferred string) string {
+	best := ""
+	bestQ := 0.0
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name := part
+		q := 1.0
+		if idx := strings.IndexByte(part, ';'); idx != -1 {
+			name = strings.TrimSpace(part[:idx])
+			param := strings.TrimSpace(part[idx+1:])
+			if v, ok := strings.CutPrefix(param, "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		name = strings.ToLower(name)
+
+		if name == "*" {
+			name = strings.ToLower(preferred)
+		}
+		if !IsSupported(name) || q <= 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && name == strings.ToLower(preferred)) {
+			best = name
+			bestQ = q
+		}
+	}
+
+	return best
+}
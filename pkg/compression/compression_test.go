@@ -0,0 +1,103 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompress_RoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"jsonrpc":"2.0","method":"message/send"}`), 50)
+
+	for _, encoding := range []string{EncodingGzip, EncodingDeflate} {
+		t.Run(encoding, func(t *testing.T) {
+			compressed, err := Compress(encoding, data)
+			require.NoError(t, err)
+			assert.Less(t, len(compressed), len(data))
+
+			decompressed, err := Decompress(encoding, compressed)
+			require.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+		})
+	}
+}
+
+func TestCompress_Unsupported(t *testing.T) {
+	_, err := Compress("br", []byte("data"))
+	assert.Error(t, err)
+
+	_, err = Decompress("br", []byte("data"))
+	assert.Error(t, err)
+}
+
+func TestDecompress_Identity(t *testing.T) {
+	out, err := Decompress("", []byte("plain"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain"), out)
+
+	out, err = Decompress(EncodingIdentity, []byte("plain"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain"), out)
+}
+
+func TestConfig_ShouldCompress(t *testing.T) {
+	var nilConfig *Config
+	assert.False(t, nilConfig.ShouldCompress(1<<20))
+
+	cfg := DefaultConfig()
+	assert.False(t, cfg.ShouldCompress(DefaultMinSize-1))
+	assert.True(t, cfg.ShouldCompress(DefaultMinSize))
+
+	cfg.Disabled = true
+	assert.False(t, cfg.ShouldCompress(1<<20))
+}
+
+func TestConfig_EncodingName(t *testing.T) {
+	var nilConfig *Config
+	assert.Equal(t, EncodingGzip, nilConfig.EncodingName())
+	assert.Equal(t, EncodingGzip, (&Config{}).EncodingName())
+	assert.Equal(t, EncodingDeflate, (&Config{Encoding: "DEFLATE"}).EncodingName())
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		preferred      string
+		want           string
+	}{
+		{"empty", "", EncodingGzip, ""},
+		{"gzip only", "gzip", EncodingGzip, EncodingGzip},
+		{"deflate only", "deflate", EncodingGzip, EncodingDeflate},
+		{"preferred on tie", "deflate, gzip", EncodingGzip, EncodingGzip},
+		{"q-values", "gzip;q=0.5, deflate;q=0.9", EncodingGzip, EncodingDeflate},
+		{"rejected", "gzip;q=0", EncodingGzip, ""},
+		{"wildcard", "*", EncodingDeflate, EncodingDeflate},
+		{"unsupported", "br, zstd", EncodingGzip, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.acceptEncoding, tt.preferred))
+		})
+	}
+}
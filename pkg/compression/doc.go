@@ -0,0 +1,38 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package compression provides gzip/deflate helpers shared by the transport
+// and server packages.
+//
+// # Signatures and Compression
+//
+// Compression is applied before signing. The Content-Digest header therefore
+// covers the compressed bytes that actually travel on the wire, and the
+// Content-Encoding header is added to the covered components so that it
+// cannot be stripped or altered in transit:
+//
+//	body (JSON) → compress → Content-Encoding: gzip → Content-Digest → sign
+//
+// On the receiving side the order is reversed: the signature is verified over
+// the compressed body first, and only then is the body decoded for the handler.
+//
+// # Configuration
+//
+//	cfg := compression.DefaultConfig() // gzip, 1 KiB threshold
+//	cfg.MinSize = 4096                 // leave small payloads alone
+//	cfg.Disabled = true                // or turn it off entirely
+package compression
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package cryptoinit registers the standard SAGE key generators, key storage
+// and key format constructors with the sage crypto package. The sage crypto
+// wrappers (crypto.GenerateSecp256k1KeyPair, crypto.NewJWKImporter, ...)
+// panic until they are registered; importing this package does it once for
+// the whole binary:
+//
+//	import _ "github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+//
+// Init performs the same registration explicitly, and Check reports a
+// missing registration as an error instead of a panic. The signer,
+// identity, transport and client packages import this package, and their
+// constructors return ErrNotInitialized when Check fails, e.g. after a
+// custom registration removed a constructor.
+package cryptoinit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
+)
+
+// ErrNotInitialized is returned by Check when the sage crypto constructors
+// have not been registered
+var ErrNotInitialized = errors.New(`sage crypto not initialized: import _ "github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit" or call cryptoinit.Init()`)
+
+var once sync.Once
+
+func init() {
+	Init()
+}
+
+// Init registers the Ed25519, secp256k1 and P-256 key generators, in-memory
+// key storage and the JWK and PEM formats. It is safe to call more than
+// once; only the first call registers.
+func Init() {
+	once.Do(func() {
+		crypto.SetKeyGenerators(
+			func() (crypto.KeyPair, error) { return keys.GenerateEd25519KeyPair() },
+			func() (crypto.KeyPair, error) { return keys.GenerateSecp256k1KeyPair() },
+			func() (crypto.KeyPair, error) { return keys.GenerateP256KeyPair() },
+		)
+		crypto.SetStorageConstructors(
+			func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() },
+		)
+		crypto.SetFormatConstructors(
+			func() crypto.KeyExporter { return formats.NewJWKExporter() },
+			func() crypto.KeyExporter { return formats.NewPEMExporter() },
+			func() crypto.KeyImporter { return formats.NewJWKImporter() },
+			func() crypto.KeyImporter { return formats.NewPEMImporter() },
+		)
+	})
+}
+
+// Check verifies that every sage crypto constructor is registered, e.g. by
+// a custom registration that bypassed Init, returning ErrNotInitialized
+// naming the first missing one otherwise
+func Check() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w (%v)", ErrNotInitialized, r)
+		}
+	}()
+
+	for _, generate := range []func() (crypto.KeyPair, error){
+		crypto.NewEd25519KeyPair,
+		crypto.NewSecp256k1KeyPair,
+		crypto.NewP256KeyPair,
+	} {
+		if _, err := generate(); err != nil {
+			return fmt.Errorf("failed to generate key pair: %w", err)
+		}
+	}
+	crypto.NewMemoryKeyStorage()
+	crypto.NewJWKExporter()
+	crypto.NewPEMExporter()
+	crypto.NewJWKImporter()
+	crypto.NewPEMImporter()
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package cryptoinit
+
+import (
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	Init() // idempotent
+	require.NoError(t, Check())
+
+	kp, err := crypto.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	jwk, err := crypto.NewJWKExporter().Export(kp, crypto.KeyFormatJWK)
+	require.NoError(t, err)
+	imported, err := crypto.NewJWKImporter().Import(jwk, crypto.KeyFormatJWK)
+	require.NoError(t, err)
+	assert.Equal(t, kp.PublicKey(), imported.PublicKey())
+}
+
+func TestCheck_NotInitialized(t *testing.T) {
+	crypto.SetStorageConstructors(nil)
+	defer crypto.SetStorageConstructors(func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() })
+
+	err := Check()
+	assert.ErrorIs(t, err, ErrNotInitialized)
+	assert.Contains(t, err.Error(), "Memory key storage constructor not initialized")
+}
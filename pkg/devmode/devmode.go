@@ -0,0 +1,140 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package devmode
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Warning is logged when an Environment is created
+const Warning = "WARNING: sage-a2a-go dev mode is INSECURE: identities are ephemeral, DIDs are fabricated and not registered on chain. Never use it in production."
+
+// Environment is a set of dev identities sharing an in-memory registry
+type Environment struct {
+	// Registry holds the identities created by the environment
+	Registry *registry.MemoryRegistry
+
+	logf func(format string, args ...any)
+}
+
+// Option configures an Environment
+type Option func(*Environment)
+
+// WithLogger sets the function warnings and verification failures are
+// logged with (default log.Printf)
+func WithLogger(logf func(format string, args ...any)) Option {
+	return func(e *Environment) {
+		e.logf = logf
+	}
+}
+
+// New creates an empty dev environment and logs Warning
+func New(opts ...Option) *Environment {
+	e := &Environment{
+		Registry: registry.NewMemoryRegistry(),
+		logf:     log.Printf,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.logf("%s", Warning)
+	return e
+}
+
+// NewIdentity generates a secp256k1 key, fabricates a DID and registers the
+// agent under name
+func (e *Environment) NewIdentity(ctx context.Context, name string) (*identity.Identity, error) {
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	agentDID, err := fabricateDID()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.Registry.Register(ctx, registry.Registration{
+		DID:         agentDID,
+		Name:        name,
+		Description: "dev mode identity",
+		Keys:        []crypto.PublicKey{keyPair.PublicKey()},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register identity: %w", err)
+	}
+	return identity.NewIdentity(agentDID, keyPair,
+		identity.WithMetadata("name", name),
+		identity.WithMetadata("devmode", "true"))
+}
+
+// MustIdentity is like NewIdentity, failing t on error
+func (e *Environment) MustIdentity(t testing.TB, name string) *identity.Identity {
+	t.Helper()
+	id, err := e.NewIdentity(context.Background(), name)
+	if err != nil {
+		t.Fatalf("devmode: %v", err)
+	}
+	return id
+}
+
+// Middleware returns a DID middleware verifying requests against the
+// environment's registry. Verification failures are logged.
+func (e *Environment) Middleware() *server.DIDAuthMiddleware {
+	m := server.NewDIDAuthMiddlewareWithVerifier(e.Registry.NewDIDVerifier())
+	m.SetVerificationHook(func(r *http.Request, agentDID did.AgentDID, err error) {
+		if err != nil {
+			e.logf("devmode: rejected %s %s: %v", r.Method, r.URL.Path, err)
+		}
+	})
+	return m
+}
+
+// Transport returns a transport to baseURL signing as id
+func (e *Environment) Transport(baseURL string, id *identity.Identity, opts ...transport.TransportOption) a2aclient.Transport {
+	return transport.NewDIDHTTPTransportFromIdentity(baseURL, id, nil, opts...)
+}
+
+// DevIdentity creates an environment logging to t and one identity in it
+func DevIdentity(t testing.TB) (*identity.Identity, *Environment) {
+	t.Helper()
+	env := New(WithLogger(t.Logf))
+	return env.MustIdentity(t, t.Name()), env
+}
+
+// fabricateDID returns a random Ethereum-style SAGE DID
+func fabricateDID() (did.AgentDID, error) {
+	var addr [20]byte
+	if _, err := rand.Read(addr[:]); err != nil {
+		return "", fmt.Errorf("failed to generate DID: %w", err)
+	}
+	return did.AgentDID("did:sage:ethereum:0x" + hex.EncodeToString(addr[:])), nil
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package devmode
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironment_EndToEnd(t *testing.T) {
+	alice, env := DevIdentity(t)
+	bob := env.MustIdentity(t, "bob")
+	assert.NotEqual(t, alice.DID, bob.DID)
+	assert.True(t, strings.HasPrefix(string(alice.DID), "did:sage:ethereum:0x"))
+	assert.Equal(t, "true", alice.Metadata["devmode"])
+
+	meta, err := env.Registry.GetAgentByDID(context.Background(), string(bob.DID))
+	require.NoError(t, err)
+	assert.Equal(t, "bob", meta.Name)
+
+	var caller string
+	srv := httptest.NewServer(env.Middleware().Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agentDID, _ := server.GetAgentDIDFromContext(r.Context())
+		caller = string(agentDID)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  a2a.Task{ID: "task-1", ContextID: "ctx-1"},
+		})
+	})))
+	defer srv.Close()
+
+	task, err := env.Transport(srv.URL, alice).GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-1"), task.ID)
+	assert.Equal(t, string(alice.DID), caller)
+}
+
+func TestEnvironment_LogsWarningAndRejections(t *testing.T) {
+	var mu sync.Mutex
+	var logs []string
+	env := New(WithLogger(func(format string, args ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, format)
+	}))
+	require.NotEmpty(t, logs)
+	assert.Equal(t, "%s", logs[0])
+
+	rr := httptest.NewRecorder()
+	env.Middleware().Wrap(http.NotFoundHandler()).ServeHTTP(rr, httptest.NewRequest("POST", "/rpc", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Len(t, logs, 2)
+	assert.Contains(t, logs[1], "devmode: rejected")
+}
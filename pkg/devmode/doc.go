@@ -0,0 +1,50 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package devmode bootstraps agents for local development and tests. It
+// generates keys, fabricates DIDs, registers them in an in-memory registry
+// and wires the DID middleware and transport against that registry,
+// replacing the crypto setup every example would otherwise repeat.
+//
+// Dev mode is insecure: identities are ephemeral, DIDs are not registered
+// on any chain and anyone holding the Environment can mint identities. A
+// warning is logged whenever an Environment is created. Never use it in
+// production.
+//
+// # Usage
+//
+//	env := devmode.New()
+//	alice, err := env.NewIdentity(ctx, "alice")
+//	bob, err := env.NewIdentity(ctx, "bob")
+//
+//	// Bob's server accepts requests signed by any identity of env
+//	http.Handle("/", env.Middleware().Wrap(bobHandler))
+//
+//	// Alice calls Bob
+//	t := env.Transport("http://localhost:8080", alice)
+//
+// # Tests
+//
+// DevIdentity creates an environment logging to the test and one identity
+// in it:
+//
+//	func TestAgent(t *testing.T) {
+//	    alice, env := devmode.DevIdentity(t)
+//	    bob := env.MustIdentity(t, "bob")
+//	    ...
+//	}
+package devmode
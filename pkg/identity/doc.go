@@ -0,0 +1,63 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package identity provides the Identity type bundling an agent's DID, key
+// pairs, signed Agent Card and metadata.
+//
+// Client, transport, signer and server constructors accept an Identity so
+// applications pass one value around instead of a DID, key pair and card
+// separately.
+//
+// # Creating an Identity
+//
+//	keyPair, _ := keys.GenerateSecp256k1KeyPair()
+//	id, err := identity.NewIdentity(agentDID, keyPair,
+//	    identity.WithKey(ed25519Key),
+//	    identity.WithMetadata("team", "payments"))
+//
+// # Persisting an Identity
+//
+// Save writes the identity, private keys included, as JSON with 0600
+// permissions; LoadIdentity reads it back:
+//
+//	if err := id.Save("agent.identity.json"); err != nil {
+//	    log.Fatal(err)
+//	}
+//	id, err := identity.LoadIdentity("agent.identity.json")
+//
+// # Identity Store
+//
+// Agents acting under several identities, e.g. one per environment or per
+// counterparty, keep them in a Store. Each identity is encrypted at rest
+// with AES-256-GCM under a key derived from the store passphrase:
+//
+//	store, err := identity.OpenStore("/var/lib/agent/identities", passphrase)
+//	err = store.Put("prod", prodID)
+//	err = store.Put("partner-x", partnerID)
+//	err = store.SetDefault("prod")
+//	err = store.Bind(string(partnerDID), "partner-x")
+//
+//	id, err := store.For(string(counterpartyDID)) // bound or default identity
+//	t, err := transport.NewDIDHTTPTransportForIdentity(store, "prod", url, nil)
+//
+// # Using an Identity
+//
+//	c := client.NewA2AClientFromIdentity(id, nil)
+//	t := transport.NewDIDHTTPTransportFromIdentity(url, id, nil)
+//	err := signer.NewDefaultA2ASigner().SignRequestAs(ctx, req, id)
+//	mux.Handle("/.well-known/agent-card.json", server.NewAgentCardHandler(id))
+package identity
@@ -0,0 +1,215 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Identity bundles everything an agent needs to act as itself: its DID, its
+// key pairs, its signed Agent Card and free-form metadata
+type Identity struct {
+	// DID is the agent's Decentralized Identifier
+	DID did.AgentDID
+
+	// KeyPair is the primary key used to sign requests
+	KeyPair sagecrypto.KeyPair
+
+	// Keys holds every key of the agent, KeyPair first
+	Keys []sagecrypto.KeyPair
+
+	// Card is the agent's signed Agent Card, if any
+	Card *protocol.SignedAgentCard
+
+	// Metadata holds application-defined attributes
+	Metadata map[string]string
+}
+
+// Option configures an Identity
+type Option func(*Identity)
+
+// WithKey adds an additional key pair, e.g. an Ed25519 key next to a
+// secp256k1 primary key or an X25519 key for HPKE
+func WithKey(keyPair sagecrypto.KeyPair) Option {
+	return func(id *Identity) {
+		id.Keys = append(id.Keys, keyPair)
+	}
+}
+
+// WithCard sets the agent's signed Agent Card
+func WithCard(card *protocol.SignedAgentCard) Option {
+	return func(id *Identity) {
+		id.Card = card
+	}
+}
+
+// WithMetadata sets a metadata attribute
+func WithMetadata(key, value string) Option {
+	return func(id *Identity) {
+		if id.Metadata == nil {
+			id.Metadata = make(map[string]string)
+		}
+		id.Metadata[key] = value
+	}
+}
+
+// NewIdentity creates an identity whose primary signing key is keyPair. It
+// fails with cryptoinit.ErrNotInitialized if the sage crypto constructors
+// are not registered.
+func NewIdentity(agentDID did.AgentDID, keyPair sagecrypto.KeyPair, opts ...Option) (*Identity, error) {
+	if err := cryptoinit.Check(); err != nil {
+		return nil, err
+	}
+	id := &Identity{DID: agentDID, KeyPair: keyPair}
+	if keyPair != nil {
+		id.Keys = []sagecrypto.KeyPair{keyPair}
+	}
+	for _, opt := range opts {
+		opt(id)
+	}
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// Validate checks that the identity is usable for signing
+func (id *Identity) Validate() error {
+	if id.DID == "" {
+		return errors.New("identity: DID is required")
+	}
+	if id.KeyPair == nil {
+		return errors.New("identity: key pair is required")
+	}
+	if id.Card != nil && id.Card.Card != nil && id.Card.Card.DID != string(id.DID) {
+		return fmt.Errorf("identity: card DID %s does not match %s", id.Card.Card.DID, id.DID)
+	}
+	return nil
+}
+
+// Key returns the first key pair of the given type
+func (id *Identity) Key(keyType sagecrypto.KeyType) (sagecrypto.KeyPair, bool) {
+	for _, kp := range id.Keys {
+		if kp.Type() == keyType {
+			return kp, true
+		}
+	}
+	return nil, false
+}
+
+// SignCard signs card with the primary key and stores the result as the
+// identity's Agent Card
+func (id *Identity) SignCard(ctx context.Context, signer protocol.AgentCardSigner, card *protocol.AgentCard) (*protocol.SignedAgentCard, error) {
+	if card.DID != string(id.DID) {
+		return nil, fmt.Errorf("identity: card DID %s does not match %s", card.DID, id.DID)
+	}
+	signed, err := signer.SignAgentCard(ctx, card, id.KeyPair)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign agent card: %w", err)
+	}
+	id.Card = signed
+	return signed, nil
+}
+
+// identityFile is the on-disk form of an Identity. Keys are private JWKs.
+type identityFile struct {
+	DID      did.AgentDID              `json:"did"`
+	Keys     []json.RawMessage         `json:"keys"`
+	Card     *protocol.SignedAgentCard `json:"card,omitempty"`
+	Metadata map[string]string         `json:"metadata,omitempty"`
+}
+
+// LoadIdentity reads an identity written by Save
+func LoadIdentity(path string) (*Identity, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity: %w", err)
+	}
+	return ParseIdentity(data)
+}
+
+// ParseIdentity decodes an identity from its JSON file form
+func ParseIdentity(data []byte) (*Identity, error) {
+	var file identityFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse identity: %w", err)
+	}
+	if len(file.Keys) == 0 {
+		return nil, errors.New("identity: no keys")
+	}
+
+	importer := formats.NewJWKImporter()
+	keys := make([]sagecrypto.KeyPair, 0, len(file.Keys))
+	for i, raw := range file.Keys {
+		kp, err := importer.Import(raw, sagecrypto.KeyFormatJWK)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import key %d: %w", i, err)
+		}
+		keys = append(keys, kp)
+	}
+
+	id := &Identity{
+		DID:      file.DID,
+		KeyPair:  keys[0],
+		Keys:     keys,
+		Card:     file.Card,
+		Metadata: file.Metadata,
+	}
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// Marshal encodes the identity, including private keys, in the form read
+// by ParseIdentity
+func (id *Identity) Marshal() ([]byte, error) {
+	exporter := formats.NewJWKExporter()
+	file := identityFile{DID: id.DID, Card: id.Card, Metadata: id.Metadata}
+	for i, kp := range id.Keys {
+		jwk, err := exporter.Export(kp, sagecrypto.KeyFormatJWK)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export key %d: %w", i, err)
+		}
+		file.Keys = append(file.Keys, jwk)
+	}
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// Save writes the identity, including private keys, to path with
+// owner-only permissions
+func (id *Identity) Save(path string) error {
+	data, err := id.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write identity: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package identity
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDID = did.AgentDID("did:sage:ethereum:0x1234567890abcdef")
+
+func newTestIdentity(t *testing.T, opts ...Option) *Identity {
+	primary, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	id, err := NewIdentity(testDID, primary, opts...)
+	require.NoError(t, err)
+	return id
+}
+
+func TestNewIdentity(t *testing.T) {
+	edKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	id := newTestIdentity(t, WithKey(edKey), WithMetadata("team", "payments"))
+	assert.Len(t, id.Keys, 2)
+	assert.Equal(t, id.KeyPair, id.Keys[0])
+	assert.Equal(t, "payments", id.Metadata["team"])
+
+	got, ok := id.Key(sagecrypto.KeyTypeEd25519)
+	require.True(t, ok)
+	assert.Equal(t, edKey, got)
+	_, ok = id.Key(sagecrypto.KeyTypeX25519)
+	assert.False(t, ok)
+}
+
+func TestNewIdentity_Invalid(t *testing.T) {
+	kp, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	_, err = NewIdentity("", kp)
+	assert.Error(t, err)
+
+	_, err = NewIdentity(testDID, nil)
+	assert.Error(t, err)
+
+	other := &protocol.SignedAgentCard{Card: &protocol.AgentCard{DID: "did:sage:ethereum:0xother"}}
+	_, err = NewIdentity(testDID, kp, WithCard(other))
+	assert.ErrorContains(t, err, "does not match")
+}
+
+func TestNewIdentity_NotInitialized(t *testing.T) {
+	kp, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	sagecrypto.SetStorageConstructors(nil)
+	defer sagecrypto.SetStorageConstructors(func() sagecrypto.KeyStorage { return storage.NewMemoryKeyStorage() })
+
+	_, err = NewIdentity(testDID, kp)
+	assert.ErrorIs(t, err, cryptoinit.ErrNotInitialized)
+}
+
+func TestIdentity_SaveLoad(t *testing.T) {
+	edKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	id := newTestIdentity(t, WithKey(edKey), WithMetadata("env", "test"))
+
+	card := protocol.NewAgentCardBuilder(testDID, "agent", "https://agent.example").Build()
+	_, err = id.SignCard(context.Background(), protocol.NewDefaultAgentCardSigner(nil), card)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "identity.json")
+	require.NoError(t, id.Save(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := LoadIdentity(path)
+	require.NoError(t, err)
+	assert.Equal(t, id.DID, loaded.DID)
+	assert.Equal(t, id.Metadata, loaded.Metadata)
+	assert.Equal(t, id.Card.Signature, loaded.Card.Signature)
+	require.Len(t, loaded.Keys, 2)
+	assert.Equal(t, sagecrypto.KeyTypeSecp256k1, loaded.KeyPair.Type())
+	assert.Equal(t, id.KeyPair.PublicKey(), loaded.KeyPair.PublicKey())
+	assert.Equal(t, edKey.PublicKey(), loaded.Keys[1].PublicKey())
+
+	// The loaded key signs like the original
+	msg := []byte("hello")
+	sig, err := loaded.KeyPair.Sign(msg)
+	require.NoError(t, err)
+	assert.NoError(t, id.KeyPair.Verify(msg, sig))
+}
+
+func TestIdentity_SignCard_WrongDID(t *testing.T) {
+	id := newTestIdentity(t)
+	card := protocol.NewAgentCardBuilder("did:sage:ethereum:0xother", "agent", "https://agent.example").Build()
+	_, err := id.SignCard(context.Background(), protocol.NewDefaultAgentCardSigner(nil), card)
+	assert.Error(t, err)
+	assert.Nil(t, id.Card)
+}
+
+func TestParseIdentity_Invalid(t *testing.T) {
+	_, err := ParseIdentity([]byte(`{"did":"did:sage:ethereum:0x1","keys":[]}`))
+	assert.Error(t, err)
+
+	_, err = ParseIdentity([]byte(`{"did":"did:sage:ethereum:0x1","keys":[{"kty":"oct"}]}`))
+	assert.Error(t, err)
+
+	_, err = LoadIdentity(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,396 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package identity
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sage-x-project/sage-a2a-go/internal/fsutil"
+)
+
+// DefaultKDFIterations is the PBKDF2-SHA256 iteration count used to derive
+// the encryption key of stored identities
+const DefaultKDFIterations = 600_000
+
+const (
+	identityFileExt = ".identity"
+	storeIndexFile  = "store.json"
+)
+
+var (
+	// ErrIdentityNotFound is returned for names the store does not hold
+	ErrIdentityNotFound = errors.New("identity not found")
+
+	// ErrWrongPassphrase is returned when a stored identity cannot be
+	// decrypted, because the passphrase is wrong or the file was altered
+	ErrWrongPassphrase = errors.New("identity store: wrong passphrase or corrupted identity")
+)
+
+// nameRe matches valid identity names, which are also file names
+var nameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// Store keeps several named identities, e.g. one per environment or
+// persona, in a directory. Each identity is a file encrypted with
+// AES-256-GCM under a key derived from the store passphrase; the default
+// name and counterparty bindings are kept unencrypted in store.json.
+// Decrypted identities are cached in memory. A Store is safe for
+// concurrent use but not for several processes writing at once.
+type Store struct {
+	dir        string
+	passphrase []byte
+	iterations int
+
+	mu    sync.Mutex
+	cache map[string]*Identity
+	index storeIndex
+}
+
+// storeIndex is the content of store.json
+type storeIndex struct {
+	Default string `json:"default,omitempty"`
+
+	// Counterparties maps counterparty DIDs or URLs to identity names
+	Counterparties map[string]string `json:"counterparties,omitempty"`
+}
+
+// sealedIdentity is the on-disk form of an encrypted identity
+type sealedIdentity struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// StoreOption configures a Store
+type StoreOption func(*Store)
+
+// WithKDFIterations sets the PBKDF2 iteration count for identities written
+// by the store (default DefaultKDFIterations). Existing files keep theirs.
+func WithKDFIterations(iterations int) StoreOption {
+	return func(s *Store) {
+		s.iterations = iterations
+	}
+}
+
+// OpenStore opens the identity store in dir, creating the directory with
+// owner-only permissions if needed
+func OpenStore(dir string, passphrase []byte, opts ...StoreOption) (*Store, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("identity store: passphrase is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create identity store: %w", err)
+	}
+	s := &Store{
+		dir:        dir,
+		passphrase: append([]byte(nil), passphrase...),
+		iterations: DefaultKDFIterations,
+		cache:      make(map[string]*Identity),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, storeIndexFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read identity store index: %w", err)
+	default:
+		if err := json.Unmarshal(data, &s.index); err != nil {
+			return nil, fmt.Errorf("failed to parse identity store index: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Put stores id under name, replacing any identity of that name
+func (s *Store) Put(name string, id *Identity) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	plaintext, err := id.Marshal()
+	if err != nil {
+		return err
+	}
+	sealed, err := s.seal(plaintext)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(sealed)
+	if err != nil {
+		return fmt.Errorf("failed to encode identity: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := fsutil.WriteFileAtomic(s.path(name), data); err != nil {
+		return fmt.Errorf("failed to write identity %s: %w", name, err)
+	}
+	s.cache[name] = id
+	return nil
+}
+
+// Get returns the identity stored under name
+func (s *Store) Get(name string) (*Identity, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(name)
+}
+
+func (s *Store) get(name string) (*Identity, error) {
+	if id, ok := s.cache[name]; ok {
+		return id, nil
+	}
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrIdentityNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity %s: %w", name, err)
+	}
+	var sealed sealedIdentity
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("failed to parse identity %s: %w", name, err)
+	}
+	plaintext, err := s.open(&sealed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, name)
+	}
+	id, err := ParseIdentity(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	s.cache[name] = id
+	return id, nil
+}
+
+// Delete removes the identity stored under name, along with the default
+// and counterparty bindings naming it
+func (s *Store) Delete(name string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrIdentityNotFound, name)
+		}
+		return fmt.Errorf("failed to delete identity %s: %w", name, err)
+	}
+	delete(s.cache, name)
+
+	if s.index.Default == name {
+		s.index.Default = ""
+	}
+	for counterparty, bound := range s.index.Counterparties {
+		if bound == name {
+			delete(s.index.Counterparties, counterparty)
+		}
+	}
+	return s.saveIndex()
+}
+
+// Names returns the names of the stored identities, sorted
+func (s *Store) Names() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), identityFileExt); ok && !e.IsDir() && nameRe.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SetDefault makes name the identity returned by Default and by For for
+// unbound counterparties
+func (s *Store) SetDefault(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.exists(name); err != nil {
+		return err
+	}
+	s.index.Default = name
+	return s.saveIndex()
+}
+
+// Default returns the default identity
+func (s *Store) Default() (*Identity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.index.Default == "" {
+		return nil, fmt.Errorf("%w: no default identity", ErrIdentityNotFound)
+	}
+	return s.get(s.index.Default)
+}
+
+// Bind makes the agent act as the identity name towards counterparty, a
+// DID or base URL (see For)
+func (s *Store) Bind(counterparty, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.exists(name); err != nil {
+		return err
+	}
+	if s.index.Counterparties == nil {
+		s.index.Counterparties = make(map[string]string)
+	}
+	s.index.Counterparties[counterparty] = name
+	return s.saveIndex()
+}
+
+// Unbind removes the binding of counterparty
+func (s *Store) Unbind(counterparty string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.index.Counterparties, counterparty)
+	return s.saveIndex()
+}
+
+// For returns the identity to act as towards counterparty: the one bound
+// to it, else the default identity
+func (s *Store) For(counterparty string) (*Identity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if name, ok := s.index.Counterparties[counterparty]; ok {
+		return s.get(name)
+	}
+	if s.index.Default == "" {
+		return nil, fmt.Errorf("%w: none bound to %s and no default", ErrIdentityNotFound, counterparty)
+	}
+	return s.get(s.index.Default)
+}
+
+// exists checks that an identity is stored under name
+func (s *Store) exists(name string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	if _, ok := s.cache[name]; ok {
+		return nil
+	}
+	if _, err := os.Stat(s.path(name)); err != nil {
+		return fmt.Errorf("%w: %s", ErrIdentityNotFound, name)
+	}
+	return nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+identityFileExt)
+}
+
+func (s *Store) saveIndex() error {
+	data, err := json.MarshalIndent(s.index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode identity store index: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(filepath.Join(s.dir, storeIndexFile), data); err != nil {
+		return fmt.Errorf("failed to write identity store index: %w", err)
+	}
+	return nil
+}
+
+// seal encrypts plaintext under a key derived with a fresh salt
+func (s *Store) seal(plaintext []byte) (*sealedIdentity, error) {
+	sealed := &sealedIdentity{
+		Version:    1,
+		KDF:        "pbkdf2-sha256",
+		Iterations: s.iterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(sealed.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := s.aead(sealed)
+	if err != nil {
+		return nil, err
+	}
+	sealed.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(sealed.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed.Ciphertext = aead.Seal(nil, sealed.Nonce, plaintext, nil)
+	return sealed, nil
+}
+
+// open decrypts a sealed identity
+func (s *Store) open(sealed *sealedIdentity) ([]byte, error) {
+	if sealed.Version != 1 || sealed.KDF != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("identity store: unsupported identity format %d/%s", sealed.Version, sealed.KDF)
+	}
+	aead, err := s.aead(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// aead returns the cipher for a sealed identity's salt and iterations
+func (s *Store) aead(sealed *sealedIdentity) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, string(s.passphrase), sealed.Salt, sealed.Iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive identity key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// checkName validates an identity name
+func checkName(name string) error {
+	if !nameRe.MatchString(name) {
+		return fmt.Errorf("identity store: invalid identity name %q", name)
+	}
+	return nil
+}
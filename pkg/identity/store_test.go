@@ -0,0 +1,117 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestStore(t *testing.T, dir string, passphrase string) *Store {
+	store, err := OpenStore(dir, []byte(passphrase), WithKDFIterations(1000))
+	require.NoError(t, err)
+	return store
+}
+
+func TestStore_PutGet(t *testing.T) {
+	dir := t.TempDir()
+	store := openTestStore(t, dir, "secret")
+	id := newTestIdentity(t, WithMetadata("env", "prod"))
+	require.NoError(t, store.Put("prod", id))
+
+	data, err := os.ReadFile(filepath.Join(dir, "prod.identity"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), string(testDID), "identity must be encrypted at rest")
+
+	info, err := os.Stat(filepath.Join(dir, "prod.identity"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A fresh store decrypts from disk
+	reopened := openTestStore(t, dir, "secret")
+	got, err := reopened.Get("prod")
+	require.NoError(t, err)
+	assert.Equal(t, testDID, got.DID)
+	assert.Equal(t, "prod", got.Metadata["env"])
+	assert.Equal(t, id.KeyPair.PublicKey(), got.KeyPair.PublicKey())
+
+	names, err := reopened.Names()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, names)
+}
+
+func TestStore_WrongPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, openTestStore(t, dir, "secret").Put("prod", newTestIdentity(t)))
+
+	_, err := openTestStore(t, dir, "wrong").Get("prod")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+}
+
+func TestStore_NotFoundAndInvalidName(t *testing.T) {
+	store := openTestStore(t, t.TempDir(), "secret")
+
+	_, err := store.Get("missing")
+	assert.ErrorIs(t, err, ErrIdentityNotFound)
+
+	assert.Error(t, store.Put("../escape", newTestIdentity(t)))
+	assert.ErrorIs(t, store.SetDefault("missing"), ErrIdentityNotFound)
+	assert.ErrorIs(t, store.Delete("missing"), ErrIdentityNotFound)
+}
+
+func TestStore_DefaultAndBindings(t *testing.T) {
+	dir := t.TempDir()
+	store := openTestStore(t, dir, "secret")
+	prod := newTestIdentity(t, WithMetadata("env", "prod"))
+	partner := newTestIdentity(t, WithMetadata("env", "partner"))
+	require.NoError(t, store.Put("prod", prod))
+	require.NoError(t, store.Put("partner", partner))
+
+	_, err := store.For("did:sage:ethereum:0xabc")
+	assert.ErrorIs(t, err, ErrIdentityNotFound)
+
+	require.NoError(t, store.SetDefault("prod"))
+	require.NoError(t, store.Bind("did:sage:ethereum:0xabc", "partner"))
+
+	// Bindings survive reopening
+	store = openTestStore(t, dir, "secret")
+	got, err := store.For("did:sage:ethereum:0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, "partner", got.Metadata["env"])
+
+	got, err = store.For("did:sage:ethereum:0xdef")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", got.Metadata["env"])
+
+	require.NoError(t, store.Unbind("did:sage:ethereum:0xabc"))
+	got, err = store.For("did:sage:ethereum:0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", got.Metadata["env"])
+
+	// Deleting the default identity clears it
+	require.NoError(t, store.Delete("prod"))
+	_, err = store.Default()
+	assert.ErrorIs(t, err, ErrIdentityNotFound)
+	names, err := store.Names()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"partner"}, names)
+}
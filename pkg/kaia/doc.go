@@ -0,0 +1,49 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package kaia resolves SAGE agents registered on the Kaia blockchain.
+//
+// Kaia is EVM compatible and hosts the same AgentCardRegistry contract as
+// Ethereum, so Client wraps the Ethereum registry bindings and restricts
+// them to the did:sage:kaia method.
+//
+// # Verifying Kaia Agents
+//
+//	client, err := kaia.NewClient(kaia.Config{
+//	    Network:         kaia.NetworkKairos,
+//	    ContractAddress: "0x...",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	middleware := server.NewDIDAuthMiddlewareWithVerifier(client.NewDIDVerifier())
+//
+// # Signing Agent Cards
+//
+// Client satisfies protocol.EthereumClient, so agent cards for Kaia DIDs can
+// be signed and verified directly:
+//
+//	cardSigner := protocol.NewDefaultAgentCardSigner(client)
+//
+// # Networks
+//
+//   - NetworkMainnet: chain ID 8217, MainnetRPCEndpoint
+//   - NetworkKairos: chain ID 1001 (testnet), KairosRPCEndpoint
+//
+// Set Config.RPCEndpoint to use a private node instead.
+package kaia
@@ -0,0 +1,179 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package kaia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	ethdid "github.com/sage-x-project/sage/pkg/agent/did/ethereum"
+)
+
+// Chain and network identifiers for Kaia
+const (
+	ChainKaia did.Chain = "kaia"
+
+	NetworkMainnet did.Network = "kaia-mainnet"
+	NetworkKairos  did.Network = "kaia-kairos"
+)
+
+// Public RPC endpoints and chain IDs
+const (
+	MainnetRPCEndpoint = "https://public-en.node.kaia.io"
+	KairosRPCEndpoint  = "https://public-en-kairos.node.kaia.io"
+
+	MainnetChainID = 8217
+	KairosChainID  = 1001
+)
+
+// DIDPrefix is the method prefix of DIDs registered on Kaia
+const DIDPrefix = "did:sage:kaia:"
+
+// ErrNotKaiaDID is returned when a DID outside the did:sage:kaia method is
+// resolved through a Kaia client
+var ErrNotKaiaDID = errors.New("not a did:sage:kaia DID")
+
+// Config configures a Kaia registry client
+type Config struct {
+	// Network selects the default RPC endpoint (default NetworkMainnet)
+	Network did.Network
+
+	// RPCEndpoint overrides the network's public endpoint
+	RPCEndpoint string
+
+	// ContractAddress is the AgentCardRegistry deployment (required)
+	ContractAddress string
+
+	// PrivateKey is a hex key paying gas for registry writes. Resolution
+	// does not need it.
+	PrivateKey string
+}
+
+// Client resolves did:sage:kaia agents from the AgentCardRegistry deployed
+// on Kaia. Kaia is EVM compatible, so the registry bindings are the same as
+// on Ethereum.
+//
+// Client implements verifier.DIDResolver, verifier.PublicKeyClient and
+// protocol.EthereumClient.
+type Client struct {
+	registry verifier.DIDResolver
+}
+
+// NewClient connects to a Kaia node and binds the registry contract
+func NewClient(config Config) (*Client, error) {
+	if config.ContractAddress == "" {
+		return nil, errors.New("kaia: registry contract address is required")
+	}
+	if config.Network == "" {
+		config.Network = NetworkMainnet
+	}
+	if config.RPCEndpoint == "" {
+		switch config.Network {
+		case NetworkMainnet:
+			config.RPCEndpoint = MainnetRPCEndpoint
+		case NetworkKairos:
+			config.RPCEndpoint = KairosRPCEndpoint
+		default:
+			return nil, fmt.Errorf("kaia: unknown network %q", config.Network)
+		}
+	}
+
+	registry, err := ethdid.NewAgentCardClient(&did.RegistryConfig{
+		Chain:           ChainKaia,
+		Network:         config.Network,
+		ContractAddress: config.ContractAddress,
+		RPCEndpoint:     config.RPCEndpoint,
+		PrivateKey:      config.PrivateKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kaia registry client: %w", err)
+	}
+	return NewClientWithRegistry(registry), nil
+}
+
+// NewClientWithRegistry creates a Client over an existing registry binding,
+// e.g. a custom AgentCardClient or a test double
+func NewClientWithRegistry(registry verifier.DIDResolver) *Client {
+	return &Client{registry: registry}
+}
+
+// IsKaiaDID reports whether didStr uses the did:sage:kaia method
+func IsKaiaDID(didStr string) bool {
+	return strings.HasPrefix(didStr, DIDPrefix) && len(didStr) > len(DIDPrefix)
+}
+
+// GetAgentByDID implements verifier.DIDResolver
+func (c *Client) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	if !IsKaiaDID(didStr) {
+		return nil, fmt.Errorf("%w: %s", ErrNotKaiaDID, didStr)
+	}
+	meta, err := c.registry.GetAgentByDID(ctx, didStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve agent on Kaia: %w", err)
+	}
+	if meta == nil {
+		return nil, did.ErrDIDNotFound
+	}
+	return meta, nil
+}
+
+// ResolvePublicKeyByType returns the agent's first verified key of keyType
+func (c *Client) ResolvePublicKeyByType(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) (interface{}, error) {
+	meta, err := c.GetAgentByDID(ctx, string(agentDID))
+	if err != nil {
+		return nil, err
+	}
+	if !meta.IsActive {
+		return nil, did.ErrInactiveAgent
+	}
+
+	if keyType == did.KeyTypeX25519 && len(meta.PublicKEMKey) == 32 {
+		return meta.PublicKEMKey, nil
+	}
+	for _, k := range meta.Keys {
+		if k.Verified && k.Type == keyType {
+			if keyType == did.KeyTypeX25519 {
+				return k.KeyData, nil
+			}
+			return verifier.NormalizePublicKey(k.KeyData, keyType)
+		}
+	}
+	return nil, fmt.Errorf("no verified %s key registered for %s", keyType, agentDID)
+}
+
+// ResolvePublicKey implements verifier.PublicKeyClient, returning the
+// agent's secp256k1 key
+func (c *Client) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return c.ResolvePublicKeyByType(ctx, agentDID, did.KeyTypeECDSA)
+}
+
+// ResolveKEMKey implements verifier.PublicKeyClient, returning the agent's
+// X25519 key
+func (c *Client) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return c.ResolvePublicKeyByType(ctx, agentDID, did.KeyTypeX25519)
+}
+
+// NewDIDVerifier builds a verifier.DIDVerifier resolving keys from Kaia
+func (c *Client) NewDIDVerifier() verifier.DIDVerifier {
+	selector := verifier.NewDefaultKeySelector(verifier.NewMemoizedResolver(c))
+	return verifier.NewDefaultDIDVerifier(verifier.NewMemoizedPublicKeyClient(c), selector, verifier.NewRFC9421Verifier())
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package kaia
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRegistry map[string]*did.AgentMetadataV4
+
+func (m mockRegistry) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	return m[didStr], nil
+}
+
+func TestClient_RejectsOtherMethods(t *testing.T) {
+	client := NewClientWithRegistry(mockRegistry{})
+
+	_, err := client.GetAgentByDID(context.Background(), "did:sage:ethereum:0xabc")
+	assert.ErrorIs(t, err, ErrNotKaiaDID)
+
+	_, err = client.GetAgentByDID(context.Background(), DIDPrefix)
+	assert.ErrorIs(t, err, ErrNotKaiaDID)
+
+	_, err = client.GetAgentByDID(context.Background(), "did:sage:kaia:0xmissing")
+	assert.Equal(t, did.ErrDIDNotFound, err)
+}
+
+func TestClient_ResolvePublicKeyByType(t *testing.T) {
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	keyData, err := did.MarshalPublicKey(keyPair.PublicKey())
+	require.NoError(t, err)
+	kem := make([]byte, 32)
+
+	agentDID := did.AgentDID("did:sage:kaia:0x1234")
+	client := NewClientWithRegistry(mockRegistry{
+		string(agentDID): {
+			DID:          agentDID,
+			IsActive:     true,
+			Keys:         []did.AgentKey{{Type: did.KeyTypeECDSA, KeyData: keyData, Verified: true}},
+			PublicKEMKey: kem,
+		},
+	})
+
+	pub, err := client.ResolvePublicKey(context.Background(), agentDID)
+	require.NoError(t, err)
+	assert.True(t, pub.(*ecdsa.PublicKey).Equal(keyPair.PublicKey()))
+
+	kemKey, err := client.ResolveKEMKey(context.Background(), agentDID)
+	require.NoError(t, err)
+	assert.Equal(t, kem, kemKey)
+
+	_, err = client.ResolvePublicKeyByType(context.Background(), agentDID, did.KeyTypeEd25519)
+	assert.Error(t, err)
+}
+
+func TestClient_InactiveAgent(t *testing.T) {
+	agentDID := did.AgentDID("did:sage:kaia:0xdead")
+	client := NewClientWithRegistry(mockRegistry{string(agentDID): {DID: agentDID}})
+
+	_, err := client.ResolvePublicKey(context.Background(), agentDID)
+	assert.Equal(t, did.ErrInactiveAgent, err)
+}
+
+func TestClient_NewDIDVerifier(t *testing.T) {
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	keyData, err := did.MarshalPublicKey(keyPair.PublicKey())
+	require.NoError(t, err)
+
+	agentDID := did.AgentDID("did:sage:kaia:0x5678")
+	client := NewClientWithRegistry(mockRegistry{
+		string(agentDID): {
+			DID:      agentDID,
+			IsActive: true,
+			Keys:     []did.AgentKey{{Type: did.KeyTypeECDSA, KeyData: keyData, Verified: true}},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "http://agent.example/rpc", strings.NewReader(`{"jsonrpc":"2.0"}`))
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, agentDID, keyPair))
+
+	got, err := client.NewDIDVerifier().VerifyHTTPSignatureWithKeyID(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, agentDID, got)
+}
+
+func TestNewClient_RequiresContract(t *testing.T) {
+	_, err := NewClient(Config{})
+	assert.Error(t, err)
+
+	_, err = NewClient(Config{Network: "kaia-devnet", ContractAddress: "0x1"})
+	assert.ErrorContains(t, err, "unknown network")
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/signer (interfaces: A2ASigner)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/a2a_signer.go -package=mocks . A2ASigner
+//
+
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	signer "github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	crypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	did "github.com/sage-x-project/sage/pkg/agent/did"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockA2ASigner is a mock of A2ASigner interface.
+type MockA2ASigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockA2ASignerMockRecorder
+	isgomock struct{}
+}
+
+// MockA2ASignerMockRecorder is the mock recorder for MockA2ASigner.
+type MockA2ASignerMockRecorder struct {
+	mock *MockA2ASigner
+}
+
+// NewMockA2ASigner creates a new mock instance.
+func NewMockA2ASigner(ctrl *gomock.Controller) *MockA2ASigner {
+	mock := &MockA2ASigner{ctrl: ctrl}
+	mock.recorder = &MockA2ASignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockA2ASigner) EXPECT() *MockA2ASignerMockRecorder {
+	return m.recorder
+}
+
+// SignRequest mocks base method.
+func (m *MockA2ASigner) SignRequest(ctx context.Context, req *http.Request, agentDID did.AgentDID, keyPair crypto.KeyPair) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignRequest", ctx, req, agentDID, keyPair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignRequest indicates an expected call of SignRequest.
+func (mr *MockA2ASignerMockRecorder) SignRequest(ctx, req, agentDID, keyPair any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignRequest", reflect.TypeOf((*MockA2ASigner)(nil).SignRequest), ctx, req, agentDID, keyPair)
+}
+
+// SignRequestWithOptions mocks base method.
+func (m *MockA2ASigner) SignRequestWithOptions(ctx context.Context, req *http.Request, agentDID did.AgentDID, keyPair crypto.KeyPair, opts *signer.SigningOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignRequestWithOptions", ctx, req, agentDID, keyPair, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignRequestWithOptions indicates an expected call of SignRequestWithOptions.
+func (mr *MockA2ASignerMockRecorder) SignRequestWithOptions(ctx, req, agentDID, keyPair, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignRequestWithOptions", reflect.TypeOf((*MockA2ASigner)(nil).SignRequestWithOptions), ctx, req, agentDID, keyPair, opts)
+}
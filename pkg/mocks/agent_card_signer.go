@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/protocol (interfaces: AgentCardSigner)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/agent_card_signer.go -package=mocks . AgentCardSigner
+//
+
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	protocol "github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	crypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAgentCardSigner is a mock of AgentCardSigner interface.
+type MockAgentCardSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentCardSignerMockRecorder
+	isgomock struct{}
+}
+
+// MockAgentCardSignerMockRecorder is the mock recorder for MockAgentCardSigner.
+type MockAgentCardSignerMockRecorder struct {
+	mock *MockAgentCardSigner
+}
+
+// NewMockAgentCardSigner creates a new mock instance.
+func NewMockAgentCardSigner(ctrl *gomock.Controller) *MockAgentCardSigner {
+	mock := &MockAgentCardSigner{ctrl: ctrl}
+	mock.recorder = &MockAgentCardSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentCardSigner) EXPECT() *MockAgentCardSignerMockRecorder {
+	return m.recorder
+}
+
+// SignAgentCard mocks base method.
+func (m *MockAgentCardSigner) SignAgentCard(ctx context.Context, card *protocol.AgentCard, keyPair crypto.KeyPair) (*protocol.SignedAgentCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignAgentCard", ctx, card, keyPair)
+	ret0, _ := ret[0].(*protocol.SignedAgentCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignAgentCard indicates an expected call of SignAgentCard.
+func (mr *MockAgentCardSignerMockRecorder) SignAgentCard(ctx, card, keyPair any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAgentCard", reflect.TypeOf((*MockAgentCardSigner)(nil).SignAgentCard), ctx, card, keyPair)
+}
+
+// VerifyAgentCard mocks base method.
+func (m *MockAgentCardSigner) VerifyAgentCard(ctx context.Context, signedCard *protocol.SignedAgentCard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAgentCard", ctx, signedCard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyAgentCard indicates an expected call of VerifyAgentCard.
+func (mr *MockAgentCardSignerMockRecorder) VerifyAgentCard(ctx, signedCard any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAgentCard", reflect.TypeOf((*MockAgentCardSigner)(nil).VerifyAgentCard), ctx, signedCard)
+}
+
+// VerifyAgentCardWithKey mocks base method.
+func (m *MockAgentCardSigner) VerifyAgentCardWithKey(ctx context.Context, signedCard *protocol.SignedAgentCard, publicKey any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAgentCardWithKey", ctx, signedCard, publicKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyAgentCardWithKey indicates an expected call of VerifyAgentCardWithKey.
+func (mr *MockAgentCardSignerMockRecorder) VerifyAgentCardWithKey(ctx, signedCard, publicKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAgentCardWithKey", reflect.TypeOf((*MockAgentCardSigner)(nil).VerifyAgentCardWithKey), ctx, signedCard, publicKey)
+}
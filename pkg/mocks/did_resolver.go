@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/verifier (interfaces: DIDResolver)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/did_resolver.go -package=mocks . DIDResolver
+//
+
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	did "github.com/sage-x-project/sage/pkg/agent/did"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDIDResolver is a mock of DIDResolver interface.
+type MockDIDResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockDIDResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockDIDResolverMockRecorder is the mock recorder for MockDIDResolver.
+type MockDIDResolverMockRecorder struct {
+	mock *MockDIDResolver
+}
+
+// NewMockDIDResolver creates a new mock instance.
+func NewMockDIDResolver(ctrl *gomock.Controller) *MockDIDResolver {
+	mock := &MockDIDResolver{ctrl: ctrl}
+	mock.recorder = &MockDIDResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDIDResolver) EXPECT() *MockDIDResolverMockRecorder {
+	return m.recorder
+}
+
+// GetAgentByDID mocks base method.
+func (m *MockDIDResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgentByDID", ctx, didStr)
+	ret0, _ := ret[0].(*did.AgentMetadataV4)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgentByDID indicates an expected call of GetAgentByDID.
+func (mr *MockDIDResolverMockRecorder) GetAgentByDID(ctx, didStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgentByDID", reflect.TypeOf((*MockDIDResolver)(nil).GetAgentByDID), ctx, didStr)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/verifier (interfaces: DIDVerifier)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/did_verifier.go -package=mocks . DIDVerifier
+//
+
+package mocks
+
+import (
+	context "context"
+	crypto "crypto"
+	http "net/http"
+	reflect "reflect"
+
+	did "github.com/sage-x-project/sage/pkg/agent/did"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDIDVerifier is a mock of DIDVerifier interface.
+type MockDIDVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockDIDVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockDIDVerifierMockRecorder is the mock recorder for MockDIDVerifier.
+type MockDIDVerifierMockRecorder struct {
+	mock *MockDIDVerifier
+}
+
+// NewMockDIDVerifier creates a new mock instance.
+func NewMockDIDVerifier(ctrl *gomock.Controller) *MockDIDVerifier {
+	mock := &MockDIDVerifier{ctrl: ctrl}
+	mock.recorder = &MockDIDVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDIDVerifier) EXPECT() *MockDIDVerifierMockRecorder {
+	return m.recorder
+}
+
+// ResolvePublicKey mocks base method.
+func (m *MockDIDVerifier) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePublicKey", ctx, agentDID, keyType)
+	ret0, _ := ret[0].(crypto.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvePublicKey indicates an expected call of ResolvePublicKey.
+func (mr *MockDIDVerifierMockRecorder) ResolvePublicKey(ctx, agentDID, keyType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePublicKey", reflect.TypeOf((*MockDIDVerifier)(nil).ResolvePublicKey), ctx, agentDID, keyType)
+}
+
+// VerifyHTTPSignature mocks base method.
+func (m *MockDIDVerifier) VerifyHTTPSignature(ctx context.Context, req *http.Request, agentDID did.AgentDID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyHTTPSignature", ctx, req, agentDID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyHTTPSignature indicates an expected call of VerifyHTTPSignature.
+func (mr *MockDIDVerifierMockRecorder) VerifyHTTPSignature(ctx, req, agentDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyHTTPSignature", reflect.TypeOf((*MockDIDVerifier)(nil).VerifyHTTPSignature), ctx, req, agentDID)
+}
+
+// VerifyHTTPSignatureWithKeyID mocks base method.
+func (m *MockDIDVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, req *http.Request) (did.AgentDID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyHTTPSignatureWithKeyID", ctx, req)
+	ret0, _ := ret[0].(did.AgentDID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyHTTPSignatureWithKeyID indicates an expected call of VerifyHTTPSignatureWithKeyID.
+func (mr *MockDIDVerifierMockRecorder) VerifyHTTPSignatureWithKeyID(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyHTTPSignatureWithKeyID", reflect.TypeOf((*MockDIDVerifier)(nil).VerifyHTTPSignatureWithKeyID), ctx, req)
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package mocks provides gomock mocks of the collaborator interfaces that
+// the client, transport, server and protocol constructors accept, so code
+// built on sage-a2a-go can be unit tested without a blockchain, DID
+// registry or real keys.
+//
+// The mocks are generated by mockgen from the go:generate directive next
+// to each interface; run go generate ./... after changing one. CI fails
+// when the generated files are out of date.
+//
+//	ctrl := gomock.NewController(t)
+//	v := mocks.NewMockDIDVerifier(ctrl)
+//	v.EXPECT().VerifyHTTPSignatureWithKeyID(gomock.Any(), gomock.Any()).
+//	    Return(did.AgentDID("did:sage:ethereum:0xabc"), nil)
+//	handler := server.NewDIDAuthMiddlewareWithVerifier(v).Wrap(rpcHandler)
+//
+//	s := mocks.NewMockA2ASigner(ctrl)
+//	s.EXPECT().SignRequest(gomock.Any(), gomock.Any(), agentDID, keyPair).Return(nil)
+//	tr := transport.NewDIDHTTPTransport(url, agentDID, keyPair, nil, transport.WithSigner(s))
+//
+// Mocks:
+//
+//   - MockA2ASigner: signer.A2ASigner
+//   - MockDIDVerifier: verifier.DIDVerifier
+//   - MockKeySelector: verifier.KeySelector
+//   - MockDIDResolver: verifier.DIDResolver
+//   - MockPublicKeyClient: verifier.PublicKeyClient
+//   - MockSignatureVerifier: verifier.SignatureVerifier
+//   - MockAgentCardSigner: protocol.AgentCardSigner
+//   - MockEthereumClient: protocol.EthereumClient
+package mocks
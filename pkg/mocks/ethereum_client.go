@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/protocol (interfaces: EthereumClient)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/ethereum_client.go -package=mocks . EthereumClient
+//
+
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	did "github.com/sage-x-project/sage/pkg/agent/did"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEthereumClient is a mock of EthereumClient interface.
+type MockEthereumClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockEthereumClientMockRecorder
+	isgomock struct{}
+}
+
+// MockEthereumClientMockRecorder is the mock recorder for MockEthereumClient.
+type MockEthereumClientMockRecorder struct {
+	mock *MockEthereumClient
+}
+
+// NewMockEthereumClient creates a new mock instance.
+func NewMockEthereumClient(ctrl *gomock.Controller) *MockEthereumClient {
+	mock := &MockEthereumClient{ctrl: ctrl}
+	mock.recorder = &MockEthereumClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEthereumClient) EXPECT() *MockEthereumClientMockRecorder {
+	return m.recorder
+}
+
+// ResolvePublicKeyByType mocks base method.
+func (m *MockEthereumClient) ResolvePublicKeyByType(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePublicKeyByType", ctx, agentDID, keyType)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvePublicKeyByType indicates an expected call of ResolvePublicKeyByType.
+func (mr *MockEthereumClientMockRecorder) ResolvePublicKeyByType(ctx, agentDID, keyType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePublicKeyByType", reflect.TypeOf((*MockEthereumClient)(nil).ResolvePublicKeyByType), ctx, agentDID, keyType)
+}
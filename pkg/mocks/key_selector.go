@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/verifier (interfaces: KeySelector)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/key_selector.go -package=mocks . KeySelector
+//
+
+package mocks
+
+import (
+	context "context"
+	crypto "crypto"
+	reflect "reflect"
+
+	did "github.com/sage-x-project/sage/pkg/agent/did"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeySelector is a mock of KeySelector interface.
+type MockKeySelector struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeySelectorMockRecorder
+	isgomock struct{}
+}
+
+// MockKeySelectorMockRecorder is the mock recorder for MockKeySelector.
+type MockKeySelectorMockRecorder struct {
+	mock *MockKeySelector
+}
+
+// NewMockKeySelector creates a new mock instance.
+func NewMockKeySelector(ctrl *gomock.Controller) *MockKeySelector {
+	mock := &MockKeySelector{ctrl: ctrl}
+	mock.recorder = &MockKeySelectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeySelector) EXPECT() *MockKeySelectorMockRecorder {
+	return m.recorder
+}
+
+// SelectKey mocks base method.
+func (m *MockKeySelector) SelectKey(ctx context.Context, agentDID did.AgentDID, protocol string) (crypto.PublicKey, did.KeyType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectKey", ctx, agentDID, protocol)
+	ret0, _ := ret[0].(crypto.PublicKey)
+	ret1, _ := ret[1].(did.KeyType)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectKey indicates an expected call of SelectKey.
+func (mr *MockKeySelectorMockRecorder) SelectKey(ctx, agentDID, protocol any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectKey", reflect.TypeOf((*MockKeySelector)(nil).SelectKey), ctx, agentDID, protocol)
+}
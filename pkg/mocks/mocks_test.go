@@ -0,0 +1,89 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package mocks_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/client"
+	"github.com/sage-x-project/sage-a2a-go/pkg/mocks"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const callerDID = did.AgentDID("did:sage:ethereum:0xabc")
+
+func signedRequest() *http.Request {
+	req := httptest.NewRequest("POST", "/rpc", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Signature", "sig1=:AAAA:")
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xabc"`)
+	return req
+}
+
+func TestMockDIDVerifier_Middleware(t *testing.T) {
+	v := mocks.NewMockDIDVerifier(gomock.NewController(t))
+	gomock.InOrder(
+		v.EXPECT().VerifyHTTPSignatureWithKeyID(gomock.Any(), gomock.Any()).Return(callerDID, nil),
+		v.EXPECT().VerifyHTTPSignatureWithKeyID(gomock.Any(), gomock.Any()).Return(did.AgentDID(""), errors.New("bad signature")),
+	)
+
+	var got did.AgentDID
+	handler := server.NewDIDAuthMiddlewareWithVerifier(v).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = server.GetAgentDIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, callerDID, got)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMockA2ASigner_Client(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "mock", r.Header.Get("Signature"))
+	}))
+	defer srv.Close()
+
+	s := mocks.NewMockA2ASigner(gomock.NewController(t))
+	s.EXPECT().SignRequest(gomock.Any(), gomock.Any(), callerDID, nil).
+		DoAndReturn(func(ctx context.Context, req *http.Request, agentDID did.AgentDID, keyPair crypto.KeyPair) error {
+			req.Header.Set("Signature", "mock")
+			return nil
+		})
+
+	c := client.NewA2AClient(callerDID, nil, nil)
+	c.SetSigner(s)
+	req, err := http.NewRequestWithContext(context.Background(), "GET", srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := c.Do(context.Background(), req)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/verifier (interfaces: PublicKeyClient)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/public_key_client.go -package=mocks . PublicKeyClient
+//
+
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	did "github.com/sage-x-project/sage/pkg/agent/did"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublicKeyClient is a mock of PublicKeyClient interface.
+type MockPublicKeyClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicKeyClientMockRecorder
+	isgomock struct{}
+}
+
+// MockPublicKeyClientMockRecorder is the mock recorder for MockPublicKeyClient.
+type MockPublicKeyClientMockRecorder struct {
+	mock *MockPublicKeyClient
+}
+
+// NewMockPublicKeyClient creates a new mock instance.
+func NewMockPublicKeyClient(ctrl *gomock.Controller) *MockPublicKeyClient {
+	mock := &MockPublicKeyClient{ctrl: ctrl}
+	mock.recorder = &MockPublicKeyClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicKeyClient) EXPECT() *MockPublicKeyClientMockRecorder {
+	return m.recorder
+}
+
+// ResolveKEMKey mocks base method.
+func (m *MockPublicKeyClient) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveKEMKey", ctx, agentDID)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveKEMKey indicates an expected call of ResolveKEMKey.
+func (mr *MockPublicKeyClientMockRecorder) ResolveKEMKey(ctx, agentDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveKEMKey", reflect.TypeOf((*MockPublicKeyClient)(nil).ResolveKEMKey), ctx, agentDID)
+}
+
+// ResolvePublicKey mocks base method.
+func (m *MockPublicKeyClient) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePublicKey", ctx, agentDID)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvePublicKey indicates an expected call of ResolvePublicKey.
+func (mr *MockPublicKeyClientMockRecorder) ResolvePublicKey(ctx, agentDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePublicKey", reflect.TypeOf((*MockPublicKeyClient)(nil).ResolvePublicKey), ctx, agentDID)
+}
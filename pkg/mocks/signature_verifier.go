@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sage-x-project/sage-a2a-go/pkg/verifier (interfaces: SignatureVerifier)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=../mocks/signature_verifier.go -package=mocks . SignatureVerifier
+//
+
+package mocks
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSignatureVerifier is a mock of SignatureVerifier interface.
+type MockSignatureVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignatureVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockSignatureVerifierMockRecorder is the mock recorder for MockSignatureVerifier.
+type MockSignatureVerifierMockRecorder struct {
+	mock *MockSignatureVerifier
+}
+
+// NewMockSignatureVerifier creates a new mock instance.
+func NewMockSignatureVerifier(ctrl *gomock.Controller) *MockSignatureVerifier {
+	mock := &MockSignatureVerifier{ctrl: ctrl}
+	mock.recorder = &MockSignatureVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignatureVerifier) EXPECT() *MockSignatureVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyHTTPRequest mocks base method.
+func (m *MockSignatureVerifier) VerifyHTTPRequest(req *http.Request, pubKey any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyHTTPRequest", req, pubKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyHTTPRequest indicates an expected call of VerifyHTTPRequest.
+func (mr *MockSignatureVerifierMockRecorder) VerifyHTTPRequest(req, pubKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyHTTPRequest", reflect.TypeOf((*MockSignatureVerifier)(nil).VerifyHTTPRequest), req, pubKey)
+}
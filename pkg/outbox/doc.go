@@ -0,0 +1,59 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package outbox delivers outbound A2A calls reliably. Calls are stored
+// durably before they are sent; a dispatcher sends them in the background,
+// retrying transient failures with backoff, so a crash between deciding to
+// call a peer and reaching it does not lose the message.
+//
+// # Usage
+//
+//	store, err := outbox.NewSQLStore(db, outbox.StoreConfig{Placeholder: server.DollarPlaceholder})
+//	err = store.CreateTable(ctx)
+//
+//	box, err := outbox.New(outbox.Config{
+//	    Store:  store,
+//	    Sender: outbox.NewTransportSender(myDID, myKeyPair, nil),
+//	    OnFailure: func(msg *outbox.Message, err error) {
+//	        log.Printf("giving up on %s: %v", msg.IdempotencyKey, err)
+//	    },
+//	})
+//	go box.Run(ctx)
+//
+//	// Typically in the same database transaction as the business change
+//	_, err = box.Enqueue(ctx, "order-42-confirm", "https://partner.example.com", "message/send", params)
+//
+// # Delivery Semantics
+//
+// Every message has an idempotency key. Enqueueing a key that is already
+// stored is a no-op, and a delivered message is never sent again. Each
+// attempt carries the key in the signed protocol.IdempotencyKeyHeader, so a
+// receiver deduplicating by key turns the outbox's at-least-once retries
+// into exactly-once processing.
+//
+// Dispatchers claim messages with a lease before sending them, so several
+// processes may share one SQL store. A message whose sender crashed
+// mid-attempt is retried once its lease expires.
+//
+// # Failures
+//
+// Errors wrapped with Permanent, 4xx responses not marked retryable and
+// JSON-RPC errors fail a message immediately; other errors are retried with
+// exponential backoff (honoring Retry-After) until Config.MaxAttempts is
+// reached. Failed messages are reported to Config.OnFailure and kept in the
+// store with StatusFailed for inspection.
+package outbox
@@ -0,0 +1,279 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Default dispatcher settings
+const (
+	DefaultMaxAttempts  = 10
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 16
+	DefaultLease        = time.Minute
+)
+
+// Status is the delivery state of a message
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusDelivered Status = "delivered"
+	StatusFailed    Status = "failed"
+)
+
+// ErrNotFound is returned for unknown idempotency keys
+var ErrNotFound = errors.New("outbox message not found")
+
+// Message is an outbound JSON-RPC call and its delivery state
+type Message struct {
+	IdempotencyKey string
+	URL            string // base URL of the target agent
+	Method         string // JSON-RPC method, e.g. "message/send"
+	Params         json.RawMessage
+
+	Status      Status
+	Attempts    int
+	NextAttempt time.Time
+	LeaseUntil  time.Time // set while a dispatcher is sending the message
+	LastError   string
+	CreatedAt   time.Time
+}
+
+// Sender sends one message. Errors wrapped with Permanent are not retried.
+type Sender func(ctx context.Context, msg *Message) error
+
+// permanentError marks an error as not worth retrying
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as a permanent failure that must not be retried
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Config configures an Outbox
+type Config struct {
+	// Store persists messages (default NewMemoryStore())
+	Store Store
+
+	// Sender sends messages; required
+	Sender Sender
+
+	// MaxAttempts is the number of attempts before a message fails
+	// (default DefaultMaxAttempts)
+	MaxAttempts int
+
+	// Backoff returns the delay before retrying after the given number of
+	// attempts (default exponential from 1s, capped at 5m)
+	Backoff func(attempts int) time.Duration
+
+	// PollInterval is how often the store is polled for due messages
+	// (default DefaultPollInterval)
+	PollInterval time.Duration
+
+	// BatchSize is the number of messages claimed per poll (default DefaultBatchSize)
+	BatchSize int
+
+	// Lease is how long a claimed message is reserved for one attempt
+	// (default DefaultLease)
+	Lease time.Duration
+
+	// OnDelivered, if set, is called after a message is delivered
+	OnDelivered func(msg *Message)
+
+	// OnFailure, if set, is called when a message fails permanently
+	OnFailure func(msg *Message, err error)
+}
+
+// Outbox stores outbound calls and delivers them in the background
+type Outbox struct {
+	config Config
+	now    func() time.Time
+	wake   chan struct{}
+}
+
+// New creates an outbox. Call Run to start delivering messages.
+func New(config Config) (*Outbox, error) {
+	if config.Sender == nil {
+		return nil, fmt.Errorf("outbox sender is required")
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.Backoff == nil {
+		config.Backoff = defaultBackoff
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.Lease <= 0 {
+		config.Lease = DefaultLease
+	}
+	return &Outbox{config: config, now: time.Now, wake: make(chan struct{}, 1)}, nil
+}
+
+// Store returns the outbox's message store
+func (o *Outbox) Store() Store {
+	return o.config.Store
+}
+
+// Enqueue stores a call of method with params to the agent at url. An empty
+// key is replaced by a random one. It reports whether the message was added;
+// a key that is already stored is left untouched.
+func (o *Outbox) Enqueue(ctx context.Context, key, url, method string, params any) (bool, error) {
+	if url == "" || method == "" {
+		return false, fmt.Errorf("url and method are required")
+	}
+	if key == "" {
+		var b [16]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return false, fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+		key = hex.EncodeToString(b[:])
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal params: %w", err)
+	}
+
+	now := o.now()
+	added, err := o.config.Store.Add(ctx, &Message{
+		IdempotencyKey: key,
+		URL:            url,
+		Method:         method,
+		Params:         data,
+		Status:         StatusPending,
+		NextAttempt:    now,
+		CreatedAt:      now,
+	})
+	if err != nil {
+		return false, err
+	}
+	if added {
+		select {
+		case o.wake <- struct{}{}:
+		default:
+		}
+	}
+	return added, nil
+}
+
+// Run delivers due messages until ctx is done
+func (o *Outbox) Run(ctx context.Context) error {
+	ticker := time.NewTicker(o.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		// Keep draining while full batches are due
+		for {
+			n, err := o.Dispatch(ctx)
+			if err != nil || n < o.config.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-o.wake:
+		}
+	}
+}
+
+// Dispatch claims one batch of due messages and attempts to deliver each,
+// returning how many were attempted
+func (o *Outbox) Dispatch(ctx context.Context) (int, error) {
+	now := o.now()
+	msgs, err := o.config.Store.Claim(ctx, now, now.Add(o.config.Lease), o.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim messages: %w", err)
+	}
+	for _, msg := range msgs {
+		if err := o.attempt(ctx, msg); err != nil {
+			return 0, err
+		}
+	}
+	return len(msgs), nil
+}
+
+// attempt sends msg once and records the outcome
+func (o *Outbox) attempt(ctx context.Context, msg *Message) error {
+	sendCtx, cancel := context.WithDeadline(ctx, msg.LeaseUntil)
+	sendErr := o.config.Sender(sendCtx, msg)
+	cancel()
+	if sendErr != nil && ctx.Err() != nil {
+		// Shutting down; the lease expires and the message is retried
+		return nil
+	}
+
+	msg.Attempts++
+	msg.LeaseUntil = time.Time{}
+	switch {
+	case sendErr == nil:
+		msg.Status = StatusDelivered
+		msg.LastError = ""
+	case IsPermanent(sendErr) || msg.Attempts >= o.config.MaxAttempts:
+		msg.Status = StatusFailed
+		msg.LastError = sendErr.Error()
+	default:
+		msg.LastError = sendErr.Error()
+		msg.NextAttempt = o.now().Add(max(o.config.Backoff(msg.Attempts), retryAfter(sendErr)))
+	}
+
+	if err := o.config.Store.Update(ctx, msg); err != nil {
+		return fmt.Errorf("failed to update message: %w", err)
+	}
+	switch {
+	case msg.Status == StatusDelivered && o.config.OnDelivered != nil:
+		o.config.OnDelivered(msg)
+	case msg.Status == StatusFailed && o.config.OnFailure != nil:
+		o.config.OnFailure(msg, sendErr)
+	}
+	return nil
+}
+
+// defaultBackoff doubles from one second up to five minutes
+func defaultBackoff(attempts int) time.Duration {
+	d := time.Second << min(attempts-1, 9)
+	return min(d, 5*time.Minute)
+}
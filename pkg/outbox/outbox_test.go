@@ -0,0 +1,222 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOutbox returns an outbox with a controllable clock
+func testOutbox(t *testing.T, sender Sender, config Config) (*Outbox, *time.Time) {
+	t.Helper()
+	config.Sender = sender
+	box, err := New(config)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	box.now = func() time.Time { return now }
+	return box, &now
+}
+
+func TestOutbox_DeliversOnce(t *testing.T) {
+	ctx := context.Background()
+	var sent []string
+	var delivered int
+	box, _ := testOutbox(t, func(ctx context.Context, msg *Message) error {
+		sent = append(sent, msg.IdempotencyKey)
+		return nil
+	}, Config{OnDelivered: func(*Message) { delivered++ }})
+
+	added, err := box.Enqueue(ctx, "order-1", "https://peer.example.com", "message/send", map[string]string{"text": "hi"})
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// Enqueueing the same key again is a no-op
+	added, err = box.Enqueue(ctx, "order-1", "https://peer.example.com", "message/send", nil)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	n, err := box.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = box.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	assert.Equal(t, []string{"order-1"}, sent)
+	assert.Equal(t, 1, delivered)
+	msg, err := box.Store().Get(ctx, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusDelivered, msg.Status)
+	assert.JSONEq(t, `{"text":"hi"}`, string(msg.Params))
+}
+
+func TestOutbox_RetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	attempts := 0
+	var failed error
+	box, now := testOutbox(t, func(ctx context.Context, msg *Message) error {
+		attempts++
+		return errors.New("connection refused")
+	}, Config{
+		MaxAttempts: 3,
+		Backoff:     func(n int) time.Duration { return time.Duration(n) * time.Minute },
+		OnFailure:   func(msg *Message, err error) { failed = err },
+	})
+
+	_, err := box.Enqueue(ctx, "k", "https://peer.example.com", "message/send", nil)
+	require.NoError(t, err)
+
+	_, err = box.Dispatch(ctx)
+	require.NoError(t, err)
+	msg, _ := box.Store().Get(ctx, "k")
+	assert.Equal(t, StatusPending, msg.Status)
+	assert.Equal(t, now.Add(time.Minute), msg.NextAttempt)
+	assert.Equal(t, "connection refused", msg.LastError)
+
+	// Not due yet
+	n, _ := box.Dispatch(ctx)
+	assert.Zero(t, n)
+
+	*now = now.Add(time.Minute)
+	_, _ = box.Dispatch(ctx)
+	*now = now.Add(2 * time.Minute)
+	_, _ = box.Dispatch(ctx)
+
+	assert.Equal(t, 3, attempts)
+	msg, _ = box.Store().Get(ctx, "k")
+	assert.Equal(t, StatusFailed, msg.Status)
+	assert.EqualError(t, failed, "connection refused")
+}
+
+func TestOutbox_PermanentFailure(t *testing.T) {
+	ctx := context.Background()
+	attempts := 0
+	box, _ := testOutbox(t, func(ctx context.Context, msg *Message) error {
+		attempts++
+		return Permanent(errors.New("rejected"))
+	}, Config{})
+
+	_, err := box.Enqueue(ctx, "k", "https://peer.example.com", "message/send", nil)
+	require.NoError(t, err)
+	_, err = box.Dispatch(ctx)
+	require.NoError(t, err)
+
+	msg, _ := box.Store().Get(ctx, "k")
+	assert.Equal(t, StatusFailed, msg.Status)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestOutbox_LeaseBlocksConcurrentClaims(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Unix(1000, 0)
+	_, err := store.Add(ctx, &Message{IdempotencyKey: "k", Status: StatusPending, NextAttempt: now})
+	require.NoError(t, err)
+
+	claimed, err := store.Claim(ctx, now, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	claimed, err = store.Claim(ctx, now.Add(time.Second), now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "leased messages are not claimed twice")
+
+	// An abandoned attempt is retried once the lease expires
+	claimed, err = store.Claim(ctx, now.Add(time.Minute), now.Add(2*time.Minute), 10)
+	require.NoError(t, err)
+	assert.Len(t, claimed, 1)
+}
+
+func TestOutbox_Run(t *testing.T) {
+	delivered := make(chan string, 1)
+	box, err := New(Config{
+		Sender:       func(ctx context.Context, msg *Message) error { return nil },
+		PollInterval: time.Hour,
+		OnDelivered:  func(msg *Message) { delivered <- msg.IdempotencyKey },
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go box.Run(ctx)
+
+	// Enqueue wakes the dispatcher without waiting for the poll interval
+	_, err = box.Enqueue(ctx, "k", "https://peer.example.com", "message/send", nil)
+	require.NoError(t, err)
+	select {
+	case key := <-delivered:
+		assert.Equal(t, "k", key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not delivered")
+	}
+}
+
+func TestTransportSender(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "order-1", r.Header.Get(protocol.IdempotencyKeyHeader))
+		assert.Contains(t, r.Header.Get("Signature-Input"), `"idempotency-key"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(int(status.Load()))
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	}))
+	defer srv.Close()
+
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	send := NewTransportSender(did.AgentDID("did:sage:ethereum:0xsender"), keyPair, nil)
+	msg := &Message{IdempotencyKey: "order-1", URL: srv.URL, Method: "message/send", Params: []byte(`{}`)}
+
+	require.NoError(t, send(context.Background(), msg))
+
+	status.Store(http.StatusServiceUnavailable)
+	err = send(context.Background(), msg)
+	require.Error(t, err)
+	assert.False(t, IsPermanent(err))
+
+	status.Store(http.StatusBadRequest)
+	err = send(context.Background(), msg)
+	assert.True(t, IsPermanent(err))
+}
+
+func TestNewSQLStore_Config(t *testing.T) {
+	_, err := NewSQLStore(nil, StoreConfig{Table: "outbox; DROP TABLE tasks"})
+	assert.Error(t, err)
+
+	store, err := NewSQLStore(nil, StoreConfig{Placeholder: func(n int) string { return "$" + string(rune('0'+n)) }})
+	require.NoError(t, err)
+	assert.Equal(t, "a2a_outbox", store.table)
+	assert.Equal(t, "SELECT $1, $2", store.bind("SELECT ?, ?"))
+}
+
+func TestNew_RequiresSender(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
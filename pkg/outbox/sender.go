@@ -0,0 +1,68 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// NewTransportSender creates a Sender making DID-signed JSON-RPC calls
+// through a transport.DIDHTTPTransport per target URL, created with opts.
+// The idempotency key is sent in the signed protocol.IdempotencyKeyHeader.
+// Client errors (4xx) that are not marked retryable and JSON-RPC errors are
+// permanent.
+func NewTransportSender(agentDID did.AgentDID, keyPair crypto.KeyPair, httpClient *http.Client, opts ...transport.TransportOption) Sender {
+	var transports sync.Map // url -> *transport.DIDHTTPTransport
+	return func(ctx context.Context, msg *Message) error {
+		t, ok := transports.Load(msg.URL)
+		if !ok {
+			t, _ = transports.LoadOrStore(msg.URL, transport.NewDIDHTTPTransport(msg.URL, agentDID, keyPair, httpClient, opts...))
+		}
+		ctx = protocol.WithIdempotencyKey(ctx, msg.IdempotencyKey)
+		_, err := t.(*transport.DIDHTTPTransport).Call(ctx, msg.Method, msg.Params)
+		return classify(err)
+	}
+}
+
+// classify marks errors that retrying cannot fix as permanent
+func classify(err error) error {
+	var httpErr *transport.HTTPError
+	var rpcErr *transport.RPCError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &httpErr) && !httpErr.Retryable && httpErr.StatusCode < 500,
+		errors.As(err, &rpcErr):
+		return Permanent(err)
+	}
+	return err
+}
+
+// retryAfter returns the delay requested by a retryable error
+func retryAfter(err error) time.Duration {
+	d, _ := transport.IsRetryable(err)
+	return d
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package outbox
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists outbox messages
+type Store interface {
+	// Add stores msg unless a message with the same idempotency key
+	// exists, reporting whether it was added
+	Add(ctx context.Context, msg *Message) (bool, error)
+
+	// Claim leases up to limit pending messages due at now whose lease has
+	// expired, setting LeaseUntil to until, oldest first
+	Claim(ctx context.Context, now, until time.Time, limit int) ([]*Message, error)
+
+	// Update saves the delivery state of msg
+	Update(ctx context.Context, msg *Message) error
+
+	// Get returns the message with the idempotency key, or ErrNotFound
+	Get(ctx context.Context, key string) (*Message, error)
+}
+
+// MemoryStore is an in-memory Store. Messages do not survive a restart;
+// use it for tests or with an external persistence layer.
+type MemoryStore struct {
+	mu   sync.Mutex
+	msgs map[string]*Message
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{msgs: make(map[string]*Message)}
+}
+
+// Add implements Store
+func (s *MemoryStore) Add(_ context.Context, msg *Message) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.msgs[msg.IdempotencyKey]; ok {
+		return false, nil
+	}
+	stored := *msg
+	s.msgs[msg.IdempotencyKey] = &stored
+	return true, nil
+}
+
+// Claim implements Store
+func (s *MemoryStore) Claim(_ context.Context, now, until time.Time, limit int) ([]*Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*Message
+	for _, msg := range s.msgs {
+		if msg.Status == StatusPending && !msg.NextAttempt.After(now) && !msg.LeaseUntil.After(now) {
+			due = append(due, msg)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].CreatedAt.Before(due[j].CreatedAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*Message, len(due))
+	for i, msg := range due {
+		msg.LeaseUntil = until
+		c := *msg
+		claimed[i] = &c
+	}
+	return claimed, nil
+}
+
+// Update implements Store
+func (s *MemoryStore) Update(_ context.Context, msg *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.msgs[msg.IdempotencyKey]; !ok {
+		return ErrNotFound
+	}
+	stored := *msg
+	s.msgs[msg.IdempotencyKey] = &stored
+	return nil
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(_ context.Context, key string) (*Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, ok := s.msgs[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *msg
+	return &c, nil
+}
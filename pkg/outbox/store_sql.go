@@ -0,0 +1,212 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/internal/sqltime"
+)
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// StoreConfig configures an SQLStore
+type StoreConfig struct {
+	// Table is the table name (default "a2a_outbox")
+	Table string
+
+	// Placeholder formats the n-th (1-based) query parameter, e.g.
+	// server.DollarPlaceholder for PostgreSQL (default "?")
+	Placeholder func(n int) string
+}
+
+// SQLStore is a Store backed by a database/sql database. It only uses
+// portable SQL, so it works with any driver given the matching
+// Placeholder. Timestamps are stored as Unix nanoseconds.
+type SQLStore struct {
+	db          *sql.DB
+	table       string
+	placeholder func(n int) string
+}
+
+// NewSQLStore creates an outbox store using db. Call CreateTable once to
+// create the table.
+func NewSQLStore(db *sql.DB, config StoreConfig) (*SQLStore, error) {
+	table := config.Table
+	if table == "" {
+		table = "a2a_outbox"
+	}
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %q", table)
+	}
+	placeholder := config.Placeholder
+	if placeholder == nil {
+		placeholder = func(int) string { return "?" }
+	}
+	return &SQLStore{db: db, table: table, placeholder: placeholder}, nil
+}
+
+// CreateTable creates the store's table if it does not exist
+func (s *SQLStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+s.table+` (
+	idempotency_key VARCHAR(255) NOT NULL PRIMARY KEY,
+	url TEXT NOT NULL,
+	method VARCHAR(255) NOT NULL,
+	params TEXT NOT NULL,
+	status VARCHAR(16) NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt BIGINT NOT NULL DEFAULT 0,
+	lease_until BIGINT NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL,
+	created_at BIGINT NOT NULL
+)`)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox table: %w", err)
+	}
+	return nil
+}
+
+// bind replaces the ? parameters of query with the configured placeholder
+func (s *SQLStore) bind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString(s.placeholder(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+const messageColumns = "idempotency_key, url, method, params, status, attempts, next_attempt, lease_until, last_error, created_at"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMessage(row rowScanner) (*Message, error) {
+	var (
+		msg                              Message
+		params, status                   string
+		nextAttempt, leaseUntil, created int64
+	)
+	err := row.Scan(&msg.IdempotencyKey, &msg.URL, &msg.Method, &params, &status, &msg.Attempts,
+		&nextAttempt, &leaseUntil, &msg.LastError, &created)
+	if err != nil {
+		return nil, err
+	}
+	msg.Params = []byte(params)
+	msg.Status = Status(status)
+	msg.NextAttempt = sqltime.FromUnixNano(nextAttempt)
+	msg.LeaseUntil = sqltime.FromUnixNano(leaseUntil)
+	msg.CreatedAt = sqltime.FromUnixNano(created)
+	return &msg, nil
+}
+
+// Add implements Store
+func (s *SQLStore) Add(ctx context.Context, msg *Message) (bool, error) {
+	if _, err := s.Get(ctx, msg.IdempotencyKey); err == nil {
+		return false, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return false, err
+	}
+	_, err := s.db.ExecContext(ctx, s.bind(`INSERT INTO `+s.table+` (`+messageColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		msg.IdempotencyKey, msg.URL, msg.Method, string(msg.Params), string(msg.Status), msg.Attempts,
+		sqltime.UnixNano(msg.NextAttempt), sqltime.UnixNano(msg.LeaseUntil), msg.LastError, sqltime.UnixNano(msg.CreatedAt))
+	if err != nil {
+		// A concurrent Add of the same key hit the primary key
+		if _, getErr := s.Get(ctx, msg.IdempotencyKey); getErr == nil {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to add outbox message: %w", err)
+	}
+	return true, nil
+}
+
+// Claim implements Store. Each candidate is leased with a conditional
+// update, so concurrent dispatchers never claim the same message.
+func (s *SQLStore) Claim(ctx context.Context, now, until time.Time, limit int) ([]*Message, error) {
+	rows, err := s.db.QueryContext(ctx, s.bind(`SELECT `+messageColumns+` FROM `+s.table+`
+	WHERE status = ? AND next_attempt <= ? AND lease_until <= ? ORDER BY created_at LIMIT ?`),
+		string(StatusPending), now.UnixNano(), now.UnixNano(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due outbox messages: %w", err)
+	}
+	var due []*Message
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		due = append(due, msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list due outbox messages: %w", err)
+	}
+
+	var claimed []*Message
+	for _, msg := range due {
+		res, err := s.db.ExecContext(ctx, s.bind(`UPDATE `+s.table+` SET lease_until = ? WHERE idempotency_key = ? AND status = ? AND lease_until = ?`),
+			until.UnixNano(), msg.IdempotencyKey, string(StatusPending), sqltime.UnixNano(msg.LeaseUntil))
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim outbox message: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil || n != 1 {
+			continue // claimed by another dispatcher
+		}
+		msg.LeaseUntil = until
+		claimed = append(claimed, msg)
+	}
+	return claimed, nil
+}
+
+// Update implements Store
+func (s *SQLStore) Update(ctx context.Context, msg *Message) error {
+	res, err := s.db.ExecContext(ctx, s.bind(`UPDATE `+s.table+` SET status = ?, attempts = ?, next_attempt = ?, lease_until = ?, last_error = ? WHERE idempotency_key = ?`),
+		string(msg.Status), msg.Attempts, sqltime.UnixNano(msg.NextAttempt), sqltime.UnixNano(msg.LeaseUntil), msg.LastError, msg.IdempotencyKey)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Get implements Store
+func (s *SQLStore) Get(ctx context.Context, key string) (*Message, error) {
+	row := s.db.QueryRowContext(ctx, s.bind(`SELECT `+messageColumns+` FROM `+s.table+` WHERE idempotency_key = ?`), key)
+	msg, err := scanMessage(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load outbox message: %w", err)
+	}
+	return msg, nil
+}
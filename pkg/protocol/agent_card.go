@@ -41,6 +41,11 @@ type AgentCard struct {
 	// Endpoint is the base URL where the agent's A2A service is accessible
 	Endpoint string `json:"endpoint"`
 
+	// Endpoints are additional service endpoints for specific transports
+	// or capabilities (see EndpointFor). Operations they do not cover are
+	// served at Endpoint.
+	Endpoints []ServiceEndpoint `json:"endpoints,omitempty"`
+
 	// Capabilities lists the operations this agent can perform
 	Capabilities []string `json:"capabilities,omitempty"`
 
@@ -58,6 +63,14 @@ type AgentCard struct {
 
 	// Metadata contains additional custom fields
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// MetadataSchema is an optional JSON Schema the Metadata object must
+	// satisfy (see ValidateMetadata)
+	MetadataSchema *MetadataSchema `json:"metadataSchema,omitempty"`
+
+	// Attestations are third-party statements about the agent, as
+	// attestation tokens (see Attestation)
+	Attestations []string `json:"attestations,omitempty"`
 }
 
 // PublicKeyInfo represents a public key in the Agent Card
@@ -90,6 +103,8 @@ type SignedAgentCard struct {
 	SignedAt int64 `json:"signedAt"`
 }
 
+//go:generate mockgen -write_package_comment=false -destination=../mocks/agent_card_signer.go -package=mocks . AgentCardSigner
+
 // AgentCardSigner signs and verifies Agent Cards
 type AgentCardSigner interface {
 	// SignAgentCard signs an Agent Card with the agent's private key
@@ -156,6 +171,13 @@ func (b *AgentCardBuilder) WithMetadata(key string, value interface{}) *AgentCar
 	return b
 }
 
+// WithAttestations adds attestation tokens issued to the agent by third
+// parties (see Attestation.Sign) to the Agent Card
+func (b *AgentCardBuilder) WithAttestations(tokens ...string) *AgentCardBuilder {
+	b.card.Attestations = append(b.card.Attestations, tokens...)
+	return b
+}
+
 // Build returns the constructed Agent Card
 func (b *AgentCardBuilder) Build() *AgentCard {
 	return b.card
@@ -193,7 +215,12 @@ func (c *AgentCard) Validate() error {
 	if c.CreatedAt == 0 {
 		return ErrInvalidAgentCard{"createdAt is required"}
 	}
-	return nil
+	for _, e := range c.Endpoints {
+		if e.Kind == "" || e.URL == "" {
+			return ErrInvalidAgentCard{"service endpoints require a kind and URL"}
+		}
+	}
+	return c.ValidateMetadata()
 }
 
 // ErrInvalidAgentCard is returned when an Agent Card is invalid
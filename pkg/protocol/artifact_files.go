@@ -0,0 +1,97 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Artifact file transfer. Files are uploaded with PUT to ArtifactFilesPath
+// followed by the file ID, optionally in chunks described by Content-Range,
+// and downloaded with GET, optionally with Range.
+const (
+	// ArtifactFilesPath is the path prefix of artifact file endpoints
+	ArtifactFilesPath = "/artifacts/"
+
+	// UploadOffsetHeader reports the number of bytes of a file the server
+	// has stored, i.e. where an interrupted upload resumes
+	UploadOffsetHeader = "Upload-Offset"
+
+	// UploadCompleteHeader reports whether an upload is complete as a
+	// structured field boolean ("?1" or "?0")
+	UploadCompleteHeader = "Upload-Complete"
+
+	// ReprDigestHeader carries the digest of the complete file (RFC 9530),
+	// independent of the range being transferred
+	ReprDigestHeader = "Repr-Digest"
+)
+
+// ContentRange is a parsed "bytes first-last/total" Content-Range value.
+// Total is -1 when the complete length is unknown ("*").
+type ContentRange struct {
+	First, Last, Total int64
+}
+
+// String formats the range as a Content-Range header value
+func (r ContentRange) String() string {
+	total := "*"
+	if r.Total >= 0 {
+		total = strconv.FormatInt(r.Total, 10)
+	}
+	return fmt.Sprintf("bytes %d-%d/%s", r.First, r.Last, total)
+}
+
+// ParseContentRange parses a Content-Range header value. Unsatisfied ranges
+// ("bytes */total") are returned with First and Last set to -1.
+func ParseContentRange(value string) (ContentRange, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(value), "bytes ")
+	if !ok {
+		return ContentRange{}, fmt.Errorf("invalid Content-Range %q: unit must be bytes", value)
+	}
+	span, size, ok := strings.Cut(spec, "/")
+	if !ok {
+		return ContentRange{}, fmt.Errorf("invalid Content-Range %q", value)
+	}
+
+	r := ContentRange{First: -1, Last: -1, Total: -1}
+	if size != "*" {
+		total, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || total < 0 {
+			return ContentRange{}, fmt.Errorf("invalid Content-Range %q: bad length", value)
+		}
+		r.Total = total
+	}
+	if span == "*" {
+		if r.Total < 0 {
+			return ContentRange{}, fmt.Errorf("invalid Content-Range %q", value)
+		}
+		return r, nil
+	}
+	first, last, ok := strings.Cut(span, "-")
+	if !ok {
+		return ContentRange{}, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	var err1, err2 error
+	r.First, err1 = strconv.ParseInt(first, 10, 64)
+	r.Last, err2 = strconv.ParseInt(last, 10, 64)
+	if err1 != nil || err2 != nil || r.First < 0 || r.Last < r.First || (r.Total >= 0 && r.Last >= r.Total) {
+		return ContentRange{}, fmt.Errorf("invalid Content-Range %q: bad range", value)
+	}
+	return r, nil
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Metadata keys used for artifact integrity
+const (
+	// ArtifactDigestKey holds the digest of the event's artifact parts in
+	// TaskArtifactUpdateEvent metadata, formatted like a Content-Digest
+	// entry: sha-256=:<base64>:
+	ArtifactDigestKey = "sage.artifact.digest"
+
+	// ArtifactSignatureKey holds the base64 signature binding the task ID,
+	// artifact ID and digest
+	ArtifactSignatureKey = "sage.artifact.signature"
+
+	// ArtifactSignerKey holds the DID of the signing agent
+	ArtifactSignerKey = "sage.artifact.signer"
+
+	// FileDigestKey holds the digest of a file part's content in the part
+	// metadata, so files referenced by URI can be checked on download
+	FileDigestKey = "sage.file.digest"
+)
+
+var (
+	// ErrArtifactDigestMismatch is returned when artifact or file content
+	// does not match its recorded digest
+	ErrArtifactDigestMismatch = errors.New("artifact digest mismatch")
+
+	// ErrArtifactDigestMissing is returned when no digest was recorded
+	ErrArtifactDigestMissing = errors.New("artifact digest missing")
+
+	// ErrArtifactSignatureInvalid is returned when the artifact signature is
+	// missing or does not verify
+	ErrArtifactSignatureInvalid = errors.New("artifact signature invalid")
+)
+
+// ArtifactSealer records digests, and optionally signatures, on artifacts
+// emitted by an agent
+type ArtifactSealer struct {
+	// DigestAlgorithm is "sha-256" (default) or "sha-512"
+	DigestAlgorithm string
+
+	// AgentDID and KeyPair sign sealed events when KeyPair is set
+	AgentDID did.AgentDID
+	KeyPair  sagecrypto.KeyPair
+}
+
+// Seal records the digest of event's artifact in the event metadata. Inline
+// file parts additionally get a FileDigestKey entry. Events without an
+// artifact are left unchanged.
+func (s *ArtifactSealer) Seal(event *a2a.TaskArtifactUpdateEvent) error {
+	if event == nil || event.Artifact == nil {
+		return nil
+	}
+	alg := s.DigestAlgorithm
+	if alg == "" {
+		alg = "sha-256"
+	}
+
+	for i, part := range event.Artifact.Parts {
+		fp, ok := filePart(part)
+		if !ok {
+			continue
+		}
+		fb, ok := fp.File.(a2a.FileBytes)
+		if !ok {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(fb.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to decode file part %d: %w", i, err)
+		}
+		if err := SetFileDigest(fp, content, alg); err != nil {
+			return err
+		}
+		event.Artifact.Parts[i] = fp
+	}
+
+	digest, err := ArtifactDigest(event.Artifact, alg)
+	if err != nil {
+		return err
+	}
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]any)
+	}
+	event.Metadata[ArtifactDigestKey] = digest
+
+	if s.KeyPair == nil {
+		return nil
+	}
+	sig, err := signDetached(s.KeyPair, artifactSigningInput(event, digest))
+	if err != nil {
+		return fmt.Errorf("failed to sign artifact: %w", err)
+	}
+	event.Metadata[ArtifactSignatureKey] = sig
+	event.Metadata[ArtifactSignerKey] = string(s.AgentDID)
+	return nil
+}
+
+// ArtifactDigest computes the digest of artifact's parts with alg
+// ("sha-256" or "sha-512"). Parts are hashed in their canonical JSON form,
+// so the digest survives transport.
+func ArtifactDigest(artifact *a2a.Artifact, alg string) (string, error) {
+	data, err := json.Marshal(artifact.Parts)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode artifact parts: %w", err)
+	}
+	// Round trip so the encoding matches what a receiver re-encodes
+	var parts a2a.ContentParts
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return "", fmt.Errorf("failed to decode artifact parts: %w", err)
+	}
+	if data, err = json.Marshal(parts); err != nil {
+		return "", fmt.Errorf("failed to encode artifact parts: %w", err)
+	}
+	return computeDigest(alg, data)
+}
+
+// VerifyArtifactEvent checks the digest recorded on event against its
+// artifact. When publicKey is non-nil the event must also carry a valid
+// signature by that key.
+func VerifyArtifactEvent(event *a2a.TaskArtifactUpdateEvent, publicKey crypto.PublicKey) error {
+	if event == nil || event.Artifact == nil {
+		return ErrArtifactDigestMissing
+	}
+	recorded, _ := event.Metadata[ArtifactDigestKey].(string)
+	if recorded == "" {
+		return ErrArtifactDigestMissing
+	}
+	alg, _, ok := strings.Cut(recorded, "=")
+	if !ok {
+		return fmt.Errorf("%w: malformed digest %q", ErrArtifactDigestMismatch, recorded)
+	}
+	digest, err := ArtifactDigest(event.Artifact, alg)
+	if err != nil {
+		return err
+	}
+	if digest != recorded {
+		return fmt.Errorf("%w: artifact %s", ErrArtifactDigestMismatch, event.Artifact.ID)
+	}
+
+	if publicKey == nil {
+		return nil
+	}
+	sig, _ := event.Metadata[ArtifactSignatureKey].(string)
+	if sig == "" {
+		return fmt.Errorf("%w: no signature", ErrArtifactSignatureInvalid)
+	}
+	return verifyDetached(publicKey, artifactSigningInput(event, digest), sig, ErrArtifactSignatureInvalid)
+}
+
+// SetFileDigest records the digest of a file part's content in its
+// metadata. Use it for file parts referenced by URI before emitting them.
+func SetFileDigest(part *a2a.FilePart, content []byte, alg string) error {
+	if alg == "" {
+		alg = "sha-256"
+	}
+	digest, err := computeDigest(alg, content)
+	if err != nil {
+		return err
+	}
+	if part.Metadata == nil {
+		part.Metadata = make(map[string]any)
+	}
+	part.Metadata[FileDigestKey] = digest
+	return nil
+}
+
+// VerifyFileDigest checks content against the digest recorded on part
+func VerifyFileDigest(part *a2a.FilePart, content []byte) error {
+	recorded, _ := part.Metadata[FileDigestKey].(string)
+	if recorded == "" {
+		return ErrArtifactDigestMissing
+	}
+	alg, _, _ := strings.Cut(recorded, "=")
+	digest, err := computeDigest(alg, content)
+	if err != nil {
+		return err
+	}
+	if digest != recorded {
+		return ErrArtifactDigestMismatch
+	}
+	return nil
+}
+
+// filePart returns part as a *a2a.FilePart copy safe to modify
+func filePart(part a2a.Part) (*a2a.FilePart, bool) {
+	switch p := part.(type) {
+	case *a2a.FilePart:
+		return p, true
+	case a2a.FilePart:
+		return &p, true
+	}
+	return nil, false
+}
+
+func computeDigest(alg string, data []byte) (string, error) {
+	var h hash.Hash
+	switch strings.ToLower(alg) {
+	case "sha-256":
+		h = sha256.New()
+	case "sha-512":
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm: %s", alg)
+	}
+	h.Write(data)
+	return strings.ToLower(alg) + "=:" + base64.StdEncoding.EncodeToString(h.Sum(nil)) + ":", nil
+}
+
+// artifactSigningInput binds the digest to the task and artifact
+func artifactSigningInput(event *a2a.TaskArtifactUpdateEvent, digest string) []byte {
+	return []byte(string(event.TaskID) + "\n" + string(event.Artifact.ID) + "\n" + digest)
+}
+
+// signDetached signs input with signRaw, returning the signature base64
+// encoded
+func signDetached(keyPair sagecrypto.KeyPair, input []byte) (string, error) {
+	sig, err := signRaw(keyPair, input)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// signRaw signs input with the private key of keyPair: Ed25519 signs input
+// directly, ECDSA signs its SHA-256 digest (ASN.1 encoded)
+func signRaw(keyPair sagecrypto.KeyPair, input []byte) ([]byte, error) {
+	signer, ok := keyPair.PrivateKey().(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("private key does not implement crypto.Signer: %T", keyPair.PrivateKey())
+	}
+
+	switch signer.Public().(type) {
+	case ed25519.PublicKey:
+		return signer.Sign(rand.Reader, input, crypto.Hash(0))
+	case *ecdsa.PublicKey:
+		sum := sha256.Sum256(input)
+		return signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", signer.Public())
+	}
+}
+
+// verifyDetached checks a signature made by signDetached, reporting
+// failures as invalid
+func verifyDetached(publicKey crypto.PublicKey, input []byte, sigB64 string, invalid error) error {
+	sig, err := base64.StdEncoding.DecodeString(sigB64)
+	if err != nil {
+		return fmt.Errorf("%w: %v", invalid, err)
+	}
+	return verifyRaw(publicKey, input, sig, invalid)
+}
+
+// verifyRaw checks a signature made by signRaw, reporting failures as
+// invalid
+func verifyRaw(publicKey crypto.PublicKey, input, sig []byte, invalid error) error {
+	valid := false
+	switch pub := publicKey.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(pub, input, sig)
+	case *ecdsa.PublicKey:
+		sum := sha256.Sum256(input)
+		valid = ecdsa.VerifyASN1(pub, sum[:], sig)
+	default:
+		return fmt.Errorf("unsupported public key type: %T", publicKey)
+	}
+	if !valid {
+		return invalid
+	}
+	return nil
+}
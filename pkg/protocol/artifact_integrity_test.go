@@ -0,0 +1,121 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArtifactEvent() *a2a.TaskArtifactUpdateEvent {
+	return &a2a.TaskArtifactUpdateEvent{
+		TaskID: "task-1",
+		Artifact: &a2a.Artifact{
+			ID: "artifact-1",
+			Parts: a2a.ContentParts{
+				a2a.TextPart{Text: "report"},
+				a2a.FilePart{File: a2a.FileBytes{
+					FileMeta: a2a.FileMeta{Name: "data.bin"},
+					Bytes:    base64.StdEncoding.EncodeToString([]byte("payload")),
+				}},
+			},
+		},
+	}
+}
+
+// roundTrip simulates delivery to a client
+func roundTrip(t *testing.T, event *a2a.TaskArtifactUpdateEvent) *a2a.TaskArtifactUpdateEvent {
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	var out a2a.TaskArtifactUpdateEvent
+	require.NoError(t, json.Unmarshal(data, &out))
+	return &out
+}
+
+func TestArtifactSealer_DigestSurvivesTransport(t *testing.T) {
+	event := newArtifactEvent()
+	require.NoError(t, (&ArtifactSealer{}).Seal(event))
+	assert.Contains(t, event.Metadata[ArtifactDigestKey], "sha-256=:")
+
+	received := roundTrip(t, event)
+	assert.NoError(t, VerifyArtifactEvent(received, nil))
+
+	received.Artifact.Parts[0] = &a2a.TextPart{Text: "tampered"}
+	assert.ErrorIs(t, VerifyArtifactEvent(received, nil), ErrArtifactDigestMismatch)
+}
+
+func TestArtifactSealer_FileDigest(t *testing.T) {
+	event := newArtifactEvent()
+	require.NoError(t, (&ArtifactSealer{DigestAlgorithm: "sha-512"}).Seal(event))
+
+	fp, ok := filePart(roundTrip(t, event).Artifact.Parts[1])
+	require.True(t, ok)
+	assert.NoError(t, VerifyFileDigest(fp, []byte("payload")))
+	assert.ErrorIs(t, VerifyFileDigest(fp, []byte("other")), ErrArtifactDigestMismatch)
+	assert.ErrorIs(t, VerifyFileDigest(&a2a.FilePart{}, nil), ErrArtifactDigestMissing)
+}
+
+func TestArtifactSealer_Signature(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		keyPair *mockKeyPair
+	}{
+		{"ecdsa", &mockKeyPair{pubKey: &ecKey.PublicKey, privKey: ecKey, keyType: crypto.KeyTypeSecp256k1}},
+		{"ed25519", &mockKeyPair{pubKey: edPub, privKey: edPriv, keyType: crypto.KeyTypeEd25519}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := newArtifactEvent()
+			sealer := &ArtifactSealer{AgentDID: "did:sage:ethereum:0xabc", KeyPair: tt.keyPair}
+			require.NoError(t, sealer.Seal(event))
+			assert.Equal(t, "did:sage:ethereum:0xabc", event.Metadata[ArtifactSignerKey])
+
+			received := roundTrip(t, event)
+			assert.NoError(t, VerifyArtifactEvent(received, tt.keyPair.pubKey))
+
+			// The signature binds the task
+			received.TaskID = "task-2"
+			assert.ErrorIs(t, VerifyArtifactEvent(received, tt.keyPair.pubKey), ErrArtifactSignatureInvalid)
+		})
+	}
+}
+
+func TestVerifyArtifactEvent_Missing(t *testing.T) {
+	assert.ErrorIs(t, VerifyArtifactEvent(newArtifactEvent(), nil), ErrArtifactDigestMissing)
+
+	event := newArtifactEvent()
+	require.NoError(t, (&ArtifactSealer{}).Seal(event))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyArtifactEvent(event, &ecKey.PublicKey), ErrArtifactSignatureInvalid)
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// attestationType is the typ of the protected header of an attestation
+const attestationType = "a2a-attestation+jws"
+
+var (
+	// ErrAttestationInvalid is returned when an attestation is malformed
+	// or its signature does not verify
+	ErrAttestationInvalid = errors.New("invalid attestation")
+
+	// ErrAttestationExpired is returned when an attestation is no longer
+	// valid
+	ErrAttestationExpired = errors.New("attestation expired")
+
+	// ErrAttestationSubjectMismatch is returned when an attestation is
+	// about another agent
+	ErrAttestationSubjectMismatch = errors.New("attestation is about another agent")
+)
+
+// Attestation is a statement by Attester, a third party such as an
+// auditor, that Claim holds for Subject, e.g. "audited" or
+// "complies:policy-x". Details carries claim-specific data such as a
+// report URL. Times are Unix seconds; Expires is zero for attestations
+// that do not expire. Agents publish attestations in
+// AgentCard.Attestations.
+type Attestation struct {
+	Attester did.AgentDID   `json:"iss"`
+	Subject  did.AgentDID   `json:"sub"`
+	Claim    string         `json:"claim"`
+	Details  map[string]any `json:"details,omitempty"`
+	IssuedAt int64          `json:"iat"`
+	Expires  int64          `json:"exp,omitempty"`
+
+	// Token is the compact JWS the attestation was signed as or parsed
+	// from
+	Token string `json:"-"`
+}
+
+// NewAttestation creates an unsigned attestation by attester that claim
+// holds for subject, valid for ttl or indefinitely if ttl is zero
+func NewAttestation(attester, subject did.AgentDID, claim string, ttl time.Duration) *Attestation {
+	now := time.Now()
+	a := &Attestation{
+		Attester: attester,
+		Subject:  subject,
+		Claim:    claim,
+		IssuedAt: now.Unix(),
+	}
+	if ttl > 0 {
+		a.Expires = now.Add(ttl).Unix()
+	}
+	return a
+}
+
+// WithDetail adds claim-specific data to the attestation
+func (a *Attestation) WithDetail(key string, value any) *Attestation {
+	if a.Details == nil {
+		a.Details = make(map[string]any)
+	}
+	a.Details[key] = value
+	return a
+}
+
+// Sign signs the attestation with the attester's keyPair and stores the
+// result in Token
+func (a *Attestation) Sign(keyPair sagecrypto.KeyPair) error {
+	if a.Claim == "" || a.Subject == "" {
+		return fmt.Errorf("%w: subject and claim are required", ErrAttestationInvalid)
+	}
+	token, err := signCompact(attestationType, a.Attester, keyPair, a)
+	if err != nil {
+		return fmt.Errorf("failed to sign attestation: %w", err)
+	}
+	a.Token = token
+	return nil
+}
+
+// ExpiresAt returns the expiry time, or the zero time if the attestation
+// does not expire
+func (a *Attestation) ExpiresAt() time.Time {
+	if a.Expires == 0 {
+		return time.Time{}
+	}
+	return time.Unix(a.Expires, 0)
+}
+
+// Valid checks that the attestation is about subject and unexpired at now.
+// The signature is not checked.
+func (a *Attestation) Valid(subject did.AgentDID, now time.Time) error {
+	if a.Subject != subject {
+		return fmt.Errorf("%w: %s", ErrAttestationSubjectMismatch, a.Subject)
+	}
+	if a.Expires != 0 && !now.Before(a.ExpiresAt()) {
+		return ErrAttestationExpired
+	}
+	return nil
+}
+
+// ParseAttestation parses an attestation token. The signature is not
+// checked.
+func ParseAttestation(token string) (*Attestation, error) {
+	a, _, _, err := parseAttestation(token)
+	return a, err
+}
+
+// VerifyAttestation parses an attestation token and checks its signature
+// under publicKey
+func VerifyAttestation(token string, publicKey crypto.PublicKey) (*Attestation, error) {
+	return verifyAttestation(token, func(did.AgentDID, string) (crypto.PublicKey, error) {
+		return publicKey, nil
+	})
+}
+
+// VerifyAttestationFrom is like VerifyAttestation, resolving the key of
+// the attester with resolve
+func VerifyAttestationFrom(ctx context.Context, token string, resolve CardKeyResolver) (*Attestation, error) {
+	return verifyAttestation(token, func(attester did.AgentDID, alg string) (crypto.PublicKey, error) {
+		return resolveSignerKey(ctx, resolve, attester, alg)
+	})
+}
+
+// VerifyCardAttestations returns the attestations of card that are about
+// the card's agent, unexpired and signed by their attester, resolving
+// attester keys with resolve. Attestations failing these checks are left
+// out; the error joins their failures.
+func VerifyCardAttestations(ctx context.Context, card *AgentCard, resolve CardKeyResolver) ([]*Attestation, error) {
+	if card == nil {
+		return nil, fmt.Errorf("card cannot be nil")
+	}
+	now := time.Now()
+	var (
+		valid []*Attestation
+		errs  []error
+	)
+	for i, token := range card.Attestations {
+		a, err := VerifyAttestationFrom(ctx, token, resolve)
+		if err == nil {
+			err = a.Valid(did.AgentDID(card.DID), now)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("attestation %d: %w", i, err))
+			continue
+		}
+		valid = append(valid, a)
+	}
+	return valid, errors.Join(errs...)
+}
+
+func verifyAttestation(token string, keyFor func(attester did.AgentDID, alg string) (crypto.PublicKey, error)) (*Attestation, error) {
+	a, alg, sig, err := parseAttestation(token)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := keyFor(a.Attester, alg)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyCompact(token, alg, sig, publicKey, ErrAttestationInvalid); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// parseAttestation decodes an attestation token, returning the
+// attestation, the signature algorithm and the raw signature
+func parseAttestation(token string) (*Attestation, string, []byte, error) {
+	var a Attestation
+	header, sig, err := parseCompact(token, attestationType, &a, ErrAttestationInvalid)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if string(a.Attester) != header.Kid || a.Claim == "" || a.Subject == "" {
+		return nil, "", nil, ErrAttestationInvalid
+	}
+	a.Token = token
+	return &a, header.Alg, sig, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestation(t *testing.T) {
+	auditorKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	auditor := did.AgentDID("did:sage:ethereum:0xauditor")
+	agent := did.AgentDID("did:sage:ethereum:0xagent")
+
+	a := NewAttestation(auditor, agent, "audited", time.Hour).WithDetail("report", "https://audits.example.com/42")
+	require.NoError(t, a.Sign(auditorKey))
+	require.NotEmpty(t, a.Token)
+
+	verified, err := VerifyAttestation(a.Token, auditorKey.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, "audited", verified.Claim)
+	assert.Equal(t, auditor, verified.Attester)
+	assert.Equal(t, "https://audits.example.com/42", verified.Details["report"])
+	require.NoError(t, verified.Valid(agent, time.Now()))
+
+	assert.ErrorIs(t, verified.Valid("did:sage:ethereum:0xother", time.Now()), ErrAttestationSubjectMismatch)
+	assert.ErrorIs(t, verified.Valid(agent, time.Now().Add(2*time.Hour)), ErrAttestationExpired)
+
+	otherKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	_, err = VerifyAttestation(a.Token, otherKey.PublicKey())
+	assert.ErrorIs(t, err, ErrAttestationInvalid)
+
+	// Swapping in another claim breaks the signature
+	other := NewAttestation(auditor, agent, "complies:policy-x", 0)
+	require.NoError(t, other.Sign(auditorKey))
+	parts := strings.Split(a.Token, ".")
+	parts[1] = strings.Split(other.Token, ".")[1]
+	_, err = VerifyAttestation(strings.Join(parts, "."), auditorKey.PublicKey())
+	assert.ErrorIs(t, err, ErrAttestationInvalid)
+
+	// Attestations without ttl do not expire
+	assert.True(t, other.ExpiresAt().IsZero())
+	require.NoError(t, other.Valid(agent, time.Now().Add(24*365*time.Hour)))
+
+	// Receipts are not attestations
+	receipt, err := IssueReceipt(auditor, auditorKey, agent, nil, "")
+	require.NoError(t, err)
+	_, err = ParseAttestation(receipt.Token)
+	assert.ErrorIs(t, err, ErrAttestationInvalid)
+
+	assert.ErrorIs(t, NewAttestation(auditor, agent, "", 0).Sign(auditorKey), ErrAttestationInvalid)
+}
+
+func TestVerifyCardAttestations(t *testing.T) {
+	auditorKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	auditor := did.AgentDID("did:sage:solana:auditor")
+	agent := did.AgentDID("did:sage:solana:agent")
+
+	audited := NewAttestation(auditor, agent, "audited", time.Hour)
+	require.NoError(t, audited.Sign(auditorKey))
+	misdirected := NewAttestation(auditor, "did:sage:solana:other", "audited", time.Hour)
+	require.NoError(t, misdirected.Sign(auditorKey))
+	unknown := NewAttestation("did:sage:solana:unknown", agent, "audited", time.Hour)
+	require.NoError(t, unknown.Sign(auditorKey))
+
+	card := NewAgentCardBuilder(agent, "agent", "https://agent.example.com").
+		WithAttestations(audited.Token, misdirected.Token, unknown.Token).
+		Build()
+
+	resolve := func(ctx context.Context, attester did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+		if attester != auditor {
+			return nil, fmt.Errorf("unknown attester %s", attester)
+		}
+		require.NotNil(t, keyType)
+		assert.Equal(t, did.KeyTypeEd25519, *keyType)
+		return auditorKey.PublicKey(), nil
+	}
+	valid, err := VerifyCardAttestations(context.Background(), card, resolve)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrAttestationSubjectMismatch)
+	require.Len(t, valid, 1)
+	assert.Equal(t, audited.Token, valid[0].Token)
+
+	diff := ComputeCardDiff(&AgentCard{}, card)
+	assert.True(t, diff.Has(CardAttestationAdded))
+	assert.Equal(t, "audited", diff[len(diff)-1].Field)
+}
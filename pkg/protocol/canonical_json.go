@@ -0,0 +1,171 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Canonicalization names, recorded in the "canon" protected header
+// parameter of agent card signatures
+const (
+	// CanonicalizationJCS is the JSON Canonicalization Scheme (RFC 8785)
+	CanonicalizationJCS = "jcs"
+
+	// CanonicalizationLegacy is the encoding/json output used by
+	// signatures made before JCS; such signatures carry no "canon"
+	// parameter. It is only accepted when verifying.
+	CanonicalizationLegacy = ""
+)
+
+// CanonicalJSON encodes v as JSON and canonicalizes it per RFC 8785: object
+// members sorted by the UTF-16 code units of their names, no whitespace,
+// minimal string escaping and numbers in their shortest ECMAScript form.
+// The result does not depend on map ordering, struct field order or the
+// encoder's HTML escaping, so signatures over it survive re-marshaling.
+func CanonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			return fmt.Errorf("number %s cannot be canonicalized: %w", v, err)
+		}
+		s, err := canonicalNumber(f)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case string:
+		writeCanonicalString(buf, v)
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		slices.SortFunc(names, compareUTF16)
+		buf.WriteByte('{')
+		for i, name := range names {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, name)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[name]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value %T", value)
+	}
+	return nil
+}
+
+// canonicalNumber formats f like ECMAScript's Number.prototype.toString
+func canonicalNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("number %v cannot be canonicalized", f)
+	}
+	if f == 0 {
+		return "0", nil // also for -0
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	// Go pads exponents to two digits ("1e-07"); ECMAScript does not
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	if n := len(s); s[n-2] == '0' && (s[n-3] == '-' || s[n-3] == '+') {
+		s = s[:n-2] + s[n-1:]
+	}
+	return s, nil
+}
+
+// writeCanonicalString writes s as a JSON string, escaping only what RFC
+// 8785 requires
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// compareUTF16 orders strings by their UTF-16 code units
+func compareUTF16(a, b string) int {
+	return slices.Compare(utf16.Encode([]rune(a)), utf16.Encode([]rune(b)))
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"sorted members", map[string]any{"b": 1, "a": map[string]any{"d": true, "c": nil}}, `{"a":{"c":null,"d":true},"b":1}`},
+		{"utf-16 order", map[string]any{"\U0001F600": 1, "דּ": 2, "a": 3}, `{"a":3,"` + "\U0001F600" + `":1,"` + "דּ" + `":2}`},
+		{"struct fields", struct {
+			Z string `json:"z"`
+			A string `json:"a"`
+		}{"1", "2"}, `{"a":"2","z":"1"}`},
+		{"no html escaping", "<a href=\"x\">&</a>", `"<a href=\"x\">&</a>"`},
+		{"control characters", "tab\there\u0001\u001f", `"tab\there\u0001\u001f"`},
+		{"non-ascii kept", "€ ", "\"€ \""},
+		{"integers", []any{0, -0.0, 1, -1, 9007199254740991}, `[0,0,1,-1,9007199254740991]`},
+		{"fractions", []any{1.5, 0.1, 1e-6, 100.0}, `[1.5,0.1,0.000001,100]`},
+		{"exponents", []any{1e-7, 1e21, 1.5e300, 5e-324}, `[1e-7,1e+21,1.5e+300,5e-324]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := CanonicalJSON(math.NaN())
+	assert.Error(t, err)
+}
+
+func TestCanonicalJSONStable(t *testing.T) {
+	card := &a2a.AgentCard{
+		Name:        "agent",
+		URL:         "https://agent.example.com/?a=1&b=<2>",
+		Description: "Agent",
+		Skills:      []a2a.AgentSkill{{ID: "s", Name: "skill", Tags: []string{"x"}}},
+	}
+	want, err := CanonicalJSON(card)
+	require.NoError(t, err)
+
+	// Re-marshaling through generic values gives the same bytes
+	data, err := json.Marshal(card)
+	require.NoError(t, err)
+	var generic map[string]any
+	require.NoError(t, json.Unmarshal(data, &generic))
+	got, err := CanonicalJSON(generic)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestVerifyLegacyCardSignature(t *testing.T) {
+	const agentDID = did.AgentDID("did:sage:ethereum:0xagent")
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	card := &a2a.AgentCard{Name: "agent", URL: "https://agent.example.com/?a=1&b=2"}
+
+	// A signature made before canonical JSON: no canon parameter, payload
+	// from encoding/json
+	header, err := json.Marshal(cardSignatureHeader{Alg: "EdDSA", Kid: string(agentDID)})
+	require.NoError(t, err)
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	input, err := cardSigningInput(card, protected, CanonicalizationLegacy)
+	require.NoError(t, err)
+	sig, err := signRaw(kp, input)
+	require.NoError(t, err)
+	card.Signatures = []a2a.AgentCardSignature{{Protected: protected, Signature: base64.RawURLEncoding.EncodeToString(sig)}}
+
+	require.NoError(t, VerifyA2AAgentCard(card, agentDID, kp.PublicKey()))
+	canon, err := CardSignatureCanonicalization(card.Signatures[0])
+	require.NoError(t, err)
+	assert.Equal(t, CanonicalizationLegacy, canon)
+
+	require.NoError(t, SignA2AAgentCard(card, "did:sage:ethereum:0xother", kp))
+	canon, err = CardSignatureCanonicalization(card.Signatures[1])
+	require.NoError(t, err)
+	assert.Equal(t, CanonicalizationJCS, canon)
+
+	// Unknown canonicalizations are rejected
+	header, err = json.Marshal(cardSignatureHeader{Alg: "EdDSA", Kid: string(agentDID), Canon: "c14n"})
+	require.NoError(t, err)
+	card.Signatures = []a2a.AgentCardSignature{{Protected: base64.RawURLEncoding.EncodeToString(header), Signature: card.Signatures[0].Signature}}
+	assert.ErrorIs(t, VerifyA2AAgentCard(card, agentDID, kp.PublicKey()), ErrCardSignatureInvalid)
+}
+
+func TestVerifyLegacyMessageDigest(t *testing.T) {
+	msg := testMessage()
+	msg.Parts = a2a.ContentParts{&a2a.TextPart{Text: "a < b & c"}}
+
+	legacy, err := legacyMessageDigest(msg, "sha-256")
+	require.NoError(t, err)
+	digest, err := MessageDigest(msg, "sha-256")
+	require.NoError(t, err)
+	assert.NotEqual(t, legacy, digest)
+
+	require.NoError(t, VerifyMessageDigest(msg, legacy))
+	require.NoError(t, VerifyMessageDigest(msg, digest))
+
+	msg.Parts = a2a.ContentParts{&a2a.TextPart{Text: "a > b"}}
+	assert.ErrorIs(t, VerifyMessageDigest(msg, legacy), ErrMessageDigestMismatch)
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// CardChangeKind identifies what changed between two Agent Cards
+type CardChangeKind string
+
+const (
+	CardDIDChanged         CardChangeKind = "did_changed"
+	CardNameChanged        CardChangeKind = "name_changed"
+	CardDescriptionChanged CardChangeKind = "description_changed"
+	CardEndpointChanged    CardChangeKind = "endpoint_changed"
+	CardVersionChanged     CardChangeKind = "version_changed"
+	CardExpiryChanged      CardChangeKind = "expiry_changed"
+	CardCapabilityAdded    CardChangeKind = "capability_added"
+	CardCapabilityRemoved  CardChangeKind = "capability_removed"
+	CardKeyAdded           CardChangeKind = "key_added"
+	CardKeyRemoved         CardChangeKind = "key_removed"
+	CardKeyChanged         CardChangeKind = "key_changed"
+	CardMetadataChanged    CardChangeKind = "metadata_changed"
+	CardAttestationAdded   CardChangeKind = "attestation_added"
+	CardAttestationRemoved CardChangeKind = "attestation_removed"
+	CardServiceAdded       CardChangeKind = "service_added"
+	CardServiceRemoved     CardChangeKind = "service_removed"
+	CardServiceChanged     CardChangeKind = "service_changed"
+)
+
+// CardChange is a single difference between two Agent Cards
+type CardChange struct {
+	Kind CardChangeKind `json:"kind"`
+
+	// Field names the changed item: the card field, capability name,
+	// key ID, metadata key, attested claim or service endpoint ID or kind
+	Field string `json:"field"`
+
+	// Old and New are string renderings of the previous and current values
+	// (empty when the item was added or removed)
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// CardDiff is the ordered list of changes between two Agent Cards
+type CardDiff []CardChange
+
+// IsEmpty reports whether the cards are equivalent
+func (d CardDiff) IsEmpty() bool {
+	return len(d) == 0
+}
+
+// Has reports whether the diff contains a change of the given kind
+func (d CardDiff) Has(kind CardChangeKind) bool {
+	for _, c := range d {
+		if c.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeCardDiff returns the structured changes from old to new.
+// A nil card is treated as empty, so diffing against nil lists every field
+// of the other card as added.
+func ComputeCardDiff(old, new *AgentCard) CardDiff {
+	if old == nil {
+		old = &AgentCard{}
+	}
+	if new == nil {
+		new = &AgentCard{}
+	}
+
+	var diff CardDiff
+	field := func(kind CardChangeKind, name, o, n string) {
+		if o != n {
+			diff = append(diff, CardChange{Kind: kind, Field: name, Old: o, New: n})
+		}
+	}
+
+	field(CardDIDChanged, "did", old.DID, new.DID)
+	field(CardNameChanged, "name", old.Name, new.Name)
+	field(CardDescriptionChanged, "description", old.Description, new.Description)
+	field(CardEndpointChanged, "endpoint", old.Endpoint, new.Endpoint)
+	field(CardVersionChanged, "version", old.Version, new.Version)
+	field(CardExpiryChanged, "expiresAt", formatUnix(old.ExpiresAt), formatUnix(new.ExpiresAt))
+
+	// Capabilities
+	oldCaps := toSet(old.Capabilities)
+	newCaps := toSet(new.Capabilities)
+	for _, c := range sortedKeys(newCaps) {
+		if !oldCaps[c] {
+			diff = append(diff, CardChange{Kind: CardCapabilityAdded, Field: c, New: c})
+		}
+	}
+	for _, c := range sortedKeys(oldCaps) {
+		if !newCaps[c] {
+			diff = append(diff, CardChange{Kind: CardCapabilityRemoved, Field: c, Old: c})
+		}
+	}
+
+	// Public keys, matched by ID
+	oldKeys := make(map[string]PublicKeyInfo, len(old.PublicKeys))
+	for _, k := range old.PublicKeys {
+		oldKeys[k.ID] = k
+	}
+	newKeys := make(map[string]PublicKeyInfo, len(new.PublicKeys))
+	for _, k := range new.PublicKeys {
+		newKeys[k.ID] = k
+	}
+	for _, k := range new.PublicKeys {
+		prev, found := oldKeys[k.ID]
+		switch {
+		case !found:
+			diff = append(diff, CardChange{Kind: CardKeyAdded, Field: k.ID, New: k.KeyData})
+		case prev.KeyData != k.KeyData || prev.Type != k.Type:
+			diff = append(diff, CardChange{Kind: CardKeyChanged, Field: k.ID, Old: prev.KeyData, New: k.KeyData})
+		}
+	}
+	for _, k := range old.PublicKeys {
+		if _, found := newKeys[k.ID]; !found {
+			diff = append(diff, CardChange{Kind: CardKeyRemoved, Field: k.ID, Old: k.KeyData})
+		}
+	}
+
+	// Service endpoints, matched by ID or kind
+	oldServices := make(map[string]ServiceEndpoint, len(old.Endpoints))
+	for _, e := range old.Endpoints {
+		oldServices[e.key()] = e
+	}
+	newServices := make(map[string]ServiceEndpoint, len(new.Endpoints))
+	for _, e := range new.Endpoints {
+		newServices[e.key()] = e
+	}
+	for _, e := range new.Endpoints {
+		prev, found := oldServices[e.key()]
+		switch {
+		case !found:
+			diff = append(diff, CardChange{Kind: CardServiceAdded, Field: e.key(), New: e.URL})
+		case prev.URL != e.URL || prev.Kind != e.Kind || !reflect.DeepEqual(prev.Capabilities, e.Capabilities):
+			diff = append(diff, CardChange{Kind: CardServiceChanged, Field: e.key(), Old: prev.URL, New: e.URL})
+		}
+	}
+	for _, e := range old.Endpoints {
+		if _, found := newServices[e.key()]; !found {
+			diff = append(diff, CardChange{Kind: CardServiceRemoved, Field: e.key(), Old: e.URL})
+		}
+	}
+
+	// Metadata
+	metaKeys := make(map[string]bool)
+	for k := range old.Metadata {
+		metaKeys[k] = true
+	}
+	for k := range new.Metadata {
+		metaKeys[k] = true
+	}
+	for _, k := range sortedKeys(metaKeys) {
+		o, oldOK := old.Metadata[k]
+		n, newOK := new.Metadata[k]
+		if oldOK != newOK || !reflect.DeepEqual(o, n) {
+			change := CardChange{Kind: CardMetadataChanged, Field: k}
+			if oldOK {
+				change.Old = fmt.Sprint(o)
+			}
+			if newOK {
+				change.New = fmt.Sprint(n)
+			}
+			diff = append(diff, change)
+		}
+	}
+	if !reflect.DeepEqual(old.MetadataSchema, new.MetadataSchema) {
+		diff = append(diff, CardChange{Kind: CardMetadataChanged, Field: "metadataSchema"})
+	}
+
+	// Attestations, matched by token
+	oldAtts := toSet(old.Attestations)
+	newAtts := toSet(new.Attestations)
+	for _, token := range new.Attestations {
+		if !oldAtts[token] {
+			diff = append(diff, CardChange{Kind: CardAttestationAdded, Field: attestationClaim(token), New: token})
+		}
+	}
+	for _, token := range old.Attestations {
+		if !newAtts[token] {
+			diff = append(diff, CardChange{Kind: CardAttestationRemoved, Field: attestationClaim(token), Old: token})
+		}
+	}
+
+	return diff
+}
+
+// attestationClaim returns the claim of an attestation token, or "" if it
+// cannot be parsed
+func attestationClaim(token string) string {
+	a, err := ParseAttestation(token)
+	if err != nil {
+		return ""
+	}
+	return a.Claim
+}
+
+func formatUnix(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return strconv.FormatInt(ts, 10)
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffTestCard() *AgentCard {
+	return NewAgentCardBuilder("did:sage:ethereum:0xdiff", "Agent", "https://a.example.com").
+		WithCapabilities("chat", "search").
+		WithPublicKey(PublicKeyInfo{ID: "k1", Type: "EcdsaSecp256k1VerificationKey2019", KeyData: "AAA"}).
+		WithMetadata("region", "eu").
+		Build()
+}
+
+func TestComputeCardDiff_NoChanges(t *testing.T) {
+	diff := ComputeCardDiff(diffTestCard(), diffTestCard())
+	assert.True(t, diff.IsEmpty())
+}
+
+func TestComputeCardDiff_Changes(t *testing.T) {
+	old := diffTestCard()
+	new := diffTestCard()
+	new.Endpoint = "https://b.example.com"
+	new.Capabilities = []string{"chat", "translate"}
+	new.PublicKeys = []PublicKeyInfo{
+		{ID: "k1", Type: "EcdsaSecp256k1VerificationKey2019", KeyData: "BBB"},
+		{ID: "k2", Type: "Ed25519VerificationKey2020", KeyData: "CCC"},
+	}
+	new.Metadata = map[string]interface{}{"region": "us"}
+
+	diff := ComputeCardDiff(old, new)
+
+	assert.Equal(t, CardDiff{
+		{Kind: CardEndpointChanged, Field: "endpoint", Old: "https://a.example.com", New: "https://b.example.com"},
+		{Kind: CardCapabilityAdded, Field: "translate", New: "translate"},
+		{Kind: CardCapabilityRemoved, Field: "search", Old: "search"},
+		{Kind: CardKeyChanged, Field: "k1", Old: "AAA", New: "BBB"},
+		{Kind: CardKeyAdded, Field: "k2", New: "CCC"},
+		{Kind: CardMetadataChanged, Field: "region", Old: "eu", New: "us"},
+	}, diff)
+	assert.True(t, diff.Has(CardKeyAdded))
+	assert.False(t, diff.Has(CardKeyRemoved))
+}
+
+func TestComputeCardDiff_KeyRemovedAndNil(t *testing.T) {
+	old := diffTestCard()
+	new := diffTestCard()
+	new.PublicKeys = nil
+
+	diff := ComputeCardDiff(old, new)
+	require.Len(t, diff, 1)
+	assert.Equal(t, CardKeyRemoved, diff[0].Kind)
+
+	assert.True(t, ComputeCardDiff(nil, old).Has(CardEndpointChanged))
+}
+
+func TestCardWatcher_PollEmitsChanges(t *testing.T) {
+	var mu sync.Mutex
+	card := diffTestCard()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = json.NewEncoder(w).Encode(card)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := NewCardWatcher(NewHTTPCardFetcher(server.URL, nil), 10*time.Millisecond)
+	events := watcher.Watch(ctx)
+
+	require.Eventually(t, func() bool { return watcher.Current() != nil }, time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	updated := diffTestCard()
+	updated.Endpoint = "https://migrated.example.com"
+	card = updated
+	mu.Unlock()
+
+	select {
+	case ev := <-events:
+		require.NoError(t, ev.Err)
+		assert.True(t, ev.Diff.Has(CardEndpointChanged))
+		assert.Equal(t, "https://migrated.example.com", ev.New.Endpoint)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for change event")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestCardWatcher_Update(t *testing.T) {
+	calls := 0
+	fetch := func(ctx context.Context) (*AgentCard, error) {
+		calls++
+		return diffTestCard(), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := NewCardWatcher(fetch, 0)
+	events := watcher.Watch(ctx)
+
+	rotated := diffTestCard()
+	rotated.PublicKeys[0].KeyData = "ROTATED"
+	require.NoError(t, watcher.Update(ctx, rotated))
+
+	ev := <-events
+	assert.True(t, ev.Diff.Has(CardKeyChanged))
+	assert.Equal(t, rotated, watcher.Current())
+
+	// Identical push produces no event
+	require.NoError(t, watcher.Update(ctx, rotated))
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected event: %+v", ev)
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestCardWatcher_FetchError(t *testing.T) {
+	fetchErr := errors.New("unreachable")
+	fetch := func(ctx context.Context) (*AgentCard, error) {
+		return nil, fetchErr
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ev := <-NewCardWatcher(fetch, 0).Watch(ctx)
+	assert.ErrorIs(t, ev.Err, fetchErr)
+	assert.Nil(t, ev.New)
+}
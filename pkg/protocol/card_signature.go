@@ -0,0 +1,214 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+var (
+	// ErrCardNotSigned is returned when an agent card carries no signature
+	// by the expected DID
+	ErrCardNotSigned = errors.New("agent card not signed by expected DID")
+
+	// ErrCardSignatureInvalid is returned when an agent card signature does
+	// not verify
+	ErrCardSignatureInvalid = errors.New("agent card signature invalid")
+)
+
+// CardKeyResolver resolves the public key of a card signer. keyType is nil
+// when the signature algorithm does not name an on-chain key type. A
+// verifier.DIDVerifier's ResolvePublicKey method satisfies it.
+type CardKeyResolver func(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error)
+
+// cardSignatureHeader is the protected JWS header of an agent card signature
+type cardSignatureHeader struct {
+	Alg   string `json:"alg"`
+	Kid   string `json:"kid"`
+	Canon string `json:"canon,omitempty"`
+}
+
+// SignA2AAgentCard adds a signature by agentDID to card.Signatures. The
+// signature is a JWS with detached payload (RFC 7515 Appendix F) over the
+// canonical JSON (CanonicalJSON) of the card without its signatures; the
+// protected header names agentDID as kid and CanonicalizationJCS as canon.
+func SignA2AAgentCard(card *a2a.AgentCard, agentDID did.AgentDID, keyPair sagecrypto.KeyPair) error {
+	if card == nil {
+		return fmt.Errorf("card cannot be nil")
+	}
+	if keyPair == nil {
+		return fmt.Errorf("keyPair cannot be nil")
+	}
+	alg, err := cardSignatureAlgorithm(keyPair.PublicKey())
+	if err != nil {
+		return err
+	}
+
+	header, err := json.Marshal(cardSignatureHeader{Alg: alg, Kid: string(agentDID), Canon: CanonicalizationJCS})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JWS header: %w", err)
+	}
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	input, err := cardSigningInput(card, protected, CanonicalizationJCS)
+	if err != nil {
+		return err
+	}
+	sig, err := signRaw(keyPair, input)
+	if err != nil {
+		return fmt.Errorf("failed to sign card: %w", err)
+	}
+
+	card.Signatures = append(card.Signatures, a2a.AgentCardSignature{
+		Protected: protected,
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	})
+	return nil
+}
+
+// VerifyA2AAgentCard checks that card carries a valid signature by
+// expectedDID under publicKey. Signatures by other DIDs are ignored.
+// Signatures made before canonical JSON, without a canon header parameter,
+// are checked against the encoding/json output of the card; use
+// CardSignatureCanonicalization to reject them.
+func VerifyA2AAgentCard(card *a2a.AgentCard, expectedDID did.AgentDID, publicKey crypto.PublicKey) error {
+	return verifyA2AAgentCard(card, expectedDID, func(string) (crypto.PublicKey, error) {
+		return publicKey, nil
+	})
+}
+
+// VerifyA2AAgentCardFrom is like VerifyA2AAgentCard, resolving the key of
+// expectedDID with resolve
+func VerifyA2AAgentCardFrom(ctx context.Context, card *a2a.AgentCard, expectedDID did.AgentDID, resolve CardKeyResolver) error {
+	return verifyA2AAgentCard(card, expectedDID, func(alg string) (crypto.PublicKey, error) {
+		var keyType *did.KeyType
+		if kt, err := keyTypeFromAlgorithm(alg); err == nil {
+			keyType = &kt
+		}
+		publicKey, err := resolve(ctx, expectedDID, keyType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve public key: %w", err)
+		}
+		return publicKey, nil
+	})
+}
+
+func verifyA2AAgentCard(card *a2a.AgentCard, expectedDID did.AgentDID, keyFor func(alg string) (crypto.PublicKey, error)) error {
+	if card == nil {
+		return fmt.Errorf("card cannot be nil")
+	}
+
+	var lastErr error
+	for _, s := range card.Signatures {
+		raw, err := base64.RawURLEncoding.DecodeString(s.Protected)
+		if err != nil {
+			continue
+		}
+		var header cardSignatureHeader
+		if err := json.Unmarshal(raw, &header); err != nil || header.Kid != string(expectedDID) {
+			continue
+		}
+
+		publicKey, err := keyFor(header.Alg)
+		if err != nil {
+			return err
+		}
+		if alg, err := cardSignatureAlgorithm(publicKey); err != nil || alg != header.Alg {
+			lastErr = fmt.Errorf("%w: algorithm %q does not match key", ErrCardSignatureInvalid, header.Alg)
+			continue
+		}
+		sig, err := base64.RawURLEncoding.DecodeString(s.Signature)
+		if err != nil {
+			lastErr = fmt.Errorf("%w: %v", ErrCardSignatureInvalid, err)
+			continue
+		}
+		input, err := cardSigningInput(card, s.Protected, header.Canon)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if lastErr = verifyRaw(publicKey, input, sig, ErrCardSignatureInvalid); lastErr == nil {
+			return nil
+		}
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return fmt.Errorf("%w: %s", ErrCardNotSigned, expectedDID)
+}
+
+// CardSignatureCanonicalization returns the canonicalization a card
+// signature was made over, CanonicalizationJCS or CanonicalizationLegacy
+func CardSignatureCanonicalization(sig a2a.AgentCardSignature) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(sig.Protected)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrCardSignatureInvalid, err)
+	}
+	var header cardSignatureHeader
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrCardSignatureInvalid, err)
+	}
+	return header.Canon, nil
+}
+
+// cardSigningInput returns the JWS signing input for card under the
+// protected header, with the card encoded per canon
+func cardSigningInput(card *a2a.AgentCard, protected, canon string) ([]byte, error) {
+	unsigned := *card
+	unsigned.Signatures = nil
+	var payload []byte
+	var err error
+	switch canon {
+	case CanonicalizationJCS:
+		payload, err = CanonicalJSON(unsigned)
+	case CanonicalizationLegacy:
+		payload, err = json.Marshal(unsigned)
+	default:
+		return nil, fmt.Errorf("%w: unknown canonicalization %q", ErrCardSignatureInvalid, canon)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal card: %w", err)
+	}
+	return []byte(protected + "." + base64.RawURLEncoding.EncodeToString(payload)), nil
+}
+
+// cardSignatureAlgorithm returns the JWS algorithm for publicKey
+func cardSignatureAlgorithm(publicKey crypto.PublicKey) (string, error) {
+	switch pub := publicKey.(type) {
+	case ed25519.PublicKey:
+		return "EdDSA", nil
+	case *ecdsa.PublicKey:
+		if pub.Curve == elliptic.P256() {
+			return "ES256", nil
+		}
+		return "ES256K", nil
+	default:
+		return "", fmt.Errorf("unsupported public key type: %T", publicKey)
+	}
+}
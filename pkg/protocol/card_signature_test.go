@@ -0,0 +1,86 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignA2AAgentCard(t *testing.T) {
+	const agentDID = did.AgentDID("did:sage:ethereum:0xagent")
+	secp, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	ed, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	card := &a2a.AgentCard{Name: "agent", URL: "https://agent.example.com"}
+	require.NoError(t, SignA2AAgentCard(card, agentDID, secp))
+	require.NoError(t, SignA2AAgentCard(card, "did:sage:solana:other", ed))
+	require.Len(t, card.Signatures, 2)
+
+	// Signatures survive a JSON round trip
+	data, err := json.Marshal(card)
+	require.NoError(t, err)
+	var decoded a2a.AgentCard
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, VerifyA2AAgentCard(&decoded, agentDID, secp.PublicKey()))
+	require.NoError(t, VerifyA2AAgentCard(&decoded, "did:sage:solana:other", ed.PublicKey()))
+
+	// Substituted content or key
+	decoded.URL = "https://attacker.example.com"
+	assert.ErrorIs(t, VerifyA2AAgentCard(&decoded, agentDID, secp.PublicKey()), ErrCardSignatureInvalid)
+	assert.ErrorIs(t, VerifyA2AAgentCard(card, agentDID, ed.PublicKey()), ErrCardSignatureInvalid)
+
+	// No signature by the expected DID
+	assert.ErrorIs(t, VerifyA2AAgentCard(card, "did:sage:ethereum:0xunknown", secp.PublicKey()), ErrCardNotSigned)
+	assert.ErrorIs(t, VerifyA2AAgentCard(&a2a.AgentCard{Name: "agent"}, agentDID, secp.PublicKey()), ErrCardNotSigned)
+}
+
+func TestVerifyA2AAgentCardFrom(t *testing.T) {
+	const agentDID = did.AgentDID("did:sage:ethereum:0xagent")
+	kp, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	card := &a2a.AgentCard{Name: "agent"}
+	require.NoError(t, SignA2AAgentCard(card, agentDID, kp))
+
+	var gotType *did.KeyType
+	err = VerifyA2AAgentCardFrom(context.Background(), card, agentDID, func(ctx context.Context, id did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+		assert.Equal(t, agentDID, id)
+		gotType = keyType
+		return kp.PublicKey(), nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, gotType)
+	assert.Equal(t, did.KeyTypeECDSA, *gotType)
+
+	resolveErr := errors.New("not registered")
+	err = VerifyA2AAgentCardFrom(context.Background(), card, agentDID, func(ctx context.Context, id did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+		return nil, resolveErr
+	})
+	assert.ErrorIs(t, err, resolveErr)
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CardFetcher retrieves the current Agent Card of a peer
+type CardFetcher func(ctx context.Context) (*AgentCard, error)
+
+// NewHTTPCardFetcher returns a CardFetcher that GETs a JSON Agent Card from url.
+// If httpClient is nil, http.DefaultClient is used.
+func NewHTTPCardFetcher(url string, httpClient *http.Client) CardFetcher {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return func(ctx context.Context) (*AgentCard, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+		}
+
+		var card AgentCard
+		if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+			return nil, fmt.Errorf("failed to decode agent card: %w", err)
+		}
+		return &card, nil
+	}
+}
+
+// CardChangeEvent is emitted by CardWatcher when a peer's card changes
+// or cannot be fetched
+type CardChangeEvent struct {
+	// Old and New are the previous and current cards
+	Old *AgentCard
+	New *AgentCard
+
+	// Diff lists the changes from Old to New
+	Diff CardDiff
+
+	// Err is set (and the other fields are empty) when fetching failed
+	Err error
+
+	// At is when the change was observed
+	At time.Time
+}
+
+// CardWatcher tracks a peer's Agent Card and emits change events, so clients
+// can react to endpoint migration or key rotation of their counterparties.
+//
+// Cards are obtained by polling the fetcher every interval and, for peers
+// that push updates (webhooks, SSE), by calling Update.
+type CardWatcher struct {
+	fetch    CardFetcher
+	interval time.Duration
+	updates  chan *AgentCard
+
+	mu      sync.RWMutex
+	current *AgentCard
+}
+
+// NewCardWatcher creates a watcher polling fetch every interval.
+// An interval <= 0 disables polling; only the initial fetch and Update are used.
+func NewCardWatcher(fetch CardFetcher, interval time.Duration) *CardWatcher {
+	return &CardWatcher{
+		fetch:    fetch,
+		interval: interval,
+		updates:  make(chan *AgentCard),
+	}
+}
+
+// Current returns the most recently observed card (nil before the first fetch)
+func (w *CardWatcher) Current() *AgentCard {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Watch fetches the baseline card and then emits an event for every change
+// until ctx is cancelled, at which point the channel is closed.
+// The baseline fetch does not produce an event unless it fails.
+func (w *CardWatcher) Watch(ctx context.Context) <-chan CardChangeEvent {
+	events := make(chan CardChangeEvent, 16)
+
+	go func() {
+		defer close(events)
+
+		emit := func(ev CardChangeEvent) bool {
+			select {
+			case events <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		poll := func() bool {
+			if w.fetch == nil {
+				return true
+			}
+			card, err := w.fetch(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return false
+				}
+				return emit(CardChangeEvent{Err: err, At: time.Now()})
+			}
+			return w.observe(card, emit)
+		}
+
+		if !poll() {
+			return
+		}
+
+		var tick <-chan time.Time
+		if w.interval > 0 {
+			ticker := time.NewTicker(w.interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+				if !poll() {
+					return
+				}
+			case card := <-w.updates:
+				if !w.observe(card, emit) {
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// Update feeds a card received out of band (e.g. via a push subscription)
+// into a running Watch loop. It blocks until the loop accepts the card or
+// ctx is cancelled.
+func (w *CardWatcher) Update(ctx context.Context, card *AgentCard) error {
+	if card == nil {
+		return fmt.Errorf("card cannot be nil")
+	}
+	select {
+	case w.updates <- card:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("context error: %w", ctx.Err())
+	}
+}
+
+// observe records card as current and emits an event if it differs from
+// the previous one. The first card observed is the baseline.
+func (w *CardWatcher) observe(card *AgentCard, emit func(CardChangeEvent) bool) bool {
+	w.mu.Lock()
+	prev := w.current
+	w.current = card
+	w.mu.Unlock()
+
+	if prev == nil {
+		return true
+	}
+
+	diff := ComputeCardDiff(prev, card)
+	if diff.IsEmpty() {
+		return true
+	}
+	return emit(CardChangeEvent{Old: prev, New: card, Diff: diff, At: time.Now()})
+}
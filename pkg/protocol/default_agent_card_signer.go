@@ -31,6 +31,8 @@ import (
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
+//go:generate mockgen -write_package_comment=false -destination=../mocks/ethereum_client.go -package=mocks . EthereumClient
+
 // EthereumClient interface for DID resolution
 type EthereumClient interface {
 	ResolvePublicKeyByType(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) (interface{}, error)
@@ -70,8 +72,8 @@ func (s *DefaultAgentCardSigner) SignAgentCard(ctx context.Context, card *AgentC
 		return nil, fmt.Errorf("invalid agent card: %w", err)
 	}
 
-	// Serialize the card to JSON
-	cardJSON, err := json.Marshal(card)
+	// Serialize the card to canonical JSON
+	cardJSON, err := CanonicalJSON(card)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal card: %w", err)
 	}
@@ -79,8 +81,9 @@ func (s *DefaultAgentCardSigner) SignAgentCard(ctx context.Context, card *AgentC
 	// Create JWS header
 	algorithm := getAlgorithmFromKeyType(keyPair.Type())
 	header := map[string]interface{}{
-		"alg": algorithm,
-		"typ": "JWT",
+		"alg":   algorithm,
+		"typ":   "JWT",
+		"canon": CanonicalizationJCS,
 	}
 
 	headerJSON, err := json.Marshal(header)
@@ -216,6 +219,11 @@ func (s *DefaultAgentCardSigner) VerifyAgentCardWithKey(ctx context.Context, sig
 		return fmt.Errorf("payload DID mismatch")
 	}
 
+	// Signed metadata must still satisfy the schemas
+	if err := decodedCard.ValidateMetadata(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -241,7 +249,11 @@ func (s *DefaultAgentCardSigner) extractKeyTypeFromSignature(signature string) (
 		return 0, fmt.Errorf("missing algorithm in header")
 	}
 
-	// Map algorithm to key type
+	return keyTypeFromAlgorithm(alg)
+}
+
+// keyTypeFromAlgorithm maps a JWS algorithm to the on-chain key type
+func keyTypeFromAlgorithm(alg string) (did.KeyType, error) {
 	switch alg {
 	case "ES256K":
 		return did.KeyTypeECDSA, nil
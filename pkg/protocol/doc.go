@@ -48,6 +48,49 @@
 //	    WithExpiresAt(time.Now().Add(365 * 24 * time.Hour)).
 //	    Build()
 //
+// # Metadata Schemas
+//
+// Card metadata is validated by Validate, and so when signing and
+// verifying cards, against JSON Schemas: those registered for well-known
+// keys with RegisterMetadataSchema (SupportedChainsKey is built in) and
+// one attached to the card itself. MetadataAs decodes a key into a typed
+// value:
+//
+//	schema := protocol.MustParseMetadataSchema(`{
+//	    "type": "object",
+//	    "required": ["region"],
+//	    "properties": {"region": {"type": "string", "enum": ["us-west-2", "eu-central-1"]}}
+//	}`)
+//	card, err := b.WithMetadataSchema(schema).BuildValidated()
+//
+//	var chains []string
+//	err = card.MetadataAs(protocol.SupportedChainsKey, &chains)
+//
+// # Service Endpoints
+//
+// Endpoint is the base URL of an agent. Cards may declare further service
+// endpoints by kind (EndpointRPC, EndpointSSE, EndpointArtifacts,
+// EndpointTunnel), optionally dedicated to some capabilities or JSON-RPC
+// methods:
+//
+//	b.WithEndpoint(protocol.EndpointSSE, "https://stream.example.com").
+//	    WithEndpoint(protocol.EndpointRPC, "https://tasks.example.com", "tasks/get")
+//
+// EndpointFor picks the endpoint for an operation, preferring dedicated
+// endpoints, then general ones of the kind, then the base Endpoint;
+// EndpointForMethod routes streaming methods to sse endpoints. The client
+// package's Call routes JSON-RPC requests this way.
+//
+// # Capabilities and Skills
+//
+// SAGE capabilities and a2a.AgentSkill entries describe the same thing.
+// ToA2A and AgentCardFromA2A convert between the two card formats, mapping
+// each capability to a skill with the same ID. Skills declared on the A2A
+// side keep their names, descriptions and tags across a round trip:
+//
+//	a2aCard := card.ToA2A()
+//	skills := protocol.CapabilitySkills(card.Capabilities, a2aCard.Skills)
+//
 // # Signing Agent Cards
 //
 // Sign cards with JWS compact serialization:
@@ -71,6 +114,47 @@
 //
 //	err = signer.VerifyAgentCardWithKey(ctx, signedCard, publicKey)
 //
+// A2A agent cards (a2a.AgentCard) carry JWS signatures in their signatures
+// field. SignA2AAgentCard adds one naming the signer's DID as kid, and
+// VerifyA2AAgentCard checks the card was signed by an expected DID:
+//
+//	err = protocol.SignA2AAgentCard(card, myDID, myKeyPair)
+//	err = protocol.VerifyA2AAgentCard(card, peerDID, peerKey)
+//
+// # Canonical JSON
+//
+// Card signatures and message digests cover the canonical JSON of their
+// content (CanonicalJSON, RFC 8785), so they do not depend on map ordering
+// or on how the content was re-marshaled in between. Card signatures name
+// the scheme in the "canon" protected header parameter. Signatures and
+// digests made before canonicalization still verify against the
+// encoding/json output; CardSignatureCanonicalization tells them apart for
+// callers that want to reject them.
+//
+// # Multi-Key Signing
+//
+// Agents holding keys for several chains can sign one card with all of them
+// (JWS General JSON Serialization) and choose how strict verification is:
+//
+//	multi, err := signer.SignAgentCardMultiKey(ctx, card, secp256k1Key, ed25519Key)
+//	err = signer.VerifyAgentCardMultiKey(ctx, multi, protocol.RequireAllSignatures)
+//
+// SignedCards converts the result to single-key compact JWS cards for peers
+// that only support VerifyAgentCard.
+//
+// # Tracking Card Changes
+//
+// ComputeCardDiff reports structured changes between two cards. CardWatcher
+// polls a peer's card (and accepts pushed updates via Update) and emits an
+// event whenever it changes:
+//
+//	watcher := protocol.NewCardWatcher(protocol.NewHTTPCardFetcher(cardURL, nil), time.Minute)
+//	for ev := range watcher.Watch(ctx) {
+//	    if ev.Diff.Has(protocol.CardEndpointChanged) || ev.Diff.Has(protocol.CardKeyChanged) {
+//	        reconnect(ev.New)
+//	    }
+//	}
+//
 // # Validation
 //
 // Agent Cards provide built-in validation methods:
@@ -90,6 +174,112 @@
 //	    // Agent can execute tasks
 //	}
 //
+// # Priorities and Deadlines
+//
+// The A2A-Priority and A2A-Deadline headers carry a task's priority and
+// absolute deadline. Clients set them through the context; the transport
+// adds the headers and covers them with the request signature:
+//
+//	ctx = protocol.WithPriority(ctx, protocol.PriorityHigh)
+//	ctx = protocol.WithDeadline(ctx, time.Now().Add(30*time.Second))
+//	task, err := client.SendMessage(ctx, params)
+//
+// The X-Request-Budget header carries the time left for a request in
+// milliseconds (FormatBudget). The transport sets it from the deadline of
+// the request context.
+//
+// # Artifact Integrity
+//
+// ArtifactSealer records a digest of each artifact's parts, and optionally a
+// signature binding it to the task, in TaskArtifactUpdateEvent metadata.
+// Inline files also get a per-part digest; set one on files referenced by
+// URI with SetFileDigest. Receivers check events with VerifyArtifactEvent:
+//
+//	if err := protocol.VerifyArtifactEvent(event, producerKey); err != nil {
+//	    // errors.Is(err, protocol.ErrArtifactDigestMismatch) on tampering
+//	}
+//
+// # Extensions
+//
+// DeclareExtension advertises an A2A extension in the agent card. Clients
+// activate extensions for a request with WithExtensions, which the
+// transport sends in the signed X-A2A-Extensions header. Extension data
+// travels in message metadata under the extension URI; AttachExtension can
+// sign it so receivers check its origin with VerifyExtension:
+//
+//	protocol.DeclareExtension(card, a2a.AgentExtension{URI: protocol.SessionKeyExtensionURI, Required: true})
+//
+//	err := protocol.AttachExtension(msg, protocol.SessionKeyExtensionURI, params, myDID, myKeyPair)
+//	signer, err := protocol.VerifyExtension(msg, protocol.SessionKeyExtensionURI, senderKey)
+//
+// # Capability Grants
+//
+// IssueGrant lets an agent grant another agent a capability for a limited
+// time. Clients attach grants to outgoing requests with WithGrants; the
+// transport sends them in the signed A2A-Capability-Grant header:
+//
+//	grant, err := protocol.IssueGrant(adminDID, adminKeyPair, peerDID, "billing", 10*time.Minute)
+//	ctx = protocol.WithGrants(ctx, grant)
+//
+// # Request Receipts
+//
+// IssueReceipt signs a receipt binding the digest of a request body to the
+// requesting DID, the task it created or addressed and the time, as a
+// compact JWS. Servers return it in the A2A-Receipt header; clients check
+// it with VerifyReceipt or VerifyReceiptFrom and Matches, and keep its Token
+// as proof of submission:
+//
+//	receipt, err := protocol.VerifyReceiptFrom(ctx, token, didVerifier.ResolvePublicKey)
+//	err = receipt.Matches(serverDID, myDID, requestBody)
+//
+// # Task Event Polling
+//
+// For networks that block SSE, task events can be long-polled with a signed
+// GET of TaskEventsPath naming the task, the cursor of the previous page
+// and how long to wait. Each TaskEventsPage carries the new events wrapped
+// as TaskEvent, the next cursor, and Final once the stream has ended.
+//
+// # Message Integrity
+//
+// MessageDigest hashes the canonical JSON form of a message's parts and
+// metadata, independently of the HTTP bytes that carried it. Clients send
+// it in the signed A2A-Message-Digest header (see WithMessageDigest), and
+// MessageSealer records it with a detached signature in the message
+// metadata, so stored messages can be checked later:
+//
+//	sealer := &protocol.MessageSealer{AgentDID: myDID, KeyPair: myKeyPair}
+//	err := sealer.Seal(msg)
+//	err = protocol.VerifyMessageFrom(ctx, storedMsg, didVerifier.ResolvePublicKey)
+//
+// # Attestations
+//
+// Third parties such as auditors vouch for an agent with signed
+// attestations of claims like "audited". The agent publishes the tokens in
+// its card; verifiers check them with VerifyCardAttestations against the
+// attesters' DIDs:
+//
+//	a := protocol.NewAttestation(auditorDID, agentDID, "audited", 365*24*time.Hour)
+//	err := a.Sign(auditorKeyPair)
+//	card := protocol.NewAgentCardBuilder(agentDID, "agent", endpoint).WithAttestations(a.Token).Build()
+//	valid, err := protocol.VerifyCardAttestations(ctx, card, didVerifier.ResolvePublicKey)
+//
+// # Task Continuation
+//
+// ExportTaskContext lets the agent holding a task delegate it to another
+// agent: the signed TaskContext carries the task and context IDs, the
+// originating DID and a HistoryDigest of the task history, which the
+// receiving agent checks with VerifyHistory when given the history:
+//
+//	tc, err := protocol.ExportTaskContext(myDID, myKeyPair, task, peerDID, time.Hour)
+//	ctx = protocol.WithTaskContext(ctx, tc)
+//
+// # Peer Exchange
+//
+// Agents list the agents they know with the agents/listPeers method
+// (ListPeersMethod). Each Peer carries its card signed by its DID, which
+// Peer.Verify checks, so peers can be discovered transitively without
+// trusting the agents relaying them.
+//
 // # Supported Algorithms
 //
 // The package supports both ECDSA and Ed25519 signing algorithms:
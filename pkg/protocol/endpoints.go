@@ -0,0 +1,109 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+// Service endpoint kinds
+const (
+	EndpointRPC       = "rpc"       // JSON-RPC requests
+	EndpointSSE       = "sse"       // streaming JSON-RPC methods
+	EndpointArtifacts = "artifacts" // artifact file downloads
+	EndpointTunnel    = "tunnel"    // tunneled connections
+)
+
+// ServiceEndpoint is a service endpoint of an agent besides its base
+// Endpoint. Kind names the transport or service it offers, one of the
+// Endpoint* kinds or a custom one. Capabilities, if set, limits it to those
+// operations: capability names or JSON-RPC methods.
+type ServiceEndpoint struct {
+	// ID identifies the endpoint when several share a kind
+	ID string `json:"id,omitempty"`
+
+	Kind         string   `json:"kind"`
+	URL          string   `json:"url"`
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// key identifies the endpoint within a card
+func (e ServiceEndpoint) key() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Kind
+}
+
+// serves reports whether the endpoint is dedicated to operation
+func (e ServiceEndpoint) serves(operation string) bool {
+	for _, c := range e.Capabilities {
+		if c == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// WithEndpoint declares a service endpoint of the given kind, limited to
+// capabilities if any are given
+func (b *AgentCardBuilder) WithEndpoint(kind, url string, capabilities ...string) *AgentCardBuilder {
+	b.card.Endpoints = append(b.card.Endpoints, ServiceEndpoint{Kind: kind, URL: url, Capabilities: capabilities})
+	return b
+}
+
+// WithServiceEndpoint declares a fully specified service endpoint
+func (b *AgentCardBuilder) WithServiceEndpoint(endpoint ServiceEndpoint) *AgentCardBuilder {
+	b.card.Endpoints = append(b.card.Endpoints, endpoint)
+	return b
+}
+
+// EndpointFor returns the URL to invoke operation, a capability name or
+// JSON-RPC method, over the given kind of endpoint: the first endpoint of
+// that kind dedicated to operation, else the first of that kind without
+// capabilities, else the base Endpoint
+func (c *AgentCard) EndpointFor(kind, operation string) string {
+	fallback := ""
+	for _, e := range c.Endpoints {
+		if e.Kind != kind {
+			continue
+		}
+		if operation != "" && e.serves(operation) {
+			return e.URL
+		}
+		if len(e.Capabilities) == 0 && fallback == "" {
+			fallback = e.URL
+		}
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return c.Endpoint
+}
+
+// EndpointForMethod returns the URL to invoke a JSON-RPC method at, over
+// an sse endpoint for streaming methods and an rpc endpoint otherwise
+func (c *AgentCard) EndpointForMethod(method string) string {
+	return c.EndpointFor(EndpointKindForMethod(method), method)
+}
+
+// EndpointKindForMethod returns the kind of endpoint serving a JSON-RPC
+// method
+func EndpointKindForMethod(method string) string {
+	switch method {
+	case "message/stream", "tasks/resubscribe":
+		return EndpointSSE
+	}
+	return EndpointRPC
+}
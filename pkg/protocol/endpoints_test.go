@@ -0,0 +1,85 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentCard_EndpointFor(t *testing.T) {
+	card := NewAgentCardBuilder("did:sage:ethereum:0xabc", "Agent", "https://agent.example.com").
+		WithEndpoint(EndpointRPC, "https://rpc.example.com").
+		WithEndpoint(EndpointRPC, "https://tasks.example.com", "tasks/get", "task.read").
+		WithEndpoint(EndpointSSE, "https://stream.example.com").
+		Build()
+	require.NoError(t, card.Validate())
+
+	tests := []struct {
+		kind, operation, want string
+	}{
+		{EndpointRPC, "message/send", "https://rpc.example.com"},
+		{EndpointRPC, "tasks/get", "https://tasks.example.com"},
+		{EndpointRPC, "task.read", "https://tasks.example.com"},
+		{EndpointSSE, "message/stream", "https://stream.example.com"},
+		{EndpointArtifacts, "", "https://agent.example.com"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, card.EndpointFor(tt.kind, tt.operation), "%s %s", tt.kind, tt.operation)
+	}
+
+	assert.Equal(t, "https://stream.example.com", card.EndpointForMethod("tasks/resubscribe"))
+	assert.Equal(t, "https://tasks.example.com", card.EndpointForMethod("tasks/get"))
+	assert.Equal(t, "https://rpc.example.com", card.EndpointForMethod("tasks/cancel"))
+}
+
+func TestAgentCard_EndpointForDedicatedOnly(t *testing.T) {
+	// Operations not covered by a dedicated endpoint fall back to the base
+	card := NewAgentCardBuilder("did:sage:ethereum:0xabc", "Agent", "https://agent.example.com").
+		WithEndpoint(EndpointRPC, "https://tasks.example.com", "tasks/get").
+		Build()
+	assert.Equal(t, "https://agent.example.com", card.EndpointForMethod("message/send"))
+	assert.Equal(t, "https://tasks.example.com", card.EndpointForMethod("tasks/get"))
+}
+
+func TestAgentCard_ValidateEndpoints(t *testing.T) {
+	card := NewAgentCardBuilder("did:sage:ethereum:0xabc", "Agent", "https://agent.example.com").
+		WithServiceEndpoint(ServiceEndpoint{Kind: EndpointTunnel}).
+		Build()
+	assert.Error(t, card.Validate())
+}
+
+func TestComputeCardDiff_Endpoints(t *testing.T) {
+	old := &AgentCard{Endpoints: []ServiceEndpoint{
+		{Kind: EndpointRPC, URL: "https://rpc.example.com"},
+		{Kind: EndpointSSE, URL: "https://stream.example.com"},
+	}}
+	new := &AgentCard{Endpoints: []ServiceEndpoint{
+		{Kind: EndpointRPC, URL: "https://rpc2.example.com"},
+		{ID: "files", Kind: EndpointArtifacts, URL: "https://files.example.com"},
+	}}
+
+	diff := ComputeCardDiff(old, new)
+	assert.Equal(t, CardDiff{
+		{Kind: CardServiceChanged, Field: "rpc", Old: "https://rpc.example.com", New: "https://rpc2.example.com"},
+		{Kind: CardServiceAdded, Field: "files", New: "https://files.example.com"},
+		{Kind: CardServiceRemoved, Field: "sse", Old: "https://stream.example.com"},
+	}, diff)
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+// Machine-readable error codes carried in ErrorBody
+const (
+	// ErrorCodeUnauthenticated: the request signature is missing or invalid
+	ErrorCodeUnauthenticated = "unauthenticated"
+
+	// ErrorCodeForbidden: the verified caller is not allowed to proceed
+	ErrorCodeForbidden = "forbidden"
+
+	// ErrorCodeRateLimited: the caller exceeded a rate limit or quota
+	ErrorCodeRateLimited = "rate_limited"
+
+	// ErrorCodeUnavailable: the server cannot handle the request right now,
+	// e.g. because verification capacity or key resolution is exhausted
+	ErrorCodeUnavailable = "unavailable"
+
+	// ErrorCodeInvalidRequest: the request is malformed or too large
+	ErrorCodeInvalidRequest = "invalid_request"
+
+	// ErrorCodeNotFound: the requested resource does not exist
+	ErrorCodeNotFound = "not_found"
+
+	// ErrorCodeConflict: the request conflicts with the resource's state,
+	// e.g. an upload chunk at the wrong offset
+	ErrorCodeConflict = "conflict"
+
+	// ErrorCodeTooEarly: the request arrived in 0-RTT early data, which
+	// can be replayed; it should be sent again after the handshake
+	ErrorCodeTooEarly = "too_early"
+)
+
+// ErrorBody is the JSON body of HTTP error responses written by the server
+// package. Clients should retry only when Retryable is set, waiting at
+// least RetryAfter seconds.
+type ErrorBody struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Retryable  bool   `json:"retryable"`
+	RetryAfter int    `json:"retry_after,omitempty"`
+
+	// Details carries additional structured information, such as a
+	// signature diagnosis returned to trusted callers in debug mode
+	Details any `json:"details,omitempty"`
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ExtensionsHeader lists the extension URIs a client activates for a
+// request, and the server echoes the ones it activated. It is covered by
+// the request signature when set.
+const ExtensionsHeader = "X-A2A-Extensions"
+
+// SAGE extension URIs
+const (
+	// SessionKeyExtensionURI carries session key agreement parameters
+	SessionKeyExtensionURI = "https://github.com/sage-x-project/sage-a2a-go/extensions/session-key/v1"
+
+	// DelegationExtensionURI carries delegation grants from one agent to another
+	DelegationExtensionURI = "https://github.com/sage-x-project/sage-a2a-go/extensions/delegation/v1"
+)
+
+var (
+	// ErrExtensionMissing is returned when a message does not carry an
+	// extension payload
+	ErrExtensionMissing = errors.New("extension payload missing")
+
+	// ErrExtensionSignatureInvalid is returned when an extension payload is
+	// unsigned or its signature does not verify
+	ErrExtensionSignatureInvalid = errors.New("extension signature invalid")
+)
+
+// DeclareExtension adds ext to the card's capabilities, replacing any
+// declaration with the same URI
+func DeclareExtension(card *a2a.AgentCard, ext a2a.AgentExtension) {
+	exts := card.Capabilities.Extensions
+	for i := range exts {
+		if exts[i].URI == ext.URI {
+			exts[i] = ext
+			return
+		}
+	}
+	card.Capabilities.Extensions = append(exts, ext)
+}
+
+// FindExtension returns the card's declaration of uri
+func FindExtension(card *a2a.AgentCard, uri string) (a2a.AgentExtension, bool) {
+	for _, ext := range card.Capabilities.Extensions {
+		if ext.URI == uri {
+			return ext, true
+		}
+	}
+	return a2a.AgentExtension{}, false
+}
+
+// RequiredExtensions returns the URIs of extensions the card marks as
+// required
+func RequiredExtensions(card *a2a.AgentCard) []string {
+	var uris []string
+	for _, ext := range card.Capabilities.Extensions {
+		if ext.Required {
+			uris = append(uris, ext.URI)
+		}
+	}
+	return uris
+}
+
+// FormatExtensions returns the header form of a list of extension URIs
+func FormatExtensions(uris []string) string {
+	return strings.Join(uris, ", ")
+}
+
+// ParseExtensions parses the header form of a list of extension URIs
+func ParseExtensions(s string) []string {
+	var uris []string
+	for _, uri := range strings.Split(s, ",") {
+		if uri = strings.TrimSpace(uri); uri != "" && !slices.Contains(uris, uri) {
+			uris = append(uris, uri)
+		}
+	}
+	return uris
+}
+
+type extensionsKey struct{}
+
+// WithExtensions returns a context whose outgoing A2A requests activate
+// the extensions identified by uris
+func WithExtensions(ctx context.Context, uris ...string) context.Context {
+	return context.WithValue(ctx, extensionsKey{}, uris)
+}
+
+// ExtensionsFromContext returns the extensions set by WithExtensions
+func ExtensionsFromContext(ctx context.Context) ([]string, bool) {
+	uris, ok := ctx.Value(extensionsKey{}).([]string)
+	return uris, ok && len(uris) > 0
+}
+
+// ExtensionPayload is extension data carried in message metadata under the
+// extension URI, optionally signed by the sending agent. Data holds the
+// JSON encoding as a string, so generic metadata decoding cannot alter the
+// signed bytes.
+type ExtensionPayload struct {
+	Data      string       `json:"data"`
+	Signer    did.AgentDID `json:"signer,omitempty"`
+	Signature string       `json:"signature,omitempty"`
+}
+
+// AttachExtension stores data as the payload of extension uri on msg and
+// lists uri in msg.Extensions. When keyPair is set the payload is signed by
+// agentDID, binding it to the extension URI and message ID.
+func AttachExtension(msg *a2a.Message, uri string, data any, agentDID did.AgentDID, keyPair sagecrypto.KeyPair) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode extension data: %w", err)
+	}
+	payload := ExtensionPayload{Data: string(raw)}
+	if keyPair != nil {
+		sig, err := signDetached(keyPair, extensionSigningInput(msg, uri, raw))
+		if err != nil {
+			return fmt.Errorf("failed to sign extension payload: %w", err)
+		}
+		payload.Signer = agentDID
+		payload.Signature = sig
+	}
+
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]any)
+	}
+	msg.Metadata[uri] = payload
+	if !slices.Contains(msg.Extensions, uri) {
+		msg.Extensions = append(msg.Extensions, uri)
+	}
+	return nil
+}
+
+// GetExtension returns the payload of extension uri on msg, decoding its
+// data into out when out is non-nil. It does not check the signature.
+func GetExtension(msg *a2a.Message, uri string, out any) (*ExtensionPayload, error) {
+	value, ok := msg.Metadata[uri]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrExtensionMissing, uri)
+	}
+
+	// Decoded messages hold a generic map, so round trip through JSON
+	var payload ExtensionPayload
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode extension payload: %w", err)
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode extension payload: %w", err)
+	}
+	if out != nil {
+		if err := json.Unmarshal([]byte(payload.Data), out); err != nil {
+			return nil, fmt.Errorf("failed to decode extension data: %w", err)
+		}
+	}
+	return &payload, nil
+}
+
+// VerifyExtension checks that the payload of extension uri on msg is signed
+// by publicKey, returning the signer DID recorded on the payload
+func VerifyExtension(msg *a2a.Message, uri string, publicKey crypto.PublicKey) (did.AgentDID, error) {
+	payload, err := GetExtension(msg, uri, nil)
+	if err != nil {
+		return "", err
+	}
+	if payload.Signature == "" {
+		return "", fmt.Errorf("%w: no signature", ErrExtensionSignatureInvalid)
+	}
+	if err := verifyDetached(publicKey, extensionSigningInput(msg, uri, []byte(payload.Data)), payload.Signature, ErrExtensionSignatureInvalid); err != nil {
+		return "", err
+	}
+	return payload.Signer, nil
+}
+
+// extensionSigningInput binds extension data to its URI and message
+func extensionSigningInput(msg *a2a.Message, uri string, data []byte) []byte {
+	return []byte(uri + "\n" + msg.ID + "\n" + string(data))
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sessionParams struct {
+	KEM   string `json:"kem"`
+	Nonce int64  `json:"nonce"`
+}
+
+func TestDeclareExtension(t *testing.T) {
+	card := &a2a.AgentCard{}
+	DeclareExtension(card, a2a.AgentExtension{URI: SessionKeyExtensionURI})
+	DeclareExtension(card, a2a.AgentExtension{URI: DelegationExtensionURI})
+	DeclareExtension(card, a2a.AgentExtension{URI: SessionKeyExtensionURI, Required: true})
+
+	require.Len(t, card.Capabilities.Extensions, 2)
+	ext, ok := FindExtension(card, SessionKeyExtensionURI)
+	require.True(t, ok)
+	assert.True(t, ext.Required)
+	assert.Equal(t, []string{SessionKeyExtensionURI}, RequiredExtensions(card))
+
+	_, ok = FindExtension(card, "https://unknown.example/ext")
+	assert.False(t, ok)
+}
+
+func TestExtensionsHeader(t *testing.T) {
+	uris := ParseExtensions(" a, b,,a ")
+	assert.Equal(t, []string{"a", "b"}, uris)
+	assert.Equal(t, "a, b", FormatExtensions(uris))
+
+	_, ok := ExtensionsFromContext(context.Background())
+	assert.False(t, ok)
+	got, ok := ExtensionsFromContext(WithExtensions(context.Background(), "a"))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, got)
+}
+
+func TestAttachExtension_SignedRoundTrip(t *testing.T) {
+	keyPair, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hello"})
+	params := sessionParams{KEM: "x25519", Nonce: 1 << 60}
+	require.NoError(t, AttachExtension(msg, SessionKeyExtensionURI, params, "did:sage:ethereum:0xabc", keyPair))
+	require.NoError(t, AttachExtension(msg, SessionKeyExtensionURI, params, "did:sage:ethereum:0xabc", keyPair))
+	assert.Equal(t, []string{SessionKeyExtensionURI}, msg.Extensions)
+
+	// Decode as a receiver would
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var received a2a.Message
+	require.NoError(t, json.Unmarshal(data, &received))
+
+	var got sessionParams
+	_, err = GetExtension(&received, SessionKeyExtensionURI, &got)
+	require.NoError(t, err)
+	assert.Equal(t, params, got)
+
+	signer, err := VerifyExtension(&received, SessionKeyExtensionURI, keyPair.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, "did:sage:ethereum:0xabc", string(signer))
+
+	// Moving the payload to another message breaks the signature
+	other := a2a.NewMessage(a2a.MessageRoleUser)
+	other.Metadata = received.Metadata
+	_, err = VerifyExtension(other, SessionKeyExtensionURI, keyPair.PublicKey())
+	assert.ErrorIs(t, err, ErrExtensionSignatureInvalid)
+}
+
+func TestVerifyExtension_Errors(t *testing.T) {
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser)
+	_, err = VerifyExtension(msg, DelegationExtensionURI, keyPair.PublicKey())
+	assert.ErrorIs(t, err, ErrExtensionMissing)
+
+	require.NoError(t, AttachExtension(msg, DelegationExtensionURI, map[string]any{"scope": "read"}, "", nil))
+	_, err = VerifyExtension(msg, DelegationExtensionURI, keyPair.PublicKey())
+	assert.ErrorIs(t, err, ErrExtensionSignatureInvalid)
+}
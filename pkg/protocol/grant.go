@@ -0,0 +1,188 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// GrantHeader carries capability grants on a request, as a comma-separated
+// list of encoded grants. It must be covered by the request signature.
+const GrantHeader = "A2A-Capability-Grant"
+
+var (
+	// ErrGrantInvalid is returned when a grant is malformed or its
+	// signature does not verify
+	ErrGrantInvalid = errors.New("invalid capability grant")
+
+	// ErrGrantExpired is returned when a grant is no longer valid
+	ErrGrantExpired = errors.New("capability grant expired")
+
+	// ErrGrantSubjectMismatch is returned when a grant is presented by an
+	// agent other than its subject
+	ErrGrantSubjectMismatch = errors.New("capability grant issued to another agent")
+)
+
+// GrantClockSkew is how far in the future a grant's issued-at time may
+// lie, to tolerate issuer clocks ahead of the verifier's
+const GrantClockSkew = 30 * time.Second
+
+// CapabilityGrant is a short-lived, signed statement by Issuer that
+// Subject holds Capability until Expires. Times are Unix seconds.
+type CapabilityGrant struct {
+	Issuer     did.AgentDID `json:"iss"`
+	Subject    did.AgentDID `json:"sub"`
+	Capability string       `json:"cap"`
+	IssuedAt   int64        `json:"iat"`
+	Expires    int64        `json:"exp"`
+	Signature  string       `json:"sig,omitempty"`
+}
+
+// IssueGrant creates a grant of capability to subject valid for ttl,
+// signed by issuer
+func IssueGrant(issuer did.AgentDID, keyPair sagecrypto.KeyPair, subject did.AgentDID, capability string, ttl time.Duration) (*CapabilityGrant, error) {
+	if capability == "" {
+		return nil, fmt.Errorf("%w: capability is required", ErrGrantInvalid)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("%w: ttl must be positive", ErrGrantInvalid)
+	}
+	now := time.Now()
+	g := &CapabilityGrant{
+		Issuer:     issuer,
+		Subject:    subject,
+		Capability: capability,
+		IssuedAt:   now.Unix(),
+		Expires:    now.Add(ttl).Unix(),
+	}
+	if err := g.Sign(keyPair); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Sign sets the grant's signature by keyPair over its other fields
+func (g *CapabilityGrant) Sign(keyPair sagecrypto.KeyPair) error {
+	sig, err := signDetached(keyPair, g.signingInput())
+	if err != nil {
+		return fmt.Errorf("failed to sign capability grant: %w", err)
+	}
+	g.Signature = sig
+	return nil
+}
+
+// signingInput binds every field of the grant except the signature
+func (g *CapabilityGrant) signingInput() []byte {
+	return []byte(strings.Join([]string{
+		"a2a-capability-grant",
+		string(g.Issuer),
+		string(g.Subject),
+		g.Capability,
+		strconv.FormatInt(g.IssuedAt, 10),
+		strconv.FormatInt(g.Expires, 10),
+	}, "\n"))
+}
+
+// ExpiresAt returns the expiry time
+func (g *CapabilityGrant) ExpiresAt() time.Time {
+	return time.Unix(g.Expires, 0)
+}
+
+// TTL returns the validity period the grant was issued with
+func (g *CapabilityGrant) TTL() time.Duration {
+	return time.Duration(g.Expires-g.IssuedAt) * time.Second
+}
+
+// Verify checks that the grant is signed by issuerKey, names subject and
+// is valid at now. Grants issued more than GrantClockSkew after now are
+// invalid.
+func (g *CapabilityGrant) Verify(issuerKey crypto.PublicKey, subject did.AgentDID, now time.Time) error {
+	if g.Capability == "" || g.Expires <= g.IssuedAt {
+		return ErrGrantInvalid
+	}
+	if time.Unix(g.IssuedAt, 0).After(now.Add(GrantClockSkew)) {
+		return fmt.Errorf("%w: issued in the future", ErrGrantInvalid)
+	}
+	if g.Subject != subject {
+		return ErrGrantSubjectMismatch
+	}
+	if !now.Before(g.ExpiresAt()) {
+		return ErrGrantExpired
+	}
+	return verifyDetached(issuerKey, g.signingInput(), g.Signature, ErrGrantInvalid)
+}
+
+// EncodeGrant encodes a grant for GrantHeader as unpadded base64url JSON
+func EncodeGrant(g *CapabilityGrant) string {
+	data, _ := json.Marshal(g) // cannot fail for this type
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// FormatGrants formats grants as a GrantHeader value
+func FormatGrants(grants []*CapabilityGrant) string {
+	encoded := make([]string, len(grants))
+	for i, g := range grants {
+		encoded[i] = EncodeGrant(g)
+	}
+	return strings.Join(encoded, ", ")
+}
+
+// ParseGrants parses a GrantHeader value. Signatures are not checked.
+func ParseGrants(header string) ([]*CapabilityGrant, error) {
+	var grants []*CapabilityGrant
+	for _, item := range strings.Split(header, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		data, err := base64.RawURLEncoding.DecodeString(item)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrGrantInvalid, err)
+		}
+		var g CapabilityGrant
+		if err := json.Unmarshal(data, &g); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrGrantInvalid, err)
+		}
+		grants = append(grants, &g)
+	}
+	return grants, nil
+}
+
+type grantsKey struct{}
+
+// WithGrants returns a context whose outgoing A2A requests present grants
+func WithGrants(ctx context.Context, grants ...*CapabilityGrant) context.Context {
+	return context.WithValue(ctx, grantsKey{}, grants)
+}
+
+// GrantsFromContext returns the grants set by WithGrants
+func GrantsFromContext(ctx context.Context) ([]*CapabilityGrant, bool) {
+	grants, ok := ctx.Value(grantsKey{}).([]*CapabilityGrant)
+	return grants, ok && len(grants) > 0
+}
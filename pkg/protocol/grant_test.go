@@ -0,0 +1,92 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilityGrant(t *testing.T) {
+	issuerKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	issuer := did.AgentDID("did:sage:ethereum:0xissuer")
+	subject := did.AgentDID("did:sage:ethereum:0xsubject")
+
+	g, err := IssueGrant(issuer, issuerKey, subject, "admin", 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, g.TTL())
+	require.NoError(t, g.Verify(issuerKey.PublicKey(), subject, time.Now()))
+
+	assert.ErrorIs(t, g.Verify(issuerKey.PublicKey(), "did:sage:ethereum:0xother", time.Now()), ErrGrantSubjectMismatch)
+	assert.ErrorIs(t, g.Verify(issuerKey.PublicKey(), subject, time.Now().Add(11*time.Minute)), ErrGrantExpired)
+
+	otherKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	assert.ErrorIs(t, g.Verify(otherKey.PublicKey(), subject, time.Now()), ErrGrantInvalid)
+
+	tampered := *g
+	tampered.Capability = "root"
+	assert.ErrorIs(t, tampered.Verify(issuerKey.PublicKey(), subject, time.Now()), ErrGrantInvalid)
+
+	_, err = IssueGrant(issuer, issuerKey, subject, "", time.Minute)
+	assert.ErrorIs(t, err, ErrGrantInvalid)
+
+	// Grants dated in the future are rejected beyond the clock skew
+	future := *g
+	future.IssuedAt = time.Now().Add(time.Hour).Unix()
+	future.Expires = future.IssuedAt + 600
+	require.NoError(t, future.Sign(issuerKey))
+	assert.ErrorIs(t, future.Verify(issuerKey.PublicKey(), subject, time.Now()), ErrGrantInvalid)
+	require.NoError(t, future.Verify(issuerKey.PublicKey(), subject, time.Now().Add(time.Hour)))
+
+	skewed := *g
+	skewed.IssuedAt = time.Now().Add(GrantClockSkew / 2).Unix()
+	require.NoError(t, skewed.Sign(issuerKey))
+	require.NoError(t, skewed.Verify(issuerKey.PublicKey(), subject, time.Now()))
+}
+
+func TestFormatParseGrants(t *testing.T) {
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	a, err := IssueGrant("did:sage:solana:issuer", kp, "did:sage:solana:subject", "read", time.Minute)
+	require.NoError(t, err)
+	b, err := IssueGrant("did:sage:solana:issuer", kp, "did:sage:solana:subject", "write", time.Minute)
+	require.NoError(t, err)
+
+	parsed, err := ParseGrants(FormatGrants([]*CapabilityGrant{a, b}))
+	require.NoError(t, err)
+	assert.Equal(t, []*CapabilityGrant{a, b}, parsed)
+	require.NoError(t, parsed[1].Verify(kp.PublicKey(), "did:sage:solana:subject", time.Now()))
+
+	_, err = ParseGrants("not base64!")
+	assert.ErrorIs(t, err, ErrGrantInvalid)
+
+	ctx := WithGrants(context.Background(), a)
+	grants, ok := GrantsFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []*CapabilityGrant{a}, grants)
+	_, ok = GrantsFromContext(context.Background())
+	assert.False(t, ok)
+}
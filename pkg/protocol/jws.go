@@ -0,0 +1,118 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// compactHeader is the protected header of the compact JWS statements
+// signed by agents, such as receipts and attestations
+type compactHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// signCompact signs claims by kid with keyPair as a compact JWS of type typ
+func signCompact(typ string, kid did.AgentDID, keyPair sagecrypto.KeyPair, claims any) (string, error) {
+	if keyPair == nil {
+		return "", fmt.Errorf("keyPair cannot be nil")
+	}
+	alg, err := cardSignatureAlgorithm(keyPair.PublicKey())
+	if err != nil {
+		return "", err
+	}
+	header, err := json.Marshal(compactHeader{Alg: alg, Kid: string(kid), Typ: typ})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWS header: %w", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWS payload: %w", err)
+	}
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sig, err := signRaw(keyPair, []byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// parseCompact decodes a compact JWS of type typ into claims, returning
+// its header and raw signature. Errors wrap invalid.
+func parseCompact(token, typ string, claims any, invalid error) (compactHeader, []byte, error) {
+	var header compactHeader
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return header, nil, fmt.Errorf("%w: expected 3 JWS parts, got %d", invalid, len(parts))
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return header, nil, fmt.Errorf("%w: %v", invalid, err)
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return header, nil, fmt.Errorf("%w: %v", invalid, err)
+	}
+	if header.Typ != typ {
+		return header, nil, fmt.Errorf("%w: unexpected type %q", invalid, header.Typ)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return header, nil, fmt.Errorf("%w: %v", invalid, err)
+	}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return header, nil, fmt.Errorf("%w: %v", invalid, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return header, nil, fmt.Errorf("%w: %v", invalid, err)
+	}
+	return header, sig, nil
+}
+
+// verifyCompact checks the signature of a compact JWS parsed with
+// parseCompact under publicKey
+func verifyCompact(token string, alg string, sig []byte, publicKey crypto.PublicKey, invalid error) error {
+	if keyAlg, err := cardSignatureAlgorithm(publicKey); err != nil || keyAlg != alg {
+		return fmt.Errorf("%w: algorithm %q does not match key", invalid, alg)
+	}
+	input := token[:strings.LastIndexByte(token, '.')]
+	return verifyRaw(publicKey, []byte(input), sig, invalid)
+}
+
+// resolveSignerKey resolves the key signerDID signed with under alg
+func resolveSignerKey(ctx context.Context, resolve CardKeyResolver, signerDID did.AgentDID, alg string) (crypto.PublicKey, error) {
+	var keyType *did.KeyType
+	if kt, err := keyTypeFromAlgorithm(alg); err == nil {
+		keyType = &kt
+	}
+	publicKey, err := resolve(ctx, signerDID, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve public key: %w", err)
+	}
+	return publicKey, nil
+}
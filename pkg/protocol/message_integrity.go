@@ -0,0 +1,252 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// MessageDigestHeader carries the MessageDigest of the message sent by a
+// message/send or message/stream request. It must be covered by the
+// request signature.
+const MessageDigestHeader = "A2A-Message-Digest"
+
+// Metadata keys used for message integrity
+const (
+	// MessageDigestKey holds the MessageDigest of a sealed message
+	MessageDigestKey = "sage.message.digest"
+
+	// MessageSignatureKey holds the base64 signature binding the signer
+	// DID and the digest
+	MessageSignatureKey = "sage.message.signature"
+
+	// MessageSignerKey holds the DID of the signing agent
+	MessageSignerKey = "sage.message.signer"
+)
+
+var (
+	// ErrMessageDigestMismatch is returned when a message does not match
+	// its recorded digest
+	ErrMessageDigestMismatch = errors.New("message digest mismatch")
+
+	// ErrMessageDigestMissing is returned when no digest was recorded
+	ErrMessageDigestMissing = errors.New("message digest missing")
+
+	// ErrMessageSignatureInvalid is returned when the message signature is
+	// missing or does not verify
+	ErrMessageSignatureInvalid = errors.New("message signature invalid")
+)
+
+// MessageDigest computes the digest of msg with alg ("sha-256" or
+// "sha-512"), formatted like a Content-Digest entry. It covers every field
+// of the message, with the metadata except the message integrity keys, in
+// canonical JSON form (CanonicalJSON), so the digest survives transport
+// and storage.
+func MessageDigest(msg *a2a.Message, alg string) (string, error) {
+	content, err := messageContent(msg)
+	if err != nil {
+		return "", err
+	}
+	data, err := CanonicalJSON(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode message: %w", err)
+	}
+	return computeDigest(digestAlgorithm(alg), data)
+}
+
+// VerifyMessageDigest checks msg against digest, as computed by
+// MessageDigest with the algorithm it names. Digests made before canonical
+// JSON, over the encoding/json output, are accepted too.
+func VerifyMessageDigest(msg *a2a.Message, digest string) error {
+	if digest == "" {
+		return ErrMessageDigestMissing
+	}
+	alg, _, ok := strings.Cut(digest, "=")
+	if !ok {
+		return fmt.Errorf("%w: malformed digest %q", ErrMessageDigestMismatch, digest)
+	}
+	computed, err := MessageDigest(msg, alg)
+	if err != nil {
+		return err
+	}
+	if computed == digest {
+		return nil
+	}
+	if legacy, err := legacyMessageDigest(msg, alg); err == nil && legacy == digest {
+		return nil
+	}
+	return fmt.Errorf("%w: message %s", ErrMessageDigestMismatch, msg.ID)
+}
+
+// legacyMessageDigest computes the digest of msg as MessageDigest did
+// before canonical JSON
+func legacyMessageDigest(msg *a2a.Message, alg string) (string, error) {
+	content, err := messageContent(msg)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode message: %w", err)
+	}
+	// Round trip through generic values so the encoding does not depend
+	// on the Go types the message was built from
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return "", fmt.Errorf("failed to decode message: %w", err)
+	}
+	if data, err = json.Marshal(generic); err != nil {
+		return "", fmt.Errorf("failed to encode message: %w", err)
+	}
+	return computeDigest(digestAlgorithm(alg), data)
+}
+
+// messageContent returns the part of msg covered by its digest
+func messageContent(msg *a2a.Message) (any, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+	metadata := make(map[string]any, len(msg.Metadata))
+	for k, v := range msg.Metadata {
+		if k != MessageDigestKey && k != MessageSignatureKey && k != MessageSignerKey {
+			metadata[k] = v
+		}
+	}
+	return struct {
+		ID             string           `json:"messageId"`
+		Role           a2a.MessageRole  `json:"role"`
+		ContextID      string           `json:"contextId,omitempty"`
+		TaskID         a2a.TaskID       `json:"taskId,omitempty"`
+		ReferenceTasks []a2a.TaskID     `json:"referenceTaskIds,omitempty"`
+		Extensions     []string         `json:"extensions,omitempty"`
+		Parts          a2a.ContentParts `json:"parts"`
+		Metadata       map[string]any   `json:"metadata,omitempty"`
+	}{msg.ID, msg.Role, msg.ContextID, msg.TaskID, msg.ReferenceTasks, msg.Extensions, msg.Parts, metadata}, nil
+}
+
+// digestAlgorithm returns alg, defaulting to sha-256
+func digestAlgorithm(alg string) string {
+	if alg == "" {
+		return "sha-256"
+	}
+	return alg
+}
+
+// MessageSealer records digests and detached signatures on messages, so
+// their content can be verified after they are persisted
+type MessageSealer struct {
+	// DigestAlgorithm is "sha-256" (default) or "sha-512"
+	DigestAlgorithm string
+
+	// AgentDID and KeyPair sign sealed messages
+	AgentDID did.AgentDID
+	KeyPair  sagecrypto.KeyPair
+}
+
+// Seal records the digest of msg and a signature over it in the message
+// metadata. Parts and other metadata must not change afterwards.
+func (s *MessageSealer) Seal(msg *a2a.Message) error {
+	if s.KeyPair == nil {
+		return fmt.Errorf("keyPair cannot be nil")
+	}
+	digest, err := MessageDigest(msg, s.DigestAlgorithm)
+	if err != nil {
+		return err
+	}
+	sig, err := signDetached(s.KeyPair, messageSigningInput(s.AgentDID, digest))
+	if err != nil {
+		return fmt.Errorf("failed to sign message: %w", err)
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]any)
+	}
+	msg.Metadata[MessageDigestKey] = digest
+	msg.Metadata[MessageSignatureKey] = sig
+	msg.Metadata[MessageSignerKey] = string(s.AgentDID)
+	return nil
+}
+
+// MessageSigner returns the DID that sealed msg, if any. It is not
+// verified.
+func MessageSigner(msg *a2a.Message) (did.AgentDID, bool) {
+	signer, _ := msg.Metadata[MessageSignerKey].(string)
+	return did.AgentDID(signer), signer != ""
+}
+
+// VerifyMessage checks the digest recorded on a sealed message against its
+// content and the signature under publicKey
+func VerifyMessage(msg *a2a.Message, publicKey crypto.PublicKey) error {
+	if msg == nil {
+		return ErrMessageDigestMissing
+	}
+	digest, _ := msg.Metadata[MessageDigestKey].(string)
+	if err := VerifyMessageDigest(msg, digest); err != nil {
+		return err
+	}
+	signer, _ := MessageSigner(msg)
+	sig, _ := msg.Metadata[MessageSignatureKey].(string)
+	if sig == "" {
+		return fmt.Errorf("%w: no signature", ErrMessageSignatureInvalid)
+	}
+	return verifyDetached(publicKey, messageSigningInput(signer, digest), sig, ErrMessageSignatureInvalid)
+}
+
+// VerifyMessageFrom is like VerifyMessage, resolving the key of the DID
+// that sealed the message with resolve
+func VerifyMessageFrom(ctx context.Context, msg *a2a.Message, resolve CardKeyResolver) error {
+	if msg == nil {
+		return ErrMessageDigestMissing
+	}
+	signer, ok := MessageSigner(msg)
+	if !ok {
+		return fmt.Errorf("%w: no signer", ErrMessageSignatureInvalid)
+	}
+	publicKey, err := resolve(ctx, signer, nil)
+	if err != nil {
+		return fmt.Errorf("failed to resolve public key: %w", err)
+	}
+	return VerifyMessage(msg, publicKey)
+}
+
+// messageSigningInput binds the digest to the signer
+func messageSigningInput(signer did.AgentDID, digest string) []byte {
+	return []byte("a2a-message\n" + string(signer) + "\n" + digest)
+}
+
+type messageDigestKey struct{}
+
+// WithMessageDigest returns a context whose outgoing A2A requests carry
+// digest in the MessageDigestHeader
+func WithMessageDigest(ctx context.Context, digest string) context.Context {
+	return context.WithValue(ctx, messageDigestKey{}, digest)
+}
+
+// MessageDigestFromContext returns the digest set by WithMessageDigest
+func MessageDigestFromContext(ctx context.Context) (string, bool) {
+	digest, ok := ctx.Value(messageDigestKey{}).(string)
+	return digest, ok && digest != ""
+}
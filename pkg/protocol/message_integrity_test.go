@@ -0,0 +1,102 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage() *a2a.Message {
+	msg := a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "transfer 10 credits"})
+	msg.Metadata = map[string]any{"priority": 2, "labels": map[string]any{"b": "2", "a": "1"}}
+	return msg
+}
+
+func TestMessageDigest(t *testing.T) {
+	msg := testMessage()
+	digest, err := MessageDigest(msg, "")
+	require.NoError(t, err)
+	assert.Regexp(t, `^sha-256=:.+:$`, digest)
+
+	// The digest survives a JSON round trip
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var decoded a2a.Message
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, VerifyMessageDigest(&decoded, digest))
+
+	sha512, err := MessageDigest(msg, "sha-512")
+	require.NoError(t, err)
+	require.NoError(t, VerifyMessageDigest(msg, sha512))
+
+	decoded.Parts = a2a.ContentParts{&a2a.TextPart{Text: "transfer 1000 credits"}}
+	assert.ErrorIs(t, VerifyMessageDigest(&decoded, digest), ErrMessageDigestMismatch)
+
+	msg.Metadata["priority"] = 3
+	assert.ErrorIs(t, VerifyMessageDigest(msg, digest), ErrMessageDigestMismatch)
+	assert.ErrorIs(t, VerifyMessageDigest(msg, ""), ErrMessageDigestMissing)
+}
+
+func TestMessageSealer(t *testing.T) {
+	keyPair, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	agentDID := did.AgentDID("did:sage:solana:agent")
+	sealer := &MessageSealer{AgentDID: agentDID, KeyPair: keyPair}
+
+	msg := testMessage()
+	require.NoError(t, sealer.Seal(msg))
+	require.NoError(t, VerifyMessage(msg, keyPair.PublicKey()))
+	signer, ok := MessageSigner(msg)
+	require.True(t, ok)
+	assert.Equal(t, agentDID, signer)
+
+	// Sealing does not change the digest of the content
+	digest, err := MessageDigest(msg, "sha-256")
+	require.NoError(t, err)
+	assert.Equal(t, digest, msg.Metadata[MessageDigestKey])
+
+	// Persisted messages can be verified later
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var stored a2a.Message
+	require.NoError(t, json.Unmarshal(data, &stored))
+	err = VerifyMessageFrom(context.Background(), &stored, func(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+		assert.Equal(t, signer, agentDID)
+		return keyPair.PublicKey(), nil
+	})
+	require.NoError(t, err)
+
+	otherKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyMessage(&stored, otherKey.PublicKey()), ErrMessageSignatureInvalid)
+
+	stored.Metadata[MessageSignerKey] = "did:sage:solana:other"
+	assert.ErrorIs(t, VerifyMessage(&stored, keyPair.PublicKey()), ErrMessageSignatureInvalid)
+
+	stored.Parts = a2a.ContentParts{&a2a.TextPart{Text: "tampered"}}
+	assert.ErrorIs(t, VerifyMessage(&stored, keyPair.PublicKey()), ErrMessageDigestMismatch)
+}
@@ -0,0 +1,362 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// SupportedChainsKey is the well-known metadata key listing the chains an
+// agent operates on, e.g. ["ethereum", "solana"]
+const SupportedChainsKey = "supported_chains"
+
+// ErrMetadataMissing is returned by MetadataAs when a card has no
+// metadata under the requested key
+var ErrMetadataMissing = errors.New("agent card metadata missing")
+
+// MetadataSchema is the subset of JSON Schema used to validate Agent Card
+// metadata: type, properties, required, additionalProperties, items,
+// enum, pattern, string length, numeric bounds and array size. Schemas
+// marshal to standard JSON Schema, so they can be embedded in cards.
+type MetadataSchema struct {
+	// Type is one of "object", "array", "string", "number", "integer",
+	// "boolean" or "null". Empty accepts any type.
+	Type string `json:"type,omitempty"`
+
+	Properties           map[string]*MetadataSchema `json:"properties,omitempty"`
+	Required             []string                   `json:"required,omitempty"`
+	AdditionalProperties *bool                      `json:"additionalProperties,omitempty"`
+
+	Items       *MetadataSchema `json:"items,omitempty"`
+	MinItems    *int            `json:"minItems,omitempty"`
+	MaxItems    *int            `json:"maxItems,omitempty"`
+	UniqueItems bool            `json:"uniqueItems,omitempty"`
+
+	Enum      []any    `json:"enum,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+}
+
+// ParseMetadataSchema parses a JSON Schema document. Keywords outside the
+// supported subset are ignored.
+func ParseMetadataSchema(data []byte) (*MetadataSchema, error) {
+	var s MetadataSchema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata schema: %w", err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// MustParseMetadataSchema is like ParseMetadataSchema but panics on error.
+// It is intended for schemas in source code.
+func MustParseMetadataSchema(data string) *MetadataSchema {
+	s, err := ParseMetadataSchema([]byte(data))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// compile checks the patterns of s and its subschemas
+func (s *MetadataSchema) compile() error {
+	if s.Pattern != "" {
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			return fmt.Errorf("invalid metadata schema pattern %q: %w", s.Pattern, err)
+		}
+	}
+	for _, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// Validate checks a JSON-decoded value against the schema. Values of other
+// Go types are normalized through JSON first.
+func (s *MetadataSchema) Validate(value any) error {
+	normalized, err := normalizeJSON(value)
+	if err != nil {
+		return err
+	}
+	return s.validate("", normalized)
+}
+
+func (s *MetadataSchema) validate(path string, value any) error {
+	fail := func(format string, args ...any) error {
+		if path == "" {
+			path = "value"
+		}
+		return fmt.Errorf("%s %s", path, fmt.Sprintf(format, args...))
+	}
+
+	if s.Type != "" && !hasJSONType(value, s.Type) {
+		return fail("must be of type %s", s.Type)
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail("must be one of %v", s.Enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		n := len([]rune(v))
+		if s.MinLength != nil && n < *s.MinLength {
+			return fail("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return fail("must be at most %d characters", *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			re, err := regexp.Compile(s.Pattern)
+			if err != nil {
+				return fmt.Errorf("invalid metadata schema pattern %q: %w", s.Pattern, err)
+			}
+			if !re.MatchString(v) {
+				return fail("must match pattern %q", s.Pattern)
+			}
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fail("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			return fail("must be at most %v", *s.Maximum)
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			return fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			return fail("must have at most %d items", *s.MaxItems)
+		}
+		for i, item := range v {
+			if s.UniqueItems {
+				for _, prev := range v[:i] {
+					if reflect.DeepEqual(prev, item) {
+						return fail("must not contain duplicate items")
+					}
+				}
+			}
+			if s.Items != nil {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fail("is missing required property %q", name)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			prop, ok := s.Properties[k]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fail("must not have property %q", k)
+				}
+				continue
+			}
+			if err := prop.validate(joinMetadataPath(path, k), v[k]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasJSONType reports whether a JSON-decoded value has the JSON Schema type t
+func hasJSONType(value any, t string) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == float64(int64(f))
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func joinMetadataPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// normalizeJSON converts value to its generic JSON-decoded form
+func normalizeJSON(value any) (any, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	return out, nil
+}
+
+// metadataSchemas holds the schemas registered for well-known metadata keys
+var metadataSchemas = struct {
+	sync.RWMutex
+	byKey map[string]*MetadataSchema
+}{
+	byKey: map[string]*MetadataSchema{
+		SupportedChainsKey: MustParseMetadataSchema(`{
+			"type": "array",
+			"minItems": 1,
+			"uniqueItems": true,
+			"items": {"type": "string", "pattern": "^[a-z0-9][a-z0-9:_-]*$"}
+		}`),
+	},
+}
+
+// RegisterMetadataSchema registers the schema every Agent Card's metadata
+// under key must satisfy, replacing any previous one. Pass nil to remove
+// it. SupportedChainsKey is registered by default.
+func RegisterMetadataSchema(key string, schema *MetadataSchema) {
+	metadataSchemas.Lock()
+	defer metadataSchemas.Unlock()
+	if schema == nil {
+		delete(metadataSchemas.byKey, key)
+		return
+	}
+	metadataSchemas.byKey[key] = schema
+}
+
+// LookupMetadataSchema returns the schema registered for key
+func LookupMetadataSchema(key string) (*MetadataSchema, bool) {
+	metadataSchemas.RLock()
+	defer metadataSchemas.RUnlock()
+	s, ok := metadataSchemas.byKey[key]
+	return s, ok
+}
+
+// WithMetadataSchema attaches a schema the card's metadata object must
+// satisfy. It is carried in the card, so it is covered by the card
+// signature.
+func (b *AgentCardBuilder) WithMetadataSchema(schema *MetadataSchema) *AgentCardBuilder {
+	b.card.MetadataSchema = schema
+	return b
+}
+
+// BuildValidated returns the constructed Agent Card after validating it,
+// metadata included
+func (b *AgentCardBuilder) BuildValidated() (*AgentCard, error) {
+	if err := b.card.Validate(); err != nil {
+		return nil, err
+	}
+	return b.card, nil
+}
+
+// ValidateMetadata checks the card's metadata against the schemas
+// registered for its keys and against the schema attached to the card
+func (c *AgentCard) ValidateMetadata() error {
+	if len(c.Metadata) == 0 && c.MetadataSchema == nil {
+		return nil
+	}
+	normalized, err := normalizeJSON(c.Metadata)
+	if err != nil {
+		return ErrInvalidAgentCard{err.Error()}
+	}
+	metadata, _ := normalized.(map[string]any)
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if schema, ok := LookupMetadataSchema(k); ok {
+			if err := schema.validate("metadata."+k, metadata[k]); err != nil {
+				return ErrInvalidAgentCard{err.Error()}
+			}
+		}
+	}
+	if c.MetadataSchema != nil {
+		if err := c.MetadataSchema.validate("metadata", metadata); err != nil {
+			return ErrInvalidAgentCard{err.Error()}
+		}
+	}
+	return nil
+}
+
+// MetadataAs decodes the card's metadata under key into out, e.g. a
+// []string for SupportedChainsKey
+func (c *AgentCard) MetadataAs(key string, out any) error {
+	value, ok := c.Metadata[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMetadataMissing, key)
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata %s: %w", key, err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("failed to decode metadata %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadataSchema = `{
+	"type": "object",
+	"required": ["region"],
+	"additionalProperties": false,
+	"properties": {
+		"region": {"type": "string", "enum": ["us-west-2", "eu-central-1"]},
+		"max_tasks": {"type": "integer", "minimum": 1},
+		"supported_chains": {"type": "array"}
+	}
+}`
+
+func newMetadataCardBuilder() *AgentCardBuilder {
+	return NewAgentCardBuilder(did.AgentDID("did:sage:ethereum:0x123"), "Agent", "https://agent.example.com")
+}
+
+func TestMetadataSchema_Validate(t *testing.T) {
+	schema := MustParseMetadataSchema(testMetadataSchema)
+
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{"valid", map[string]any{"region": "us-west-2", "max_tasks": 4}, ""},
+		{"missing required", map[string]any{"max_tasks": 4}, `missing required property "region"`},
+		{"enum", map[string]any{"region": "mars"}, "region must be one of"},
+		{"integer", map[string]any{"region": "us-west-2", "max_tasks": 1.5}, "max_tasks must be of type integer"},
+		{"minimum", map[string]any{"region": "us-west-2", "max_tasks": 0}, "max_tasks must be at least 1"},
+		{"additional", map[string]any{"region": "us-west-2", "extra": true}, `must not have property "extra"`},
+		{"type", []string{"us-west-2"}, "must be of type object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseMetadataSchema_InvalidPattern(t *testing.T) {
+	_, err := ParseMetadataSchema([]byte(`{"items": {"pattern": "("}}`))
+	assert.Error(t, err)
+}
+
+func TestAgentCard_SupportedChains(t *testing.T) {
+	card, err := newMetadataCardBuilder().
+		WithMetadata(SupportedChainsKey, []string{"ethereum", "solana"}).
+		BuildValidated()
+	require.NoError(t, err)
+
+	var chains []string
+	require.NoError(t, card.MetadataAs(SupportedChainsKey, &chains))
+	assert.Equal(t, []string{"ethereum", "solana"}, chains)
+
+	err = card.MetadataAs("missing", &chains)
+	assert.ErrorIs(t, err, ErrMetadataMissing)
+
+	for _, bad := range []any{"ethereum", []string{}, []string{"ethereum", "ethereum"}, []any{"Ethereum"}, []any{1}} {
+		_, err := newMetadataCardBuilder().WithMetadata(SupportedChainsKey, bad).BuildValidated()
+		assert.Error(t, err, "%v", bad)
+	}
+}
+
+func TestRegisterMetadataSchema(t *testing.T) {
+	RegisterMetadataSchema("tier", MustParseMetadataSchema(`{"type": "string", "enum": ["free", "premium"]}`))
+	defer RegisterMetadataSchema("tier", nil)
+
+	_, err := newMetadataCardBuilder().WithMetadata("tier", "premium").BuildValidated()
+	assert.NoError(t, err)
+
+	_, err = newMetadataCardBuilder().WithMetadata("tier", "gold").BuildValidated()
+	var invalid ErrInvalidAgentCard
+	require.ErrorAs(t, err, &invalid)
+	assert.Contains(t, invalid.Message, "metadata.tier")
+}
+
+func TestAgentCard_MetadataSchemaSignedAndVerified(t *testing.T) {
+	keyPair, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	signer := NewDefaultAgentCardSigner(nil)
+	ctx := context.Background()
+
+	card := newMetadataCardBuilder().
+		WithMetadataSchema(MustParseMetadataSchema(testMetadataSchema)).
+		WithMetadata("region", "us-west-2").
+		Build()
+	signed, err := signer.SignAgentCard(ctx, card, keyPair)
+	require.NoError(t, err)
+
+	// The schema survives the JSON round trip and is verified
+	data, err := json.Marshal(signed)
+	require.NoError(t, err)
+	var decoded SignedAgentCard
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NotNil(t, decoded.Card.MetadataSchema)
+	assert.NoError(t, signer.VerifyAgentCardWithKey(ctx, &decoded, keyPair.PublicKey()))
+
+	// A card violating its schema cannot be signed
+	card.Metadata["region"] = "mars"
+	_, err = signer.SignAgentCard(ctx, card, keyPair)
+	assert.Error(t, err)
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// MultiKeyVerifyMode controls how many signatures of a multi-signed card must verify
+type MultiKeyVerifyMode int
+
+const (
+	// RequireAnySignature accepts the card if at least one signature verifies
+	// against the agent's on-chain keys
+	RequireAnySignature MultiKeyVerifyMode = iota
+
+	// RequireAllSignatures accepts the card only if every signature verifies
+	RequireAllSignatures
+)
+
+// CardSignature is one signature entry in JWS General JSON Serialization
+type CardSignature struct {
+	// Protected is the base64url-encoded protected header (alg, typ, kid)
+	Protected string `json:"protected"`
+
+	// Signature is the base64url-encoded signature over protected.payload
+	Signature string `json:"signature"`
+}
+
+// MultiSignedAgentCard is an Agent Card signed by several keys of the same
+// agent, e.g. a secp256k1 key for Ethereum and an Ed25519 key for Solana.
+// The signatures follow JWS General JSON Serialization (RFC 7515 §7.2.1).
+type MultiSignedAgentCard struct {
+	// Card is the Agent Card data
+	Card *AgentCard `json:"card"`
+
+	// Payload is the base64url-encoded card shared by all signatures
+	Payload string `json:"payload"`
+
+	// Signatures contains one entry per signing key
+	Signatures []CardSignature `json:"signatures"`
+
+	// SignedAt is when the signatures were created (Unix timestamp)
+	SignedAt int64 `json:"signedAt"`
+}
+
+// SignedCards returns each signature as a single-key SignedAgentCard in JWS
+// compact serialization, for peers that only understand SignAgentCard output
+func (m *MultiSignedAgentCard) SignedCards() []*SignedAgentCard {
+	cards := make([]*SignedAgentCard, 0, len(m.Signatures))
+	for _, sig := range m.Signatures {
+		cards = append(cards, &SignedAgentCard{
+			Card:      m.Card,
+			Signature: sig.Protected + "." + m.Payload + "." + sig.Signature,
+			SignedAt:  m.SignedAt,
+		})
+	}
+	return cards
+}
+
+// SignAgentCardMultiKey signs an Agent Card with every given key pair.
+// All signatures cover the same payload, so any of them can be verified
+// independently against the corresponding on-chain key.
+func (s *DefaultAgentCardSigner) SignAgentCardMultiKey(ctx context.Context, card *AgentCard, keyPairs ...sagecrypto.KeyPair) (*MultiSignedAgentCard, error) {
+	// Check context cancellation
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context error: %w", err)
+	}
+
+	// Validate inputs
+	if card == nil {
+		return nil, fmt.Errorf("card cannot be nil")
+	}
+
+	if len(keyPairs) == 0 {
+		return nil, fmt.Errorf("at least one keyPair is required")
+	}
+
+	if err := card.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid agent card: %w", err)
+	}
+
+	cardJSON, err := CanonicalJSON(card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal card: %w", err)
+	}
+	payloadB64 := base64.RawURLEncoding.EncodeToString(cardJSON)
+
+	signatures := make([]CardSignature, 0, len(keyPairs))
+	for i, keyPair := range keyPairs {
+		if keyPair == nil {
+			return nil, fmt.Errorf("keyPair %d cannot be nil", i)
+		}
+
+		header := map[string]interface{}{
+			"alg":   getAlgorithmFromKeyType(keyPair.Type()),
+			"typ":   "JWT",
+			"kid":   keyPair.ID(),
+			"canon": CanonicalizationJCS,
+		}
+		headerJSON, err := json.Marshal(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JWS header: %w", err)
+		}
+		headerB64 := base64.RawURLEncoding.EncodeToString(headerJSON)
+
+		signature, err := keyPair.Sign([]byte(headerB64 + "." + payloadB64))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign card with key %d: %w", i, err)
+		}
+
+		signatures = append(signatures, CardSignature{
+			Protected: headerB64,
+			Signature: base64.RawURLEncoding.EncodeToString(signature),
+		})
+	}
+
+	return &MultiSignedAgentCard{
+		Card:       card,
+		Payload:    payloadB64,
+		Signatures: signatures,
+		SignedAt:   time.Now().Unix(),
+	}, nil
+}
+
+// VerifyAgentCardMultiKey verifies a multi-signed Agent Card against the
+// keys registered on-chain for the card's DID, according to mode
+func (s *DefaultAgentCardSigner) VerifyAgentCardMultiKey(ctx context.Context, signedCard *MultiSignedAgentCard, mode MultiKeyVerifyMode) error {
+	// Check context cancellation
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context error: %w", err)
+	}
+
+	// Validate inputs
+	if signedCard == nil {
+		return fmt.Errorf("signedCard cannot be nil")
+	}
+
+	if signedCard.Card == nil {
+		return fmt.Errorf("card cannot be nil")
+	}
+
+	if len(signedCard.Signatures) == 0 {
+		return fmt.Errorf("no signatures present")
+	}
+
+	// All signatures share the payload; it must describe the presented card
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(signedCard.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	var decodedCard AgentCard
+	if err := json.Unmarshal(payloadJSON, &decodedCard); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	if decodedCard.DID != signedCard.Card.DID {
+		return fmt.Errorf("payload DID mismatch")
+	}
+
+	agentDID := did.AgentDID(signedCard.Card.DID)
+	var errs []error
+	verified := 0
+	for i, sig := range signedCard.Signatures {
+		if err := s.verifyCardSignature(ctx, agentDID, signedCard.Payload, sig); err != nil {
+			errs = append(errs, fmt.Errorf("signature %d: %w", i, err))
+			continue
+		}
+		verified++
+	}
+
+	switch mode {
+	case RequireAllSignatures:
+		if len(errs) > 0 {
+			return fmt.Errorf("signature verification failed: %w", errors.Join(errs...))
+		}
+	default:
+		if verified == 0 {
+			return fmt.Errorf("signature verification failed: no valid signature: %w", errors.Join(errs...))
+		}
+	}
+
+	return nil
+}
+
+// verifyCardSignature verifies a single signature entry with the on-chain
+// key matching its algorithm
+func (s *DefaultAgentCardSigner) verifyCardSignature(ctx context.Context, agentDID did.AgentDID, payloadB64 string, sig CardSignature) error {
+	headerJSON, err := base64.RawURLEncoding.DecodeString(sig.Protected)
+	if err != nil {
+		return fmt.Errorf("failed to decode header: %w", err)
+	}
+
+	var header map[string]interface{}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return fmt.Errorf("failed to unmarshal header: %w", err)
+	}
+
+	alg, ok := header["alg"].(string)
+	if !ok {
+		return fmt.Errorf("missing algorithm in header")
+	}
+
+	keyType, err := keyTypeFromAlgorithm(alg)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := s.client.ResolvePublicKeyByType(ctx, agentDID, keyType)
+	if err != nil {
+		return fmt.Errorf("failed to resolve public key: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	valid, err := s.verifySignature(publicKey, []byte(sig.Protected+"."+payloadB64), signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+	if !valid {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultiKeyFixture(t *testing.T) (*AgentCard, *mockKeyPair, *mockKeyPair, *mockEthereumClient) {
+	t.Helper()
+	testDID := did.AgentDID("did:sage:ethereum:0xmulti")
+	card := NewAgentCardBuilder(testDID, "Multi-Chain Agent", "https://agent.example.com").Build()
+
+	ecPriv, ecPub := createTestECDSAKeyPair()
+	edPriv, edPub := createTestEd25519KeyPair()
+	ecKey := &mockKeyPair{pubKey: ecPub, privKey: ecPriv, keyType: crypto.KeyTypeSecp256k1, id: "eth-key"}
+	edKey := &mockKeyPair{pubKey: edPub, privKey: edPriv, keyType: crypto.KeyTypeEd25519, id: "sol-key"}
+
+	client := &mockEthereumClient{
+		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{
+			testDID: {
+				did.KeyTypeECDSA:   ecPub,
+				did.KeyTypeEd25519: edPub,
+			},
+		},
+	}
+	return card, ecKey, edKey, client
+}
+
+func TestSignAgentCardMultiKey(t *testing.T) {
+	ctx := context.Background()
+	card, ecKey, edKey, client := newMultiKeyFixture(t)
+	signer := NewDefaultAgentCardSigner(client)
+
+	signed, err := signer.SignAgentCardMultiKey(ctx, card, ecKey, edKey)
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures, 2)
+	assert.NotZero(t, signed.SignedAt)
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(signed.Signatures[1].Protected)
+	require.NoError(t, err)
+	var header map[string]string
+	require.NoError(t, json.Unmarshal(headerJSON, &header))
+	assert.Equal(t, "EdDSA", header["alg"])
+	assert.Equal(t, "sol-key", header["kid"])
+
+	assert.NoError(t, signer.VerifyAgentCardMultiKey(ctx, signed, RequireAllSignatures))
+	assert.NoError(t, signer.VerifyAgentCardMultiKey(ctx, signed, RequireAnySignature))
+
+	// Each signature is also a valid single-key card
+	for _, single := range signed.SignedCards() {
+		assert.NoError(t, signer.VerifyAgentCard(ctx, single))
+	}
+}
+
+func TestVerifyAgentCardMultiKey_Modes(t *testing.T) {
+	ctx := context.Background()
+	card, ecKey, edKey, client := newMultiKeyFixture(t)
+	signer := NewDefaultAgentCardSigner(client)
+
+	signed, err := signer.SignAgentCardMultiKey(ctx, card, ecKey, edKey)
+	require.NoError(t, err)
+
+	// Corrupt the Ed25519 signature
+	signed.Signatures[1].Signature = base64.RawURLEncoding.EncodeToString([]byte("not-a-valid-signature"))
+
+	assert.NoError(t, signer.VerifyAgentCardMultiKey(ctx, signed, RequireAnySignature))
+
+	err = signer.VerifyAgentCardMultiKey(ctx, signed, RequireAllSignatures)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signature 1")
+
+	// Key not registered on-chain
+	delete(client.publicKeys[did.AgentDID(card.DID)], did.KeyTypeECDSA)
+	err = signer.VerifyAgentCardMultiKey(ctx, signed, RequireAnySignature)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no valid signature")
+}
+
+func TestVerifyAgentCardMultiKey_PayloadMismatch(t *testing.T) {
+	ctx := context.Background()
+	card, ecKey, _, client := newMultiKeyFixture(t)
+	signer := NewDefaultAgentCardSigner(client)
+
+	signed, err := signer.SignAgentCardMultiKey(ctx, card, ecKey)
+	require.NoError(t, err)
+
+	signed.Card = NewAgentCardBuilder("did:sage:ethereum:0xother", "Other", "https://other.example.com").Build()
+	err = signer.VerifyAgentCardMultiKey(ctx, signed, RequireAnySignature)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "payload DID mismatch")
+}
+
+func TestSignAgentCardMultiKey_InvalidInputs(t *testing.T) {
+	ctx := context.Background()
+	card, ecKey, _, _ := newMultiKeyFixture(t)
+	signer := NewDefaultAgentCardSigner(nil)
+
+	_, err := signer.SignAgentCardMultiKey(ctx, nil, ecKey)
+	assert.Error(t, err)
+
+	_, err = signer.SignAgentCardMultiKey(ctx, card)
+	assert.Error(t, err)
+
+	_, err = signer.SignAgentCardMultiKey(ctx, card, ecKey, nil)
+	assert.Error(t, err)
+
+	assert.Error(t, signer.VerifyAgentCardMultiKey(ctx, &MultiSignedAgentCard{Card: card}, RequireAnySignature))
+}
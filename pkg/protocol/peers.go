@@ -0,0 +1,61 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ListPeersMethod is the JSON-RPC method of the peer exchange, by which an
+// agent lists the agents it knows to a verified caller
+const ListPeersMethod = "agents/listPeers"
+
+// MaxListPeers is the largest number of peers returned by one call
+const MaxListPeers = 100
+
+// ListPeersParams are the parameters of agents/listPeers
+type ListPeersParams struct {
+	// Limit is the maximum number of peers to return (default and
+	// maximum MaxListPeers)
+	Limit int `json:"limit,omitempty"`
+}
+
+// Peer is an agent known to another, with its card signed by its DID
+// (see SignA2AAgentCard)
+type Peer struct {
+	DID  did.AgentDID   `json:"did"`
+	Card *a2a.AgentCard `json:"card"`
+}
+
+// Verify checks that the card carries a valid signature by the peer's DID,
+// resolving its key with resolve
+func (p Peer) Verify(ctx context.Context, resolve CardKeyResolver) error {
+	if p.Card == nil {
+		return fmt.Errorf("peer %s has no card", p.DID)
+	}
+	return VerifyA2AAgentCardFrom(ctx, p.Card, p.DID, resolve)
+}
+
+// ListPeersResult is the result of agents/listPeers
+type ListPeersResult struct {
+	Peers []Peer `json:"peers"`
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeer_Verify(t *testing.T) {
+	const peerDID = did.AgentDID("did:sage:ethereum:0xpeer")
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	resolve := func(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+		return kp.PublicKey(), nil
+	}
+
+	card := &a2a.AgentCard{Name: "peer", URL: "https://peer.example.com"}
+	require.NoError(t, SignA2AAgentCard(card, peerDID, kp))
+	assert.NoError(t, Peer{DID: peerDID, Card: card}.Verify(context.Background(), resolve))
+
+	// A card listed under another DID does not verify
+	assert.ErrorIs(t, Peer{DID: "did:sage:ethereum:0xother", Card: card}.Verify(context.Background(), resolve), ErrCardNotSigned)
+	assert.Error(t, Peer{DID: peerDID}.Verify(context.Background(), resolve))
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request hint headers. They are covered by the request signature when set,
+// so servers can trust them once the signature is verified.
+const (
+	// PriorityHeader carries the task priority: low, normal, high or critical
+	PriorityHeader = "A2A-Priority"
+
+	// DeadlineHeader carries the absolute deadline as an RFC 3339 timestamp
+	DeadlineHeader = "A2A-Deadline"
+
+	// BudgetHeader carries the time left for the request in milliseconds.
+	// Being relative, it holds across hops without synchronized clocks.
+	BudgetHeader = "X-Request-Budget"
+)
+
+// Priority orders requests for queueing. The zero value is PriorityNormal.
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+	PriorityCritical
+)
+
+// String returns the header form of p
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	}
+	return fmt.Sprintf("Priority(%d)", int(p))
+}
+
+// ParsePriority parses the header form of a priority
+func ParsePriority(s string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return PriorityLow, nil
+	case "normal":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	case "critical":
+		return PriorityCritical, nil
+	}
+	return PriorityNormal, fmt.Errorf("invalid priority: %q", s)
+}
+
+// FormatDeadline returns the header form of a deadline
+func FormatDeadline(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ParseDeadline parses the header form of a deadline
+func ParseDeadline(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid deadline: %w", err)
+	}
+	return t, nil
+}
+
+// FormatBudget returns the header form of a time budget. Negative budgets
+// are sent as zero.
+func FormatBudget(d time.Duration) string {
+	return strconv.FormatInt(max(d.Milliseconds(), 0), 10)
+}
+
+// ParseBudget parses the header form of a time budget
+func ParseBudget(s string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || ms < 0 || ms > math.MaxInt64/int64(time.Millisecond) {
+		return 0, fmt.Errorf("invalid budget: %q", s)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+type hintKey int
+
+const (
+	priorityKey hintKey = iota
+	deadlineKey
+)
+
+// WithPriority returns a context whose outgoing A2A requests carry priority p
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey, p)
+}
+
+// PriorityFromContext returns the priority set by WithPriority
+func PriorityFromContext(ctx context.Context) (Priority, bool) {
+	p, ok := ctx.Value(priorityKey).(Priority)
+	return p, ok
+}
+
+// WithDeadline returns a context whose outgoing A2A requests carry deadline
+// t. Unlike context.WithDeadline it does not cancel anything locally; it
+// tells the remote agent when the result stops being useful.
+func WithDeadline(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, deadlineKey, t)
+}
+
+// DeadlineFromContext returns the deadline set by WithDeadline
+func DeadlineFromContext(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(deadlineKey).(time.Time)
+	return t, ok
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority(t *testing.T) {
+	for _, p := range []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical} {
+		got, err := ParsePriority(p.String())
+		require.NoError(t, err)
+		assert.Equal(t, p, got)
+	}
+
+	got, err := ParsePriority(" HIGH ")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityHigh, got)
+
+	_, err = ParsePriority("urgent")
+	assert.Error(t, err)
+	assert.Equal(t, PriorityNormal, Priority(0))
+}
+
+func TestDeadline_RoundTrip(t *testing.T) {
+	deadline := time.Date(2025, 6, 1, 12, 30, 0, 123000000, time.FixedZone("KST", 9*3600))
+	got, err := ParseDeadline(FormatDeadline(deadline))
+	require.NoError(t, err)
+	assert.True(t, deadline.Equal(got))
+
+	_, err = ParseDeadline("tomorrow")
+	assert.Error(t, err)
+}
+
+func TestBudget_RoundTrip(t *testing.T) {
+	got, err := ParseBudget(FormatBudget(1500 * time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, got)
+
+	// Sub-millisecond remainders are dropped, overdue budgets are zero
+	assert.Equal(t, "2", FormatBudget(2*time.Millisecond+999*time.Microsecond))
+	assert.Equal(t, "0", FormatBudget(-time.Second))
+
+	for _, invalid := range []string{"", "-1", "1.5", "1s", "99999999999999999999"} {
+		_, err = ParseBudget(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestHintContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := PriorityFromContext(ctx)
+	assert.False(t, ok)
+
+	deadline := time.Now().Add(time.Minute)
+	ctx = WithDeadline(WithPriority(ctx, PriorityHigh), deadline)
+
+	p, ok := PriorityFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, PriorityHigh, p)
+	d, ok := DeadlineFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, deadline, d)
+
+	// No local cancellation
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline)
+}
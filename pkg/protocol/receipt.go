@@ -0,0 +1,155 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ReceiptHeader carries the receipt a server issues for a signed request
+// it processed successfully, as a compact JWS
+const ReceiptHeader = "A2A-Receipt"
+
+// receiptType is the typ of the protected header of a receipt
+const receiptType = "a2a-receipt+jws"
+
+var (
+	// ErrReceiptInvalid is returned when a receipt is malformed or its
+	// signature does not verify
+	ErrReceiptInvalid = errors.New("invalid request receipt")
+
+	// ErrReceiptMismatch is returned when a receipt does not cover the
+	// request it was returned for
+	ErrReceiptMismatch = errors.New("request receipt does not match request")
+)
+
+// Receipt is a statement by Issuer, the server, that it accepted a request
+// from Subject whose body has digest RequestDigest, formatted like a
+// Content-Digest entry. TaskID names the task the request created or
+// addressed, if any. IssuedAt is in Unix seconds.
+type Receipt struct {
+	Issuer        did.AgentDID `json:"iss"`
+	Subject       did.AgentDID `json:"sub"`
+	RequestDigest string       `json:"digest"`
+	TaskID        a2a.TaskID   `json:"task,omitempty"`
+	IssuedAt      int64        `json:"iat"`
+
+	// Token is the compact JWS the receipt was parsed from, kept so the
+	// receipt can be presented as proof later
+	Token string `json:"-"`
+}
+
+// ReceiptDigest returns the digest of a request body as recorded in
+// receipts
+func ReceiptDigest(body []byte) string {
+	digest, _ := computeDigest("sha-256", body) // cannot fail for sha-256
+	return digest
+}
+
+// IssueReceipt creates a receipt by issuer for a request from subject with
+// the given body, signed with keyPair. The returned receipt carries its
+// token.
+func IssueReceipt(issuer did.AgentDID, keyPair sagecrypto.KeyPair, subject did.AgentDID, body []byte, taskID a2a.TaskID) (*Receipt, error) {
+	r := &Receipt{
+		Issuer:        issuer,
+		Subject:       subject,
+		RequestDigest: ReceiptDigest(body),
+		TaskID:        taskID,
+		IssuedAt:      time.Now().Unix(),
+	}
+	token, err := signCompact(receiptType, issuer, keyPair, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign receipt: %w", err)
+	}
+	r.Token = token
+	return r, nil
+}
+
+// ParseReceipt parses a receipt token. The signature is not checked.
+func ParseReceipt(token string) (*Receipt, error) {
+	r, _, _, err := parseReceipt(token)
+	return r, err
+}
+
+// VerifyReceipt parses a receipt token and checks its signature under
+// publicKey
+func VerifyReceipt(token string, publicKey crypto.PublicKey) (*Receipt, error) {
+	return verifyReceipt(token, func(did.AgentDID, string) (crypto.PublicKey, error) {
+		return publicKey, nil
+	})
+}
+
+// VerifyReceiptFrom is like VerifyReceipt, resolving the key of the
+// receipt issuer with resolve
+func VerifyReceiptFrom(ctx context.Context, token string, resolve CardKeyResolver) (*Receipt, error) {
+	return verifyReceipt(token, func(issuer did.AgentDID, alg string) (crypto.PublicKey, error) {
+		return resolveSignerKey(ctx, resolve, issuer, alg)
+	})
+}
+
+// Matches checks that the receipt was issued by issuer, if set, for a
+// request from subject with the given body
+func (r *Receipt) Matches(issuer, subject did.AgentDID, body []byte) error {
+	switch {
+	case issuer != "" && r.Issuer != issuer:
+		return fmt.Errorf("%w: issued by %s", ErrReceiptMismatch, r.Issuer)
+	case r.Subject != subject:
+		return fmt.Errorf("%w: issued to %s", ErrReceiptMismatch, r.Subject)
+	case r.RequestDigest != ReceiptDigest(body):
+		return fmt.Errorf("%w: digest differs", ErrReceiptMismatch)
+	}
+	return nil
+}
+
+func verifyReceipt(token string, keyFor func(issuer did.AgentDID, alg string) (crypto.PublicKey, error)) (*Receipt, error) {
+	r, alg, sig, err := parseReceipt(token)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := keyFor(r.Issuer, alg)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyCompact(token, alg, sig, publicKey, ErrReceiptInvalid); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// parseReceipt decodes a receipt token, returning the receipt, the
+// signature algorithm and the raw signature
+func parseReceipt(token string) (*Receipt, string, []byte, error) {
+	var r Receipt
+	header, sig, err := parseCompact(token, receiptType, &r, ErrReceiptInvalid)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if string(r.Issuer) != header.Kid || r.RequestDigest == "" {
+		return nil, "", nil, ErrReceiptInvalid
+	}
+	r.Token = token
+	return &r, header.Alg, sig, nil
+}
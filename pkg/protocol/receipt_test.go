@@ -0,0 +1,89 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceipt(t *testing.T) {
+	serverKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	server := did.AgentDID("did:sage:ethereum:0xserver")
+	client := did.AgentDID("did:sage:ethereum:0xclient")
+	body := []byte(`{"jsonrpc":"2.0","method":"message/send","id":1}`)
+
+	issued, err := IssueReceipt(server, serverKey, client, body, "task-1")
+	require.NoError(t, err)
+
+	r, err := VerifyReceipt(issued.Token, serverKey.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, issued, r)
+	assert.Equal(t, "task-1", string(r.TaskID))
+	require.NoError(t, r.Matches(server, client, body))
+	require.NoError(t, r.Matches("", client, body))
+
+	assert.ErrorIs(t, r.Matches("did:sage:ethereum:0xother", client, body), ErrReceiptMismatch)
+	assert.ErrorIs(t, r.Matches(server, "did:sage:ethereum:0xother", body), ErrReceiptMismatch)
+	assert.ErrorIs(t, r.Matches(server, client, []byte("{}")), ErrReceiptMismatch)
+
+	otherKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	_, err = VerifyReceipt(issued.Token, otherKey.PublicKey())
+	assert.ErrorIs(t, err, ErrReceiptInvalid)
+
+	// Swapping in another payload breaks the signature
+	other, err := IssueReceipt(server, serverKey, client, body, "task-2")
+	require.NoError(t, err)
+	parts := strings.Split(issued.Token, ".")
+	parts[1] = strings.Split(other.Token, ".")[1]
+	_, err = VerifyReceipt(strings.Join(parts, "."), serverKey.PublicKey())
+	assert.ErrorIs(t, err, ErrReceiptInvalid)
+
+	_, err = ParseReceipt("not-a-jws")
+	assert.ErrorIs(t, err, ErrReceiptInvalid)
+}
+
+func TestVerifyReceiptFrom(t *testing.T) {
+	serverKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	server := did.AgentDID("did:sage:solana:server")
+
+	issued, err := IssueReceipt(server, serverKey, "did:sage:solana:client", nil, "")
+	require.NoError(t, err)
+
+	var resolved did.AgentDID
+	var keyType *did.KeyType
+	r, err := VerifyReceiptFrom(context.Background(), issued.Token, func(ctx context.Context, agentDID did.AgentDID, kt *did.KeyType) (crypto.PublicKey, error) {
+		resolved, keyType = agentDID, kt
+		return serverKey.PublicKey(), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, server, resolved)
+	require.NotNil(t, keyType)
+	assert.Equal(t, did.KeyTypeEd25519, *keyType)
+	assert.Empty(t, r.TaskID)
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// CapabilitySkillTag tags a2a.AgentSkill entries created from SAGE
+// capabilities
+const CapabilitySkillTag = "sage-capability"
+
+// SkillsMetadataKey is the AgentCard metadata entry holding the full
+// a2a.AgentSkill entries of a card converted by AgentCardFromA2A, so that
+// descriptions and tags survive a round trip through the SAGE card
+const SkillsMetadataKey = "a2aSkills"
+
+// CapabilitySkills returns existing plus a skill for each capability that
+// no skill in existing has as its ID. Existing skills are kept as they are,
+// tags included; duplicate IDs in either input are dropped.
+func CapabilitySkills(capabilities []string, existing []a2a.AgentSkill) []a2a.AgentSkill {
+	skills := make([]a2a.AgentSkill, 0, len(existing)+len(capabilities))
+	seen := make(map[string]bool, cap(skills))
+	for _, skill := range existing {
+		if !seen[skill.ID] {
+			seen[skill.ID] = true
+			skill.Tags = slices.Clone(skill.Tags)
+			skills = append(skills, skill)
+		}
+	}
+	for _, capability := range capabilities {
+		if capability == "" || seen[capability] {
+			continue
+		}
+		seen[capability] = true
+		skills = append(skills, a2a.AgentSkill{
+			ID:          capability,
+			Name:        capability,
+			Description: fmt.Sprintf("Provides the %s capability", capability),
+			Tags:        []string{CapabilitySkillTag},
+		})
+	}
+	return skills
+}
+
+// SkillCapabilities returns the capability names for skills: their IDs,
+// without duplicates, in order
+func SkillCapabilities(skills []a2a.AgentSkill) []string {
+	var capabilities []string
+	for _, skill := range skills {
+		if skill.ID != "" && !slices.Contains(capabilities, skill.ID) {
+			capabilities = append(capabilities, skill.ID)
+		}
+	}
+	return capabilities
+}
+
+// ToA2A converts the card to an a2a.AgentCard. Capabilities become skills
+// (see CapabilitySkills); skills stored under SkillsMetadataKey are reused
+// for capabilities the card still lists.
+func (c *AgentCard) ToA2A() *a2a.AgentCard {
+	var existing []a2a.AgentSkill
+	for _, skill := range c.metadataSkills() {
+		if slices.Contains(c.Capabilities, skill.ID) {
+			existing = append(existing, skill)
+		}
+	}
+	return &a2a.AgentCard{
+		Name:        c.Name,
+		Description: c.Description,
+		URL:         c.Endpoint,
+		Skills:      CapabilitySkills(c.Capabilities, existing),
+	}
+}
+
+// metadataSkills decodes the skills stored under SkillsMetadataKey, which
+// after a JSON round trip are generic values
+func (c *AgentCard) metadataSkills() []a2a.AgentSkill {
+	raw, ok := c.Metadata[SkillsMetadataKey]
+	if !ok {
+		return nil
+	}
+	if skills, ok := raw.([]a2a.AgentSkill); ok {
+		return skills
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var skills []a2a.AgentSkill
+	if err := json.Unmarshal(data, &skills); err != nil {
+		return nil
+	}
+	return skills
+}
+
+// AgentCardFromA2A converts an a2a.AgentCard to a SAGE card for agentDID.
+// Skill IDs become capabilities, and the skills themselves are kept under
+// SkillsMetadataKey.
+func AgentCardFromA2A(card *a2a.AgentCard, agentDID did.AgentDID) *AgentCard {
+	b := NewAgentCardBuilder(agentDID, card.Name, card.URL).
+		WithDescription(card.Description).
+		WithCapabilities(SkillCapabilities(card.Skills)...)
+	if len(card.Skills) > 0 {
+		b.WithMetadata(SkillsMetadataKey, CapabilitySkills(nil, card.Skills))
+	}
+	return b.Build()
+}
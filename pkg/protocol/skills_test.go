@@ -0,0 +1,75 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilitySkills(t *testing.T) {
+	existing := []a2a.AgentSkill{
+		{ID: "chat", Name: "Chat", Description: "Talks", Tags: []string{"conversation"}},
+		{ID: "chat", Name: "Duplicate"},
+	}
+	skills := CapabilitySkills([]string{"chat", "search", "search", ""}, existing)
+	require.Len(t, skills, 2)
+	assert.Equal(t, "Chat", skills[0].Name)
+	assert.Equal(t, []string{"conversation"}, skills[0].Tags)
+	assert.Equal(t, "search", skills[1].ID)
+	assert.Equal(t, []string{CapabilitySkillTag}, skills[1].Tags)
+
+	assert.Equal(t, []string{"chat", "search"}, SkillCapabilities(append(skills, skills...)))
+}
+
+func TestAgentCard_A2ARoundTrip(t *testing.T) {
+	a2aCard := &a2a.AgentCard{
+		Name:        "Agent",
+		Description: "Test agent",
+		URL:         "https://agent.example.com",
+		Skills: []a2a.AgentSkill{
+			{ID: "chat", Name: "Chat", Description: "Talks", Tags: []string{"conversation"}},
+		},
+	}
+
+	card := AgentCardFromA2A(a2aCard, "did:sage:ethereum:0xabc")
+	assert.Equal(t, "did:sage:ethereum:0xabc", card.DID)
+	assert.Equal(t, "https://agent.example.com", card.Endpoint)
+	assert.Equal(t, []string{"chat"}, card.Capabilities)
+
+	// Skills survive serialization of the SAGE card
+	data, err := json.Marshal(card)
+	require.NoError(t, err)
+	var decoded AgentCard
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	decoded.Capabilities = append(decoded.Capabilities, "search")
+
+	back := decoded.ToA2A()
+	assert.Equal(t, "Agent", back.Name)
+	assert.Equal(t, "https://agent.example.com", back.URL)
+	require.Len(t, back.Skills, 2)
+	assert.Equal(t, a2aCard.Skills[0], back.Skills[0])
+	assert.Equal(t, "search", back.Skills[1].ID)
+
+	// Skills for dropped capabilities are dropped
+	decoded.Capabilities = []string{"search"}
+	assert.Equal(t, []string{"search"}, SkillCapabilities(decoded.ToA2A().Skills))
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// TaskContextHeader carries the task context a request continues, as a
+// compact JWS. It must be covered by the request signature.
+const TaskContextHeader = "A2A-Task-Context"
+
+// taskContextType is the typ of the protected header of a task context
+const taskContextType = "a2a-task-context+jws"
+
+var (
+	// ErrTaskContextInvalid is returned when a task context is malformed
+	// or its signature does not verify
+	ErrTaskContextInvalid = errors.New("invalid task context")
+
+	// ErrTaskContextExpired is returned when a task context is no longer
+	// valid
+	ErrTaskContextExpired = errors.New("task context expired")
+
+	// ErrTaskContextAudience is returned when a task context was exported
+	// for another agent
+	ErrTaskContextAudience = errors.New("task context exported for another agent")
+
+	// ErrTaskHistoryMismatch is returned when a history does not match the
+	// digest of a task context
+	ErrTaskHistoryMismatch = errors.New("task history does not match task context")
+)
+
+// TaskContext is a statement by Origin, the agent holding a task, that
+// the task is delegated to Audience, or to any agent if Audience is
+// empty. It carries the context ID to continue under and HistoryDigest,
+// the HistoryDigest of the first HistoryLength messages of the task, so
+// the receiving agent can check history it is given. Times are Unix
+// seconds; Expires is zero for contexts that do not expire.
+type TaskContext struct {
+	Origin        did.AgentDID `json:"iss"`
+	Audience      did.AgentDID `json:"aud,omitempty"`
+	TaskID        a2a.TaskID   `json:"task"`
+	ContextID     string       `json:"ctx"`
+	HistoryDigest string       `json:"hist"`
+	HistoryLength int          `json:"len"`
+	IssuedAt      int64        `json:"iat"`
+	Expires       int64        `json:"exp,omitempty"`
+
+	// Token is the compact JWS the task context was signed as or parsed
+	// from
+	Token string `json:"-"`
+}
+
+// ExportTaskContext creates a task context by origin for task, delegated
+// to audience and valid for ttl, or indefinitely if ttl is zero, signed
+// with keyPair. The returned context carries its token.
+func ExportTaskContext(origin did.AgentDID, keyPair sagecrypto.KeyPair, task *a2a.Task, audience did.AgentDID, ttl time.Duration) (*TaskContext, error) {
+	if task == nil {
+		return nil, fmt.Errorf("task cannot be nil")
+	}
+	if task.ID == "" || task.ContextID == "" {
+		return nil, fmt.Errorf("%w: task and context IDs are required", ErrTaskContextInvalid)
+	}
+	digest, err := HistoryDigest(task.History)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tc := &TaskContext{
+		Origin:        origin,
+		Audience:      audience,
+		TaskID:        task.ID,
+		ContextID:     task.ContextID,
+		HistoryDigest: digest,
+		HistoryLength: len(task.History),
+		IssuedAt:      now.Unix(),
+	}
+	if ttl > 0 {
+		tc.Expires = now.Add(ttl).Unix()
+	}
+	token, err := signCompact(taskContextType, origin, keyPair, tc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign task context: %w", err)
+	}
+	tc.Token = token
+	return tc, nil
+}
+
+// HistoryDigest returns the sha-256 digest of a task history, formatted
+// like a Content-Digest entry. Like MessageDigest it covers each message
+// in canonical JSON form, so it survives transport and storage.
+func HistoryDigest(history []*a2a.Message) (string, error) {
+	contents := make([]any, len(history))
+	for i, msg := range history {
+		content, err := messageContent(msg)
+		if err != nil {
+			return "", fmt.Errorf("history message %d: %w", i, err)
+		}
+		contents[i] = content
+	}
+	data, err := CanonicalJSON(contents)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode history: %w", err)
+	}
+	return computeDigest("sha-256", data)
+}
+
+// VerifyHistory checks that history starts with the messages the task
+// context was exported with. Messages added since are not covered.
+func (tc *TaskContext) VerifyHistory(history []*a2a.Message) error {
+	if len(history) < tc.HistoryLength {
+		return fmt.Errorf("%w: %d messages, expected at least %d", ErrTaskHistoryMismatch, len(history), tc.HistoryLength)
+	}
+	digest, err := HistoryDigest(history[:tc.HistoryLength])
+	if err != nil {
+		return err
+	}
+	if digest != tc.HistoryDigest {
+		return fmt.Errorf("%w: digest differs", ErrTaskHistoryMismatch)
+	}
+	return nil
+}
+
+// ExpiresAt returns the expiry time, or the zero time if the task context
+// does not expire
+func (tc *TaskContext) ExpiresAt() time.Time {
+	if tc.Expires == 0 {
+		return time.Time{}
+	}
+	return time.Unix(tc.Expires, 0)
+}
+
+// Valid checks that the task context may be accepted by audience at now.
+// The signature is not checked.
+func (tc *TaskContext) Valid(audience did.AgentDID, now time.Time) error {
+	if tc.Audience != "" && tc.Audience != audience {
+		return fmt.Errorf("%w: %s", ErrTaskContextAudience, tc.Audience)
+	}
+	if tc.Expires != 0 && !now.Before(tc.ExpiresAt()) {
+		return ErrTaskContextExpired
+	}
+	return nil
+}
+
+// ParseTaskContext parses a task context token. The signature is not
+// checked.
+func ParseTaskContext(token string) (*TaskContext, error) {
+	tc, _, _, err := parseTaskContext(token)
+	return tc, err
+}
+
+// VerifyTaskContext parses a task context token and checks its signature
+// under publicKey
+func VerifyTaskContext(token string, publicKey crypto.PublicKey) (*TaskContext, error) {
+	return verifyTaskContext(token, func(did.AgentDID, string) (crypto.PublicKey, error) {
+		return publicKey, nil
+	})
+}
+
+// VerifyTaskContextFrom is like VerifyTaskContext, resolving the key of
+// the origin with resolve
+func VerifyTaskContextFrom(ctx context.Context, token string, resolve CardKeyResolver) (*TaskContext, error) {
+	return verifyTaskContext(token, func(origin did.AgentDID, alg string) (crypto.PublicKey, error) {
+		return resolveSignerKey(ctx, resolve, origin, alg)
+	})
+}
+
+func verifyTaskContext(token string, keyFor func(origin did.AgentDID, alg string) (crypto.PublicKey, error)) (*TaskContext, error) {
+	tc, alg, sig, err := parseTaskContext(token)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := keyFor(tc.Origin, alg)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyCompact(token, alg, sig, publicKey, ErrTaskContextInvalid); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
+
+// parseTaskContext decodes a task context token, returning the task
+// context, the signature algorithm and the raw signature
+func parseTaskContext(token string) (*TaskContext, string, []byte, error) {
+	var tc TaskContext
+	header, sig, err := parseCompact(token, taskContextType, &tc, ErrTaskContextInvalid)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if string(tc.Origin) != header.Kid || tc.TaskID == "" || tc.ContextID == "" || tc.HistoryDigest == "" {
+		return nil, "", nil, ErrTaskContextInvalid
+	}
+	tc.Token = token
+	return &tc, header.Alg, sig, nil
+}
+
+type taskContextKey struct{}
+
+// WithTaskContext returns a context whose outgoing A2A requests continue
+// tc, presenting it in the TaskContextHeader
+func WithTaskContext(ctx context.Context, tc *TaskContext) context.Context {
+	return context.WithValue(ctx, taskContextKey{}, tc)
+}
+
+// TaskContextFromContext returns the task context set by WithTaskContext
+func TaskContextFromContext(ctx context.Context) (*TaskContext, bool) {
+	tc, ok := ctx.Value(taskContextKey{}).(*TaskContext)
+	return tc, ok && tc != nil && tc.Token != ""
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"context"
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskContext(t *testing.T) {
+	originKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	origin := did.AgentDID("did:sage:ethereum:0xorigin")
+	delegate := did.AgentDID("did:sage:ethereum:0xdelegate")
+	task := &a2a.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		History: []*a2a.Message{
+			a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "plan a trip"}),
+			a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "where to?"}),
+		},
+	}
+
+	exported, err := ExportTaskContext(origin, originKey, task, delegate, time.Hour)
+	require.NoError(t, err)
+
+	tc, err := VerifyTaskContext(exported.Token, originKey.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, exported, tc)
+	assert.Equal(t, "ctx-1", tc.ContextID)
+	assert.Equal(t, 2, tc.HistoryLength)
+
+	require.NoError(t, tc.Valid(delegate, time.Now()))
+	assert.ErrorIs(t, tc.Valid("did:sage:ethereum:0xother", time.Now()), ErrTaskContextAudience)
+	assert.ErrorIs(t, tc.Valid(delegate, time.Now().Add(2*time.Hour)), ErrTaskContextExpired)
+
+	// History may grow after the export
+	require.NoError(t, tc.VerifyHistory(task.History))
+	grown := append(task.History, a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "Lisbon"}))
+	require.NoError(t, tc.VerifyHistory(grown))
+	assert.ErrorIs(t, tc.VerifyHistory(task.History[:1]), ErrTaskHistoryMismatch)
+	assert.ErrorIs(t, tc.VerifyHistory(grown[1:]), ErrTaskHistoryMismatch)
+
+	otherKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	_, err = VerifyTaskContext(exported.Token, otherKey.PublicKey())
+	assert.ErrorIs(t, err, ErrTaskContextInvalid)
+
+	_, err = VerifyTaskContextFrom(context.Background(), exported.Token, func(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+		assert.Equal(t, origin, agentDID)
+		return originKey.PublicKey(), nil
+	})
+	require.NoError(t, err)
+}
+
+func TestTaskContext_Invalid(t *testing.T) {
+	key, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	_, err = ExportTaskContext("did:sage:ethereum:0xorigin", key, nil, "", 0)
+	assert.Error(t, err)
+	_, err = ExportTaskContext("did:sage:ethereum:0xorigin", key, &a2a.Task{ID: "task-1"}, "", 0)
+	assert.ErrorIs(t, err, ErrTaskContextInvalid)
+
+	_, err = ParseTaskContext("not-a-token")
+	assert.ErrorIs(t, err, ErrTaskContextInvalid)
+
+	// A receipt is not a task context
+	receipt, err := IssueReceipt("did:sage:ethereum:0xorigin", key, "did:sage:ethereum:0xclient", []byte("{}"), "task-1")
+	require.NoError(t, err)
+	_, err = ParseTaskContext(receipt.Token)
+	assert.ErrorIs(t, err, ErrTaskContextInvalid)
+
+	tc, err := ExportTaskContext("did:sage:ethereum:0xorigin", key, &a2a.Task{ID: "task-1", ContextID: "ctx-1"}, "", 0)
+	require.NoError(t, err)
+	assert.True(t, tc.ExpiresAt().IsZero())
+	require.NoError(t, tc.Valid("did:sage:ethereum:0xanyone", time.Now().Add(24*time.Hour)))
+
+	ctx := WithTaskContext(context.Background(), tc)
+	got, ok := TaskContextFromContext(ctx)
+	assert.True(t, ok)
+	assert.Same(t, tc, got)
+	_, ok = TaskContextFromContext(context.Background())
+	assert.False(t, ok)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Long polling of task events, for clients whose network blocks SSE. A
+// signed GET of TaskEventsPath returns a TaskEventsPage with the events of
+// a task after a cursor, waiting up to the requested time for new ones.
+const (
+	// TaskEventsPath is the path of the long-polling task event endpoint
+	TaskEventsPath = "/tasks/events"
+
+	// TaskEventsTaskParam is the query parameter naming the task
+	TaskEventsTaskParam = "task"
+
+	// TaskEventsCursorParam is the query parameter carrying the Cursor of
+	// the previous page; without it events are returned from the start
+	TaskEventsCursorParam = "cursor"
+
+	// TaskEventsWaitParam is the query parameter with the longest time to
+	// wait for new events, as a Go duration such as "30s"; without it the
+	// server answers immediately
+	TaskEventsWaitParam = "wait"
+
+	// DefaultTaskEventsWait is the wait clients request by default
+	DefaultTaskEventsWait = 30 * time.Second
+
+	// MaxTaskEventsWait is the longest wait a server honors; longer
+	// requests are shortened to it
+	MaxTaskEventsWait = 60 * time.Second
+)
+
+// TaskEvent is an a2a.Event wrapped under a key naming its type
+type TaskEvent struct {
+	Message        *a2a.Message                 `json:"message,omitempty"`
+	Task           *a2a.Task                    `json:"task,omitempty"`
+	StatusUpdate   *a2a.TaskStatusUpdateEvent   `json:"statusUpdate,omitempty"`
+	ArtifactUpdate *a2a.TaskArtifactUpdateEvent `json:"artifactUpdate,omitempty"`
+}
+
+// NewTaskEvent wraps event
+func NewTaskEvent(event a2a.Event) TaskEvent {
+	switch ev := event.(type) {
+	case *a2a.Message:
+		return TaskEvent{Message: ev}
+	case *a2a.Task:
+		return TaskEvent{Task: ev}
+	case *a2a.TaskStatusUpdateEvent:
+		return TaskEvent{StatusUpdate: ev}
+	case *a2a.TaskArtifactUpdateEvent:
+		return TaskEvent{ArtifactUpdate: ev}
+	}
+	return TaskEvent{}
+}
+
+// Event returns the wrapped event, or nil if there is none
+func (e TaskEvent) Event() a2a.Event {
+	switch {
+	case e.Message != nil:
+		return e.Message
+	case e.Task != nil:
+		return e.Task
+	case e.StatusUpdate != nil:
+		return e.StatusUpdate
+	case e.ArtifactUpdate != nil:
+		return e.ArtifactUpdate
+	}
+	return nil
+}
+
+// TaskEventsPage is a response of the task event endpoint. Events may be
+// empty when the wait elapsed without new events. Cursor is passed with
+// the next poll. Final is set once the last event ended the stream, as a
+// final status update does for SSE.
+type TaskEventsPage struct {
+	Events []TaskEvent `json:"events"`
+	Cursor string      `json:"cursor"`
+	Final  bool        `json:"final,omitempty"`
+}
+
+// ParseTaskEventsWait parses the wait query parameter. An empty value is
+// no wait; waits above MaxTaskEventsWait are shortened to it.
+func ParseTaskEventsWait(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	wait, err := time.ParseDuration(value)
+	if err != nil || wait < 0 {
+		return 0, fmt.Errorf("invalid wait %q", value)
+	}
+	if wait > MaxTaskEventsWait {
+		wait = MaxTaskEventsWait
+	}
+	return wait, nil
+}
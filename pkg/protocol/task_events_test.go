@@ -0,0 +1,72 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskEvent_RoundTrip(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	events := []a2a.Event{
+		task,
+		a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "hi"}),
+		a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil),
+		a2a.NewArtifactEvent(task, a2a.TextPart{Text: "result"}),
+	}
+	page := TaskEventsPage{Cursor: "4", Final: true}
+	for _, event := range events {
+		page.Events = append(page.Events, NewTaskEvent(event))
+	}
+
+	data, err := json.Marshal(page)
+	require.NoError(t, err)
+	var decoded TaskEventsPage
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Len(t, decoded.Events, len(events))
+	for i, event := range events {
+		assert.IsType(t, event, decoded.Events[i].Event())
+	}
+	assert.True(t, decoded.Final)
+	assert.Nil(t, TaskEvent{}.Event())
+}
+
+func TestParseTaskEventsWait(t *testing.T) {
+	wait, err := ParseTaskEventsWait("")
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	wait, err = ParseTaskEventsWait("30s")
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, wait)
+
+	wait, err = ParseTaskEventsWait("10m")
+	require.NoError(t, err)
+	assert.Equal(t, MaxTaskEventsWait, wait)
+
+	_, err = ParseTaskEventsWait("-1s")
+	assert.Error(t, err)
+	_, err = ParseTaskEventsWait("30")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package protocol
+
+import (
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// NeedsInput reports whether task is paused waiting for the client, i.e.
+// in the input-required or auth-required state. Such tasks continue when
+// the client sends a message with the same task ID.
+func NeedsInput(task *a2a.Task) bool {
+	if task == nil {
+		return false
+	}
+	return task.Status.State == a2a.TaskStateInputRequired || task.Status.State == a2a.TaskStateAuthRequired
+}
+
+// StatusText returns the text of the task's status message, such as the
+// prompt of a task waiting for input
+func StatusText(task *a2a.Task) string {
+	if task == nil || task.Status.Message == nil {
+		return ""
+	}
+	var texts []string
+	for _, part := range task.Status.Message.Parts {
+		switch p := part.(type) {
+		case a2a.TextPart:
+			texts = append(texts, p.Text)
+		case *a2a.TextPart:
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
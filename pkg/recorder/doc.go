@@ -0,0 +1,60 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package recorder captures signed A2A exchanges to disk and replays them.
+//
+// Recordings are invaluable when debugging interoperability with A2A agents
+// written in other languages: capture what a Python or TypeScript agent
+// actually sent, then re-drive the exact bytes against a local server.
+//
+// # Recording Client Traffic
+//
+//	rec, err := recorder.NewFileRecorder("client.jsonl")
+//	if err != nil {
+//	    return err
+//	}
+//	defer rec.Close()
+//
+//	httpClient := &http.Client{Transport: rec.RoundTripper(nil)}
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, httpClient)
+//
+// # Recording Server Traffic
+//
+//	auth := server.NewDIDAuthMiddleware(resolver, client)
+//	auth.SetVerificationHook(rec.VerificationHook())
+//	http.Handle("/rpc", rec.Wrap(auth.Wrap(rpcHandler)))
+//
+// # Replaying
+//
+//	exchanges, err := recorder.LoadFile("client.jsonl")
+//	results, err := recorder.NewReplayer(exchanges, nil).Replay(ctx, testServer.URL)
+//	for _, r := range results {
+//	    if !r.StatusMatches() {
+//	        log.Printf("%s %s: got %d", r.Exchange.Request.Method, r.Exchange.Request.URL, r.StatusCode)
+//	    }
+//	}
+//
+// # Format
+//
+// Each line of a recording is a JSON-encoded Exchange. Bodies are stored
+// base64-encoded so binary and compressed payloads survive unchanged.
+//
+// # Security Considerations
+//
+// Recordings contain full request bodies and signature headers. Treat them
+// as sensitive and do not record in production unless required.
+package recorder
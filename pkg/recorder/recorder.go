@@ -0,0 +1,317 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+type contextKey string
+
+const exchangeKey contextKey = "recorder_exchange"
+
+// RecordedRequest is a captured HTTP request
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a captured HTTP response
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// VerificationResult is the outcome of DID signature verification
+type VerificationResult struct {
+	Verified bool   `json:"verified"`
+	AgentDID string `json:"agentDid,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Exchange is a single recorded request/response pair.
+// Exchanges are written as one JSON object per line (JSON Lines).
+type Exchange struct {
+	// Timestamp is when the request was captured
+	Timestamp time.Time `json:"timestamp"`
+
+	// Side is "client" for outgoing requests or "server" for incoming requests
+	Side string `json:"side"`
+
+	Request  *RecordedRequest  `json:"request"`
+	Response *RecordedResponse `json:"response,omitempty"`
+
+	// Verification is set by the server-side recorder when
+	// DIDAuthMiddleware reported a result (nil if not attempted)
+	Verification *VerificationResult `json:"verification,omitempty"`
+
+	// Error is set when the round trip itself failed
+	Error string `json:"error,omitempty"`
+}
+
+// Signed reports whether the recorded request carries RFC 9421 signature headers
+func (e *Exchange) Signed() bool {
+	if e.Request == nil {
+		return false
+	}
+	return e.Request.Header.Get("Signature-Input") != "" && e.Request.Header.Get("Signature") != ""
+}
+
+// Recorder writes exchanges to an io.Writer in JSON Lines format.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// NewRecorder creates a Recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// NewFileRecorder creates a Recorder appending to the file at path
+func NewFileRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording file: %w", err)
+	}
+	return &Recorder{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+// Record writes a single exchange
+func (r *Recorder) Record(ex *Exchange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.enc.Encode(ex); err != nil {
+		return fmt.Errorf("failed to write exchange: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying file if the Recorder owns one
+func (r *Recorder) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// ========================================
+// Client side
+// ========================================
+
+// RoundTripper wraps base so every outgoing request (already signed by the
+// transport) and its response are recorded. If base is nil,
+// http.DefaultTransport is used.
+//
+// Example:
+//
+//	rec, _ := recorder.NewFileRecorder("exchanges.jsonl")
+//	httpClient := &http.Client{Transport: rec.RoundTripper(nil)}
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, httpClient)
+func (r *Recorder) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &recordingRoundTripper{recorder: r, base: base}
+}
+
+type recordingRoundTripper struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (rt *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := drainRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ex := &Exchange{
+		Timestamp: time.Now().UTC(),
+		Side:      "client",
+		Request: &RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   body,
+		},
+	}
+
+	resp, err := rt.base.RoundTrip(req)
+	if err != nil {
+		ex.Error = err.Error()
+		_ = rt.recorder.Record(ex)
+		return nil, err
+	}
+
+	ex.Response = &RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+
+	// Capture the body as it is consumed so streaming responses keep working;
+	// the exchange is written once the caller closes the body.
+	resp.Body = &captureBody{
+		ReadCloser: resp.Body,
+		onClose: func(captured []byte) {
+			ex.Response.Body = captured
+			_ = rt.recorder.Record(ex)
+		},
+	}
+
+	return resp, nil
+}
+
+// captureBody tees everything read from the body and reports it on Close
+type captureBody struct {
+	io.ReadCloser
+	buf     bytes.Buffer
+	once    sync.Once
+	onClose func([]byte)
+}
+
+func (b *captureBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	return n, err
+}
+
+func (b *captureBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(b.buf.Bytes()) })
+	return err
+}
+
+// drainRequestBody reads the request body and restores it for sending
+func drainRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// ========================================
+// Server side
+// ========================================
+
+// Wrap records incoming requests and the responses written by next.
+// Place it outside DIDAuthMiddleware and register VerificationHook so the
+// verification result is captured as well:
+//
+//	auth.SetVerificationHook(rec.VerificationHook())
+//	handler := rec.Wrap(auth.Wrap(rpcHandler))
+func (r *Recorder) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := drainRequestBody(req)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read body: %s", err.Error()), http.StatusBadRequest)
+			return
+		}
+
+		ex := &Exchange{
+			Timestamp: time.Now().UTC(),
+			Side:      "server",
+			Request: &RecordedRequest{
+				Method: req.Method,
+				URL:    req.URL.RequestURI(),
+				Header: req.Header.Clone(),
+				Body:   body,
+			},
+		}
+
+		cw := &captureResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(cw, req.WithContext(context.WithValue(req.Context(), exchangeKey, ex)))
+
+		ex.Response = &RecordedResponse{
+			StatusCode: cw.status,
+			Header:     w.Header().Clone(),
+			Body:       cw.buf.Bytes(),
+		}
+		_ = r.Record(ex)
+	})
+}
+
+// VerificationHook returns a hook for DIDAuthMiddleware.SetVerificationHook
+// that attaches the verification result to the exchange being recorded.
+func (r *Recorder) VerificationHook() func(req *http.Request, agentDID did.AgentDID, err error) {
+	return func(req *http.Request, agentDID did.AgentDID, err error) {
+		ex, ok := req.Context().Value(exchangeKey).(*Exchange)
+		if !ok {
+			return
+		}
+		result := &VerificationResult{
+			Verified: err == nil,
+			AgentDID: string(agentDID),
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		ex.Verification = result
+	}
+}
+
+// captureResponseWriter copies the status code and body written by the handler
+type captureResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	buf         bytes.Buffer
+}
+
+func (w *captureResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *captureResponseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	w.buf.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher so SSE handlers keep working
+func (w *captureResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
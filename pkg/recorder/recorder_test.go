@@ -0,0 +1,167 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Echo", "1")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	client := &http.Client{Transport: rec.RoundTripper(nil)}
+
+	req, err := http.NewRequest("POST", server.URL+"/rpc?x=1", strings.NewReader(`{"jsonrpc":"2.0"}`))
+	require.NoError(t, err)
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:test:0x1"`)
+	req.Header.Set("Signature", "sig1=:AAAA:")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, `{"jsonrpc":"2.0"}`, string(respBody))
+
+	exchanges, err := Load(&buf)
+	require.NoError(t, err)
+	require.Len(t, exchanges, 1)
+
+	ex := exchanges[0]
+	assert.Equal(t, "client", ex.Side)
+	assert.True(t, ex.Signed())
+	assert.Equal(t, "POST", ex.Request.Method)
+	assert.Equal(t, server.URL+"/rpc?x=1", ex.Request.URL)
+	assert.Equal(t, []byte(`{"jsonrpc":"2.0"}`), ex.Request.Body)
+	assert.Equal(t, http.StatusOK, ex.Response.StatusCode)
+	assert.Equal(t, "1", ex.Response.Header.Get("X-Echo"))
+	assert.Equal(t, []byte(`{"jsonrpc":"2.0"}`), ex.Response.Body)
+}
+
+func TestRecorder_RoundTripper_ConnectionError(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	client := &http.Client{Transport: rec.RoundTripper(nil)}
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	_, err := client.Get(down.URL)
+	require.Error(t, err)
+
+	exchanges, err := Load(&buf)
+	require.NoError(t, err)
+	require.Len(t, exchanges, 1)
+	assert.NotEmpty(t, exchanges[0].Error)
+	assert.Nil(t, exchanges[0].Response)
+	assert.False(t, exchanges[0].Signed())
+}
+
+func TestRecorder_Wrap_WithVerificationHook(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	hook := rec.VerificationHook()
+
+	// Simulates DIDAuthMiddleware reporting a verification result
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Signature") == "" {
+				hook(r, "", fmt.Errorf("missing signature headers"))
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			hook(r, did.AgentDID("did:sage:test:0xabc"), nil)
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	handler := rec.Wrap(auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write(body)
+	})))
+
+	signed := httptest.NewRequest("POST", "/rpc", strings.NewReader("hello"))
+	signed.Header.Set("Signature-Input", `sig1=();keyid="did:sage:test:0xabc"`)
+	signed.Header.Set("Signature", "sig1=:AAAA:")
+	rec1 := httptest.NewRecorder()
+	handler.ServeHTTP(rec1, signed)
+	assert.Equal(t, "hello", rec1.Body.String())
+
+	unsigned := httptest.NewRequest("POST", "/rpc", strings.NewReader("hello"))
+	handler.ServeHTTP(httptest.NewRecorder(), unsigned)
+
+	exchanges, err := Load(&buf)
+	require.NoError(t, err)
+	require.Len(t, exchanges, 2)
+
+	assert.Equal(t, "server", exchanges[0].Side)
+	assert.Equal(t, "/rpc", exchanges[0].Request.URL)
+	assert.Equal(t, []byte("hello"), exchanges[0].Request.Body)
+	assert.Equal(t, http.StatusAccepted, exchanges[0].Response.StatusCode)
+	require.NotNil(t, exchanges[0].Verification)
+	assert.True(t, exchanges[0].Verification.Verified)
+	assert.Equal(t, "did:sage:test:0xabc", exchanges[0].Verification.AgentDID)
+
+	assert.Equal(t, http.StatusUnauthorized, exchanges[1].Response.StatusCode)
+	require.NotNil(t, exchanges[1].Verification)
+	assert.False(t, exchanges[1].Verification.Verified)
+	assert.Contains(t, exchanges[1].Verification.Error, "missing signature headers")
+}
+
+func TestRecorder_FileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exchanges.jsonl")
+
+	rec, err := NewFileRecorder(path)
+	require.NoError(t, err)
+	require.NoError(t, rec.Record(&Exchange{Side: "client", Request: &RecordedRequest{Method: "GET", URL: "/a"}}))
+	require.NoError(t, rec.Record(&Exchange{Side: "client", Request: &RecordedRequest{Method: "GET", URL: "/b"}}))
+	require.NoError(t, rec.Close())
+
+	exchanges, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Len(t, exchanges, 2)
+	assert.Equal(t, "/b", exchanges[1].Request.URL)
+}
+
+func TestVerificationHook_NoExchangeInContext(t *testing.T) {
+	rec := NewRecorder(io.Discard)
+	req := httptest.NewRequest("GET", "/", nil).WithContext(context.Background())
+
+	assert.NotPanics(t, func() {
+		rec.VerificationHook()(req, "", nil)
+	})
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ReplayResult is the outcome of replaying a single exchange
+type ReplayResult struct {
+	// Exchange is the recorded exchange that was replayed
+	Exchange *Exchange
+
+	// StatusCode is the status returned by the target server
+	StatusCode int
+
+	// Body is the response body returned by the target server
+	Body []byte
+
+	// Err is set if the request could not be sent
+	Err error
+}
+
+// StatusMatches reports whether the replayed status equals the recorded one
+func (r *ReplayResult) StatusMatches() bool {
+	if r.Err != nil || r.Exchange.Response == nil {
+		return false
+	}
+	return r.StatusCode == r.Exchange.Response.StatusCode
+}
+
+// Replayer re-drives recorded exchanges against a server
+type Replayer struct {
+	exchanges  []*Exchange
+	httpClient *http.Client
+}
+
+// NewReplayer creates a Replayer for the given exchanges.
+// If httpClient is nil, http.DefaultClient is used.
+func NewReplayer(exchanges []*Exchange, httpClient *http.Client) *Replayer {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Replayer{exchanges: exchanges, httpClient: httpClient}
+}
+
+// Load reads exchanges in JSON Lines format
+func Load(r io.Reader) ([]*Exchange, error) {
+	var exchanges []*Exchange
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var ex Exchange
+		if err := json.Unmarshal(raw, &ex); err != nil {
+			return nil, fmt.Errorf("failed to parse exchange on line %d: %w", line, err)
+		}
+		exchanges = append(exchanges, &ex)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	return exchanges, nil
+}
+
+// LoadFile reads exchanges from a recording file
+func LoadFile(path string) ([]*Exchange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording file: %w", err)
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Replay sends every recorded request to baseURL, preserving method, path,
+// query, headers and body byte-for-byte so the original signatures remain
+// intact. Only the scheme and host are rewritten.
+//
+// Note that servers enforcing signature expiry or nonce replay protection
+// will (correctly) reject replayed requests.
+func (p *Replayer) Replay(ctx context.Context, baseURL string) ([]*ReplayResult, error) {
+	target, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	results := make([]*ReplayResult, 0, len(p.exchanges))
+	for _, ex := range p.exchanges {
+		if err := ctx.Err(); err != nil {
+			return results, fmt.Errorf("context error: %w", err)
+		}
+		results = append(results, p.replayOne(ctx, target, ex))
+	}
+
+	return results, nil
+}
+
+func (p *Replayer) replayOne(ctx context.Context, target *url.URL, ex *Exchange) *ReplayResult {
+	result := &ReplayResult{Exchange: ex}
+
+	if ex.Request == nil {
+		result.Err = fmt.Errorf("exchange has no request")
+		return result
+	}
+
+	original, err := url.Parse(ex.Request.URL)
+	if err != nil {
+		result.Err = fmt.Errorf("invalid recorded URL: %w", err)
+		return result
+	}
+
+	replayURL := *original
+	replayURL.Scheme = target.Scheme
+	replayURL.Host = target.Host
+	if prefix := strings.TrimRight(target.Path, "/"); prefix != "" {
+		replayURL.Path = prefix + original.Path
+	}
+
+	req, err := http.NewRequestWithContext(ctx, ex.Request.Method, replayURL.String(), bytes.NewReader(ex.Request.Body))
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create request: %w", err)
+		return result
+	}
+	for name, values := range ex.Request.Header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		result.Err = fmt.Errorf("HTTP request failed: %w", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return result
+}
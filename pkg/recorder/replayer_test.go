@@ -0,0 +1,96 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package recorder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayer_Replay(t *testing.T) {
+	type seen struct {
+		path, query, sig, body string
+	}
+	var got []seen
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, seen{r.URL.Path, r.URL.RawQuery, r.Header.Get("Signature"), string(body)})
+		if r.Header.Get("Signature") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	exchanges := []*Exchange{
+		{
+			Request: &RecordedRequest{
+				Method: "POST",
+				URL:    "https://remote-agent.example.com/rpc?trace=1",
+				Header: http.Header{"Signature": {"sig1=:AAAA:"}},
+				Body:   []byte(`{"jsonrpc":"2.0"}`),
+			},
+			Response: &RecordedResponse{StatusCode: http.StatusOK},
+		},
+		{
+			Request:  &RecordedRequest{Method: "GET", URL: "/.well-known/agent-card.json"},
+			Response: &RecordedResponse{StatusCode: http.StatusOK},
+		},
+	}
+
+	results, err := NewReplayer(exchanges, nil).Replay(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.True(t, results[0].StatusMatches())
+	assert.Equal(t, "ok", string(results[0].Body))
+	assert.False(t, results[1].StatusMatches())
+	assert.Equal(t, http.StatusUnauthorized, results[1].StatusCode)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, seen{"/rpc", "trace=1", "sig1=:AAAA:", `{"jsonrpc":"2.0"}`}, got[0])
+	assert.Equal(t, "/.well-known/agent-card.json", got[1].path)
+}
+
+func TestReplayer_InvalidBaseURL(t *testing.T) {
+	_, err := NewReplayer(nil, nil).Replay(context.Background(), "://bad")
+	assert.Error(t, err)
+}
+
+func TestReplayer_MissingRequest(t *testing.T) {
+	results, err := NewReplayer([]*Exchange{{}}, nil).Replay(context.Background(), "http://127.0.0.1")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Error(t, results[0].Err)
+	assert.False(t, results[0].StatusMatches())
+}
+
+func TestLoad_InvalidLine(t *testing.T) {
+	_, err := Load(strings.NewReader("{\"side\":\"client\"}\nnot-json\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package registration registers new agents in the SAGE registry in a
+// single call: it checks whether the DID is already registered, estimates
+// gas, submits the registration transaction from a funded account and
+// waits for it to be confirmed.
+//
+// The registry contract is reached through a Chain, an adapter over the
+// chain's registry binding that holds the funded account and encodes the
+// registration call, including the key ownership proofs the contract
+// requires. EthereumChain is the Chain of sage's SageRegistryV4 contract:
+// the contract only accepts the sending account's own secp256k1 key, so a
+// request's secp256k1 key pair sends and pays for its registration, and
+// requests with Ed25519 keys only are sent from the chain's account.
+//
+//	reg, err := registry.DialEthereumRegistry(ctx, registry.EthereumConfig{
+//	    RPCEndpoint:     rpcURL,
+//	    ContractAddress: registryAddress,
+//	})
+//	chain := registration.NewEthereumChain(reg, fundedAccount)
+//
+// # Registering an Agent
+//
+//	req, err := registration.RequestFromIdentity(id)
+//	registrar := registration.NewRegistrar(chain, registration.Config{
+//	    Confirmations: 2,
+//	    MaxGas:        2_000_000,
+//	})
+//	result, err := registrar.Register(ctx, req)
+//	if result.AlreadyRegistered {
+//	    log.Printf("%s already registered", result.DID)
+//	}
+//
+// Register is idempotent: a DID already registered with the requested keys
+// is reported as AlreadyRegistered without a transaction, and a call for a
+// DID whose transaction is still pending waits for that transaction
+// instead of submitting another. A DID registered with other keys fails
+// with ErrDIDTaken.
+package registration
@@ -0,0 +1,111 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registration
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+)
+
+// EthereumChain is the Chain of a SageRegistryV4 deployment. The contract
+// only accepts ECDSA keys of the account sending the registration, so a
+// request's secp256k1 key pair sends its own registration and pays for it;
+// requests with Ed25519 keys only are sent from the chain's account.
+type EthereumChain struct {
+	*registry.EthereumRegistry
+
+	account *ecdsa.PrivateKey
+}
+
+// NewEthereumChain returns a Chain over reg. account funds requests
+// without a secp256k1 key and may be nil.
+func NewEthereumChain(reg *registry.EthereumRegistry, account *ecdsa.PrivateKey) *EthereumChain {
+	return &EthereumChain{EthereumRegistry: reg, account: account}
+}
+
+// EstimateRegistrationGas implements Chain
+func (c *EthereumChain) EstimateRegistrationGas(ctx context.Context, req *Request) (uint64, error) {
+	reg, sender, err := c.registration(req)
+	if err != nil {
+		return 0, err
+	}
+	return c.EstimateRegistration(ctx, reg, sender)
+}
+
+// SubmitRegistration implements Chain
+func (c *EthereumChain) SubmitRegistration(ctx context.Context, req *Request, gasLimit uint64) (string, error) {
+	reg, sender, err := c.registration(req)
+	if err != nil {
+		return "", err
+	}
+	return c.SendRegistration(ctx, reg, sender, gasLimit)
+}
+
+// TransactionReceipt implements Chain
+func (c *EthereumChain) TransactionReceipt(ctx context.Context, txHash string) (*TxReceipt, error) {
+	receipt, err := c.EthereumRegistry.TransactionReceipt(ctx, txHash)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return &TxReceipt{
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		Success:     receipt.Status == types.ReceiptStatusSuccessful,
+	}, nil
+}
+
+// registration converts req to the registry's form and picks the account
+// sending it
+func (c *EthereumChain) registration(req *Request) (registry.Registration, *ecdsa.PrivateKey, error) {
+	reg := registry.Registration{
+		DID:          req.DID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Endpoint:     req.Endpoint,
+		Capabilities: req.Capabilities,
+	}
+	var sender *ecdsa.PrivateKey
+	for _, kp := range req.Keys {
+		reg.Keys = append(reg.Keys, kp.PublicKey())
+		if kp.Type() != sagecrypto.KeyTypeSecp256k1 {
+			continue
+		}
+		if sender != nil {
+			return registry.Registration{}, nil, errors.New("registration: the registry accepts one secp256k1 key per agent")
+		}
+		priv, ok := kp.PrivateKey().(*ecdsa.PrivateKey)
+		if !ok {
+			return registry.Registration{}, nil, fmt.Errorf("registration: unsupported secp256k1 private key %T", kp.PrivateKey())
+		}
+		sender = priv
+	}
+	if sender == nil {
+		sender = c.account
+	}
+	if sender == nil {
+		return registry.Registration{}, nil, fmt.Errorf("registration: %s has no secp256k1 key and the chain has no account", req.DID)
+	}
+	return reg, sender, nil
+}
+
+var _ Chain = (*EthereumChain)(nil)
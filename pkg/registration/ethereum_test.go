@@ -0,0 +1,106 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/sage-x-project/sage-a2a-go/internal/ethtest"
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEthereumChain(t *testing.T) (*EthereumChain, *ethtest.Backend) {
+	backend, err := ethtest.NewBackend()
+	require.NoError(t, err)
+	reg, err := registry.NewEthereumRegistry(context.Background(), backend, "0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	require.NoError(t, err)
+	account, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	return NewEthereumChain(reg, account), backend
+}
+
+func TestEthereumChain_Register(t *testing.T) {
+	chain, backend := newTestEthereumChain(t)
+	r := NewRegistrar(chain, Config{PollInterval: time.Millisecond})
+	req := newTestRequest(t)
+	edKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	req.Keys = append(req.Keys, edKey)
+
+	result, err := r.Register(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, result.AlreadyRegistered)
+	assert.Equal(t, uint64(ethtest.EstimatedGas*DefaultGasMultiplier), result.GasLimit)
+	assert.Equal(t, uint64(1), result.BlockNumber)
+
+	// The agent's secp256k1 key sends its own registration
+	sent := backend.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, result.TxHash, sent[0].Hash().Hex())
+
+	meta, err := chain.GetAgentByDID(context.Background(), string(req.DID))
+	require.NoError(t, err)
+	assert.Equal(t, "New Agent", meta.Name)
+	assert.Len(t, meta.Keys, 2)
+
+	// Registering again is a no-op
+	result, err = r.Register(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, result.AlreadyRegistered)
+	assert.Len(t, backend.Sent(), 1)
+}
+
+func TestEthereumChain_Ed25519OnlyUsesAccount(t *testing.T) {
+	chain, backend := newTestEthereumChain(t)
+	edKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	req := &Request{DID: "did:sage:ethereum:0xed", Name: "Ed Agent", Keys: []sagecrypto.KeyPair{edKey}}
+
+	_, err = NewRegistrar(chain, Config{PollInterval: time.Millisecond}).Register(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, backend.Sent(), 1)
+
+	meta, err := chain.GetAgentByDID(context.Background(), string(req.DID))
+	require.NoError(t, err)
+	assert.Equal(t, ethcrypto.PubkeyToAddress(chain.account.PublicKey).Hex(), meta.Owner)
+
+	// Without an account there is nobody to send it
+	chain.account = nil
+	req.DID = "did:sage:ethereum:0xed2"
+	_, err = chain.EstimateRegistrationGas(context.Background(), req)
+	assert.ErrorContains(t, err, "no secp256k1 key")
+}
+
+func TestEthereumChain_RejectsSecondSecp256k1Key(t *testing.T) {
+	chain, backend := newTestEthereumChain(t)
+	req := newTestRequest(t)
+	second, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	req.Keys = append(req.Keys, second)
+
+	_, err = chain.SubmitRegistration(context.Background(), req, 0)
+	assert.ErrorContains(t, err, "one secp256k1 key")
+	assert.Empty(t, backend.Sent())
+}
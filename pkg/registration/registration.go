@@ -0,0 +1,340 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registration
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Defaults for Config
+const (
+	DefaultPollInterval  = 2 * time.Second
+	DefaultGasMultiplier = 1.2
+)
+
+var (
+	// ErrDIDTaken is returned when the DID is registered with other keys
+	ErrDIDTaken = errors.New("DID already registered with different keys")
+
+	// ErrTransactionFailed is returned when the registration transaction
+	// was mined but reverted
+	ErrTransactionFailed = errors.New("registration transaction failed")
+
+	// ErrGasLimitExceeded is returned when the estimated gas exceeds
+	// Config.MaxGas
+	ErrGasLimitExceeded = errors.New("registration gas exceeds limit")
+)
+
+// Request describes an agent to register
+type Request struct {
+	DID          did.AgentDID
+	Name         string
+	Description  string
+	Endpoint     string
+	Capabilities map[string]interface{}
+
+	// Keys are the agent's key pairs. The registry requires each key to
+	// sign an ownership proof, so private keys are needed.
+	Keys []sagecrypto.KeyPair
+}
+
+// Validate checks that the request is complete
+func (r *Request) Validate() error {
+	switch {
+	case r.DID == "":
+		return errors.New("registration: agent DID is required")
+	case r.Name == "":
+		return errors.New("registration: agent name is required")
+	case len(r.Keys) == 0:
+		return errors.New("registration: at least one key is required")
+	}
+	return nil
+}
+
+// RequestFromIdentity builds a registration request for id, taking the
+// name, description, endpoint and capabilities from its signed card
+func RequestFromIdentity(id *identity.Identity) (*Request, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	if id.Card == nil || id.Card.Card == nil {
+		return nil, errors.New("registration: identity has no agent card")
+	}
+	card := id.Card.Card
+	req := &Request{
+		DID:         id.DID,
+		Name:        card.Name,
+		Description: card.Description,
+		Endpoint:    card.Endpoint,
+		Keys:        id.Keys,
+	}
+	if len(card.Capabilities) > 0 {
+		req.Capabilities = make(map[string]interface{}, len(card.Capabilities))
+		for _, c := range card.Capabilities {
+			req.Capabilities[c] = true
+		}
+	}
+	return req, nil
+}
+
+// TxReceipt is the outcome of a mined transaction
+type TxReceipt struct {
+	BlockNumber uint64
+	Success     bool
+}
+
+// Chain is the access to the registry contract a Registrar needs, sending
+// transactions from a funded account
+type Chain interface {
+	// GetAgentByDID resolves registered agents, failing with
+	// did.ErrDIDNotFound for unknown DIDs
+	verifier.DIDResolver
+
+	// BlockNumber returns the number of the latest block
+	BlockNumber(ctx context.Context) (uint64, error)
+
+	// EstimateRegistrationGas returns the gas the registration
+	// transaction for req needs
+	EstimateRegistrationGas(ctx context.Context, req *Request) (uint64, error)
+
+	// SubmitRegistration signs and sends the registration transaction for
+	// req with the given gas limit, returning its hash
+	SubmitRegistration(ctx context.Context, req *Request, gasLimit uint64) (string, error)
+
+	// TransactionReceipt returns the receipt of a transaction, or nil
+	// while it is pending
+	TransactionReceipt(ctx context.Context, txHash string) (*TxReceipt, error)
+}
+
+// Config configures a Registrar
+type Config struct {
+	// Confirmations is the number of blocks required on top of the block
+	// including the transaction (default 0)
+	Confirmations uint64
+
+	// PollInterval is how often pending transactions are checked (default
+	// DefaultPollInterval)
+	PollInterval time.Duration
+
+	// GasMultiplier scales the gas estimate to leave headroom (default
+	// DefaultGasMultiplier)
+	GasMultiplier float64
+
+	// MaxGas caps the gas limit; 0 means no cap
+	MaxGas uint64
+}
+
+// Result is the outcome of a registration
+type Result struct {
+	DID did.AgentDID
+
+	// AlreadyRegistered is set when the DID was registered with the
+	// requested keys before, in which case no transaction was sent
+	AlreadyRegistered bool
+
+	TxHash      string
+	GasLimit    uint64
+	BlockNumber uint64
+}
+
+// Registrar registers agents through a Chain. It is safe for concurrent
+// use.
+type Registrar struct {
+	chain  Chain
+	config Config
+
+	mu      sync.Mutex
+	pending map[did.AgentDID]*pendingTx
+}
+
+// pendingTx is a submitted registration transaction not yet confirmed
+type pendingTx struct {
+	hash     string
+	gasLimit uint64
+}
+
+// NewRegistrar creates a Registrar sending transactions through chain
+func NewRegistrar(chain Chain, config Config) *Registrar {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.GasMultiplier <= 0 {
+		config.GasMultiplier = DefaultGasMultiplier
+	}
+	return &Registrar{
+		chain:   chain,
+		config:  config,
+		pending: make(map[did.AgentDID]*pendingTx),
+	}
+}
+
+// Register registers the agent described by req and waits until its
+// transaction is confirmed. If ctx ends while waiting, calling Register
+// again resumes waiting for the same transaction.
+func (r *Registrar) Register(ctx context.Context, req *Request) (*Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.start(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return &Result{DID: req.DID, AlreadyRegistered: true}, nil
+	}
+
+	block, err := r.waitConfirmed(ctx, tx.hash)
+	if err != nil {
+		// Only a reverted transaction is retried; otherwise the next call
+		// keeps waiting for it
+		if errors.Is(err, ErrTransactionFailed) {
+			r.forget(req.DID)
+		}
+		return nil, err
+	}
+	r.forget(req.DID)
+	return &Result{DID: req.DID, TxHash: tx.hash, GasLimit: tx.gasLimit, BlockNumber: block}, nil
+}
+
+// start returns the pending transaction registering req.DID, submitting
+// one unless it is in flight already. It returns nil if the DID is
+// registered. Submissions are serialized so concurrent calls for a DID
+// send a single transaction.
+func (r *Registrar) start(ctx context.Context, req *Request) (*pendingTx, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tx, ok := r.pending[req.DID]; ok {
+		return tx, nil
+	}
+
+	registered, err := r.isRegistered(ctx, req)
+	if err != nil || registered {
+		return nil, err
+	}
+	tx, err := r.submit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r.pending[req.DID] = tx
+	return tx, nil
+}
+
+// isRegistered reports whether req.DID is registered with req's keys,
+// failing with ErrDIDTaken if it is registered with others
+func (r *Registrar) isRegistered(ctx context.Context, req *Request) (bool, error) {
+	meta, err := r.chain.GetAgentByDID(ctx, string(req.DID))
+	// did.DIDError holds a map, so errors.Is cannot match its sentinels
+	var didErr did.DIDError
+	if (errors.As(err, &didErr) && didErr.Code == did.ErrDIDNotFound.Code) || (err == nil && meta == nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to look up %s: %w", req.DID, err)
+	}
+	for _, kp := range req.Keys {
+		if !hasKey(meta, kp.PublicKey()) {
+			return false, fmt.Errorf("%w: %s", ErrDIDTaken, req.DID)
+		}
+	}
+	return true, nil
+}
+
+// hasKey reports whether meta lists pub among its keys
+func hasKey(meta *did.AgentMetadataV4, pub crypto.PublicKey) bool {
+	want, ok := pub.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return false
+	}
+	for _, k := range meta.Keys {
+		registered, err := verifier.NormalizePublicKey(k.KeyData, k.Type)
+		if err == nil && want.Equal(registered) {
+			return true
+		}
+	}
+	return false
+}
+
+// submit estimates gas and sends the registration transaction
+func (r *Registrar) submit(ctx context.Context, req *Request) (*pendingTx, error) {
+	estimate, err := r.chain.EstimateRegistrationGas(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate registration gas: %w", err)
+	}
+	gasLimit := uint64(float64(estimate) * r.config.GasMultiplier)
+	if r.config.MaxGas > 0 && gasLimit > r.config.MaxGas {
+		if estimate > r.config.MaxGas {
+			return nil, fmt.Errorf("%w: estimated %d, limit %d", ErrGasLimitExceeded, estimate, r.config.MaxGas)
+		}
+		gasLimit = r.config.MaxGas
+	}
+
+	hash, err := r.chain.SubmitRegistration(ctx, req, gasLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit registration: %w", err)
+	}
+	return &pendingTx{hash: hash, gasLimit: gasLimit}, nil
+}
+
+// waitConfirmed polls until the transaction has the configured
+// confirmations, returning its block number
+func (r *Registrar) waitConfirmed(ctx context.Context, hash string) (uint64, error) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := r.chain.TransactionReceipt(ctx, hash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get receipt of %s: %w", hash, err)
+		}
+		if receipt != nil {
+			if !receipt.Success {
+				return 0, fmt.Errorf("%w: %s", ErrTransactionFailed, hash)
+			}
+			head, err := r.chain.BlockNumber(ctx)
+			if err != nil {
+				return 0, fmt.Errorf("failed to read chain head: %w", err)
+			}
+			if head >= receipt.BlockNumber && head-receipt.BlockNumber >= r.config.Confirmations {
+				return receipt.BlockNumber, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Registrar) forget(agentDID did.AgentDID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, agentDID)
+}
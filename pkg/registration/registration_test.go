@@ -0,0 +1,198 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registration
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain mines one block per receipt poll and registers agents in a
+// MemoryRegistry when their transaction is mined
+type fakeChain struct {
+	*registry.MemoryRegistry
+
+	mu        sync.Mutex
+	head      uint64
+	gas       uint64
+	revert    bool
+	submitted []uint64 // gas limits
+	txs       map[string]*Request
+	mined     map[string]uint64
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		MemoryRegistry: registry.NewMemoryRegistry(),
+		head:           100,
+		gas:            100_000,
+		txs:            make(map[string]*Request),
+		mined:          make(map[string]uint64),
+	}
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head, nil
+}
+
+func (c *fakeChain) EstimateRegistrationGas(ctx context.Context, req *Request) (uint64, error) {
+	return c.gas, nil
+}
+
+func (c *fakeChain) SubmitRegistration(ctx context.Context, req *Request, gasLimit uint64) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.submitted = append(c.submitted, gasLimit)
+	hash := fmt.Sprintf("0x%02d", len(c.submitted))
+	c.txs[hash] = req
+	return hash, nil
+}
+
+func (c *fakeChain) TransactionReceipt(ctx context.Context, hash string) (*TxReceipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head++
+	if block, ok := c.mined[hash]; ok {
+		return &TxReceipt{BlockNumber: block, Success: !c.revert}, nil
+	}
+	req := c.txs[hash]
+	c.mined[hash] = c.head
+	if c.revert {
+		return nil, nil
+	}
+	var pubs []crypto.PublicKey
+	for _, kp := range req.Keys {
+		pubs = append(pubs, kp.PublicKey())
+	}
+	err := c.Register(ctx, registry.Registration{DID: req.DID, Name: req.Name, Keys: pubs})
+	return nil, err
+}
+
+func newTestRequest(t *testing.T) *Request {
+	kp, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	return &Request{
+		DID:      "did:sage:ethereum:0xnew",
+		Name:     "New Agent",
+		Endpoint: "https://agent.example.com",
+		Keys:     []sagecrypto.KeyPair{kp},
+	}
+}
+
+func testConfig() Config {
+	return Config{Confirmations: 2, PollInterval: time.Millisecond}
+}
+
+func TestRegistrar_Register(t *testing.T) {
+	chain := newFakeChain()
+	r := NewRegistrar(chain, testConfig())
+	req := newTestRequest(t)
+
+	result, err := r.Register(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, result.AlreadyRegistered)
+	assert.Equal(t, "0x01", result.TxHash)
+	assert.Equal(t, uint64(120_000), result.GasLimit)
+	assert.Equal(t, uint64(101), result.BlockNumber)
+
+	meta, err := chain.GetAgentByDID(context.Background(), string(req.DID))
+	require.NoError(t, err)
+	assert.True(t, meta.IsActive)
+
+	// Registering again is a no-op
+	result, err = r.Register(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, result.AlreadyRegistered)
+	assert.Len(t, chain.submitted, 1)
+}
+
+func TestRegistrar_DIDTaken(t *testing.T) {
+	chain := newFakeChain()
+	other, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	req := newTestRequest(t)
+	require.NoError(t, chain.Register(context.Background(), registry.Registration{
+		DID: req.DID, Keys: []crypto.PublicKey{other.PublicKey()},
+	}))
+
+	_, err = NewRegistrar(chain, testConfig()).Register(context.Background(), req)
+	assert.ErrorIs(t, err, ErrDIDTaken)
+	assert.Empty(t, chain.submitted)
+}
+
+func TestRegistrar_GasLimit(t *testing.T) {
+	chain := newFakeChain()
+	config := testConfig()
+	config.MaxGas = 110_000
+	r := NewRegistrar(chain, config)
+
+	result, err := r.Register(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(110_000), result.GasLimit, "headroom is capped")
+
+	chain.gas = 200_000
+	req := newTestRequest(t)
+	req.DID = "did:sage:ethereum:0xother"
+	_, err = r.Register(context.Background(), req)
+	assert.ErrorIs(t, err, ErrGasLimitExceeded)
+}
+
+func TestRegistrar_ResumesPendingTransaction(t *testing.T) {
+	chain := newFakeChain()
+	r := NewRegistrar(chain, Config{Confirmations: 50, PollInterval: time.Millisecond})
+	req := newTestRequest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := r.Register(ctx, req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	result, err := r.Register(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "0x01", result.TxHash)
+	assert.Len(t, chain.submitted, 1, "the pending transaction is not resubmitted")
+}
+
+func TestRegistrar_Reverted(t *testing.T) {
+	chain := newFakeChain()
+	chain.revert = true
+	r := NewRegistrar(chain, testConfig())
+
+	_, err := r.Register(context.Background(), newTestRequest(t))
+	assert.True(t, errors.Is(err, ErrTransactionFailed))
+	assert.Empty(t, r.pending)
+}
+
+func TestRequest_Validate(t *testing.T) {
+	assert.Error(t, (&Request{Name: "a", Keys: newTestRequest(t).Keys}).Validate())
+	assert.Error(t, (&Request{DID: "did:sage:ethereum:0x1", Keys: newTestRequest(t).Keys}).Validate())
+	assert.Error(t, (&Request{DID: "did:sage:ethereum:0x1", Name: "a"}).Validate())
+}
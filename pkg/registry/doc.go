@@ -0,0 +1,68 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package registry provides the agent registry: EthereumRegistry over the
+// on-chain SAGE registry, and an in-memory registry standing in for it in
+// tests, examples and local multi-agent simulations.
+//
+// MemoryRegistry implements verifier.DIDResolver, verifier.PublicKeyClient
+// and protocol.EthereumClient, so it can back the DID verifier, the key
+// selector and agent card signing without a chain.
+//
+// # Registering Agents
+//
+//	reg := registry.NewMemoryRegistry()
+//	err := reg.Register(ctx, registry.Registration{
+//	    DID:      "did:sage:ethereum:0xalice",
+//	    Name:     "Alice",
+//	    Endpoint: "http://localhost:8080",
+//	    Keys:     []crypto.PublicKey{keyPair.PublicKey(), kemKey},
+//	})
+//
+//	middleware := server.NewDIDAuthMiddlewareWithVerifier(reg.NewDIDVerifier())
+//
+// # Lifecycle
+//
+// RotateKey replaces a key, Revoke removes one and Deactivate marks the
+// agent inactive; AddKey and RevokeKey add and retire single keys so old
+// and new keys overlap during a rotation (see rotation.Manager). These
+// mirror the registry contract's operations. Changes are visible to the
+// next resolution; wrap the registry with verifier.NewCachedResolver to
+// also exercise caching behaviour.
+//
+// # On-Chain Registry
+//
+// EthereumRegistry reads agents from sage's SageRegistryV4 contract and
+// sends its transactions; it implements verifier.DIDResolver and backs
+// registration.EthereumChain.
+//
+//	reg, err := registry.DialEthereumRegistry(ctx, registry.EthereumConfig{
+//	    RPCEndpoint:     "http://localhost:8545",
+//	    ContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+//	})
+//
+// The contract stores Ed25519 and secp256k1 keys only. Each secp256k1 key
+// must be the key of the account sending the transaction, which becomes
+// the agent's owner, so an agent has at most one; registering any other
+// fails with ErrKeyNotOwned. Ed25519 keys
+// are listed unverified, and skipped by the default key selector, until
+// the contract owner approves them.
+//
+// EthereumKeyRegistry adds and revokes keys from the owner account for
+// rotation.Manager. Under the rules above only Ed25519 keys can be
+// rotated on chain.
+package registry
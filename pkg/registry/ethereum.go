@@ -0,0 +1,471 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/sage-x-project/sage/pkg/blockchain/ethereum/contracts/registryv4"
+)
+
+// ErrKeyNotOwned is returned for ECDSA keys of another account than the
+// one sending the transaction. SageRegistryV4 binds every ECDSA key of an
+// agent to the agent's owner account.
+var ErrKeyNotOwned = errors.New("ECDSA key does not belong to the sending account")
+
+// EthereumBackend is the node access of an EthereumRegistry.
+// *ethclient.Client implements it.
+type EthereumBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	BlockNumber(ctx context.Context) (uint64, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// EthereumConfig configures DialEthereumRegistry
+type EthereumConfig struct {
+	RPCEndpoint string
+
+	// ContractAddress is the SageRegistryV4 deployment (required)
+	ContractAddress string
+}
+
+// EthereumRegistry reads and registers agents in sage's SageRegistryV4
+// contract. It implements verifier.DIDResolver and sends the transactions
+// of registration.EthereumChain.
+type EthereumRegistry struct {
+	backend  EthereumBackend
+	contract *registryv4.SageRegistryV4
+	chainID  *big.Int
+}
+
+// DialEthereumRegistry connects to config.RPCEndpoint and binds the
+// registry contract
+func DialEthereumRegistry(ctx context.Context, config EthereumConfig) (*EthereumRegistry, error) {
+	client, err := ethclient.DialContext(ctx, config.RPCEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", config.RPCEndpoint, err)
+	}
+	return NewEthereumRegistry(ctx, client, config.ContractAddress)
+}
+
+// NewEthereumRegistry binds the registry contract at contractAddress
+// through backend
+func NewEthereumRegistry(ctx context.Context, backend EthereumBackend, contractAddress string) (*EthereumRegistry, error) {
+	if !common.IsHexAddress(contractAddress) {
+		return nil, fmt.Errorf("invalid registry contract address %q", contractAddress)
+	}
+	contract, err := registryv4.NewSageRegistryV4(common.HexToAddress(contractAddress), backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind registry contract: %w", err)
+	}
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain ID: %w", err)
+	}
+	return &EthereumRegistry{backend: backend, contract: contract, chainID: chainID}, nil
+}
+
+// GetAgentByDID implements verifier.DIDResolver. Ed25519 keys are listed
+// unverified until the contract owner approves them.
+func (r *EthereumRegistry) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	call := &bind.CallOpts{Context: ctx}
+	agent, err := r.contract.GetAgentByDID(call, didStr)
+	if reason, ok := revertReason(err); ok && reason == "Agent not found" {
+		return nil, did.ErrDIDNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+	if agent.Did == "" {
+		return nil, did.ErrDIDNotFound
+	}
+
+	meta := &did.AgentMetadataV4{
+		DID:         did.AgentDID(agent.Did),
+		Name:        agent.Name,
+		Description: agent.Description,
+		Endpoint:    agent.Endpoint,
+		Owner:       agent.Owner.Hex(),
+		IsActive:    agent.Active,
+		CreatedAt:   unixTime(agent.RegisteredAt),
+		UpdatedAt:   unixTime(agent.UpdatedAt),
+	}
+	if agent.Capabilities != "" {
+		if err := json.Unmarshal([]byte(agent.Capabilities), &meta.Capabilities); err != nil {
+			return nil, fmt.Errorf("failed to decode capabilities: %w", err)
+		}
+	}
+	for _, hash := range agent.KeyHashes {
+		key, err := r.contract.GetKey(call, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key %x: %w", hash, err)
+		}
+		meta.Keys = append(meta.Keys, did.AgentKey{
+			Type:      did.KeyType(key.KeyType),
+			KeyData:   key.KeyData,
+			Signature: key.Signature,
+			Verified:  key.Verified,
+			CreatedAt: unixTime(key.RegisteredAt),
+		})
+	}
+	return meta, nil
+}
+
+// BlockNumber returns the number of the latest block
+func (r *EthereumRegistry) BlockNumber(ctx context.Context) (uint64, error) {
+	return r.backend.BlockNumber(ctx)
+}
+
+// EstimateRegistration returns the gas registering reg from sender needs
+func (r *EthereumRegistry) EstimateRegistration(ctx context.Context, reg Registration, sender *ecdsa.PrivateKey) (uint64, error) {
+	tx, err := r.registerAgent(ctx, reg, sender, 0, true)
+	if err != nil {
+		return 0, err
+	}
+	return tx.Gas(), nil
+}
+
+// SendRegistration sends the transaction registering reg from sender,
+// which becomes the agent's owner, and returns its hash without waiting
+// for it. Every ECDSA key of reg must be sender's; Ed25519 keys stay
+// unverified until the contract owner approves them. A zero gasLimit is
+// estimated.
+func (r *EthereumRegistry) SendRegistration(ctx context.Context, reg Registration, sender *ecdsa.PrivateKey, gasLimit uint64) (string, error) {
+	tx, err := r.registerAgent(ctx, reg, sender, gasLimit, false)
+	if err != nil {
+		return "", err
+	}
+	return tx.Hash().Hex(), nil
+}
+
+// TransactionReceipt returns the receipt of a transaction, or nil while
+// it is pending
+func (r *EthereumRegistry) TransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
+	receipt, err := r.backend.TransactionReceipt(ctx, common.HexToHash(txHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+// registerAgent builds, signs and optionally sends the registration of reg
+func (r *EthereumRegistry) registerAgent(ctx context.Context, reg Registration, sender *ecdsa.PrivateKey, gasLimit uint64, noSend bool) (*types.Transaction, error) {
+	if sender == nil {
+		return nil, errors.New("registration sender key is required")
+	}
+	if len(reg.Keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+	params := registryv4.ISageRegistryV4RegistrationParams{
+		Did:         string(reg.DID),
+		Name:        reg.Name,
+		Description: reg.Description,
+		Endpoint:    reg.Endpoint,
+	}
+	for _, pub := range reg.Keys {
+		keyType, keyData, err := contractKey(pub)
+		if err != nil {
+			return nil, err
+		}
+		params.KeyTypes = append(params.KeyTypes, uint8(keyType))
+		params.KeyData = append(params.KeyData, keyData)
+	}
+
+	// New agents start at nonce 0
+	agentID, err := newAgentID(reg.DID, params.KeyData[0])
+	if err != nil {
+		return nil, err
+	}
+	for i, keyData := range params.KeyData {
+		signature := []byte{}
+		if did.KeyType(params.KeyTypes[i]) == did.KeyTypeECDSA {
+			if signature, err = ownershipProof(sender, agentID, keyData, big.NewInt(0)); err != nil {
+				return nil, err
+			}
+		}
+		params.Signatures = append(params.Signatures, signature)
+	}
+	if reg.Capabilities != nil {
+		capabilities, err := json.Marshal(reg.Capabilities)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode capabilities: %w", err)
+		}
+		params.Capabilities = string(capabilities)
+	}
+
+	opts, err := r.transactOpts(ctx, sender)
+	if err != nil {
+		return nil, err
+	}
+	opts.GasLimit = gasLimit
+	opts.NoSend = noSend
+	tx, err := r.contract.RegisterAgent(opts, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register agent: %w", err)
+	}
+	return tx, nil
+}
+
+func (r *EthereumRegistry) transactOpts(ctx context.Context, key *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, r.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transactor: %w", err)
+	}
+	opts.Context = ctx
+	return opts, nil
+}
+
+// EthereumKeyRegistry adds and revokes keys of agents owned by one
+// account in the SageRegistryV4 contract. It implements
+// rotation.KeyRegistry.
+type EthereumKeyRegistry struct {
+	*EthereumRegistry
+
+	owner *ecdsa.PrivateKey
+}
+
+// NewEthereumKeyRegistry returns a KeyRegistry over reg sending its
+// transactions from owner, the account that registered the agents
+func NewEthereumKeyRegistry(reg *EthereumRegistry, owner *ecdsa.PrivateKey) *EthereumKeyRegistry {
+	return &EthereumKeyRegistry{EthereumRegistry: reg, owner: owner}
+}
+
+// AddKey implements rotation.KeyRegistry, registering newKey next to the
+// agent's keys and waiting for the transaction. ECDSA keys must be the
+// owner account's own key, so only Ed25519 keys can be rotated; they are
+// unverified until the contract owner approves them.
+func (r *EthereumKeyRegistry) AddKey(ctx context.Context, agentDID did.AgentDID, newKey crypto.PublicKey) error {
+	agentID, err := r.agentID(ctx, agentDID)
+	if err != nil {
+		return err
+	}
+	keyType, keyData, err := contractKey(newKey)
+	if err != nil {
+		return err
+	}
+	signature := []byte{}
+	if keyType == did.KeyTypeECDSA {
+		nonce, err := r.contract.GetNonce(&bind.CallOpts{Context: ctx}, agentID)
+		if err != nil {
+			return fmt.Errorf("failed to get agent nonce: %w", err)
+		}
+		if signature, err = ownershipProof(r.owner, agentID, keyData, nonce); err != nil {
+			return err
+		}
+	}
+
+	opts, err := r.transactOpts(ctx, r.owner)
+	if err != nil {
+		return err
+	}
+	tx, err := r.contract.AddKey(opts, agentID, uint8(keyType), keyData, signature)
+	if err != nil {
+		return fmt.Errorf("failed to add key: %w", err)
+	}
+	return r.waitMined(ctx, tx)
+}
+
+// RevokeKey implements rotation.KeyRegistry, removing key from the agent
+// and waiting for the transaction
+func (r *EthereumKeyRegistry) RevokeKey(ctx context.Context, agentDID did.AgentDID, key crypto.PublicKey) error {
+	agentID, err := r.agentID(ctx, agentDID)
+	if err != nil {
+		return err
+	}
+	keyHash, err := r.keyHash(ctx, agentID, key)
+	if err != nil {
+		return err
+	}
+
+	opts, err := r.transactOpts(ctx, r.owner)
+	if err != nil {
+		return err
+	}
+	tx, err := r.contract.RevokeKey(opts, agentID, keyHash)
+	if err != nil {
+		return fmt.Errorf("failed to revoke key: %w", err)
+	}
+	return r.waitMined(ctx, tx)
+}
+
+// agentID finds the ID of agentDID among the agents of the owner account
+func (r *EthereumKeyRegistry) agentID(ctx context.Context, agentDID did.AgentDID) ([32]byte, error) {
+	call := &bind.CallOpts{Context: ctx}
+	ids, err := r.contract.GetAgentsByOwner(call, ethcrypto.PubkeyToAddress(r.owner.PublicKey))
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to list owned agents: %w", err)
+	}
+	for _, id := range ids {
+		agent, err := r.contract.GetAgent(call, id)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("failed to get agent %x: %w", id, err)
+		}
+		if agent.Did == string(agentDID) {
+			return id, nil
+		}
+	}
+	return [32]byte{}, fmt.Errorf("%s is not owned by the registry owner key: %w", agentDID, did.ErrDIDNotFound)
+}
+
+// keyHash finds the hash under which key is registered for agentID
+func (r *EthereumKeyRegistry) keyHash(ctx context.Context, agentID [32]byte, key crypto.PublicKey) ([32]byte, error) {
+	keyType, keyData, err := contractKey(key)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	call := &bind.CallOpts{Context: ctx}
+	hashes, err := r.contract.GetAgentKeys(call, agentID)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to list agent keys: %w", err)
+	}
+	for _, hash := range hashes {
+		k, err := r.contract.GetKey(call, hash)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("failed to get key %x: %w", hash, err)
+		}
+		registered := k.KeyData
+		if len(registered) == 65 && registered[0] == 0x04 {
+			registered = registered[1:]
+		}
+		if did.KeyType(k.KeyType) == keyType && bytes.Equal(registered, keyData) {
+			return hash, nil
+		}
+	}
+	return [32]byte{}, errors.New("key is not registered for the agent")
+}
+
+// waitMined waits for tx and fails if it reverted
+func (r *EthereumKeyRegistry) waitMined(ctx context.Context, tx *types.Transaction) error {
+	receipt, err := bind.WaitMined(ctx, r.backend, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return nil
+}
+
+// contractKey returns the contract key type and encoding of pub. The
+// contract stores Ed25519 keys and secp256k1 keys, the latter as 64 raw
+// bytes.
+func contractKey(pub crypto.PublicKey) (did.KeyType, []byte, error) {
+	switch pk := pub.(type) {
+	case ed25519.PublicKey:
+		return did.KeyTypeEd25519, slices.Clone(pk), nil
+	case *ecdsa.PublicKey:
+		// go-ethereum and sage use different secp256k1 curve values
+		if pk.Curve.Params().P.Cmp(ethcrypto.S256().Params().P) != 0 {
+			return 0, nil, fmt.Errorf("registry contract does not store %s keys", pk.Curve.Params().Name)
+		}
+		return did.KeyTypeECDSA, ethcrypto.FromECDSAPub(pk)[1:], nil
+	default:
+		return 0, nil, fmt.Errorf("registry contract does not store %T keys", pub)
+	}
+}
+
+// newAgentID computes the contract's agent ID, keccak256(abi.encode(did,
+// firstKey))
+func newAgentID(agentDID did.AgentDID, firstKey []byte) ([32]byte, error) {
+	stringType, _ := abi.NewType("string", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	data, err := abi.Arguments{{Type: stringType}, {Type: bytesType}}.Pack(string(agentDID), firstKey)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to encode agent ID: %w", err)
+	}
+	return ethcrypto.Keccak256Hash(data), nil
+}
+
+// ownershipProof signs the contract's proof that the 64-byte secp256k1
+// key keyData belongs to the sending account:
+// keccak256(abi.encode(agentID, keyData, sender, nonce)) as an Ethereum
+// signed message
+func ownershipProof(sender *ecdsa.PrivateKey, agentID [32]byte, keyData []byte, nonce *big.Int) ([]byte, error) {
+	from := ethcrypto.PubkeyToAddress(sender.PublicKey)
+	if common.BytesToAddress(ethcrypto.Keccak256(keyData)[12:]) != from {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotOwned, from.Hex())
+	}
+
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	message, err := abi.Arguments{{Type: bytes32Type}, {Type: bytesType}, {Type: addressType}, {Type: uint256Type}}.
+		Pack(agentID, keyData, from, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ownership proof: %w", err)
+	}
+	signature, err := ethcrypto.Sign(signedMessageHash(ethcrypto.Keccak256(message)), sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign ownership proof: %w", err)
+	}
+	// ecrecover expects v as 27 or 28
+	signature[64] += 27
+	return signature, nil
+}
+
+// signedMessageHash hashes a 32-byte digest as an Ethereum signed message
+func signedMessageHash(digest []byte) []byte {
+	return ethcrypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), digest)
+}
+
+// revertReason returns the reason string of a call the contract reverted
+func revertReason(err error) (string, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return "", false
+	}
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return "", false
+	}
+	data, err := hexutil.Decode(encoded)
+	if err != nil {
+		return "", false
+	}
+	reason, err := abi.UnpackRevert(data)
+	return reason, err == nil
+}
+
+func unixTime(seconds *big.Int) time.Time {
+	if seconds == nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds.Int64(), 0)
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/sage-x-project/sage-a2a-go/internal/ethtest"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ verifier.DIDResolver = (*EthereumRegistry)(nil)
+
+const testContract = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+
+func newTestEthereumRegistry(t *testing.T) (*EthereumRegistry, *ethtest.Backend, *ecdsa.PrivateKey) {
+	backend, err := ethtest.NewBackend()
+	require.NoError(t, err)
+	owner, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	reg, err := NewEthereumRegistry(context.Background(), backend, testContract)
+	require.NoError(t, err)
+	return reg, backend, owner
+}
+
+// assertDIDNotFound checks err by code, as did.DIDError is not comparable
+func assertDIDNotFound(t *testing.T, err error) {
+	t.Helper()
+	var didErr did.DIDError
+	require.ErrorAs(t, err, &didErr)
+	assert.Equal(t, did.ErrDIDNotFound.Code, didErr.Code)
+}
+
+func TestEthereumRegistry_Registration(t *testing.T) {
+	ctx := context.Background()
+	reg, backend, owner := newTestEthereumRegistry(t)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, err = reg.GetAgentByDID(ctx, string(testDID))
+	assertDIDNotFound(t, err)
+
+	registration := Registration{
+		DID:          testDID,
+		Name:         "Alice",
+		Endpoint:     "http://localhost:8080",
+		Capabilities: map[string]interface{}{"chat": true},
+		Keys:         []crypto.PublicKey{&owner.PublicKey, edPub},
+	}
+	gas, err := reg.EstimateRegistration(ctx, registration, owner)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(ethtest.EstimatedGas), gas)
+	assert.Empty(t, backend.Sent(), "estimating must not send")
+
+	txHash, err := reg.SendRegistration(ctx, registration, owner, 300_000)
+	require.NoError(t, err)
+	receipt, err := reg.TransactionReceipt(ctx, txHash)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.Equal(t, uint64(1), receipt.Status)
+	assert.Equal(t, uint64(300_000), backend.Sent()[0].Gas())
+
+	meta, err := reg.GetAgentByDID(ctx, string(testDID))
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", meta.Name)
+	assert.Equal(t, ethcrypto.PubkeyToAddress(owner.PublicKey).Hex(), meta.Owner)
+	assert.Equal(t, map[string]interface{}{"chat": true}, meta.Capabilities)
+	require.Len(t, meta.Keys, 2)
+	assert.True(t, meta.Keys[0].Verified)
+	assert.False(t, meta.Keys[1].Verified, "Ed25519 keys wait for the contract owner's approval")
+
+	// The key selector only uses verified keys
+	pub, keyType, err := verifier.NewDefaultKeySelector(reg).SelectKey(ctx, testDID, "ethereum")
+	require.NoError(t, err)
+	assert.Equal(t, did.KeyTypeECDSA, keyType)
+	assert.Equal(t, ethcrypto.PubkeyToAddress(owner.PublicKey), ethcrypto.PubkeyToAddress(*pub.(*ecdsa.PublicKey)))
+}
+
+func TestEthereumRegistry_RegistrationRejectsForeignECDSAKey(t *testing.T) {
+	reg, backend, owner := newTestEthereumRegistry(t)
+	other, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	_, err = reg.SendRegistration(context.Background(), Registration{
+		DID:  testDID,
+		Name: "Alice",
+		Keys: []crypto.PublicKey{&other.PublicKey},
+	}, owner, 0)
+	assert.ErrorIs(t, err, ErrKeyNotOwned)
+	assert.Empty(t, backend.Sent())
+}
+
+func TestEthereumRegistry_RotateEd25519Key(t *testing.T) {
+	ctx := context.Background()
+	reg, backend, owner := newTestEthereumRegistry(t)
+	keyRegistry := NewEthereumKeyRegistry(reg, owner)
+	oldKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	newKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = reg.SendRegistration(ctx, Registration{
+		DID:  testDID,
+		Name: "Alice",
+		Keys: []crypto.PublicKey{&owner.PublicKey, oldKey},
+	}, owner, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, keyRegistry.AddKey(ctx, testDID, newKey))
+	require.NoError(t, keyRegistry.RevokeKey(ctx, testDID, oldKey))
+
+	meta, err := reg.GetAgentByDID(ctx, string(testDID))
+	require.NoError(t, err)
+	require.Len(t, meta.Keys, 2)
+	assert.Equal(t, []byte(newKey), meta.Keys[1].KeyData)
+	assert.False(t, meta.Keys[1].Verified)
+
+	// The new key is used once the contract owner approves it
+	require.True(t, backend.ApproveKey(newKey))
+	pub, _, err := verifier.NewDefaultKeySelector(reg).SelectKey(ctx, testDID, "solana")
+	require.NoError(t, err)
+	assert.Equal(t, newKey, pub)
+
+	// Revoking a key the agent does not have fails before sending
+	assert.Error(t, keyRegistry.RevokeKey(ctx, testDID, oldKey))
+
+	// ECDSA keys are bound to the owner account and cannot be added
+	other, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	assert.ErrorIs(t, keyRegistry.AddKey(ctx, testDID, &other.PublicKey), ErrKeyNotOwned)
+}
+
+func TestEthereumRegistry_AgentNotOwned(t *testing.T) {
+	ctx := context.Background()
+	reg, backend, owner := newTestEthereumRegistry(t)
+	someone, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = reg.SendRegistration(ctx, Registration{
+		DID:  testDID,
+		Name: "Alice",
+		Keys: []crypto.PublicKey{&someone.PublicKey},
+	}, someone, 0)
+	require.NoError(t, err)
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	assertDIDNotFound(t, NewEthereumKeyRegistry(reg, owner).AddKey(ctx, testDID, edPub))
+	assert.Len(t, backend.Sent(), 1)
+}
+
+func TestEthereumRegistry_UnsupportedKeys(t *testing.T) {
+	reg, _, owner := newTestEthereumRegistry(t)
+	_, err := reg.EstimateRegistration(context.Background(), Registration{
+		DID:  testDID,
+		Name: "Alice",
+		Keys: []crypto.PublicKey{make([]byte, 32)},
+	}, owner)
+	assert.ErrorContains(t, err, "does not store")
+}
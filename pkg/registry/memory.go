@@ -0,0 +1,367 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Registration describes an agent to register
+type Registration struct {
+	DID          did.AgentDID
+	Name         string
+	Description  string
+	Endpoint     string
+	Owner        string
+	Capabilities map[string]interface{}
+
+	// Keys are the agent's public keys: *ecdsa.PublicKey, ed25519.PublicKey,
+	// or an X25519 KEM key as *ecdh.PublicKey or 32 raw bytes
+	Keys []crypto.PublicKey
+}
+
+// record is a registered agent and its parsed keys
+type record struct {
+	meta did.AgentMetadataV4
+	keys map[did.KeyType]crypto.PublicKey
+}
+
+// MemoryRegistry is an in-memory stand-in for the on-chain agent registry,
+// for tests, examples and local multi-agent simulations. It implements
+// verifier.DIDResolver, verifier.PublicKeyClient and
+// protocol.EthereumClient and is safe for concurrent use.
+type MemoryRegistry struct {
+	mu     sync.RWMutex
+	agents map[did.AgentDID]*record
+	now    func() time.Time
+}
+
+// NewMemoryRegistry creates an empty registry
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{
+		agents: make(map[did.AgentDID]*record),
+		now:    time.Now,
+	}
+}
+
+// Register adds an active agent. It fails with did.ErrDIDAlreadyExists
+// when the DID is taken.
+func (r *MemoryRegistry) Register(ctx context.Context, reg Registration) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context error: %w", err)
+	}
+	if reg.DID == "" {
+		return fmt.Errorf("agent DID is required")
+	}
+
+	now := r.now()
+	rec := &record{
+		meta: did.AgentMetadataV4{
+			DID:          reg.DID,
+			Name:         reg.Name,
+			Description:  reg.Description,
+			Endpoint:     reg.Endpoint,
+			Owner:        reg.Owner,
+			Capabilities: maps.Clone(reg.Capabilities),
+			IsActive:     true,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+		keys: make(map[did.KeyType]crypto.PublicKey),
+	}
+	for _, pub := range reg.Keys {
+		if err := rec.setKey(pub, now); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.agents[reg.DID]; exists {
+		return did.ErrDIDAlreadyExists
+	}
+	r.agents[reg.DID] = rec
+	return nil
+}
+
+// RegisterKeys registers an agent with only a DID and keys
+func (r *MemoryRegistry) RegisterKeys(ctx context.Context, agentDID did.AgentDID, keys ...crypto.PublicKey) error {
+	return r.Register(ctx, Registration{DID: agentDID, Keys: keys})
+}
+
+// RotateKey replaces the agent's key of newKey's type
+func (r *MemoryRegistry) RotateKey(ctx context.Context, agentDID did.AgentDID, newKey crypto.PublicKey) error {
+	return r.update(ctx, agentDID, func(rec *record, now time.Time) error {
+		return rec.setKey(newKey, now)
+	})
+}
+
+// AddKey registers newKey next to the agent's existing keys, even of the
+// same type, as during a key rotation overlap. Resolution by type returns
+// the newest key.
+func (r *MemoryRegistry) AddKey(ctx context.Context, agentDID did.AgentDID, newKey crypto.PublicKey) error {
+	return r.update(ctx, agentDID, func(rec *record, now time.Time) error {
+		keyType, pub, keyData, err := encodeKey(newKey)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(rec.meta.Keys, func(k did.AgentKey) bool {
+			return k.Type == keyType && bytes.Equal(k.KeyData, keyData)
+		}) {
+			return nil
+		}
+		rec.keys[keyType] = pub
+		rec.meta.Keys = append(rec.meta.Keys, did.AgentKey{
+			Type:      keyType,
+			KeyData:   keyData,
+			Verified:  true,
+			CreatedAt: now,
+		})
+		if keyType == did.KeyTypeX25519 {
+			rec.meta.PublicKEMKey = keyData
+		}
+		return nil
+	})
+}
+
+// RevokeKey removes one key of the agent, leaving its other keys of the
+// same type, e.g. to retire the old key after a rotation
+func (r *MemoryRegistry) RevokeKey(ctx context.Context, agentDID did.AgentDID, key crypto.PublicKey) error {
+	return r.update(ctx, agentDID, func(rec *record, now time.Time) error {
+		keyType, _, keyData, err := encodeKey(key)
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(rec.meta.Keys, func(k did.AgentKey) bool {
+			return k.Type == keyType && bytes.Equal(k.KeyData, keyData)
+		})
+		if i < 0 {
+			return fmt.Errorf("key not registered for %s", agentDID)
+		}
+		rec.meta.Keys = slices.Delete(rec.meta.Keys, i, i+1)
+
+		// Resolution by type falls back to the newest remaining key
+		delete(rec.keys, keyType)
+		if keyType == did.KeyTypeX25519 {
+			rec.meta.PublicKEMKey = nil
+		}
+		for j := len(rec.meta.Keys) - 1; j >= 0; j-- {
+			k := rec.meta.Keys[j]
+			if k.Type != keyType {
+				continue
+			}
+			if keyType == did.KeyTypeX25519 {
+				rec.keys[keyType] = slices.Clone(k.KeyData)
+				rec.meta.PublicKEMKey = k.KeyData
+				break
+			}
+			pub, err := verifier.NormalizePublicKey(k.KeyData, keyType)
+			if err != nil {
+				return err
+			}
+			rec.keys[keyType] = pub
+			break
+		}
+		return nil
+	})
+}
+
+// Revoke removes the agent's key of keyType. Requests signed with the key
+// no longer verify.
+func (r *MemoryRegistry) Revoke(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) error {
+	return r.update(ctx, agentDID, func(rec *record, now time.Time) error {
+		if _, ok := rec.keys[keyType]; !ok {
+			return fmt.Errorf("no %s key registered for %s", keyType, agentDID)
+		}
+		rec.removeKey(keyType)
+		return nil
+	})
+}
+
+// Deactivate marks the agent inactive. Resolution of its keys then fails
+// with did.ErrInactiveAgent.
+func (r *MemoryRegistry) Deactivate(ctx context.Context, agentDID did.AgentDID) error {
+	return r.update(ctx, agentDID, func(rec *record, now time.Time) error {
+		rec.meta.IsActive = false
+		return nil
+	})
+}
+
+// update applies fn to a registered agent under the write lock
+func (r *MemoryRegistry) update(ctx context.Context, agentDID did.AgentDID, fn func(rec *record, now time.Time) error) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context error: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.agents[agentDID]
+	if !ok {
+		return did.ErrDIDNotFound
+	}
+	now := r.now()
+	if err := fn(rec, now); err != nil {
+		return err
+	}
+	rec.meta.UpdatedAt = now
+	return nil
+}
+
+// GetAgentByDID implements verifier.DIDResolver. Inactive agents are
+// returned with IsActive unset, as the on-chain registry does.
+func (r *MemoryRegistry) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context error: %w", err)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rec, ok := r.agents[did.AgentDID(didStr)]
+	if !ok {
+		return nil, did.ErrDIDNotFound
+	}
+	meta := rec.meta
+	meta.Keys = slices.Clone(rec.meta.Keys)
+	meta.Capabilities = maps.Clone(rec.meta.Capabilities)
+	meta.PublicKEMKey = slices.Clone(rec.meta.PublicKEMKey)
+	return &meta, nil
+}
+
+// ResolvePublicKeyByType implements protocol.EthereumClient
+func (r *MemoryRegistry) ResolvePublicKeyByType(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context error: %w", err)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rec, ok := r.agents[agentDID]
+	if !ok {
+		return nil, did.ErrDIDNotFound
+	}
+	if !rec.meta.IsActive {
+		return nil, did.ErrInactiveAgent
+	}
+	pub, ok := rec.keys[keyType]
+	if !ok {
+		return nil, fmt.Errorf("no %s key registered for %s", keyType, agentDID)
+	}
+	return pub, nil
+}
+
+// ResolveAllPublicKeys returns the agent's registered keys
+func (r *MemoryRegistry) ResolveAllPublicKeys(ctx context.Context, agentDID did.AgentDID) ([]did.AgentKey, error) {
+	meta, err := r.GetAgentByDID(ctx, string(agentDID))
+	if err != nil {
+		return nil, err
+	}
+	if !meta.IsActive {
+		return nil, did.ErrInactiveAgent
+	}
+	return meta.Keys, nil
+}
+
+// ResolvePublicKey implements verifier.PublicKeyClient, returning the
+// agent's ECDSA key
+func (r *MemoryRegistry) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return r.ResolvePublicKeyByType(ctx, agentDID, did.KeyTypeECDSA)
+}
+
+// ResolveKEMKey implements verifier.PublicKeyClient, returning the agent's
+// X25519 key as raw bytes
+func (r *MemoryRegistry) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return r.ResolvePublicKeyByType(ctx, agentDID, did.KeyTypeX25519)
+}
+
+// NewDIDVerifier builds a verifier.DIDVerifier resolving keys from the
+// registry
+func (r *MemoryRegistry) NewDIDVerifier() verifier.DIDVerifier {
+	return verifier.NewDefaultDIDVerifier(r, verifier.NewDefaultKeySelector(r), verifier.NewRFC9421Verifier())
+}
+
+// setKey adds pub, replacing any key of the same type
+func (rec *record) setKey(pub crypto.PublicKey, now time.Time) error {
+	keyType, pub, keyData, err := encodeKey(pub)
+	if err != nil {
+		return err
+	}
+	rec.removeKey(keyType)
+	rec.keys[keyType] = pub
+	rec.meta.Keys = append(rec.meta.Keys, did.AgentKey{
+		Type:      keyType,
+		KeyData:   keyData,
+		Verified:  true,
+		CreatedAt: now,
+	})
+	if keyType == did.KeyTypeX25519 {
+		rec.meta.PublicKEMKey = keyData
+	}
+	return nil
+}
+
+func (rec *record) removeKey(keyType did.KeyType) {
+	delete(rec.keys, keyType)
+	rec.meta.Keys = slices.DeleteFunc(rec.meta.Keys, func(k did.AgentKey) bool {
+		return k.Type == keyType
+	})
+	if keyType == did.KeyTypeX25519 {
+		rec.meta.PublicKEMKey = nil
+	}
+}
+
+// encodeKey determines the registry key type of pub and its on-chain
+// encoding. ECDSA keys on curves other than secp256k1 are stored as DER so
+// the default key selector can parse them.
+func encodeKey(pub crypto.PublicKey) (did.KeyType, crypto.PublicKey, []byte, error) {
+	switch pk := pub.(type) {
+	case *ecdsa.PublicKey:
+		encoding := verifier.KeyEncodingDER
+		if pk.Curve.Params().Name == "secp256k1" {
+			encoding = verifier.KeyEncodingRaw
+		}
+		data, err := verifier.MarshalPublicKey(pk, encoding)
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("failed to encode ECDSA key: %w", err)
+		}
+		return did.KeyTypeECDSA, pk, data, nil
+	case ed25519.PublicKey:
+		return did.KeyTypeEd25519, pk, slices.Clone(pk), nil
+	case *ecdh.PublicKey:
+		if pk.Curve() != ecdh.X25519() {
+			return 0, nil, nil, fmt.Errorf("unsupported ECDH curve: %v", pk.Curve())
+		}
+		return did.KeyTypeX25519, pk.Bytes(), pk.Bytes(), nil
+	case []byte:
+		if len(pk) != 32 {
+			return 0, nil, nil, fmt.Errorf("x25519: want 32 bytes, got %d", len(pk))
+		}
+		return did.KeyTypeX25519, slices.Clone(pk), slices.Clone(pk), nil
+	default:
+		return 0, nil, nil, fmt.Errorf("unsupported public key type: %T", pub)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ verifier.DIDResolver     = (*MemoryRegistry)(nil)
+	_ verifier.PublicKeyClient = (*MemoryRegistry)(nil)
+	_ protocol.EthereumClient  = (*MemoryRegistry)(nil)
+)
+
+const testDID = did.AgentDID("did:sage:ethereum:0xalice")
+
+func TestMemoryRegistry_Register(t *testing.T) {
+	ctx := context.Background()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	kemKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Register(ctx, Registration{
+		DID:      testDID,
+		Name:     "Alice",
+		Endpoint: "http://localhost:8080",
+		Keys:     []crypto.PublicKey{&ecKey.PublicKey, edPub, kemKey.PublicKey()},
+	}))
+	assert.Equal(t, did.ErrDIDAlreadyExists, reg.RegisterKeys(ctx, testDID, edPub))
+
+	meta, err := reg.GetAgentByDID(ctx, string(testDID))
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", meta.Name)
+	assert.True(t, meta.IsActive)
+	assert.Len(t, meta.Keys, 3)
+	assert.Equal(t, kemKey.PublicKey().Bytes(), meta.PublicKEMKey)
+
+	// The key selector parses the stored encodings
+	selector := verifier.NewDefaultKeySelector(reg)
+	pub, keyType, err := selector.SelectKey(ctx, testDID, "ethereum")
+	require.NoError(t, err)
+	assert.Equal(t, did.KeyTypeECDSA, keyType)
+	assert.True(t, ecKey.PublicKey.Equal(pub))
+
+	pub, _, err = selector.SelectKey(ctx, testDID, "solana")
+	require.NoError(t, err)
+	assert.Equal(t, edPub, pub)
+
+	kem, err := reg.ResolveKEMKey(ctx, testDID)
+	require.NoError(t, err)
+	assert.Equal(t, kemKey.PublicKey().Bytes(), kem)
+
+	_, err = reg.GetAgentByDID(ctx, "did:sage:ethereum:0xmissing")
+	assert.Equal(t, did.ErrDIDNotFound, err)
+	assert.Error(t, reg.RegisterKeys(ctx, "did:sage:ethereum:0xbob", "not a key"))
+}
+
+func TestMemoryRegistry_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	oldKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	newKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(ctx, testDID, oldKey.PublicKey()))
+	didVerifier := reg.NewDIDVerifier()
+
+	verify := func(keyPair sagecrypto.KeyPair) error {
+		req := httptest.NewRequest(http.MethodPost, "http://agent.example/rpc", strings.NewReader(`{"jsonrpc":"2.0"}`))
+		require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(ctx, req, testDID, keyPair))
+		_, err := didVerifier.VerifyHTTPSignatureWithKeyID(ctx, req)
+		return err
+	}
+	require.NoError(t, verify(oldKey))
+
+	// Rotation replaces the key
+	require.NoError(t, reg.RotateKey(ctx, testDID, newKey.PublicKey()))
+	assert.Error(t, verify(oldKey))
+	assert.NoError(t, verify(newKey))
+	meta, err := reg.GetAgentByDID(ctx, string(testDID))
+	require.NoError(t, err)
+	assert.Len(t, meta.Keys, 1)
+
+	// Deactivated agents no longer resolve
+	require.NoError(t, reg.Deactivate(ctx, testDID))
+	assert.Error(t, verify(newKey))
+	_, err = reg.ResolvePublicKey(ctx, testDID)
+	assert.Equal(t, did.ErrInactiveAgent, err)
+
+	assert.Equal(t, did.ErrDIDNotFound, reg.Deactivate(ctx, "did:sage:ethereum:0xmissing"))
+}
+
+func TestMemoryRegistry_KeyOverlap(t *testing.T) {
+	ctx := context.Background()
+	oldKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	newKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(ctx, testDID, oldKey.PublicKey()))
+	didVerifier := reg.NewDIDVerifier()
+
+	verify := func(keyPair sagecrypto.KeyPair) error {
+		req := httptest.NewRequest(http.MethodPost, "http://agent.example/rpc", strings.NewReader(`{"jsonrpc":"2.0"}`))
+		require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(ctx, req, testDID, keyPair))
+		_, err := didVerifier.VerifyHTTPSignatureWithKeyID(ctx, req)
+		return err
+	}
+
+	// Both keys verify while they overlap
+	require.NoError(t, reg.AddKey(ctx, testDID, newKey.PublicKey()))
+	require.NoError(t, reg.AddKey(ctx, testDID, newKey.PublicKey()), "adding a key twice is a no-op")
+	assert.NoError(t, verify(oldKey))
+	assert.NoError(t, verify(newKey))
+	pub, err := reg.ResolvePublicKey(ctx, testDID)
+	require.NoError(t, err)
+	assert.Equal(t, newKey.PublicKey(), pub)
+
+	require.NoError(t, reg.RevokeKey(ctx, testDID, oldKey.PublicKey()))
+	assert.Error(t, verify(oldKey))
+	assert.NoError(t, verify(newKey))
+	meta, err := reg.GetAgentByDID(ctx, string(testDID))
+	require.NoError(t, err)
+	assert.Len(t, meta.Keys, 1)
+
+	assert.Error(t, reg.RevokeKey(ctx, testDID, oldKey.PublicKey()))
+}
+
+func TestMemoryRegistry_Revoke(t *testing.T) {
+	ctx := context.Background()
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(ctx, testDID, edPub))
+	require.NoError(t, reg.Revoke(ctx, testDID, did.KeyTypeEd25519))
+	assert.Error(t, reg.Revoke(ctx, testDID, did.KeyTypeEd25519))
+
+	all, err := reg.ResolveAllPublicKeys(ctx, testDID)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+	_, err = reg.ResolvePublicKeyByType(ctx, testDID, did.KeyTypeEd25519)
+	assert.Error(t, err)
+}
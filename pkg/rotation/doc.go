@@ -0,0 +1,53 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package rotation rotates an agent's signing key without interrupting
+// traffic. A rotation registers the new key on chain next to the old one,
+// switches signing to the new key, publishes the updated Agent Card, and
+// after an overlap window retires the old key:
+//
+//	key := signer.NewRotatingKeyPair(oldKey)
+//	tr := transport.NewDIDHTTPTransport(url, agentDID, key, nil)
+//
+//	manager, err := rotation.NewManager(agentDID, key, rotation.Config{
+//	    Registry: registry.NewEthereumKeyRegistry(reg, ownerKey),
+//	    Overlap:  time.Hour,
+//	    UpdateCard: func(ctx context.Context, newKey sagecrypto.KeyPair) error {
+//	        return republishCard(ctx, newKey)
+//	    },
+//	    Invalidate: []verifier.Invalidator{cachedResolver},
+//	    OnProgress: func(p rotation.Progress) {
+//	        log.Printf("rotation of %s: %s", p.DID, p.Phase)
+//	    },
+//	})
+//	err = manager.Rotate(ctx, newKey)
+//
+// During the overlap window both keys are registered, so peers holding
+// cached keys or requests signed just before the switch still verify:
+// verifier.DefaultDIDVerifier accepts signatures by any registered key of
+// the signature's type. Rotate blocks through the window; long-running
+// services can call Begin and later Retire instead, e.g. from a scheduled
+// job.
+//
+// # On-Chain Keys
+//
+// SageRegistryV4 binds an agent's ECDSA key to the account that owns the
+// agent, so only Ed25519 keys can be rotated on chain; adding another
+// ECDSA key fails with registry.ErrKeyNotOwned. New Ed25519 keys are not
+// used by verifiers until the contract owner approves them, so the
+// overlap window must cover that approval.
+package rotation
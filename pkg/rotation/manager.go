@@ -0,0 +1,254 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package rotation
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultOverlap is how long old and new keys are both registered when
+// Config.Overlap is zero
+const DefaultOverlap = 24 * time.Hour
+
+var (
+	// ErrRotationInProgress is returned by Begin while a rotation has not
+	// been retired
+	ErrRotationInProgress = errors.New("key rotation already in progress")
+
+	// ErrNoRotation is returned by Retire when no rotation is in progress
+	ErrNoRotation = errors.New("no key rotation in progress")
+)
+
+// Phase is a step of a key rotation
+type Phase string
+
+const (
+	// PhaseRegistering: the new key is being registered on chain
+	PhaseRegistering Phase = "registering"
+
+	// PhaseOverlap: both keys are registered and the agent signs with the
+	// new key
+	PhaseOverlap Phase = "overlap"
+
+	// PhaseRetiring: the old key is being revoked
+	PhaseRetiring Phase = "retiring"
+
+	// PhaseCompleted: only the new key remains
+	PhaseCompleted Phase = "completed"
+
+	// PhaseFailed: a step failed; see Progress.Err
+	PhaseFailed Phase = "failed"
+)
+
+// Progress reports the state of a rotation
+type Progress struct {
+	DID      did.AgentDID
+	Phase    Phase
+	OldKeyID string
+	NewKeyID string
+
+	// OverlapEnds is when the old key is due to be retired, once known
+	OverlapEnds time.Time
+
+	// Err is the error that failed the rotation, for PhaseFailed
+	Err error
+}
+
+// KeyRegistry adds and revokes single keys of an agent on chain.
+// registry.EthereumKeyRegistry implements it over the SageRegistryV4
+// contract, and registry.MemoryRegistry in memory.
+type KeyRegistry interface {
+	AddKey(ctx context.Context, agentDID did.AgentDID, key crypto.PublicKey) error
+	RevokeKey(ctx context.Context, agentDID did.AgentDID, key crypto.PublicKey) error
+}
+
+// Config configures a Manager
+type Config struct {
+	// Registry registers and revokes keys (required)
+	Registry KeyRegistry
+
+	// Overlap is how long both keys stay registered (default
+	// DefaultOverlap)
+	Overlap time.Duration
+
+	// UpdateCard, if set, publishes an Agent Card listing newKey, e.g. by
+	// re-signing the card served by server.AgentCardHandler. It is called
+	// after signing has switched to the new key.
+	UpdateCard func(ctx context.Context, newKey sagecrypto.KeyPair) error
+
+	// Invalidate are caches holding the agent's keys, flushed when the
+	// new key is registered and when the old key is retired
+	Invalidate []verifier.Invalidator
+
+	// OnProgress, if set, is called at every phase change
+	OnProgress func(Progress)
+}
+
+// Manager orchestrates key rotations of one agent. It is safe for
+// concurrent use.
+type Manager struct {
+	agentDID did.AgentDID
+	key      *signer.RotatingKeyPair
+	config   Config
+	now      func() time.Time
+
+	mu      sync.Mutex
+	pending *rotation
+}
+
+// rotation is a rotation whose old key is not retired yet
+type rotation struct {
+	oldKey      sagecrypto.KeyPair
+	newKey      sagecrypto.KeyPair
+	overlapEnds time.Time
+}
+
+// NewManager creates a Manager rotating the key agentDID signs with
+func NewManager(agentDID did.AgentDID, key *signer.RotatingKeyPair, config Config) (*Manager, error) {
+	if config.Registry == nil {
+		return nil, errors.New("rotation: key registry is required")
+	}
+	if config.Overlap <= 0 {
+		config.Overlap = DefaultOverlap
+	}
+	return &Manager{agentDID: agentDID, key: key, config: config, now: time.Now}, nil
+}
+
+// Rotate runs a whole rotation to newKey: Begin, waiting out the overlap
+// window, then Retire. If ctx ends during the window, the rotation stays
+// pending and can be finished with Retire.
+func (m *Manager) Rotate(ctx context.Context, newKey sagecrypto.KeyPair) error {
+	overlapEnds, err := m.Begin(ctx, newKey)
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(time.Until(overlapEnds))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+	return m.Retire(ctx)
+}
+
+// Begin registers newKey on chain, switches signing to it and publishes
+// the updated card, returning when the overlap window ends. If a step
+// fails, the new key is revoked and signing switches back.
+func (m *Manager) Begin(ctx context.Context, newKey sagecrypto.KeyPair) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending != nil {
+		return time.Time{}, ErrRotationInProgress
+	}
+
+	r := &rotation{oldKey: m.key.Current(), newKey: newKey}
+	m.report(r, PhaseRegistering, nil)
+	if err := m.config.Registry.AddKey(ctx, m.agentDID, newKey.PublicKey()); err != nil {
+		return time.Time{}, m.fail(r, fmt.Errorf("failed to register new key: %w", err))
+	}
+	m.invalidate()
+
+	m.key.Switch(newKey)
+	if m.config.UpdateCard != nil {
+		if err := m.config.UpdateCard(ctx, newKey); err != nil {
+			m.key.Switch(r.oldKey)
+			if revokeErr := m.config.Registry.RevokeKey(ctx, m.agentDID, newKey.PublicKey()); revokeErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to revoke new key: %w", revokeErr))
+			}
+			m.invalidate()
+			return time.Time{}, m.fail(r, fmt.Errorf("failed to update agent card: %w", err))
+		}
+	}
+
+	r.overlapEnds = m.now().Add(m.config.Overlap)
+	m.pending = r
+	m.report(r, PhaseOverlap, nil)
+	return r.overlapEnds, nil
+}
+
+// Retire revokes the old key of the pending rotation, ending its overlap
+// window early if it has not elapsed
+func (m *Manager) Retire(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := m.pending
+	if r == nil {
+		return ErrNoRotation
+	}
+
+	m.report(r, PhaseRetiring, nil)
+	if err := m.config.Registry.RevokeKey(ctx, m.agentDID, r.oldKey.PublicKey()); err != nil {
+		// The rotation stays pending so Retire can be retried
+		return m.fail(r, fmt.Errorf("failed to revoke old key: %w", err))
+	}
+	m.invalidate()
+	m.pending = nil
+	m.report(r, PhaseCompleted, nil)
+	return nil
+}
+
+// Pending returns the progress of the rotation awaiting Retire, if any
+func (m *Manager) Pending() (Progress, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending == nil {
+		return Progress{}, false
+	}
+	return m.progress(m.pending, PhaseOverlap, nil), true
+}
+
+func (m *Manager) invalidate() {
+	for _, cache := range m.config.Invalidate {
+		cache.Invalidate(m.agentDID)
+	}
+}
+
+// fail reports err as a failed phase and returns it
+func (m *Manager) fail(r *rotation, err error) error {
+	m.report(r, PhaseFailed, err)
+	return err
+}
+
+func (m *Manager) report(r *rotation, phase Phase, err error) {
+	if m.config.OnProgress != nil {
+		m.config.OnProgress(m.progress(r, phase, err))
+	}
+}
+
+func (m *Manager) progress(r *rotation, phase Phase, err error) Progress {
+	return Progress{
+		DID:         m.agentDID,
+		Phase:       phase,
+		OldKeyID:    r.oldKey.ID(),
+		NewKeyID:    r.newKey.ID(),
+		OverlapEnds: r.overlapEnds,
+		Err:         err,
+	}
+}
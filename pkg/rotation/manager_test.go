@@ -0,0 +1,162 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package rotation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ KeyRegistry = (*registry.MemoryRegistry)(nil)
+	_ KeyRegistry = (*registry.EthereumKeyRegistry)(nil)
+)
+
+const testDID = did.AgentDID("did:sage:ethereum:0xrotating")
+
+type countingCache struct{ invalidated []did.AgentDID }
+
+func (c *countingCache) Invalidate(agentDID did.AgentDID) {
+	c.invalidated = append(c.invalidated, agentDID)
+}
+
+type rotationFixture struct {
+	reg      *registry.MemoryRegistry
+	verifier verifier.DIDVerifier
+	key      *signer.RotatingKeyPair
+	oldKey   sagecrypto.KeyPair
+	newKey   sagecrypto.KeyPair
+}
+
+func newRotationFixture(t *testing.T) *rotationFixture {
+	oldKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	newKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	reg := registry.NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(context.Background(), testDID, oldKey.PublicKey()))
+	return &rotationFixture{
+		reg:      reg,
+		verifier: reg.NewDIDVerifier(),
+		key:      signer.NewRotatingKeyPair(oldKey),
+		oldKey:   oldKey,
+		newKey:   newKey,
+	}
+}
+
+// sign signs a request with keyPair
+func (f *rotationFixture) sign(t *testing.T, keyPair sagecrypto.KeyPair) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "http://agent.example/rpc", strings.NewReader(`{"jsonrpc":"2.0"}`))
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, testDID, keyPair))
+	return req
+}
+
+func (f *rotationFixture) verify(req *http.Request) error {
+	_, err := f.verifier.VerifyHTTPSignatureWithKeyID(context.Background(), req)
+	return err
+}
+
+func TestManager_BeginRetire(t *testing.T) {
+	f := newRotationFixture(t)
+	cache := &countingCache{}
+	var phases []Phase
+	var published sagecrypto.KeyPair
+	m, err := NewManager(testDID, f.key, Config{
+		Registry: f.reg,
+		Overlap:  time.Hour,
+		UpdateCard: func(ctx context.Context, newKey sagecrypto.KeyPair) error {
+			published = newKey
+			return nil
+		},
+		Invalidate: []verifier.Invalidator{cache},
+		OnProgress: func(p Progress) { phases = append(phases, p.Phase) },
+	})
+	require.NoError(t, err)
+
+	signedBefore := f.sign(t, f.key)
+	overlapEnds, err := m.Begin(context.Background(), f.newKey)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), overlapEnds, time.Minute)
+	assert.Equal(t, f.newKey, published)
+	assert.Equal(t, f.newKey.PublicKey(), f.key.PublicKey(), "signer switched")
+
+	// During the overlap, requests signed with either key verify
+	assert.NoError(t, f.verify(signedBefore))
+	assert.NoError(t, f.verify(f.sign(t, f.key)))
+
+	p, ok := m.Pending()
+	require.True(t, ok)
+	assert.Equal(t, f.newKey.ID(), p.NewKeyID)
+	_, err = m.Begin(context.Background(), f.newKey)
+	assert.ErrorIs(t, err, ErrRotationInProgress)
+
+	require.NoError(t, m.Retire(context.Background()))
+	assert.Error(t, f.verify(f.sign(t, f.oldKey)))
+	assert.NoError(t, f.verify(f.sign(t, f.key)))
+
+	assert.Equal(t, []Phase{PhaseRegistering, PhaseOverlap, PhaseRetiring, PhaseCompleted}, phases)
+	assert.Equal(t, []did.AgentDID{testDID, testDID}, cache.invalidated)
+	assert.ErrorIs(t, m.Retire(context.Background()), ErrNoRotation)
+}
+
+func TestManager_Rotate(t *testing.T) {
+	f := newRotationFixture(t)
+	m, err := NewManager(testDID, f.key, Config{Registry: f.reg, Overlap: time.Millisecond})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Rotate(context.Background(), f.newKey))
+	_, ok := m.Pending()
+	assert.False(t, ok)
+	meta, err := f.reg.GetAgentByDID(context.Background(), string(testDID))
+	require.NoError(t, err)
+	assert.Len(t, meta.Keys, 1)
+}
+
+func TestManager_CardUpdateFailureRollsBack(t *testing.T) {
+	f := newRotationFixture(t)
+	var last Progress
+	m, err := NewManager(testDID, f.key, Config{
+		Registry: f.reg,
+		UpdateCard: func(ctx context.Context, newKey sagecrypto.KeyPair) error {
+			return errors.New("card store unavailable")
+		},
+		OnProgress: func(p Progress) { last = p },
+	})
+	require.NoError(t, err)
+
+	_, err = m.Begin(context.Background(), f.newKey)
+	require.Error(t, err)
+	assert.Equal(t, PhaseFailed, last.Phase)
+	assert.Equal(t, f.oldKey.PublicKey(), f.key.PublicKey(), "signer switched back")
+	assert.Error(t, f.verify(f.sign(t, f.newKey)), "new key revoked")
+	assert.NoError(t, f.verify(f.sign(t, f.oldKey)))
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// AnomalyKind identifies the heuristic that flagged a request
+type AnomalyKind string
+
+const (
+	// AnomalyManyIPs is reported when one DID is seen from too many IPs
+	AnomalyManyIPs AnomalyKind = "many_ips"
+
+	// AnomalyUnusualHour is reported for requests outside the active hours
+	AnomalyUnusualHour AnomalyKind = "unusual_hour"
+
+	// AnomalyFailureBurst is reported when one DID fails verification repeatedly
+	AnomalyFailureBurst AnomalyKind = "failure_burst"
+)
+
+// Anomaly describes a suspicious request
+type Anomaly struct {
+	Kind        AnomalyKind
+	AgentDID    did.AgentDID
+	Fingerprint *RequestFingerprint
+	Detail      string
+}
+
+// AnomalyConfig configures the built-in heuristic detector.
+// Zero values disable the corresponding heuristic.
+type AnomalyConfig struct {
+	// Window is the sliding window for per-DID statistics
+	Window time.Duration
+
+	// MaxIPsPerDID flags a DID seen from more distinct IPs within Window
+	MaxIPsPerDID int
+
+	// MaxFailuresPerDID flags a DID failing verification more often within Window
+	MaxFailuresPerDID int
+
+	// ActiveHoursStart and ActiveHoursEnd (UTC, 0-23) bound normal activity.
+	// Requests outside [start, end) are flagged. Equal values disable the check.
+	ActiveHoursStart int
+	ActiveHoursEnd   int
+
+	// OnAnomaly is called for each detected anomaly
+	OnAnomaly func(Anomaly)
+}
+
+// DefaultAnomalyConfig returns a configuration flagging DIDs seen from more
+// than 5 IPs or failing more than 10 times within 10 minutes
+func DefaultAnomalyConfig() AnomalyConfig {
+	return AnomalyConfig{
+		Window:            10 * time.Minute,
+		MaxIPsPerDID:      5,
+		MaxFailuresPerDID: 10,
+	}
+}
+
+// AnomalyDetector is a simple in-memory heuristic detector fed by request
+// fingerprints. It is safe for concurrent use.
+type AnomalyDetector struct {
+	config AnomalyConfig
+	now    func() time.Time
+
+	mu    sync.Mutex
+	stats map[did.AgentDID]*didStats
+}
+
+type didStats struct {
+	ips      map[string]time.Time
+	failures []time.Time
+}
+
+// NewAnomalyDetector creates a detector with the given configuration
+func NewAnomalyDetector(config AnomalyConfig) *AnomalyDetector {
+	return &AnomalyDetector{
+		config: config,
+		now:    time.Now,
+		stats:  make(map[did.AgentDID]*didStats),
+	}
+}
+
+// Hook returns a FingerprintHook feeding this detector
+//
+// Example:
+//
+//	detector := server.NewAnomalyDetector(server.DefaultAnomalyConfig())
+//	middleware.SetFingerprintHook(detector.Hook())
+func (d *AnomalyDetector) Hook() FingerprintHook {
+	return func(_ *http.Request, fp *RequestFingerprint) {
+		d.Observe(fp)
+	}
+}
+
+// Observe records a fingerprint and returns any anomalies it triggers
+func (d *AnomalyDetector) Observe(fp *RequestFingerprint) []Anomaly {
+	if fp == nil || fp.AgentDID == "" {
+		return nil
+	}
+
+	now := d.now()
+	var anomalies []Anomaly
+
+	d.mu.Lock()
+	s, ok := d.stats[fp.AgentDID]
+	if !ok {
+		s = &didStats{ips: make(map[string]time.Time)}
+		d.stats[fp.AgentDID] = s
+	}
+	d.prune(s, now)
+
+	if d.config.MaxIPsPerDID > 0 && fp.RemoteIP != "" {
+		s.ips[fp.RemoteIP] = now
+		if len(s.ips) > d.config.MaxIPsPerDID {
+			anomalies = append(anomalies, Anomaly{
+				Kind:   AnomalyManyIPs,
+				Detail: fmt.Sprintf("seen from %d IPs", len(s.ips)),
+			})
+		}
+	}
+
+	if d.config.MaxFailuresPerDID > 0 && !fp.Verified {
+		s.failures = append(s.failures, now)
+		if len(s.failures) > d.config.MaxFailuresPerDID {
+			anomalies = append(anomalies, Anomaly{
+				Kind:   AnomalyFailureBurst,
+				Detail: fmt.Sprintf("%d verification failures", len(s.failures)),
+			})
+		}
+	}
+	d.mu.Unlock()
+
+	if start, end := d.config.ActiveHoursStart, d.config.ActiveHoursEnd; start != end {
+		hour := fp.Timestamp.UTC().Hour()
+		inside := hour >= start && hour < end
+		if start > end {
+			// Window wraps around midnight
+			inside = hour >= start || hour < end
+		}
+		if !inside {
+			anomalies = append(anomalies, Anomaly{
+				Kind:   AnomalyUnusualHour,
+				Detail: fmt.Sprintf("request at %02d:00 UTC", hour),
+			})
+		}
+	}
+
+	for i := range anomalies {
+		anomalies[i].AgentDID = fp.AgentDID
+		anomalies[i].Fingerprint = fp
+		if d.config.OnAnomaly != nil {
+			d.config.OnAnomaly(anomalies[i])
+		}
+	}
+
+	return anomalies
+}
+
+// prune drops statistics older than the window
+func (d *AnomalyDetector) prune(s *didStats, now time.Time) {
+	if d.config.Window <= 0 {
+		return
+	}
+	cutoff := now.Add(-d.config.Window)
+	for ip, seen := range s.ips {
+		if seen.Before(cutoff) {
+			delete(s.ips, ip)
+		}
+	}
+	i := 0
+	for i < len(s.failures) && s.failures[i].Before(cutoff) {
+		i++
+	}
+	s.failures = s.failures[i:]
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// NewArtifactSealingExecutor wraps next so every TaskArtifactUpdateEvent it
+// emits carries a digest, and a signature when sealer has a key pair. Clients
+// check them with protocol.VerifyArtifactEvent.
+func NewArtifactSealingExecutor(next a2asrv.AgentExecutor, sealer *protocol.ArtifactSealer) a2asrv.AgentExecutor {
+	return &sealingExecutor{next: next, sealer: sealer}
+}
+
+type sealingExecutor struct {
+	next   a2asrv.AgentExecutor
+	sealer *protocol.ArtifactSealer
+}
+
+// Execute implements a2asrv.AgentExecutor
+func (e *sealingExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return e.next.Execute(ctx, reqCtx, &sealingQueue{Queue: queue, sealer: e.sealer})
+}
+
+// Cancel implements a2asrv.AgentExecutor
+func (e *sealingExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return e.next.Cancel(ctx, reqCtx, &sealingQueue{Queue: queue, sealer: e.sealer})
+}
+
+// sealingQueue seals artifact events as they are written
+type sealingQueue struct {
+	eventqueue.Queue
+	sealer *protocol.ArtifactSealer
+}
+
+func (q *sealingQueue) Write(ctx context.Context, event a2a.Event) error {
+	if ev, ok := event.(*a2a.TaskArtifactUpdateEvent); ok {
+		if err := q.sealer.Seal(ev); err != nil {
+			return fmt.Errorf("failed to seal artifact: %w", err)
+		}
+	}
+	return q.Queue.Write(ctx, event)
+}
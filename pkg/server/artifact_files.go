@@ -0,0 +1,345 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+var (
+	// ErrArtifactFileNotFound is returned for unknown artifact files
+	ErrArtifactFileNotFound = errors.New("artifact file not found")
+
+	// ErrArtifactOffset is returned when an upload chunk does not start at
+	// the number of bytes already stored
+	ErrArtifactOffset = errors.New("upload offset does not match stored size")
+
+	// ErrArtifactComplete is returned when appending to a complete file
+	ErrArtifactComplete = errors.New("artifact file already complete")
+)
+
+// artifactIDPattern restricts file IDs to names that are safe as URL path
+// segments and file names
+var artifactIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]{0,127}$`)
+
+// ValidArtifactFileID reports whether id may be used as an artifact file ID
+func ValidArtifactFileID(id string) bool {
+	return artifactIDPattern.MatchString(id)
+}
+
+// ArtifactFileInfo describes a stored, possibly partial, artifact file
+type ArtifactFileInfo struct {
+	ID string `json:"id"`
+
+	// Owner is the DID that uploaded the file; only it may append to or
+	// delete the file
+	Owner did.AgentDID `json:"owner"`
+
+	// Size is the number of bytes stored so far
+	Size int64 `json:"size"`
+
+	// Total is the declared size of the complete file, or -1 if unknown
+	Total int64 `json:"total"`
+
+	// Complete is set once Total bytes have been stored
+	Complete bool `json:"complete"`
+
+	// Digest is the sha-256 Repr-Digest value of the complete file
+	Digest string `json:"digest,omitempty"`
+
+	ModTime time.Time `json:"mod_time"`
+}
+
+// ArtifactFileStore stores artifact files uploaded in one or more chunks
+type ArtifactFileStore interface {
+	// Stat returns the file's info or ErrArtifactFileNotFound
+	Stat(ctx context.Context, id string) (*ArtifactFileInfo, error)
+
+	// Append stores data at offset, which must equal the stored size
+	// (ErrArtifactOffset), creating the file owned by owner if it does not
+	// exist. total is the declared size of the complete file, or -1 if
+	// data ends the file.
+	Append(ctx context.Context, id string, owner did.AgentDID, offset, total int64, data []byte) (*ArtifactFileInfo, error)
+
+	// Open opens the stored content for reading
+	Open(ctx context.Context, id string) (io.ReadSeekCloser, *ArtifactFileInfo, error)
+
+	// Delete removes the file
+	Delete(ctx context.Context, id string) error
+}
+
+// appendInfo validates a chunk against the stored info and returns the
+// updated info. Stores fill in the digest on completion.
+func appendInfo(info *ArtifactFileInfo, id string, owner did.AgentDID, offset, total int64, n int) (*ArtifactFileInfo, error) {
+	if info == nil {
+		info = &ArtifactFileInfo{ID: id, Owner: owner, Total: -1}
+	}
+	if info.Complete {
+		return nil, ErrArtifactComplete
+	}
+	if offset != info.Size {
+		return nil, fmt.Errorf("%w: stored %d bytes, chunk starts at %d", ErrArtifactOffset, info.Size, offset)
+	}
+	if total < 0 {
+		total = offset + int64(n)
+	}
+	if info.Total >= 0 && total != info.Total {
+		return nil, fmt.Errorf("declared size changed from %d to %d", info.Total, total)
+	}
+	if offset+int64(n) > total {
+		return nil, fmt.Errorf("chunk ends past declared size %d", total)
+	}
+
+	next := *info
+	next.Size = offset + int64(n)
+	next.Total = total
+	next.Complete = next.Size == total
+	next.ModTime = time.Now()
+	return &next, nil
+}
+
+// MemoryArtifactFileStore is an in-memory ArtifactFileStore
+type MemoryArtifactFileStore struct {
+	mu    sync.RWMutex
+	files map[string]*memoryArtifactFile
+}
+
+type memoryArtifactFile struct {
+	info ArtifactFileInfo
+	data []byte
+}
+
+// NewMemoryArtifactFileStore creates an empty in-memory artifact file store
+func NewMemoryArtifactFileStore() *MemoryArtifactFileStore {
+	return &MemoryArtifactFileStore{files: make(map[string]*memoryArtifactFile)}
+}
+
+// Stat implements ArtifactFileStore
+func (s *MemoryArtifactFileStore) Stat(ctx context.Context, id string) (*ArtifactFileInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.files[id]
+	if !ok {
+		return nil, ErrArtifactFileNotFound
+	}
+	info := f.info
+	return &info, nil
+}
+
+// Append implements ArtifactFileStore
+func (s *MemoryArtifactFileStore) Append(ctx context.Context, id string, owner did.AgentDID, offset, total int64, data []byte) (*ArtifactFileInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.files[id]
+	var current *ArtifactFileInfo
+	if f != nil {
+		current = &f.info
+	}
+	info, err := appendInfo(current, id, owner, offset, total, len(data))
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		f = &memoryArtifactFile{}
+		s.files[id] = f
+	}
+	f.data = append(f.data, data...)
+	if info.Complete {
+		info.Digest, _ = signer.ComputeContentDigest(signer.DigestSHA256, f.data)
+	}
+	f.info = *info
+	out := *info
+	return &out, nil
+}
+
+// Open implements ArtifactFileStore
+func (s *MemoryArtifactFileStore) Open(ctx context.Context, id string) (io.ReadSeekCloser, *ArtifactFileInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.files[id]
+	if !ok {
+		return nil, nil, ErrArtifactFileNotFound
+	}
+	info := f.info
+	return nopSeekCloser{bytes.NewReader(f.data[:info.Size:info.Size])}, &info, nil
+}
+
+// Delete implements ArtifactFileStore
+func (s *MemoryArtifactFileStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.files[id]; !ok {
+		return ErrArtifactFileNotFound
+	}
+	delete(s.files, id)
+	return nil
+}
+
+// Files returns the info of every stored file, sorted by ID
+func (s *MemoryArtifactFileStore) Files() []*ArtifactFileInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	infos := make([]*ArtifactFileInfo, 0, len(s.files))
+	for _, f := range s.files {
+		info := f.info
+		infos = append(infos, &info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+type nopSeekCloser struct{ io.ReadSeeker }
+
+func (nopSeekCloser) Close() error { return nil }
+
+// DirArtifactFileStore stores artifact files in a directory, each as a
+// content file "<id>.data" and an info file "<id>.json"
+type DirArtifactFileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewDirArtifactFileStore creates a store in dir, creating it if needed
+func NewDirArtifactFileStore(dir string) (*DirArtifactFileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+	return &DirArtifactFileStore{dir: dir}, nil
+}
+
+func (s *DirArtifactFileStore) path(id, ext string) string {
+	return filepath.Join(s.dir, id+ext)
+}
+
+// Stat implements ArtifactFileStore
+func (s *DirArtifactFileStore) Stat(ctx context.Context, id string) (*ArtifactFileInfo, error) {
+	if !ValidArtifactFileID(id) {
+		return nil, ErrArtifactFileNotFound
+	}
+	data, err := os.ReadFile(s.path(id, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrArtifactFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact info: %w", err)
+	}
+	var info ArtifactFileInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode artifact info: %w", err)
+	}
+	return &info, nil
+}
+
+// Append implements ArtifactFileStore. The info file is written after the
+// content, so a crash mid-write is recovered by the next chunk overwriting
+// the unaccounted tail.
+func (s *DirArtifactFileStore) Append(ctx context.Context, id string, owner did.AgentDID, offset, total int64, data []byte) (*ArtifactFileInfo, error) {
+	if !ValidArtifactFileID(id) {
+		return nil, fmt.Errorf("invalid artifact file ID %q", id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.Stat(ctx, id)
+	if errors.Is(err, ErrArtifactFileNotFound) {
+		current = nil
+	} else if err != nil {
+		return nil, err
+	}
+	info, err := appendInfo(current, id, owner, offset, total, len(data))
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(s.path(id, ".data"), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteAt(data, offset); err != nil {
+		return nil, fmt.Errorf("failed to write artifact file: %w", err)
+	}
+	if err := f.Truncate(info.Size); err != nil {
+		return nil, fmt.Errorf("failed to write artifact file: %w", err)
+	}
+	if info.Complete {
+		digester, _ := signer.NewContentDigester(signer.DigestSHA256)
+		if _, err := io.Copy(digester, io.NewSectionReader(f, 0, info.Size)); err != nil {
+			return nil, fmt.Errorf("failed to read artifact file: %w", err)
+		}
+		info.Digest = digester.Value()
+	}
+
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode artifact info: %w", err)
+	}
+	tmp := s.path(id, ".json.tmp")
+	if err := os.WriteFile(tmp, encoded, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write artifact info: %w", err)
+	}
+	if err := os.Rename(tmp, s.path(id, ".json")); err != nil {
+		return nil, fmt.Errorf("failed to write artifact info: %w", err)
+	}
+	return info, nil
+}
+
+// Open implements ArtifactFileStore
+func (s *DirArtifactFileStore) Open(ctx context.Context, id string) (io.ReadSeekCloser, *ArtifactFileInfo, error) {
+	info, err := s.Stat(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(s.path(id, ".data"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open artifact file: %w", err)
+	}
+	return struct {
+		*io.SectionReader
+		io.Closer
+	}{io.NewSectionReader(f, 0, info.Size), f}, info, nil
+}
+
+// Delete implements ArtifactFileStore
+func (s *DirArtifactFileStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.Stat(ctx, id); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(id, ".json")); err != nil {
+		return fmt.Errorf("failed to delete artifact file: %w", err)
+	}
+	if err := os.Remove(s.path(id, ".data")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete artifact file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// DefaultArtifactStreamChunkSize is the content size of each chunk an
+// ArtifactStreamWriter emits unless configured otherwise
+const DefaultArtifactStreamChunkSize = 64 << 10
+
+// ErrArtifactStreamClosed is returned when writing to a closed
+// ArtifactStreamWriter
+var ErrArtifactStreamClosed = errors.New("artifact stream closed")
+
+// ArtifactStreamConfig describes an artifact streamed in chunks
+type ArtifactStreamConfig struct {
+	// ID identifies the artifact within the task (generated when empty)
+	ID a2a.ArtifactID
+
+	// Name, Description and Metadata are sent with the first chunk
+	Name        string
+	Description string
+	Metadata    map[string]any
+
+	// Text sends the content as text parts, split on UTF-8 boundaries.
+	// Otherwise it is sent as file parts with MimeType and FileName.
+	Text     bool
+	MimeType string
+	FileName string
+
+	// ChunkSize is the content size of each chunk (default
+	// DefaultArtifactStreamChunkSize)
+	ChunkSize int
+}
+
+// ArtifactStreamWriter streams an artifact produced by an agent executor
+// as a sequence of TaskArtifactUpdateEvent chunks, following the A2A
+// artifact chunking model: the first chunk creates the artifact, later
+// ones have append set and the last one has lastChunk set. Content is
+// buffered up to one chunk; Close sends the last chunk.
+type ArtifactStreamWriter struct {
+	ctx    context.Context
+	queue  eventqueue.Writer
+	taskID a2a.TaskID
+	ctxID  string
+	config ArtifactStreamConfig
+
+	buf    []byte
+	sent   int // chunks sent
+	closed bool
+}
+
+// NewArtifactStreamWriter creates a writer streaming an artifact of the
+// task of reqCtx to queue. Events are written with ctx.
+func NewArtifactStreamWriter(ctx context.Context, queue eventqueue.Writer, reqCtx *a2asrv.RequestContext, config ArtifactStreamConfig) *ArtifactStreamWriter {
+	if config.ID == "" {
+		config.ID = a2a.NewArtifactID()
+	}
+	if config.ChunkSize <= 0 {
+		config.ChunkSize = DefaultArtifactStreamChunkSize
+	}
+	return &ArtifactStreamWriter{
+		ctx:    ctx,
+		queue:  queue,
+		taskID: reqCtx.TaskID,
+		ctxID:  reqCtx.ContextID,
+		config: config,
+	}
+}
+
+// ID returns the ID of the streamed artifact
+func (w *ArtifactStreamWriter) ID() a2a.ArtifactID {
+	return w.config.ID
+}
+
+// Write implements io.Writer, sending every full chunk
+func (w *ArtifactStreamWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrArtifactStreamClosed
+	}
+	w.buf = append(w.buf, p...)
+	for len(w.buf) > w.config.ChunkSize {
+		n := w.config.ChunkSize
+		if w.config.Text {
+			// Never split a UTF-8 sequence across text parts
+			for n > 0 && !utf8.RuneStart(w.buf[n]) {
+				n--
+			}
+			if n == 0 {
+				_, n = utf8.DecodeRune(w.buf)
+			}
+		}
+		if err := w.send(w.buf[:n], false); err != nil {
+			return len(p), err
+		}
+		w.buf = append(w.buf[:0], w.buf[n:]...)
+	}
+	return len(p), nil
+}
+
+// Close sends the buffered content as the last chunk. An artifact
+// without content is sent as a single chunk without content.
+func (w *ArtifactStreamWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	err := w.send(w.buf, true)
+	w.buf = nil
+	return err
+}
+
+// send writes one chunk event
+func (w *ArtifactStreamWriter) send(content []byte, last bool) error {
+	artifact := &a2a.Artifact{ID: w.config.ID, Parts: a2a.ContentParts{}}
+	switch {
+	case w.config.Text:
+		artifact.Parts = append(artifact.Parts, a2a.TextPart{Text: string(content)})
+	case len(content) > 0:
+		// File parts must not be empty, so an empty artifact has no parts
+		artifact.Parts = append(artifact.Parts, a2a.FilePart{File: a2a.FileBytes{
+			FileMeta: a2a.FileMeta{MimeType: w.config.MimeType, Name: w.config.FileName},
+			Bytes:    base64.StdEncoding.EncodeToString(content),
+		}})
+	}
+	if w.sent == 0 {
+		artifact.Name = w.config.Name
+		artifact.Description = w.config.Description
+		artifact.Metadata = w.config.Metadata
+	}
+	event := &a2a.TaskArtifactUpdateEvent{
+		TaskID:    w.taskID,
+		ContextID: w.ctxID,
+		Artifact:  artifact,
+		Append:    w.sent > 0,
+		LastChunk: last,
+	}
+	w.sent++
+	return w.queue.Write(w.ctx, event)
+}
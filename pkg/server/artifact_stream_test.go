@@ -0,0 +1,106 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder is an eventqueue.Writer recording artifact events
+type eventRecorder struct {
+	events []*a2a.TaskArtifactUpdateEvent
+}
+
+func (r *eventRecorder) Write(ctx context.Context, event a2a.Event) error {
+	r.events = append(r.events, event.(*a2a.TaskArtifactUpdateEvent))
+	return nil
+}
+
+func TestArtifactStreamWriter(t *testing.T) {
+	ctx := context.Background()
+	reqCtx := &a2asrv.RequestContext{TaskID: "task-1", ContextID: "ctx-1"}
+
+	t.Run("file chunks", func(t *testing.T) {
+		rec := &eventRecorder{}
+		w := NewArtifactStreamWriter(ctx, rec, reqCtx, ArtifactStreamConfig{
+			ID:        "report",
+			Name:      "report.bin",
+			MimeType:  "application/octet-stream",
+			ChunkSize: 4,
+		})
+		_, err := w.Write([]byte("0123456"))
+		require.NoError(t, err)
+		_, err = w.Write([]byte("789"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, w.Close())
+		_, err = w.Write([]byte("x"))
+		assert.ErrorIs(t, err, ErrArtifactStreamClosed)
+
+		require.Len(t, rec.events, 3)
+		var content []string
+		for i, ev := range rec.events {
+			assert.Equal(t, a2a.TaskID("task-1"), ev.TaskID)
+			assert.Equal(t, "ctx-1", ev.ContextID)
+			assert.Equal(t, a2a.ArtifactID("report"), ev.Artifact.ID)
+			assert.Equal(t, i > 0, ev.Append)
+			assert.Equal(t, i == 2, ev.LastChunk)
+			file := ev.Artifact.Parts[0].(a2a.FilePart).File.(a2a.FileBytes)
+			assert.Equal(t, "application/octet-stream", file.MimeType)
+			data, err := base64.StdEncoding.DecodeString(file.Bytes)
+			require.NoError(t, err)
+			content = append(content, string(data))
+		}
+		assert.Equal(t, []string{"0123", "4567", "89"}, content)
+		assert.Equal(t, "report.bin", rec.events[0].Artifact.Name)
+		assert.Empty(t, rec.events[1].Artifact.Name)
+	})
+
+	t.Run("text chunks keep runes whole", func(t *testing.T) {
+		rec := &eventRecorder{}
+		w := NewArtifactStreamWriter(ctx, rec, reqCtx, ArtifactStreamConfig{Text: true, ChunkSize: 4})
+		assert.NotEmpty(t, w.ID())
+		_, err := w.Write([]byte("abc한글"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		var chunks []string
+		for _, ev := range rec.events {
+			chunks = append(chunks, ev.Artifact.Parts[0].(a2a.TextPart).Text)
+		}
+		assert.Equal(t, []string{"abc", "한", "글"}, chunks)
+		assert.Equal(t, "abc한글", strings.Join(chunks, ""))
+	})
+
+	t.Run("empty artifact", func(t *testing.T) {
+		rec := &eventRecorder{}
+		require.NoError(t, NewArtifactStreamWriter(ctx, rec, reqCtx, ArtifactStreamConfig{}).Close())
+		require.Len(t, rec.events, 1)
+		assert.True(t, rec.events[0].LastChunk)
+		assert.False(t, rec.events[0].Append)
+		assert.Empty(t, rec.events[0].Artifact.Parts)
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// artifactExecutor emits a single artifact event
+type artifactExecutor struct{}
+
+func (artifactExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return queue.Write(ctx, &a2a.TaskArtifactUpdateEvent{
+		TaskID:   reqCtx.TaskID,
+		Artifact: &a2a.Artifact{ID: "artifact-1", Parts: a2a.ContentParts{a2a.TextPart{Text: "result"}}},
+	})
+}
+
+func (artifactExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return nil
+}
+
+func TestArtifactSealingExecutor(t *testing.T) {
+	queue := eventqueue.NewInMemoryQueue(4)
+	executor := NewArtifactSealingExecutor(artifactExecutor{}, &protocol.ArtifactSealer{})
+
+	ctx := context.Background()
+	require.NoError(t, executor.Execute(ctx, &a2asrv.RequestContext{TaskID: "task-1"}, queue))
+
+	event, err := queue.Read(ctx)
+	require.NoError(t, err)
+	artifactEvent, ok := event.(*a2a.TaskArtifactUpdateEvent)
+	require.True(t, ok)
+	assert.NotEmpty(t, artifactEvent.Metadata[protocol.ArtifactDigestKey])
+	assert.NoError(t, protocol.VerifyArtifactEvent(artifactEvent, nil))
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// AttestationResolver returns the attestation tokens an agent presents,
+// typically the Attestations of its published protocol.AgentCard
+type AttestationResolver func(ctx context.Context, agentDID did.AgentDID) ([]string, error)
+
+// AttestationRequirement is met by a valid attestation of Claim signed by
+// one of Attesters
+type AttestationRequirement struct {
+	Claim     string
+	Attesters []did.AgentDID
+}
+
+// AttestationPolicy requires callers of sensitive endpoints to hold
+// third-party attestations (see protocol.Attestation)
+type AttestationPolicy struct {
+	// Resolver returns the caller's attestations
+	Resolver AttestationResolver
+
+	// Methods lists the attestations required to call each JSON-RPC method
+	Methods map[string][]AttestationRequirement
+
+	// Paths lists the attestations required for each URL path, for every
+	// request to it
+	Paths map[string][]AttestationRequirement
+}
+
+// SetAttestationPolicy requires callers to hold the attestations policy
+// lists for the requested path and JSON-RPC method, checked after the
+// method capabilities and before the authorizer. The caller's attestations
+// must be about it, unexpired and signed by the on-chain key of a listed
+// attester. Streamed requests, whose method is not known up front, must
+// meet the requirements of every method; unsigned requests admitted in
+// optional mode meet none. Denials are passed to the error handler as
+// *AuthorizationError. Pass nil to disable the check.
+func (m *DIDAuthMiddleware) SetAttestationPolicy(policy *AttestationPolicy) {
+	m.update(func(c *middlewareConfig) {
+		c.attestations = policy
+	})
+}
+
+// GetAttestationsFromContext returns the caller's verified attestations.
+// It is empty unless the request had to meet attestation requirements.
+func GetAttestationsFromContext(ctx context.Context) []*protocol.Attestation {
+	attestations, _ := ctx.Value(attestationsKey).([]*protocol.Attestation)
+	return attestations
+}
+
+// requiredAttestations returns the requirements for r when calling
+// methods
+func (m *middlewareConfig) requiredAttestations(r *http.Request, methods ...string) []AttestationRequirement {
+	if m.attestations == nil {
+		return nil
+	}
+	required := slices.Clone(m.attestations.Paths[r.URL.Path])
+	for _, method := range methods {
+		required = append(required, m.attestations.Methods[method]...)
+	}
+	return required
+}
+
+// allAttestationMethods returns every method with attestation
+// requirements, for requests whose method is not known
+func (m *middlewareConfig) allAttestationMethods() []string {
+	if m.attestations == nil {
+		return nil
+	}
+	methods := slices.Collect(maps.Keys(m.attestations.Methods))
+	sort.Strings(methods)
+	return methods
+}
+
+// checkAttestations verifies that agentDID holds the attestations required
+// for r when calling methods and stores them in the context. Failures are
+// returned as *AuthorizationError.
+func (m *middlewareConfig) checkAttestations(ctx context.Context, r *http.Request, agentDID did.AgentDID, methods ...string) (context.Context, error) {
+	required := m.requiredAttestations(r, methods...)
+	if len(required) == 0 {
+		return ctx, nil
+	}
+
+	var tokens []string
+	if agentDID != "" && m.attestations.Resolver != nil {
+		var err error
+		tokens, err = m.attestations.Resolver(ctx, agentDID)
+		if err != nil {
+			return ctx, &AuthorizationError{Err: fmt.Errorf("failed to resolve attestations: %w", err)}
+		}
+	}
+
+	now := time.Now()
+	var held []*protocol.Attestation
+	for _, token := range tokens {
+		// Only resolve the keys of attesters the policy trusts
+		a, err := protocol.ParseAttestation(token)
+		if err != nil || !trustedAttester(required, a) {
+			continue
+		}
+		a, err = protocol.VerifyAttestationFrom(ctx, token, m.verifier.ResolvePublicKey)
+		if err != nil || a.Valid(agentDID, now) != nil {
+			continue
+		}
+		held = append(held, a)
+	}
+
+	for _, req := range required {
+		if !slices.ContainsFunc(held, req.metBy) {
+			return ctx, &AuthorizationError{Reason: fmt.Sprintf("attestation %q required", req.Claim)}
+		}
+	}
+	return context.WithValue(ctx, attestationsKey, held), nil
+}
+
+// metBy reports whether a meets the requirement
+func (req AttestationRequirement) metBy(a *protocol.Attestation) bool {
+	return a.Claim == req.Claim && slices.Contains(req.Attesters, a.Attester)
+}
+
+// trustedAttester reports whether a could meet one of required
+func trustedAttester(required []AttestationRequirement, a *protocol.Attestation) bool {
+	return slices.ContainsFunc(required, func(req AttestationRequirement) bool {
+		return req.metBy(a)
+	})
+}
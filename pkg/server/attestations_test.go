@@ -0,0 +1,144 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDAuthMiddleware_AttestationPolicy(t *testing.T) {
+	const (
+		caller  = did.AgentDID("did:sage:ethereum:0xabc")
+		auditor = did.AgentDID("did:sage:ethereum:0xauditor")
+		rogue   = did.AgentDID("did:sage:ethereum:0xrogue")
+	)
+	auditorKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	rogueKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	attest := func(attester did.AgentDID, claim string, ttl time.Duration) string {
+		t.Helper()
+		a := protocol.NewAttestation(attester, caller, claim, ttl)
+		if ttl < 0 {
+			a.Expires = time.Now().Add(ttl).Unix()
+		}
+		if attester == auditor {
+			require.NoError(t, a.Sign(auditorKey))
+		} else {
+			require.NoError(t, a.Sign(rogueKey))
+		}
+		return a.Token
+	}
+
+	var tokens []string
+	middleware := NewDIDAuthMiddlewareWithVerifier(&keyResolvingVerifier{
+		mockDIDVerifier: mockDIDVerifier{shouldSucceed: true, extractedDID: caller},
+		keys: map[did.AgentDID]stdcrypto.PublicKey{
+			auditor: auditorKey.PublicKey(),
+			rogue:   rogueKey.PublicKey(),
+		},
+	})
+	middleware.SetAttestationPolicy(&AttestationPolicy{
+		Resolver: func(ctx context.Context, agentDID did.AgentDID) ([]string, error) {
+			return tokens, nil
+		},
+		Methods: map[string][]AttestationRequirement{
+			"tasks/cancel": {{Claim: "audited", Attesters: []did.AgentDID{auditor}}},
+		},
+	})
+	var gotInput AuthzInput
+	middleware.SetAuthorizer(AuthorizerFunc(func(ctx context.Context, input AuthzInput) (Decision, error) {
+		gotInput = input
+		return Decision{Allow: true}, nil
+	}))
+
+	var held []*protocol.Attestation
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		held = GetAttestationsFromContext(r.Context())
+	}))
+	serve := func(method string) int {
+		rec := httptest.NewRecorder()
+		held = nil
+		handler.ServeHTTP(rec, signedRequest(`{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`))
+		return rec.Code
+	}
+
+	// Methods without requirements need no attestation
+	assert.Equal(t, http.StatusOK, serve("message/send"))
+	assert.Empty(t, held)
+
+	assert.Equal(t, http.StatusForbidden, serve("tasks/cancel"))
+
+	// Attestations by untrusted attesters, of other claims or expired do
+	// not count
+	tokens = []string{
+		attest(rogue, "audited", time.Hour),
+		attest(auditor, "complies:policy-x", time.Hour),
+		attest(auditor, "audited", -time.Hour),
+	}
+	assert.Equal(t, http.StatusForbidden, serve("tasks/cancel"))
+
+	tokens = append(tokens, attest(auditor, "audited", time.Hour))
+	assert.Equal(t, http.StatusOK, serve("tasks/cancel"))
+	require.Len(t, held, 1)
+	assert.Equal(t, "audited", held[0].Claim)
+	assert.Equal(t, auditor, held[0].Attester)
+	assert.Equal(t, []string{"audited"}, gotInput.Attestations)
+}
+
+func TestDIDAuthMiddleware_AttestationPolicy_PathsAndOptional(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	middleware.SetOptional(true)
+	middleware.SetAttestationPolicy(&AttestationPolicy{
+		Resolver: func(ctx context.Context, agentDID did.AgentDID) ([]string, error) {
+			return nil, nil
+		},
+		Paths: map[string][]AttestationRequirement{
+			"/admin": {{Claim: "audited", Attesters: []did.AgentDID{"did:sage:ethereum:0xauditor"}}},
+		},
+	})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	serve := func(path string, signed bool) int {
+		req := httptest.NewRequest("POST", path, nil)
+		if signed {
+			req = signedRequest(`{}`)
+			req.URL.Path = path
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/rpc", false))
+	assert.Equal(t, http.StatusForbidden, serve("/admin", false))
+	assert.Equal(t, http.StatusForbidden, serve("/admin", true))
+	assert.Equal(t, http.StatusOK, serve("/rpc", true))
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// AuditEventType identifies the kind of audit event
+type AuditEventType string
+
+const (
+	// AuditAuthFailure is a single failed verification attempt
+	AuditAuthFailure AuditEventType = "auth_failure"
+
+	// AuditAuthFailureSummary aggregates failures suppressed during a window
+	AuditAuthFailureSummary AuditEventType = "auth_failure_summary"
+
+	// AuditAuthzDenied is a verified request rejected by the Authorizer
+	AuditAuthzDenied AuditEventType = "authz_denied"
+)
+
+// DefaultAuditWindow is the default aggregation window for repeated failures
+const DefaultAuditWindow = time.Minute
+
+// AuditEvent is a security-relevant event emitted by the middleware
+type AuditEvent struct {
+	Time     time.Time
+	Type     AuditEventType
+	AgentDID did.AgentDID // claimed DID from the keyid; unverified for failures
+	RemoteIP string
+	Method   string
+	Path     string
+	Reason   string
+
+	// Count, First and Last are set on summaries: the number of failures
+	// suppressed and when the first and last of them occurred
+	Count int
+	First time.Time
+	Last  time.Time
+}
+
+// AuditLogger receives audit events. Implementations must be safe for
+// concurrent use.
+type AuditLogger interface {
+	LogAudit(event AuditEvent)
+}
+
+// AuditLoggerFunc adapts a function to the AuditLogger interface
+type AuditLoggerFunc func(event AuditEvent)
+
+// LogAudit implements AuditLogger
+func (f AuditLoggerFunc) LogAudit(event AuditEvent) {
+	f(event)
+}
+
+// logAuditLogger writes audit events to a standard library logger
+type logAuditLogger struct {
+	logger *log.Logger
+}
+
+// NewLogAuditLogger creates an AuditLogger writing to logger.
+// If logger is nil, log.Default() is used.
+func NewLogAuditLogger(logger *log.Logger) AuditLogger {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &logAuditLogger{logger: logger}
+}
+
+// LogAudit implements AuditLogger
+func (l *logAuditLogger) LogAudit(e AuditEvent) {
+	switch e.Type {
+	case AuditAuthFailureSummary:
+		l.logger.Printf("audit %s did=%q ip=%s count=%d first=%s last=%s reason=%q",
+			e.Type, e.AgentDID, e.RemoteIP, e.Count,
+			e.First.Format(time.RFC3339), e.Last.Format(time.RFC3339), e.Reason)
+	default:
+		l.logger.Printf("audit %s did=%q ip=%s %s %s reason=%q",
+			e.Type, e.AgentDID, e.RemoteIP, e.Method, e.Path, e.Reason)
+	}
+}
+
+// SetAuditLogger sets the logger receiving an AuditAuthFailure event for
+// every failed verification. Wrap it with NewRateLimitedAuditLogger to
+// avoid flooding logs under attack.
+func (m *DIDAuthMiddleware) SetAuditLogger(logger AuditLogger) {
+	m.update(func(c *middlewareConfig) {
+		c.auditLogger = logger
+	})
+}
+
+// auditFailure emits an AuditAuthFailure event if an audit logger is set
+func (m *middlewareConfig) auditFailure(r *http.Request, err error) {
+	if m.auditLogger == nil {
+		return
+	}
+	fp := NewRequestFingerprint(r, 0)
+	m.auditLogger.LogAudit(AuditEvent{
+		Time:     fp.Timestamp,
+		Type:     AuditAuthFailure,
+		AgentDID: fp.AgentDID,
+		RemoteIP: fp.RemoteIP,
+		Method:   fp.Method,
+		Path:     fp.Path,
+		Reason:   err.Error(),
+	})
+}
+
+// RateLimitedAuditConfig configures NewRateLimitedAuditLogger
+type RateLimitedAuditConfig struct {
+	// Window is the aggregation period; defaults to DefaultAuditWindow
+	Window time.Duration
+
+	// Debug passes every failure through unchanged, disabling suppression
+	Debug bool
+}
+
+// failureAggregate tracks failures for one DID/IP pair within a window
+type failureAggregate struct {
+	did        did.AgentDID
+	ip         string
+	suppressed int
+	first      time.Time
+	last       time.Time
+	reason     string
+}
+
+// RateLimitedAuditLogger suppresses repeated authentication failures from the
+// same DID and IP. The first failure of each pair in a window is logged
+// immediately; the rest are counted and reported as one AuditAuthFailureSummary
+// event when the window is flushed. Other event types pass through unchanged.
+type RateLimitedAuditLogger struct {
+	next   AuditLogger
+	config RateLimitedAuditConfig
+
+	mu      sync.Mutex
+	entries map[string]*failureAggregate
+}
+
+// NewRateLimitedAuditLogger creates a rate-limited decorator around next.
+// Call Start to flush summaries periodically, or Flush manually.
+func NewRateLimitedAuditLogger(next AuditLogger, config RateLimitedAuditConfig) *RateLimitedAuditLogger {
+	if config.Window <= 0 {
+		config.Window = DefaultAuditWindow
+	}
+	return &RateLimitedAuditLogger{
+		next:    next,
+		config:  config,
+		entries: make(map[string]*failureAggregate),
+	}
+}
+
+// LogAudit implements AuditLogger
+func (l *RateLimitedAuditLogger) LogAudit(e AuditEvent) {
+	if e.Type != AuditAuthFailure || l.config.Debug {
+		l.next.LogAudit(e)
+		return
+	}
+
+	key := string(e.AgentDID) + "|" + e.RemoteIP
+
+	l.mu.Lock()
+	agg, seen := l.entries[key]
+	if !seen {
+		l.entries[key] = &failureAggregate{did: e.AgentDID, ip: e.RemoteIP}
+		l.mu.Unlock()
+		l.next.LogAudit(e)
+		return
+	}
+	if agg.suppressed == 0 {
+		agg.first = e.Time
+	}
+	agg.suppressed++
+	agg.last = e.Time
+	agg.reason = e.Reason
+	l.mu.Unlock()
+}
+
+// Flush emits a summary for every DID/IP pair with suppressed failures and
+// starts a new window
+func (l *RateLimitedAuditLogger) Flush() {
+	l.mu.Lock()
+	entries := l.entries
+	l.entries = make(map[string]*failureAggregate)
+	l.mu.Unlock()
+
+	keys := make([]string, 0, len(entries))
+	for k, agg := range entries {
+		if agg.suppressed > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		agg := entries[k]
+		l.next.LogAudit(AuditEvent{
+			Time:     time.Now().UTC(),
+			Type:     AuditAuthFailureSummary,
+			AgentDID: agg.did,
+			RemoteIP: agg.ip,
+			Reason:   agg.reason,
+			Count:    agg.suppressed,
+			First:    agg.first,
+			Last:     agg.last,
+		})
+	}
+}
+
+// Start flushes summaries every window until ctx is done, then flushes
+// once more. It blocks; run it in its own goroutine.
+func (l *RateLimitedAuditLogger) Start(ctx context.Context) {
+	ticker := time.NewTicker(l.config.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			l.Flush()
+			return
+		case <-ticker.C:
+			l.Flush()
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditCollector records audit events for assertions
+type auditCollector struct {
+	mu     sync.Mutex
+	events []AuditEvent
+}
+
+func (c *auditCollector) LogAudit(e AuditEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, e)
+}
+
+func (c *auditCollector) Events() []AuditEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]AuditEvent(nil), c.events...)
+}
+
+func failure(agentDID, ip string) AuditEvent {
+	return AuditEvent{
+		Time:     time.Now().UTC(),
+		Type:     AuditAuthFailure,
+		AgentDID: did.AgentDID(agentDID),
+		RemoteIP: ip,
+		Reason:   "signature verification failed",
+	}
+}
+
+func TestDIDAuthMiddleware_AuditLogger(t *testing.T) {
+	collector := &auditCollector{}
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: false})
+	middleware.SetAuditLogger(collector)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.RemoteAddr = "10.0.0.7:51234"
+	req.Header.Set("Signature", "sig1=:AAAA:")
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xabc"`)
+	rr := httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	events := collector.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, AuditAuthFailure, events[0].Type)
+	assert.Equal(t, did.AgentDID("did:sage:ethereum:0xabc"), events[0].AgentDID)
+	assert.Equal(t, "10.0.0.7", events[0].RemoteIP)
+	assert.Equal(t, "POST", events[0].Method)
+	assert.Equal(t, "/rpc", events[0].Path)
+	assert.Contains(t, events[0].Reason, "signature verification failed")
+
+	// Successful requests are not audited as failures
+	ok := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	ok.SetAuditLogger(collector)
+	ok.Wrap(handler).ServeHTTP(httptest.NewRecorder(), req)
+	assert.Len(t, collector.Events(), 1)
+}
+
+func TestRateLimitedAuditLogger_Suppression(t *testing.T) {
+	collector := &auditCollector{}
+	logger := NewRateLimitedAuditLogger(collector, RateLimitedAuditConfig{Window: time.Hour})
+
+	for i := 0; i < 5; i++ {
+		logger.LogAudit(failure("did:a", "10.0.0.1"))
+	}
+	logger.LogAudit(failure("did:a", "10.0.0.2"))
+	logger.LogAudit(failure("did:b", "10.0.0.1"))
+
+	// Only the first failure of each DID/IP pair is emitted immediately
+	events := collector.Events()
+	require.Len(t, events, 3)
+	for _, e := range events {
+		assert.Equal(t, AuditAuthFailure, e.Type)
+	}
+
+	logger.Flush()
+	events = collector.Events()
+	require.Len(t, events, 4)
+	summary := events[3]
+	assert.Equal(t, AuditAuthFailureSummary, summary.Type)
+	assert.Equal(t, did.AgentDID("did:a"), summary.AgentDID)
+	assert.Equal(t, "10.0.0.1", summary.RemoteIP)
+	assert.Equal(t, 4, summary.Count)
+	assert.False(t, summary.First.After(summary.Last))
+
+	// A new window starts after the flush
+	logger.LogAudit(failure("did:a", "10.0.0.1"))
+	assert.Len(t, collector.Events(), 5)
+	logger.Flush()
+	assert.Len(t, collector.Events(), 5)
+}
+
+func TestRateLimitedAuditLogger_Debug(t *testing.T) {
+	collector := &auditCollector{}
+	logger := NewRateLimitedAuditLogger(collector, RateLimitedAuditConfig{Debug: true})
+
+	for i := 0; i < 3; i++ {
+		logger.LogAudit(failure("did:a", "10.0.0.1"))
+	}
+	logger.Flush()
+
+	assert.Len(t, collector.Events(), 3)
+}
+
+func TestRateLimitedAuditLogger_Start(t *testing.T) {
+	collector := &auditCollector{}
+	logger := NewRateLimitedAuditLogger(collector, RateLimitedAuditConfig{Window: time.Hour})
+	logger.LogAudit(failure("did:a", "10.0.0.1"))
+	logger.LogAudit(failure("did:a", "10.0.0.1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		logger.Start(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	// Cancellation flushes pending summaries
+	events := collector.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, AuditAuthFailureSummary, events[1].Type)
+	assert.Equal(t, 1, events[1].Count)
+}
+
+func TestNewLogAuditLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogAuditLogger(log.New(&buf, "", 0))
+
+	logger.LogAudit(failure("did:a", "10.0.0.1"))
+	assert.Contains(t, buf.String(), `audit auth_failure did="did:a" ip=10.0.0.1`)
+
+	buf.Reset()
+	logger.LogAudit(AuditEvent{Type: AuditAuthFailureSummary, AgentDID: "did:a", RemoteIP: "10.0.0.1", Count: 7})
+	assert.Contains(t, buf.String(), "count=7")
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// AuthzInput describes a request to be authorized. It is serialized as the
+// input document for external policy decision points.
+type AuthzInput struct {
+	// AgentDID is the verified caller; empty for unsigned requests in optional mode
+	AgentDID did.AgentDID `json:"agent_did"`
+
+	// Capabilities are the caller's registered capabilities, if a
+	// CapabilityResolver is configured, plus any granted capabilities
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// GrantedCapabilities are the capabilities held through verified
+	// capability grants for this request only
+	GrantedCapabilities []string `json:"granted_capabilities,omitempty"`
+
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	RemoteIP string `json:"remote_ip"`
+
+	// RPCMethod is the JSON-RPC method (e.g. "message/send") if the body
+	// is a JSON-RPC request
+	RPCMethod string `json:"rpc_method,omitempty"`
+
+	// Reputation is the caller's reputation score, if a ReputationTracker
+	// is configured
+	Reputation *float64 `json:"reputation,omitempty"`
+
+	// Attestations are the claims of the caller's verified attestations,
+	// if the request had to meet attestation requirements
+	Attestations []string `json:"attestations,omitempty"`
+
+	// Unverified holds the identity hints of an unsigned request, if
+	// SetUnverifiedHints is configured. They are not proven.
+	Unverified *UnverifiedIdentity `json:"unverified,omitempty"`
+}
+
+// Decision is the result of an authorization check
+type Decision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Authorizer decides whether a verified request may proceed
+type Authorizer interface {
+	Authorize(ctx context.Context, input AuthzInput) (Decision, error)
+}
+
+// AuthorizerFunc adapts a function to the Authorizer interface
+type AuthorizerFunc func(ctx context.Context, input AuthzInput) (Decision, error)
+
+// Authorize implements Authorizer
+func (f AuthorizerFunc) Authorize(ctx context.Context, input AuthzInput) (Decision, error) {
+	return f(ctx, input)
+}
+
+// CapabilityResolver returns the capabilities registered for an agent
+type CapabilityResolver func(ctx context.Context, agentDID did.AgentDID) ([]string, error)
+
+// NewMetadataCapabilityResolver creates a CapabilityResolver reading the
+// capability names from on-chain agent metadata. Lookups share the
+// middleware's per-request resolution cache. DIDs of methods added with
+// verifier.RegisterDIDMethod are resolved by their registered resolvers.
+func NewMetadataCapabilityResolver(resolver verifier.DIDResolver) CapabilityResolver {
+	memo := verifier.NewMemoizedResolver(verifier.NewMethodResolver(resolver))
+	return func(ctx context.Context, agentDID did.AgentDID) ([]string, error) {
+		meta, err := memo.GetAgentByDID(ctx, string(agentDID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve agent metadata: %w", err)
+		}
+		caps := make([]string, 0, len(meta.Capabilities))
+		for name := range meta.Capabilities {
+			caps = append(caps, name)
+		}
+		sort.Strings(caps)
+		return caps, nil
+	}
+}
+
+// AuthorizationError is returned to the error handler when a request is
+// denied or the authorizer fails. The default error handler responds with
+// 403 Forbidden.
+type AuthorizationError struct {
+	Reason string
+	Err    error // set if the decision could not be made
+}
+
+// Error implements error
+func (e *AuthorizationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("authorization failed: %v", e.Err)
+	}
+	if e.Reason != "" {
+		return fmt.Sprintf("access denied: %s", e.Reason)
+	}
+	return "access denied"
+}
+
+// Unwrap returns the underlying error
+func (e *AuthorizationError) Unwrap() error {
+	return e.Err
+}
+
+// SetAuthorizer sets the authorizer consulted after successful verification.
+// Unsigned requests allowed by optional mode are authorized with an empty
+// AgentDID. Denials and authorizer errors are passed to the error handler
+// as *AuthorizationError; requests fail closed.
+func (m *DIDAuthMiddleware) SetAuthorizer(authorizer Authorizer) {
+	m.update(func(c *middlewareConfig) {
+		c.authorizer = authorizer
+	})
+}
+
+// SetCapabilityResolver sets the resolver used to fill
+// AuthzInput.Capabilities for signed requests
+func (m *DIDAuthMiddleware) SetCapabilityResolver(resolver CapabilityResolver) {
+	m.update(func(c *middlewareConfig) {
+		c.capabilityResolver = resolver
+	})
+}
+
+// authorize runs the authorizer, returning an *AuthorizationError if the
+// request must not proceed
+func (m *middlewareConfig) authorize(ctx context.Context, r *http.Request, agentDID did.AgentDID, body []byte) error {
+	input := AuthzInput{
+		AgentDID:  agentDID,
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		RemoteIP:  remoteIP(r),
+		RPCMethod: rpcMethod(body),
+	}
+
+	input.Capabilities = GetCapabilitiesFromContext(ctx)
+	for _, g := range GetGrantsFromContext(ctx) {
+		input.GrantedCapabilities = append(input.GrantedCapabilities, g.Capability)
+	}
+	if m.reputation != nil && agentDID != "" {
+		score := m.reputation.Reputation(agentDID).Score
+		input.Reputation = &score
+	}
+	for _, a := range GetAttestationsFromContext(ctx) {
+		input.Attestations = append(input.Attestations, a.Claim)
+	}
+	if id, ok := GetUnverifiedIdentityFromContext(ctx); ok {
+		input.Unverified = &id
+	}
+
+	decision, err := m.authorizer.Authorize(ctx, input)
+	if err != nil {
+		return &AuthorizationError{Err: err}
+	}
+	if !decision.Allow {
+		return &AuthorizationError{Reason: decision.Reason}
+	}
+	return nil
+}
+
+// deny reports an authorization failure to the audit logger and writes the
+// error response
+func (m *middlewareConfig) deny(w http.ResponseWriter, r *http.Request, agentDID did.AgentDID, err error) {
+	if m.auditLogger != nil {
+		m.auditLogger.LogAudit(AuditEvent{
+			Time:     time.Now().UTC(),
+			Type:     AuditAuthzDenied,
+			AgentDID: agentDID,
+			RemoteIP: remoteIP(r),
+			Method:   r.Method,
+			Path:     r.URL.Path,
+			Reason:   err.Error(),
+		})
+	}
+	m.errorHandler(w, r, err)
+}
+
+// rpcMethod extracts the JSON-RPC method from a request body, if any
+func rpcMethod(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var req struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	return req.Method
+}
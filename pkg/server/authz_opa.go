@@ -0,0 +1,94 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// OPAAuthorizer delegates decisions to an Open Policy Agent server through
+// its Data API. The policy receives AuthzInput as input and must produce
+// either a boolean or an object {"allow": bool, "reason": string}.
+type OPAAuthorizer struct {
+	decisionURL string
+	httpClient  *http.Client
+}
+
+// NewOPAAuthorizer creates an authorizer querying decisionURL, e.g.
+// "http://localhost:8181/v1/data/a2a/authz". If httpClient is nil,
+// http.DefaultClient is used.
+func NewOPAAuthorizer(decisionURL string, httpClient *http.Client) *OPAAuthorizer {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &OPAAuthorizer{decisionURL: decisionURL, httpClient: httpClient}
+}
+
+// Authorize implements Authorizer
+func (o *OPAAuthorizer) Authorize(ctx context.Context, input AuthzInput) (Decision, error) {
+	reqBody, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to marshal OPA input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.decisionURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to create OPA request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("OPA request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to read OPA response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Decision{}, fmt.Errorf("OPA returned HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	var opaResp struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &opaResp); err != nil {
+		return Decision{}, fmt.Errorf("failed to parse OPA response: %w", err)
+	}
+	if len(opaResp.Result) == 0 {
+		// OPA omits result when the policy is undefined
+		return Decision{Allow: false, Reason: "policy undefined"}, nil
+	}
+
+	var allow bool
+	if err := json.Unmarshal(opaResp.Result, &allow); err == nil {
+		return Decision{Allow: allow}, nil
+	}
+	var decision Decision
+	if err := json.Unmarshal(opaResp.Result, &decision); err != nil {
+		return Decision{}, fmt.Errorf("unexpected OPA result: %s", string(opaResp.Result))
+	}
+	return decision, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"path"
+	"strconv"
+)
+
+// PolicyEffect is the outcome of a matching PolicyRule
+type PolicyEffect string
+
+const (
+	PolicyAllow PolicyEffect = "allow"
+	PolicyDeny  PolicyEffect = "deny"
+)
+
+// PolicyRule matches requests by caller, path, JSON-RPC method and
+// capabilities. Empty fields match anything. DIDs and Paths accept
+// path.Match patterns, e.g. "did:sage:ethereum:*" or "/agents/*/rpc".
+type PolicyRule struct {
+	Name       string
+	Effect     PolicyEffect
+	DIDs       []string
+	Paths      []string
+	RPCMethods []string
+
+	// RequiredCapabilities must all be present in AuthzInput.Capabilities
+	RequiredCapabilities []string
+}
+
+// PolicyAuthorizer is a local policy engine. Deny rules take precedence over
+// allow rules; requests matching no allow rule are denied.
+type PolicyAuthorizer struct {
+	rules []PolicyRule
+}
+
+// NewPolicyAuthorizer creates a local policy engine with the given rules
+func NewPolicyAuthorizer(rules ...PolicyRule) *PolicyAuthorizer {
+	return &PolicyAuthorizer{rules: rules}
+}
+
+// Authorize implements Authorizer
+func (p *PolicyAuthorizer) Authorize(ctx context.Context, input AuthzInput) (Decision, error) {
+	allowed := false
+	for i := range p.rules {
+		rule := &p.rules[i]
+		if !rule.matches(input) {
+			continue
+		}
+		if rule.Effect == PolicyDeny {
+			return Decision{Allow: false, Reason: "denied by rule " + rule.label(i)}, nil
+		}
+		allowed = true
+	}
+
+	if !allowed {
+		return Decision{Allow: false, Reason: "no matching allow rule"}, nil
+	}
+	return Decision{Allow: true}, nil
+}
+
+// matches reports whether every condition of the rule holds for input
+func (r *PolicyRule) matches(input AuthzInput) bool {
+	return matchAny(r.DIDs, string(input.AgentDID)) &&
+		matchAny(r.Paths, input.Path) &&
+		containsOrEmpty(r.RPCMethods, input.RPCMethod) &&
+		hasAll(input.Capabilities, r.RequiredCapabilities)
+}
+
+// label identifies the rule in denial reasons
+func (r *PolicyRule) label(index int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return "#" + strconv.Itoa(index)
+}
+
+// matchAny reports whether value matches one of patterns; empty matches all
+func matchAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, err := path.Match(p, value); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// containsOrEmpty reports whether value is in list; an empty list matches all
+func containsOrEmpty(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAll reports whether have contains every element of want
+func hasAll(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedRequest(body string) *http.Request {
+	req := httptest.NewRequest("POST", "/rpc", bytes.NewReader([]byte(body)))
+	req.Header.Set("Signature", "sig1=:AAAA:")
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xabc"`)
+	return req
+}
+
+func TestDIDAuthMiddleware_Authorizer(t *testing.T) {
+	var got AuthzInput
+	authorizer := AuthorizerFunc(func(ctx context.Context, input AuthzInput) (Decision, error) {
+		got = input
+		return Decision{Allow: input.RPCMethod == "message/send", Reason: "method not allowed"}, nil
+	})
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	middleware.SetAuthorizer(authorizer)
+	middleware.SetCapabilityResolver(func(ctx context.Context, agentDID did.AgentDID) ([]string, error) {
+		return []string{"chat"}, nil
+	})
+	collector := &auditCollector{}
+	middleware.SetAuditLogger(collector)
+
+	var handlerBody []byte
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"message/send"}`
+	rr := httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, signedRequest(body))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, body, string(handlerBody))
+	assert.Equal(t, did.AgentDID("did:sage:ethereum:0xabc"), got.AgentDID)
+	assert.Equal(t, []string{"chat"}, got.Capabilities)
+	assert.Equal(t, "/rpc", got.Path)
+
+	rr = httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, signedRequest(`{"jsonrpc":"2.0","id":1,"method":"tasks/cancel"}`))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "method not allowed")
+
+	events := collector.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, AuditAuthzDenied, events[0].Type)
+}
+
+func TestDIDAuthMiddleware_AuthorizerFailsClosed(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	middleware.SetAuthorizer(AuthorizerFunc(func(ctx context.Context, input AuthzInput) (Decision, error) {
+		return Decision{}, errors.New("pdp unreachable")
+	}))
+
+	var handlerErr error
+	middleware.SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+		handlerErr = err
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	rr := httptest.NewRecorder()
+	middleware.Wrap(http.NotFoundHandler()).ServeHTTP(rr, signedRequest(""))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	var authzErr *AuthorizationError
+	require.True(t, errors.As(handlerErr, &authzErr))
+	assert.EqualError(t, authzErr.Err, "pdp unreachable")
+}
+
+func TestDIDAuthMiddleware_AuthorizerOptionalUnsigned(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true})
+	middleware.SetOptional(true)
+	middleware.SetAuthorizer(NewPolicyAuthorizer(PolicyRule{Effect: PolicyAllow, DIDs: []string{"did:*"}}))
+
+	rr := httptest.NewRecorder()
+	middleware.Wrap(http.NotFoundHandler()).ServeHTTP(rr, httptest.NewRequest("POST", "/rpc", nil))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestPolicyAuthorizer(t *testing.T) {
+	p := NewPolicyAuthorizer(
+		PolicyRule{Name: "block-0xbad", Effect: PolicyDeny, DIDs: []string{"did:sage:ethereum:0xbad"}},
+		PolicyRule{Effect: PolicyAllow, DIDs: []string{"did:sage:ethereum:*"}, Paths: []string{"/rpc"}, RPCMethods: []string{"message/send", "tasks/get"}},
+		PolicyRule{Effect: PolicyAllow, Paths: []string{"/admin/*"}, RequiredCapabilities: []string{"admin"}},
+	)
+
+	tests := []struct {
+		name  string
+		input AuthzInput
+		allow bool
+	}{
+		{"allowed method", AuthzInput{AgentDID: "did:sage:ethereum:0x1", Path: "/rpc", RPCMethod: "message/send"}, true},
+		{"other method", AuthzInput{AgentDID: "did:sage:ethereum:0x1", Path: "/rpc", RPCMethod: "tasks/cancel"}, false},
+		{"denied DID", AuthzInput{AgentDID: "did:sage:ethereum:0xbad", Path: "/rpc", RPCMethod: "message/send"}, false},
+		{"other DID method", AuthzInput{AgentDID: "did:web:example.com", Path: "/rpc", RPCMethod: "message/send"}, false},
+		{"admin with capability", AuthzInput{AgentDID: "did:web:example.com", Path: "/admin/keys", Capabilities: []string{"chat", "admin"}}, true},
+		{"admin without capability", AuthzInput{AgentDID: "did:web:example.com", Path: "/admin/keys", Capabilities: []string{"chat"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := p.Authorize(context.Background(), tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allow, d.Allow)
+		})
+	}
+
+	d, _ := p.Authorize(context.Background(), tests[2].input)
+	assert.Equal(t, "denied by rule block-0xbad", d.Reason)
+}
+
+func TestOPAAuthorizer(t *testing.T) {
+	tests := []struct {
+		name   string
+		result string
+		allow  bool
+		reason string
+	}{
+		{"boolean", `{"result": true}`, true, ""},
+		{"object", `{"result": {"allow": false, "reason": "quota"}}`, false, "quota"},
+		{"undefined", `{}`, false, "policy undefined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input map[string]AuthzInput
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/data/a2a/authz", r.URL.Path)
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+				w.Write([]byte(tt.result))
+			}))
+			defer srv.Close()
+
+			opa := NewOPAAuthorizer(srv.URL+"/v1/data/a2a/authz", nil)
+			d, err := opa.Authorize(context.Background(), AuthzInput{AgentDID: "did:sage:ethereum:0x1", RPCMethod: "message/send"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.allow, d.Allow)
+			assert.Equal(t, tt.reason, d.Reason)
+			assert.Equal(t, "message/send", input["input"].RPCMethod)
+		})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	_, err := NewOPAAuthorizer(srv.URL, nil).Authorize(context.Background(), AuthzInput{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import "net/http"
+
+// Middleware wraps an http.Handler. DIDAuthMiddleware.Wrap,
+// CompressionHandler.Wrap and recorder.Recorder.Wrap are Middlewares.
+type Middleware func(next http.Handler) http.Handler
+
+// Chain composes middlewares into one. Requests pass through them in the
+// order given, so the first middleware is the outermost; responses pass
+// back in reverse order. Nil middlewares are skipped, which lets optional
+// stages be left out without rebuilding the list:
+//
+//	handler := server.Chain(rec.Wrap, auth.Wrap, server.NewCompressionHandler(nil).Wrap)(rpcHandler)
+//
+// DIDAuthMiddleware must come before middlewares that rewrite the request
+// body, such as CompressionHandler, so the signature is verified over the
+// bytes that were signed.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			if middlewares[i] != nil {
+				next = middlewares[i](next)
+			}
+		}
+		return next
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	stage := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	auth := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	var optional Middleware
+	handler := Chain(stage("outer"), auth.Wrap, optional, stage("inner"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agentDID, ok := GetAgentDIDFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, did.AgentDID("did:sage:ethereum:0xabc"), agentDID)
+		order = append(order, "handler")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(`{"method":"message/send"}`))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"outer", "inner", "handler"}, order)
+
+	// Rejected requests do not reach the inner stages
+	order = nil
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/rpc", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, []string{"outer"}, order)
+}
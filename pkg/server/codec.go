@@ -0,0 +1,241 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/codec"
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
+)
+
+// CodecHandler decodes request bodies encoded with a registered codec (see
+// the codec package), such as CBOR, into JSON for the handler, and encodes
+// JSON responses and event streams with the codec the client accepts.
+//
+// It must be placed inside DIDAuthMiddleware and CompressionHandler, so
+// signatures cover the encoded bytes, and outside ReceiptHandler, which
+// reads JSON:
+//
+//	handler := server.Chain(
+//	    auth.Wrap,
+//	    server.NewCompressionHandler(nil).Wrap,
+//	    server.NewCodecHandler().Wrap,
+//	    server.NewReceiptHandler(agentDID, keyPair).Wrap,
+//	)(rpcHandler)
+//
+// Encoded JSON responses are buffered to the end; event streams are
+// re-encoded event by event.
+type CodecHandler struct{}
+
+// NewCodecHandler creates a CodecHandler
+func NewCodecHandler() *CodecHandler {
+	return &CodecHandler{}
+}
+
+// Wrap wraps an HTTP handler with request decoding and response encoding
+func (h *CodecHandler) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, ok := codec.ForContentType(r.Header.Get("Content-Type")); ok && !codec.IsJSON(c) && r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to read body: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+			decoded, err := c.ToJSON(body)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s body: %s", c.ContentType(), err.Error()), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(decoded))
+			r.ContentLength = int64(len(decoded))
+			r.Header.Set("Content-Type", codec.ContentTypeJSON)
+			r.Header.Del("Content-Length")
+		}
+
+		accept := r.Header.Get("Accept")
+		cw := &codecResponseWriter{
+			ResponseWriter: w,
+			codec:          codec.Negotiate(accept),
+			stream:         codec.NegotiateStream(accept),
+		}
+		if codec.IsJSON(cw.codec) && cw.stream == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		defer cw.close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// codecResponseWriter encodes JSON responses with codec and SSE streams
+// with stream, deciding by the Content-Type of the first write
+type codecResponseWriter struct {
+	http.ResponseWriter
+	codec  codec.Codec       // nil or JSON leaves JSON responses alone
+	stream codec.StreamCodec // nil leaves event streams alone
+
+	status  int
+	decided bool
+	whole   bool   // buffering a JSON response to encode at the end
+	events  bool   // re-encoding an SSE stream
+	buf     []byte // buffered response or partial event
+}
+
+func (w *codecResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *codecResponseWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.decide()
+	}
+	switch {
+	case w.whole:
+		w.buf = append(w.buf, p...)
+		return len(p), nil
+	case w.events:
+		w.buf = append(w.buf, p...)
+		if err := w.writeEvents(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher so SSE handlers keep working
+func (w *codecResponseWriter) Flush() {
+	if !w.decided {
+		w.decide()
+	}
+	if w.whole {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *codecResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide picks how to write the response from its Content-Type, writing
+// the header unless the response is buffered
+func (w *codecResponseWriter) decide() {
+	w.decided = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	header := w.Header()
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch {
+	case mediaType == codec.ContentTypeJSON && !codec.IsJSON(w.codec):
+		w.whole = true
+		return
+	case mediaType == "text/event-stream" && w.stream != nil:
+		w.events = true
+		header.Set("Content-Type", w.stream.StreamContentType())
+		header.Del("Content-Length")
+		header.Add("Vary", "Accept")
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// writeEvents encodes the complete SSE events buffered so far
+func (w *codecResponseWriter) writeEvents() error {
+	for {
+		event, rest, ok := bytes.Cut(w.buf, []byte("\n\n"))
+		if !ok {
+			return nil
+		}
+		w.buf = rest
+		data := eventData(event)
+		if len(data) == 0 {
+			continue
+		}
+		encoded, err := w.stream.FromJSON(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		if _, err := w.ResponseWriter.Write(encoded); err != nil {
+			return err
+		}
+	}
+}
+
+// close writes out a buffered JSON response, encoded if possible
+func (w *codecResponseWriter) close() {
+	if !w.decided {
+		w.decide()
+	}
+	if !w.whole {
+		return
+	}
+	body := w.buf
+	if encoded, err := w.codec.FromJSON(body); err == nil {
+		body = encoded
+		header := w.Header()
+		header.Set("Content-Type", w.codec.ContentType())
+		header.Del("Content-Length")
+		header.Add("Vary", "Accept")
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// eventData returns the data of one SSE event
+func eventData(event []byte) []byte {
+	var data [][]byte
+	for _, line := range bytes.Split(event, []byte("\n")) {
+		if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			data = append(data, bytes.TrimPrefix(value, []byte(" ")))
+		}
+	}
+	return bytes.Join(data, []byte("\n"))
+}
+
+// rpcBody returns the JSON-RPC request in body as JSON, undoing the
+// content coding and codec of r, so checks inspecting the request see it
+// whatever its encoding. Bodies that cannot be decoded are returned as
+// is.
+func rpcBody(r *http.Request, body []byte) []byte {
+	if encoding := r.Header.Get("Content-Encoding"); encoding != "" {
+		decoded, err := compression.Decompress(encoding, body)
+		if err != nil {
+			return body
+		}
+		body = decoded
+	}
+	if c, ok := codec.ForContentType(r.Header.Get("Content-Type")); ok && !codec.IsJSON(c) {
+		if decoded, err := c.ToJSON(body); err == nil {
+			return decoded
+		}
+	}
+	return body
+}
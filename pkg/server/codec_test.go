@@ -0,0 +1,134 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/codec"
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecHandler_DecodesRequestBody(t *testing.T) {
+	original := `{"jsonrpc":"2.0","method":"tasks/get","id":1}`
+	encoded, err := codec.CBOR.FromJSON([]byte(original))
+	require.NoError(t, err)
+
+	var received []byte
+	var contentType string
+	handler := NewCodecHandler().Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", bytes.NewReader(encoded))
+	req.Header.Set("Content-Type", codec.ContentTypeCBOR)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, original, string(received))
+	assert.Equal(t, codec.ContentTypeJSON, contentType)
+
+	// Invalid bodies are rejected
+	req = httptest.NewRequest("POST", "/rpc", strings.NewReader("\xff"))
+	req.Header.Set("Content-Type", codec.ContentTypeCBOR)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCodecHandler_EncodesResponse(t *testing.T) {
+	payload := `{"jsonrpc":"2.0","id":1,"result":{"kind":"task","id":"t1"}}`
+	handler := NewCodecHandler().Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(payload))
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Accept", "application/cbor, application/json;q=0.5")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, codec.ContentTypeCBOR, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+	decoded, err := codec.CBOR.ToJSON(rec.Body.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(decoded))
+
+	// JSON clients get JSON
+	req = httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, payload, rec.Body.String())
+}
+
+func TestCodecHandler_EncodesEventStream(t *testing.T) {
+	events := []string{`{"jsonrpc":"2.0","id":1,"result":{"kind":"task"}}`, `{"jsonrpc":"2.0","id":1,"result":{"final":true}}`}
+	handler := NewCodecHandler().Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprintf(w, "id: 1\ndata: %s\n\n", event)
+			w.(http.Flusher).Flush()
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Accept", "application/cbor-seq, text/event-stream;q=0.5")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, codec.ContentTypeCBORSeq, rec.Header().Get("Content-Type"))
+	reader := codec.CBOR.NewStreamReader(rec.Body, 0)
+	for _, want := range events {
+		got, err := reader.Next()
+		require.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+	_, err := reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestRPCBody(t *testing.T) {
+	original := []byte(`{"jsonrpc":"2.0","method":"message/send"}`)
+	encoded, err := codec.CBOR.FromJSON(original)
+	require.NoError(t, err)
+	compressed, err := compression.Compress(compression.EncodingGzip, encoded)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Content-Type", codec.ContentTypeCBOR)
+	req.Header.Set("Content-Encoding", compression.EncodingGzip)
+	assert.Equal(t, "message/send", rpcMethod(rpcBody(req, compressed)))
+
+	req = httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Content-Type", codec.ContentTypeJSON)
+	assert.Equal(t, original, rpcBody(req, original))
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
+)
+
+// CompressionHandler decodes compressed request bodies and compresses
+// responses for clients that advertise Accept-Encoding.
+//
+// It must be placed inside DIDAuthMiddleware so that signatures are verified
+// over the compressed representation that was actually signed:
+//
+//	handler := auth.Wrap(server.NewCompressionHandler(nil).Wrap(rpcHandler))
+type CompressionHandler struct {
+	config *compression.Config
+}
+
+// NewCompressionHandler creates a new CompressionHandler.
+// If cfg is nil, compression.DefaultConfig() is used.
+func NewCompressionHandler(cfg *compression.Config) *CompressionHandler {
+	if cfg == nil {
+		cfg = compression.DefaultConfig()
+	}
+	return &CompressionHandler{config: cfg}
+}
+
+// Wrap wraps an HTTP handler with request decoding and response compression
+func (h *CompressionHandler) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Decode request body (signature has already been verified upstream)
+		if encoding := r.Header.Get("Content-Encoding"); encoding != "" && r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to read body: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+
+			decoded, err := compression.Decompress(encoding, body)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Unsupported Media Type: %s", err.Error()), http.StatusUnsupportedMediaType)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(decoded))
+			r.ContentLength = int64(len(decoded))
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+		}
+
+		// Negotiate response encoding
+		encoding := compression.Negotiate(r.Header.Get("Accept-Encoding"), h.config.EncodingName())
+		if encoding == "" || h.config.Disabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressResponseWriter{
+			ResponseWriter: w,
+			encoding:       encoding,
+			minSize:        h.config.MinSize,
+		}
+		defer cw.close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressResponseWriter buffers the response until minSize bytes are
+// written, then decides whether to compress. Streaming responses
+// (text/event-stream or an early Flush) are passed through unmodified.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	decided bool
+	writer  io.WriteCloser
+}
+
+func (w *compressResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.writer != nil {
+			return w.writer.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < w.minSize && !w.isStream() {
+		return len(p), nil
+	}
+	if err := w.start(!w.isStream()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush implements http.Flusher so SSE handlers keep working
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		if err := w.start(false); err != nil {
+			return
+		}
+	}
+	if f, ok := w.writer.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// isStream reports whether the handler is producing an SSE stream
+func (w *compressResponseWriter) isStream() bool {
+	return strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream")
+}
+
+// start writes the header and any buffered data, compressing if requested
+func (w *compressResponseWriter) start(compress bool) error {
+	w.decided = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	header := w.Header()
+	if compress && header.Get("Content-Encoding") == "" {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		header.Add("Vary", "Accept-Encoding")
+
+		writer, err := compression.NewWriter(w.encoding, w.ResponseWriter)
+		if err != nil {
+			return err
+		}
+		w.writer = writer
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.writer != nil {
+		_, err := w.writer.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// close flushes buffered data and finishes the compressed stream
+func (w *compressResponseWriter) close() {
+	if !w.decided {
+		_ = w.start(false)
+	}
+	if w.writer != nil {
+		_ = w.writer.Close()
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionHandler_DecodesRequestBody(t *testing.T) {
+	original := []byte(`{"jsonrpc":"2.0","method":"tasks/get"}`)
+	compressed, err := compression.Compress(compression.EncodingGzip, original)
+	require.NoError(t, err)
+
+	var received []byte
+	handler := NewCompressionHandler(nil).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		assert.Empty(t, r.Header.Get("Content-Encoding"))
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", bytes.NewReader(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, original, received)
+}
+
+func TestCompressionHandler_UnsupportedRequestEncoding(t *testing.T) {
+	called := false
+	handler := NewCompressionHandler(nil).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader("data"))
+	req.Header.Set("Content-Encoding", "br")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestCompressionHandler_CompressesLargeResponse(t *testing.T) {
+	payload := strings.Repeat(`{"result":"ok"}`, 200)
+	handler := NewCompressionHandler(nil).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(payload))
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+	decoded, err := compression.Decompress("gzip", rec.Body.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(decoded))
+}
+
+func TestCompressionHandler_SkipsSmallResponse(t *testing.T) {
+	handler := NewCompressionHandler(nil).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("small"))
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "small", rec.Body.String())
+}
+
+func TestCompressionHandler_Disabled(t *testing.T) {
+	payload := strings.Repeat("x", 4096)
+	handler := NewCompressionHandler(&compression.Config{Disabled: true}).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, payload, rec.Body.String())
+}
+
+func TestCompressionHandler_SSEPassthrough(t *testing.T) {
+	handler := NewCompressionHandler(&compression.Config{MinSize: 1}).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: {}\n\n"))
+		w.(http.Flusher).Flush()
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "data: {}\n\n", rec.Body.String())
+	assert.True(t, rec.Flushed)
+}
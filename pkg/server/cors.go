@@ -0,0 +1,224 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// signatureHeaders are always allowed in preflight requests: browsers
+// cannot send signed requests without them
+var signatureHeaders = []string{"Signature", "Signature-Input", "Content-Digest"}
+
+// CORSConfig configures cross-origin handling in DIDAuthMiddleware
+type CORSConfig struct {
+	// AllowedOrigins lists origins allowed to make cross-origin requests.
+	// "*" allows any origin; "https://*.example.com" allows any subdomain.
+	AllowedOrigins []string
+
+	// AllowedMethods lists methods allowed in preflight requests
+	AllowedMethods []string
+
+	// AllowedHeaders lists request headers allowed in preflight requests.
+	// Signature, Signature-Input and Content-Digest are always allowed so
+	// browsers can send signed requests.
+	AllowedHeaders []string
+
+	// ExposedHeaders lists response headers readable by browser scripts
+	ExposedHeaders []string
+
+	// AllowCredentials sets Access-Control-Allow-Credentials
+	AllowCredentials bool
+
+	// MaxAge is how long (in seconds) a preflight result may be cached; 0 omits the header
+	MaxAge int
+
+	// RequireSignedCrossOrigin rejects unsigned cross-origin requests even when
+	// the middleware is in optional mode
+	RequireSignedCrossOrigin bool
+
+	// Strict disables the preflight bypass entirely: OPTIONS requests must be
+	// signed like any other request. Use only when no browser clients exist.
+	Strict bool
+}
+
+// DefaultCORSConfig returns a CORS configuration allowing any origin to send
+// signed JSON-RPC requests, including the signed SAGE request headers, and
+// to read the SAGE response headers
+func DefaultCORSConfig() *CORSConfig {
+	return &CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders: []string{
+			"Content-Type", "Content-Digest", "Content-Encoding", "Signature", "Signature-Input", "Accept",
+			protocol.PriorityHeader, protocol.DeadlineHeader, protocol.ExtensionsHeader,
+			protocol.GrantHeader, protocol.IdempotencyKeyHeader, protocol.MessageDigestHeader,
+		},
+		ExposedHeaders: []string{
+			"Want-Content-Digest", "Retry-After", protocol.ExtensionsHeader, protocol.ReceiptHeader,
+		},
+		MaxAge: 600,
+	}
+}
+
+// SetCORS sets the CORS configuration.
+// When nil (the default), OPTIONS requests bypass verification unconditionally.
+func (m *DIDAuthMiddleware) SetCORS(cfg *CORSConfig) {
+	m.update(func(c *middlewareConfig) {
+		c.cors = cfg
+	})
+}
+
+// isPreflight reports whether r is a CORS preflight request
+func isPreflight(r *http.Request) bool {
+	return r.Method == "OPTIONS" &&
+		r.Header.Get("Origin") != "" &&
+		r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// isOriginAllowed reports whether origin matches the allow list
+func (c *CORSConfig) isOriginAllowed(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range c.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		// Subdomain wildcard: scheme://*.domain
+		if idx := strings.Index(allowed, "://*."); idx != -1 {
+			scheme := allowed[:idx+3]
+			suffix := allowed[idx+4:]
+			if strings.HasPrefix(origin, scheme) && strings.HasSuffix(origin, suffix) &&
+				len(origin) > len(scheme)+len(suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isMethodAllowed reports whether method is in AllowedMethods
+func (c *CORSConfig) isMethodAllowed(method string) bool {
+	for _, m := range c.AllowedMethods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// areHeadersAllowed reports whether every header in the
+// Access-Control-Request-Headers value is allowed
+func (c *CORSConfig) areHeadersAllowed(requested string) bool {
+	for _, h := range strings.Split(requested, ",") {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+		found := false
+		for _, allowed := range c.allowedHeaders() {
+			if allowed == "*" || strings.EqualFold(allowed, h) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedHeaders returns AllowedHeaders plus the signature headers
+func (c *CORSConfig) allowedHeaders() []string {
+	headers := c.AllowedHeaders
+	for _, required := range signatureHeaders {
+		found := false
+		for _, h := range headers {
+			if h == "*" || strings.EqualFold(h, required) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			headers = append(headers[:len(headers):len(headers)], required)
+		}
+	}
+	return headers
+}
+
+// writeOriginHeaders sets the headers shared by preflight and actual responses
+func (c *CORSConfig) writeOriginHeaders(w http.ResponseWriter, origin string) {
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", origin)
+	h.Add("Vary", "Origin")
+	if c.AllowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// handlePreflight answers a preflight request without invoking the handler
+func (c *CORSConfig) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	method := r.Header.Get("Access-Control-Request-Method")
+	headers := r.Header.Get("Access-Control-Request-Headers")
+
+	if !c.isMethodAllowed(method) || !c.areHeadersAllowed(headers) {
+		writeForbidden(w, "CORS preflight rejected")
+		return
+	}
+
+	c.writeOriginHeaders(w, origin)
+	h := w.Header()
+	h.Set("Access-Control-Allow-Methods", strings.Join(c.AllowedMethods, ", "))
+	h.Set("Access-Control-Allow-Headers", strings.Join(c.allowedHeaders(), ", "))
+	if c.MaxAge > 0 {
+		h.Set("Access-Control-Max-Age", strconv.Itoa(c.MaxAge))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleCORS applies the CORS policy. It returns handled=true if a response
+// has already been written, and crossOrigin=true for allowed cross-origin
+// requests that continue to signature verification.
+func (m *middlewareConfig) handleCORS(w http.ResponseWriter, r *http.Request) (handled, crossOrigin bool) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false, false
+	}
+
+	if !m.cors.isOriginAllowed(origin) {
+		writeForbidden(w, "origin not allowed")
+		return true, false
+	}
+
+	if isPreflight(r) && !m.cors.Strict {
+		m.cors.handlePreflight(w, r)
+		return true, false
+	}
+
+	m.cors.writeOriginHeaders(w, origin)
+	if len(m.cors.ExposedHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(m.cors.ExposedHeaders, ", "))
+	}
+	return false, true
+}
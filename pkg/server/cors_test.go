@@ -0,0 +1,207 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCORSTestMiddleware(cfg *CORSConfig, succeed bool) (*DIDAuthMiddleware, *bool) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{
+		shouldSucceed: succeed,
+		extractedDID:  "did:sage:ethereum:0xtest",
+	})
+	middleware.SetCORS(cfg)
+	called := false
+	return middleware, &called
+}
+
+func TestCORS_PreflightAllowed(t *testing.T) {
+	middleware, called := newCORSTestMiddleware(DefaultCORSConfig(), false)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { *called = true }))
+
+	req := httptest.NewRequest("OPTIONS", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "content-type, signature, signature-input, content-digest")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.False(t, *called, "preflight must not reach the handler")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, rr.Header().Get("Access-Control-Allow-Headers"), "Signature-Input")
+	assert.Contains(t, rr.Header().Get("Access-Control-Allow-Methods"), "POST")
+	assert.Equal(t, "600", rr.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORS_PreflightRejectsHeaderAndMethod(t *testing.T) {
+	middleware, _ := newCORSTestMiddleware(DefaultCORSConfig(), false)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("OPTIONS", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "DELETE")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	req = httptest.NewRequest("OPTIONS", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "x-secret")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestCORS_OriginNotAllowed(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.AllowedOrigins = []string{"https://*.example.com"}
+	middleware, called := newCORSTestMiddleware(cfg, true)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { *called = true }))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Origin", "https://evil.com")
+	req.Header.Set("Signature", "mock-signature")
+	req.Header.Set("Signature-Input", `sig1=();keyid="did:sage:ethereum:0xtest"`)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.False(t, *called)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestCORS_SubdomainWildcard(t *testing.T) {
+	cfg := &CORSConfig{AllowedOrigins: []string{"https://*.example.com"}}
+
+	assert.True(t, cfg.isOriginAllowed("https://app.example.com"))
+	assert.True(t, cfg.isOriginAllowed("https://a.b.example.com"))
+	assert.False(t, cfg.isOriginAllowed("https://example.com"))
+	assert.False(t, cfg.isOriginAllowed("http://app.example.com"))
+	assert.False(t, cfg.isOriginAllowed("https://app.example.com.evil.io"))
+}
+
+func TestCORS_ActualRequestStillVerified(t *testing.T) {
+	middleware, called := newCORSTestMiddleware(DefaultCORSConfig(), false)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { *called = true }))
+
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Signature", "bad")
+	req.Header.Set("Signature-Input", `sig1=();keyid="did:sage:ethereum:0xtest"`)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.False(t, *called)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_RequireSignedCrossOriginInOptionalMode(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.RequireSignedCrossOrigin = true
+	middleware, called := newCORSTestMiddleware(cfg, true)
+	middleware.SetOptional(true)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { *called = true }))
+
+	// Same-origin unsigned request is allowed in optional mode
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/rpc", nil))
+	assert.True(t, *called)
+
+	// Cross-origin unsigned request is rejected
+	*called = false
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.False(t, *called)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestCORS_StrictModeDisablesBypass(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.Strict = true
+	middleware, called := newCORSTestMiddleware(cfg, false)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { *called = true }))
+
+	// Unsigned preflight
+	req := httptest.NewRequest("OPTIONS", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// Plain OPTIONS without Origin
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("OPTIONS", "/rpc", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.False(t, *called)
+}
+
+func TestCORS_SignatureHeadersAlwaysAllowed(t *testing.T) {
+	cfg := &CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"POST"},
+		AllowedHeaders: []string{"Content-Type"},
+	}
+	middleware, _ := newCORSTestMiddleware(cfg, false)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("OPTIONS", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "content-type, signature, signature-input, content-digest")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "Content-Type, Signature, Signature-Input, Content-Digest", rr.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, []string{"Content-Type"}, cfg.AllowedHeaders)
+}
+
+func TestCORS_DefaultConfigCoversSAGEHeaders(t *testing.T) {
+	middleware, _ := newCORSTestMiddleware(DefaultCORSConfig(), true)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("OPTIONS", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "signature, a2a-priority, a2a-deadline, a2a-message-digest, idempotency-key")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	req = httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Signature", "mock-signature")
+	req.Header.Set("Signature-Input", `sig1=();keyid="did:sage:ethereum:0xtest"`)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Access-Control-Expose-Headers"), "A2A-Receipt")
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+)
+
+// maxDebugRequestSize bounds the pasted requests DebugHandler accepts
+const maxDebugRequestSize = 1 << 20
+
+// DebugConfig configures signature debugging. Diagnoses reveal the
+// reconstructed signature base and the resolved key, and computing them
+// resolves the key a second time, so debug mode is meant for interop
+// testing rather than production traffic.
+type DebugConfig struct {
+	// Trusted reports whether the caller of r may see the diagnosis in the
+	// 401 response body. Nil discloses it to nobody.
+	Trusted func(r *http.Request) bool
+
+	// Log receives the diagnosis of every failed verification, if set
+	Log func(r *http.Request, d *verifier.Diagnosis)
+}
+
+// DiagnosedError is a verification failure with its diagnosis, passed to
+// the error handler in debug mode. The default error handler returns the
+// diagnosis in the Details of the error body when Disclose is set.
+type DiagnosedError struct {
+	Err       error
+	Diagnosis *verifier.Diagnosis
+	Disclose  bool
+}
+
+func (e *DiagnosedError) Error() string { return e.Err.Error() }
+func (e *DiagnosedError) Unwrap() error { return e.Err }
+
+// SetDebug enables debug mode: failed verifications of signed requests are
+// diagnosed (see verifier.Diagnose), logged and, for trusted callers,
+// explained in the response. Pass nil to disable it.
+func (m *DIDAuthMiddleware) SetDebug(cfg *DebugConfig) {
+	m.update(func(c *middlewareConfig) {
+		c.debug = cfg
+	})
+}
+
+// diagnose wraps err with a diagnosis of r, if r is signed. Requests with
+// streamed bodies are not diagnosed, as that would consume the upload.
+func (m *middlewareConfig) diagnose(r *http.Request, err error) error {
+	if r.Header.Get("Signature-Input") == "" || streamingSigned(r) {
+		return err
+	}
+	d := verifier.Diagnose(r.Context(), m.verifier, r)
+	if m.debug.Log != nil {
+		m.debug.Log(r, d)
+	}
+	return &DiagnosedError{
+		Err:       err,
+		Diagnosis: d,
+		Disclose:  m.debug.Trusted != nil && m.debug.Trusted(r),
+	}
+}
+
+// DebugRequest is the JSON form of a request submitted to DebugHandler
+type DebugRequest struct {
+	// Request is the raw HTTP/1.1 request as sent, headers and body
+	Request string `json:"request"`
+
+	// Scheme is the scheme the request was sent with (default "https")
+	Scheme string `json:"scheme,omitempty"`
+
+	// ExpectedBase is the signature base the signer computed, if known
+	ExpectedBase string `json:"expectedBase,omitempty"`
+}
+
+// DebugHandler returns an http.Handler for a /debug/verify endpoint: it
+// verifies a pasted request with the middleware's verifier and answers
+// with its verifier.Diagnosis as JSON. The request is POSTed either raw,
+// exactly as sent on the wire, or as a DebugRequest JSON object, which can
+// also carry the signer's signature base for comparison. The handler is
+// not authenticated; mount it on an internal listener.
+func (m *DIDAuthMiddleware) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, protocol.ErrorBody{Code: protocol.ErrorCodeInvalidRequest, Message: "POST a request to verify"})
+			return
+		}
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxDebugRequestSize))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, protocol.ErrorBody{Code: protocol.ErrorCodeInvalidRequest, Message: err.Error()})
+			return
+		}
+
+		in := DebugRequest{Request: string(data)}
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			in = DebugRequest{}
+			if err := json.Unmarshal(data, &in); err != nil {
+				writeError(w, http.StatusBadRequest, protocol.ErrorBody{Code: protocol.ErrorCodeInvalidRequest, Message: "invalid debug request: " + err.Error()})
+				return
+			}
+		}
+
+		pasted, err := parsePastedRequest(in.Request, in.Scheme)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, protocol.ErrorBody{Code: protocol.ErrorCodeInvalidRequest, Message: err.Error()})
+			return
+		}
+		pasted = pasted.WithContext(r.Context())
+
+		d := verifier.Diagnose(r.Context(), m.config.Load().verifier, pasted)
+		if in.ExpectedBase != "" {
+			d.CompareBase(in.ExpectedBase)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(d)
+	})
+}
+
+// parsePastedRequest parses a raw HTTP/1.1 request. Pasted text often has
+// bare newlines and a trailing newline, so the body is taken as the text
+// after the blank line, cut to Content-Length if present and otherwise
+// without its final newline.
+func parsePastedRequest(raw, scheme string) (*http.Request, error) {
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+	head, body, _ := strings.Cut(raw, "\n\n")
+	head = strings.TrimLeft(head, "\n")
+
+	req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(strings.ReplaceAll(head, "\n", "\r\n") + "\r\n\r\n")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if n, err := strconv.Atoi(req.Header.Get("Content-Length")); err == nil {
+		if n >= 0 && n < len(body) {
+			body = body[:n]
+		}
+	} else {
+		body = strings.TrimSuffix(body, "\n")
+	}
+	req.Body = io.NopCloser(bytes.NewReader([]byte(body)))
+	req.ContentLength = int64(len(body))
+
+	if scheme == "" {
+		scheme = "https"
+	}
+	req.URL.Scheme = scheme
+	req.URL.Host = req.Host
+	return req, nil
+}
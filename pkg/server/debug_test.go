@@ -0,0 +1,121 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugMode(t *testing.T) {
+	middleware, agentDID, keyPair := streamingFixture(t)
+	var logged []*verifier.Diagnosis
+	middleware.SetDebug(&DebugConfig{
+		Trusted: func(r *http.Request) bool { return r.Header.Get("X-Debug") != "" },
+		Log:     func(r *http.Request, d *verifier.Diagnosis) { logged = append(logged, d) },
+	})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tampered := func() *http.Request {
+		req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{"a":1}`))
+		require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, agentDID, keyPair))
+		req.Body = httptest.NewRequest("POST", "/rpc", strings.NewReader(`{"a":2}`)).Body
+		return req
+	}
+
+	// Trusted callers get the diagnosis
+	req := tampered()
+	req.Header.Set("X-Debug", "1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var body struct {
+		Code    string              `json:"code"`
+		Details *verifier.Diagnosis `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.NotNil(t, body.Details)
+	assert.Equal(t, verifier.DiagnosisContentDigest, body.Details.Stage)
+	assert.Equal(t, string(agentDID), body.Details.KeyID)
+	assert.NotEmpty(t, body.Details.KeyFingerprint)
+
+	// Others only get the error
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, tampered())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "details")
+
+	assert.Len(t, logged, 2)
+}
+
+func TestDebugHandler(t *testing.T) {
+	middleware, agentDID, keyPair := streamingFixture(t)
+	debug := httptest.NewServer(middleware.DebugHandler())
+	defer debug.Close()
+
+	req := httptest.NewRequest("POST", "https://agent.example.com/rpc", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, agentDID, keyPair))
+
+	var raw strings.Builder
+	raw.WriteString("POST /rpc HTTP/1.1\nHost: agent.example.com\n")
+	for _, name := range []string{"Content-Type", "Content-Digest", "Signature-Input", "Signature"} {
+		raw.WriteString(name + ": " + req.Header.Get(name) + "\n")
+	}
+	raw.WriteString("\n")
+
+	diagnose := func(contentType, payload string) *verifier.Diagnosis {
+		resp, err := http.Post(debug.URL, contentType, strings.NewReader(payload))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var d verifier.Diagnosis
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&d))
+		return &d
+	}
+
+	d := diagnose("text/plain", raw.String()+`{"a":1}`+"\n")
+	assert.True(t, d.Verified, "%+v", d)
+
+	d = diagnose("text/plain", raw.String()+`{"a":2}`)
+	assert.False(t, d.Verified)
+	assert.Equal(t, verifier.DiagnosisContentDigest, d.Stage)
+
+	payload, err := json.Marshal(DebugRequest{
+		Request:      raw.String() + `{"a":1}`,
+		ExpectedBase: "\"@method\": GET\n",
+	})
+	require.NoError(t, err)
+	d = diagnose("application/json", string(payload))
+	assert.NotEmpty(t, d.BaseMismatches)
+
+	resp, err := http.Get(debug.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
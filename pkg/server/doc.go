@@ -36,7 +36,8 @@
 //
 //	// Create DID authentication middleware
 //	ethereumClient, _ := ethereum.NewEthereumClientV4(config)
-//	middleware := server.NewDIDAuthMiddleware(ethereumClient)
+//	cardClient, _ := ethereum.NewAgentCardClient(config)
+//	middleware := server.NewDIDAuthMiddleware(cardClient, ethereumClient)
 //
 //	// Wrap HTTP handler
 //	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -59,6 +60,53 @@
 //	// Allow unsigned requests to pass through
 //	middleware.SetOptional(true)
 //
+// SetOptionalPolicy decides per request instead: unsigned requests pass
+// only when the policy returns true, while signed requests are always
+// verified. Policies compose with OptionalAny and OptionalAll:
+//
+//	middleware.SetOptionalPolicy(server.OptionalAny(
+//	    server.OptionalForPrivateNetworks(),
+//	    server.OptionalForPaths("/public"),
+//	))
+//
+// SetSkipFunc exempts selected requests from the middleware altogether,
+// for example traffic from an internal network:
+//
+//	_, internal, _ := net.ParseCIDR("10.0.0.0/8")
+//	middleware.SetSkipFunc(func(r *http.Request) bool {
+//	    host, _, _ := net.SplitHostPort(r.RemoteAddr)
+//	    return internal.Contains(net.ParseIP(host))
+//	})
+//
+// Unsigned requests can still say who they claim to be. With
+// SetUnverifiedHints, a DID claimed in the A2A-Claimed-DID header and the
+// identity of a known API key are exposed through
+// GetUnverifiedIdentityFromContext and AuthzInput.Unverified, kept apart
+// from the verified DID, so handlers can extend some trust to them, e.g.
+// lower rate limits:
+//
+//	middleware.SetUnverifiedHints(&server.UnverifiedHintConfig{APIKeys: lookupAPIKey})
+//
+//	if id, ok := server.GetUnverifiedIdentityFromContext(r.Context()); ok {
+//	    limiter = unverifiedLimiter(id.APIKeyIdentity)
+//	}
+//
+// With optional verification, a handler that forgets to check the caller
+// DID leaks privileged data to anonymous callers. ResponseGuard, placed
+// inside the middleware, withholds 2xx responses to requests without a
+// verified DID unless the handler calls AllowAnonymous:
+//
+//	guard := server.NewResponseGuard()
+//	guard.SetBlockHook(func(r *http.Request, status int) {
+//	    log.Printf("withheld %d response to anonymous %s", status, r.URL.Path)
+//	})
+//	handler := server.Chain(middleware.Wrap, guard.Wrap)(mux)
+//
+//	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+//	    server.AllowAnonymous(r.Context())
+//	    // public response
+//	})
+//
 // # Custom Error Handler
 //
 //	middleware.SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
@@ -104,15 +152,51 @@
 //	    // Process request...
 //	}
 //
+// GetSignatureParamsFromContext returns the parameters of the verified
+// signature, such as its tag and extension parameters.
+//
+// When Signature-Input carries several signatures, the middleware reads
+// parameters and covered headers only from the one the verifier checks,
+// as reported by verifier.SignatureParamsReader. With a verifier that
+// does not report it, requests carrying several signatures are rejected.
+//
+// Executors written against plain a2a-go can get the same information from
+// the request metadata instead: IdentityInterceptor copies the verified
+// DID, capabilities, extensions and SPIFFE ID into
+// a2asrv.RequestContext.Metadata under "sage.caller.did" and the other
+// Caller* keys, discarding any the client sent itself:
+//
+//	handler := a2asrv.NewHandler(executor,
+//	    a2asrv.WithRequestContextInterceptor(server.IdentityInterceptor{}))
+//
+//	// In the executor
+//	caller, _ := reqCtx.Metadata["sage.caller.did"].(string)
+//
 // # CORS Support
 //
-// The middleware automatically allows OPTIONS requests to pass through without
+// By default the middleware allows OPTIONS requests to pass through without
 // signature verification. This is essential for browser-based clients that
 // send CORS preflight requests.
 //
 //	// OPTIONS requests are not verified
 //	// Other methods (GET, POST, etc.) require signatures
 //
+// For finer control, configure a CORS policy. Preflights from allowed origins
+// are answered directly, other origins are rejected with 403, and actual
+// cross-origin requests still go through signature verification:
+//
+//	cors := server.DefaultCORSConfig()
+//	cors.AllowedOrigins = []string{"https://*.example.com"}
+//	cors.RequireSignedCrossOrigin = true // even in optional mode
+//	middleware.SetCORS(cors)
+//
+// Signature, Signature-Input and Content-Digest are always allowed in
+// preflights, so browser agents (see cmd/examples/browser-agent) can send
+// signed requests whatever AllowedHeaders lists. DefaultCORSConfig also
+// allows the SAGE request headers and exposes the SAGE response headers.
+//
+// Set Strict to disable the preflight bypass entirely.
+//
 // # Body Preservation
 //
 // The middleware reads and preserves the request body so it can be used by
@@ -130,9 +214,27 @@
 //	    // Process body...
 //	}
 //
+// Requests signed with signer.SignRequestStreaming are the exception: their
+// header signature is verified before the body is read, so rejected
+// "Expect: 100-continue" uploads are never sent, and the body reaches the
+// handler unbuffered. It is hashed as it is read and the Content-Digest and
+// signature trailers are verified at the end; if they do not verify, the
+// handler's final Read returns an error wrapping ErrTrailerVerification
+// instead of io.EOF. Handlers must therefore read such bodies to the end
+// and check the error before acting on them. Authorization and quotas see
+// these requests with an empty body.
+//
 // # Error Handling
 //
-// By default, verification errors return 401 Unauthorized with an error message.
+// By default, verification errors return 401 Unauthorized with an error message
+// and authorization errors return 403 Forbidden. A key resolution that
+// timed out (verifier.ErrResolutionTimeout) returns 503 Service Unavailable.
+// The 401, 403, 429 and 503 responses written by the middleware carry a JSON
+// protocol.ErrorBody with a machine-readable code, whether the request may
+// be retried, and after how many seconds:
+//
+//	{"code":"rate_limited","message":"Too Many Requests: ...","retryable":true,"retry_after":12}
+//
 // You can customize this behavior:
 //
 //	middleware.SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
@@ -148,6 +250,497 @@
 //	    })
 //	})
 //
+// # Debugging Signatures
+//
+// SetDebug diagnoses failed verifications (see verifier.Diagnose): the
+// first failing stage, missing components, the reconstructed signature
+// base and the fingerprint of the resolved key. Diagnoses go to Log and,
+// for callers Trusted accepts, into the Details of the 401 body.
+// DebugHandler serves a /debug/verify endpoint diagnosing a pasted raw
+// request, optionally compared with the signer's signature base:
+//
+//	middleware.SetDebug(&server.DebugConfig{
+//	    Trusted: func(r *http.Request) bool { return isInternal(r.RemoteAddr) },
+//	    Log:     func(r *http.Request, d *verifier.Diagnosis) { log.Printf("%+v", d) },
+//	})
+//	internal.Handle("/debug/verify", middleware.DebugHandler())
+//
+//	curl --data-binary @request.txt http://localhost:9090/debug/verify
+//
+// Diagnoses reveal signature internals; keep both off public listeners.
+//
+// # Content Digests
+//
+// When a request carries Content-Digest, the body is checked against it.
+// sha-256 and sha-512 entries are accepted; SetDigestAlgorithms restricts the
+// list and advertises it via Want-Content-Digest so DIDHTTPTransport switches
+// algorithms automatically:
+//
+//	middleware.SetDigestAlgorithms(signer.DigestSHA512, signer.DigestSHA256)
+//
+// # Message Digests
+//
+// When a signed message/send or message/stream request covers an
+// A2A-Message-Digest header, the middleware checks it against the message
+// in the body and rejects mismatches with 401 Unauthorized. Handlers read
+// the verified digest with GetMessageDigestFromContext and can store it
+// with the message; bodies with trailing digests are not checked.
+//
+// # Compression
+//
+// CompressionHandler decodes gzip/deflate request bodies and compresses
+// responses. Wrap it inside the DID middleware so the signature is checked
+// against the compressed bytes the client signed:
+//
+//	handler := middleware.Wrap(server.NewCompressionHandler(nil).Wrap(rpcHandler))
+//
+// # Middleware Chains
+//
+// Chain composes middlewares in request order, outermost first, skipping
+// nil entries. Rate limiting, authorization and quotas are configured on
+// DIDAuthMiddleware itself, so a full stack is:
+//
+//	handler := server.Chain(
+//	    recorder.Wrap,
+//	    middleware.Wrap,
+//	    server.NewCompressionHandler(nil).Wrap,
+//	)(rpcHandler)
+//
+// Values the middlewares store in the request context are read with the
+// Get*FromContext functions; their keys are unexported and cannot collide
+// with keys set by other packages.
+//
+// # Request Receipts
+//
+// ReceiptHandler adds a signed receipt to the A2A-Receipt header of every
+// successful response to a verified request, covering the request digest,
+// task ID and time. Place it inside the DID middleware and any
+// CompressionHandler:
+//
+//	handler := server.Chain(
+//	    middleware.Wrap,
+//	    server.NewCompressionHandler(nil).Wrap,
+//	    server.NewReceiptHandler(agentDID, keyPair).Wrap,
+//	)(rpcHandler)
+//
+// # Payload Codecs
+//
+// CodecHandler lets clients send and receive CBOR instead of JSON (see
+// the codec package). Request bodies with a registered Content-Type are
+// decoded to JSON for the handler, and responses and event streams are
+// encoded with the codec the Accept header prefers. Place it inside
+// CompressionHandler and outside ReceiptHandler:
+//
+//	handler := server.Chain(
+//	    middleware.Wrap,
+//	    server.NewCompressionHandler(nil).Wrap,
+//	    server.NewCodecHandler().Wrap,
+//	    server.NewReceiptHandler(agentDID, keyPair).Wrap,
+//	)(rpcHandler)
+//
+// Method capabilities, quotas and message digests are checked against the
+// decoded request, whatever its encoding.
+//
+// # Fingerprinting and Anomaly Detection
+//
+// SetFingerprintHook receives a RequestFingerprint (DID, remote IP, user agent,
+// method, path, body size, Signature-Input parameters) after every
+// verification attempt. AnomalyDetector is a built-in heuristic detector that
+// flags DIDs seen from many IPs, repeated verification failures and requests
+// outside active hours:
+//
+//	cfg := server.DefaultAnomalyConfig()
+//	cfg.OnAnomaly = func(a server.Anomaly) { log.Printf("anomaly %s: %s %s", a.Kind, a.AgentDID, a.Detail) }
+//	middleware.SetFingerprintHook(server.NewAnomalyDetector(cfg).Hook())
+//
+// # Authorization
+//
+// SetAuthorizer adds an authorization step after verification. The Authorizer
+// receives the verified DID, path, JSON-RPC method and (with
+// SetCapabilityResolver) the caller's registered capabilities. Denied
+// requests get 403 Forbidden from the default error handler.
+//
+// A local policy engine:
+//
+//	middleware.SetCapabilityResolver(server.NewMetadataCapabilityResolver(resolver))
+//	middleware.SetAuthorizer(server.NewPolicyAuthorizer(
+//	    server.PolicyRule{Effect: server.PolicyDeny, DIDs: []string{"did:sage:ethereum:0xbad"}},
+//	    server.PolicyRule{Effect: server.PolicyAllow, RPCMethods: []string{"message/send"}},
+//	    server.PolicyRule{Effect: server.PolicyAllow, RequiredCapabilities: []string{"admin"}},
+//	))
+//
+// Or an external Open Policy Agent:
+//
+//	middleware.SetAuthorizer(server.NewOPAAuthorizer("http://localhost:8181/v1/data/a2a/authz", nil))
+//
+// # Method Capabilities
+//
+// SetMethodCapabilities requires each JSON-RPC method to be called by agents
+// holding the listed capabilities, checked after verification and before the
+// authorizer. MethodCapabilitiesFromCard derives the map from the agent's
+// own card: message/send and message/stream need "messaging.receive",
+// tasks/cancel needs "task.cancel", other task methods need "task.read" and
+// push notification config methods need "task.push". Methods the card does
+// not advertise, and unknown methods, are denied with 403 Forbidden:
+//
+//	middleware.SetCapabilityResolver(server.NewMetadataCapabilityResolver(resolver))
+//	middleware.SetMethodCapabilities(server.MethodCapabilitiesFromCard(card))
+//
+// # Capability Grants
+//
+// SetCapabilityGrants lets callers hold extra capabilities for a limited
+// time. Grants arrive in the signed A2A-Capability-Grant header; each one is
+// signed by its issuer, bound to the caller's DID and checked against
+// MaxTTL. Issuers must be trusted, or with Delegation hold the capability
+// themselves. Granted capabilities are merged into AuthzInput.Capabilities
+// and exposed through GetCapabilitiesFromContext; invalid grants are denied
+// with 403 Forbidden:
+//
+//	middleware.SetCapabilityGrants(&server.GrantConfig{
+//	    TrustedIssuers: []did.AgentDID{"did:sage:ethereum:0xadmin"},
+//	    MaxTTL:         15 * time.Minute,
+//	})
+//
+// # Attestations
+//
+// SetAttestationPolicy protects sensitive methods and paths with
+// third-party attestations (see protocol.Attestation). The Resolver
+// returns the caller's attestation tokens, typically from its Agent Card;
+// only unexpired attestations about the caller signed by a listed attester
+// count. Verified attestations are exposed through
+// GetAttestationsFromContext and AuthzInput.Attestations; callers missing
+// one are denied with 403 Forbidden:
+//
+//	middleware.SetAttestationPolicy(&server.AttestationPolicy{
+//	    Resolver: cardAttestations,
+//	    Methods: map[string][]server.AttestationRequirement{
+//	        "tasks/cancel": {{Claim: "audited", Attesters: []did.AgentDID{auditorDID}}},
+//	    },
+//	})
+//
+// # Task Continuation
+//
+// SetTaskContinuation accepts signed task contexts (see
+// protocol.ExportTaskContext) from agents delegating their tasks. A
+// context must be exported by the caller for this agent; accepted ones
+// are exposed through GetTaskContextFromContext and the
+// CallerTaskContextKey of IdentityInterceptor. TaskQueue creates the
+// continued task under the original context ID and records the link,
+// which ContinuedFrom returns:
+//
+//	middleware.SetTaskContinuation(&server.TaskContextConfig{Audience: agentDID})
+//
+// # Peer Exchange
+//
+// PeerExchange serves agents/listPeers to verified callers from the peers
+// added to it, never listing a caller to itself:
+//
+//	peers := server.NewPeerExchange()
+//	_ = peers.Add(protocol.Peer{DID: peerDID, Card: signedPeerCard})
+//	handler := server.Chain(auth.Wrap, peers.Wrap)(rpcHandler)
+//
+// # SPIFFE Interop
+//
+// Agents inside a service mesh can present a SPIFFE SVID over mTLS in
+// addition to (or instead of) a DID signature. The http.Server performs
+// client certificate verification; SetSPIFFE decides how the SVID combines
+// with the signature:
+//
+//	middleware.SetSPIFFE(&server.SPIFFEConfig{
+//	    Mode:         server.SPIFFEBoth, // or server.SPIFFEEither
+//	    TrustDomains: []string{"cluster.local"},
+//	    Mapper: server.NewStaticSPIFFEMapper(map[string]did.AgentDID{
+//	        "spiffe://cluster.local/ns/agents/sa/planner": "did:sage:ethereum:0x...",
+//	    }),
+//	})
+//
+// SPIFFEBoth requires both credentials and, with a Mapper, that they name the
+// same agent. SPIFFEEither accepts unsigned requests whose SVID maps to a
+// DID. The SPIFFE ID is available via GetSPIFFEIDFromContext.
+//
+// # Audit Logging
+//
+// SetAuditLogger emits an AuditEvent for every failed verification. Wrap the
+// logger with NewRateLimitedAuditLogger so repeated failures from the same
+// DID and IP are logged once per window and then summarized:
+//
+//	audit := server.NewRateLimitedAuditLogger(server.NewLogAuditLogger(nil),
+//	    server.RateLimitedAuditConfig{Window: time.Minute})
+//	go audit.Start(ctx)
+//	middleware.SetAuditLogger(audit)
+//
+// Set Debug in the config to log every failure individually.
+//
+// # Replay Protection
+//
+// SetReplayProtection rejects signatures that were already accepted, keyed
+// by signer and nonce, or by the signature itself when it has no nonce.
+// Entries live until the signature expires, but no longer than
+// ReplayConfig.TTL, and are only written once the signature has been
+// verified. The default in-memory store only covers one process; replicas
+// should share a RedisReplayStore, which pipelines concurrent checks and
+// fails closed (503) unless FailOpen is set:
+//
+//	replays, err := server.NewRedisReplayStore(server.RedisReplayConfig{
+//	    Client:      redisClient, // see RedisClient
+//	    BatchWindow: time.Millisecond,
+//	})
+//	defer replays.Close()
+//	middleware.SetReplayProtection(&server.ReplayConfig{Store: replays})
+//
+// # Tiered Verification
+//
+// SetPrecheck runs cheap checks before key resolution and signature math:
+// signature header syntax, DID syntax of the keyid, the created/expires
+// window, a Denylist and, with replay protection, a read-only lookup of
+// the signature in the replay store. Garbage and abusive traffic is then
+// rejected without chain lookups. StageMetrics counts passes, rejections and time per stage,
+// including the signature stage itself:
+//
+//	metrics := server.NewStageMetrics()
+//	denied := server.NewDenylist()
+//	middleware.SetPrecheck(&server.PrecheckConfig{Denylist: denied, Metrics: metrics})
+//	denied.Add(abusiveDID)
+//	for _, s := range metrics.Stats() {
+//	    log.Printf("%s: %d passed, %d rejected, %v", s.Stage, s.Passed, s.Rejected, s.Duration)
+//	}
+//
+// # Priorities and Deadlines
+//
+// Signed A2A-Priority and A2A-Deadline headers (see the protocol package) are
+// exposed through GetPriorityFromContext and GetDeadlineFromContext, and the
+// request context is cancelled at the deadline. Requests whose deadline has
+// passed are rejected with 504 Gateway Timeout; malformed hints with 400.
+// Hint headers not covered by the signature are ignored.
+//
+// A signed X-Request-Budget header carries the time the caller has left.
+// The request context ends at the earliest of the deadline, the budget and
+// the limit set with SetRequestTimeout, and the transport passes what
+// remains on to the agents the handler calls, so a chain of agents stops
+// at the first caller's deadline:
+//
+//	middleware.SetRequestTimeout(30 * time.Second)
+//
+// With a verification pool, requests marked low priority are shed as soon as
+// the queue is full instead of waiting for QueueTimeout.
+//
+// # HTTP/3
+//
+// NewHTTP3Server serves a handler over HTTP/3 (QUIC) with quic-go, and
+// AdvertiseHTTP3 announces it in the Alt-Svc header of HTTP/1.1 and HTTP/2
+// responses. HTTP/3 requests carry their scheme and authority in
+// pseudo-headers only, so the middleware restores them before
+// reconstructing @target-uri and @authority.
+//
+// Requests received in 0-RTT early data, reported by the server or by a
+// proxy's Early-Data header, can be replayed by an attacker. The server
+// refuses 0-RTT unless HTTP3Config.Allow0RTT is set, and even then signed
+// requests in early data are refused with 425 Too Early unless
+// SetEarlyData allows them; the transport then sends them again:
+//
+//	h3 := server.NewHTTP3Server(":443", handler, tlsConfig, &server.HTTP3Config{Allow0RTT: true})
+//	middleware.SetEarlyData(&server.EarlyDataConfig{Methods: []string{"tasks/get"}})
+//	go h3.ListenAndServe()
+//	http.ListenAndServeTLS(":443", certFile, keyFile, server.AdvertiseHTTP3(h3, handler))
+//
+// # Usage Tracking and Quotas
+//
+// SetUsageTracker tracks in-flight requests and rolling request, byte and
+// task counts per verified DID. Non-zero limits are enforced with 429 Too
+// Many Requests, RateLimit-* and Retry-After headers, and an
+// X-Quota-Exceeded header naming the quota:
+//
+//	usage := server.NewUsageTracker(server.QuotaConfig{
+//	    Window:      time.Minute,
+//	    MaxInFlight: 8,
+//	    MaxRequests: 600,
+//	})
+//	middleware.SetUsageTracker(usage)
+//	mux.Handle("/internal/usage", usage.Handler())
+//
+// Handlers can read the caller's usage with GetUsageFromContext.
+//
+// UsageTracker counts per replica. For a global limit per calling DID across
+// horizontally scaled servers, SetRateLimiter applies a token bucket kept in
+// a shared RateLimitStore. RedisRateLimitStore updates buckets atomically
+// with a Lua script; if the store fails or times out, the limiter enforces
+// Fallback locally for FallbackPeriod instead of rejecting requests:
+//
+//	store, _ := server.NewRedisRateLimitStore(server.RedisRateLimitConfig{Client: goRedis{rdb}})
+//	limiter, _ := server.NewDistributedRateLimiter(server.DistributedRateLimitConfig{
+//	    Store:    store,
+//	    Limit:    server.RateLimit{Rate: 10, Burst: 50},
+//	    Fallback: server.RateLimit{Rate: 2.5, Burst: 15}, // four replicas
+//	})
+//	middleware.SetRateLimiter(limiter)
+//
+// # Reputation
+//
+// ReputationTracker aggregates per-DID verification outcomes, task results
+// and error responses into a score between 0 and 1 that decays towards a
+// neutral prior. The score is available from GetReputationFromContext and
+// AuthzInput.Reputation, and the tracker's Authorizer can gate expensive
+// methods on a minimum score:
+//
+//	reputation := server.NewReputationTracker(server.ReputationConfig{})
+//	middleware.SetReputationTracker(reputation)
+//	middleware.SetAuthorizer(reputation.Authorizer(0.7, "message/stream"))
+//
+//	queue := server.NewTaskQueue(executor, server.TaskQueueConfig{Reputation: reputation})
+//
+// Verification failures are attributed to the unverified keyid, so they
+// are counted but do not lower the score.
+//
+// # Artifact Integrity
+//
+// NewArtifactSealingExecutor wraps an a2asrv.AgentExecutor so every artifact
+// it emits carries a digest, and a signature when the sealer has a key pair:
+//
+//	executor = server.NewArtifactSealingExecutor(executor, &protocol.ArtifactSealer{
+//	    AgentDID: agentDID,
+//	    KeyPair:  keyPair,
+//	})
+//
+// # Streaming Artifacts
+//
+// An ArtifactStreamWriter streams large artifact content from an executor
+// as a sequence of TaskArtifactUpdateEvent chunks: the first chunk creates
+// the artifact, later ones set append and the last one sets lastChunk.
+// Content is sent as file parts, or as text parts split on UTF-8
+// boundaries:
+//
+//	w := server.NewArtifactStreamWriter(ctx, queue, reqCtx, server.ArtifactStreamConfig{
+//	    Name:     "dump.tar",
+//	    MimeType: "application/x-tar",
+//	})
+//	if _, err := io.Copy(w, src); err != nil {
+//	    return err
+//	}
+//	return w.Close()
+//
+// Clients reassemble the content with transport.ArtifactReader.
+//
+// # Artifact Files
+//
+// NewArtifactFileHandler serves artifact files at /artifacts/{id} for signed
+// callers. Uploads may arrive in chunks and resume after interruption;
+// downloads honor Range requests. The Repr-Digest of the complete file is
+// checked on upload and sent with every download. Read and write access can
+// be limited to callers holding a capability:
+//
+//	store, err := server.NewDirArtifactFileStore(dir)
+//	files := server.NewArtifactFileHandler(server.ArtifactFileConfig{
+//	    Store:           store,
+//	    WriteCapability: "files:write",
+//	})
+//	mux.Handle(protocol.ArtifactFilesPath, middleware.Wrap(files))
+//
+// # Task Queue
+//
+// TaskQueue runs an a2asrv.AgentExecutor in the background with a bounded
+// number of workers. Submitted tasks are persisted in a TaskStore, every
+// executor event is applied to the stored task, and events are published
+// to the task's event queue for streaming:
+//
+//	queue := server.NewTaskQueue(executor, server.TaskQueueConfig{
+//	    Workers: 8,
+//	    Store:   store,
+//	})
+//	defer queue.Close()
+//
+//	// Pick up tasks interrupted by the last shutdown
+//	if _, err := queue.Resume(ctx); err != nil {
+//	    log.Printf("resume: %v", err)
+//	}
+//
+//	task, err := queue.Submit(r.Context(), msg)
+//	events, err := queue.Subscribe(ctx, task.ID)
+//
+// Shutdown stops accepting tasks and lets running executions finish until
+// its context is done, then cancels them. Unfinished tasks keep their
+// stored state, so Resume continues them after a restart, and their event
+// streams end with a final status event carrying TaskInterruptedKey. Close
+// cancels running executions immediately. Shut down the push notifier
+// afterwards so final task states are still delivered:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	_ = httpServer.Shutdown(ctx)
+//	_ = queue.Shutdown(ctx)
+//	_ = notifier.Shutdown(ctx)
+//
+// GetTask and ListTasks serve tasks/get and tasks/list from the store.
+// HistoryLength limits each task to its most recent messages, ListTasks
+// returns pages of PageSize tasks with an opaque NextPageToken, and
+// artifacts are only listed with IncludeArtifacts.
+//
+// Clients that cannot use SSE poll task events instead. The queue keeps
+// the events of each task, and of finished tasks for EventRetention;
+// NewTaskEventsHandler serves them at protocol.TaskEventsPath to the DID
+// that submitted the task, waiting up to the requested time for new ones:
+//
+//	mux.Handle(protocol.TaskEventsPath, middleware.Wrap(server.NewTaskEventsHandler(queue)))
+//
+// Executors pause a task with RequestInput or RequestAuth. The client's
+// reply, submitted with the same task ID, starts a new execution with the
+// reply as reqCtx.Message. Only the DID that submitted the task may reply.
+//
+// # Push Notifications
+//
+// WithPushConfigStore serves the tasks/pushNotificationConfig/* methods of
+// an a2asrv.RequestHandler from a PushConfigStore. MemoryPushConfigStore and
+// SQLPushConfigStore (any database/sql driver) also record delivery
+// attempts made by PushNotifier; endpoints failing MaxFailures times in a
+// row are disabled until reset through PushAdminHandler:
+//
+//	store, _ := server.NewSQLPushConfigStore(db, server.PushStoreConfig{Placeholder: server.DollarPlaceholder})
+//	_ = store.CreateTable(ctx)
+//	handler := server.WithPushConfigStore(a2asrv.NewHandler(executor,
+//	    a2asrv.WithPushConfigStore(store),
+//	    a2asrv.WithPushNotifier(server.NewPushNotifier(store, nil)),
+//	), store)
+//	mux.Handle("/internal/push", server.PushAdminHandler(store))
+//
+// # Extensions
+//
+// SetExtensions declares the supported A2A extensions. Requests activate
+// extensions through the signed X-A2A-Extensions header; a request that
+// does not activate a required extension is rejected with 400 Bad Request.
+// Handlers read the activated set with GetExtensionsFromContext, and check
+// message payloads with ValidateMessageExtensions:
+//
+//	middleware.SetExtensions(card.Capabilities.Extensions...)
+//
+//	if server.IsExtensionActive(r.Context(), protocol.SessionKeyExtensionURI) {
+//	    // ...
+//	}
+//
+// # Health Probes
+//
+// Load balancer health checks cannot produce RFC 9421 signatures.
+// SetProbeBypass admits them on specific paths with a static bearer token,
+// a verified mTLS client certificate, or both. Every bypass is audited:
+//
+//	middleware.SetProbeBypass(&server.ProbeBypass{
+//	    Paths: []string{"/healthz"},
+//	    Token: os.Getenv("PROBE_TOKEN"),
+//	})
+//
+// # OpenAPI
+//
+// NewOpenAPIHandler serves an OpenAPI 3.1 document describing the JSON-RPC
+// methods the agent serves, the RFC 9421 signature headers and the SAGE
+// extension payloads, so non-Go clients can be generated from it. With
+// ?schema=<name> it serves one component as a standalone JSON Schema:
+//
+//	openapi, err := server.NewOpenAPIHandler(card, server.OpenAPIConfig{RPCPath: "/rpc"})
+//	mux.Handle(server.DefaultOpenAPIPath, openapi)
+//
+//	// GET /openapi.json?schema=MessageSendRequest
+//
+// Mount it outside the DID middleware (or behind SetProbeBypass) so clients
+// can fetch it before they have an identity.
+//
 // # Integration with A2A Protocol
 //
 // This middleware is designed for use with the Agent-to-Agent (A2A) Protocol,
@@ -162,6 +755,16 @@
 // The middleware is safe for concurrent use by multiple goroutines and can
 // be shared across multiple HTTP servers.
 //
+// Its setters may also be called while requests are being served, e.g. to
+// require signatures during an incident. Each request uses the
+// configuration current when it arrived; UpdateConfig changes several
+// settings at once so no request sees a partial update:
+//
+//	middleware.UpdateConfig(func(staged *server.DIDAuthMiddleware) {
+//	    staged.SetOptional(false)
+//	    staged.SetAuditLogger(incidentLogger)
+//	})
+//
 // # Performance Considerations
 //
 //   - Signature verification requires public key resolution from blockchain
@@ -169,6 +772,18 @@
 //   - Body buffering requires memory proportional to body size
 //   - For large bodies, consider streaming verification if supported
 //
+// Under burst traffic, CPU-heavy verification (notably secp256k1) can be
+// bounded with a worker pool. Requests that cannot be queued are shed with
+// 503 Service Unavailable and Retry-After:
+//
+//	pool := server.NewVerificationPool(server.WorkerPoolConfig{
+//	    Workers:      runtime.NumCPU(),
+//	    QueueSize:    256,
+//	    QueueTimeout: 50 * time.Millisecond,
+//	})
+//	defer pool.Close()
+//	middleware.SetVerificationPool(pool)
+//
 // # Security Best Practices
 //
 //   - Always use HTTPS/TLS 1.3+ in production
@@ -176,7 +791,7 @@
 //   - Log all authentication failures for security monitoring
 //   - Regularly rotate agent keys
 //   - Validate DID format before blockchain lookup
-//   - Consider implementing replay attack protection
+//   - Enable replay protection with a store shared by all replicas
 //
 // See the examples directory for complete usage examples.
 package server
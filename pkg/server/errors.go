@@ -0,0 +1,59 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// writeError writes a structured error response. Retryable errors also set
+// the Retry-After header.
+func writeError(w http.ResponseWriter, status int, body protocol.ErrorBody) {
+	h := w.Header()
+	if body.Retryable {
+		h.Set("Retry-After", strconv.Itoa(body.RetryAfter))
+	}
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeUnauthorized writes a 401 response for a failed verification
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusUnauthorized, protocol.ErrorBody{Code: protocol.ErrorCodeUnauthenticated, Message: "Unauthorized: " + message})
+}
+
+// writeForbidden writes a 403 response for a denied request
+func writeForbidden(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusForbidden, protocol.ErrorBody{Code: protocol.ErrorCodeForbidden, Message: "Forbidden: " + message})
+}
+
+// writeUnavailable writes a retryable 503 response
+func writeUnavailable(w http.ResponseWriter, message string, retryAfter int) {
+	writeError(w, http.StatusServiceUnavailable, protocol.ErrorBody{
+		Code:       protocol.ErrorCodeUnavailable,
+		Message:    "Service Unavailable: " + message,
+		Retryable:  true,
+		RetryAfter: retryAfter,
+	})
+}
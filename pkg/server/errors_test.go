@@ -0,0 +1,89 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeErrorBody(t *testing.T, rr *httptest.ResponseRecorder) protocol.ErrorBody {
+	t.Helper()
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var body protocol.ErrorBody
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	return body
+}
+
+func TestDefaultErrorHandler_StructuredBodies(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		code      string
+		retryable bool
+	}{
+		{"unauthenticated", errors.New("missing signature"), http.StatusUnauthorized, protocol.ErrorCodeUnauthenticated, false},
+		{"forbidden", &AuthorizationError{Reason: "nope"}, http.StatusForbidden, protocol.ErrorCodeForbidden, false},
+		{"resolution timeout", verifier.ErrResolutionTimeout, http.StatusServiceUnavailable, protocol.ErrorCodeUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			defaultErrorHandler(rr, httptest.NewRequest("POST", "/", nil), tt.err)
+
+			assert.Equal(t, tt.status, rr.Code)
+			body := decodeErrorBody(t, rr)
+			assert.Equal(t, tt.code, body.Code)
+			assert.Equal(t, tt.retryable, body.Retryable)
+			assert.Contains(t, body.Message, tt.err.Error())
+			if tt.retryable {
+				assert.Equal(t, 1, body.RetryAfter)
+				assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+			} else {
+				assert.Empty(t, rr.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestDIDAuthMiddleware_QuotaErrorBody(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: quotaDID})
+	middleware.SetUsageTracker(NewUsageTracker(QuotaConfig{MaxRequests: 1}))
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"message/send"}`
+	handler.ServeHTTP(httptest.NewRecorder(), signedRequest(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(body))
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	errBody := decodeErrorBody(t, rr)
+	assert.Equal(t, protocol.ErrorCodeRateLimited, errBody.Code)
+	assert.True(t, errBody.Retryable)
+	assert.Positive(t, errBody.RetryAfter)
+	assert.Equal(t, rr.Header().Get("RateLimit-Reset"), rr.Header().Get("Retry-After"))
+}
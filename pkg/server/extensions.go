@@ -0,0 +1,111 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+var (
+	// ErrExtensionRequired is returned when a required extension was not
+	// activated or its payload is missing
+	ErrExtensionRequired = errors.New("required extension not activated")
+
+	// ErrExtensionUnsupported is returned when a message uses an extension
+	// the server does not support
+	ErrExtensionUnsupported = errors.New("extension not supported")
+)
+
+// SetExtensions declares the extensions the server supports, usually
+// card.Capabilities.Extensions. Requests must activate every required
+// extension via the signed X-A2A-Extensions header or are rejected with 400
+// Bad Request; activated extensions are echoed in the response header and
+// exposed through GetExtensionsFromContext.
+func (m *DIDAuthMiddleware) SetExtensions(exts ...a2a.AgentExtension) {
+	m.update(func(c *middlewareConfig) {
+		c.extensions = exts
+	})
+}
+
+// GetExtensionsFromContext returns the URIs of the extensions activated for
+// the request
+func GetExtensionsFromContext(ctx context.Context) []string {
+	uris, _ := ctx.Value(extensionsKey).([]string)
+	return uris
+}
+
+// IsExtensionActive reports whether extension uri was activated for the
+// request
+func IsExtensionActive(ctx context.Context, uri string) bool {
+	return slices.Contains(GetExtensionsFromContext(ctx), uri)
+}
+
+// negotiateExtensions activates the supported extensions requested by the
+// signed extensions header. It writes an error response and returns ok
+// false when a required extension was not requested.
+func (m *middlewareConfig) negotiateExtensions(ctx context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool) {
+	if len(m.extensions) == 0 {
+		return ctx, true
+	}
+
+	var requested []string
+	if signedHeaders(r)(protocol.ExtensionsHeader) {
+		requested = protocol.ParseExtensions(r.Header.Get(protocol.ExtensionsHeader))
+	}
+
+	var activated []string
+	for _, ext := range m.extensions {
+		if slices.Contains(requested, ext.URI) {
+			activated = append(activated, ext.URI)
+		} else if ext.Required {
+			http.Error(w, fmt.Sprintf("Bad Request: %s: %s", ErrExtensionRequired.Error(), ext.URI), http.StatusBadRequest)
+			return ctx, false
+		}
+	}
+	if len(activated) > 0 {
+		w.Header().Set(protocol.ExtensionsHeader, protocol.FormatExtensions(activated))
+	}
+	return context.WithValue(ctx, extensionsKey, activated), true
+}
+
+// ValidateMessageExtensions checks msg against the supported extensions:
+// every extension it lists must be supported, and every required extension
+// must carry a payload
+func ValidateMessageExtensions(msg *a2a.Message, exts []a2a.AgentExtension) error {
+	for _, uri := range msg.Extensions {
+		if !slices.ContainsFunc(exts, func(ext a2a.AgentExtension) bool { return ext.URI == uri }) {
+			return fmt.Errorf("%w: %s", ErrExtensionUnsupported, uri)
+		}
+	}
+	for _, ext := range exts {
+		if !ext.Required {
+			continue
+		}
+		if _, ok := msg.Metadata[ext.URI]; !ok {
+			return fmt.Errorf("%w: %s", ErrExtensionRequired, ext.URI)
+		}
+	}
+	return nil
+}
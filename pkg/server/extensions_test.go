@@ -0,0 +1,90 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func extensionRequest(uris string, covered bool) *http.Request {
+	req := signedRequest(`{"jsonrpc":"2.0","id":1,"method":"message/send"}`)
+	components := `"@method"`
+	if covered {
+		components += ` "x-a2a-extensions"`
+	}
+	req.Header.Set("Signature-Input", `sig1=(`+components+`);keyid="did:sage:ethereum:0xabc"`)
+	if uris != "" {
+		req.Header.Set(protocol.ExtensionsHeader, uris)
+	}
+	return req
+}
+
+func TestDIDAuthMiddleware_Extensions(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	middleware.SetExtensions(
+		a2a.AgentExtension{URI: protocol.SessionKeyExtensionURI, Required: true},
+		a2a.AgentExtension{URI: protocol.DelegationExtensionURI},
+	)
+
+	var active []string
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		active = GetExtensionsFromContext(r.Context())
+		assert.True(t, IsExtensionActive(r.Context(), protocol.SessionKeyExtensionURI))
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, extensionRequest(protocol.SessionKeyExtensionURI+", https://unknown.example/ext", true))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{protocol.SessionKeyExtensionURI}, active)
+	assert.Equal(t, protocol.SessionKeyExtensionURI, rr.Header().Get(protocol.ExtensionsHeader))
+
+	// Required extension missing, or requested outside the signature
+	for _, covered := range []bool{true, false} {
+		rr = httptest.NewRecorder()
+		uris := protocol.DelegationExtensionURI
+		if !covered {
+			uris = protocol.SessionKeyExtensionURI
+		}
+		handler.ServeHTTP(rr, extensionRequest(uris, covered))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), protocol.SessionKeyExtensionURI)
+	}
+}
+
+func TestValidateMessageExtensions(t *testing.T) {
+	exts := []a2a.AgentExtension{
+		{URI: protocol.SessionKeyExtensionURI, Required: true},
+		{URI: protocol.DelegationExtensionURI},
+	}
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})
+	assert.ErrorIs(t, ValidateMessageExtensions(msg, exts), ErrExtensionRequired)
+
+	require.NoError(t, protocol.AttachExtension(msg, protocol.SessionKeyExtensionURI, map[string]string{"kem": "x25519"}, "", nil))
+	assert.NoError(t, ValidateMessageExtensions(msg, exts))
+
+	msg.Extensions = append(msg.Extensions, "https://unknown.example/ext")
+	assert.ErrorIs(t, ValidateMessageExtensions(msg, exts), ErrExtensionUnsupported)
+}
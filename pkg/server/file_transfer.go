@@ -0,0 +1,271 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultArtifactMaxSize is the largest artifact file accepted when
+// ArtifactFileConfig.MaxSize is zero
+const DefaultArtifactMaxSize = 64 << 20
+
+// ArtifactFileConfig configures NewArtifactFileHandler
+type ArtifactFileConfig struct {
+	// Store holds the files (default NewMemoryArtifactFileStore())
+	Store ArtifactFileStore
+
+	// ReadCapability, if set, must be among the caller's capabilities
+	// (GetCapabilitiesFromContext) to download files
+	ReadCapability string
+
+	// WriteCapability, if set, must be among the caller's capabilities to
+	// upload files
+	WriteCapability string
+
+	// MaxSize is the largest accepted file in bytes (default
+	// DefaultArtifactMaxSize)
+	MaxSize int64
+}
+
+// NewArtifactFileHandler creates a handler serving artifact files under
+// protocol.ArtifactFilesPath. It must be wrapped by DIDAuthMiddleware;
+// unsigned requests are rejected.
+//
+//   - PUT uploads a file, or with Content-Range one chunk of it. Chunks must
+//     be sent in order; a chunk at the wrong offset is answered with 409
+//     Conflict and the Upload-Offset to resume from. A Repr-Digest sent
+//     with the final chunk is checked against the complete file.
+//   - HEAD reports the stored size in Upload-Offset, and whether the upload
+//     is complete in Upload-Complete.
+//   - GET downloads a complete file, honoring Range and conditional
+//     requests. The Repr-Digest of the complete file is sent with every
+//     response, and doubles as the ETag.
+//   - DELETE removes a file.
+//
+// Only the DID that started an upload may continue, replace or delete it.
+func NewArtifactFileHandler(config ArtifactFileConfig) http.Handler {
+	if config.Store == nil {
+		config.Store = NewMemoryArtifactFileStore()
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = DefaultArtifactMaxSize
+	}
+	return &artifactFileHandler{config: config}
+}
+
+type artifactFileHandler struct {
+	config ArtifactFileConfig
+}
+
+// ServeHTTP implements http.Handler
+func (h *artifactFileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := strings.CutPrefix(r.URL.Path, protocol.ArtifactFilesPath)
+	if !ok || !ValidArtifactFileID(id) {
+		writeArtifactError(w, http.StatusNotFound, protocol.ErrorCodeNotFound, "unknown artifact file")
+		return
+	}
+	agentDID, ok := GetAgentDIDFromContext(r.Context())
+	if !ok {
+		writeUnauthorized(w, "artifact transfers must be signed")
+		return
+	}
+
+	capability := h.config.ReadCapability
+	if r.Method == http.MethodPut || r.Method == http.MethodDelete {
+		capability = h.config.WriteCapability
+	}
+	if capability != "" && !slices.Contains(GetCapabilitiesFromContext(r.Context()), capability) {
+		writeForbidden(w, fmt.Sprintf("capability %q required", capability))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.serveDownload(w, r, id)
+	case http.MethodPut:
+		h.serveUpload(w, r, id, agentDID)
+	case http.MethodDelete:
+		h.serveDelete(w, r, id, agentDID)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, DELETE")
+		writeArtifactError(w, http.StatusMethodNotAllowed, protocol.ErrorCodeInvalidRequest, "method not allowed")
+	}
+}
+
+func (h *artifactFileHandler) serveDownload(w http.ResponseWriter, r *http.Request, id string) {
+	content, info, err := h.config.Store.Open(r.Context(), id)
+	if err != nil {
+		h.storeError(w, err)
+		return
+	}
+	defer content.Close()
+
+	setUploadState(w, info)
+	if !info.Complete {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		writeArtifactError(w, http.StatusConflict, protocol.ErrorCodeConflict, "upload incomplete")
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", strconv.Quote(info.Digest))
+	http.ServeContent(w, r, "", info.ModTime, content)
+}
+
+func (h *artifactFileHandler) serveUpload(w http.ResponseWriter, r *http.Request, id string, owner did.AgentDID) {
+	ctx := r.Context()
+	offset, total := int64(0), int64(-1)
+	if value := r.Header.Get("Content-Range"); value != "" {
+		cr, err := protocol.ParseContentRange(value)
+		if err != nil || cr.First < 0 {
+			writeArtifactError(w, http.StatusBadRequest, protocol.ErrorCodeInvalidRequest, "invalid Content-Range")
+			return
+		}
+		offset, total = cr.First, cr.Total
+	}
+	if total > h.config.MaxSize {
+		writeArtifactError(w, http.StatusRequestEntityTooLarge, protocol.ErrorCodeInvalidRequest, "artifact file too large")
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, h.config.MaxSize-offset+1))
+	if err != nil {
+		writeArtifactError(w, http.StatusBadRequest, protocol.ErrorCodeInvalidRequest, "failed to read body")
+		return
+	}
+	if offset+int64(len(data)) > h.config.MaxSize {
+		writeArtifactError(w, http.StatusRequestEntityTooLarge, protocol.ErrorCodeInvalidRequest, "artifact file too large")
+		return
+	}
+
+	existing, err := h.config.Store.Stat(ctx, id)
+	switch {
+	case errors.Is(err, ErrArtifactFileNotFound):
+	case err != nil:
+		h.storeError(w, err)
+		return
+	case existing.Owner != owner:
+		writeForbidden(w, "artifact file belongs to another agent")
+		return
+	case offset == 0 && (existing.Complete || existing.Size > 0):
+		// A new upload from the start replaces the file
+		if err := h.config.Store.Delete(ctx, id); err != nil {
+			h.storeError(w, err)
+			return
+		}
+	}
+
+	info, err := h.config.Store.Append(ctx, id, owner, offset, total, data)
+	if err != nil {
+		if errors.Is(err, ErrArtifactOffset) || errors.Is(err, ErrArtifactComplete) {
+			if current, statErr := h.config.Store.Stat(ctx, id); statErr == nil {
+				setUploadState(w, current)
+			}
+		}
+		h.storeError(w, err)
+		return
+	}
+
+	setUploadState(w, info)
+	if !info.Complete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if want := r.Header.Get(protocol.ReprDigestHeader); want != "" {
+		if err := h.verifyDigest(r, id, want); err != nil {
+			_ = h.config.Store.Delete(ctx, id)
+			writeArtifactError(w, http.StatusBadRequest, protocol.ErrorCodeInvalidRequest, err.Error())
+			return
+		}
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *artifactFileHandler) serveDelete(w http.ResponseWriter, r *http.Request, id string, owner did.AgentDID) {
+	info, err := h.config.Store.Stat(r.Context(), id)
+	if err != nil {
+		h.storeError(w, err)
+		return
+	}
+	if info.Owner != owner {
+		writeForbidden(w, "artifact file belongs to another agent")
+		return
+	}
+	if err := h.config.Store.Delete(r.Context(), id); err != nil {
+		h.storeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyDigest checks a client's Repr-Digest against the stored file
+func (h *artifactFileHandler) verifyDigest(r *http.Request, id, want string) error {
+	content, _, err := h.config.Store.Open(r.Context(), id)
+	if err != nil {
+		return fmt.Errorf("failed to open artifact file: %w", err)
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("failed to read artifact file: %w", err)
+	}
+	if err := signer.VerifyContentDigest(want, data, nil); err != nil {
+		return fmt.Errorf("repr-digest mismatch: %w", err)
+	}
+	return nil
+}
+
+// storeError maps store errors to responses
+func (h *artifactFileHandler) storeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrArtifactFileNotFound):
+		writeArtifactError(w, http.StatusNotFound, protocol.ErrorCodeNotFound, err.Error())
+	case errors.Is(err, ErrArtifactOffset), errors.Is(err, ErrArtifactComplete):
+		writeArtifactError(w, http.StatusConflict, protocol.ErrorCodeConflict, err.Error())
+	default:
+		writeArtifactError(w, http.StatusBadRequest, protocol.ErrorCodeInvalidRequest, err.Error())
+	}
+}
+
+// setUploadState sets the upload progress and digest headers for info
+func setUploadState(w http.ResponseWriter, info *ArtifactFileInfo) {
+	h := w.Header()
+	h.Set(protocol.UploadOffsetHeader, strconv.FormatInt(info.Size, 10))
+	if info.Complete {
+		h.Set(protocol.UploadCompleteHeader, "?1")
+		h.Set(protocol.ReprDigestHeader, info.Digest)
+	} else {
+		h.Set(protocol.UploadCompleteHeader, "?0")
+	}
+}
+
+func writeArtifactError(w http.ResponseWriter, status int, code, message string) {
+	writeError(w, status, protocol.ErrorBody{Code: code, Message: message})
+}
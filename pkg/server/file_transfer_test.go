@@ -0,0 +1,157 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fileRequest sends a request to handler as if verified for caller
+func fileRequest(handler http.Handler, caller did.AgentDID, caps []string, method, id, body string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, protocol.ArtifactFilesPath+id, strings.NewReader(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	ctx := req.Context()
+	if caller != "" {
+		ctx = context.WithValue(ctx, agentDIDKey, caller)
+	}
+	if caps != nil {
+		ctx = context.WithValue(ctx, capabilitiesKey, caps)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req.WithContext(ctx))
+	return rec
+}
+
+func TestArtifactFileHandler_ChunkedUpload(t *testing.T) {
+	store := NewMemoryArtifactFileStore()
+	handler := NewArtifactFileHandler(ArtifactFileConfig{Store: store})
+	alice := did.AgentDID("did:sage:ethereum:0xalice")
+	digest, _ := signer.ComputeContentDigest(signer.DigestSHA256, []byte("hello world"))
+
+	rec := fileRequest(handler, alice, nil, "PUT", "report", "hello", map[string]string{"Content-Range": "bytes 0-4/11"})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get(protocol.UploadOffsetHeader))
+	assert.Equal(t, "?0", rec.Header().Get(protocol.UploadCompleteHeader))
+
+	rec = fileRequest(handler, alice, nil, "GET", "report", "", nil)
+	assert.Equal(t, http.StatusConflict, rec.Code, "incomplete files cannot be downloaded")
+
+	// A chunk at the wrong offset reports where to resume
+	rec = fileRequest(handler, alice, nil, "PUT", "report", "world", map[string]string{"Content-Range": "bytes 6-10/11"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get(protocol.UploadOffsetHeader))
+
+	rec = fileRequest(handler, alice, nil, "PUT", "report", " world", map[string]string{
+		"Content-Range":           "bytes 5-10/11",
+		protocol.ReprDigestHeader: digest,
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, digest, rec.Header().Get(protocol.ReprDigestHeader))
+
+	rec = fileRequest(handler, alice, nil, "GET", "report", "", map[string]string{"Range": "bytes=6-"})
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, "world", rec.Body.String())
+	assert.Equal(t, digest, rec.Header().Get(protocol.ReprDigestHeader))
+}
+
+func TestArtifactFileHandler_Access(t *testing.T) {
+	handler := NewArtifactFileHandler(ArtifactFileConfig{WriteCapability: "files:write", ReadCapability: "files:read"})
+	alice := did.AgentDID("did:sage:ethereum:0xalice")
+	bob := did.AgentDID("did:sage:ethereum:0xbob")
+
+	rec := fileRequest(handler, "", nil, "GET", "report", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = fileRequest(handler, alice, []string{"files:read"}, "PUT", "report", "data", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = fileRequest(handler, alice, []string{"files:write"}, "PUT", "report", "data", nil)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	// Only the owner may replace or delete a file
+	rec = fileRequest(handler, bob, []string{"files:write"}, "PUT", "report", "other", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = fileRequest(handler, bob, []string{"files:write"}, "DELETE", "report", "", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = fileRequest(handler, bob, []string{"files:read"}, "GET", "report", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "data", rec.Body.String())
+
+	rec = fileRequest(handler, alice, []string{"files:read"}, "GET", "../etc", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestArtifactFileHandler_DigestMismatch(t *testing.T) {
+	store := NewMemoryArtifactFileStore()
+	handler := NewArtifactFileHandler(ArtifactFileConfig{Store: store, MaxSize: 8})
+	alice := did.AgentDID("did:sage:ethereum:0xalice")
+	digest, _ := signer.ComputeContentDigest(signer.DigestSHA256, []byte("expected"))
+
+	rec := fileRequest(handler, alice, nil, "PUT", "report", "tampered", map[string]string{protocol.ReprDigestHeader: digest})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, store.Files(), "files failing the digest check are discarded")
+
+	rec = fileRequest(handler, alice, nil, "PUT", "report", "too large", nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestDirArtifactFileStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewDirArtifactFileStore(t.TempDir())
+	require.NoError(t, err)
+	alice := did.AgentDID("did:sage:ethereum:0xalice")
+
+	_, err = store.Append(ctx, "report", alice, 0, 11, []byte("hello"))
+	require.NoError(t, err)
+	_, err = store.Append(ctx, "report", alice, 4, 11, []byte("o world"))
+	assert.ErrorIs(t, err, ErrArtifactOffset)
+	info, err := store.Append(ctx, "report", alice, 5, 11, []byte(" world"))
+	require.NoError(t, err)
+	assert.True(t, info.Complete)
+	assert.Equal(t, alice, info.Owner)
+	_, err = store.Append(ctx, "report", alice, 11, -1, []byte("!"))
+	assert.ErrorIs(t, err, ErrArtifactComplete)
+
+	content, info, err := store.Open(ctx, "report")
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, "hello world", string(data))
+	want, _ := signer.ComputeContentDigest(signer.DigestSHA256, data)
+	assert.Equal(t, want, info.Digest)
+
+	require.NoError(t, store.Delete(ctx, "report"))
+	_, err = store.Stat(ctx, "report")
+	assert.ErrorIs(t, err, ErrArtifactFileNotFound)
+	_, err = store.Append(ctx, "../escape", alice, 0, -1, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// RequestFingerprint summarizes a verified (or rejected) request for
+// security tooling
+type RequestFingerprint struct {
+	// Timestamp is when verification completed
+	Timestamp time.Time
+
+	// AgentDID is the verified DID, or the claimed keyid if verification failed
+	AgentDID did.AgentDID
+
+	// Verified reports whether the signature verified
+	Verified bool
+
+	// Err is the verification error, if any
+	Err error
+
+	RemoteIP  string
+	UserAgent string
+	Method    string
+	Path      string
+
+	// BodySize is the request body length in bytes
+	BodySize int64
+
+	// Signature parameters from the Signature-Input header
+	Label      string
+	Components []string
+	KeyID      string
+	Algorithm  string
+	Nonce      string
+	Created    time.Time
+	Expires    time.Time
+}
+
+// FingerprintHook is called with the fingerprint of each request after
+// signature verification. The hook must not retain or modify r.
+type FingerprintHook func(r *http.Request, fp *RequestFingerprint)
+
+// SetFingerprintHook sets a hook that receives a fingerprint for every
+// verification attempt. Use it to feed anomaly detection or audit logs.
+func (m *DIDAuthMiddleware) SetFingerprintHook(hook FingerprintHook) {
+	m.update(func(c *middlewareConfig) {
+		c.fingerprintHook = hook
+	})
+}
+
+// notifyFingerprint builds a fingerprint and invokes the fingerprint hook if one is set
+func (m *middlewareConfig) notifyFingerprint(r *http.Request, bodySize int64, agentDID did.AgentDID, err error) {
+	if m.fingerprintHook == nil {
+		return
+	}
+	fp := NewRequestFingerprint(r, bodySize)
+	fp.Verified = err == nil && agentDID != ""
+	fp.Err = err
+	if agentDID != "" {
+		fp.AgentDID = agentDID
+	}
+	m.fingerprintHook(r, fp)
+}
+
+// NewRequestFingerprint computes a fingerprint from the request line, headers
+// and Signature-Input parameters. AgentDID is initialized from the claimed keyid.
+func NewRequestFingerprint(r *http.Request, bodySize int64) *RequestFingerprint {
+	fp := &RequestFingerprint{
+		Timestamp: time.Now().UTC(),
+		RemoteIP:  remoteIP(r),
+		UserAgent: r.UserAgent(),
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		BodySize:  bodySize,
+	}
+
+	parseSignature(r, fp)
+	fp.AgentDID = did.AgentDID(fp.KeyID)
+
+	return fp
+}
+
+// remoteIP returns the client IP from RemoteAddr without the port.
+// Forwarding headers are not trusted; place a proxy-aware middleware in
+// front if RemoteAddr is rewritten.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// parseSignature extracts the parameters of the signature verified on r,
+// parsed the way the verifier parses them
+func parseSignature(r *http.Request, fp *RequestFingerprint) {
+	params, err := signer.LookupSignatureParams(r.Header.Get("Signature-Input"), signatureLabel(r))
+	if err != nil {
+		return
+	}
+	fp.Label = params.Label
+	fp.Components = params.Components
+	fp.KeyID = params.KeyID
+	fp.Algorithm = params.Algorithm
+	fp.Nonce = params.Nonce
+	if params.Created != 0 {
+		fp.Created = time.Unix(params.Created, 0).UTC()
+	}
+	if params.Expires != 0 {
+		fp.Expires = time.Unix(params.Expires, 0).UTC()
+	}
+}
+
+// signatureLabels returns the labels of the members of a Signature-Input
+// header, or none if it does not parse
+func signatureLabels(header string) []string {
+	members, err := signer.ParseSignatureParams(header)
+	if err != nil {
+		return nil
+	}
+	labels := make([]string, len(members))
+	for i, m := range members {
+		labels[i] = m.Label
+	}
+	return labels
+}
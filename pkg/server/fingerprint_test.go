@@ -0,0 +1,171 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRequestFingerprint(t *testing.T) {
+	req := httptest.NewRequest("POST", "/rpc?x=1", bytes.NewReader([]byte("hello")))
+	req.RemoteAddr = "10.0.0.7:51234"
+	req.Header.Set("User-Agent", "agent/1.0")
+	req.Header.Set("Signature-Input",
+		`sig1=("@method" "@path" "content-digest");keyid="did:sage:ethereum:0xabc";alg="es256k";created=1700000000;expires=1700000300;nonce="n-1", sig2=("@method");keyid="did:other"`)
+
+	fp := NewRequestFingerprint(req, 5)
+
+	assert.Equal(t, "10.0.0.7", fp.RemoteIP)
+	assert.Equal(t, "agent/1.0", fp.UserAgent)
+	assert.Equal(t, "POST", fp.Method)
+	assert.Equal(t, "/rpc", fp.Path)
+	assert.Equal(t, int64(5), fp.BodySize)
+	assert.Equal(t, "sig1", fp.Label)
+	assert.Equal(t, []string{"@method", "@path", "content-digest"}, fp.Components)
+	assert.Equal(t, "did:sage:ethereum:0xabc", fp.KeyID)
+	assert.Equal(t, did.AgentDID("did:sage:ethereum:0xabc"), fp.AgentDID)
+	assert.Equal(t, "es256k", fp.Algorithm)
+	assert.Equal(t, "n-1", fp.Nonce)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), fp.Created)
+	assert.Equal(t, time.Unix(1700000300, 0).UTC(), fp.Expires)
+}
+
+func TestNewRequestFingerprint_StructuredFields(t *testing.T) {
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	req.Header.Set("Signature-Input",
+		`sig1=("@method" "@query-param";name="id");tag="a;nonce=\"x\"";nonce="n\"1";keyid="did:sage:ethereum:0xabc"`)
+
+	fp := NewRequestFingerprint(req, 0)
+
+	assert.Equal(t, []string{"@method", `@query-param;name="id"`}, fp.Components)
+	assert.Equal(t, `n"1`, fp.Nonce)
+	assert.Equal(t, "did:sage:ethereum:0xabc", fp.KeyID)
+
+	req.Header.Set("Signature-Input", `sig1=("@method";keyid="did:sage:ethereum:0xabc"`)
+	fp = NewRequestFingerprint(req, 0)
+	assert.Empty(t, fp.Label)
+	assert.Empty(t, fp.KeyID)
+}
+
+func TestDIDAuthMiddleware_FingerprintHook(t *testing.T) {
+	tests := []struct {
+		name     string
+		succeed  bool
+		verified bool
+	}{
+		{"success", true, true},
+		{"failure", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{
+				shouldSucceed: tt.succeed,
+				extractedDID:  "did:sage:ethereum:0xtest",
+			})
+
+			var got *RequestFingerprint
+			middleware.SetFingerprintHook(func(r *http.Request, fp *RequestFingerprint) { got = fp })
+
+			handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			req := httptest.NewRequest("POST", "/rpc", bytes.NewReader([]byte(`{"a":1}`)))
+			req.Header.Set("Signature", "sig1=:abc:")
+			req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xclaimed"`)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, got)
+			assert.Equal(t, tt.verified, got.Verified)
+			assert.Equal(t, int64(7), got.BodySize)
+			if tt.verified {
+				assert.Equal(t, did.AgentDID("did:sage:ethereum:0xtest"), got.AgentDID)
+				assert.NoError(t, got.Err)
+			} else {
+				assert.Equal(t, did.AgentDID("did:sage:ethereum:0xclaimed"), got.AgentDID)
+				assert.Error(t, got.Err)
+			}
+		})
+	}
+}
+
+func TestAnomalyDetector_ManyIPs(t *testing.T) {
+	var reported []Anomaly
+	detector := NewAnomalyDetector(AnomalyConfig{
+		Window:       time.Minute,
+		MaxIPsPerDID: 2,
+		OnAnomaly:    func(a Anomaly) { reported = append(reported, a) },
+	})
+	now := time.Now()
+	detector.now = func() time.Time { return now }
+
+	observe := func(ip string) []Anomaly {
+		return detector.Observe(&RequestFingerprint{AgentDID: "did:a", RemoteIP: ip, Verified: true, Timestamp: now})
+	}
+
+	assert.Empty(t, observe("1.1.1.1"))
+	assert.Empty(t, observe("2.2.2.2"))
+	assert.Empty(t, observe("1.1.1.1"))
+
+	anomalies := observe("3.3.3.3")
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, AnomalyManyIPs, anomalies[0].Kind)
+	assert.Equal(t, did.AgentDID("did:a"), anomalies[0].AgentDID)
+	assert.Len(t, reported, 1)
+
+	// Old IPs fall out of the window
+	now = now.Add(2 * time.Minute)
+	assert.Empty(t, observe("4.4.4.4"))
+}
+
+func TestAnomalyDetector_FailureBurst(t *testing.T) {
+	detector := NewAnomalyDetector(AnomalyConfig{Window: time.Minute, MaxFailuresPerDID: 2})
+
+	fp := &RequestFingerprint{AgentDID: "did:a", Timestamp: time.Now()}
+	assert.Empty(t, detector.Observe(fp))
+	assert.Empty(t, detector.Observe(fp))
+
+	anomalies := detector.Observe(fp)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, AnomalyFailureBurst, anomalies[0].Kind)
+}
+
+func TestAnomalyDetector_UnusualHour(t *testing.T) {
+	detector := NewAnomalyDetector(AnomalyConfig{ActiveHoursStart: 22, ActiveHoursEnd: 6})
+
+	at := func(hour int) *RequestFingerprint {
+		return &RequestFingerprint{
+			AgentDID:  "did:a",
+			Verified:  true,
+			Timestamp: time.Date(2025, 1, 1, hour, 0, 0, 0, time.UTC),
+		}
+	}
+
+	assert.Empty(t, detector.Observe(at(23)))
+	assert.Empty(t, detector.Observe(at(3)))
+
+	anomalies := detector.Observe(at(12))
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, AnomalyUnusualHour, anomalies[0].Kind)
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultMaxGrantTTL is the longest grant validity accepted when
+// GrantConfig.MaxTTL is zero
+const DefaultMaxGrantTTL = time.Hour
+
+// GrantConfig configures which capability grants the middleware accepts
+type GrantConfig struct {
+	// TrustedIssuers may grant any capability
+	TrustedIssuers []did.AgentDID
+
+	// Delegation also accepts grants from issuers that hold the granted
+	// capability themselves, according to the CapabilityResolver
+	Delegation bool
+
+	// MaxTTL rejects grants issued for longer (default DefaultMaxGrantTTL)
+	MaxTTL time.Duration
+}
+
+// SetCapabilityGrants enables signed capability grants. Grants are read
+// from the protocol.GrantHeader when it is covered by the request
+// signature; each one must name the verified caller as subject, be
+// unexpired and be signed by an acceptable issuer's on-chain key. Granted
+// capabilities are added to AuthzInput.Capabilities and to
+// GetCapabilitiesFromContext. A request carrying an unacceptable grant is
+// denied with 403 Forbidden. Pass nil to disable grants.
+func (m *DIDAuthMiddleware) SetCapabilityGrants(cfg *GrantConfig) {
+	m.update(func(c *middlewareConfig) {
+		c.grants = cfg
+	})
+}
+
+// GetGrantsFromContext returns the verified capability grants of the request
+func GetGrantsFromContext(ctx context.Context) []*protocol.CapabilityGrant {
+	grants, _ := ctx.Value(grantsKey).([]*protocol.CapabilityGrant)
+	return grants
+}
+
+// GetCapabilitiesFromContext returns the caller's effective capabilities:
+// those registered for its DID plus those granted for this request. It is
+// empty unless a CapabilityResolver or capability grants are configured.
+func GetCapabilitiesFromContext(ctx context.Context) []string {
+	caps, _ := ctx.Value(capabilitiesKey).([]string)
+	return caps
+}
+
+// applyCapabilities resolves the caller's registered capabilities, verifies
+// its grants and stores the result in the context. Failures are returned
+// as *AuthorizationError.
+func (m *middlewareConfig) applyCapabilities(ctx context.Context, r *http.Request, agentDID did.AgentDID) (context.Context, error) {
+	var registered []string
+	if m.capabilityResolver != nil {
+		caps, err := m.capabilityResolver(ctx, agentDID)
+		if err != nil {
+			return ctx, &AuthorizationError{Err: err}
+		}
+		registered = caps
+	}
+
+	var grants []*protocol.CapabilityGrant
+	if m.grants != nil && signedHeaders(r)(protocol.GrantHeader) {
+		parsed, err := protocol.ParseGrants(r.Header.Get(protocol.GrantHeader))
+		if err != nil {
+			return ctx, &AuthorizationError{Err: err}
+		}
+		for _, g := range parsed {
+			if err := m.checkGrant(ctx, g, agentDID); err != nil {
+				return ctx, &AuthorizationError{Err: fmt.Errorf("capability grant %q from %s: %w", g.Capability, g.Issuer, err)}
+			}
+		}
+		grants = parsed
+	}
+
+	if m.capabilityResolver == nil && len(grants) == 0 {
+		return ctx, nil
+	}
+	caps := slices.Clone(registered)
+	for _, g := range grants {
+		if !slices.Contains(caps, g.Capability) {
+			caps = append(caps, g.Capability)
+		}
+	}
+	sort.Strings(caps)
+	ctx = context.WithValue(ctx, capabilitiesKey, caps)
+	if len(grants) > 0 {
+		ctx = context.WithValue(ctx, grantsKey, grants)
+	}
+	return ctx, nil
+}
+
+// checkGrant verifies one grant presented by agentDID
+func (m *middlewareConfig) checkGrant(ctx context.Context, g *protocol.CapabilityGrant, agentDID did.AgentDID) error {
+	maxTTL := m.grants.MaxTTL
+	if maxTTL == 0 {
+		maxTTL = DefaultMaxGrantTTL
+	}
+	if g.TTL() > maxTTL {
+		return fmt.Errorf("%w: valid for %s, at most %s accepted", protocol.ErrGrantInvalid, g.TTL(), maxTTL)
+	}
+	// The issued-at time is chosen by the issuer, so bound the remaining
+	// lifetime as well
+	if remaining := time.Until(g.ExpiresAt()); remaining > maxTTL+protocol.GrantClockSkew {
+		return fmt.Errorf("%w: valid for another %s, at most %s accepted", protocol.ErrGrantInvalid, remaining.Round(time.Second), maxTTL)
+	}
+
+	trusted := slices.Contains(m.grants.TrustedIssuers, g.Issuer)
+	if !trusted && m.grants.Delegation && m.capabilityResolver != nil {
+		caps, err := m.capabilityResolver(ctx, g.Issuer)
+		if err != nil {
+			return fmt.Errorf("failed to resolve issuer capabilities: %w", err)
+		}
+		trusted = slices.Contains(caps, g.Capability)
+	}
+	if !trusted {
+		return fmt.Errorf("issuer may not grant this capability")
+	}
+
+	issuerKey, err := m.verifier.ResolvePublicKey(ctx, g.Issuer, nil)
+	if err != nil {
+		return fmt.Errorf("failed to resolve issuer key: %w", err)
+	}
+	return g.Verify(issuerKey, agentDID, time.Now())
+}
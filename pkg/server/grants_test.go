@@ -0,0 +1,166 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keyResolvingVerifier accepts every signature and resolves issuer keys
+// from a map
+type keyResolvingVerifier struct {
+	mockDIDVerifier
+	keys map[did.AgentDID]stdcrypto.PublicKey
+}
+
+func (v *keyResolvingVerifier) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (stdcrypto.PublicKey, error) {
+	return v.keys[agentDID], nil
+}
+
+func TestDIDAuthMiddleware_CapabilityGrants(t *testing.T) {
+	const (
+		caller  = did.AgentDID("did:sage:ethereum:0xabc")
+		admin   = did.AgentDID("did:sage:ethereum:0xadmin")
+		peer    = did.AgentDID("did:sage:ethereum:0xpeer")
+		unknown = did.AgentDID("did:sage:ethereum:0xunknown")
+	)
+	adminKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	peerKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	unknownKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(&keyResolvingVerifier{
+		mockDIDVerifier: mockDIDVerifier{shouldSucceed: true, extractedDID: caller},
+		keys: map[did.AgentDID]stdcrypto.PublicKey{
+			admin:   adminKey.PublicKey(),
+			peer:    peerKey.PublicKey(),
+			unknown: unknownKey.PublicKey(),
+		},
+	})
+	middleware.SetCapabilityResolver(func(ctx context.Context, agentDID did.AgentDID) ([]string, error) {
+		if agentDID == peer {
+			return []string{"billing"}, nil
+		}
+		return []string{"chat"}, nil
+	})
+	middleware.SetCapabilityGrants(&GrantConfig{TrustedIssuers: []did.AgentDID{admin}, Delegation: true})
+
+	var gotCaps []string
+	var gotInput AuthzInput
+	middleware.SetAuthorizer(AuthorizerFunc(func(ctx context.Context, input AuthzInput) (Decision, error) {
+		gotInput = input
+		return Decision{Allow: true}, nil
+	}))
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCaps = GetCapabilitiesFromContext(r.Context())
+	}))
+
+	serve := func(covered bool, grants ...*protocol.CapabilityGrant) int {
+		req := signedRequest(`{"jsonrpc":"2.0","id":1,"method":"message/send"}`)
+		if covered {
+			req.Header.Set("Signature-Input", `sig1=("@method" "a2a-capability-grant");keyid="`+string(caller)+`"`)
+		}
+		req.Header.Set(protocol.GrantHeader, protocol.FormatGrants(grants))
+		rec := httptest.NewRecorder()
+		gotCaps = nil
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	grant := func(issuer did.AgentDID, kp interface {
+		PublicKey() stdcrypto.PublicKey
+	}, capability string, ttl time.Duration) *protocol.CapabilityGrant {
+		t.Helper()
+		var g *protocol.CapabilityGrant
+		var err error
+		switch issuer {
+		case admin:
+			g, err = protocol.IssueGrant(issuer, adminKey, caller, capability, ttl)
+		case peer:
+			g, err = protocol.IssueGrant(issuer, peerKey, caller, capability, ttl)
+		default:
+			g, err = protocol.IssueGrant(issuer, unknownKey, caller, capability, ttl)
+		}
+		require.NoError(t, err)
+		return g
+	}
+
+	// A trusted issuer and a delegating holder both extend the capabilities
+	assert.Equal(t, http.StatusOK, serve(true, grant(admin, adminKey, "admin", time.Minute), grant(peer, peerKey, "billing", time.Minute)))
+	assert.Equal(t, []string{"admin", "billing", "chat"}, gotCaps)
+	assert.Equal(t, []string{"admin", "billing"}, gotInput.GrantedCapabilities)
+	assert.Equal(t, gotCaps, gotInput.Capabilities)
+
+	// Grants not covered by the signature are ignored
+	assert.Equal(t, http.StatusOK, serve(false, grant(admin, adminKey, "admin", time.Minute)))
+	assert.Equal(t, []string{"chat"}, gotCaps)
+
+	// Delegation is limited to capabilities the issuer holds
+	assert.Equal(t, http.StatusForbidden, serve(true, grant(peer, peerKey, "admin", time.Minute)))
+	assert.Equal(t, http.StatusForbidden, serve(true, grant(unknown, unknownKey, "admin", time.Minute)))
+
+	// Overlong grants are rejected
+	assert.Equal(t, http.StatusForbidden, serve(true, grant(admin, adminKey, "admin", 2*time.Hour)))
+
+	// Grants dated in the future cannot outlive MaxTTL
+	future := grant(admin, adminKey, "admin", time.Minute)
+	future.IssuedAt = time.Now().Add(2 * time.Hour).Unix()
+	future.Expires = future.IssuedAt + 60
+	require.NoError(t, future.Sign(adminKey))
+	assert.Equal(t, http.StatusForbidden, serve(true, future))
+
+	// Tampered grants fail signature verification
+	tampered := grant(admin, adminKey, "admin", time.Minute)
+	tampered.Capability = "root"
+	assert.Equal(t, http.StatusForbidden, serve(true, tampered))
+}
+
+func TestDIDAuthMiddleware_CapabilityGrantSubject(t *testing.T) {
+	adminKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	middleware := NewDIDAuthMiddlewareWithVerifier(&keyResolvingVerifier{
+		mockDIDVerifier: mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"},
+		keys:            map[did.AgentDID]stdcrypto.PublicKey{"did:sage:ethereum:0xadmin": adminKey.PublicKey()},
+	})
+	middleware.SetCapabilityGrants(&GrantConfig{TrustedIssuers: []did.AgentDID{"did:sage:ethereum:0xadmin"}})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// A grant issued to another agent cannot be replayed by the caller
+	g, err := protocol.IssueGrant("did:sage:ethereum:0xadmin", adminKey, "did:sage:ethereum:0xother", "admin", time.Minute)
+	require.NoError(t, err)
+	req := signedRequest(`{}`)
+	req.Header.Set("Signature-Input", `sig1=("@method" "a2a-capability-grant");keyid="did:sage:ethereum:0xabc"`)
+	req.Header.Set(protocol.GrantHeader, protocol.EncodeGrant(g))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "another agent"), rec.Body.String())
+}
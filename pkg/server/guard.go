@@ -0,0 +1,162 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+// ErrResponseWithheld is returned by writes a ResponseGuard refused
+var ErrResponseWithheld = errors.New("response withheld: request not authenticated")
+
+// ResponseGuard refuses to send successful (2xx) responses to requests
+// that carry no verified DID, unless the handler called AllowAnonymous.
+// It catches handler bugs where requests let through by optional
+// verification receive privileged data: such responses are replaced by a
+// 401 and reported to the block hook.
+//
+// It must be placed inside DIDAuthMiddleware, which supplies the caller
+// DID:
+//
+//	handler := server.Chain(auth.Wrap, server.NewResponseGuard().Wrap)(rpcHandler)
+//
+// Error and redirect responses always pass.
+type ResponseGuard struct {
+	blocked   atomic.Uint64
+	blockHook func(r *http.Request, status int)
+}
+
+// NewResponseGuard creates a ResponseGuard
+func NewResponseGuard() *ResponseGuard {
+	return &ResponseGuard{}
+}
+
+// SetBlockHook sets a function called with the request and the status
+// the handler tried to send whenever a response is withheld, to log or
+// alert on the handler bug
+func (g *ResponseGuard) SetBlockHook(hook func(r *http.Request, status int)) {
+	g.blockHook = hook
+}
+
+// Blocked returns the number of responses withheld so far
+func (g *ResponseGuard) Blocked() uint64 {
+	return g.blocked.Load()
+}
+
+type anonymousKey struct{}
+
+// AllowAnonymous marks the response to the request with context ctx as
+// safe to send to unauthenticated callers. Handlers call it for public
+// data before writing the response; it has no effect outside a
+// ResponseGuard.
+func AllowAnonymous(ctx context.Context) {
+	if allowed, ok := ctx.Value(anonymousKey{}).(*atomic.Bool); ok {
+		allowed.Store(true)
+	}
+}
+
+// Wrap wraps an HTTP handler with the guard
+func (g *ResponseGuard) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetAgentDIDFromContext(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &guardResponseWriter{ResponseWriter: w, guard: g}
+		ctx := context.WithValue(r.Context(), anonymousKey{}, &gw.allowed)
+		gw.request = r.WithContext(ctx)
+		next.ServeHTTP(gw, gw.request)
+	})
+}
+
+// guardResponseWriter decides at the status line whether an
+// unauthenticated response may be sent
+type guardResponseWriter struct {
+	http.ResponseWriter
+	guard   *ResponseGuard
+	request *http.Request
+	allowed atomic.Bool
+
+	decided  bool
+	withheld bool
+}
+
+func (w *guardResponseWriter) WriteHeader(code int) {
+	if w.decided {
+		return
+	}
+	// Informational responses such as 103 Early Hints precede the final
+	// status, which is the one the guard decides on
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.decided = true
+	if code >= 200 && code < 300 && !w.allowed.Load() {
+		w.withhold(code)
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *guardResponseWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.withheld {
+		return 0, ErrResponseWithheld
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher so SSE handlers keep working
+func (w *guardResponseWriter) Flush() {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.withheld {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *guardResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// withhold replaces the response with a 401
+func (w *guardResponseWriter) withhold(status int) {
+	w.withheld = true
+	w.guard.blocked.Add(1)
+	if w.guard.blockHook != nil {
+		w.guard.blockHook(w.request, status)
+	}
+
+	h := w.ResponseWriter.Header()
+	for _, name := range []string{"Content-Length", "Content-Encoding", "ETag", "Last-Modified", "Cache-Control"} {
+		h.Del(name)
+	}
+	writeUnauthorized(w.ResponseWriter, "signature required")
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseGuard(t *testing.T) {
+	const secret = `{"balance":100}`
+
+	serve := func(signed bool, handler http.HandlerFunc) (*httptest.ResponseRecorder, *ResponseGuard) {
+		middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+		middleware.SetOptional(true)
+		guard := NewResponseGuard()
+		req := signedRequest(`{}`)
+		if !signed {
+			req.Header.Del("Signature")
+		}
+		rec := httptest.NewRecorder()
+		Chain(middleware.Wrap, guard.Wrap)(handler).ServeHTTP(rec, req)
+		return rec, guard
+	}
+	privileged := func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(secret))
+		assert.NoError(t, err)
+	}
+
+	t.Run("verified caller", func(t *testing.T) {
+		rec, guard := serve(true, privileged)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, secret, rec.Body.String())
+		assert.Zero(t, guard.Blocked())
+	})
+
+	t.Run("anonymous caller", func(t *testing.T) {
+		var hooked int
+		middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true})
+		middleware.SetOptional(true)
+		guard := NewResponseGuard()
+		guard.SetBlockHook(func(r *http.Request, status int) { hooked = status })
+
+		req := signedRequest(`{}`)
+		req.Header.Del("Signature")
+		rec := httptest.NewRecorder()
+		var writeErr error
+		Chain(middleware.Wrap, guard.Wrap)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", "15")
+			_, writeErr = w.Write([]byte(secret))
+		})).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "balance")
+		assert.ErrorIs(t, writeErr, ErrResponseWithheld)
+		assert.Equal(t, http.StatusOK, hooked)
+		assert.Equal(t, uint64(1), guard.Blocked())
+	})
+
+	t.Run("anonymous allowed", func(t *testing.T) {
+		rec, guard := serve(false, func(w http.ResponseWriter, r *http.Request) {
+			AllowAnonymous(r.Context())
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"status":"ok"}`, rec.Body.String())
+		assert.Zero(t, guard.Blocked())
+	})
+
+	t.Run("early hints pass", func(t *testing.T) {
+		middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true})
+		middleware.SetOptional(true)
+		guard := NewResponseGuard()
+		srv := httptest.NewServer(Chain(middleware.Wrap, guard.Wrap)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Link", "</style.css>; rel=preload")
+			w.WriteHeader(http.StatusEarlyHints)
+			_, _ = w.Write([]byte(secret))
+		})))
+		defer srv.Close()
+
+		var informational []int
+		trace := &httptrace.ClientTrace{Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			informational = append(informational, code)
+			return nil
+		}}
+		req, err := http.NewRequestWithContext(httptrace.WithClientTrace(context.Background(), trace), http.MethodPost, srv.URL+"/rpc", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		// The hint is forwarded and the final 200 is still withheld
+		assert.Equal(t, []int{http.StatusEarlyHints}, informational)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.NotContains(t, string(body), "balance")
+		assert.Equal(t, uint64(1), guard.Blocked())
+	})
+
+	t.Run("anonymous error passes", func(t *testing.T) {
+		rec, _ := serve(false, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "not found", http.StatusNotFound)
+		})
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"crypto/tls"
+	"net/http"
+	"slices"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// EarlyDataHeader is set to "1" by proxies forwarding a request received
+// in TLS 1.3 or QUIC 0-RTT early data (RFC 8470)
+const EarlyDataHeader = "Early-Data"
+
+// EarlyDataConfig configures which signed requests the middleware accepts
+// in 0-RTT early data. Anyone who captured early data can replay it to the
+// server before the signature expires, so by default every signed request
+// received in early data is refused with 425 Too Early; clients send it
+// again once the handshake completed.
+type EarlyDataConfig struct {
+	// Methods are the JSON-RPC methods accepted in early data. They must
+	// be safe to repeat, such as tasks/get.
+	Methods []string
+
+	// ReplayProtected accepts every method in early data when replay
+	// protection is enabled (see SetReplayProtection), which rejects a
+	// second use of the same signature
+	ReplayProtected bool
+}
+
+// SetEarlyData configures which signed requests are accepted in 0-RTT
+// early data, as served by an HTTP/3 server allowing 0-RTT or reported by
+// a proxy in the Early-Data header. Pass nil to refuse them all.
+func (m *DIDAuthMiddleware) SetEarlyData(cfg *EarlyDataConfig) {
+	m.update(func(c *middlewareConfig) {
+		c.earlyData = cfg
+	})
+}
+
+// isEarlyData reports whether r was received in 0-RTT early data. HTTP/3
+// servers expose such requests with a TLS state whose handshake is not
+// complete yet.
+func isEarlyData(r *http.Request) bool {
+	if r.Header.Get(EarlyDataHeader) == "1" {
+		return true
+	}
+	return r.ProtoMajor == 3 && r.TLS != nil && !r.TLS.HandshakeComplete
+}
+
+// acceptEarlyData reports whether r, calling method, may be processed. It
+// writes a 425 Too Early response if not.
+func (m *middlewareConfig) acceptEarlyData(w http.ResponseWriter, r *http.Request, method string) bool {
+	if !isEarlyData(r) {
+		return true
+	}
+	if cfg := m.earlyData; cfg != nil {
+		if (cfg.ReplayProtected && m.replay != nil) || (method != "" && slices.Contains(cfg.Methods, method)) {
+			return true
+		}
+	}
+	writeError(w, http.StatusTooEarly, protocol.ErrorBody{
+		Code:      protocol.ErrorCodeTooEarly,
+		Message:   "Too Early: signed request received in early data",
+		Retryable: true,
+	})
+	return false
+}
+
+// signedTarget returns r with the scheme and authority of its URL filled
+// in for HTTP/3, whose requests carry them in the :scheme and :authority
+// pseudo-headers only, so @target-uri and @authority are reconstructed as
+// the client signed them. Other requests are returned unchanged.
+func signedTarget(r *http.Request) *http.Request {
+	if r.ProtoMajor != 3 || (r.URL.Scheme != "" && r.URL.Host != "") {
+		return r
+	}
+	target := *r.URL
+	if target.Scheme == "" {
+		target.Scheme = "https"
+	}
+	if target.Host == "" {
+		target.Host = r.Host
+	}
+	r2 := *r
+	r2.URL = &target
+	return &r2
+}
+
+// HTTP3Config configures the server returned by NewHTTP3Server
+type HTTP3Config struct {
+	// Allow0RTT accepts requests in 0-RTT early data. Signed ones are still
+	// refused with 425 Too Early unless SetEarlyData allows them.
+	Allow0RTT bool
+
+	// QUICConfig tunes the QUIC connections; its Allow0RTT is replaced
+	QUICConfig *quic.Config
+}
+
+// NewHTTP3Server returns a quic-go HTTP/3 server serving handler on the
+// UDP address addr, with tlsConfig. Unlike quic-go's default, it refuses
+// 0-RTT early data unless cfg.Allow0RTT is set. cfg may be nil.
+func NewHTTP3Server(addr string, handler http.Handler, tlsConfig *tls.Config, cfg *HTTP3Config) *http3.Server {
+	if cfg == nil {
+		cfg = &HTTP3Config{}
+	}
+	quicConfig := &quic.Config{}
+	if cfg.QUICConfig != nil {
+		quicConfig = cfg.QUICConfig.Clone()
+	}
+	quicConfig.Allow0RTT = cfg.Allow0RTT
+	return &http3.Server{
+		Addr:       addr,
+		Handler:    handler,
+		TLSConfig:  tlsConfig,
+		QUICConfig: quicConfig,
+	}
+}
+
+// AdvertiseHTTP3 returns a handler announcing srv in the Alt-Svc header of
+// the HTTP/1.1 and HTTP/2 responses of next, so clients switch to HTTP/3
+func AdvertiseHTTP3(srv *http3.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor < 3 {
+			// Fails only until srv listens
+			_ = srv.SetQUICHeaders(w.Header())
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDAuthMiddleware_EarlyData(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	serve := func(method string, early bool) *httptest.ResponseRecorder {
+		req := signedRequest(`{"jsonrpc":"2.0","id":1,"method":"` + method + `"}`)
+		if early {
+			req.Header.Set(EarlyDataHeader, "1")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Refused by default
+	rec := serve("tasks/get", true)
+	require.Equal(t, http.StatusTooEarly, rec.Code)
+	var body protocol.ErrorBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, protocol.ErrorCodeTooEarly, body.Code)
+	assert.True(t, body.Retryable)
+	assert.Equal(t, http.StatusOK, serve("message/send", false).Code)
+
+	// Listed methods are accepted
+	middleware.SetEarlyData(&EarlyDataConfig{Methods: []string{"tasks/get"}})
+	assert.Equal(t, http.StatusOK, serve("tasks/get", true).Code)
+	assert.Equal(t, http.StatusTooEarly, serve("message/send", true).Code)
+
+	// Replay protection makes every method safe
+	middleware.SetEarlyData(&EarlyDataConfig{ReplayProtected: true})
+	assert.Equal(t, http.StatusTooEarly, serve("message/send", true).Code)
+	middleware.SetReplayProtection(&ReplayConfig{})
+	assert.Equal(t, http.StatusOK, serve("message/send", true).Code)
+}
+
+func TestIsEarlyData_HTTP3(t *testing.T) {
+	req := httptest.NewRequest("POST", "https://agent.example.com/rpc", nil)
+	assert.False(t, isEarlyData(req))
+
+	req.ProtoMajor = 3
+	req.TLS = &tls.ConnectionState{HandshakeComplete: false}
+	assert.True(t, isEarlyData(req))
+	req.TLS.HandshakeComplete = true
+	assert.False(t, isEarlyData(req))
+
+	// Only HTTP/3 servers serve requests before the handshake completed
+	req.ProtoMajor = 1
+	req.TLS.HandshakeComplete = false
+	assert.False(t, isEarlyData(req))
+}
+
+func TestSignedTarget(t *testing.T) {
+	req := httptest.NewRequest("POST", "/rpc?x=1", nil)
+	req.Host = "agent.example.com:8443"
+	assert.Same(t, req, signedTarget(req))
+
+	req.ProtoMajor = 3
+	target := signedTarget(req)
+	require.NotSame(t, req, target)
+	assert.Equal(t, "https://agent.example.com:8443/rpc?x=1", target.URL.String())
+	assert.Equal(t, "/rpc?x=1", req.URL.String(), "the request is not modified")
+}
+
+func TestNewHTTP3Server(t *testing.T) {
+	// Borrow the test certificate of httptest, valid for 127.0.0.1
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+	clientTLS := tlsServer.Client().Transport.(*http.Transport).TLSClientConfig
+
+	var (
+		proto    string
+		agentDID string
+	)
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	srv := NewHTTP3Server("127.0.0.1:0", middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.Proto
+		did, _ := GetAgentDIDFromContext(r.Context())
+		agentDID = string(did)
+	})), tlsServer.TLS, &HTTP3Config{QUICConfig: &quic.Config{Allow0RTT: true}})
+	assert.False(t, srv.QUICConfig.Allow0RTT, "0-RTT is refused unless allowed")
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(conn)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http3.Transport{TLSClientConfig: clientTLS}}
+	defer client.Transport.(*http3.Transport).Close()
+	req := signedRequest(`{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`)
+	req.URL.Scheme, req.URL.Host = "https", conn.LocalAddr().String()
+	req.Host, req.RequestURI = "", ""
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HTTP/3.0", proto)
+	assert.Equal(t, "did:sage:ethereum:0xabc", agentDID)
+
+	// HTTP/1.1 responses announce the HTTP/3 server
+	rec := httptest.NewRecorder()
+	AdvertiseHTTP3(srv, http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Alt-Svc"), "h3=\":"), rec.Header().Get("Alt-Svc"))
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentCardHandler(t *testing.T) {
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	id, err := identity.NewIdentity("did:sage:ethereum:0xabc", kp)
+	require.NoError(t, err)
+	handler := NewAgentCardHandler(id)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/.well-known/agent-card.json", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	id.Card = &protocol.SignedAgentCard{Card: &protocol.AgentCard{DID: "did:sage:ethereum:0xabc", Name: "agent"}, Signature: "sig"}
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/.well-known/agent-card.json", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got protocol.SignedAgentCard
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, "agent", got.Card.Name)
+	assert.Equal(t, "sig", got.Signature)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/.well-known/agent-card.json", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"maps"
+
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// Request metadata keys set by IdentityInterceptor. Executors written
+// against plain a2a-go read them from a2asrv.RequestContext.Metadata.
+const (
+	// CallerDIDKey holds the verified caller DID as a string
+	CallerDIDKey = "sage.caller.did"
+
+	// CallerCapabilitiesKey holds the caller's capabilities as a []string
+	// (see GetCapabilitiesFromContext)
+	CallerCapabilitiesKey = "sage.caller.capabilities"
+
+	// CallerExtensionsKey holds the URIs of the activated extensions as a
+	// []string
+	CallerExtensionsKey = "sage.caller.extensions"
+
+	// CallerSPIFFEIDKey holds the SPIFFE ID of the caller's SVID, if it
+	// presented one
+	CallerSPIFFEIDKey = "sage.caller.spiffe"
+
+	// CallerTaskContextKey holds the *protocol.TaskContext the request
+	// continues, if it presented one (see SetTaskContinuation)
+	CallerTaskContextKey = "sage.caller.taskcontext"
+)
+
+// callerKeys are the metadata keys owned by IdentityInterceptor
+var callerKeys = []string{CallerDIDKey, CallerCapabilitiesKey, CallerExtensionsKey, CallerSPIFFEIDKey, CallerTaskContextKey}
+
+// IdentityInterceptor is an a2asrv.RequestContextInterceptor copying what
+// DIDAuthMiddleware verified into the request metadata under the Caller*
+// keys, so executors can read the caller's identity without this
+// package's context helpers:
+//
+//	handler := a2asrv.NewHandler(executor,
+//	    a2asrv.WithRequestContextInterceptor(server.IdentityInterceptor{}))
+//
+// Keys the client put into the request metadata itself are removed, so an
+// executor can trust them; unsigned requests carry none.
+type IdentityInterceptor struct{}
+
+// Intercept implements a2asrv.RequestContextInterceptor
+func (IdentityInterceptor) Intercept(ctx context.Context, reqCtx *a2asrv.RequestContext) (context.Context, error) {
+	// The metadata may be shared with the request message
+	metadata := maps.Clone(reqCtx.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+	for _, key := range callerKeys {
+		delete(metadata, key)
+	}
+
+	if agentDID, ok := GetAgentDIDFromContext(ctx); ok {
+		metadata[CallerDIDKey] = string(agentDID)
+	}
+	if caps := GetCapabilitiesFromContext(ctx); len(caps) > 0 {
+		metadata[CallerCapabilitiesKey] = caps
+	}
+	if exts := GetExtensionsFromContext(ctx); len(exts) > 0 {
+		metadata[CallerExtensionsKey] = exts
+	}
+	if spiffeID, ok := GetSPIFFEIDFromContext(ctx); ok {
+		metadata[CallerSPIFFEIDKey] = spiffeID
+	}
+	if tc, ok := GetTaskContextFromContext(ctx); ok {
+		metadata[CallerTaskContextKey] = tc
+	}
+	reqCtx.Metadata = metadata
+	return ctx, nil
+}
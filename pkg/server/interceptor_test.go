@@ -0,0 +1,72 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityInterceptor(t *testing.T) {
+	ctx := context.WithValue(context.Background(), agentDIDKey, did.AgentDID("did:sage:ethereum:0xabc"))
+	ctx = context.WithValue(ctx, capabilitiesKey, []string{"billing"})
+	ctx = context.WithValue(ctx, spiffeIDKey, "spiffe://cluster.local/ns/agents/sa/peer")
+	tc := &protocol.TaskContext{Origin: "did:sage:ethereum:0xabc", TaskID: "task-1", ContextID: "ctx-1"}
+	ctx = context.WithValue(ctx, taskContextKey, tc)
+
+	// Caller keys sent by the client are replaced, others kept
+	original := map[string]any{CallerDIDKey: "did:sage:ethereum:0xforged", CallerExtensionsKey: []string{"x"}, "trace": "t-1"}
+	reqCtx := &a2asrv.RequestContext{Metadata: original}
+	_, err := IdentityInterceptor{}.Intercept(ctx, reqCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "did:sage:ethereum:0xabc", reqCtx.Metadata[CallerDIDKey])
+	assert.Equal(t, []string{"billing"}, reqCtx.Metadata[CallerCapabilitiesKey])
+	assert.Equal(t, "spiffe://cluster.local/ns/agents/sa/peer", reqCtx.Metadata[CallerSPIFFEIDKey])
+	assert.Same(t, tc, reqCtx.Metadata[CallerTaskContextKey])
+	assert.NotContains(t, reqCtx.Metadata, CallerExtensionsKey)
+	assert.Equal(t, "t-1", reqCtx.Metadata["trace"])
+	assert.Equal(t, "did:sage:ethereum:0xforged", original[CallerDIDKey], "request metadata is not modified")
+
+	// Unsigned requests carry no caller
+	reqCtx = &a2asrv.RequestContext{Metadata: map[string]any{CallerDIDKey: "did:sage:ethereum:0xforged"}}
+	_, err = IdentityInterceptor{}.Intercept(context.Background(), reqCtx)
+	require.NoError(t, err)
+	assert.Empty(t, reqCtx.Metadata)
+}
+
+func TestIdentityInterceptor_Handler(t *testing.T) {
+	var caller any
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		caller = reqCtx.Metadata[CallerDIDKey]
+		return queue.Write(ctx, a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: "done"}))
+	}}
+	handler := a2asrv.NewHandler(executor, a2asrv.WithRequestContextInterceptor(IdentityInterceptor{}))
+
+	ctx := context.WithValue(context.Background(), agentDIDKey, did.AgentDID("did:sage:ethereum:0xabc"))
+	_, err := handler.OnSendMessage(ctx, &a2a.MessageSendParams{Message: userMessage("hello")})
+	require.NoError(t, err)
+	assert.Equal(t, "did:sage:ethereum:0xabc", caller)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// GetMessageDigestFromContext returns the verified digest of the message
+// sent by the request (see protocol.MessageDigest), when the client
+// covered an A2A-Message-Digest header with its signature. Handlers can
+// persist it with the message as an integrity proof of its content.
+func GetMessageDigestFromContext(ctx context.Context) (string, bool) {
+	digest, ok := ctx.Value(messageDigestKey).(string)
+	return digest, ok
+}
+
+// checkMessageDigest checks the signed A2A-Message-Digest header of r, if
+// any, against the message in the JSON-RPC request body, returning the
+// digest
+func checkMessageDigest(r *http.Request, body []byte) (string, error) {
+	if !signedHeaders(r)(protocol.MessageDigestHeader) {
+		return "", nil
+	}
+	var req struct {
+		Params struct {
+			Message *a2a.Message `json:"message"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || req.Params.Message == nil {
+		return "", fmt.Errorf("%w: request carries no message", protocol.ErrMessageDigestMismatch)
+	}
+	digest := r.Header.Get(protocol.MessageDigestHeader)
+	if err := protocol.VerifyMessageDigest(req.Params.Message, digest); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDAuthMiddleware_MessageDigest(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	var (
+		got    string
+		gotOK  bool
+		called bool
+	)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		got, gotOK = GetMessageDigestFromContext(r.Context())
+	}))
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "hello"})
+	digest, err := protocol.MessageDigest(msg, "sha-256")
+	require.NoError(t, err)
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "message/send",
+		"params":  a2a.MessageSendParams{Message: msg},
+	})
+	require.NoError(t, err)
+
+	serve := func(digest string, covered bool) int {
+		req := signedRequest(string(body))
+		req.Header.Set(protocol.MessageDigestHeader, digest)
+		if covered {
+			req.Header.Set("Signature-Input", `sig1=("@method" "a2a-message-digest");keyid="did:sage:ethereum:0xabc"`)
+		}
+		rec := httptest.NewRecorder()
+		called, got, gotOK = false, "", false
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(digest, true))
+	assert.True(t, gotOK)
+	assert.Equal(t, digest, got)
+
+	other, err := protocol.MessageDigest(a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "bye"}), "sha-256")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, serve(other, true))
+	assert.False(t, called)
+
+	// Uncovered headers are ignored
+	assert.Equal(t, http.StatusOK, serve(other, false))
+	assert.False(t, gotOK)
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Capabilities required by DefaultMethodCapabilities
+const (
+	CapabilityMessagingReceive = "messaging.receive"
+	CapabilityTaskRead         = "task.read"
+	CapabilityTaskCancel       = "task.cancel"
+	CapabilityTaskPush         = "task.push"
+)
+
+// MethodCapabilities maps JSON-RPC methods to the capabilities a caller
+// must hold, all of them, to invoke the method. A method listed with no
+// capabilities is open to every verified caller.
+type MethodCapabilities map[string][]string
+
+// DefaultMethodCapabilities returns the capabilities required for each A2A
+// JSON-RPC method by default
+func DefaultMethodCapabilities() MethodCapabilities {
+	methods := make(MethodCapabilities, len(rpcMethodSpecs))
+	for _, spec := range rpcMethodSpecs {
+		methods[spec.name] = []string{defaultMethodCapability(spec)}
+	}
+	return methods
+}
+
+// defaultMethodCapability returns the capability guarding a protocol method
+func defaultMethodCapability(spec rpcMethodSpec) string {
+	switch {
+	case spec.push:
+		return CapabilityTaskPush
+	case spec.name == "message/send" || spec.name == "message/stream":
+		return CapabilityMessagingReceive
+	case spec.name == "tasks/cancel":
+		return CapabilityTaskCancel
+	}
+	return CapabilityTaskRead
+}
+
+// MethodCapabilitiesFromCard returns DefaultMethodCapabilities restricted
+// to the methods card advertises: streaming and push notification methods
+// are left out unless the card declares support for them, so calls to them
+// are rejected.
+func MethodCapabilitiesFromCard(card *a2a.AgentCard) MethodCapabilities {
+	methods := DefaultMethodCapabilities()
+	for _, spec := range rpcMethodSpecs {
+		if (spec.streaming && !card.Capabilities.Streaming) || (spec.push && !card.Capabilities.PushNotifications) {
+			delete(methods, spec.name)
+		}
+	}
+	return methods
+}
+
+// SetMethodCapabilities requires callers of each JSON-RPC method to hold
+// the capabilities listed for it (see GetCapabilitiesFromContext), checked
+// after verification and before the authorizer. Calls to methods not in
+// methods are rejected; requests that are not JSON-RPC calls are not
+// checked. Unsigned requests admitted in optional mode hold no
+// capabilities. Streamed requests, whose method is not known up front,
+// must hold the capabilities of every method. Denials are passed to the
+// error handler as *AuthorizationError. Pass nil to disable the check.
+func (m *DIDAuthMiddleware) SetMethodCapabilities(methods MethodCapabilities) {
+	if methods != nil {
+		methods = maps.Clone(methods)
+	}
+	m.update(func(c *middlewareConfig) {
+		c.methodCapabilities = methods
+	})
+}
+
+// checkMethod returns an *AuthorizationError unless caps satisfy the
+// requirements of method
+func (m *middlewareConfig) checkMethod(method string, caps []string) error {
+	required, ok := m.methodCapabilities[method]
+	if !ok {
+		return &AuthorizationError{Reason: fmt.Sprintf("method %q is not served", method)}
+	}
+	for _, c := range required {
+		if !slices.Contains(caps, c) {
+			return &AuthorizationError{Reason: fmt.Sprintf("method %s requires capability %q", method, c)}
+		}
+	}
+	return nil
+}
+
+// checkAnyMethod checks caps against the requirements of every method,
+// for requests whose method is not known
+func (m *middlewareConfig) checkAnyMethod(caps []string) error {
+	methods := slices.Collect(maps.Keys(m.methodCapabilities))
+	sort.Strings(methods)
+	for _, method := range methods {
+		if err := m.checkMethod(method, caps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodCapabilitiesFromCard(t *testing.T) {
+	methods := MethodCapabilitiesFromCard(&a2a.AgentCard{})
+	assert.Equal(t, []string{CapabilityMessagingReceive}, methods["message/send"])
+	assert.Equal(t, []string{CapabilityTaskCancel}, methods["tasks/cancel"])
+	assert.Equal(t, []string{CapabilityTaskRead}, methods["tasks/get"])
+	assert.NotContains(t, methods, "message/stream")
+	assert.NotContains(t, methods, "tasks/pushNotificationConfig/set")
+
+	methods = MethodCapabilitiesFromCard(&a2a.AgentCard{
+		Capabilities: a2a.AgentCapabilities{Streaming: true, PushNotifications: true},
+	})
+	assert.Equal(t, []string{CapabilityMessagingReceive}, methods["message/stream"])
+	assert.Equal(t, []string{CapabilityTaskPush}, methods["tasks/pushNotificationConfig/set"])
+	assert.Equal(t, DefaultMethodCapabilities(), methods)
+}
+
+func TestDIDAuthMiddleware_MethodCapabilities(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	middleware.SetCapabilityResolver(func(ctx context.Context, agentDID did.AgentDID) ([]string, error) {
+		return []string{CapabilityMessagingReceive}, nil
+	})
+	middleware.SetMethodCapabilities(MethodCapabilitiesFromCard(&a2a.AgentCard{}))
+
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"allowed", `{"jsonrpc":"2.0","id":1,"method":"message/send"}`, http.StatusOK},
+		{"missing capability", `{"jsonrpc":"2.0","id":1,"method":"tasks/cancel"}`, http.StatusForbidden},
+		{"not advertised", `{"jsonrpc":"2.0","id":1,"method":"message/stream"}`, http.StatusForbidden},
+		{"unknown method", `{"jsonrpc":"2.0","id":1,"method":"admin/reset"}`, http.StatusForbidden},
+		{"not json-rpc", `plain`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, signedRequest(tt.body))
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(`{"jsonrpc":"2.0","id":1,"method":"tasks/cancel"}`))
+	assert.Contains(t, rr.Body.String(), CapabilityTaskCancel)
+}
+
+func TestDIDAuthMiddleware_MethodCapabilitiesOptionalUnsigned(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true})
+	middleware.SetOptional(true)
+	middleware.SetMethodCapabilities(MethodCapabilities{
+		"tasks/get":    nil,
+		"message/send": {CapabilityMessagingReceive},
+	})
+
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(body string) int {
+		req := httptest.NewRequest("POST", "/rpc", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	require.Equal(t, http.StatusOK, send(`{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`))
+	assert.Equal(t, http.StatusForbidden, send(`{"jsonrpc":"2.0","id":1,"method":"message/send"}`))
+}
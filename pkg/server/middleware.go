@@ -20,121 +20,469 @@ package server
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
 	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
 	"github.com/sage-x-project/sage/pkg/agent/did"
-	ethdid "github.com/sage-x-project/sage/pkg/agent/did/ethereum"
 )
 
-type contextKey string
+// contextKey is the type of the request context keys set by the
+// middlewares of this package. Every key is declared here so the built-in
+// middlewares share a single set of distinct keys, and the unexported type
+// keeps them from colliding with keys of other packages.
+type contextKey int
 
-const agentDIDKey contextKey = "agent_did"
+const (
+	agentDIDKey contextKey = iota
+	capabilitiesKey
+	grantsKey
+	extensionsKey
+	priorityKey
+	deadlineKey
+	probeKey
+	usageKey
+	reputationKey
+	spiffeIDKey
+	attestationsKey
+	messageDigestKey
+	taskContextKey
+	budgetKey
+	unverifiedKey
+	signatureParamsKey
+	signatureLabelKey
+)
 
 // ErrorHandler handles verification errors
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
 
-// DIDAuthMiddleware provides HTTP middleware for DID signature verification
+// VerificationHook is called after each signature verification attempt.
+// On success agentDID is set and err is nil; on failure err describes why.
+type VerificationHook func(r *http.Request, agentDID did.AgentDID, err error)
+
+// DIDAuthMiddleware provides HTTP middleware for DID signature verification.
+//
+// Its configuration may be changed while it serves requests: every setter
+// installs a new configuration atomically, and each request is handled
+// with the configuration current when it arrived. UpdateConfig applies
+// several changes as one.
 type DIDAuthMiddleware struct {
-	verifier     verifier.DIDVerifier
-	errorHandler ErrorHandler
-	optional     bool
+	mu     sync.Mutex // serializes configuration updates
+	config atomic.Pointer[middlewareConfig]
+}
+
+// middlewareConfig is a snapshot of the DIDAuthMiddleware configuration.
+// Snapshots are never modified once installed; updates copy them.
+type middlewareConfig struct {
+	verifier           verifier.DIDVerifier
+	errorHandler       ErrorHandler
+	optional           bool
+	optionalPolicy     OptionalPolicy
+	verificationHook   VerificationHook
+	cors               *CORSConfig
+	fingerprintHook    FingerprintHook
+	digestAlgorithms   []string // accepted Content-Digest algorithms; nil accepts all supported
+	auditLogger        AuditLogger
+	authorizer         Authorizer
+	capabilityResolver CapabilityResolver
+	spiffe             *SPIFFEConfig
+	pool               *VerificationPool
+	usage              *UsageTracker
+	rateLimiter        *DistributedRateLimiter
+	extensions         []a2a.AgentExtension
+	probe              *ProbeBypass
+	reputation         *ReputationTracker
+	grants             *GrantConfig
+	replay             *ReplayConfig
+	methodCapabilities MethodCapabilities
+	attestations       *AttestationPolicy
+	taskContexts       *TaskContextConfig
+	requestTimeout     time.Duration
+	earlyData          *EarlyDataConfig
+	unverifiedHints    *UnverifiedHintConfig
+	prechecks          *PrecheckConfig
+	debug              *DebugConfig
+	skip               func(*http.Request) bool
 }
 
-// DIDClient combines DID resolution capabilities needed by middleware
-// It must be able to resolve agent metadata (for key selection)
-// and resolve a concrete public key by key type.
 // NewDIDAuthMiddleware creates a new DID authentication middleware
-func NewDIDAuthMiddleware(
-	resolver *ethdid.AgentCardClient, // DIDResolver: GetAgentByDID
-	client *ethdid.EthereumClient, // PublicKeyClient: ResolvePublicKey/ResolveKEMKey
-) *DIDAuthMiddleware {
-	selector := verifier.NewDefaultKeySelector(resolver) // DIDResolver 기반 선택
-	sigVerifier := verifier.NewRFC9421Verifier()
-	didVerifier := verifier.NewDefaultDIDVerifier(client, selector, sigVerifier)
-
-	return &DIDAuthMiddleware{
-		verifier:     didVerifier,
-		errorHandler: defaultErrorHandler,
-		optional:     false,
+// resolving agents with resolver (e.g. *ethereum.AgentCardClient) and keys
+// with client (e.g. *ethereum.EthereumClient). Either may be nil: a nil
+// resolver only resolves DID methods added with verifier.RegisterDIDMethod,
+// and a nil client picks every key through the resolver.
+func NewDIDAuthMiddleware(resolver verifier.DIDResolver, client verifier.PublicKeyClient) *DIDAuthMiddleware {
+	// Resolution is memoized per request (see verifier.WithResolutionCache)
+	var selector verifier.KeySelector
+	if resolver != nil {
+		selector = verifier.NewDefaultKeySelector(verifier.NewMemoizedResolver(resolver))
+	}
+	var keyClient verifier.PublicKeyClient
+	if client != nil {
+		keyClient = verifier.NewMemoizedPublicKeyClient(client)
 	}
+	didVerifier := verifier.NewDefaultDIDVerifier(keyClient, selector, verifier.NewRFC9421Verifier())
+
+	return NewDIDAuthMiddlewareWithVerifier(didVerifier)
 }
 
 // NewDIDAuthMiddlewareWithVerifier creates middleware with a custom verifier
 func NewDIDAuthMiddlewareWithVerifier(didVerifier verifier.DIDVerifier) *DIDAuthMiddleware {
-	return &DIDAuthMiddleware{
+	m := &DIDAuthMiddleware{}
+	m.config.Store(&middlewareConfig{
 		verifier:     didVerifier,
 		errorHandler: defaultErrorHandler,
 		optional:     false,
-	}
+	})
+	return m
+}
+
+// update installs a copy of the current configuration modified by apply
+func (m *DIDAuthMiddleware) update(apply func(c *middlewareConfig)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := *m.config.Load()
+	apply(&c)
+	m.config.Store(&c)
+}
+
+// UpdateConfig applies several configuration changes atomically: update
+// calls the setters of staged, a private copy of the middleware, and its
+// configuration replaces the current one when update returns. Requests in
+// flight finish with the configuration they started with, and no request
+// observes only part of the changes:
+//
+//	middleware.UpdateConfig(func(staged *server.DIDAuthMiddleware) {
+//	    staged.SetOptional(false)
+//	    staged.SetAuthorizer(lockdown)
+//	})
+//
+// update must not call methods of the middleware itself.
+func (m *DIDAuthMiddleware) UpdateConfig(update func(staged *DIDAuthMiddleware)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	staged := &DIDAuthMiddleware{}
+	c := *m.config.Load()
+	staged.config.Store(&c)
+	update(staged)
+	m.config.Store(staged.config.Load())
 }
 
 // SetErrorHandler sets a custom error handler
 func (m *DIDAuthMiddleware) SetErrorHandler(handler ErrorHandler) {
-	m.errorHandler = handler
+	m.update(func(c *middlewareConfig) {
+		c.errorHandler = handler
+	})
 }
 
 // SetOptional sets whether signature verification is optional
-// If true, requests without signatures are allowed to pass through
+// If true, requests without signatures are allowed to pass through.
+// It replaces any policy set with SetOptionalPolicy.
 func (m *DIDAuthMiddleware) SetOptional(optional bool) {
-	m.optional = optional
+	m.update(func(c *middlewareConfig) {
+		c.optional = optional
+		c.optionalPolicy = nil
+	})
+}
+
+// SetSkipFunc sets a predicate selecting requests that bypass the
+// middleware entirely, e.g. traffic from internal networks or sessions
+// authenticated by other means. Skipped requests reach the handler without
+// an agent DID and are not authorized. Pass nil to verify every request.
+func (m *DIDAuthMiddleware) SetSkipFunc(skip func(r *http.Request) bool) {
+	m.update(func(c *middlewareConfig) {
+		c.skip = skip
+	})
+}
+
+// SetVerificationHook sets a hook that observes every verification result.
+// The hook must not modify the request or write to the response.
+func (m *DIDAuthMiddleware) SetVerificationHook(hook VerificationHook) {
+	m.update(func(c *middlewareConfig) {
+		c.verificationHook = hook
+	})
+}
+
+// SetDigestAlgorithms restricts the accepted Content-Digest algorithms, in
+// order of preference, and advertises them to clients via the
+// Want-Content-Digest response header. By default sha-256 and sha-512 are
+// accepted and nothing is advertised.
+func (m *DIDAuthMiddleware) SetDigestAlgorithms(algs ...string) {
+	m.update(func(c *middlewareConfig) {
+		c.digestAlgorithms = algs
+	})
 }
 
 // Wrap wraps an HTTP handler with DID authentication
 func (m *DIDAuthMiddleware) Wrap(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip verification for OPTIONS requests (CORS preflight)
-		if r.Method == "OPTIONS" {
-			next.ServeHTTP(w, r)
+		m.config.Load().serve(w, r, next)
+	})
+}
+
+// serve authenticates r with this configuration and passes it to next
+func (m *middlewareConfig) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if len(m.digestAlgorithms) > 0 {
+		w.Header().Set("Want-Content-Digest", signer.FormatWantContentDigest(m.digestAlgorithms...))
+	}
+
+	crossOrigin := false
+	if m.cors != nil {
+		// Answer preflights and enforce the origin allow list
+		var handled bool
+		if handled, crossOrigin = m.handleCORS(w, r); handled {
 			return
 		}
+	} else if r.Method == "OPTIONS" {
+		// Skip verification for OPTIONS requests (CORS preflight)
+		next.ServeHTTP(w, r)
+		return
+	}
 
-		// Check if signature headers are present
-		signatureInput := r.Header.Get("Signature-Input")
-		signature := r.Header.Get("Signature")
+	if m.skip != nil && m.skip(r) {
+		next.ServeHTTP(w, r)
+		return
+	}
 
-		if signatureInput == "" || signature == "" {
-			if m.optional {
-				// Allow request to proceed without DID in context
-				next.ServeHTTP(w, r)
+	// Admit authenticated health probes without a signature
+	if m.probe != nil && m.probe.matches(r) {
+		m.serveProbe(w, r, next)
+		return
+	}
+
+	// Check if signature headers are present
+	signatureInput := r.Header.Get("Signature-Input")
+	signature := r.Header.Get("Signature")
+
+	if signatureInput == "" || signature == "" {
+		if m.spiffe != nil && m.spiffe.Mode == SPIFFEEither {
+			if spiffeID, ok := m.spiffe.peerID(r); ok {
+				m.serveSVID(w, r, spiffeID, next)
 				return
 			}
-			m.errorHandler(w, r, fmt.Errorf("missing signature headers"))
+		}
+		if m.allowUnsigned(r) && !(crossOrigin && m.cors.RequireSignedCrossOrigin) {
+			r = r.WithContext(m.withUnverifiedIdentity(r))
+			if m.authorizer != nil || m.methodCapabilities != nil || m.attestations != nil {
+				var bodyBytes []byte
+				if r.Body != nil {
+					bodyBytes, _ = io.ReadAll(r.Body)
+					r.Body.Close()
+				}
+				r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+				request := rpcBody(r, bodyBytes)
+				if method := rpcMethod(request); method != "" && m.methodCapabilities != nil {
+					if err := m.checkMethod(method, nil); err != nil {
+						m.deny(w, r, "", err)
+						return
+					}
+				}
+				if _, err := m.checkAttestations(r.Context(), r, "", rpcMethod(request)); err != nil {
+					m.deny(w, r, "", err)
+					return
+				}
+				if m.authorizer != nil {
+					if err := m.authorize(r.Context(), r, "", request); err != nil {
+						m.deny(w, r, "", err)
+						return
+					}
+				}
+			}
+			// Allow request to proceed without DID in context
+			next.ServeHTTP(w, r)
+			return
+		}
+		if m.prechecks != nil {
+			m.prechecks.Metrics.record(StageHeaders, 0, ErrMalformedSignature)
+		}
+		m.fail(w, r, r.ContentLength, fmt.Errorf("missing signature headers"))
+		return
+	}
+
+	// Read signature parameters only from the signature the verifier checks
+	r, err := m.selectSignature(r)
+	if err != nil {
+		m.fail(w, r, r.ContentLength, fmt.Errorf("signature verification failed: %w", err))
+		return
+	}
+
+	// Reject obviously invalid requests before any key resolution
+	if m.prechecks != nil {
+		if err := m.precheck(r.Context(), r); err != nil {
+			m.fail(w, r, r.ContentLength, fmt.Errorf("signature verification failed: %w", err))
 			return
 		}
+	}
+
+	// Bodies whose digest follows as a trailer are verified as they
+	// stream to the handler
+	if streamingSigned(r) {
+		m.serveStreaming(w, r, next)
+		return
+	}
 
-		// Read body to preserve it for handler
-		var bodyBytes []byte
-		if r.Body != nil {
-			bodyBytes, _ = io.ReadAll(r.Body)
-			r.Body.Close()
+	// Read body to preserve it for handler
+	var bodyBytes []byte
+	if r.Body != nil {
+		bodyBytes, _ = io.ReadAll(r.Body)
+		r.Body.Close()
+	}
+
+	// Check the body against Content-Digest; any supported algorithm
+	// (or several at once) may be used by the client
+	if digestHeader := r.Header.Get("Content-Digest"); digestHeader != "" {
+		if err := signer.VerifyContentDigest(digestHeader, bodyBytes, m.digestAlgorithms); err != nil {
+			r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			m.fail(w, r, int64(len(bodyBytes)), fmt.Errorf("content digest verification failed: %w", err))
+			return
 		}
+	}
+
+	// Restore body for verification
+	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 
-		// Restore body for verification
+	// Extract and verify DID signature; resolution results are memoized
+	// for the rest of the request so handlers can re-verify cheaply
+	ctx := verifier.WithResolutionCache(r.Context())
+	agentDID, err := m.verify(ctx, r)
+	if errors.Is(err, ErrPoolSaturated) || errors.Is(err, ErrPoolClosed) {
+		shed(w)
+		return
+	}
+	if err != nil {
+		// Restore body even on error
 		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		m.fail(w, r, int64(len(bodyBytes)), fmt.Errorf("signature verification failed: %w", err))
+		return
+	}
+	spiffeID, err := m.checkSVID(ctx, r, agentDID)
+	if err != nil {
+		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		m.fail(w, r, int64(len(bodyBytes)), fmt.Errorf("SPIFFE verification failed: %w", err))
+		return
+	}
+	request := rpcBody(r, bodyBytes)
+	if !m.acceptEarlyData(w, r, rpcMethod(request)) {
+		return
+	}
+	messageDigest, err := checkMessageDigest(r, request)
+	if err != nil {
+		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		m.fail(w, r, int64(len(bodyBytes)), fmt.Errorf("message digest verification failed: %w", err))
+		return
+	}
+	m.notifyVerification(r, agentDID, nil)
+	m.notifyFingerprint(r, int64(len(bodyBytes)), agentDID, nil)
+	ctx = m.withSignatureParams(ctx, r)
 
-		// Extract and verify DID signature
-		ctx := r.Context()
-		agentDID, err := m.verifier.VerifyHTTPSignatureWithKeyID(ctx, r)
-		if err != nil {
-			// Restore body even on error
+	ctx, err = m.applyCapabilities(ctx, r, agentDID)
+	if err != nil {
+		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		m.deny(w, r, agentDID, err)
+		return
+	}
+
+	if method := rpcMethod(request); method != "" && m.methodCapabilities != nil {
+		if err := m.checkMethod(method, GetCapabilitiesFromContext(ctx)); err != nil {
 			r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-			m.errorHandler(w, r, fmt.Errorf("signature verification failed: %w", err))
+			m.deny(w, r, agentDID, err)
 			return
 		}
+	}
 
-		// Restore body for handler
+	ctx, err = m.checkAttestations(ctx, r, agentDID, rpcMethod(request))
+	if err != nil {
 		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		m.deny(w, r, agentDID, err)
+		return
+	}
 
-		// Add DID to context
-		ctx = context.WithValue(ctx, agentDIDKey, agentDID)
-		r = r.WithContext(ctx)
+	ctx, err = m.checkTaskContext(ctx, r, agentDID, request)
+	if err != nil {
+		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		m.deny(w, r, agentDID, err)
+		return
+	}
 
-		// Call next handler
-		next.ServeHTTP(w, r)
-	})
+	if m.authorizer != nil {
+		if err := m.authorize(ctx, r, agentDID, request); err != nil {
+			r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			m.deny(w, r, agentDID, err)
+			return
+		}
+	}
+
+	// Restore body for handler
+	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+	ctx, cancel, ok := applyHints(ctx, w, r, m.requestTimeout)
+	if !ok {
+		return
+	}
+	defer cancel()
+
+	ctx, ok = m.negotiateExtensions(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	ctx, release, ok := m.admit(ctx, w, agentDID, int64(len(bodyBytes)), rpcMethod(request))
+	if !ok {
+		return
+	}
+	defer release()
+
+	// Add DID to context
+	ctx = context.WithValue(ctx, agentDIDKey, agentDID)
+	if spiffeID != "" {
+		ctx = context.WithValue(ctx, spiffeIDKey, spiffeID)
+	}
+	if messageDigest != "" {
+		ctx = context.WithValue(ctx, messageDigestKey, messageDigest)
+	}
+	if m.reputation != nil {
+		ctx = context.WithValue(ctx, reputationKey, m.reputation.Reputation(agentDID))
+	}
+	r = r.WithContext(ctx)
+
+	// Call next handler
+	m.serveTracked(w, r, agentDID, next)
+}
+
+// fail reports a verification failure to the hooks and audit logger and
+// writes the error response
+func (m *middlewareConfig) fail(w http.ResponseWriter, r *http.Request, bodySize int64, err error) {
+	m.notifyVerification(r, "", err)
+	m.notifyFingerprint(r, bodySize, "", err)
+	m.auditFailure(r, err)
+	if m.debug != nil {
+		err = m.diagnose(r, err)
+	}
+	m.errorHandler(w, r, err)
+}
+
+// notifyVerification invokes the verification hook if one is set
+func (m *middlewareConfig) notifyVerification(r *http.Request, agentDID did.AgentDID, err error) {
+	if m.reputation != nil {
+		subject := agentDID
+		if err != nil {
+			// Attribute failures to the claimed keyid
+			var fp RequestFingerprint
+			parseSignature(r, &fp)
+			subject = did.AgentDID(fp.KeyID)
+		}
+		m.reputation.RecordVerification(subject, err)
+	}
+	if m.verificationHook != nil {
+		m.verificationHook(r, agentDID, err)
+	}
 }
 
 // GetAgentDIDFromContext extracts the agent DID from request context
@@ -145,5 +493,25 @@ func GetAgentDIDFromContext(ctx context.Context) (did.AgentDID, bool) {
 
 // defaultErrorHandler is the default error handler
 func defaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
-	http.Error(w, fmt.Sprintf("Unauthorized: %s", err.Error()), http.StatusUnauthorized)
+	var authzErr *AuthorizationError
+	if errors.As(err, &authzErr) {
+		writeForbidden(w, err.Error())
+		return
+	}
+	if errors.Is(err, verifier.ErrResolutionTimeout) || errors.Is(err, ErrReplayCheckFailed) {
+		// The signer's key could not be resolved in time, or replays could
+		// not be ruled out; not the client's fault
+		writeUnavailable(w, err.Error(), 1)
+		return
+	}
+	var diagnosed *DiagnosedError
+	if errors.As(err, &diagnosed) && diagnosed.Disclose {
+		writeError(w, http.StatusUnauthorized, protocol.ErrorBody{
+			Code:    protocol.ErrorCodeUnauthenticated,
+			Message: "Unauthorized: " + err.Error(),
+			Details: diagnosed.Diagnosis,
+		})
+		return
+	}
+	writeUnauthorized(w, err.Error())
 }
@@ -20,18 +20,19 @@ package server
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
-	"time"
 
 	stdcrypto "crypto"
 
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -61,77 +62,12 @@ func (m *mockDIDVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, req
 	return m.extractedDID, nil
 }
 
-// mockEthereumClient for testing
-type mockEthereumClient struct {
-	publicKeys map[did.AgentDID]map[did.KeyType]interface{}
-}
-
-func (m *mockEthereumClient) ResolveAllPublicKeys(ctx context.Context, agentDID did.AgentDID) ([]did.AgentKey, error) {
-	keys := []did.AgentKey{}
-	if keyMap, found := m.publicKeys[agentDID]; found {
-		for keyType, pubKey := range keyMap {
-			keyData, _ := did.MarshalPublicKey(pubKey)
-			keys = append(keys, did.AgentKey{
-				Type:      keyType,
-				KeyData:   keyData,
-				Verified:  true,
-				CreatedAt: time.Now(),
-			})
-		}
-	}
-	return keys, nil
-}
-
-func (m *mockEthereumClient) ResolvePublicKeyByType(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) (interface{}, error) {
-	if keyMap, found := m.publicKeys[agentDID]; found {
-		if pubKey, found := keyMap[keyType]; found {
-			return pubKey, nil
-		}
-	}
-	return nil, nil
-}
-
-// Implement DIDResolver for key selection
-func (m *mockEthereumClient) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
-	d := did.AgentDID(didStr)
-	meta := &did.AgentMetadataV4{
-		DID:      d,
-		IsActive: true,
-		Keys:     []did.AgentKey{},
-	}
-	if keyMap, ok := m.publicKeys[d]; ok {
-		for kt, pk := range keyMap {
-			if keyData, err := did.MarshalPublicKey(pk); err == nil {
-				meta.Keys = append(meta.Keys, did.AgentKey{
-					Type:      kt,
-					KeyData:   keyData,
-					Verified:  true,
-					CreatedAt: time.Now(),
-				})
-			}
-		}
-	}
-	return meta, nil
-}
-
 // Test NewDIDAuthMiddleware creates middleware
 func TestNewDIDAuthMiddleware(t *testing.T) {
-	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	testDID := did.AgentDID("did:sage:ethereum:0xtest")
-
-	client := &mockEthereumClient{
-		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{
-			testDID: {
-				did.KeyTypeECDSA: &privKey.PublicKey,
-			},
-		},
-	}
-	_ = client
-
 	middleware := NewDIDAuthMiddleware(nil, nil)
 
 	assert.NotNil(t, middleware)
-	assert.NotNil(t, middleware.verifier)
+	assert.NotNil(t, middleware.config.Load().verifier)
 }
 
 // Test middleware allows valid signed requests
@@ -179,13 +115,37 @@ func TestDIDAuthMiddleware_ValidSignature(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
 
-// Test middleware rejects unsigned requests
-func TestDIDAuthMiddleware_MissingSignature(t *testing.T) {
-	client := &mockEthereumClient{
-		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{},
+// Test middleware verifies real signatures against the in-memory registry
+func TestDIDAuthMiddleware_MemoryRegistry(t *testing.T) {
+	ctx := context.Background()
+	testDID := did.AgentDID("did:sage:ethereum:0xtest")
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	reg := registry.NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(ctx, testDID, keyPair.PublicKey()))
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(reg.NewDIDVerifier())
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func() int {
+		req := httptest.NewRequest("POST", "http://agent.example.com/rpc", bytes.NewReader([]byte(`{"method":"test"}`)))
+		require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(ctx, req, testDID, keyPair))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
 	}
-	_ = client
+	assert.Equal(t, http.StatusOK, serve())
+
+	// Revoked keys no longer verify
+	require.NoError(t, reg.Revoke(ctx, testDID, did.KeyTypeECDSA))
+	assert.Equal(t, http.StatusUnauthorized, serve())
+}
 
+// Test middleware rejects unsigned requests
+func TestDIDAuthMiddleware_MissingSignature(t *testing.T) {
 	middleware := NewDIDAuthMiddleware(nil, nil)
 
 	handlerCalled := false
@@ -232,12 +192,19 @@ func TestDIDAuthMiddleware_InvalidSignature(t *testing.T) {
 }
 
 // Test middleware with custom error handler
-func TestDIDAuthMiddleware_CustomErrorHandler(t *testing.T) {
-	client := &mockEthereumClient{
-		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{},
-	}
-	_ = client
+func TestDefaultErrorHandler_ResolutionTimeout(t *testing.T) {
+	rr := httptest.NewRecorder()
+	err := fmt.Errorf("signature verification failed: %w", verifier.ErrResolutionTimeout)
+	defaultErrorHandler(rr, httptest.NewRequest("POST", "/rpc", nil), err)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+
+	rr = httptest.NewRecorder()
+	defaultErrorHandler(rr, httptest.NewRequest("POST", "/rpc", nil), fmt.Errorf("bad signature"))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
 
+func TestDIDAuthMiddleware_CustomErrorHandler(t *testing.T) {
 	customErrorCalled := false
 	customErrorHandler := func(w http.ResponseWriter, r *http.Request, err error) {
 		customErrorCalled = true
@@ -264,11 +231,6 @@ func TestDIDAuthMiddleware_CustomErrorHandler(t *testing.T) {
 
 // Test middleware with optional verification
 func TestDIDAuthMiddleware_OptionalVerification(t *testing.T) {
-	client := &mockEthereumClient{
-		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{},
-	}
-	_ = client
-
 	middleware := NewDIDAuthMiddleware(nil, nil)
 	middleware.SetOptional(true)
 
@@ -294,6 +256,48 @@ func TestDIDAuthMiddleware_OptionalVerification(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
 
+func TestDIDAuthMiddleware_UpdateConfig(t *testing.T) {
+	middleware := NewDIDAuthMiddleware(nil, nil)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	status := func() int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/test", nil))
+		return rr.Code
+	}
+	assert.Equal(t, http.StatusUnauthorized, status())
+
+	// Changes apply to a handler wrapped earlier
+	middleware.UpdateConfig(func(staged *DIDAuthMiddleware) {
+		staged.SetOptional(true)
+		staged.SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+	})
+	assert.Equal(t, http.StatusOK, status())
+	middleware.SetOptional(false)
+	assert.Equal(t, http.StatusTeapot, status())
+}
+
+// Reconfiguring while serving must not race (run with -race)
+func TestDIDAuthMiddleware_ConcurrentReconfiguration(t *testing.T) {
+	middleware := NewDIDAuthMiddleware(nil, nil)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			middleware.SetOptional(i%2 == 0)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/test", nil))
+		assert.Contains(t, []int{http.StatusOK, http.StatusUnauthorized}, rr.Code)
+	}
+	<-done
+}
+
 // Test GetAgentDIDFromContext with missing DID
 func TestGetAgentDIDFromContext_Missing(t *testing.T) {
 	ctx := context.Background()
@@ -313,11 +317,6 @@ func TestGetAgentDIDFromContext_Present(t *testing.T) {
 
 // Test middleware with OPTIONS request (CORS preflight)
 func TestDIDAuthMiddleware_OptionsRequest(t *testing.T) {
-	client := &mockEthereumClient{
-		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{},
-	}
-	_ = client
-
 	middleware := NewDIDAuthMiddleware(nil, nil)
 
 	handlerCalled := false
@@ -337,6 +336,32 @@ func TestDIDAuthMiddleware_OptionsRequest(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
 
+func TestDIDAuthMiddleware_SkipFunc(t *testing.T) {
+	middleware := NewDIDAuthMiddleware(nil, nil)
+	middleware.SetSkipFunc(func(r *http.Request) bool {
+		return strings.HasPrefix(r.RemoteAddr, "10.")
+	})
+
+	var agentDIDSet bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, agentDIDSet = GetAgentDIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/test", nil)
+	req.RemoteAddr = "10.1.2.3:4567"
+	rr := httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, agentDIDSet)
+
+	req = httptest.NewRequest("POST", "/test", nil)
+	req.RemoteAddr = "203.0.113.7:4567"
+	rr = httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
 // Test middleware preserves request body
 func TestDIDAuthMiddleware_PreservesBody(t *testing.T) {
 	testDID := did.AgentDID("did:sage:ethereum:0xtest")
@@ -369,3 +394,114 @@ func TestDIDAuthMiddleware_PreservesBody(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
+
+// Test verification hook observes success and failure
+func TestDIDAuthMiddleware_VerificationHook(t *testing.T) {
+	testDID := did.AgentDID("did:sage:ethereum:0xtest")
+
+	type observed struct {
+		agentDID did.AgentDID
+		err      error
+	}
+	var results []observed
+
+	mockVerifier := &mockDIDVerifier{shouldSucceed: true, extractedDID: testDID}
+	middleware := NewDIDAuthMiddlewareWithVerifier(mockVerifier)
+	middleware.SetVerificationHook(func(r *http.Request, agentDID did.AgentDID, err error) {
+		results = append(results, observed{agentDID, err})
+	})
+
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	signed := httptest.NewRequest("POST", "/test", nil)
+	signed.Header.Set("Signature", "mock-signature")
+	signed.Header.Set("Signature-Input", `sig1=();keyid="did:sage:ethereum:0xtest"`)
+	handler.ServeHTTP(httptest.NewRecorder(), signed)
+
+	mockVerifier.shouldSucceed = false
+	handler.ServeHTTP(httptest.NewRecorder(), signed)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/test", nil))
+
+	require.Len(t, results, 3)
+	assert.Equal(t, testDID, results[0].agentDID)
+	assert.NoError(t, results[0].err)
+	assert.Error(t, results[1].err)
+	assert.Contains(t, results[2].err.Error(), "missing signature headers")
+}
+
+// Test handlers receive a per-request resolution cache
+func TestDIDAuthMiddleware_ResolutionCacheInContext(t *testing.T) {
+	mockVerifier := &mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xtest"}
+	middleware := NewDIDAuthMiddlewareWithVerifier(mockVerifier)
+
+	calls := 0
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			_, _ = verifier.Memoize(r.Context(), "agent:did:sage:ethereum:0xtest", func() (interface{}, error) {
+				calls++
+				return nil, nil
+			})
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", "/test", nil)
+	req.Header.Set("Signature", "mock-signature")
+	req.Header.Set("Signature-Input", `sig1=();keyid="did:sage:ethereum:0xtest"`)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestDIDAuthMiddleware_ContentDigest(t *testing.T) {
+	body := []byte(`{"jsonrpc":"2.0","method":"message/send"}`)
+	d256, _ := signer.ComputeContentDigest(signer.DigestSHA256, body)
+	d512, _ := signer.ComputeContentDigest(signer.DigestSHA512, body)
+
+	tests := []struct {
+		name     string
+		accepted []string
+		digest   string
+		reqBody  []byte
+		want     int
+	}{
+		{"sha-256 accepted by default", nil, d256, body, http.StatusOK},
+		{"sha-512 accepted by default", nil, d512, body, http.StatusOK},
+		{"multiple entries", nil, d256 + ", " + d512, body, http.StatusOK},
+		{"tampered body", nil, d256, []byte(`{"tampered":true}`), http.StatusUnauthorized},
+		{"algorithm not accepted", []string{signer.DigestSHA512}, d256, body, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{
+				shouldSucceed: true,
+				extractedDID:  "did:sage:ethereum:0xtest",
+			})
+			if tt.accepted != nil {
+				middleware.SetDigestAlgorithms(tt.accepted...)
+			}
+			handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest("POST", "/rpc", bytes.NewReader(tt.reqBody))
+			req.Header.Set("Content-Digest", tt.digest)
+			req.Header.Set("Signature", "sig1=:abc:")
+			req.Header.Set("Signature-Input", `sig1=("@method" "content-digest");keyid="did:sage:ethereum:0xtest"`)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+			if tt.accepted != nil {
+				assert.Equal(t, "sha-512=10", rr.Header().Get("Want-Content-Digest"))
+			} else {
+				assert.Empty(t, rr.Header().Get("Want-Content-Digest"))
+			}
+		})
+	}
+}
@@ -0,0 +1,555 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// DefaultOpenAPIPath is where the OpenAPI document is conventionally served
+const DefaultOpenAPIPath = "/openapi.json"
+
+// OpenAPIConfig configures the generated OpenAPI document. Unset fields are
+// taken from the agent card.
+type OpenAPIConfig struct {
+	// Title and Version of the API. Default to the card's name and version.
+	Title   string
+	Version string
+
+	// ServerURL is the base URL of the agent. Defaults to the card's URL.
+	ServerURL string
+
+	// RPCPath is the path JSON-RPC requests are posted to. Defaults to "/rpc",
+	// the path DIDHTTPTransport uses.
+	RPCPath string
+
+	// Methods restricts the documented JSON-RPC methods. When empty, all
+	// methods are documented except streaming and push notification methods
+	// the card does not advertise.
+	Methods []string
+
+	// Extensions lists the A2A extensions served. Defaults to the card's
+	// capability extensions.
+	Extensions []a2a.AgentExtension
+}
+
+// rpcMethodSpec describes the params and result of a JSON-RPC method
+type rpcMethodSpec struct {
+	name      string
+	params    reflect.Type
+	result    func(g *schemaGen) map[string]any
+	streaming bool
+	push      bool
+}
+
+// rpcMethodSpecs are the JSON-RPC methods of the A2A protocol, in document order
+var rpcMethodSpecs = []rpcMethodSpec{
+	{name: "message/send", params: typeOf[a2a.MessageSendParams](), result: func(g *schemaGen) map[string]any {
+		return oneOf(g.schema(typeOf[a2a.Task]()), g.schema(typeOf[a2a.Message]()))
+	}},
+	{name: "message/stream", params: typeOf[a2a.MessageSendParams](), result: eventSchema, streaming: true},
+	{name: "tasks/get", params: typeOf[a2a.TaskQueryParams](), result: resultOf[a2a.Task]},
+	{name: "tasks/cancel", params: typeOf[a2a.TaskIDParams](), result: resultOf[a2a.Task]},
+	{name: "tasks/resubscribe", params: typeOf[a2a.TaskIDParams](), result: eventSchema, streaming: true},
+	{name: "tasks/list", params: typeOf[protocol.ListTasksParams](), result: resultOf[protocol.ListTasksResult]},
+	{name: "tasks/pushNotificationConfig/get", params: typeOf[a2a.GetTaskPushConfigParams](), result: resultOf[a2a.TaskPushConfig], push: true},
+	{name: "tasks/pushNotificationConfig/list", params: typeOf[a2a.ListTaskPushConfigParams](), result: resultOf[[]*a2a.TaskPushConfig], push: true},
+	{name: "tasks/pushNotificationConfig/set", params: typeOf[a2a.TaskPushConfig](), result: resultOf[a2a.TaskPushConfig], push: true},
+	{name: "tasks/pushNotificationConfig/delete", params: typeOf[a2a.DeleteTaskPushConfigParams](), result: func(*schemaGen) map[string]any {
+		return map[string]any{"type": "null"}
+	}, push: true},
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func resultOf[T any](g *schemaGen) map[string]any {
+	return g.schema(typeOf[T]())
+}
+
+// eventSchema is the result of each event of a streaming method
+func eventSchema(g *schemaGen) map[string]any {
+	return oneOf(
+		g.schema(typeOf[a2a.Message]()),
+		g.schema(typeOf[a2a.Task]()),
+		g.schema(typeOf[a2a.TaskStatusUpdateEvent]()),
+		g.schema(typeOf[a2a.TaskArtifactUpdateEvent]()),
+	)
+}
+
+func oneOf(schemas ...map[string]any) map[string]any {
+	return map[string]any{"oneOf": schemas}
+}
+
+// componentName derives a schema name from a JSON-RPC method name,
+// e.g. "tasks/pushNotificationConfig/get" becomes "TasksPushNotificationConfigGet"
+func componentName(method string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(method, "/") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// selectMethods returns the methods to document for card and cfg
+func selectMethods(card *a2a.AgentCard, cfg OpenAPIConfig) ([]rpcMethodSpec, error) {
+	if len(cfg.Methods) > 0 {
+		var methods []rpcMethodSpec
+		for _, name := range cfg.Methods {
+			i := slices.IndexFunc(rpcMethodSpecs, func(m rpcMethodSpec) bool { return m.name == name })
+			if i < 0 {
+				return nil, fmt.Errorf("unknown JSON-RPC method: %s", name)
+			}
+			methods = append(methods, rpcMethodSpecs[i])
+		}
+		return methods, nil
+	}
+
+	var methods []rpcMethodSpec
+	for _, m := range rpcMethodSpecs {
+		if card != nil && m.streaming && !card.Capabilities.Streaming {
+			continue
+		}
+		if card != nil && m.push && !card.Capabilities.PushNotifications {
+			continue
+		}
+		methods = append(methods, m)
+	}
+	return methods, nil
+}
+
+// defineMethods adds the JSON-RPC request and response envelopes of methods
+// to g, plus the JSON-RPC error and SAGE extension payload schemas
+func defineMethods(g *schemaGen, methods []rpcMethodSpec) {
+	g.defs["JSONRPCError"] = map[string]any{
+		"type":     "object",
+		"required": []string{"code", "message"},
+		"properties": map[string]any{
+			"code":    map[string]any{"type": "integer"},
+			"message": map[string]any{"type": "string"},
+			"data":    map[string]any{},
+		},
+	}
+	g.schema(typeOf[protocol.ExtensionPayload]())
+	g.defs["ArtifactIntegrityMetadata"] = map[string]any{
+		"type":        "object",
+		"description": "SAGE artifact integrity entries in artifact metadata",
+		"properties": map[string]any{
+			protocol.ArtifactDigestKey:    map[string]any{"type": "string"},
+			protocol.ArtifactSignatureKey: map[string]any{"type": "string"},
+			protocol.ArtifactSignerKey:    map[string]any{"type": "string"},
+		},
+	}
+
+	for _, m := range methods {
+		name := componentName(m.name)
+		g.defs[name+"Request"] = map[string]any{
+			"type":     "object",
+			"required": []string{"jsonrpc", "method", "params", "id"},
+			"properties": map[string]any{
+				"jsonrpc": map[string]any{"const": "2.0"},
+				"method":  map[string]any{"const": m.name},
+				"params":  g.schema(m.params),
+				"id":      map[string]any{"type": []string{"string", "integer"}},
+			},
+		}
+		g.defs[name+"Response"] = map[string]any{
+			"type":     "object",
+			"required": []string{"jsonrpc", "id"},
+			"properties": map[string]any{
+				"jsonrpc": map[string]any{"const": "2.0"},
+				"result":  m.result(g),
+				"error":   g.ref("JSONRPCError"),
+				"id":      map[string]any{"type": []string{"string", "integer", "null"}},
+			},
+			"oneOf": []map[string]any{
+				{"required": []string{"result"}},
+				{"required": []string{"error"}},
+			},
+		}
+	}
+}
+
+// GenerateOpenAPI generates an OpenAPI 3.1 document describing the A2A
+// JSON-RPC methods served for card, so clients can be generated in other
+// languages. Each method has Request and Response schemas in the
+// components section, named after the method (e.g. MessageSendRequest).
+// card may be nil when cfg names the API.
+func GenerateOpenAPI(card *a2a.AgentCard, cfg OpenAPIConfig) (map[string]any, error) {
+	if card != nil {
+		if cfg.Title == "" {
+			cfg.Title = card.Name
+		}
+		if cfg.Version == "" {
+			cfg.Version = card.Version
+		}
+		if cfg.ServerURL == "" {
+			cfg.ServerURL = card.URL
+		}
+		if cfg.Extensions == nil {
+			cfg.Extensions = card.Capabilities.Extensions
+		}
+	}
+	if cfg.Title == "" {
+		return nil, fmt.Errorf("OpenAPI title is required without an agent card")
+	}
+	if cfg.Version == "" {
+		cfg.Version = "1.0.0"
+	}
+	if cfg.RPCPath == "" {
+		cfg.RPCPath = "/rpc"
+	}
+	methods, err := selectMethods(card, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newSchemaGen("#/components/schemas/")
+	defineMethods(g, methods)
+
+	var requests, responses, streams []map[string]any
+	mapping := map[string]string{}
+	names := make([]string, 0, len(methods))
+	for _, m := range methods {
+		name := componentName(m.name)
+		names = append(names, m.name)
+		mapping[m.name] = g.prefix + name + "Request"
+		requests = append(requests, g.ref(name+"Request"))
+		if m.streaming {
+			streams = append(streams, g.ref(name+"Response"))
+		} else {
+			responses = append(responses, g.ref(name+"Response"))
+		}
+	}
+
+	content := map[string]any{}
+	if len(responses) > 0 {
+		content["application/json"] = map[string]any{"schema": oneOf(responses...)}
+	}
+	if len(streams) > 0 {
+		content["text/event-stream"] = map[string]any{
+			"description": "Each SSE data field is one JSON-RPC response",
+			"schema":      oneOf(streams...),
+		}
+	}
+
+	info := map[string]any{"title": cfg.Title, "version": cfg.Version}
+	if card != nil && card.Description != "" {
+		info["description"] = card.Description
+	}
+
+	doc := map[string]any{
+		"openapi":           "3.1.0",
+		"jsonSchemaDialect": "https://json-schema.org/draft/2020-12/schema",
+		"info":              info,
+		"paths": map[string]any{
+			cfg.RPCPath: map[string]any{
+				"post": map[string]any{
+					"operationId": "a2aRPC",
+					"summary":     "A2A JSON-RPC 2.0 endpoint",
+					"parameters":  signatureParameters(),
+					"requestBody": map[string]any{
+						"required": true,
+						"content": map[string]any{
+							"application/json": map[string]any{
+								"schema": map[string]any{
+									"oneOf":         requests,
+									"discriminator": map[string]any{"propertyName": "method", "mapping": mapping},
+								},
+							},
+						},
+					},
+					"responses": map[string]any{
+						"200": map[string]any{"description": "JSON-RPC response", "content": content},
+						"400": map[string]any{"description": "Malformed request hints or missing required extension"},
+						"401": map[string]any{"description": "Missing or invalid DID signature"},
+						"403": map[string]any{"description": "Caller not authorized"},
+					},
+					"x-jsonrpc-methods": names,
+				},
+			},
+			"/.well-known/agent-card.json": map[string]any{
+				"get": map[string]any{
+					"operationId": "getAgentCard",
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "Agent card",
+							"content": map[string]any{
+								"application/json": map[string]any{"schema": g.schema(typeOf[a2a.AgentCard]())},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]any{"schemas": g.defs},
+	}
+	if cfg.ServerURL != "" {
+		doc["servers"] = []map[string]any{{"url": cfg.ServerURL}}
+	}
+	if len(cfg.Extensions) > 0 {
+		exts := make([]map[string]any, 0, len(cfg.Extensions))
+		for _, ext := range cfg.Extensions {
+			e := map[string]any{"uri": ext.URI, "required": ext.Required, "payload": g.ref("ExtensionPayload")}
+			if ext.Description != "" {
+				e["description"] = ext.Description
+			}
+			if len(ext.Params) > 0 {
+				e["params"] = ext.Params
+			}
+			exts = append(exts, e)
+		}
+		doc["x-a2a-extensions"] = exts
+	}
+	return doc, nil
+}
+
+// signatureParameters documents the RFC 9421 signature headers and the
+// signed request hint headers
+func signatureParameters() []map[string]any {
+	header := func(name, description string, required bool) map[string]any {
+		return map[string]any{
+			"name":        name,
+			"in":          "header",
+			"required":    required,
+			"description": description,
+			"schema":      map[string]any{"type": "string"},
+		}
+	}
+	return []map[string]any{
+		header("Signature-Input", "RFC 9421 signature parameters; keyid is the caller's DID", true),
+		header("Signature", "RFC 9421 signature over the covered components", true),
+		header("Content-Digest", "RFC 9530 digest of the request body (sha-256 or sha-512)", true),
+		header(protocol.ExtensionsHeader, "Comma-separated extension URIs to activate, covered by the signature", false),
+		header(protocol.PriorityHeader, "Request priority hint, covered by the signature", false),
+		header(protocol.DeadlineHeader, "Request deadline hint, covered by the signature", false),
+	}
+}
+
+// GenerateJSONSchema returns the standalone JSON Schema (draft 2020-12) of
+// a component of the document generated for card and cfg, such as
+// "MessageSendRequest" or "ExtensionPayload".
+func GenerateJSONSchema(card *a2a.AgentCard, cfg OpenAPIConfig, name string) (map[string]any, error) {
+	methods, err := selectMethods(card, cfg)
+	if err != nil {
+		return nil, err
+	}
+	g := newSchemaGen("#/$defs/")
+	defineMethods(g, methods)
+	if _, ok := g.defs[name]; !ok {
+		return nil, fmt.Errorf("unknown schema: %s", name)
+	}
+	return map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref":    g.prefix + name,
+		"$defs":   g.defs,
+	}, nil
+}
+
+// NewOpenAPIHandler serves the OpenAPI document for card, typically mounted
+// at DefaultOpenAPIPath. With ?schema=<name> it serves the standalone JSON
+// Schema of that component instead. Documents are generated once.
+func NewOpenAPIHandler(card *a2a.AgentCard, cfg OpenAPIConfig) (http.Handler, error) {
+	doc, err := GenerateOpenAPI(card, cfg)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	var mu sync.Mutex
+	schemas := map[string][]byte{}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name := r.URL.Query().Get("schema")
+		if name == "" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(body)
+			return
+		}
+
+		mu.Lock()
+		schema, ok := schemas[name]
+		if !ok {
+			s, err := GenerateJSONSchema(card, cfg, name)
+			if err == nil {
+				schema, err = json.Marshal(s)
+			}
+			if err != nil {
+				mu.Unlock()
+				http.NotFound(w, r)
+				return
+			}
+			schemas[name] = schema
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/schema+json")
+		_, _ = w.Write(schema)
+	}), nil
+}
+
+// schemaGen generates JSON Schemas from Go types via their JSON encoding.
+// Named structs are emitted once into defs and referenced by prefix+name.
+type schemaGen struct {
+	prefix string
+	defs   map[string]any
+}
+
+func newSchemaGen(prefix string) *schemaGen {
+	return &schemaGen{prefix: prefix, defs: map[string]any{}}
+}
+
+func (g *schemaGen) ref(name string) map[string]any {
+	return map[string]any{"$ref": g.prefix + name}
+}
+
+// partKinds are the "kind" discriminators added by the part MarshalJSON methods
+var partKinds = map[reflect.Type]string{
+	typeOf[a2a.TextPart](): "text",
+	typeOf[a2a.FilePart](): "file",
+	typeOf[a2a.DataPart](): "data",
+}
+
+// stringEnums lists the values of string types with a closed set of values
+var stringEnums = map[reflect.Type][]string{
+	typeOf[a2a.TaskState](): {
+		string(a2a.TaskStateSubmitted), string(a2a.TaskStateWorking),
+		string(a2a.TaskStateInputRequired), string(a2a.TaskStateAuthRequired),
+		string(a2a.TaskStateCompleted), string(a2a.TaskStateCanceled),
+		string(a2a.TaskStateFailed), string(a2a.TaskStateRejected),
+		string(a2a.TaskStateUnknown),
+	},
+	typeOf[a2a.MessageRole](): {string(a2a.MessageRoleUser), string(a2a.MessageRoleAgent)},
+}
+
+func (g *schemaGen) schema(t reflect.Type) map[string]any {
+	switch t {
+	case typeOf[time.Time]():
+		return map[string]any{"type": "string", "format": "date-time"}
+	case typeOf[a2a.Part]():
+		return oneOf(g.schema(typeOf[a2a.TextPart]()), g.schema(typeOf[a2a.FilePart]()), g.schema(typeOf[a2a.DataPart]()))
+	case typeOf[a2a.FilePartContent]():
+		return oneOf(g.schema(typeOf[a2a.FileBytes]()), g.schema(typeOf[a2a.FileURI]()))
+	}
+	if values, ok := stringEnums[t]; ok {
+		return map[string]any{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // placeholder for recursive types
+			g.defs[t.Name()] = g.object(t)
+		}
+		return g.ref(t.Name())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return map[string]any{"type": "object"}
+		}
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		// Interfaces without a known set of implementations accept any value
+		return map[string]any{}
+	}
+}
+
+// object generates the schema of a struct, following encoding/json field rules
+func (g *schemaGen) object(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	g.fields(t, props, &required)
+	if kind, ok := partKinds[t]; ok {
+		props["kind"] = map[string]any{"const": kind}
+		required = append([]string{"kind"}, required...)
+	}
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *schemaGen) fields(t reflect.Type, props map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(ft, props, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = g.schema(f.Type)
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openAPICard() *a2a.AgentCard {
+	return &a2a.AgentCard{
+		Name:    "planner",
+		Version: "2.1.0",
+		URL:     "https://planner.example.com",
+		Capabilities: a2a.AgentCapabilities{
+			Streaming: true,
+			Extensions: []a2a.AgentExtension{
+				{URI: protocol.DelegationExtensionURI, Required: true},
+			},
+		},
+	}
+}
+
+// roundTrip encodes v as JSON and decodes it generically
+func roundTrip(t *testing.T, v any) map[string]any {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(raw, &out))
+	return out
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	generated, err := GenerateOpenAPI(openAPICard(), OpenAPIConfig{})
+	require.NoError(t, err)
+	doc := roundTrip(t, generated)
+
+	assert.Equal(t, "3.1.0", doc["openapi"])
+	assert.Equal(t, "planner", doc["info"].(map[string]any)["title"])
+	assert.Equal(t, "https://planner.example.com", doc["servers"].([]any)[0].(map[string]any)["url"])
+
+	post := doc["paths"].(map[string]any)["/rpc"].(map[string]any)["post"].(map[string]any)
+	methods := post["x-jsonrpc-methods"].([]any)
+	assert.Contains(t, methods, "message/stream")
+	assert.NotContains(t, methods, "tasks/pushNotificationConfig/set", "push is not advertised")
+
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	req := schemas["MessageSendRequest"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "message/send", req["method"].(map[string]any)["const"])
+	assert.Equal(t, "#/components/schemas/MessageSendParams", req["params"].(map[string]any)["$ref"])
+
+	text := schemas["TextPart"].(map[string]any)
+	assert.Equal(t, "text", text["properties"].(map[string]any)["kind"].(map[string]any)["const"])
+	assert.ElementsMatch(t, []any{"kind", "text"}, text["required"])
+
+	fileBytes := schemas["FileBytes"].(map[string]any)["properties"].(map[string]any)
+	assert.Contains(t, fileBytes, "mimeType", "embedded fields are flattened")
+	assert.Contains(t, schemas, "ExtensionPayload")
+
+	exts := doc["x-a2a-extensions"].([]any)
+	require.Len(t, exts, 1)
+	assert.Equal(t, protocol.DelegationExtensionURI, exts[0].(map[string]any)["uri"])
+}
+
+func TestGenerateOpenAPI_Config(t *testing.T) {
+	doc, err := GenerateOpenAPI(nil, OpenAPIConfig{
+		Title:   "agent",
+		RPCPath: "/a2a",
+		Methods: []string{"tasks/get", "tasks/pushNotificationConfig/delete"},
+	})
+	require.NoError(t, err)
+	out := roundTrip(t, doc)
+	post := out["paths"].(map[string]any)["/a2a"].(map[string]any)["post"].(map[string]any)
+	assert.Equal(t, []any{"tasks/get", "tasks/pushNotificationConfig/delete"}, post["x-jsonrpc-methods"])
+
+	_, err = GenerateOpenAPI(nil, OpenAPIConfig{Title: "agent", Methods: []string{"tasks/unknown"}})
+	assert.Error(t, err)
+	_, err = GenerateOpenAPI(nil, OpenAPIConfig{})
+	assert.Error(t, err, "title is required without a card")
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	h, err := NewOpenAPIHandler(openAPICard(), OpenAPIConfig{})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultOpenAPIPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultOpenAPIPath+"?schema=TasksGetResponse", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &schema))
+	assert.Equal(t, "#/$defs/TasksGetResponse", schema["$ref"])
+	assert.Contains(t, schema["$defs"], "Task")
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultOpenAPIPath+"?schema=Nope", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DefaultOpenAPIPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// OptionalPolicy decides per request whether an unsigned request may pass
+// as in optional mode. It only sees requests without signature headers;
+// signed requests are always verified.
+type OptionalPolicy func(r *http.Request) bool
+
+// SetOptionalPolicy makes optional mode a per-request decision of policy,
+// e.g. unsigned requests only from internal networks or only for public
+// paths, instead of the all-or-nothing SetOptional. Unsigned requests the
+// policy rejects fail as in required mode. Pass nil to require signatures
+// on every request.
+func (m *DIDAuthMiddleware) SetOptionalPolicy(policy OptionalPolicy) {
+	m.update(func(c *middlewareConfig) {
+		c.optional = false
+		c.optionalPolicy = policy
+	})
+}
+
+// allowUnsigned reports whether the unsigned request r may pass
+func (m *middlewareConfig) allowUnsigned(r *http.Request) bool {
+	if m.optionalPolicy != nil {
+		return m.optionalPolicy(r)
+	}
+	return m.optional
+}
+
+// OptionalForNetworks allows unsigned requests from clients in prefixes.
+// The client address is taken from RemoteAddr; forwarding headers are not
+// trusted.
+func OptionalForNetworks(prefixes ...netip.Prefix) OptionalPolicy {
+	return func(r *http.Request) bool {
+		addr, ok := remoteAddr(r)
+		if !ok {
+			return false
+		}
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OptionalForPrivateNetworks allows unsigned requests from private
+// (RFC 1918 and RFC 4193) and loopback addresses
+func OptionalForPrivateNetworks() OptionalPolicy {
+	return func(r *http.Request) bool {
+		addr, ok := remoteAddr(r)
+		return ok && (addr.IsPrivate() || addr.IsLoopback())
+	}
+}
+
+// OptionalForPaths allows unsigned requests to each of prefixes and the
+// paths below it: "/public" matches "/public" and "/public/docs" but not
+// "/publicity"
+func OptionalForPaths(prefixes ...string) OptionalPolicy {
+	return func(r *http.Request) bool {
+		for _, prefix := range prefixes {
+			prefix = strings.TrimSuffix(prefix, "/")
+			if r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OptionalAll allows unsigned requests every one of policies allows
+func OptionalAll(policies ...OptionalPolicy) OptionalPolicy {
+	return func(r *http.Request) bool {
+		for _, policy := range policies {
+			if !policy(r) {
+				return false
+			}
+		}
+		return len(policies) > 0
+	}
+}
+
+// OptionalAny allows unsigned requests any of policies allows
+func OptionalAny(policies ...OptionalPolicy) OptionalPolicy {
+	return func(r *http.Request) bool {
+		for _, policy := range policies {
+			if policy(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// remoteAddr parses the client address of r
+func remoteAddr(r *http.Request) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(remoteIP(r))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
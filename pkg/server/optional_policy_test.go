@@ -0,0 +1,89 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDIDAuthMiddleware_OptionalPolicy(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: false})
+	middleware.SetOptionalPolicy(OptionalAny(OptionalForPrivateNetworks(), OptionalForPaths("/public")))
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	status := func(req *http.Request) int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	unsigned := func(path, remoteAddr string) *http.Request {
+		req := httptest.NewRequest("POST", path, nil)
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, status(unsigned("/rpc", "192.0.2.1:4000")))
+	assert.Equal(t, http.StatusOK, status(unsigned("/public/card", "192.0.2.1:4000")))
+	assert.Equal(t, http.StatusOK, status(unsigned("/rpc", "10.1.2.3:4000")))
+	assert.Equal(t, http.StatusOK, status(unsigned("/rpc", "[::1]:4000")))
+
+	// Signed requests are verified whatever the policy says
+	req := signedRequest(`{}`)
+	req.RemoteAddr = "10.1.2.3:4000"
+	assert.Equal(t, http.StatusUnauthorized, status(req))
+
+	// SetOptional replaces the policy
+	middleware.SetOptional(true)
+	assert.Equal(t, http.StatusOK, status(unsigned("/rpc", "192.0.2.1:4000")))
+	middleware.SetOptionalPolicy(nil)
+	assert.Equal(t, http.StatusUnauthorized, status(unsigned("/rpc", "10.1.2.3:4000")))
+}
+
+func TestOptionalPolicies(t *testing.T) {
+	request := func(path, remoteAddr string) *http.Request {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	networks := OptionalForNetworks(netip.MustParsePrefix("203.0.113.0/24"))
+	assert.True(t, networks(request("/", "203.0.113.7:80")))
+	assert.True(t, networks(request("/", "[::ffff:203.0.113.7]:80")))
+	assert.False(t, networks(request("/", "198.51.100.1:80")))
+	assert.False(t, networks(request("/", "not-an-address")))
+
+	private := OptionalForPrivateNetworks()
+	assert.True(t, private(request("/", "172.16.0.1:80")))
+	assert.True(t, private(request("/", "[fd00::1]:80")))
+	assert.False(t, private(request("/", "8.8.8.8:80")))
+
+	paths := OptionalForPaths("/public", "/health/")
+	assert.True(t, paths(request("/public", "")))
+	assert.True(t, paths(request("/public/docs", "")))
+	assert.True(t, paths(request("/health", "")))
+	assert.False(t, paths(request("/publicity", "")))
+
+	assert.True(t, OptionalAll(private, paths)(request("/public", "10.0.0.1:80")))
+	assert.False(t, OptionalAll(private, paths)(request("/rpc", "10.0.0.1:80")))
+	assert.False(t, OptionalAll()(request("/", "10.0.0.1:80")))
+	assert.False(t, OptionalAny()(request("/", "10.0.0.1:80")))
+}
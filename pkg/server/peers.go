@@ -0,0 +1,146 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// PeerExchange serves agents/listPeers (protocol.ListPeersMethod) from the
+// peers added to it, so agents can discover each other without a central
+// registry. Other requests are passed on.
+//
+// It must be placed inside DIDAuthMiddleware: only verified callers may
+// list peers, and a caller is never listed to itself.
+//
+//	peers := server.NewPeerExchange()
+//	handler := server.Chain(auth.Wrap, peers.Wrap)(rpcHandler)
+type PeerExchange struct {
+	mu    sync.RWMutex
+	peers map[did.AgentDID]*a2a.AgentCard
+}
+
+// NewPeerExchange creates an empty peer exchange
+func NewPeerExchange() *PeerExchange {
+	return &PeerExchange{peers: make(map[did.AgentDID]*a2a.AgentCard)}
+}
+
+// Add adds or replaces a peer. Its card should be signed by its DID, as
+// crawlers drop peers whose card does not verify.
+func (x *PeerExchange) Add(peer protocol.Peer) error {
+	if peer.DID == "" || peer.Card == nil {
+		return fmt.Errorf("peer needs a DID and a card")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.peers[peer.DID] = peer.Card
+	return nil
+}
+
+// Remove removes the peer with agentDID
+func (x *PeerExchange) Remove(agentDID did.AgentDID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.peers, agentDID)
+}
+
+// Peers returns the known peers ordered by DID
+func (x *PeerExchange) Peers() []protocol.Peer {
+	x.mu.RLock()
+	out := make([]protocol.Peer, 0, len(x.peers))
+	for agentDID, card := range x.peers {
+		out = append(out, protocol.Peer{DID: agentDID, Card: card})
+	}
+	x.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].DID < out[j].DID })
+	return out
+}
+
+// list returns up to limit peers other than caller
+func (x *PeerExchange) list(caller did.AgentDID, limit int) []protocol.Peer {
+	if limit <= 0 || limit > protocol.MaxListPeers {
+		limit = protocol.MaxListPeers
+	}
+	peers := make([]protocol.Peer, 0, limit)
+	for _, peer := range x.Peers() {
+		if len(peers) == limit {
+			break
+		}
+		if peer.DID != caller {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+// Wrap wraps an HTTP handler with the peer exchange method
+func (x *PeerExchange) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var req struct {
+			ID     any             `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if json.Unmarshal(body, &req) != nil || req.Method != protocol.ListPeersMethod {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var params protocol.ListPeersParams
+		if len(req.Params) > 0 && json.Unmarshal(req.Params, &params) != nil {
+			writeError(w, http.StatusBadRequest, protocol.ErrorBody{
+				Code:    protocol.ErrorCodeInvalidRequest,
+				Message: "Bad Request: invalid " + protocol.ListPeersMethod + " params",
+			})
+			return
+		}
+
+		caller, ok := GetAgentDIDFromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, protocol.ErrorBody{
+				Code:    protocol.ErrorCodeUnauthenticated,
+				Message: "Unauthorized: " + protocol.ListPeersMethod + " requires a signed request",
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  protocol.ListPeersResult{Peers: x.list(caller, params.Limit)},
+		})
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerExchange(t *testing.T) {
+	peers := NewPeerExchange()
+	require.Error(t, peers.Add(protocol.Peer{DID: "did:sage:ethereum:0xnocard"}))
+	for _, id := range []string{"0xabc", "0xdef", "0x123"} {
+		require.NoError(t, peers.Add(protocol.Peer{DID: did.AgentDID("did:sage:ethereum:" + id), Card: &a2a.AgentCard{Name: id}}))
+	}
+	peers.Remove("did:sage:ethereum:0x123")
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	var passed bool
+	handler := middleware.Wrap(peers.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		passed = true
+	})))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(`{"jsonrpc":"2.0","id":7,"method":"agents/listPeers","params":{}}`))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, passed)
+	var resp struct {
+		ID     int                      `json:"id"`
+		Result protocol.ListPeersResult `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, 7, resp.ID)
+	require.Len(t, resp.Result.Peers, 1, "the caller is not listed to itself")
+	assert.Equal(t, "0xdef", resp.Result.Peers[0].Card.Name)
+
+	// Other methods are passed on
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(`{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`))
+	assert.True(t, passed)
+
+	// Unverified callers cannot list peers
+	rr = httptest.NewRecorder()
+	peers.Wrap(http.NotFoundHandler()).ServeHTTP(rr, httptest.NewRequest("POST", "/rpc",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"agents/listPeers"}`)))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Precheck defaults
+const (
+	DefaultPrecheckMaxAge    = 5 * time.Minute
+	DefaultPrecheckClockSkew = 30 * time.Second
+)
+
+var (
+	// ErrMalformedSignature is returned when the signature headers cannot
+	// be parsed or do not match each other
+	ErrMalformedSignature = errors.New("malformed signature headers")
+
+	// ErrInvalidKeyID is returned when the signature keyid is not a DID
+	ErrInvalidKeyID = errors.New("signature keyid is not a valid DID")
+
+	// ErrSignatureTimestamp is returned when a signature was created too
+	// long ago or in the future, or has expired
+	ErrSignatureTimestamp = errors.New("signature timestamp outside accepted window")
+
+	// ErrDIDDenied is returned for requests signed as a denied DID
+	ErrDIDDenied = errors.New("agent DID is denied")
+)
+
+// VerificationStage names a step of the verification pipeline
+type VerificationStage string
+
+// Verification stages, in the order they run
+const (
+	StageHeaders   VerificationStage = "headers"   // signature headers present and well formed
+	StageDID       VerificationStage = "did"       // keyid is a syntactically valid DID
+	StageTimestamp VerificationStage = "timestamp" // created and expires within the window
+	StageDenylist  VerificationStage = "denylist"  // keyid is not denied
+	StageReplay    VerificationStage = "replay"    // signature not seen before
+	StageSignature VerificationStage = "signature" // key resolution and signature math
+)
+
+// verificationStages lists the stages in pipeline order
+var verificationStages = []VerificationStage{
+	StageHeaders, StageDID, StageTimestamp, StageDenylist, StageReplay, StageSignature,
+}
+
+// didSyntaxRe matches DIDs as defined by W3C DID Core
+var didSyntaxRe = regexp.MustCompile(`^did:[a-z0-9]+:(?:(?:[A-Za-z0-9._-]|%[0-9A-Fa-f]{2})*:)*(?:[A-Za-z0-9._-]|%[0-9A-Fa-f]{2})+$`)
+
+// PrecheckConfig configures the cheap checks run on signed requests before
+// the signer's key is resolved and the signature verified, so obviously
+// invalid or abusive traffic is rejected without chain lookups or
+// signature math
+type PrecheckConfig struct {
+	// MaxAge is how old a signature's created parameter may be (default
+	// DefaultPrecheckMaxAge). It should not be shorter than the verifier's.
+	MaxAge time.Duration
+
+	// ClockSkew is the tolerance for clocks ahead of or behind ours
+	// (default DefaultPrecheckClockSkew)
+	ClockSkew time.Duration
+
+	// Denylist rejects requests signed as its DIDs, if set
+	Denylist *Denylist
+
+	// Metrics counts outcomes and time spent per stage, if set
+	Metrics *StageMetrics
+}
+
+// SetPrecheck runs the checks of cfg on every signed request before
+// signature verification, in order: header syntax, DID syntax of the
+// keyid, timestamp window, denylist and, with replay protection enabled,
+// the replay check. Pass nil to disable prechecks.
+//
+// The replay precheck only looks signatures up, in stores implementing
+// ReplayLookup, so a replayed request costs no key resolution; signatures
+// are recorded once verified, for at most MaxAge plus ClockSkew.
+func (m *DIDAuthMiddleware) SetPrecheck(cfg *PrecheckConfig) {
+	if cfg != nil {
+		c := *cfg
+		if c.MaxAge <= 0 {
+			c.MaxAge = DefaultPrecheckMaxAge
+		}
+		if c.ClockSkew <= 0 {
+			c.ClockSkew = DefaultPrecheckClockSkew
+		}
+		cfg = &c
+	}
+	m.update(func(c *middlewareConfig) {
+		c.prechecks = cfg
+	})
+}
+
+// precheck runs the prechecks on the signed request r, returning the
+// first failure
+func (m *middlewareConfig) precheck(ctx context.Context, r *http.Request) error {
+	cfg := m.prechecks
+	var fp RequestFingerprint
+	parseSignature(r, &fp)
+
+	err := cfg.Metrics.run(StageHeaders, func() error {
+		if fp.Label == "" || !hasSignatureLabel(r.Header.Get("Signature"), fp.Label) {
+			return ErrMalformedSignature
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = cfg.Metrics.run(StageDID, func() error {
+		if !didSyntaxRe.MatchString(fp.KeyID) {
+			return fmt.Errorf("%w: %q", ErrInvalidKeyID, fp.KeyID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = cfg.Metrics.run(StageTimestamp, func() error {
+		now := time.Now()
+		switch {
+		case !fp.Created.IsZero() && fp.Created.After(now.Add(cfg.ClockSkew)):
+			return fmt.Errorf("%w: created in the future", ErrSignatureTimestamp)
+		case !fp.Created.IsZero() && fp.Created.Before(now.Add(-cfg.MaxAge-cfg.ClockSkew)):
+			return fmt.Errorf("%w: created too long ago", ErrSignatureTimestamp)
+		case !fp.Expires.IsZero() && fp.Expires.Before(now.Add(-cfg.ClockSkew)):
+			return fmt.Errorf("%w: expired", ErrSignatureTimestamp)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	agentDID := did.AgentDID(fp.KeyID)
+	if cfg.Denylist != nil {
+		err = cfg.Metrics.run(StageDenylist, func() error {
+			if cfg.Denylist.Contains(agentDID) {
+				return ErrDIDDenied
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Signatures are only recorded once verified, so unauthenticated
+	// clients can neither fill the store nor burn a genuine signer's nonce
+	if m.replay != nil {
+		return cfg.Metrics.run(StageReplay, func() error {
+			return m.lookupReplay(ctx, r, agentDID)
+		})
+	}
+	return nil
+}
+
+// hasSignatureLabel reports whether the Signature header has an entry for
+// label
+func hasSignatureLabel(signature, label string) bool {
+	for _, entry := range strings.Split(signature, ",") {
+		if name, _, ok := strings.Cut(strings.TrimSpace(entry), "="); ok && name == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Denylist is a set of agent DIDs whose requests are rejected before their
+// keys are resolved. It is safe for concurrent use and may be changed
+// while the middleware serves requests.
+type Denylist struct {
+	mu   sync.RWMutex
+	dids map[did.AgentDID]struct{}
+}
+
+// NewDenylist creates a denylist of dids
+func NewDenylist(dids ...did.AgentDID) *Denylist {
+	d := &Denylist{dids: make(map[did.AgentDID]struct{})}
+	d.Add(dids...)
+	return d
+}
+
+// Add denies dids
+func (d *Denylist) Add(dids ...did.AgentDID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, agentDID := range dids {
+		d.dids[agentDID] = struct{}{}
+	}
+}
+
+// Remove stops denying dids
+func (d *Denylist) Remove(dids ...did.AgentDID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, agentDID := range dids {
+		delete(d.dids, agentDID)
+	}
+}
+
+// Contains reports whether agentDID is denied
+func (d *Denylist) Contains(agentDID did.AgentDID) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.dids[agentDID]
+	return ok
+}
+
+// StageStats counts the requests a verification stage passed and rejected
+// and the time spent in it
+type StageStats struct {
+	Stage    VerificationStage
+	Passed   uint64
+	Rejected uint64
+	Duration time.Duration // total time spent in the stage
+}
+
+// StageMetrics counts outcomes per verification stage. It is safe for
+// concurrent use; a nil *StageMetrics counts nothing.
+type StageMetrics struct {
+	stages map[VerificationStage]*stageCounters
+}
+
+type stageCounters struct {
+	passed, rejected atomic.Uint64
+	nanos            atomic.Int64
+}
+
+// NewStageMetrics creates metrics with all counters at zero
+func NewStageMetrics() *StageMetrics {
+	s := &StageMetrics{stages: make(map[VerificationStage]*stageCounters, len(verificationStages))}
+	for _, stage := range verificationStages {
+		s.stages[stage] = &stageCounters{}
+	}
+	return s
+}
+
+// Stats returns the counters of every stage, in pipeline order
+func (s *StageMetrics) Stats() []StageStats {
+	stats := make([]StageStats, 0, len(verificationStages))
+	for _, stage := range verificationStages {
+		c := s.stages[stage]
+		stats = append(stats, StageStats{
+			Stage:    stage,
+			Passed:   c.passed.Load(),
+			Rejected: c.rejected.Load(),
+			Duration: time.Duration(c.nanos.Load()),
+		})
+	}
+	return stats
+}
+
+// run runs check as stage, recording its outcome and duration
+func (s *StageMetrics) run(stage VerificationStage, check func() error) error {
+	if s == nil {
+		return check()
+	}
+	start := time.Now()
+	err := check()
+	s.record(stage, time.Since(start), err)
+	return err
+}
+
+// record records one run of stage
+func (s *StageMetrics) record(stage VerificationStage, elapsed time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	c := s.stages[stage]
+	c.nanos.Add(int64(elapsed))
+	if err != nil {
+		c.rejected.Add(1)
+	} else {
+		c.passed.Add(1)
+	}
+}
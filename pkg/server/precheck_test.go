@@ -0,0 +1,251 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stageCounts returns the passed and rejected counts of stage
+func stageCounts(t *testing.T, metrics *StageMetrics, stage VerificationStage) (uint64, uint64) {
+	t.Helper()
+	for _, s := range metrics.Stats() {
+		if s.Stage == stage {
+			return s.Passed, s.Rejected
+		}
+	}
+	t.Fatalf("no stats for stage %s", stage)
+	return 0, 0
+}
+
+func TestPrecheck(t *testing.T) {
+	middleware, agentDID, keyPair := streamingFixture(t)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	signed := func(signAs did.AgentDID, opts *signer.SigningOptions) *http.Request {
+		req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+		require.NoError(t, signer.NewDefaultA2ASigner().SignRequestWithOptions(context.Background(), req, signAs, keyPair, opts))
+		return req
+	}
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		req    func() *http.Request
+		stage  VerificationStage
+		errMsg string
+	}{
+		{
+			name: "missing headers",
+			req: func() *http.Request {
+				return httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+			},
+			stage:  StageHeaders,
+			errMsg: "missing signature headers",
+		},
+		{
+			name: "label mismatch",
+			req: func() *http.Request {
+				req := signed(agentDID, nil)
+				req.Header.Set("Signature", strings.Replace(req.Header.Get("Signature"), "sig1=", "other=", 1))
+				return req
+			},
+			stage:  StageHeaders,
+			errMsg: ErrMalformedSignature.Error(),
+		},
+		{
+			name:   "keyid not a DID",
+			req:    func() *http.Request { return signed("agent-42", nil) },
+			stage:  StageDID,
+			errMsg: ErrInvalidKeyID.Error(),
+		},
+		{
+			name: "created too long ago",
+			req: func() *http.Request {
+				return signed(agentDID, &signer.SigningOptions{Created: time.Now().Add(-time.Hour).Unix()})
+			},
+			stage:  StageTimestamp,
+			errMsg: ErrSignatureTimestamp.Error(),
+		},
+		{
+			name: "created in the future",
+			req: func() *http.Request {
+				return signed(agentDID, &signer.SigningOptions{Created: time.Now().Add(time.Hour).Unix()})
+			},
+			stage:  StageTimestamp,
+			errMsg: ErrSignatureTimestamp.Error(),
+		},
+		{
+			name:   "denied DID",
+			req:    func() *http.Request { return signed("did:sage:ethereum:0xdenied", nil) },
+			stage:  StageDenylist,
+			errMsg: ErrDIDDenied.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := NewStageMetrics()
+			middleware.SetPrecheck(&PrecheckConfig{
+				Denylist: NewDenylist("did:sage:ethereum:0xdenied"),
+				Metrics:  metrics,
+			})
+
+			rec := serve(tt.req())
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.errMsg)
+
+			_, rejected := stageCounts(t, metrics, tt.stage)
+			assert.Equal(t, uint64(1), rejected)
+			passed, rejected := stageCounts(t, metrics, StageSignature)
+			assert.Zero(t, passed+rejected, "signature must not be verified")
+		})
+	}
+
+	t.Run("valid request", func(t *testing.T) {
+		metrics := NewStageMetrics()
+		middleware.SetPrecheck(&PrecheckConfig{Denylist: NewDenylist(), Metrics: metrics})
+
+		rec := serve(signed(agentDID, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		for _, s := range metrics.Stats() {
+			if s.Stage == StageReplay {
+				assert.Zero(t, s.Passed, "replay protection is off")
+				continue
+			}
+			assert.Equal(t, uint64(1), s.Passed, s.Stage)
+			assert.Zero(t, s.Rejected, s.Stage)
+		}
+	})
+}
+
+func TestPrecheck_Replay(t *testing.T) {
+	middleware, agentDID, keyPair := streamingFixture(t)
+	store := NewMemoryReplayStore()
+	metrics := NewStageMetrics()
+	middleware.SetReplayProtection(&ReplayConfig{Store: store})
+	middleware.SetPrecheck(&PrecheckConfig{Metrics: metrics})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, agentDID, keyPair))
+	replay := req.Clone(context.Background())
+	replay.Body = httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`)).Body
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, replay)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), ErrReplayedRequest.Error())
+
+	passed, rejected := stageCounts(t, metrics, StageReplay)
+	assert.Equal(t, uint64(1), passed)
+	assert.Equal(t, uint64(1), rejected)
+	passed, _ = stageCounts(t, metrics, StageSignature)
+	assert.Equal(t, uint64(1), passed, "the replay is rejected before verification")
+	assert.Equal(t, 1, store.Len(), "the signature is recorded once")
+}
+
+// ttlStore is a MemoryReplayStore recording the TTL of each entry
+type ttlStore struct {
+	*MemoryReplayStore
+	ttls []time.Duration
+}
+
+func (s *ttlStore) CheckAndStore(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.ttls = append(s.ttls, ttl)
+	return s.MemoryReplayStore.CheckAndStore(ctx, key, ttl)
+}
+
+func TestPrecheck_ReplayRecordsVerifiedOnly(t *testing.T) {
+	store := &ttlStore{MemoryReplayStore: NewMemoryReplayStore()}
+	mock := &mockDIDVerifier{extractedDID: "did:sage:ethereum:0xabc"}
+	middleware := NewDIDAuthMiddlewareWithVerifier(mock)
+	middleware.SetReplayProtection(&ReplayConfig{Store: store, TTL: time.Hour})
+	middleware.SetPrecheck(&PrecheckConfig{MaxAge: time.Minute, ClockSkew: time.Second})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	now := time.Now().Unix()
+	req := func() *http.Request {
+		req := signedRequest(`{}`)
+		req.Header.Set("Signature-Input", fmt.Sprintf(`sig1=("@method");keyid="did:sage:ethereum:0xabc";created=%d;expires=%d;nonce="n-1"`, now, now+365*24*3600))
+		return req
+	}
+
+	// Forged signatures are not recorded and do not burn the nonce
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Zero(t, store.Len())
+
+	mock.shouldSucceed = true
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, store.Len())
+	require.Len(t, store.ttls, 1)
+	assert.Equal(t, time.Minute+time.Second, store.ttls[0], "capped at MaxAge plus ClockSkew")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), ErrReplayedRequest.Error())
+	assert.Len(t, store.ttls, 1, "the replay is rejected by the lookup")
+}
+
+func TestDenylist(t *testing.T) {
+	d := NewDenylist("did:sage:ethereum:0xa")
+	assert.True(t, d.Contains("did:sage:ethereum:0xa"))
+	assert.False(t, d.Contains("did:sage:ethereum:0xb"))
+
+	d.Add("did:sage:ethereum:0xb")
+	d.Remove("did:sage:ethereum:0xa")
+	assert.False(t, d.Contains("did:sage:ethereum:0xa"))
+	assert.True(t, d.Contains("did:sage:ethereum:0xb"))
+}
+
+func TestDIDSyntax(t *testing.T) {
+	for _, valid := range []string{
+		"did:sage:ethereum:0x1234",
+		"did:web:example.com%3A8443",
+		"did:key:z6Mk",
+	} {
+		assert.True(t, didSyntaxRe.MatchString(valid), valid)
+	}
+	for _, invalid := range []string{"", "agent", "did:", "did:sage:", "did:SAGE:x", "did:sage:has space", "did:sage:%zz"} {
+		assert.False(t, didSyntaxRe.MatchString(invalid), invalid)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// GetPriorityFromContext returns the signed request priority. Requests
+// without a signed priority report protocol.PriorityNormal and false.
+func GetPriorityFromContext(ctx context.Context) (protocol.Priority, bool) {
+	p, ok := ctx.Value(priorityKey).(protocol.Priority)
+	return p, ok
+}
+
+// GetDeadlineFromContext returns the signed request deadline. The request
+// context is also cancelled when it passes.
+func GetDeadlineFromContext(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(deadlineKey).(time.Time)
+	return t, ok
+}
+
+// GetBudgetFromContext returns the time budget the caller signed for the
+// request, as received. The request context is bounded by it, so handlers
+// calling other agents pass on what remains of it.
+func GetBudgetFromContext(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(budgetKey).(time.Duration)
+	return d, ok
+}
+
+// SetRequestTimeout bounds the context of every verified request by
+// timeout, or by the caller's signed deadline or budget if sooner. Zero
+// removes the limit.
+func (m *DIDAuthMiddleware) SetRequestTimeout(timeout time.Duration) {
+	m.update(func(c *middlewareConfig) {
+		c.requestTimeout = max(timeout, 0)
+	})
+}
+
+// applyHints stores the signed priority, deadline and budget in ctx and
+// bounds ctx by the earliest of the deadline, the budget and limit, if
+// positive. Hint headers not covered by the signature are ignored. It
+// writes an error response and returns ok false for malformed hints or a
+// deadline or budget that has already run out.
+func applyHints(ctx context.Context, w http.ResponseWriter, r *http.Request, limit time.Duration) (context.Context, context.CancelFunc, bool) {
+	covered := signedHeaders(r)
+	now := time.Now()
+
+	if covered(protocol.PriorityHeader) {
+		p, err := protocol.ParsePriority(r.Header.Get(protocol.PriorityHeader))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Bad Request: %s", err.Error()), http.StatusBadRequest)
+			return ctx, nil, false
+		}
+		ctx = context.WithValue(ctx, priorityKey, p)
+	}
+
+	var deadline time.Time
+	if limit > 0 {
+		deadline = now.Add(limit)
+	}
+	if covered(protocol.DeadlineHeader) {
+		d, err := protocol.ParseDeadline(r.Header.Get(protocol.DeadlineHeader))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Bad Request: %s", err.Error()), http.StatusBadRequest)
+			return ctx, nil, false
+		}
+		if !now.Before(d) {
+			http.Error(w, "Gateway Timeout: request deadline exceeded", http.StatusGatewayTimeout)
+			return ctx, nil, false
+		}
+		ctx = context.WithValue(ctx, deadlineKey, d)
+		deadline = earliest(deadline, d)
+	}
+	if covered(protocol.BudgetHeader) {
+		budget, err := protocol.ParseBudget(r.Header.Get(protocol.BudgetHeader))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Bad Request: %s", err.Error()), http.StatusBadRequest)
+			return ctx, nil, false
+		}
+		if budget == 0 {
+			http.Error(w, "Gateway Timeout: request budget exhausted", http.StatusGatewayTimeout)
+			return ctx, nil, false
+		}
+		ctx = context.WithValue(ctx, budgetKey, budget)
+		deadline = earliest(deadline, now.Add(budget))
+	}
+
+	if deadline.IsZero() {
+		return ctx, func() {}, true
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return ctx, cancel, true
+}
+
+// earliest returns the earlier of a and b, treating the zero time as
+// unbounded
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// signedHeaders returns a predicate reporting whether a header is present
+// and covered by the request signature
+func signedHeaders(r *http.Request) func(header string) bool {
+	var fp RequestFingerprint
+	parseSignature(r, &fp)
+	return func(header string) bool {
+		return r.Header.Get(header) != "" && slices.Contains(fp.Components, strings.ToLower(header))
+	}
+}
+
+// lowPriority reports whether the request asks for low priority. The header
+// is read before verification, which is safe because it can only lower the
+// request's standing.
+func lowPriority(r *http.Request) bool {
+	p, err := protocol.ParsePriority(r.Header.Get(protocol.PriorityHeader))
+	return err == nil && p == protocol.PriorityLow
+}
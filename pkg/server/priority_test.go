@@ -0,0 +1,196 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hintedRequest(priority, deadline string, covered bool) *http.Request {
+	req := signedRequest(`{"jsonrpc":"2.0","id":1,"method":"message/send"}`)
+	components := `"@method"`
+	if covered {
+		components += ` "a2a-priority" "a2a-deadline"`
+	}
+	req.Header.Set("Signature-Input", `sig1=(`+components+`);keyid="did:sage:ethereum:0xabc"`)
+	if priority != "" {
+		req.Header.Set(protocol.PriorityHeader, priority)
+	}
+	if deadline != "" {
+		req.Header.Set(protocol.DeadlineHeader, deadline)
+	}
+	return req
+}
+
+func TestDIDAuthMiddleware_RequestHints(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+
+	var (
+		priority       protocol.Priority
+		hasPriority    bool
+		deadline       time.Time
+		hasDeadline    bool
+		ctxDeadline    time.Time
+		hasCtxDeadline bool
+	)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		priority, hasPriority = GetPriorityFromContext(r.Context())
+		deadline, hasDeadline = GetDeadlineFromContext(r.Context())
+		ctxDeadline, hasCtxDeadline = r.Context().Deadline()
+	}))
+
+	due := time.Now().Add(time.Minute).UTC().Truncate(time.Millisecond)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, hintedRequest("critical", protocol.FormatDeadline(due), true))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, hasPriority)
+	assert.Equal(t, protocol.PriorityCritical, priority)
+	assert.True(t, hasDeadline)
+	assert.True(t, due.Equal(deadline))
+	assert.True(t, hasCtxDeadline)
+	assert.True(t, due.Equal(ctxDeadline))
+
+	// Headers outside the signature are ignored
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, hintedRequest("critical", protocol.FormatDeadline(due), false))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, hasPriority)
+	assert.False(t, hasDeadline)
+	assert.False(t, hasCtxDeadline)
+}
+
+func TestDIDAuthMiddleware_RequestHints_Rejected(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler must not run")
+	}))
+
+	tests := []struct {
+		name     string
+		priority string
+		deadline string
+		status   int
+	}{
+		{"bad priority", "urgent", "", http.StatusBadRequest},
+		{"bad deadline", "", "soon", http.StatusBadRequest},
+		{"expired", "", protocol.FormatDeadline(time.Now().Add(-time.Second)), http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, hintedRequest(tt.priority, tt.deadline, true))
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestDIDAuthMiddleware_RequestBudget(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	middleware.SetRequestTimeout(10 * time.Second)
+
+	var (
+		budget      time.Duration
+		hasBudget   bool
+		ctxDeadline time.Time
+	)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		budget, hasBudget = GetBudgetFromContext(r.Context())
+		ctxDeadline, _ = r.Context().Deadline()
+	}))
+	serve := func(budget string, covered bool) int {
+		req := signedRequest(`{"jsonrpc":"2.0","id":1,"method":"message/send"}`)
+		if covered {
+			req.Header.Set("Signature-Input", `sig1=("@method" "x-request-budget");keyid="did:sage:ethereum:0xabc"`)
+		}
+		req.Header.Set(protocol.BudgetHeader, budget)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	remaining := func() time.Duration {
+		return time.Until(ctxDeadline)
+	}
+
+	// The caller's budget wins when it is shorter than the own limit
+	require.Equal(t, http.StatusOK, serve("2000", true))
+	assert.True(t, hasBudget)
+	assert.Equal(t, 2*time.Second, budget)
+	assert.True(t, remaining() > time.Second && remaining() <= 2*time.Second, "remaining %s", remaining())
+
+	// and the own limit when it is shorter than the budget
+	require.Equal(t, http.StatusOK, serve("60000", true))
+	assert.True(t, remaining() > 9*time.Second && remaining() <= 10*time.Second, "remaining %s", remaining())
+
+	// Budgets outside the signature are ignored
+	require.Equal(t, http.StatusOK, serve("2000", false))
+	assert.False(t, hasBudget)
+	assert.True(t, remaining() > 9*time.Second, "remaining %s", remaining())
+
+	// The earliest of deadline and budget applies
+	req := hintedRequest("", protocol.FormatDeadline(time.Now().Add(time.Second)), true)
+	req.Header.Set("Signature-Input", `sig1=("@method" "a2a-deadline" "x-request-budget");keyid="did:sage:ethereum:0xabc"`)
+	req.Header.Set(protocol.BudgetHeader, "5000")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, remaining() <= time.Second, "remaining %s", remaining())
+
+	// Exhausted and malformed budgets are rejected
+	assert.Equal(t, http.StatusGatewayTimeout, serve("0", true))
+	assert.Equal(t, http.StatusBadRequest, serve("soon", true))
+}
+
+func TestVerificationPool_LowPriorityShedsImmediately(t *testing.T) {
+	v := &blockingVerifier{started: make(chan struct{}, 1), release: make(chan struct{})}
+	pool := NewVerificationPool(WorkerPoolConfig{Workers: 1, QueueSize: 1, QueueTimeout: time.Minute})
+	defer pool.Close()
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(v)
+	middleware.SetVerificationPool(pool)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// Occupy the worker and the queue slot
+	done := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			handler.ServeHTTP(httptest.NewRecorder(), signedRequest(`{}`))
+			done <- struct{}{}
+		}()
+	}
+	<-v.started
+	require.Eventually(t, func() bool { return pool.Stats().Queued == 1 }, time.Second, time.Millisecond)
+
+	start := time.Now()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, hintedRequest("low", "", false).WithContext(context.Background()))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Less(t, time.Since(start), time.Second)
+
+	close(v.release)
+	<-done
+	<-v.started
+	<-done
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AuditProbeBypass is a health probe admitted without a signature
+const AuditProbeBypass AuditEventType = "probe_bypass"
+
+// ProbeBypass admits load balancer health checks to signed-only endpoints
+// without an RFC 9421 signature. A probe must hit one of Paths with one of
+// Methods and authenticate with the bearer token, a verified mTLS client
+// certificate, or both when both are configured.
+type ProbeBypass struct {
+	// Paths lists the exact request paths probes may reach, e.g. "/healthz".
+	// The bypass never applies when empty.
+	Paths []string
+
+	// Methods lists the accepted methods (default GET and HEAD)
+	Methods []string
+
+	// Token is a static bearer token expected in the Authorization header.
+	// Empty disables token authentication.
+	Token string
+
+	// RequireMTLS requires a client certificate verified by the TLS layer
+	RequireMTLS bool
+
+	// ClientNames, when set with RequireMTLS, restricts accepted client
+	// certificates to those whose common name, DNS name or URI SAN matches
+	ClientNames []string
+}
+
+// SetProbeBypass lets health probes matching cfg skip signature
+// verification. Every bypass is audited as AuditProbeBypass. The handler
+// runs without an agent DID in the context; IsProbeRequest reports probes.
+func (m *DIDAuthMiddleware) SetProbeBypass(cfg *ProbeBypass) {
+	m.update(func(c *middlewareConfig) {
+		c.probe = cfg
+	})
+}
+
+// IsProbeRequest reports whether the request was admitted as a health probe
+func IsProbeRequest(ctx context.Context) bool {
+	probe, _ := ctx.Value(probeKey).(bool)
+	return probe
+}
+
+// matches reports whether r is an authenticated probe
+func (p *ProbeBypass) matches(r *http.Request) bool {
+	if p.Token == "" && !p.RequireMTLS {
+		return false
+	}
+	if !slices.Contains(p.Paths, r.URL.Path) {
+		return false
+	}
+	methods := p.Methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+	if !slices.Contains(methods, r.Method) {
+		return false
+	}
+	if p.RequireMTLS && !p.clientAllowed(r) {
+		return false
+	}
+	if p.Token != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			return false
+		}
+		// Compare digests so the comparison does not leak the token length
+		got, want := sha256.Sum256([]byte(token)), sha256.Sum256([]byte(p.Token))
+		if subtle.ConstantTimeCompare(got[:], want[:]) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// clientAllowed reports whether r presents an accepted verified client
+// certificate
+func (p *ProbeBypass) clientAllowed(r *http.Request) bool {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return false
+	}
+	if len(p.ClientNames) == 0 {
+		return true
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+	names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	for _, name := range names {
+		if name != "" && slices.Contains(p.ClientNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// serveProbe admits a health probe without signature verification
+func (m *middlewareConfig) serveProbe(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if m.auditLogger != nil {
+		m.auditLogger.LogAudit(AuditEvent{
+			Time:     time.Now(),
+			Type:     AuditProbeBypass,
+			RemoteIP: remoteIP(r),
+			Method:   r.Method,
+			Path:     r.URL.Path,
+		})
+	}
+	next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), probeKey, true)))
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func probeRequest(method, path, token string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestDIDAuthMiddleware_ProbeBypassToken(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: false})
+	middleware.SetProbeBypass(&ProbeBypass{Paths: []string{"/healthz"}, Token: "s3cret"})
+	var events []AuditEvent
+	middleware.SetAuditLogger(AuditLoggerFunc(func(e AuditEvent) { events = append(events, e) }))
+
+	var probe, hasDID bool
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probe = IsProbeRequest(r.Context())
+		_, hasDID = GetAgentDIDFromContext(r.Context())
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, probeRequest(http.MethodGet, "/healthz", "s3cret"))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, probe)
+	assert.False(t, hasDID)
+	require.Len(t, events, 1)
+	assert.Equal(t, AuditProbeBypass, events[0].Type)
+	assert.Equal(t, "/healthz", events[0].Path)
+
+	tests := []struct {
+		name string
+		req  *http.Request
+	}{
+		{"wrong token", probeRequest(http.MethodGet, "/healthz", "guess")},
+		{"no token", probeRequest(http.MethodGet, "/healthz", "")},
+		{"other path", probeRequest(http.MethodGet, "/rpc", "s3cret")},
+		{"other method", probeRequest(http.MethodPost, "/healthz", "s3cret")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, tt.req)
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		})
+	}
+}
+
+func TestDIDAuthMiddleware_ProbeBypassMTLS(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: false})
+	middleware.SetProbeBypass(&ProbeBypass{
+		Paths:       []string{"/healthz"},
+		RequireMTLS: true,
+		ClientNames: []string{"spiffe://cluster.local/ns/infra/sa/lb"},
+	})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, withSVID(probeRequest(http.MethodHead, "/healthz", ""), "spiffe://cluster.local/ns/infra/sa/lb"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, withSVID(probeRequest(http.MethodHead, "/healthz", ""), "spiffe://cluster.local/ns/a/sa/agent"))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, probeRequest(http.MethodHead, "/healthz", ""))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestProbeBypass_RequiresCredentials(t *testing.T) {
+	p := &ProbeBypass{Paths: []string{"/healthz"}}
+	assert.False(t, p.matches(probeRequest(http.MethodGet, "/healthz", "")))
+}
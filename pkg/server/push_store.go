@@ -0,0 +1,451 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// DefaultPushMaxFailures is the number of consecutive failed deliveries
+// after which a push endpoint is disabled, when PushStoreConfig.MaxFailures
+// is zero
+const DefaultPushMaxFailures = 5
+
+// PushTokenHeader carries PushConfig.Token on push notification requests
+const PushTokenHeader = "X-A2A-Notification-Token"
+
+// ErrPushConfigNotFound is returned for unknown task/config pairs
+var ErrPushConfigNotFound = errors.New("push notification config not found")
+
+// ErrPushNotifierClosed is returned by SendPush after Shutdown
+var ErrPushNotifierClosed = errors.New("push notifier closed")
+
+// PushStoreConfig configures a PushConfigStore
+type PushStoreConfig struct {
+	// MaxFailures is the number of consecutive failed deliveries after which
+	// an endpoint is disabled (default DefaultPushMaxFailures, negative
+	// never disables)
+	MaxFailures int
+
+	// Table is the table used by SQLPushConfigStore (default "a2a_push_configs")
+	Table string
+
+	// Placeholder formats query parameters for SQLPushConfigStore
+	// (default QuestionPlaceholder)
+	Placeholder SQLPlaceholder
+}
+
+func (c PushStoreConfig) maxFailures() int {
+	if c.MaxFailures == 0 {
+		return DefaultPushMaxFailures
+	}
+	return c.MaxFailures
+}
+
+// PushDelivery is a push notification config with its delivery state
+type PushDelivery struct {
+	TaskID              a2a.TaskID     `json:"taskId"`
+	Config              a2a.PushConfig `json:"config"`
+	Attempts            int            `json:"attempts"`
+	Failures            int            `json:"failures"`
+	ConsecutiveFailures int            `json:"consecutiveFailures"`
+	LastAttempt         time.Time      `json:"lastAttempt,omitzero"`
+	LastSuccess         time.Time      `json:"lastSuccess,omitzero"`
+	LastError           string         `json:"lastError,omitempty"`
+	Disabled            bool           `json:"disabled"`
+}
+
+// record applies the outcome of a delivery attempt at now
+func (d *PushDelivery) record(now time.Time, deliveryErr error, maxFailures int) {
+	d.Attempts++
+	d.LastAttempt = now
+	if deliveryErr == nil {
+		d.ConsecutiveFailures = 0
+		d.LastSuccess = now
+		d.LastError = ""
+		return
+	}
+	d.Failures++
+	d.ConsecutiveFailures++
+	d.LastError = deliveryErr.Error()
+	if maxFailures > 0 && d.ConsecutiveFailures >= maxFailures {
+		d.Disabled = true
+	}
+}
+
+// PushConfigStore persists push notification configs together with their
+// delivery state. Get returns only enabled configs, so notifiers skip
+// endpoints disabled after repeated failures; Deliveries lists all of them.
+type PushConfigStore interface {
+	a2asrv.PushConfigStore
+
+	// RecordDelivery records the outcome of a delivery attempt, a nil err
+	// meaning success, and returns the updated state
+	RecordDelivery(ctx context.Context, taskID a2a.TaskID, configID string, err error) (*PushDelivery, error)
+
+	// Deliveries returns the delivery state of every config, or of the
+	// configs of taskID when it is set
+	Deliveries(ctx context.Context, taskID a2a.TaskID) ([]*PushDelivery, error)
+
+	// Reset clears the failure count of a config and re-enables it
+	Reset(ctx context.Context, taskID a2a.TaskID, configID string) error
+}
+
+// pushConfigID returns the ID a config is stored under. Configs without an
+// ID use the task ID, as in the A2A specification.
+func pushConfigID(taskID a2a.TaskID, config *a2a.PushConfig) string {
+	if config.ID != "" {
+		return config.ID
+	}
+	return string(taskID)
+}
+
+type pushKey struct {
+	taskID   a2a.TaskID
+	configID string
+}
+
+// MemoryPushConfigStore is a PushConfigStore keeping configs in memory
+type MemoryPushConfigStore struct {
+	config PushStoreConfig
+	now    func() time.Time
+
+	mu         sync.Mutex
+	deliveries map[pushKey]*PushDelivery
+}
+
+// NewMemoryPushConfigStore creates an empty in-memory push config store
+func NewMemoryPushConfigStore(config PushStoreConfig) *MemoryPushConfigStore {
+	return &MemoryPushConfigStore{
+		config:     config,
+		now:        time.Now,
+		deliveries: make(map[pushKey]*PushDelivery),
+	}
+}
+
+// Save implements a2asrv.PushConfigStore. Saving an existing config keeps
+// its delivery state.
+func (s *MemoryPushConfigStore) Save(ctx context.Context, taskID a2a.TaskID, config *a2a.PushConfig) error {
+	cfg := *config
+	cfg.ID = pushConfigID(taskID, config)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := pushKey{taskID, cfg.ID}
+	if d, ok := s.deliveries[key]; ok {
+		d.Config = cfg
+		return nil
+	}
+	s.deliveries[key] = &PushDelivery{TaskID: taskID, Config: cfg}
+	return nil
+}
+
+// Get implements a2asrv.PushConfigStore, omitting disabled configs
+func (s *MemoryPushConfigStore) Get(ctx context.Context, taskID a2a.TaskID) ([]*a2a.PushConfig, error) {
+	deliveries, _ := s.Deliveries(ctx, taskID)
+	var configs []*a2a.PushConfig
+	for _, d := range deliveries {
+		if !d.Disabled {
+			configs = append(configs, &d.Config)
+		}
+	}
+	return configs, nil
+}
+
+// Delete implements a2asrv.PushConfigStore
+func (s *MemoryPushConfigStore) Delete(ctx context.Context, taskID a2a.TaskID, configID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.deliveries, pushKey{taskID, configID})
+	return nil
+}
+
+// DeleteAll implements a2asrv.PushConfigStore
+func (s *MemoryPushConfigStore) DeleteAll(ctx context.Context, taskID a2a.TaskID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.deliveries {
+		if key.taskID == taskID {
+			delete(s.deliveries, key)
+		}
+	}
+	return nil
+}
+
+// RecordDelivery implements PushConfigStore
+func (s *MemoryPushConfigStore) RecordDelivery(ctx context.Context, taskID a2a.TaskID, configID string, err error) (*PushDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.deliveries[pushKey{taskID, configID}]
+	if !ok {
+		return nil, ErrPushConfigNotFound
+	}
+	d.record(s.now(), err, s.config.maxFailures())
+	snapshot := *d
+	return &snapshot, nil
+}
+
+// Deliveries implements PushConfigStore, ordered by task and config ID
+func (s *MemoryPushConfigStore) Deliveries(ctx context.Context, taskID a2a.TaskID) ([]*PushDelivery, error) {
+	s.mu.Lock()
+	deliveries := make([]*PushDelivery, 0, len(s.deliveries))
+	for key, d := range s.deliveries {
+		if taskID == "" || key.taskID == taskID {
+			snapshot := *d
+			deliveries = append(deliveries, &snapshot)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(deliveries, func(i, j int) bool {
+		if deliveries[i].TaskID != deliveries[j].TaskID {
+			return deliveries[i].TaskID < deliveries[j].TaskID
+		}
+		return deliveries[i].Config.ID < deliveries[j].Config.ID
+	})
+	return deliveries, nil
+}
+
+// Reset implements PushConfigStore
+func (s *MemoryPushConfigStore) Reset(ctx context.Context, taskID a2a.TaskID, configID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.deliveries[pushKey{taskID, configID}]
+	if !ok {
+		return ErrPushConfigNotFound
+	}
+	d.ConsecutiveFailures = 0
+	d.Disabled = false
+	return nil
+}
+
+// pushConfigHandler serves the push notification config methods from a
+// PushConfigStore and delegates everything else
+type pushConfigHandler struct {
+	a2asrv.RequestHandler
+	store PushConfigStore
+}
+
+// WithPushConfigStore returns a request handler serving the
+// tasks/pushNotificationConfig/* methods of h from store
+func WithPushConfigStore(h a2asrv.RequestHandler, store PushConfigStore) a2asrv.RequestHandler {
+	return &pushConfigHandler{RequestHandler: h, store: store}
+}
+
+// OnGetTaskPushConfig implements a2asrv.RequestHandler
+func (h *pushConfigHandler) OnGetTaskPushConfig(ctx context.Context, params *a2a.GetTaskPushConfigParams) (*a2a.TaskPushConfig, error) {
+	configs, err := h.store.Get(ctx, params.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	configID := params.ConfigID
+	if configID == "" {
+		configID = string(params.TaskID)
+	}
+	for _, config := range configs {
+		if config.ID == configID {
+			return &a2a.TaskPushConfig{TaskID: params.TaskID, Config: *config}, nil
+		}
+	}
+	return nil, ErrPushConfigNotFound
+}
+
+// OnListTaskPushConfig implements a2asrv.RequestHandler
+func (h *pushConfigHandler) OnListTaskPushConfig(ctx context.Context, params *a2a.ListTaskPushConfigParams) ([]*a2a.TaskPushConfig, error) {
+	configs, err := h.store.Get(ctx, params.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*a2a.TaskPushConfig, 0, len(configs))
+	for _, config := range configs {
+		result = append(result, &a2a.TaskPushConfig{TaskID: params.TaskID, Config: *config})
+	}
+	return result, nil
+}
+
+// OnSetTaskPushConfig implements a2asrv.RequestHandler
+func (h *pushConfigHandler) OnSetTaskPushConfig(ctx context.Context, params *a2a.TaskPushConfig) (*a2a.TaskPushConfig, error) {
+	if params.Config.URL == "" {
+		return nil, fmt.Errorf("push notification URL is required: %w", a2a.ErrInvalidParams)
+	}
+	config := params.Config
+	config.ID = pushConfigID(params.TaskID, &config)
+	if err := h.store.Save(ctx, params.TaskID, &config); err != nil {
+		return nil, fmt.Errorf("failed to save push notification config: %w", err)
+	}
+	return &a2a.TaskPushConfig{TaskID: params.TaskID, Config: config}, nil
+}
+
+// OnDeleteTaskPushConfig implements a2asrv.RequestHandler
+func (h *pushConfigHandler) OnDeleteTaskPushConfig(ctx context.Context, params *a2a.DeleteTaskPushConfigParams) error {
+	return h.store.Delete(ctx, params.TaskID, params.ConfigID)
+}
+
+// PushNotifier implements a2asrv.PushNotifier, posting the task to every
+// enabled config of the task and recording each delivery in the store
+type PushNotifier struct {
+	store      PushConfigStore
+	httpClient *http.Client
+
+	// baseCtx is cancelled by Shutdown to abort in-flight deliveries
+	baseCtx context.Context
+	stop    context.CancelFunc
+
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+}
+
+// NewPushNotifier creates a PushNotifier delivering with httpClient
+// (http.DefaultClient when nil)
+func NewPushNotifier(store PushConfigStore, httpClient *http.Client) *PushNotifier {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	n := &PushNotifier{store: store, httpClient: httpClient}
+	n.baseCtx, n.stop = context.WithCancel(context.Background())
+	return n
+}
+
+// SendPush implements a2asrv.PushNotifier, returning the joined delivery
+// errors
+func (n *PushNotifier) SendPush(ctx context.Context, task *a2a.Task) error {
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return ErrPushNotifierClosed
+	}
+	n.inflight.Add(1)
+	n.mu.Unlock()
+	defer n.inflight.Done()
+
+	deliverCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(n.baseCtx, cancel)()
+
+	configs, err := n.store.Get(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load push notification configs: %w", err)
+	}
+	body, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	var errs []error
+	for _, config := range configs {
+		deliveryErr := n.deliver(deliverCtx, config, body)
+		if _, err := n.store.RecordDelivery(ctx, task.ID, config.ID, deliveryErr); err != nil {
+			errs = append(errs, fmt.Errorf("failed to record push delivery: %w", err))
+		}
+		if deliveryErr != nil {
+			errs = append(errs, fmt.Errorf("push to %s failed: %w", config.URL, deliveryErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown stops accepting notifications and waits for in-flight
+// deliveries until ctx is done, then aborts them. Aborted deliveries are
+// recorded as failed. It returns ctx.Err() if deliveries were aborted.
+func (n *PushNotifier) Shutdown(ctx context.Context) error {
+	n.mu.Lock()
+	n.closed = true
+	n.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		n.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		n.stop()
+		return nil
+	case <-ctx.Done():
+		n.stop()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (n *PushNotifier) deliver(ctx context.Context, config *a2a.PushConfig, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if config.Token != "" {
+		req.Header.Set(PushTokenHeader, config.Token)
+	}
+	if auth := config.Auth; auth != nil && auth.Credentials != "" && len(auth.Schemes) > 0 {
+		req.Header.Set("Authorization", auth.Schemes[0]+" "+auth.Credentials)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP error: %s", resp.Status)
+	}
+	return nil
+}
+
+// PushAdminHandler returns a management endpoint for store. GET lists
+// delivery states (optionally ?task=<id>); POST ?task=<id>&config=<id>
+// resets a config and re-enables its endpoint.
+func PushAdminHandler(store PushConfigStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		taskID := a2a.TaskID(r.URL.Query().Get("task"))
+		switch r.Method {
+		case http.MethodGet:
+			deliveries, err := store.Deliveries(r.Context(), taskID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(deliveries)
+		case http.MethodPost:
+			err := store.Reset(r.Context(), taskID, r.URL.Query().Get("config"))
+			switch {
+			case errors.Is(err, ErrPushConfigNotFound):
+				http.Error(w, err.Error(), http.StatusNotFound)
+			case err != nil:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,304 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/internal/sqltime"
+)
+
+// SQLPlaceholder formats the n-th (1-based) query parameter
+type SQLPlaceholder func(n int) string
+
+var (
+	// QuestionPlaceholder formats parameters as ? (SQLite, MySQL)
+	QuestionPlaceholder SQLPlaceholder = func(int) string { return "?" }
+
+	// DollarPlaceholder formats parameters as $1, $2, ... (PostgreSQL)
+	DollarPlaceholder SQLPlaceholder = func(n int) string { return "$" + strconv.Itoa(n) }
+)
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// SQLPushConfigStore is a PushConfigStore backed by a database/sql
+// database. It only uses portable SQL, so it works with any driver given
+// the matching Placeholder. Timestamps are stored as Unix nanoseconds.
+type SQLPushConfigStore struct {
+	db          *sql.DB
+	table       string
+	placeholder SQLPlaceholder
+	maxFailures int
+	now         func() time.Time
+}
+
+// NewSQLPushConfigStore creates a push config store using db. Call
+// CreateTable once to create the table.
+func NewSQLPushConfigStore(db *sql.DB, config PushStoreConfig) (*SQLPushConfigStore, error) {
+	table := config.Table
+	if table == "" {
+		table = "a2a_push_configs"
+	}
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %q", table)
+	}
+	placeholder := config.Placeholder
+	if placeholder == nil {
+		placeholder = QuestionPlaceholder
+	}
+	return &SQLPushConfigStore{
+		db:          db,
+		table:       table,
+		placeholder: placeholder,
+		maxFailures: config.maxFailures(),
+		now:         time.Now,
+	}, nil
+}
+
+// CreateTable creates the store's table if it does not exist
+func (s *SQLPushConfigStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+s.table+` (
+	task_id VARCHAR(255) NOT NULL,
+	config_id VARCHAR(255) NOT NULL,
+	config TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	failures INTEGER NOT NULL DEFAULT 0,
+	consecutive_failures INTEGER NOT NULL DEFAULT 0,
+	last_attempt BIGINT NOT NULL DEFAULT 0,
+	last_success BIGINT NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL,
+	disabled BOOLEAN NOT NULL DEFAULT FALSE,
+	PRIMARY KEY (task_id, config_id)
+)`)
+	if err != nil {
+		return fmt.Errorf("failed to create push config table: %w", err)
+	}
+	return nil
+}
+
+// bind replaces the ? parameters of query with the configured placeholder
+func (s *SQLPushConfigStore) bind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString(s.placeholder(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// querier is implemented by *sql.DB and *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+const pushDeliveryColumns = "task_id, config, attempts, failures, consecutive_failures, last_attempt, last_success, last_error, disabled"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPushDelivery(row rowScanner) (*PushDelivery, error) {
+	var (
+		d                        PushDelivery
+		config                   string
+		lastAttempt, lastSuccess int64
+	)
+	err := row.Scan(&d.TaskID, &config, &d.Attempts, &d.Failures, &d.ConsecutiveFailures,
+		&lastAttempt, &lastSuccess, &d.LastError, &d.Disabled)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(config), &d.Config); err != nil {
+		return nil, fmt.Errorf("failed to decode push notification config: %w", err)
+	}
+	d.LastAttempt = sqltime.FromUnixNano(lastAttempt)
+	d.LastSuccess = sqltime.FromUnixNano(lastSuccess)
+	return &d, nil
+}
+
+// load returns the delivery state of one config
+func (s *SQLPushConfigStore) load(ctx context.Context, q querier, taskID a2a.TaskID, configID string) (*PushDelivery, error) {
+	row := q.QueryRowContext(ctx, s.bind(`SELECT `+pushDeliveryColumns+` FROM `+s.table+` WHERE task_id = ? AND config_id = ?`),
+		string(taskID), configID)
+	d, err := scanPushDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPushConfigNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load push notification config: %w", err)
+	}
+	return d, nil
+}
+
+// inTx runs fn in a transaction, committing when it succeeds
+func (s *SQLPushConfigStore) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Save implements a2asrv.PushConfigStore. Saving an existing config keeps
+// its delivery state.
+func (s *SQLPushConfigStore) Save(ctx context.Context, taskID a2a.TaskID, config *a2a.PushConfig) error {
+	cfg := *config
+	cfg.ID = pushConfigID(taskID, config)
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to encode push notification config: %w", err)
+	}
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := s.load(ctx, tx, taskID, cfg.ID)
+		switch {
+		case errors.Is(err, ErrPushConfigNotFound):
+			_, err = tx.ExecContext(ctx, s.bind(`INSERT INTO `+s.table+` (task_id, config_id, config, last_error) VALUES (?, ?, ?, ?)`),
+				string(taskID), cfg.ID, string(data), "")
+		case err == nil:
+			_, err = tx.ExecContext(ctx, s.bind(`UPDATE `+s.table+` SET config = ? WHERE task_id = ? AND config_id = ?`),
+				string(data), string(taskID), cfg.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save push notification config: %w", err)
+		}
+		return nil
+	})
+}
+
+// Get implements a2asrv.PushConfigStore, omitting disabled configs
+func (s *SQLPushConfigStore) Get(ctx context.Context, taskID a2a.TaskID) ([]*a2a.PushConfig, error) {
+	deliveries, err := s.Deliveries(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	var configs []*a2a.PushConfig
+	for _, d := range deliveries {
+		if !d.Disabled {
+			configs = append(configs, &d.Config)
+		}
+	}
+	return configs, nil
+}
+
+// Delete implements a2asrv.PushConfigStore
+func (s *SQLPushConfigStore) Delete(ctx context.Context, taskID a2a.TaskID, configID string) error {
+	_, err := s.db.ExecContext(ctx, s.bind(`DELETE FROM `+s.table+` WHERE task_id = ? AND config_id = ?`), string(taskID), configID)
+	if err != nil {
+		return fmt.Errorf("failed to delete push notification config: %w", err)
+	}
+	return nil
+}
+
+// DeleteAll implements a2asrv.PushConfigStore
+func (s *SQLPushConfigStore) DeleteAll(ctx context.Context, taskID a2a.TaskID) error {
+	_, err := s.db.ExecContext(ctx, s.bind(`DELETE FROM `+s.table+` WHERE task_id = ?`), string(taskID))
+	if err != nil {
+		return fmt.Errorf("failed to delete push notification configs: %w", err)
+	}
+	return nil
+}
+
+// RecordDelivery implements PushConfigStore
+func (s *SQLPushConfigStore) RecordDelivery(ctx context.Context, taskID a2a.TaskID, configID string, deliveryErr error) (*PushDelivery, error) {
+	var d *PushDelivery
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if d, err = s.load(ctx, tx, taskID, configID); err != nil {
+			return err
+		}
+		d.record(s.now(), deliveryErr, s.maxFailures)
+		_, err = tx.ExecContext(ctx, s.bind(`UPDATE `+s.table+` SET attempts = ?, failures = ?, consecutive_failures = ?,
+	last_attempt = ?, last_success = ?, last_error = ?, disabled = ? WHERE task_id = ? AND config_id = ?`),
+			d.Attempts, d.Failures, d.ConsecutiveFailures, sqltime.UnixNano(d.LastAttempt), sqltime.UnixNano(d.LastSuccess),
+			d.LastError, d.Disabled, string(taskID), configID)
+		if err != nil {
+			return fmt.Errorf("failed to record push delivery: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Deliveries implements PushConfigStore, ordered by task and config ID
+func (s *SQLPushConfigStore) Deliveries(ctx context.Context, taskID a2a.TaskID) ([]*PushDelivery, error) {
+	query := `SELECT ` + pushDeliveryColumns + ` FROM ` + s.table
+	var args []any
+	if taskID != "" {
+		query += ` WHERE task_id = ?`
+		args = append(args, string(taskID))
+	}
+	rows, err := s.db.QueryContext(ctx, s.bind(query+` ORDER BY task_id, config_id`), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push notification configs: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*PushDelivery
+	for rows.Next() {
+		d, err := scanPushDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list push notification configs: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Reset implements PushConfigStore
+func (s *SQLPushConfigStore) Reset(ctx context.Context, taskID a2a.TaskID, configID string) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := s.load(ctx, tx, taskID, configID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, s.bind(`UPDATE `+s.table+` SET consecutive_failures = ?, disabled = ? WHERE task_id = ? AND config_id = ?`),
+			0, false, string(taskID), configID)
+		if err != nil {
+			return fmt.Errorf("failed to reset push notification config: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryPushConfigStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryPushConfigStore(PushStoreConfig{MaxFailures: 2})
+
+	require.NoError(t, store.Save(ctx, "task-1", &a2a.PushConfig{URL: "https://a.example.com"}))
+	require.NoError(t, store.Save(ctx, "task-1", &a2a.PushConfig{ID: "b", URL: "https://b.example.com"}))
+	configs, err := store.Get(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "b", configs[0].ID)
+	assert.Equal(t, "task-1", configs[1].ID, "configs without an ID use the task ID")
+
+	// Two consecutive failures disable the endpoint
+	_, err = store.RecordDelivery(ctx, "task-1", "b", assert.AnError)
+	require.NoError(t, err)
+	d, err := store.RecordDelivery(ctx, "task-1", "b", assert.AnError)
+	require.NoError(t, err)
+	assert.True(t, d.Disabled)
+	assert.Equal(t, 2, d.Attempts)
+	assert.Equal(t, assert.AnError.Error(), d.LastError)
+	configs, _ = store.Get(ctx, "task-1")
+	assert.Len(t, configs, 1)
+
+	// Saving again keeps the delivery state; Reset re-enables it
+	require.NoError(t, store.Save(ctx, "task-1", &a2a.PushConfig{ID: "b", URL: "https://b2.example.com"}))
+	deliveries, _ := store.Deliveries(ctx, "task-1")
+	assert.True(t, deliveries[0].Disabled)
+	assert.Equal(t, "https://b2.example.com", deliveries[0].Config.URL)
+	require.NoError(t, store.Reset(ctx, "task-1", "b"))
+	configs, _ = store.Get(ctx, "task-1")
+	assert.Len(t, configs, 2)
+	assert.ErrorIs(t, store.Reset(ctx, "task-1", "missing"), ErrPushConfigNotFound)
+
+	require.NoError(t, store.DeleteAll(ctx, "task-1"))
+	deliveries, _ = store.Deliveries(ctx, "")
+	assert.Empty(t, deliveries)
+}
+
+func TestWithPushConfigStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryPushConfigStore(PushStoreConfig{})
+	h := WithPushConfigStore(a2asrv.NewHandler(&funcExecutor{}), store)
+
+	set, err := h.OnSetTaskPushConfig(ctx, &a2a.TaskPushConfig{TaskID: "task-1", Config: a2a.PushConfig{URL: "https://a.example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", set.Config.ID)
+
+	got, err := h.OnGetTaskPushConfig(ctx, &a2a.GetTaskPushConfigParams{TaskID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.example.com", got.Config.URL)
+
+	list, err := h.OnListTaskPushConfig(ctx, &a2a.ListTaskPushConfigParams{TaskID: "task-1"})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	require.NoError(t, h.OnDeleteTaskPushConfig(ctx, &a2a.DeleteTaskPushConfigParams{TaskID: "task-1", ConfigID: "task-1"}))
+	_, err = h.OnGetTaskPushConfig(ctx, &a2a.GetTaskPushConfigParams{TaskID: "task-1"})
+	assert.ErrorIs(t, err, ErrPushConfigNotFound)
+
+	_, err = h.OnSetTaskPushConfig(ctx, &a2a.TaskPushConfig{TaskID: "task-1"})
+	assert.ErrorIs(t, err, a2a.ErrInvalidParams)
+}
+
+func TestPushNotifier(t *testing.T) {
+	ctx := context.Background()
+	var failing atomic.Bool
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get(PushTokenHeader))
+		assert.Equal(t, "Bearer creds", r.Header.Get("Authorization"))
+		var task a2a.Task
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&task))
+		assert.Equal(t, a2a.TaskID("task-1"), task.ID)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer endpoint.Close()
+
+	store := NewMemoryPushConfigStore(PushStoreConfig{MaxFailures: 1})
+	require.NoError(t, store.Save(ctx, "task-1", &a2a.PushConfig{
+		URL:   endpoint.URL,
+		Token: "secret",
+		Auth:  &a2a.PushAuthInfo{Schemes: []string{"Bearer"}, Credentials: "creds"},
+	}))
+	notifier := NewPushNotifier(store, nil)
+	task := &a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}
+
+	require.NoError(t, notifier.SendPush(ctx, task))
+	failing.Store(true)
+	assert.Error(t, notifier.SendPush(ctx, task))
+
+	deliveries, err := store.Deliveries(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, 1, deliveries[0].Failures)
+	assert.False(t, deliveries[0].LastSuccess.IsZero())
+	assert.True(t, deliveries[0].Disabled)
+
+	// Disabled endpoints are skipped until reset through the admin API
+	require.NoError(t, notifier.SendPush(ctx, task))
+	admin := PushAdminHandler(store)
+	rec := httptest.NewRecorder()
+	admin.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/push?task=task-1&config=task-1", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	admin.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/push", nil))
+	var listed []*PushDelivery
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+	require.Len(t, listed, 1)
+	assert.False(t, listed[0].Disabled)
+
+	rec = httptest.NewRecorder()
+	admin.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/push?task=task-1&config=nope", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSQLPushConfigStore_Config(t *testing.T) {
+	_, err := NewSQLPushConfigStore(nil, PushStoreConfig{Table: "push; DROP TABLE tasks"})
+	assert.Error(t, err)
+
+	store, err := NewSQLPushConfigStore(nil, PushStoreConfig{Table: "a2a.push", Placeholder: DollarPlaceholder})
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE a2a.push SET config = $1 WHERE task_id = $2", store.bind("UPDATE a2a.push SET config = ? WHERE task_id = ?"))
+
+	store, err = NewSQLPushConfigStore(nil, PushStoreConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "a2a_push_configs", store.table)
+	assert.Equal(t, "WHERE task_id = ?", store.bind("WHERE task_id = ?"))
+}
+
+func TestPushNotifier_Shutdown(t *testing.T) {
+	ctx := context.Background()
+	received, unblock := make(chan struct{}, 1), make(chan struct{})
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-unblock
+	}))
+	defer endpoint.Close()
+	defer close(unblock)
+
+	store := NewMemoryPushConfigStore(PushStoreConfig{})
+	require.NoError(t, store.Save(ctx, "task-1", &a2a.PushConfig{URL: endpoint.URL}))
+	notifier := NewPushNotifier(store, nil)
+	task := &a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}
+
+	sent := make(chan error, 1)
+	go func() { sent <- notifier.SendPush(ctx, task) }()
+	<-received
+
+	// The hanging delivery is aborted at the deadline and recorded
+	shutdownCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, notifier.Shutdown(shutdownCtx), context.DeadlineExceeded)
+	assert.ErrorIs(t, <-sent, context.Canceled)
+
+	deliveries, err := store.Deliveries(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 1, deliveries[0].Failures)
+
+	assert.ErrorIs(t, notifier.SendPush(ctx, task), ErrPushNotifierClosed)
+}
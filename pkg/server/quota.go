@@ -0,0 +1,333 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultUsageWindow is the rolling window used when QuotaConfig.Window is zero
+const DefaultUsageWindow = time.Minute
+
+// usageBuckets is the number of buckets the rolling window is divided into
+const usageBuckets = 10
+
+// Quota names reported in QuotaExceededError and the X-Quota-Exceeded header
+const (
+	QuotaInFlight = "in-flight"
+	QuotaRequests = "requests"
+	QuotaBytes    = "bytes"
+	QuotaTasks    = "tasks"
+)
+
+// taskMethods are the JSON-RPC methods counted as task submissions
+var taskMethods = map[string]bool{
+	"message/send":   true,
+	"message/stream": true,
+}
+
+// QuotaConfig configures per-DID usage tracking. Zero limits are tracked but
+// not enforced.
+type QuotaConfig struct {
+	// Window is the rolling window for request, byte and task counts
+	// (default DefaultUsageWindow)
+	Window time.Duration
+
+	// MaxInFlight limits concurrent requests per DID
+	MaxInFlight int64
+
+	// MaxRequests limits requests per DID within Window
+	MaxRequests int64
+
+	// MaxBytes limits request body bytes per DID within Window
+	MaxBytes int64
+
+	// MaxTasks limits message/send and message/stream calls per DID within Window
+	MaxTasks int64
+}
+
+// DIDUsage is a snapshot of one DID's usage
+type DIDUsage struct {
+	AgentDID did.AgentDID `json:"agentDid"`
+	InFlight int64        `json:"inFlight"`
+	Requests int64        `json:"requests"`
+	Bytes    int64        `json:"bytes"`
+	Tasks    int64        `json:"tasks"`
+}
+
+// QuotaExceededError is returned when a request would exceed a quota
+type QuotaExceededError struct {
+	AgentDID did.AgentDID
+	Quota    string
+	Limit    int64
+	Reset    time.Duration // until usage falls back under the limit
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota exceeded for %s: %s limit %d", e.AgentDID, e.Quota, e.Limit)
+}
+
+type usageBucket struct {
+	start    time.Time
+	requests int64
+	bytes    int64
+	tasks    int64
+}
+
+type didUsage struct {
+	inFlight int64
+	buckets  []usageBucket // oldest first
+}
+
+// UsageTracker tracks in-flight requests and rolling usage per verified DID
+// and optionally enforces quotas. It is safe for concurrent use.
+type UsageTracker struct {
+	config QuotaConfig
+	now    func() time.Time
+
+	mu    sync.Mutex
+	usage map[did.AgentDID]*didUsage
+}
+
+// NewUsageTracker creates a tracker with the given configuration
+func NewUsageTracker(config QuotaConfig) *UsageTracker {
+	if config.Window <= 0 {
+		config.Window = DefaultUsageWindow
+	}
+	return &UsageTracker{
+		config: config,
+		now:    time.Now,
+		usage:  make(map[did.AgentDID]*didUsage),
+	}
+}
+
+// Acquire records a request from agentDID with a body of size bytes calling
+// the given JSON-RPC method. It returns a release function to call when the
+// request completes, or a *QuotaExceededError if a quota would be exceeded,
+// in which case nothing is recorded.
+func (t *UsageTracker) Acquire(agentDID did.AgentDID, size int64, method string) (func(), error) {
+	_, release, err := t.acquire(agentDID, size, method)
+	return release, err
+}
+
+// acquire is Acquire also returning the usage including this request
+func (t *UsageTracker) acquire(agentDID did.AgentDID, size int64, method string) (DIDUsage, func(), error) {
+	now := t.now()
+	isTask := taskMethods[method]
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, ok := t.usage[agentDID]
+	if !ok {
+		u = &didUsage{}
+		t.usage[agentDID] = u
+	}
+	t.prune(u, now)
+	snap := t.snapshot(agentDID, u)
+
+	var tasks int64
+	if isTask {
+		tasks = 1
+	}
+	if err := t.check(u, snap, size, tasks, now); err != nil {
+		return DIDUsage{}, nil, err
+	}
+
+	start := now.Truncate(t.bucketWidth())
+	if n := len(u.buckets); n == 0 || !u.buckets[n-1].start.Equal(start) {
+		u.buckets = append(u.buckets, usageBucket{start: start})
+	}
+	b := &u.buckets[len(u.buckets)-1]
+	b.requests++
+	b.bytes += size
+	b.tasks += tasks
+	u.inFlight++
+
+	snap.InFlight++
+	snap.Requests++
+	snap.Bytes += size
+	snap.Tasks += tasks
+
+	var once sync.Once
+	return snap, func() {
+		once.Do(func() {
+			t.mu.Lock()
+			u.inFlight--
+			t.mu.Unlock()
+		})
+	}, nil
+}
+
+// check returns the first quota the request would exceed
+func (t *UsageTracker) check(u *didUsage, snap DIDUsage, size, tasks int64, now time.Time) error {
+	c := t.config
+	switch {
+	case c.MaxInFlight > 0 && snap.InFlight+1 > c.MaxInFlight:
+		return &QuotaExceededError{AgentDID: snap.AgentDID, Quota: QuotaInFlight, Limit: c.MaxInFlight, Reset: time.Second}
+	case c.MaxRequests > 0 && snap.Requests+1 > c.MaxRequests:
+		return &QuotaExceededError{AgentDID: snap.AgentDID, Quota: QuotaRequests, Limit: c.MaxRequests, Reset: t.reset(u, now)}
+	case c.MaxBytes > 0 && snap.Bytes+size > c.MaxBytes:
+		return &QuotaExceededError{AgentDID: snap.AgentDID, Quota: QuotaBytes, Limit: c.MaxBytes, Reset: t.reset(u, now)}
+	case c.MaxTasks > 0 && snap.Tasks+tasks > c.MaxTasks:
+		return &QuotaExceededError{AgentDID: snap.AgentDID, Quota: QuotaTasks, Limit: c.MaxTasks, Reset: t.reset(u, now)}
+	}
+	return nil
+}
+
+// Usage returns the current usage of agentDID
+func (t *UsageTracker) Usage(agentDID did.AgentDID) DIDUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, ok := t.usage[agentDID]
+	if !ok {
+		return DIDUsage{AgentDID: agentDID}
+	}
+	t.prune(u, t.now())
+	return t.snapshot(agentDID, u)
+}
+
+// Snapshot returns the usage of every DID with activity in the window,
+// ordered by DID. Idle DIDs are dropped.
+func (t *UsageTracker) Snapshot() []DIDUsage {
+	now := t.now()
+
+	t.mu.Lock()
+	out := make([]DIDUsage, 0, len(t.usage))
+	for agentDID, u := range t.usage {
+		t.prune(u, now)
+		if u.inFlight == 0 && len(u.buckets) == 0 {
+			delete(t.usage, agentDID)
+			continue
+		}
+		out = append(out, t.snapshot(agentDID, u))
+	}
+	t.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].AgentDID < out[j].AgentDID })
+	return out
+}
+
+// Handler returns an http.Handler serving Snapshot as JSON, or the usage of
+// a single DID when the "did" query parameter is set. It is not
+// authenticated; mount it behind the middleware or on an internal listener.
+func (t *UsageTracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v any
+		if agentDID := r.URL.Query().Get("did"); agentDID != "" {
+			v = t.Usage(did.AgentDID(agentDID))
+		} else {
+			v = t.Snapshot()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	})
+}
+
+func (t *UsageTracker) bucketWidth() time.Duration {
+	return t.config.Window / usageBuckets
+}
+
+// prune drops buckets that have left the window
+func (t *UsageTracker) prune(u *didUsage, now time.Time) {
+	cutoff := now.Add(-t.config.Window)
+	i := 0
+	for i < len(u.buckets) && !u.buckets[i].start.After(cutoff) {
+		i++
+	}
+	u.buckets = u.buckets[i:]
+}
+
+// reset returns how long until the oldest bucket leaves the window
+func (t *UsageTracker) reset(u *didUsage, now time.Time) time.Duration {
+	if len(u.buckets) == 0 {
+		return 0
+	}
+	return u.buckets[0].start.Add(t.config.Window).Sub(now)
+}
+
+func (t *UsageTracker) snapshot(agentDID did.AgentDID, u *didUsage) DIDUsage {
+	s := DIDUsage{AgentDID: agentDID, InFlight: u.inFlight}
+	for _, b := range u.buckets {
+		s.Requests += b.requests
+		s.Bytes += b.bytes
+		s.Tasks += b.tasks
+	}
+	return s
+}
+
+// SetUsageTracker enables per-DID usage tracking and quota enforcement for
+// verified requests. Requests over quota receive 429 Too Many Requests.
+func (m *DIDAuthMiddleware) SetUsageTracker(tracker *UsageTracker) {
+	m.update(func(c *middlewareConfig) {
+		c.usage = tracker
+	})
+}
+
+// GetUsageFromContext returns the caller's usage, including the current
+// request, as recorded when the request was admitted
+func GetUsageFromContext(ctx context.Context) (DIDUsage, bool) {
+	usage, ok := ctx.Value(usageKey).(DIDUsage)
+	return usage, ok
+}
+
+// admit applies the rate limiter and records the request, of size bytes
+// calling the JSON-RPC method, against agentDID's quota. On success it
+// returns the context carrying the usage snapshot and a release function;
+// otherwise it writes a 429 response and returns ok false.
+func (m *middlewareConfig) admit(ctx context.Context, w http.ResponseWriter, agentDID did.AgentDID, size int64, method string) (context.Context, func(), bool) {
+	if !m.allowRate(ctx, w, agentDID) {
+		return ctx, nil, false
+	}
+	if m.usage == nil {
+		return ctx, func() {}, true
+	}
+	usage, release, err := m.usage.acquire(agentDID, size, method)
+	if err != nil {
+		writeQuotaExceeded(w, err.(*QuotaExceededError))
+		return ctx, nil, false
+	}
+	return context.WithValue(ctx, usageKey, usage), release, true
+}
+
+// writeQuotaExceeded writes a 429 response with quota headers
+func writeQuotaExceeded(w http.ResponseWriter, err *QuotaExceededError) {
+	seconds := int(math.Ceil(err.Reset.Seconds()))
+	w.Header().Set("X-Quota-Exceeded", err.Quota)
+	w.Header().Set("RateLimit-Limit", strconv.FormatInt(err.Limit, 10))
+	w.Header().Set("RateLimit-Remaining", "0")
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(seconds))
+	writeError(w, http.StatusTooManyRequests, protocol.ErrorBody{
+		Code:       protocol.ErrorCodeRateLimited,
+		Message:    "Too Many Requests: " + err.Error(),
+		Retryable:  true,
+		RetryAfter: seconds,
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const quotaDID = did.AgentDID("did:sage:ethereum:0xabc")
+
+func TestUsageTracker_RollingWindow(t *testing.T) {
+	now := time.Unix(1_700_000_040, 0) // aligned to a bucket boundary
+	tracker := NewUsageTracker(QuotaConfig{Window: time.Minute, MaxRequests: 2})
+	tracker.now = func() time.Time { return now }
+
+	release, err := tracker.Acquire(quotaDID, 10, "message/send")
+	require.NoError(t, err)
+	release()
+	release() // idempotent
+	_, err = tracker.Acquire(quotaDID, 20, "tasks/get")
+	require.NoError(t, err)
+
+	usage := tracker.Usage(quotaDID)
+	assert.Equal(t, DIDUsage{AgentDID: quotaDID, InFlight: 1, Requests: 2, Bytes: 30, Tasks: 1}, usage)
+
+	_, err = tracker.Acquire(quotaDID, 0, "tasks/get")
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, QuotaRequests, quotaErr.Quota)
+	assert.Equal(t, time.Minute, quotaErr.Reset)
+
+	// Rejected requests are not recorded
+	assert.Equal(t, int64(2), tracker.Usage(quotaDID).Requests)
+
+	now = now.Add(61 * time.Second)
+	_, err = tracker.Acquire(quotaDID, 0, "tasks/get")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tracker.Usage(quotaDID).Requests)
+}
+
+func TestUsageTracker_Limits(t *testing.T) {
+	tests := []struct {
+		name   string
+		config QuotaConfig
+		size   int64
+		method string
+		quota  string
+	}{
+		{"in-flight", QuotaConfig{MaxInFlight: 1}, 0, "", QuotaInFlight},
+		{"bytes", QuotaConfig{MaxBytes: 100}, 60, "", QuotaBytes},
+		{"tasks", QuotaConfig{MaxTasks: 1}, 0, "message/stream", QuotaTasks},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewUsageTracker(tt.config)
+			_, err := tracker.Acquire(quotaDID, tt.size, tt.method)
+			require.NoError(t, err)
+
+			_, err = tracker.Acquire(quotaDID, tt.size, tt.method)
+			var quotaErr *QuotaExceededError
+			require.ErrorAs(t, err, &quotaErr)
+			assert.Equal(t, tt.quota, quotaErr.Quota)
+
+			// Other DIDs are unaffected
+			_, err = tracker.Acquire("did:sage:ethereum:0xdef", tt.size, tt.method)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDIDAuthMiddleware_UsageTracker(t *testing.T) {
+	tracker := NewUsageTracker(QuotaConfig{MaxRequests: 1})
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: quotaDID})
+	middleware.SetUsageTracker(tracker)
+
+	var seen DIDUsage
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = GetUsageFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"message/send"}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(body))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, DIDUsage{AgentDID: quotaDID, InFlight: 1, Requests: 1, Bytes: int64(len(body)), Tasks: 1}, seen)
+	assert.Equal(t, int64(0), tracker.Usage(quotaDID).InFlight)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(body))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, QuotaRequests, rr.Header().Get("X-Quota-Exceeded"))
+	assert.Equal(t, "1", rr.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rr.Header().Get("RateLimit-Remaining"))
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+}
+
+func TestUsageTracker_Handler(t *testing.T) {
+	tracker := NewUsageTracker(QuotaConfig{})
+	_, err := tracker.Acquire(quotaDID, 5, "")
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	tracker.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/usage", nil))
+	var all []DIDUsage
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &all))
+	require.Len(t, all, 1)
+	assert.Equal(t, int64(5), all[0].Bytes)
+
+	rr = httptest.NewRecorder()
+	tracker.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/usage?did=did:sage:ethereum:0xnone", nil))
+	var one DIDUsage
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &one))
+	assert.Equal(t, did.AgentDID("did:sage:ethereum:0xnone"), one.AgentDID)
+	assert.Zero(t, one.Requests)
+}
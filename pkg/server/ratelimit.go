@@ -0,0 +1,374 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Defaults for DistributedRateLimitConfig and RedisRateLimitConfig
+const (
+	DefaultRateLimitTimeout        = 100 * time.Millisecond
+	DefaultRateLimitFallbackPeriod = 5 * time.Second
+	DefaultRedisRateLimitPrefix    = "a2a:ratelimit:"
+)
+
+// QuotaRate is the quota reported when a DistributedRateLimiter rejects a request
+const QuotaRate = "rate"
+
+// RateLimit is a token bucket: Burst tokens at most, refilled at Rate per second
+type RateLimit struct {
+	Rate  float64
+	Burst int64
+}
+
+func (l RateLimit) valid() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// RateLimitResult is the outcome of one RateLimitStore.Take
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int64         // whole tokens left in the bucket
+	RetryAfter time.Duration // until cost tokens are available, when not allowed
+}
+
+// RateLimitStore keeps token buckets. Take must update the bucket for key
+// atomically, so that replicas sharing a store enforce one limit.
+// Implementations exist for Redis and process memory; memcached or other
+// stores can implement it with compare-and-swap.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit RateLimit, cost int64) (RateLimitResult, error)
+}
+
+// MemoryRateLimitStore is a RateLimitStore local to the process. It is the
+// fallback of a DistributedRateLimiter and is enough for a single replica.
+type MemoryRateLimitStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time // when the bucket will be full again
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Take implements RateLimitStore
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit RateLimit, cost int64) (RateLimitResult, error) {
+	now := s.now()
+	burst := float64(limit.Burst)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		s.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*limit.Rate)
+	}
+	b.last = now
+
+	res := RateLimitResult{}
+	if b.tokens >= float64(cost) {
+		b.tokens -= float64(cost)
+		res.Allowed = true
+	} else {
+		res.RetryAfter = refillTime(float64(cost)-b.tokens, limit.Rate)
+	}
+	res.Remaining = int64(b.tokens)
+	b.full = now.Add(refillTime(burst-b.tokens, limit.Rate))
+	return res, nil
+}
+
+// sweep drops buckets that have refilled, at most once a minute
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if !b.full.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// refillTime returns how long rate takes to add tokens
+func refillTime(tokens, rate float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / rate * float64(time.Second)))
+}
+
+// redisTokenBucket atomically refills and takes from the bucket at KEYS[1],
+// a hash of tokens and ts in milliseconds of the Redis clock, so replicas
+// with skewed clocks agree. ARGV is the rate per millisecond, the burst and
+// the cost. It returns {allowed, remaining, retry after in milliseconds}.
+const redisTokenBucket = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) * rate)
+end
+local allowed = 0
+local wait = 0
+if tokens >= cost then
+  tokens = tokens - cost
+  allowed = 1
+else
+  wait = math.ceil((cost - tokens) / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, math.floor(tokens), wait}
+`
+
+// RedisScripter runs Lua scripts returning integer arrays. With
+// github.com/redis/go-redis/v9, which retries with EVAL when the script is
+// not cached:
+//
+//	type goRedis struct{ *redis.Client }
+//
+//	func (c goRedis) Eval(ctx context.Context, script string, keys []string, args ...any) ([]int64, error) {
+//	    return redis.NewScript(script).Run(ctx, c.Client, keys, args...).Int64Slice()
+//	}
+type RedisScripter interface {
+	Eval(ctx context.Context, script string, keys []string, args ...any) ([]int64, error)
+}
+
+// RedisRateLimitConfig configures a RedisRateLimitStore
+type RedisRateLimitConfig struct {
+	// Client runs the token bucket script
+	Client RedisScripter
+
+	// Prefix is prepended to every key (default DefaultRedisRateLimitPrefix)
+	Prefix string
+}
+
+// RedisRateLimitStore is a RateLimitStore shared by all replicas through
+// Redis. Each Take is one Lua script run, so concurrent takes from different
+// replicas cannot overspend a bucket.
+type RedisRateLimitStore struct {
+	config RedisRateLimitConfig
+}
+
+// NewRedisRateLimitStore creates a store using config.Client
+func NewRedisRateLimitStore(config RedisRateLimitConfig) (*RedisRateLimitStore, error) {
+	if config.Client == nil {
+		return nil, fmt.Errorf("redis client is required")
+	}
+	if config.Prefix == "" {
+		config.Prefix = DefaultRedisRateLimitPrefix
+	}
+	return &RedisRateLimitStore{config: config}, nil
+}
+
+// Take implements RateLimitStore
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit RateLimit, cost int64) (RateLimitResult, error) {
+	out, err := s.config.Client.Eval(ctx, redisTokenBucket, []string{s.config.Prefix + key},
+		strconv.FormatFloat(limit.Rate/1000, 'g', -1, 64), limit.Burst, cost)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	if len(out) != 3 {
+		return RateLimitResult{}, fmt.Errorf("unexpected token bucket reply %v", out)
+	}
+	return RateLimitResult{
+		Allowed:    out[0] == 1,
+		Remaining:  out[1],
+		RetryAfter: time.Duration(out[2]) * time.Millisecond,
+	}, nil
+}
+
+// DistributedRateLimitConfig configures a DistributedRateLimiter
+type DistributedRateLimitConfig struct {
+	// Store holds the buckets shared by all replicas
+	Store RateLimitStore
+
+	// Limit is the global limit per calling DID
+	Limit RateLimit
+
+	// Fallback is the per-replica limit enforced while Store is unavailable
+	// (default Limit). Set it to Limit divided by the number of replicas to
+	// keep the global limit during an outage.
+	Fallback RateLimit
+
+	// Timeout bounds each Take on Store (default DefaultRateLimitTimeout)
+	Timeout time.Duration
+
+	// FallbackPeriod is how long local limits are used after Store fails
+	// before it is tried again (default DefaultRateLimitFallbackPeriod)
+	FallbackPeriod time.Duration
+}
+
+// DistributedRateLimitStats counts the decisions of a DistributedRateLimiter
+type DistributedRateLimitStats struct {
+	Allowed  uint64 // requests admitted
+	Limited  uint64 // requests rejected
+	Errors   uint64 // takes that failed because Store did
+	Fallback uint64 // decisions made by the local fallback
+}
+
+// DistributedRateLimiter enforces a token bucket per calling DID across
+// replicas. When the shared store fails or times out it degrades to local
+// per-replica limits for FallbackPeriod instead of rejecting requests.
+type DistributedRateLimiter struct {
+	config DistributedRateLimitConfig
+	local  *MemoryRateLimitStore
+	now    func() time.Time
+
+	fallbackUntil atomic.Int64 // unix nanoseconds
+
+	allowed, limited, errs, fallback atomic.Uint64
+}
+
+// NewDistributedRateLimiter creates a limiter using config.Store
+func NewDistributedRateLimiter(config DistributedRateLimitConfig) (*DistributedRateLimiter, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("rate limit store is required")
+	}
+	if !config.Limit.valid() {
+		return nil, fmt.Errorf("rate limit needs a positive rate and burst")
+	}
+	if !config.Fallback.valid() {
+		config.Fallback = config.Limit
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultRateLimitTimeout
+	}
+	if config.FallbackPeriod <= 0 {
+		config.FallbackPeriod = DefaultRateLimitFallbackPeriod
+	}
+	return &DistributedRateLimiter{
+		config: config,
+		local:  NewMemoryRateLimitStore(),
+		now:    time.Now,
+	}, nil
+}
+
+// Allow takes one token from agentDID's bucket. It returns a
+// *QuotaExceededError if the bucket is empty.
+func (l *DistributedRateLimiter) Allow(ctx context.Context, agentDID did.AgentDID) error {
+	res, err := l.take(ctx, string(agentDID))
+	if err != nil {
+		return err
+	}
+	if res.Allowed {
+		l.allowed.Add(1)
+		return nil
+	}
+	l.limited.Add(1)
+	return &QuotaExceededError{AgentDID: agentDID, Quota: QuotaRate, Limit: l.config.Limit.Burst, Reset: res.RetryAfter}
+}
+
+// take asks the shared store, or the local one while it is unavailable
+func (l *DistributedRateLimiter) take(ctx context.Context, key string) (RateLimitResult, error) {
+	now := l.now()
+	if now.UnixNano() >= l.fallbackUntil.Load() {
+		tctx, cancel := context.WithTimeout(ctx, l.config.Timeout)
+		res, err := l.config.Store.Take(tctx, key, l.config.Limit, 1)
+		cancel()
+		if err == nil {
+			return res, nil
+		}
+		if ctx.Err() != nil {
+			// The request itself was cancelled; the store is not to blame
+			return RateLimitResult{}, ctx.Err()
+		}
+		l.errs.Add(1)
+		l.fallbackUntil.Store(now.Add(l.config.FallbackPeriod).UnixNano())
+	}
+	l.fallback.Add(1)
+	return l.local.Take(ctx, key, l.config.Fallback, 1)
+}
+
+// Stats returns the limiter's counters
+func (l *DistributedRateLimiter) Stats() DistributedRateLimitStats {
+	return DistributedRateLimitStats{
+		Allowed:  l.allowed.Load(),
+		Limited:  l.limited.Load(),
+		Errors:   l.errs.Load(),
+		Fallback: l.fallback.Load(),
+	}
+}
+
+// SetRateLimiter enforces limiter's per-DID limit on verified requests
+// before usage tracking. Rejected requests receive 429 Too Many Requests
+// with X-Quota-Exceeded: rate.
+func (m *DIDAuthMiddleware) SetRateLimiter(limiter *DistributedRateLimiter) {
+	m.update(func(c *middlewareConfig) {
+		c.rateLimiter = limiter
+	})
+}
+
+// allowRate applies the rate limiter, writing the error response on failure
+func (m *middlewareConfig) allowRate(ctx context.Context, w http.ResponseWriter, agentDID did.AgentDID) bool {
+	if m.rateLimiter == nil {
+		return true
+	}
+	err := m.rateLimiter.Allow(ctx, agentDID)
+	var quota *QuotaExceededError
+	switch {
+	case err == nil:
+		return true
+	case errors.As(err, &quota):
+		writeQuotaExceeded(w, quota)
+	default:
+		writeError(w, http.StatusServiceUnavailable, protocol.ErrorBody{
+			Code:      protocol.ErrorCodeUnavailable,
+			Message:   "Service Unavailable: " + err.Error(),
+			Retryable: true,
+		})
+	}
+	return false
+}
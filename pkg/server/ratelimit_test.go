@@ -0,0 +1,160 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	limit := RateLimit{Rate: 2, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		res, err := store.Take(ctx, "a", limit, 1)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, int64(i), res.Remaining)
+	}
+	res, err := store.Take(ctx, "a", limit, 1)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+
+	// Buckets are independent and refill at Rate
+	res, _ = store.Take(ctx, "b", limit, 1)
+	assert.True(t, res.Allowed)
+	now = now.Add(time.Second)
+	res, _ = store.Take(ctx, "a", limit, 1)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, int64(1), res.Remaining)
+
+	// Refilled buckets are swept
+	now = now.Add(2 * time.Minute)
+	_, _ = store.Take(ctx, "c", limit, 1)
+	assert.Len(t, store.buckets, 1)
+}
+
+// fakeScripter records script calls and replies with reply or fails
+type fakeScripter struct {
+	keys  []string
+	args  []any
+	reply []int64
+	fail  bool
+	calls int
+}
+
+func (f *fakeScripter) Eval(ctx context.Context, script string, keys []string, args ...any) ([]int64, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("connection refused")
+	}
+	f.keys, f.args = keys, args
+	return f.reply, nil
+}
+
+func TestRedisRateLimitStore(t *testing.T) {
+	_, err := NewRedisRateLimitStore(RedisRateLimitConfig{})
+	assert.Error(t, err)
+
+	client := &fakeScripter{reply: []int64{0, 0, 250}}
+	store, err := NewRedisRateLimitStore(RedisRateLimitConfig{Client: client})
+	require.NoError(t, err)
+
+	res, err := store.Take(context.Background(), string(quotaDID), RateLimit{Rate: 4, Burst: 10}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitResult{RetryAfter: 250 * time.Millisecond}, res)
+	assert.Equal(t, []string{DefaultRedisRateLimitPrefix + string(quotaDID)}, client.keys)
+	assert.Equal(t, []any{"0.004", int64(10), int64(1)}, client.args)
+
+	client.reply = []int64{1}
+	_, err = store.Take(context.Background(), "a", RateLimit{Rate: 4, Burst: 10}, 1)
+	assert.Error(t, err, "malformed replies are errors")
+}
+
+func TestDistributedRateLimiter_Fallback(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	client := &fakeScripter{fail: true}
+	store, err := NewRedisRateLimitStore(RedisRateLimitConfig{Client: client})
+	require.NoError(t, err)
+	limiter, err := NewDistributedRateLimiter(DistributedRateLimitConfig{
+		Store:    store,
+		Limit:    RateLimit{Rate: 100, Burst: 100},
+		Fallback: RateLimit{Rate: 1, Burst: 1},
+	})
+	require.NoError(t, err)
+	limiter.now = func() time.Time { return now }
+	limiter.local.now = limiter.now
+
+	// The store is down: local limits apply and the store is not retried
+	require.NoError(t, limiter.Allow(ctx, quotaDID))
+	err = limiter.Allow(ctx, quotaDID)
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, QuotaRate, quotaErr.Quota)
+	assert.Equal(t, time.Second, quotaErr.Reset)
+	assert.Equal(t, 1, client.calls)
+
+	// After FallbackPeriod the store is used again
+	client.fail = false
+	client.reply = []int64{1, 99, 0}
+	now = now.Add(DefaultRateLimitFallbackPeriod)
+	require.NoError(t, limiter.Allow(ctx, quotaDID))
+	assert.Equal(t, 2, client.calls)
+
+	assert.Equal(t, DistributedRateLimitStats{Allowed: 2, Limited: 1, Errors: 1, Fallback: 2}, limiter.Stats())
+}
+
+func TestDIDAuthMiddleware_RateLimiter(t *testing.T) {
+	limiter, err := NewDistributedRateLimiter(DistributedRateLimitConfig{
+		Store: NewMemoryRateLimitStore(),
+		Limit: RateLimit{Rate: 0.1, Burst: 1},
+	})
+	require.NoError(t, err)
+	tracker := NewUsageTracker(QuotaConfig{})
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: quotaDID})
+	middleware.SetRateLimiter(limiter)
+	middleware.SetUsageTracker(tracker)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(body))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(body))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, QuotaRate, rr.Header().Get("X-Quota-Exceeded"))
+	assert.Equal(t, "10", rr.Header().Get("Retry-After"))
+	assert.Equal(t, int64(1), tracker.Usage(quotaDID).Requests, "rate limited requests are not tracked")
+}
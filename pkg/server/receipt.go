@@ -0,0 +1,208 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ReceiptHandler returns a signed receipt (see protocol.IssueReceipt) in
+// the A2A-Receipt header of every successful response to a verified
+// request, so clients can prove what they submitted and when.
+//
+// It must be placed inside DIDAuthMiddleware, which supplies the caller
+// DID, and inside CompressionHandler, so receipts cover the decoded request
+// body; unsigned requests get no receipt:
+//
+//	handler := server.Chain(
+//	    auth.Wrap,
+//	    server.NewCompressionHandler(nil).Wrap,
+//	    server.NewReceiptHandler(agentDID, keyPair).Wrap,
+//	)(rpcHandler)
+//
+// Responses are buffered until the task ID is known: to the end for plain
+// JSON-RPC responses, to the first event for streams.
+type ReceiptHandler struct {
+	issuer  did.AgentDID
+	keyPair sagecrypto.KeyPair
+}
+
+// NewReceiptHandler creates a ReceiptHandler signing receipts as issuer
+func NewReceiptHandler(issuer did.AgentDID, keyPair sagecrypto.KeyPair) *ReceiptHandler {
+	return &ReceiptHandler{issuer: issuer, keyPair: keyPair}
+}
+
+// Wrap wraps an HTTP handler with receipt issuance
+func (h *ReceiptHandler) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject, ok := GetAgentDIDFromContext(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body []byte
+		if r.Body != nil {
+			body, _ = io.ReadAll(r.Body)
+			r.Body.Close()
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		rw := &receiptResponseWriter{
+			ResponseWriter: w,
+			issue: func(taskID a2a.TaskID) {
+				receipt, err := protocol.IssueReceipt(h.issuer, h.keyPair, subject, body, taskID)
+				if err != nil {
+					// A receipt that cannot be signed is omitted rather
+					// than failing a request that was already processed
+					return
+				}
+				w.Header().Set(protocol.ReceiptHeader, receipt.Token)
+			},
+		}
+		defer rw.close()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// receiptResponseWriter buffers a response until its outcome and task ID
+// are known, then adds the receipt header and writes it out
+type receiptResponseWriter struct {
+	http.ResponseWriter
+	issue func(taskID a2a.TaskID)
+
+	status  int
+	buf     []byte
+	decided bool
+}
+
+func (w *receiptResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *receiptResponseWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if w.isStream() && bytes.Contains(w.buf, []byte("\n\n")) {
+		if err := w.start(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush implements http.Flusher so SSE handlers keep working
+func (w *receiptResponseWriter) Flush() {
+	if !w.decided {
+		if err := w.start(); err != nil {
+			return
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *receiptResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// isStream reports whether the handler is producing an SSE stream
+func (w *receiptResponseWriter) isStream() bool {
+	return strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream")
+}
+
+// start issues the receipt if the buffered response is a success, then
+// writes the header and buffered data
+func (w *receiptResponseWriter) start() error {
+	w.decided = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if w.status == http.StatusOK {
+		result := w.buf
+		if w.isStream() {
+			result = firstEventData(w.buf)
+		}
+		if taskID, ok := responseTaskID(result); ok {
+			w.issue(taskID)
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// close writes out a response that is still buffered
+func (w *receiptResponseWriter) close() {
+	if !w.decided {
+		_ = w.start()
+	}
+}
+
+// firstEventData returns the data of the first event of an SSE stream
+func firstEventData(stream []byte) []byte {
+	event, _, _ := bytes.Cut(stream, []byte("\n\n"))
+	return eventData(event)
+}
+
+// responseTaskID reports whether body is a successful JSON-RPC response,
+// returning the ID of the task its result belongs to, if any
+func responseTaskID(body []byte) (a2a.TaskID, bool) {
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  any             `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Result == nil || resp.Error != nil {
+		return "", false
+	}
+	var result struct {
+		Kind   string     `json:"kind"`
+		ID     a2a.TaskID `json:"id"`
+		TaskID a2a.TaskID `json:"taskId"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return "", true
+	}
+	if result.Kind == "task" {
+		return result.ID, true
+	}
+	return result.TaskID, true
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptHandler(t *testing.T) {
+	const (
+		caller = did.AgentDID("did:sage:ethereum:0xabc")
+		issuer = did.AgentDID("did:sage:ethereum:0xserver")
+		body   = `{"jsonrpc":"2.0","method":"message/send","id":1}`
+	)
+	serverKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	serve := func(response string, signed bool) *httptest.ResponseRecorder {
+		middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: caller})
+		middleware.SetOptional(true)
+		handler := Chain(middleware.Wrap, NewReceiptHandler(issuer, serverKey).Wrap)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ := io.ReadAll(r.Body)
+				assert.Equal(t, body, string(got))
+				_, _ = w.Write([]byte(response))
+			}))
+		req := signedRequest(body)
+		if !signed {
+			req.Header.Del("Signature")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(`{"jsonrpc":"2.0","id":1,"result":{"kind":"task","id":"task-1"}}`, true)
+	r, err := protocol.VerifyReceipt(rec.Header().Get(protocol.ReceiptHeader), serverKey.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", string(r.TaskID))
+	require.NoError(t, r.Matches(issuer, caller, []byte(body)))
+	assert.Contains(t, rec.Body.String(), "task-1")
+
+	rec = serve(`{"jsonrpc":"2.0","id":1,"result":{"kind":"message","messageId":"m","taskId":"task-2"}}`, true)
+	r, err = protocol.VerifyReceipt(rec.Header().Get(protocol.ReceiptHeader), serverKey.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, "task-2", string(r.TaskID))
+
+	// Failed calls and unsigned requests get no receipt
+	rec = serve(`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"not found"}}`, true)
+	assert.Empty(t, rec.Header().Get(protocol.ReceiptHeader))
+	assert.Contains(t, rec.Body.String(), "not found")
+	rec = serve(`{"jsonrpc":"2.0","id":1,"result":{"kind":"task","id":"task-1"}}`, false)
+	assert.Empty(t, rec.Header().Get(protocol.ReceiptHeader))
+}
+
+func TestReceiptHandler_Stream(t *testing.T) {
+	serverKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	handler := middleware.Wrap(NewReceiptHandler("did:sage:ethereum:0xserver", serverKey).Wrap(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":{\"kind\":\"status-update\",\"taskId\":\"task-3\"}}\n\n"))
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte("data: {}\n\n"))
+		})))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(`{}`))
+	r, err := protocol.VerifyReceipt(rec.Header().Get(protocol.ReceiptHeader), serverKey.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, "task-3", string(r.TaskID))
+	assert.True(t, rec.Flushed)
+	assert.Contains(t, rec.Body.String(), "data: {}")
+}
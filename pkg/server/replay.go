@@ -0,0 +1,213 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultReplayTTL is how long a signature is remembered when it has no
+// expires parameter and ReplayConfig.TTL is zero
+const DefaultReplayTTL = 5 * time.Minute
+
+var (
+	// ErrReplayedRequest is returned for a signature, or nonce, seen before
+	ErrReplayedRequest = errors.New("replayed request")
+
+	// ErrReplayCheckFailed is returned when the replay store cannot be
+	// consulted. The default error handler responds with 503.
+	ErrReplayCheckFailed = errors.New("replay check failed")
+)
+
+// ReplayStore remembers request identifiers to detect replays. It must be
+// shared by all replicas serving the same agent.
+type ReplayStore interface {
+	// CheckAndStore records key for ttl and reports whether it was already
+	// recorded. It must be atomic across concurrent callers.
+	CheckAndStore(ctx context.Context, key string, ttl time.Duration) (seen bool, err error)
+}
+
+// ReplayLookup is implemented by replay stores that can report whether a
+// key was recorded without recording it. Prechecks use it to reject
+// replays before verifying their signature.
+type ReplayLookup interface {
+	Seen(ctx context.Context, key string) (bool, error)
+}
+
+// ReplayConfig configures replay protection
+type ReplayConfig struct {
+	// Store records seen requests (default NewMemoryReplayStore(), which
+	// only protects a single replica)
+	Store ReplayStore
+
+	// TTL is how long signatures are remembered, or until they expire if
+	// sooner (default DefaultReplayTTL). With prechecks, their MaxAge plus
+	// ClockSkew is used instead. It should be at least the maximum
+	// signature age the verifier accepts.
+	TTL time.Duration
+}
+
+// SetReplayProtection rejects requests whose signature was already
+// accepted. Signatures with a nonce are identified by signer and nonce,
+// others by their decoded signature bytes; each is remembered until it
+// expires. Pass nil to disable replay protection.
+func (m *DIDAuthMiddleware) SetReplayProtection(cfg *ReplayConfig) {
+	if cfg != nil {
+		c := *cfg
+		if c.Store == nil {
+			c.Store = NewMemoryReplayStore()
+		}
+		if c.TTL <= 0 {
+			c.TTL = DefaultReplayTTL
+		}
+		cfg = &c
+	}
+	m.update(func(c *middlewareConfig) {
+		c.replay = cfg
+	})
+}
+
+// checkReplay records the verified signature of r, failing if it was seen
+func (m *middlewareConfig) checkReplay(ctx context.Context, r *http.Request, agentDID did.AgentDID) error {
+	key, ttl, err := m.replayKey(r, agentDID)
+	if err != nil {
+		return err
+	}
+	seen, err := m.replay.Store.CheckAndStore(ctx, key, ttl)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrReplayCheckFailed, err)
+	}
+	if seen {
+		return ErrReplayedRequest
+	}
+	return nil
+}
+
+// lookupReplay reports ErrReplayedRequest if the signature of r, not yet
+// verified, was already recorded. It records nothing, and does nothing for
+// stores that do not implement ReplayLookup.
+func (m *middlewareConfig) lookupReplay(ctx context.Context, r *http.Request, agentDID did.AgentDID) error {
+	lookup, ok := m.replay.Store.(ReplayLookup)
+	if !ok {
+		return nil
+	}
+	key, _, err := m.replayKey(r, agentDID)
+	if err != nil {
+		return err
+	}
+	seen, err := lookup.Seen(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrReplayCheckFailed, err)
+	}
+	if seen {
+		return ErrReplayedRequest
+	}
+	return nil
+}
+
+// replayKey returns the store key identifying the signature of r and how
+// long to remember it: until the signature expires, but no longer than
+// signatures are accepted, so clients cannot pick the lifetime of entries
+func (m *middlewareConfig) replayKey(r *http.Request, agentDID did.AgentDID) (string, time.Duration, error) {
+	var fp RequestFingerprint
+	parseSignature(r, &fp)
+
+	// Signatures are identified by their bytes, so a signature re-encoded
+	// or sent along with other signatures is still recognized
+	var id string
+	if fp.Nonce != "" {
+		id = "nonce:" + fp.Nonce
+	} else {
+		sig, err := signer.DecodeSignatureHeader(r.Header.Get("Signature"), fp.Label)
+		if err != nil {
+			return "", 0, fmt.Errorf("%w: %v", ErrMalformedSignature, err)
+		}
+		id = "sig:" + hex.EncodeToString(sig)
+	}
+	sum := sha256.Sum256([]byte(string(agentDID) + "\n" + id))
+
+	limit := m.replay.TTL
+	if m.prechecks != nil {
+		limit = m.prechecks.MaxAge + m.prechecks.ClockSkew
+	}
+	ttl := limit
+	if !fp.Expires.IsZero() {
+		ttl = min(max(time.Until(fp.Expires), time.Second), limit)
+	}
+	return hex.EncodeToString(sum[:]), ttl, nil
+}
+
+// MemoryReplayStore is an in-process ReplayStore
+type MemoryReplayStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	inserts int
+}
+
+// NewMemoryReplayStore creates an empty in-memory replay store
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{entries: make(map[string]time.Time)}
+}
+
+// CheckAndStore implements ReplayStore
+func (s *MemoryReplayStore) CheckAndStore(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until, ok := s.entries[key]; ok && now.Before(until) {
+		return true, nil
+	}
+	s.entries[key] = now.Add(ttl)
+
+	// Sweep expired entries every so often
+	s.inserts++
+	if s.inserts >= 1024 {
+		s.inserts = 0
+		for k, until := range s.entries {
+			if !now.Before(until) {
+				delete(s.entries, k)
+			}
+		}
+	}
+	return false, nil
+}
+
+// Seen implements ReplayLookup
+func (s *MemoryReplayStore) Seen(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.entries[key]
+	return ok && time.Now().Before(until), nil
+}
+
+// Len returns the number of remembered entries, including expired ones
+// not yet swept
+func (s *MemoryReplayStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
@@ -0,0 +1,307 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults for RedisReplayConfig
+const (
+	DefaultRedisReplayPrefix    = "a2a:replay:"
+	DefaultRedisReplayBatchSize = 64
+	DefaultRedisReplayTimeout   = time.Second
+)
+
+// ErrReplayStoreClosed is returned by a closed RedisReplayStore
+var ErrReplayStoreClosed = errors.New("replay store closed")
+
+// RedisSetNX is one SET key 1 NX PX ttl command
+type RedisSetNX struct {
+	Key string
+	TTL time.Duration
+}
+
+// RedisClient runs batches of SET NX commands. Implementations should send
+// each batch as one pipeline and report, per command, whether the key was
+// set. With github.com/redis/go-redis/v9:
+//
+//	type goRedis struct{ *redis.Client }
+//
+//	func (c goRedis) SetNX(ctx context.Context, cmds []server.RedisSetNX) ([]bool, error) {
+//	    pipe := c.Pipeline()
+//	    results := make([]*redis.BoolCmd, len(cmds))
+//	    for i, cmd := range cmds {
+//	        results[i] = pipe.SetNX(ctx, cmd.Key, 1, cmd.TTL)
+//	    }
+//	    if _, err := pipe.Exec(ctx); err != nil {
+//	        return nil, err
+//	    }
+//	    set := make([]bool, len(cmds))
+//	    for i, r := range results {
+//	        set[i] = r.Val()
+//	    }
+//	    return set, nil
+//	}
+type RedisClient interface {
+	SetNX(ctx context.Context, cmds []RedisSetNX) ([]bool, error)
+}
+
+// RedisReplayConfig configures a RedisReplayStore
+type RedisReplayConfig struct {
+	// Client runs the commands
+	Client RedisClient
+
+	// Prefix is prepended to every key (default DefaultRedisReplayPrefix)
+	Prefix string
+
+	// BatchSize is the maximum number of checks sent in one pipeline
+	// (default DefaultRedisReplayBatchSize)
+	BatchSize int
+
+	// BatchWindow is how long a check waits for others to share its
+	// pipeline. Zero batches only checks that are already waiting.
+	BatchWindow time.Duration
+
+	// Timeout bounds each pipeline (default DefaultRedisReplayTimeout)
+	Timeout time.Duration
+
+	// FailOpen admits requests when Redis cannot be reached. By default
+	// such requests fail with ErrReplayCheckFailed, i.e. 503 responses.
+	FailOpen bool
+}
+
+// RedisReplayStats counts the checks of a RedisReplayStore
+type RedisReplayStats struct {
+	Checks     uint64 // checks answered
+	Replays    uint64 // checks that found a replay
+	Errors     uint64 // checks that failed because Redis did
+	FailedOpen uint64 // failed checks admitted because of FailOpen
+	Batches    uint64 // pipelines sent
+}
+
+// RedisReplayStore is a ReplayStore shared by all replicas through Redis.
+// Concurrent checks are batched into pipelines by a background goroutine,
+// stopped by Close.
+type RedisReplayStore struct {
+	config   RedisReplayConfig
+	requests chan *replayCheck
+	done     chan struct{}
+	wg       sync.WaitGroup
+	close    sync.Once
+
+	checks, replays, errs, failedOpen, batches atomic.Uint64
+}
+
+// replayCheck is a pending CheckAndStore call
+type replayCheck struct {
+	ctx    context.Context
+	cmd    RedisSetNX
+	result chan replayResult
+}
+
+type replayResult struct {
+	seen bool
+	err  error
+}
+
+// NewRedisReplayStore creates a store using config.Client
+func NewRedisReplayStore(config RedisReplayConfig) (*RedisReplayStore, error) {
+	if config.Client == nil {
+		return nil, fmt.Errorf("redis client is required")
+	}
+	if config.Prefix == "" {
+		config.Prefix = DefaultRedisReplayPrefix
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultRedisReplayBatchSize
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultRedisReplayTimeout
+	}
+	s := &RedisReplayStore{
+		config:   config,
+		requests: make(chan *replayCheck, config.BatchSize),
+		done:     make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+// CheckAndStore implements ReplayStore. A check whose ctx ends before its
+// pipeline is sent is dropped from it, so the key is not stored; once sent,
+// the key may be stored even though ctx.Err() is returned.
+func (s *RedisReplayStore) CheckAndStore(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	check := &replayCheck{
+		ctx:    ctx,
+		cmd:    RedisSetNX{Key: s.config.Prefix + key, TTL: ttl},
+		result: make(chan replayResult, 1),
+	}
+	select {
+	case s.requests <- check:
+	case <-s.done:
+		return false, ErrReplayStoreClosed
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	var res replayResult
+	select {
+	case res = <-check.result:
+	case <-s.done:
+		return false, ErrReplayStoreClosed
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	s.checks.Add(1)
+	switch {
+	case res.err == nil:
+		if res.seen {
+			s.replays.Add(1)
+		}
+		return res.seen, nil
+	case s.config.FailOpen:
+		s.errs.Add(1)
+		s.failedOpen.Add(1)
+		return false, nil
+	default:
+		s.errs.Add(1)
+		return false, res.err
+	}
+}
+
+// Stats returns the store's counters
+func (s *RedisReplayStore) Stats() RedisReplayStats {
+	return RedisReplayStats{
+		Checks:     s.checks.Load(),
+		Replays:    s.replays.Load(),
+		Errors:     s.errs.Load(),
+		FailedOpen: s.failedOpen.Load(),
+		Batches:    s.batches.Load(),
+	}
+}
+
+// Close stops the batching goroutine. Pending and later checks fail with
+// ErrReplayStoreClosed.
+func (s *RedisReplayStore) Close() error {
+	s.close.Do(func() { close(s.done) })
+	s.wg.Wait()
+	return nil
+}
+
+// run collects checks into batches and sends them
+func (s *RedisReplayStore) run() {
+	defer s.wg.Done()
+	for {
+		var first *replayCheck
+		select {
+		case first = <-s.requests:
+		case <-s.done:
+			s.drain()
+			return
+		}
+		s.flush(s.collect(first))
+	}
+}
+
+// collect gathers checks to send along with first
+func (s *RedisReplayStore) collect(first *replayCheck) []*replayCheck {
+	batch := []*replayCheck{first}
+	var window <-chan time.Time
+	if s.config.BatchWindow > 0 {
+		timer := time.NewTimer(s.config.BatchWindow)
+		defer timer.Stop()
+		window = timer.C
+	}
+	for len(batch) < s.config.BatchSize {
+		if window == nil {
+			select {
+			case check := <-s.requests:
+				batch = append(batch, check)
+				continue
+			default:
+			}
+			return batch
+		}
+		select {
+		case check := <-s.requests:
+			batch = append(batch, check)
+		case <-window:
+			return batch
+		case <-s.done:
+			return batch
+		}
+	}
+	return batch
+}
+
+// drain answers checks queued when the store was closed
+func (s *RedisReplayStore) drain() {
+	for {
+		select {
+		case check := <-s.requests:
+			check.result <- replayResult{err: ErrReplayStoreClosed}
+		default:
+			return
+		}
+	}
+}
+
+// flush sends one batch and answers its checks. Checks whose caller gave
+// up are dropped first, so their keys stay free for a retry.
+func (s *RedisReplayStore) flush(batch []*replayCheck) {
+	live := batch[:0]
+	for _, check := range batch {
+		if err := check.ctx.Err(); err != nil {
+			check.result <- replayResult{err: err}
+			continue
+		}
+		live = append(live, check)
+	}
+	batch = live
+	if len(batch) == 0 {
+		return
+	}
+
+	cmds := make([]RedisSetNX, len(batch))
+	for i, check := range batch {
+		cmds[i] = check.cmd
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	set, err := s.config.Client.SetNX(ctx, cmds)
+	cancel()
+	s.batches.Add(1)
+	if err == nil && len(set) != len(cmds) {
+		err = fmt.Errorf("redis returned %d results for %d commands", len(set), len(cmds))
+	}
+
+	for i, check := range batch {
+		if err != nil {
+			check.result <- replayResult{err: err}
+			continue
+		}
+		check.result <- replayResult{seen: !set[i]}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayProtection(t *testing.T) {
+	middleware, agentDID, keyPair := streamingFixture(t)
+	store := NewMemoryReplayStore()
+	middleware.SetReplayProtection(&ReplayConfig{Store: store})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, agentDID, keyPair))
+	replay := req.Clone(context.Background())
+	replay.Body = httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`)).Body
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, replay)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), ErrReplayedRequest.Error())
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestReplayProtection_ReencodedSignature(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	store := NewMemoryReplayStore()
+	middleware.SetReplayProtection(&ReplayConfig{Store: store})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := signedRequest(`{}`)
+	req.Header.Set("Signature", "sig1=:3q2+7w==:")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	for _, signature := range []string{
+		"sig1=deadbeef",
+		`sig1="3q2-7w"`,
+		"sig1=:3q2+7w==:, proxy=:AAEC:",
+		"  sig1=:3q2+7w==:  ",
+	} {
+		replay := signedRequest(`{}`)
+		replay.Header.Set("Signature", signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, replay)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, signature)
+		assert.Contains(t, rec.Body.String(), ErrReplayedRequest.Error(), signature)
+	}
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestReplayProtection_QuotedNonce(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	store := NewMemoryReplayStore()
+	middleware.SetReplayProtection(&ReplayConfig{Store: store})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// The nonces differ only after an escaped quote, so they must be
+	// compared in their parsed form
+	serve := func(nonce string) *httptest.ResponseRecorder {
+		req := signedRequest(`{}`)
+		req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xabc";nonce="`+nonce+`"`)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	assert.Equal(t, http.StatusOK, serve(`n\"1`).Code)
+	assert.Equal(t, http.StatusOK, serve(`n\"2`).Code)
+
+	rec := serve(`n\"1`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), ErrReplayedRequest.Error())
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestReplayProtection_StoreFailure(t *testing.T) {
+	middleware, agentDID, keyPair := streamingFixture(t)
+	redis, err := NewRedisReplayStore(RedisReplayConfig{Client: &fakeRedis{fail: true}})
+	require.NoError(t, err)
+	defer redis.Close()
+	middleware.SetReplayProtection(&ReplayConfig{Store: redis})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, agentDID, keyPair))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "fails closed by default")
+}
+
+// fakeRedis is an in-memory RedisClient recording batch sizes
+type fakeRedis struct {
+	mu      sync.Mutex
+	keys    map[string]time.Duration
+	batches []int
+	fail    bool
+}
+
+func (f *fakeRedis) SetNX(ctx context.Context, cmds []RedisSetNX) ([]bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		return nil, errors.New("connection refused")
+	}
+	if f.keys == nil {
+		f.keys = make(map[string]time.Duration)
+	}
+	f.batches = append(f.batches, len(cmds))
+	set := make([]bool, len(cmds))
+	for i, cmd := range cmds {
+		if _, ok := f.keys[cmd.Key]; !ok {
+			f.keys[cmd.Key] = cmd.TTL
+			set[i] = true
+		}
+	}
+	return set, nil
+}
+
+func TestRedisReplayStore(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeRedis{}
+	store, err := NewRedisReplayStore(RedisReplayConfig{Client: client, BatchWindow: 20 * time.Millisecond})
+	require.NoError(t, err)
+	defer store.Close()
+
+	// Concurrent checks share a pipeline
+	var wg sync.WaitGroup
+	seen := make([]bool, 8)
+	for i := range seen {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seen[i], _ = store.CheckAndStore(ctx, "same", time.Minute)
+		}()
+	}
+	wg.Wait()
+
+	replays := 0
+	for _, s := range seen {
+		if s {
+			replays++
+		}
+	}
+	assert.Equal(t, 7, replays, "exactly one check wins")
+	assert.Less(t, len(client.batches), 8)
+	assert.Equal(t, time.Minute, client.keys[DefaultRedisReplayPrefix+"same"])
+
+	stats := store.Stats()
+	assert.Equal(t, uint64(8), stats.Checks)
+	assert.Equal(t, uint64(7), stats.Replays)
+	assert.Equal(t, uint64(len(client.batches)), stats.Batches)
+
+	require.NoError(t, store.Close())
+	_, err = store.CheckAndStore(ctx, "other", time.Minute)
+	assert.ErrorIs(t, err, ErrReplayStoreClosed)
+}
+
+func TestRedisReplayStore_CancelledCheck(t *testing.T) {
+	client := &fakeRedis{}
+	store, err := NewRedisReplayStore(RedisReplayConfig{Client: client, BatchWindow: 100 * time.Millisecond})
+	require.NoError(t, err)
+	defer store.Close()
+
+	// A check given up on while waiting for its pipeline is dropped from it
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = store.CheckAndStore(ctx, "cancelled", time.Minute)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	seen, err := store.CheckAndStore(context.Background(), "other", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, seen)
+
+	client.mu.Lock()
+	assert.NotContains(t, client.keys, DefaultRedisReplayPrefix+"cancelled")
+	assert.Equal(t, []int{1}, client.batches)
+	client.mu.Unlock()
+
+	// so its retry is not taken for a replay
+	seen, err = store.CheckAndStore(context.Background(), "cancelled", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, seen)
+}
+
+func TestRedisReplayStore_FailurePolicy(t *testing.T) {
+	ctx := context.Background()
+	closed, err := NewRedisReplayStore(RedisReplayConfig{Client: &fakeRedis{fail: true}})
+	require.NoError(t, err)
+	defer closed.Close()
+	_, err = closed.CheckAndStore(ctx, "k", time.Minute)
+	assert.Error(t, err)
+
+	open, err := NewRedisReplayStore(RedisReplayConfig{Client: &fakeRedis{fail: true}, FailOpen: true})
+	require.NoError(t, err)
+	defer open.Close()
+	seen, err := open.CheckAndStore(ctx, "k", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, seen)
+	assert.Equal(t, uint64(1), open.Stats().FailedOpen)
+
+	_, err = NewRedisReplayStore(RedisReplayConfig{})
+	assert.Error(t, err)
+}
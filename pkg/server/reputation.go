@@ -0,0 +1,337 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Reputation defaults
+const (
+	// DefaultReputationHalfLife is how long until an outcome counts half
+	DefaultReputationHalfLife = 24 * time.Hour
+
+	// DefaultReputationPrior is the score of an agent without history
+	DefaultReputationPrior = 0.5
+
+	// DefaultReputationPriorWeight is how many outcomes the prior is worth
+	DefaultReputationPriorWeight = 5
+)
+
+// ReputationConfig configures a ReputationTracker
+type ReputationConfig struct {
+	// HalfLife decays old outcomes (default DefaultReputationHalfLife)
+	HalfLife time.Duration
+
+	// Prior is the score of unknown agents, between 0 and 1
+	// (default DefaultReputationPrior)
+	Prior float64
+
+	// PriorWeight is how many outcomes the prior is worth; higher values
+	// make scores move more slowly (default DefaultReputationPriorWeight)
+	PriorWeight float64
+}
+
+// Reputation is a snapshot of one agent's history. Counters are totals;
+// Score weighs recent outcomes more heavily.
+type Reputation struct {
+	AgentDID did.AgentDID `json:"agentDid"`
+
+	// Score is between 0 (bad) and 1 (good)
+	Score float64 `json:"score"`
+
+	Verifications        int64     `json:"verifications"`
+	VerificationFailures int64     `json:"verificationFailures"`
+	TasksCompleted       int64     `json:"tasksCompleted"`
+	TasksFailed          int64     `json:"tasksFailed"`
+	TasksCanceled        int64     `json:"tasksCanceled"`
+	Errors               int64     `json:"errors"`
+	LastSeen             time.Time `json:"lastSeen"`
+}
+
+// ErrorRate is the fraction of verified requests answered with an error
+func (r Reputation) ErrorRate() float64 {
+	if r.Verifications == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Verifications)
+}
+
+type agentHistory struct {
+	stats   Reputation
+	good    float64 // decayed weight of good outcomes
+	bad     float64 // decayed weight of bad outcomes
+	updated time.Time
+}
+
+// ReputationTracker aggregates per-DID verification outcomes, task results
+// and error responses into a reputation score. It is safe for concurrent
+// use.
+//
+// Verification failures are attributed to the DID claimed in the keyid,
+// which anyone can forge, so they are counted but never lower the score.
+type ReputationTracker struct {
+	config ReputationConfig
+	now    func() time.Time
+
+	mu     sync.Mutex
+	agents map[did.AgentDID]*agentHistory
+}
+
+// NewReputationTracker creates a tracker with the given configuration
+func NewReputationTracker(config ReputationConfig) *ReputationTracker {
+	if config.HalfLife <= 0 {
+		config.HalfLife = DefaultReputationHalfLife
+	}
+	if config.Prior <= 0 || config.Prior > 1 {
+		config.Prior = DefaultReputationPrior
+	}
+	if config.PriorWeight <= 0 {
+		config.PriorWeight = DefaultReputationPriorWeight
+	}
+	return &ReputationTracker{
+		config: config,
+		now:    time.Now,
+		agents: make(map[did.AgentDID]*agentHistory),
+	}
+}
+
+// RecordVerification records a verification attempt by agentDID
+func (t *ReputationTracker) RecordVerification(agentDID did.AgentDID, err error) {
+	t.update(agentDID, func(h *agentHistory) {
+		if err != nil {
+			h.stats.VerificationFailures++
+			return
+		}
+		h.stats.Verifications++
+		h.good++
+	})
+}
+
+// RecordTask records the outcome of a task submitted by agentDID. Completed
+// tasks raise the score and failed ones lower it; canceled and rejected
+// tasks are counted only.
+func (t *ReputationTracker) RecordTask(agentDID did.AgentDID, state a2a.TaskState) {
+	t.update(agentDID, func(h *agentHistory) {
+		switch state {
+		case a2a.TaskStateCompleted:
+			h.stats.TasksCompleted++
+			h.good += 2
+		case a2a.TaskStateFailed:
+			h.stats.TasksFailed++
+			h.bad += 2
+		case a2a.TaskStateCanceled, a2a.TaskStateRejected:
+			h.stats.TasksCanceled++
+		}
+	})
+}
+
+// RecordResponse records the status of a response to a verified request
+// from agentDID. Client errors (4xx) lower the score; server errors are
+// counted only, as they are not the caller's fault.
+func (t *ReputationTracker) RecordResponse(agentDID did.AgentDID, status int) {
+	if status < 400 {
+		return
+	}
+	t.update(agentDID, func(h *agentHistory) {
+		h.stats.Errors++
+		if status < 500 {
+			h.bad++
+		}
+	})
+}
+
+// Reputation returns the reputation of agentDID
+func (t *ReputationTracker) Reputation(agentDID did.AgentDID) Reputation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.agents[agentDID]
+	if !ok {
+		return Reputation{AgentDID: agentDID, Score: t.config.Prior}
+	}
+	t.decay(h, t.now())
+	return t.snapshot(h)
+}
+
+// Snapshot returns the reputation of every tracked agent, ordered by DID
+func (t *ReputationTracker) Snapshot() []Reputation {
+	now := t.now()
+
+	t.mu.Lock()
+	out := make([]Reputation, 0, len(t.agents))
+	for _, h := range t.agents {
+		t.decay(h, now)
+		out = append(out, t.snapshot(h))
+	}
+	t.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].AgentDID < out[j].AgentDID })
+	return out
+}
+
+// Handler returns an http.Handler serving Snapshot as JSON, or the
+// reputation of a single DID when the "did" query parameter is set. It is
+// not authenticated; mount it behind the middleware or on an internal
+// listener.
+func (t *ReputationTracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v any
+		if agentDID := r.URL.Query().Get("did"); agentDID != "" {
+			v = t.Reputation(did.AgentDID(agentDID))
+		} else {
+			v = t.Snapshot()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	})
+}
+
+// Authorizer returns an Authorizer denying callers whose score is below
+// min. With rpcMethods set, only those JSON-RPC methods are gated, so
+// expensive operations can require a track record while cheap ones stay
+// open. Unsigned requests are denied for gated methods.
+func (t *ReputationTracker) Authorizer(min float64, rpcMethods ...string) Authorizer {
+	gated := make(map[string]bool, len(rpcMethods))
+	for _, method := range rpcMethods {
+		gated[method] = true
+	}
+	return AuthorizerFunc(func(ctx context.Context, input AuthzInput) (Decision, error) {
+		if len(gated) > 0 && !gated[input.RPCMethod] {
+			return Decision{Allow: true}, nil
+		}
+		if input.AgentDID == "" {
+			return Decision{Reason: "reputation requires a signed request"}, nil
+		}
+		if score := t.Reputation(input.AgentDID).Score; score < min {
+			return Decision{Reason: fmt.Sprintf("reputation %.2f below %.2f", score, min)}, nil
+		}
+		return Decision{Allow: true}, nil
+	})
+}
+
+// update applies fn to agentDID's history after decaying it
+func (t *ReputationTracker) update(agentDID did.AgentDID, fn func(h *agentHistory)) {
+	if agentDID == "" {
+		return
+	}
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h, ok := t.agents[agentDID]
+	if !ok {
+		h = &agentHistory{stats: Reputation{AgentDID: agentDID}, updated: now}
+		t.agents[agentDID] = h
+	}
+	t.decay(h, now)
+	fn(h)
+	h.stats.LastSeen = now
+}
+
+// decay ages the outcome weights of h to now
+func (t *ReputationTracker) decay(h *agentHistory, now time.Time) {
+	elapsed := now.Sub(h.updated)
+	if elapsed <= 0 {
+		return
+	}
+	factor := math.Pow(0.5, float64(elapsed)/float64(t.config.HalfLife))
+	h.good *= factor
+	h.bad *= factor
+	h.updated = now
+}
+
+func (t *ReputationTracker) snapshot(h *agentHistory) Reputation {
+	r := h.stats
+	w := t.config.PriorWeight
+	r.Score = (h.good + t.config.Prior*w) / (h.good + h.bad + w)
+	return r
+}
+
+// SetReputationTracker records verification outcomes and response statuses
+// of every request in tracker, and exposes the caller's reputation through
+// GetReputationFromContext and AuthzInput.Reputation
+func (m *DIDAuthMiddleware) SetReputationTracker(tracker *ReputationTracker) {
+	m.update(func(c *middlewareConfig) {
+		c.reputation = tracker
+	})
+}
+
+// GetReputationFromContext returns the caller's reputation as of when the
+// request was verified
+func GetReputationFromContext(ctx context.Context) (Reputation, bool) {
+	r, ok := ctx.Value(reputationKey).(Reputation)
+	return r, ok
+}
+
+// statusRecorder captures the response status for reputation tracking
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush supports streaming responses
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// serveTracked runs next for a verified request, recording its response
+// status against agentDID
+func (m *middlewareConfig) serveTracked(w http.ResponseWriter, r *http.Request, agentDID did.AgentDID, next http.Handler) {
+	if m.reputation == nil {
+		next.ServeHTTP(w, r)
+		return
+	}
+	rec := &statusRecorder{ResponseWriter: w}
+	next.ServeHTTP(rec, r)
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	m.reputation.RecordResponse(agentDID, rec.status)
+}
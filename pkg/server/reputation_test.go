@@ -0,0 +1,158 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputationTracker_Score(t *testing.T) {
+	tracker := NewReputationTracker(ReputationConfig{HalfLife: time.Hour})
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+
+	good := did.AgentDID("did:sage:ethereum:0xgood")
+	bad := did.AgentDID("did:sage:ethereum:0xbad")
+
+	assert.Equal(t, DefaultReputationPrior, tracker.Reputation(good).Score)
+
+	for i := 0; i < 10; i++ {
+		tracker.RecordVerification(good, nil)
+		tracker.RecordTask(good, a2a.TaskStateCompleted)
+		tracker.RecordVerification(bad, nil)
+		tracker.RecordResponse(bad, http.StatusBadRequest)
+		tracker.RecordTask(bad, a2a.TaskStateFailed)
+	}
+	tracker.RecordResponse(good, http.StatusInternalServerError)
+	tracker.RecordVerification(good, errors.New("forged keyid"))
+
+	g, b := tracker.Reputation(good), tracker.Reputation(bad)
+	assert.Greater(t, g.Score, 0.9)
+	assert.Less(t, b.Score, 0.3)
+	assert.Equal(t, int64(10), g.TasksCompleted)
+	assert.Equal(t, int64(1), g.VerificationFailures)
+	assert.Equal(t, int64(1), g.Errors)
+	assert.InDelta(t, 1.0, b.ErrorRate(), 1e-9)
+
+	// Old outcomes fade back towards the prior
+	now = now.Add(24 * time.Hour)
+	assert.InDelta(t, DefaultReputationPrior, tracker.Reputation(bad).Score, 0.01)
+
+	snapshot := tracker.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, bad, snapshot[0].AgentDID)
+}
+
+func TestReputationTracker_Authorizer(t *testing.T) {
+	tracker := NewReputationTracker(ReputationConfig{})
+	agentDID := did.AgentDID("did:sage:ethereum:0xabc")
+	authz := tracker.Authorizer(0.6, "message/send")
+
+	decision, err := authz.Authorize(context.Background(), AuthzInput{AgentDID: agentDID, RPCMethod: "tasks/get"})
+	require.NoError(t, err)
+	assert.True(t, decision.Allow)
+
+	decision, err = authz.Authorize(context.Background(), AuthzInput{AgentDID: agentDID, RPCMethod: "message/send"})
+	require.NoError(t, err)
+	assert.False(t, decision.Allow)
+	assert.Contains(t, decision.Reason, "reputation")
+
+	for i := 0; i < 5; i++ {
+		tracker.RecordTask(agentDID, a2a.TaskStateCompleted)
+	}
+	decision, err = authz.Authorize(context.Background(), AuthzInput{AgentDID: agentDID, RPCMethod: "message/send"})
+	require.NoError(t, err)
+	assert.True(t, decision.Allow)
+
+	decision, err = authz.Authorize(context.Background(), AuthzInput{RPCMethod: "message/send"})
+	require.NoError(t, err)
+	assert.False(t, decision.Allow)
+}
+
+func TestDIDAuthMiddleware_ReputationTracker(t *testing.T) {
+	agentDID := did.AgentDID("did:sage:ethereum:0xabc")
+	tracker := NewReputationTracker(ReputationConfig{})
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: agentDID})
+	middleware.SetReputationTracker(tracker)
+	var authzScore *float64
+	middleware.SetAuthorizer(AuthorizerFunc(func(ctx context.Context, input AuthzInput) (Decision, error) {
+		authzScore = input.Reputation
+		return Decision{Allow: true}, nil
+	}))
+
+	var fromCtx Reputation
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fromCtx, _ = GetReputationFromContext(r.Context())
+		http.Error(w, "bad params", http.StatusBadRequest)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, signedRequest(`{"jsonrpc":"2.0","id":1,"method":"message/send"}`))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	require.NotNil(t, authzScore)
+	assert.Equal(t, agentDID, fromCtx.AgentDID)
+	assert.Equal(t, int64(1), fromCtx.Verifications)
+
+	rep := tracker.Reputation(agentDID)
+	assert.Equal(t, int64(1), rep.Verifications)
+	assert.Equal(t, int64(1), rep.Errors)
+
+	// Failures are attributed to the claimed keyid
+	failing := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: false})
+	failing.SetReputationTracker(tracker)
+	failing.Wrap(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(), signedRequest(`{}`))
+	assert.Equal(t, int64(1), tracker.Reputation(agentDID).VerificationFailures)
+
+	rr = httptest.NewRecorder()
+	tracker.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/reputation?did="+string(agentDID), nil))
+	var served Reputation
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&served))
+	assert.Equal(t, int64(1), served.Errors)
+}
+
+func TestTaskQueue_RecordsReputation(t *testing.T) {
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		return nil
+	}}
+	tracker := NewReputationTracker(ReputationConfig{})
+	q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1, Reputation: tracker})
+	defer q.Close()
+
+	agentDID := did.AgentDID("did:sage:ethereum:0xabc")
+	task, err := q.Submit(context.WithValue(context.Background(), agentDIDKey, agentDID), userMessage("hello"))
+	require.NoError(t, err)
+	reader, err := q.Subscribe(context.Background(), task.ID)
+	require.NoError(t, err)
+	readUntilFinal(t, reader)
+
+	assert.Equal(t, int64(1), tracker.Reputation(agentDID).TasksCompleted)
+}
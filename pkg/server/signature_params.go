@@ -0,0 +1,83 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+)
+
+// GetSignatureParamsFromContext returns the parameters of the verified
+// request signature, such as tag and parameters defined by extensions
+func GetSignatureParamsFromContext(ctx context.Context) (*signer.SignatureParams, bool) {
+	params, ok := ctx.Value(signatureParamsKey).(*signer.SignatureParams)
+	return params, ok
+}
+
+// withSignatureParams adds the parameters of the signature verified on r
+// to ctx, if they parse
+func (m *middlewareConfig) withSignatureParams(ctx context.Context, r *http.Request) context.Context {
+	params, err := signer.LookupSignatureParams(r.Header.Get("Signature-Input"), signatureLabel(r))
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, signatureParamsKey, params)
+}
+
+// selectSignature records in the context of r the label of the signature
+// the verifier checks. Parameters read from Signature-Input before and
+// after verification then come from that signature, never from another
+// member a client added. A request with several signatures is rejected
+// if the verifier cannot report which of them it checks.
+func (m *middlewareConfig) selectSignature(r *http.Request) (*http.Request, error) {
+	var label string
+	if reader, ok := m.verifier.(verifier.SignatureParamsReader); ok {
+		if params, err := reader.SignatureParams(r); err == nil {
+			label = params.Label
+		}
+	}
+	if label == "" {
+		labels := signatureLabels(r.Header.Get("Signature-Input"))
+		if len(labels) > 1 {
+			return r, fmt.Errorf("%w: cannot tell which of %d signatures is verified", ErrMalformedSignature, len(labels))
+		}
+		if len(labels) == 1 {
+			label = labels[0]
+		}
+	}
+	return r.WithContext(context.WithValue(r.Context(), signatureLabelKey, label)), nil
+}
+
+// signatureLabel returns the label of the signature verified on r. Outside
+// the middleware it is the label a verifier picks by default:
+// signer.DefaultSignatureLabel if present, else the first in label order.
+func signatureLabel(r *http.Request) string {
+	if label, ok := r.Context().Value(signatureLabelKey).(string); ok {
+		return label
+	}
+	labels := signatureLabels(r.Header.Get("Signature-Input"))
+	if len(labels) == 0 || slices.Contains(labels, signer.DefaultSignatureLabel) {
+		return signer.DefaultSignatureLabel
+	}
+	return slices.Min(labels)
+}
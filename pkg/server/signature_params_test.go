@@ -0,0 +1,123 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDAuthMiddleware_SignatureParams(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+
+	var params *signer.SignatureParams
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, _ = GetSignatureParamsFromContext(r.Context())
+	}))
+
+	req := signedRequest(`{}`)
+	req.Header.Set("Signature-Input", `sig1=("@method");created=1700000000;keyid="did:sage:ethereum:0xabc";tag="a2a";hop=2`)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	require.NotNil(t, params)
+	assert.Equal(t, "a2a", params.Tag)
+	v, ok := params.Param("hop")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), v)
+}
+
+func TestDIDAuthMiddleware_SignatureParamsStreaming(t *testing.T) {
+	middleware, agentDID, keyPair := streamingFixture(t)
+
+	var params *signer.SignatureParams
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, _ = GetSignatureParamsFromContext(r.Context())
+		_, _ = io.ReadAll(r.Body)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, streamedRequest(t, agentDID, keyPair, `{"jsonrpc":"2.0","id":1,"method":"message/send"}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	require.NotNil(t, params)
+	assert.Equal(t, string(agentDID), params.KeyID)
+	assert.Contains(t, params.Components, "a2a-trailer-digest")
+}
+
+// selectingVerifier is a mockDIDVerifier that checks the sig1 signature
+type selectingVerifier struct {
+	mockDIDVerifier
+}
+
+func (v *selectingVerifier) SignatureParams(r *http.Request) (*signer.SignatureParams, error) {
+	return signer.LookupSignatureParams(r.Header.Get("Signature-Input"), signer.DefaultSignatureLabel)
+}
+
+func TestDIDAuthMiddleware_ForgedSignatureLabel(t *testing.T) {
+	forged := func() *http.Request {
+		req := signedRequest(`{}`)
+		req.Header.Set(protocol.PriorityHeader, "high")
+		req.Header.Set("Signature-Input",
+			`sig0=("@method" "a2a-priority");keyid="did:sage:ethereum:0xabc";nonce="forged", `+
+				`sig1=("@method");keyid="did:sage:ethereum:0xabc";nonce="genuine"`)
+		req.Header.Set("Signature", "sig0=:AAAA:, sig1=:AAAA:")
+		return req
+	}
+
+	t.Run("verified signature is used", func(t *testing.T) {
+		middleware := NewDIDAuthMiddlewareWithVerifier(&selectingVerifier{mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"}})
+		var fingerprint *RequestFingerprint
+		middleware.SetFingerprintHook(func(r *http.Request, fp *RequestFingerprint) {
+			fingerprint = fp
+		})
+
+		var (
+			params      *signer.SignatureParams
+			hasPriority bool
+		)
+		handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			params, _ = GetSignatureParamsFromContext(r.Context())
+			_, hasPriority = GetPriorityFromContext(r.Context())
+		}))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, forged())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		require.NotNil(t, params)
+		assert.Equal(t, "sig1", params.Label)
+		assert.Equal(t, "genuine", params.Nonce)
+		assert.False(t, hasPriority, "priority is only covered by the forged signature")
+		require.NotNil(t, fingerprint)
+		assert.Equal(t, "sig1", fingerprint.Label)
+		assert.Equal(t, "genuine", fingerprint.Nonce)
+	})
+
+	t.Run("ambiguous without selection", func(t *testing.T) {
+		middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+		rr := httptest.NewRecorder()
+		middleware.Wrap(http.NotFoundHandler()).ServeHTTP(rr, forged())
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
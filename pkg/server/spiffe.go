@@ -0,0 +1,213 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// SPIFFEMode selects how a SPIFFE SVID presented over mTLS combines with
+// DID signatures
+type SPIFFEMode int
+
+const (
+	// SPIFFEEither accepts a DID signature or, for unsigned requests, an
+	// SVID mapped to an agent DID
+	SPIFFEEither SPIFFEMode = iota
+
+	// SPIFFEBoth requires a DID signature and an SVID. With a Mapper set,
+	// the SVID must map to the DID that signed the request.
+	SPIFFEBoth
+)
+
+// SPIFFEMapper maps a SPIFFE ID to the agent DID it belongs to
+type SPIFFEMapper func(ctx context.Context, spiffeID string) (did.AgentDID, error)
+
+// NewStaticSPIFFEMapper creates a SPIFFEMapper from a fixed table
+func NewStaticSPIFFEMapper(table map[string]did.AgentDID) SPIFFEMapper {
+	return func(ctx context.Context, spiffeID string) (did.AgentDID, error) {
+		agentDID, ok := table[spiffeID]
+		if !ok {
+			return "", fmt.Errorf("no agent DID mapped to %s", spiffeID)
+		}
+		return agentDID, nil
+	}
+}
+
+// SPIFFEConfig configures SVID handling in DIDAuthMiddleware. TLS client
+// authentication itself is performed by the http.Server; configure its
+// TLSConfig with ClientAuth and the trust bundle as ClientCAs.
+type SPIFFEConfig struct {
+	Mode SPIFFEMode
+
+	// TrustDomains lists the accepted trust domains, e.g. "cluster.local".
+	// Empty accepts any trust domain the TLS layer verified.
+	TrustDomains []string
+
+	// Mapper links SPIFFE IDs to agent DIDs. Required for SPIFFEEither.
+	Mapper SPIFFEMapper
+}
+
+// SetSPIFFE enables SPIFFE SVID interop. When nil (the default), client
+// certificates are ignored.
+func (m *DIDAuthMiddleware) SetSPIFFE(cfg *SPIFFEConfig) {
+	m.update(func(c *middlewareConfig) {
+		c.spiffe = cfg
+	})
+}
+
+// GetSPIFFEIDFromContext extracts the peer's SPIFFE ID from request context
+func GetSPIFFEIDFromContext(ctx context.Context) (string, bool) {
+	spiffeID, ok := ctx.Value(spiffeIDKey).(string)
+	return spiffeID, ok
+}
+
+// SPIFFEIDFromRequest returns the SPIFFE ID of the verified mTLS client
+// certificate, if any
+func SPIFFEIDFromRequest(r *http.Request) (string, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return spiffeIDFromCert(r.TLS.VerifiedChains[0][0])
+}
+
+// spiffeIDFromCert returns the spiffe:// URI SAN of cert. An SVID has
+// exactly one.
+func spiffeIDFromCert(cert *x509.Certificate) (string, bool) {
+	var id string
+	for _, uri := range cert.URIs {
+		if uri.Scheme != "spiffe" {
+			continue
+		}
+		if id != "" {
+			return "", false
+		}
+		id = uri.String()
+	}
+	return id, id != ""
+}
+
+// peerID returns the client's SPIFFE ID if it belongs to an accepted trust domain
+func (c *SPIFFEConfig) peerID(r *http.Request) (string, bool) {
+	id, ok := SPIFFEIDFromRequest(r)
+	if !ok {
+		return "", false
+	}
+	if len(c.TrustDomains) == 0 {
+		return id, true
+	}
+	domain := strings.TrimPrefix(id, "spiffe://")
+	if i := strings.IndexByte(domain, '/'); i != -1 {
+		domain = domain[:i]
+	}
+	for _, td := range c.TrustDomains {
+		if strings.EqualFold(td, domain) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// checkSVID applies the SPIFFE policy to a request signed by agentDID.
+// It returns the peer's SPIFFE ID, or "" if none was presented.
+func (m *middlewareConfig) checkSVID(ctx context.Context, r *http.Request, agentDID did.AgentDID) (string, error) {
+	if m.spiffe == nil {
+		return "", nil
+	}
+	spiffeID, ok := m.spiffe.peerID(r)
+	if m.spiffe.Mode != SPIFFEBoth {
+		return spiffeID, nil
+	}
+	if !ok {
+		return "", fmt.Errorf("missing SPIFFE SVID")
+	}
+	if m.spiffe.Mapper != nil {
+		mapped, err := m.spiffe.Mapper(ctx, spiffeID)
+		if err != nil {
+			return "", fmt.Errorf("failed to map SPIFFE ID: %w", err)
+		}
+		if mapped != agentDID {
+			return "", fmt.Errorf("SPIFFE ID %s is not linked to %s", spiffeID, agentDID)
+		}
+	}
+	return spiffeID, nil
+}
+
+// serveSVID authenticates an unsigned request by its SVID alone (SPIFFEEither)
+func (m *middlewareConfig) serveSVID(w http.ResponseWriter, r *http.Request, spiffeID string, next http.Handler) {
+	var bodyBytes []byte
+	if r.Body != nil {
+		bodyBytes, _ = io.ReadAll(r.Body)
+		r.Body.Close()
+	}
+	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+	if m.spiffe.Mapper == nil {
+		m.fail(w, r, int64(len(bodyBytes)), fmt.Errorf("SPIFFE authentication failed: no mapper configured"))
+		return
+	}
+	ctx := verifier.WithResolutionCache(r.Context())
+	agentDID, err := m.spiffe.Mapper(ctx, spiffeID)
+	if err != nil {
+		m.fail(w, r, int64(len(bodyBytes)), fmt.Errorf("SPIFFE authentication failed: %w", err))
+		return
+	}
+	m.notifyVerification(r, agentDID, nil)
+	m.notifyFingerprint(r, int64(len(bodyBytes)), agentDID, nil)
+
+	// Grants are ignored here: without a signature they are not covered
+	ctx, err = m.applyCapabilities(ctx, r, agentDID)
+	if err != nil {
+		m.deny(w, r, agentDID, err)
+		return
+	}
+
+	request := rpcBody(r, bodyBytes)
+	ctx, err = m.checkAttestations(ctx, r, agentDID, rpcMethod(request))
+	if err != nil {
+		m.deny(w, r, agentDID, err)
+		return
+	}
+
+	if m.authorizer != nil {
+		if err := m.authorize(ctx, r, agentDID, request); err != nil {
+			m.deny(w, r, agentDID, err)
+			return
+		}
+	}
+
+	ctx, release, ok := m.admit(ctx, w, agentDID, int64(len(bodyBytes)), rpcMethod(request))
+	if !ok {
+		return
+	}
+	defer release()
+
+	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	ctx = context.WithValue(ctx, agentDIDKey, agentDID)
+	ctx = context.WithValue(ctx, spiffeIDKey, spiffeID)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
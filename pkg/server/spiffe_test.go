@@ -0,0 +1,140 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+)
+
+// withSVID attaches a verified client certificate carrying spiffeID to req
+func withSVID(req *http.Request, spiffeID string) *http.Request {
+	u, _ := url.Parse(spiffeID)
+	cert := &x509.Certificate{URIs: []*url.URL{u}}
+	req.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert}},
+	}
+	return req
+}
+
+func TestSPIFFEIDFromRequest(t *testing.T) {
+	req := httptest.NewRequest("POST", "/rpc", nil)
+	_, ok := SPIFFEIDFromRequest(req)
+	assert.False(t, ok)
+
+	// Unverified certificates are ignored
+	u, _ := url.Parse("spiffe://cluster.local/ns/a/sa/agent")
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{u}}}}
+	_, ok = SPIFFEIDFromRequest(req)
+	assert.False(t, ok)
+
+	id, ok := SPIFFEIDFromRequest(withSVID(httptest.NewRequest("POST", "/rpc", nil), u.String()))
+	assert.True(t, ok)
+	assert.Equal(t, "spiffe://cluster.local/ns/a/sa/agent", id)
+}
+
+func TestDIDAuthMiddleware_SPIFFEEither(t *testing.T) {
+	const spiffeID = "spiffe://cluster.local/ns/a/sa/agent"
+	agentDID := did.AgentDID("did:sage:ethereum:0xabc")
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: false})
+	middleware.SetSPIFFE(&SPIFFEConfig{
+		Mode:         SPIFFEEither,
+		TrustDomains: []string{"cluster.local"},
+		Mapper:       NewStaticSPIFFEMapper(map[string]did.AgentDID{spiffeID: agentDID}),
+	})
+
+	var gotDID did.AgentDID
+	var gotSPIFFE string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDID, _ = GetAgentDIDFromContext(r.Context())
+		gotSPIFFE, _ = GetSPIFFEIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Unsigned request with a mapped SVID
+	rr := httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, withSVID(httptest.NewRequest("POST", "/rpc", nil), spiffeID))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, agentDID, gotDID)
+	assert.Equal(t, spiffeID, gotSPIFFE)
+
+	// Foreign trust domain
+	rr = httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, withSVID(httptest.NewRequest("POST", "/rpc", nil), "spiffe://other.org/agent"))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// Unmapped SPIFFE ID
+	rr = httptest.NewRecorder()
+	middleware.Wrap(handler).ServeHTTP(rr, withSVID(httptest.NewRequest("POST", "/rpc", nil), "spiffe://cluster.local/unknown"))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestDIDAuthMiddleware_SPIFFEBoth(t *testing.T) {
+	const spiffeID = "spiffe://cluster.local/ns/a/sa/agent"
+	agentDID := did.AgentDID("did:sage:ethereum:0xabc")
+
+	tests := []struct {
+		name     string
+		signed   bool
+		svid     string
+		mapTo    did.AgentDID
+		wantCode int
+	}{
+		{"signature and linked SVID", true, spiffeID, agentDID, http.StatusOK},
+		{"signature only", true, "", agentDID, http.StatusUnauthorized},
+		{"SVID only", false, spiffeID, agentDID, http.StatusUnauthorized},
+		{"SVID linked to another DID", true, spiffeID, "did:sage:ethereum:0xother", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: agentDID})
+			middleware.SetSPIFFE(&SPIFFEConfig{
+				Mode: SPIFFEBoth,
+				Mapper: func(ctx context.Context, id string) (did.AgentDID, error) {
+					return tt.mapTo, nil
+				},
+			})
+
+			req := httptest.NewRequest("POST", "/rpc", nil)
+			if tt.signed {
+				req.Header.Set("Signature", "sig1=:AAAA:")
+				req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xabc"`)
+			}
+			if tt.svid != "" {
+				req = withSVID(req, tt.svid)
+			}
+
+			rr := httptest.NewRecorder()
+			middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantCode, rr.Code)
+		})
+	}
+}
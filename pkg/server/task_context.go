@@ -0,0 +1,134 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// TaskContinuationKey is the task metadata key recording the token of the
+// task context (see protocol.TaskContext) a task continues
+const TaskContinuationKey = "sage.continuation"
+
+// DefaultMaxTaskContextAge is the oldest task context accepted when
+// TaskContextConfig.MaxAge is zero
+const DefaultMaxTaskContextAge = 24 * time.Hour
+
+// TaskContextConfig configures which task contexts the middleware accepts
+type TaskContextConfig struct {
+	// Audience is the DID of this agent. Task contexts exported for
+	// another agent are rejected; if empty, only contexts without an
+	// audience are accepted.
+	Audience did.AgentDID
+
+	// MaxAge rejects task contexts issued longer ago (default
+	// DefaultMaxTaskContextAge)
+	MaxAge time.Duration
+}
+
+// SetTaskContinuation enables task continuation. A task context is read
+// from the protocol.TaskContextHeader when it is covered by the request
+// signature; it must be exported by the verified caller, signed with its
+// on-chain key, unexpired and meant for cfg.Audience, and any message in
+// the request must use its context ID. Accepted contexts are available
+// through GetTaskContextFromContext, and TaskQueue links the tasks they
+// create to the original one. A request carrying an unacceptable task
+// context is denied with 403 Forbidden. Pass nil to disable continuation.
+func (m *DIDAuthMiddleware) SetTaskContinuation(cfg *TaskContextConfig) {
+	m.update(func(c *middlewareConfig) {
+		c.taskContexts = cfg
+	})
+}
+
+// GetTaskContextFromContext returns the verified task context the request
+// continues
+func GetTaskContextFromContext(ctx context.Context) (*protocol.TaskContext, bool) {
+	tc, ok := ctx.Value(taskContextKey).(*protocol.TaskContext)
+	return tc, ok
+}
+
+// ContinuedFrom returns the task context recorded on a task created by a
+// continuation (see TaskContinuationKey). The signature was checked when
+// the task was submitted and is not checked again.
+func ContinuedFrom(task *a2a.Task) (*protocol.TaskContext, bool) {
+	if task == nil {
+		return nil, false
+	}
+	token, ok := task.Metadata[TaskContinuationKey].(string)
+	if !ok {
+		return nil, false
+	}
+	tc, err := protocol.ParseTaskContext(token)
+	return tc, err == nil
+}
+
+// checkTaskContext verifies the task context presented with r by
+// agentDID, if any, and stores it in the context. Failures are returned
+// as *AuthorizationError.
+func (m *middlewareConfig) checkTaskContext(ctx context.Context, r *http.Request, agentDID did.AgentDID, body []byte) (context.Context, error) {
+	if m.taskContexts == nil || !signedHeaders(r)(protocol.TaskContextHeader) {
+		return ctx, nil
+	}
+	token := r.Header.Get(protocol.TaskContextHeader)
+	tc, err := protocol.ParseTaskContext(token)
+	if err != nil {
+		return ctx, &AuthorizationError{Err: err}
+	}
+	if tc.Origin != agentDID {
+		return ctx, &AuthorizationError{Reason: fmt.Sprintf("task context exported by %s", tc.Origin)}
+	}
+	publicKey, err := m.verifier.ResolvePublicKey(ctx, tc.Origin, nil)
+	if err != nil {
+		return ctx, &AuthorizationError{Err: fmt.Errorf("failed to resolve task context origin key: %w", err)}
+	}
+	if tc, err = protocol.VerifyTaskContext(token, publicKey); err != nil {
+		return ctx, &AuthorizationError{Err: err}
+	}
+
+	now := time.Now()
+	if err := tc.Valid(m.taskContexts.Audience, now); err != nil {
+		return ctx, &AuthorizationError{Err: err}
+	}
+	maxAge := m.taskContexts.MaxAge
+	if maxAge == 0 {
+		maxAge = DefaultMaxTaskContextAge
+	}
+	if now.Sub(time.Unix(tc.IssuedAt, 0)) > maxAge {
+		return ctx, &AuthorizationError{Err: fmt.Errorf("%w: issued more than %s ago", protocol.ErrTaskContextExpired, maxAge)}
+	}
+
+	var req struct {
+		Params struct {
+			Message *a2a.Message `json:"message"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(body, &req) == nil && req.Params.Message != nil {
+		if id := req.Params.Message.ContextID; id != "" && id != tc.ContextID {
+			return ctx, &AuthorizationError{Reason: fmt.Sprintf("message contextID %s differs from task context", id)}
+		}
+	}
+	return context.WithValue(ctx, taskContextKey, tc), nil
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDAuthMiddleware_TaskContinuation(t *testing.T) {
+	const (
+		caller = did.AgentDID("did:sage:ethereum:0xabc")
+		self   = did.AgentDID("did:sage:ethereum:0xself")
+		other  = did.AgentDID("did:sage:ethereum:0xother")
+	)
+	callerKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	otherKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(&keyResolvingVerifier{
+		mockDIDVerifier: mockDIDVerifier{shouldSucceed: true, extractedDID: caller},
+		keys: map[did.AgentDID]stdcrypto.PublicKey{
+			caller: callerKey.PublicKey(),
+			other:  otherKey.PublicKey(),
+		},
+	})
+	middleware.SetTaskContinuation(&TaskContextConfig{Audience: self})
+
+	q := NewTaskQueue(&funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		return nil
+	}}, TaskQueueConfig{Workers: 1})
+	defer q.Close()
+
+	var created *a2a.Task
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params a2a.MessageSendParams `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		task, err := q.Submit(r.Context(), req.Params.Message)
+		require.NoError(t, err)
+		created = task
+	}))
+
+	original := &a2a.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		History:   []*a2a.Message{userMessage("book a flight")},
+	}
+	export := func(origin did.AgentDID, key sagecrypto.KeyPair, audience did.AgentDID) *protocol.TaskContext {
+		t.Helper()
+		tc, err := protocol.ExportTaskContext(origin, key, original, audience, time.Hour)
+		require.NoError(t, err)
+		return tc
+	}
+	serve := func(covered bool, tc *protocol.TaskContext, contextID string) int {
+		msg := userMessage("find a hotel")
+		msg.ContextID = contextID
+		body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "message/send", "params": map[string]any{"message": msg}})
+		require.NoError(t, err)
+		req := signedRequest(string(body))
+		if covered {
+			req.Header.Set("Signature-Input", `sig1=("@method" "a2a-task-context");keyid="`+string(caller)+`"`)
+		}
+		req.Header.Set(protocol.TaskContextHeader, tc.Token)
+		rec := httptest.NewRecorder()
+		created = nil
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// An accepted task context links the new task to the original one
+	tc := export(caller, callerKey, self)
+	require.Equal(t, http.StatusOK, serve(true, tc, ""))
+	require.NotNil(t, created)
+	assert.Equal(t, "ctx-1", created.ContextID)
+	linked, ok := ContinuedFrom(created)
+	require.True(t, ok)
+	assert.Equal(t, a2a.TaskID("task-1"), linked.TaskID)
+	assert.Equal(t, caller, linked.Origin)
+	require.NoError(t, linked.VerifyHistory(original.History))
+
+	// Task contexts not covered by the signature are ignored
+	require.Equal(t, http.StatusOK, serve(false, tc, ""))
+	_, ok = ContinuedFrom(created)
+	assert.False(t, ok)
+	assert.NotEqual(t, "ctx-1", created.ContextID)
+
+	// Contexts exported by another agent, for another audience or under
+	// another context ID are rejected
+	assert.Equal(t, http.StatusForbidden, serve(true, export(other, otherKey, self), ""))
+	assert.Equal(t, http.StatusForbidden, serve(true, export(caller, callerKey, other), ""))
+	assert.Equal(t, http.StatusForbidden, serve(true, tc, "ctx-2"))
+
+	// Tampered contexts fail signature verification
+	forged, err := protocol.ExportTaskContext(caller, otherKey, original, self, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, serve(true, forged, ""))
+}
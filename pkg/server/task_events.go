@@ -0,0 +1,256 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// DefaultTaskEventRetention is how long the events of a finished task are
+// kept for pollers unless TaskQueueConfig.EventRetention is set
+const DefaultTaskEventRetention = 5 * time.Minute
+
+// ErrInvalidCursor is returned by PollEvents for a cursor it did not issue
+var ErrInvalidCursor = errors.New("invalid task event cursor")
+
+// taskEvents is the event log of one task
+type taskEvents struct {
+	events []a2a.Event
+	final  bool          // the last event ended the stream
+	ended  time.Time     // when the task reached a terminal state; zero while it runs
+	notify chan struct{} // closed on the next append
+}
+
+// taskEventLog records the events published for each task so they can be
+// polled from any position, unlike the single-reader event queues
+type taskEventLog struct {
+	retention time.Duration
+
+	mu     sync.Mutex
+	tasks  map[a2a.TaskID]*taskEvents
+	pruned time.Time
+}
+
+func newTaskEventLog(retention time.Duration) *taskEventLog {
+	return &taskEventLog{retention: retention, tasks: make(map[a2a.TaskID]*taskEvents)}
+}
+
+// get returns the log of taskID, creating it if needed. l.mu must be held.
+func (l *taskEventLog) get(taskID a2a.TaskID) *taskEvents {
+	te, ok := l.tasks[taskID]
+	if !ok {
+		te = &taskEvents{notify: make(chan struct{})}
+		l.tasks[taskID] = te
+	}
+	return te
+}
+
+// append records event and wakes waiting pollers
+func (l *taskEventLog) append(taskID a2a.TaskID, event a2a.Event) {
+	// Tasks are mutated by the queue after publishing; keep a snapshot
+	if task, ok := event.(*a2a.Task); ok {
+		event = cloneTask(task)
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	te := l.get(taskID)
+	te.events = append(te.events, event)
+	te.final, te.ended = false, time.Time{}
+	if ev, ok := event.(*a2a.TaskStatusUpdateEvent); ok {
+		te.final = ev.Final
+		if ev.Status.State.Terminal() {
+			te.ended = now
+		}
+	}
+	close(te.notify)
+	te.notify = make(chan struct{})
+}
+
+// prune drops the logs of tasks that ended more than the retention ago,
+// checking at most once per retention period. l.mu must be held.
+func (l *taskEventLog) prune(now time.Time) {
+	if now.Sub(l.pruned) < l.retention {
+		return
+	}
+	l.pruned = now
+	for id, te := range l.tasks {
+		if !te.ended.IsZero() && now.Sub(te.ended) > l.retention {
+			delete(l.tasks, id)
+		}
+	}
+}
+
+// has reports whether any events of taskID are recorded
+func (l *taskEventLog) has(taskID a2a.TaskID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	te, ok := l.tasks[taskID]
+	return ok && len(te.events) > 0
+}
+
+// read returns the events of taskID after the first n, the total number
+// of events, whether the last one ended the stream, and a channel closed
+// on the next append
+func (l *taskEventLog) read(taskID a2a.TaskID, n int) ([]a2a.Event, int, bool, <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	te := l.get(taskID)
+	if n > len(te.events) {
+		return nil, len(te.events), false, te.notify
+	}
+	return append([]a2a.Event(nil), te.events[n:]...), len(te.events), te.final, te.notify
+}
+
+// PollEvents returns the events published for a task after cursor, the
+// Cursor of an earlier page or "" for the start, waiting up to wait for
+// new events if there are none yet. Events of a task that finished more
+// than TaskQueueConfig.EventRetention ago, or before a restart, are
+// reported as a single final page with the stored task.
+func (q *TaskQueue) PollEvents(ctx context.Context, taskID a2a.TaskID, cursor string, wait time.Duration) (*protocol.TaskEventsPage, error) {
+	after := 0
+	if cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 0 {
+			return nil, ErrInvalidCursor
+		}
+		after = n
+	}
+	task, err := q.config.Store.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status.State.Terminal() && !q.events.has(taskID) {
+		return &protocol.TaskEventsPage{
+			Events: []protocol.TaskEvent{protocol.NewTaskEvent(task)},
+			Cursor: cursor,
+			Final:  true,
+		}, nil
+	}
+
+	var timeout <-chan time.Time
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		events, total, final, notify := q.events.read(taskID, after)
+		if after > total {
+			return nil, ErrInvalidCursor
+		}
+		if len(events) > 0 || final || timeout == nil {
+			page := &protocol.TaskEventsPage{
+				Events: make([]protocol.TaskEvent, 0, len(events)),
+				Cursor: strconv.Itoa(total),
+				Final:  final,
+			}
+			for _, event := range events {
+				page.Events = append(page.Events, protocol.NewTaskEvent(event))
+			}
+			return page, nil
+		}
+		select {
+		case <-notify:
+		case <-timeout:
+			timeout = nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// NewTaskEventsHandler creates a handler serving the events of the tasks
+// of queue at protocol.TaskEventsPath by long polling, for clients that
+// cannot use SSE. It must be wrapped by DIDAuthMiddleware; only the DID
+// that submitted a task may poll its events. The server's WriteTimeout
+// must exceed protocol.MaxTaskEventsWait.
+func NewTaskEventsHandler(queue *TaskQueue) http.Handler {
+	return &taskEventsHandler{queue: queue}
+}
+
+type taskEventsHandler struct {
+	queue *TaskQueue
+}
+
+// ServeHTTP implements http.Handler
+func (h *taskEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeArtifactError(w, http.StatusMethodNotAllowed, protocol.ErrorCodeInvalidRequest, "method not allowed")
+		return
+	}
+	agentDID, ok := GetAgentDIDFromContext(r.Context())
+	if !ok {
+		writeUnauthorized(w, "task event polls must be signed")
+		return
+	}
+
+	query := r.URL.Query()
+	taskID := a2a.TaskID(query.Get(protocol.TaskEventsTaskParam))
+	if taskID == "" {
+		writeArtifactError(w, http.StatusBadRequest, protocol.ErrorCodeInvalidRequest, "task is required")
+		return
+	}
+	wait, err := protocol.ParseTaskEventsWait(query.Get(protocol.TaskEventsWaitParam))
+	if err != nil {
+		writeArtifactError(w, http.StatusBadRequest, protocol.ErrorCodeInvalidRequest, err.Error())
+		return
+	}
+
+	// Tasks of other agents are reported as missing
+	task, err := h.queue.config.Store.Get(r.Context(), taskID)
+	if err == nil {
+		if submitter, ok := task.Metadata[TaskSubmitterKey].(string); ok && submitter != string(agentDID) {
+			err = a2a.ErrTaskNotFound
+		}
+	}
+	if err != nil {
+		writeArtifactError(w, http.StatusNotFound, protocol.ErrorCodeNotFound, fmt.Sprintf("task %s not found", taskID))
+		return
+	}
+
+	page, err := h.queue.PollEvents(r.Context(), taskID, query.Get(protocol.TaskEventsCursorParam), wait)
+	switch {
+	case errors.Is(err, ErrInvalidCursor):
+		writeArtifactError(w, http.StatusBadRequest, protocol.ErrorCodeInvalidRequest, err.Error())
+		return
+	case err != nil:
+		// The caller went away, or the task vanished from the store
+		if r.Context().Err() == nil {
+			writeArtifactError(w, http.StatusNotFound, protocol.ErrorCodeNotFound, err.Error())
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(page)
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskQueue_PollEvents(t *testing.T) {
+	release := make(chan struct{})
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		<-release
+		return queue.Write(ctx, a2a.NewArtifactEvent(reqCtx.Task, a2a.TextPart{Text: "result"}))
+	}}
+	q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1})
+	defer q.Close()
+
+	ctx := context.Background()
+	task, err := q.Submit(ctx, userMessage("hello"))
+	require.NoError(t, err)
+
+	page, err := q.PollEvents(ctx, task.ID, "", 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, page.Events)
+	assert.Equal(t, task.ID, page.Events[0].Task.ID)
+	assert.False(t, page.Final)
+
+	// Nothing new arrives until the executor is released
+	cursor := page.Cursor
+	if len(page.Events) == 1 {
+		page, err = q.PollEvents(ctx, task.ID, cursor, 5*time.Second)
+		require.NoError(t, err)
+		cursor = page.Cursor
+	}
+	page, err = q.PollEvents(ctx, task.ID, cursor, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, page.Events)
+	assert.Equal(t, cursor, page.Cursor)
+
+	close(release)
+	var events []a2a.Event
+	for !page.Final {
+		page, err = q.PollEvents(ctx, task.ID, page.Cursor, 5*time.Second)
+		require.NoError(t, err)
+		for _, e := range page.Events {
+			events = append(events, e.Event())
+		}
+	}
+	require.Len(t, events, 2)
+	assert.IsType(t, &a2a.TaskArtifactUpdateEvent{}, events[0])
+	assert.Equal(t, a2a.TaskStateCompleted, events[1].(*a2a.TaskStatusUpdateEvent).Status.State)
+
+	// Replaying from the start returns the whole log
+	page, err = q.PollEvents(ctx, task.ID, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Events, 4)
+	assert.True(t, page.Final)
+
+	_, err = q.PollEvents(ctx, task.ID, "99", 0)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = q.PollEvents(ctx, task.ID, "x", 0)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// A queue without the log reports the stored final state
+	restarted := NewTaskQueue(executor, TaskQueueConfig{Workers: 1, Store: q.Store()})
+	defer restarted.Close()
+	page, err = restarted.PollEvents(ctx, task.ID, "", time.Second)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, a2a.TaskStateCompleted, page.Events[0].Task.Status.State)
+	assert.True(t, page.Final)
+}
+
+func TestTaskEventsHandler(t *testing.T) {
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		return nil
+	}}
+	q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1})
+	defer q.Close()
+	handler := NewTaskEventsHandler(q)
+
+	owner := did.AgentDID("did:sage:ethereum:0xabc")
+	task, err := q.Submit(context.WithValue(context.Background(), agentDIDKey, owner), userMessage("hello"))
+	require.NoError(t, err)
+
+	poll := func(caller did.AgentDID, query url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, protocol.TaskEventsPath+"?"+query.Encode(), nil)
+		if caller != "" {
+			req = req.WithContext(context.WithValue(req.Context(), agentDIDKey, caller))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	query := url.Values{
+		protocol.TaskEventsTaskParam: {string(task.ID)},
+		protocol.TaskEventsWaitParam: {"5s"},
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, poll("", query).Code)
+	assert.Equal(t, http.StatusNotFound, poll("did:sage:ethereum:0xdef", query).Code)
+	assert.Equal(t, http.StatusBadRequest, poll(owner, url.Values{protocol.TaskEventsTaskParam: {string(task.ID)}, protocol.TaskEventsWaitParam: {"soon"}}).Code)
+	assert.Equal(t, http.StatusBadRequest, poll(owner, url.Values{protocol.TaskEventsTaskParam: {string(task.ID)}, protocol.TaskEventsCursorParam: {"-1"}}).Code)
+
+	var events []a2a.Event
+	for {
+		rec := poll(owner, query)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var page protocol.TaskEventsPage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		for _, e := range page.Events {
+			events = append(events, e.Event())
+		}
+		if page.Final {
+			break
+		}
+		query.Set(protocol.TaskEventsCursorParam, page.Cursor)
+	}
+	require.Len(t, events, 3)
+	assert.Equal(t, a2a.TaskStateCompleted, events[2].(*a2a.TaskStatusUpdateEvent).Status.State)
+}
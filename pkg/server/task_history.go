@@ -0,0 +1,159 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+const (
+	// DefaultTaskPageSize is the tasks/list page size used when none is requested
+	DefaultTaskPageSize = 50
+
+	// MaxTaskPageSize is the largest tasks/list page size accepted
+	MaxTaskPageSize = 100
+)
+
+// GetTask implements tasks/get on the queue's store. With
+// query.HistoryLength set, only that many of the most recent history
+// messages are returned.
+func (q *TaskQueue) GetTask(ctx context.Context, query *a2a.TaskQueryParams) (*a2a.Task, error) {
+	if query == nil {
+		return nil, fmt.Errorf("task query is required: %w", a2a.ErrInvalidParams)
+	}
+	if query.HistoryLength != nil && *query.HistoryLength < 0 {
+		return nil, fmt.Errorf("historyLength must be non-negative: %w", a2a.ErrInvalidParams)
+	}
+	task, err := q.config.Store.Get(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if query.HistoryLength != nil {
+		task.History = trimHistory(task.History, *query.HistoryLength)
+	}
+	return task, nil
+}
+
+// ListTasks implements tasks/list on the queue's store. Tasks are returned
+// in the order they were first saved, filtered by params, in pages of
+// params.PageSize; NextPageToken is set while more tasks remain. Each task
+// carries its params.HistoryLength most recent history messages, and its
+// artifacts only if params.IncludeArtifacts is set.
+func (q *TaskQueue) ListTasks(ctx context.Context, params *protocol.ListTasksParams) (*protocol.ListTasksResult, error) {
+	if params == nil {
+		params = &protocol.ListTasksParams{}
+	}
+	pageSize := params.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultTaskPageSize
+	}
+	if pageSize < 1 || pageSize > MaxTaskPageSize {
+		return nil, fmt.Errorf("pageSize must be between 1 and %d: %w", MaxTaskPageSize, a2a.ErrInvalidParams)
+	}
+	if params.HistoryLength < 0 {
+		return nil, fmt.Errorf("historyLength must be non-negative: %w", a2a.ErrInvalidParams)
+	}
+
+	tasks, err := q.config.Store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	matched := tasks[:0]
+	for _, task := range tasks {
+		if matchesTaskFilter(task, params) {
+			matched = append(matched, task)
+		}
+	}
+
+	start := 0
+	if params.PageToken != "" {
+		start, err = pageStart(matched, params.PageToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+	end := min(start+pageSize, len(matched))
+
+	result := &protocol.ListTasksResult{
+		Tasks:     matched[start:end],
+		TotalSize: len(matched),
+		PageSize:  pageSize,
+	}
+	if end < len(matched) {
+		result.NextPageToken = encodePageToken(matched[end].ID)
+	}
+	for _, task := range result.Tasks {
+		task.History = trimHistory(task.History, params.HistoryLength)
+		if !params.IncludeArtifacts {
+			task.Artifacts = nil
+		}
+	}
+	return result, nil
+}
+
+// matchesTaskFilter reports whether task passes the tasks/list filters
+func matchesTaskFilter(task *a2a.Task, params *protocol.ListTasksParams) bool {
+	if params.ContextID != "" && task.ContextID != params.ContextID {
+		return false
+	}
+	if params.Status != "" && task.Status.State != params.Status {
+		return false
+	}
+	if params.LastUpdatedAfter > 0 {
+		ts := task.Status.Timestamp
+		if ts == nil || ts.UnixMilli() < params.LastUpdatedAfter {
+			return false
+		}
+	}
+	return true
+}
+
+// trimHistory returns the n most recent messages of history
+func trimHistory(history []*a2a.Message, n int) []*a2a.Message {
+	if n == 0 {
+		return nil
+	}
+	if len(history) > n {
+		return history[len(history)-n:]
+	}
+	return history
+}
+
+// encodePageToken returns the page token starting at id. Tokens name the
+// first task of the next page, so pages stay consistent while new tasks are
+// appended.
+func encodePageToken(id a2a.TaskID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// pageStart returns the index of the task named by token
+func pageStart(tasks []*a2a.Task, token string) (int, error) {
+	id, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		for i, task := range tasks {
+			if task.ID == a2a.TaskID(id) {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid page token: %w", a2a.ErrInvalidParams)
+}
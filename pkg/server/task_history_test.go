@@ -0,0 +1,115 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyTask(id, contextID string, state a2a.TaskState, messages int) *a2a.Task {
+	ts := time.UnixMilli(int64(1000 * messages))
+	task := &a2a.Task{
+		ID:        a2a.TaskID(id),
+		ContextID: contextID,
+		Status:    a2a.TaskStatus{State: state, Timestamp: &ts},
+		Artifacts: []*a2a.Artifact{{ID: a2a.ArtifactID(id + "-out")}},
+	}
+	for i := 0; i < messages; i++ {
+		task.History = append(task.History, userMessage(fmt.Sprintf("%s-%d", id, i)))
+	}
+	return task
+}
+
+func TestTaskQueue_GetTaskHistoryLength(t *testing.T) {
+	store := NewMemoryTaskStore()
+	queue := NewTaskQueue(&funcExecutor{}, TaskQueueConfig{Workers: 1, Store: store})
+	defer queue.Close()
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, historyTask("t1", "c1", a2a.TaskStateCompleted, 5)))
+
+	task, err := queue.GetTask(ctx, &a2a.TaskQueryParams{ID: "t1"})
+	require.NoError(t, err)
+	assert.Len(t, task.History, 5)
+
+	n := 2
+	task, err = queue.GetTask(ctx, &a2a.TaskQueryParams{ID: "t1", HistoryLength: &n})
+	require.NoError(t, err)
+	require.Len(t, task.History, 2)
+	assert.Equal(t, "t1-3", task.History[0].Parts[0].(*a2a.TextPart).Text)
+
+	n = -1
+	_, err = queue.GetTask(ctx, &a2a.TaskQueryParams{ID: "t1", HistoryLength: &n})
+	assert.True(t, errors.Is(err, a2a.ErrInvalidParams))
+
+	_, err = queue.GetTask(ctx, &a2a.TaskQueryParams{ID: "missing"})
+	assert.True(t, errors.Is(err, a2a.ErrTaskNotFound))
+}
+
+func TestTaskQueue_ListTasksPagination(t *testing.T) {
+	store := NewMemoryTaskStore()
+	queue := NewTaskQueue(&funcExecutor{}, TaskQueueConfig{Workers: 1, Store: store})
+	defer queue.Close()
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, store.Save(ctx, historyTask(fmt.Sprintf("t%d", i), "c1", a2a.TaskStateCompleted, i)))
+	}
+	require.NoError(t, store.Save(ctx, historyTask("other", "c2", a2a.TaskStateWorking, 1)))
+
+	var ids []a2a.TaskID
+	params := &protocol.ListTasksParams{ContextID: "c1", PageSize: 2, HistoryLength: 1}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		result, err := queue.ListTasks(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.TotalSize)
+		for _, task := range result.Tasks {
+			ids = append(ids, task.ID)
+			assert.Len(t, task.History, 1)
+			assert.Nil(t, task.Artifacts)
+		}
+		if result.NextPageToken == "" {
+			break
+		}
+		params.PageToken = result.NextPageToken
+	}
+	assert.Equal(t, []a2a.TaskID{"t1", "t2", "t3", "t4", "t5"}, ids)
+
+	result, err := queue.ListTasks(ctx, &protocol.ListTasksParams{Status: a2a.TaskStateWorking, IncludeArtifacts: true})
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, a2a.TaskID("other"), result.Tasks[0].ID)
+	assert.Empty(t, result.Tasks[0].History)
+	assert.Len(t, result.Tasks[0].Artifacts, 1)
+
+	result, err = queue.ListTasks(ctx, &protocol.ListTasksParams{LastUpdatedAfter: 4000})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalSize)
+
+	_, err = queue.ListTasks(ctx, &protocol.ListTasksParams{PageToken: "bogus"})
+	assert.True(t, errors.Is(err, a2a.ErrInvalidParams))
+	_, err = queue.ListTasks(ctx, &protocol.ListTasksParams{PageSize: MaxTaskPageSize + 1})
+	assert.True(t, errors.Is(err, a2a.ErrInvalidParams))
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// RequestInput pauses the task in the input-required state, sending prompt
+// to the client. The executor should return afterwards; the client's reply
+// arrives as a new execution of the same task with the reply as
+// reqCtx.Message.
+func RequestInput(ctx context.Context, queue eventqueue.Writer, reqCtx *a2asrv.RequestContext, prompt string) error {
+	return pauseTask(ctx, queue, reqCtx, a2a.TaskStateInputRequired, prompt)
+}
+
+// RequestAuth pauses the task in the auth-required state until the client
+// supplies credentials, e.g. in the metadata of its next message
+func RequestAuth(ctx context.Context, queue eventqueue.Writer, reqCtx *a2asrv.RequestContext, prompt string) error {
+	return pauseTask(ctx, queue, reqCtx, a2a.TaskStateAuthRequired, prompt)
+}
+
+func pauseTask(ctx context.Context, queue eventqueue.Writer, reqCtx *a2asrv.RequestContext, state a2a.TaskState, prompt string) error {
+	task := &a2a.Task{ID: reqCtx.TaskID, ContextID: reqCtx.ContextID}
+	var msg *a2a.Message
+	if prompt != "" {
+		msg = agentMessage(task, prompt)
+	}
+	event := a2a.NewStatusUpdateEvent(task, state, msg)
+	event.Final = true
+	return queue.Write(ctx, event)
+}
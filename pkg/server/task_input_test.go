@@ -0,0 +1,86 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskQueue_InputRequired(t *testing.T) {
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		text := reqCtx.Message.Parts[0].(*a2a.TextPart).Text
+		if text == "book a flight" {
+			return RequestInput(ctx, queue, reqCtx, "Which date?")
+		}
+		return queue.Write(ctx, a2a.NewArtifactEvent(reqCtx.Task, a2a.TextPart{Text: "booked for " + text}))
+	}}
+	q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1})
+	defer q.Close()
+
+	alice := context.WithValue(context.Background(), agentDIDKey, did.AgentDID("did:sage:ethereum:0xa11ce"))
+	task, err := q.Submit(alice, userMessage("book a flight"))
+	require.NoError(t, err)
+	reader, err := q.Subscribe(alice, task.ID)
+	require.NoError(t, err)
+
+	events := readUntilFinal(t, reader)
+	paused, err := q.Store().Get(alice, task.ID)
+	require.NoError(t, err)
+	assert.True(t, protocol.NeedsInput(paused))
+	assert.Equal(t, "Which date?", protocol.StatusText(paused))
+	assert.Equal(t, a2a.TaskStateInputRequired, events[len(events)-1].(*a2a.TaskStatusUpdateEvent).Status.State)
+
+	// Only the submitter may answer
+	reply := userMessage("May 1st")
+	reply.TaskID = task.ID
+	mallory := context.WithValue(context.Background(), agentDIDKey, did.AgentDID("did:sage:ethereum:0xbad"))
+	_, err = q.Submit(mallory, reply)
+	assert.ErrorIs(t, err, a2a.ErrInvalidRequest)
+
+	_, err = q.Submit(alice, reply)
+	require.NoError(t, err)
+	events = readUntilFinal(t, reader)
+	assert.Equal(t, a2a.TaskStateCompleted, events[len(events)-1].(*a2a.TaskStatusUpdateEvent).Status.State)
+
+	done, err := q.Store().Get(alice, task.ID)
+	require.NoError(t, err)
+	require.Len(t, done.Artifacts, 1)
+	assert.Equal(t, "booked for May 1st", done.Artifacts[0].Parts[0].(*a2a.TextPart).Text)
+}
+
+func TestRequestAuth(t *testing.T) {
+	queue := eventqueue.NewInMemoryQueue(1)
+	reqCtx := &a2asrv.RequestContext{TaskID: "task-1", ContextID: "ctx-1"}
+	require.NoError(t, RequestAuth(context.Background(), queue, reqCtx, "Sign in to your calendar"))
+
+	event, err := queue.Read(context.Background())
+	require.NoError(t, err)
+	status := event.(*a2a.TaskStatusUpdateEvent)
+	assert.Equal(t, a2a.TaskStateAuthRequired, status.Status.State)
+	assert.True(t, status.Final)
+	assert.Equal(t, a2a.TaskID("task-1"), status.Status.Message.TaskID)
+}
@@ -0,0 +1,629 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// TaskSubmitterKey is the task metadata key recording the DID that
+// submitted a task
+const TaskSubmitterKey = "sage.submitter"
+
+// TaskInterruptedKey is set in the metadata of the final status event
+// published for a task left unfinished by a shutdown. The task is resumed
+// after a restart and can be resubscribed to.
+const TaskInterruptedKey = "sage.interrupted"
+
+var (
+	// ErrTaskQueueFull is returned when a task cannot be queued. The task is
+	// stored as rejected.
+	ErrTaskQueueFull = errors.New("task queue full")
+
+	// ErrTaskQueueClosed is returned after the queue has been closed
+	ErrTaskQueueClosed = errors.New("task queue closed")
+
+	// ErrTaskFinished is returned when subscribing to a task in a terminal state
+	ErrTaskFinished = errors.New("task finished")
+)
+
+// TaskQueueConfig configures a TaskQueue
+type TaskQueueConfig struct {
+	// Workers is the number of tasks executed concurrently (default runtime.NumCPU())
+	Workers int
+
+	// QueueSize is the number of tasks that may wait for a worker
+	// (default 16 * Workers)
+	QueueSize int
+
+	// Store persists tasks (default NewMemoryTaskStore())
+	Store TaskStore
+
+	// Events receives task events for subscribers (default
+	// eventqueue.NewInMemoryManager())
+	Events eventqueue.Manager
+
+	// Reputation, if set, records the outcome of every finished task
+	// against its submitter
+	Reputation *ReputationTracker
+
+	// EventRetention is how long the events of a finished task stay
+	// available to PollEvents (default DefaultTaskEventRetention)
+	EventRetention time.Duration
+}
+
+// runningTask tracks a task executing on a worker
+type runningTask struct {
+	cancel   context.CancelFunc
+	canceled bool
+	reqCtx   *a2asrv.RequestContext
+	sink     *taskSink
+}
+
+// TaskQueue persists submitted tasks and executes them through an
+// a2asrv.AgentExecutor on a bounded set of workers. Every event the executor
+// writes is applied to the stored task and published to the task's event
+// queue. Tasks left unfinished by a shutdown are picked up again by Resume.
+type TaskQueue struct {
+	executor a2asrv.AgentExecutor
+	config   TaskQueueConfig
+	jobs     chan a2a.TaskID
+	wg       sync.WaitGroup
+	events   *taskEventLog
+
+	// baseCtx is cancelled by Shutdown to stop running executions
+	baseCtx context.Context
+	stop    context.CancelFunc
+
+	mu      sync.RWMutex
+	closed  bool
+	running map[a2a.TaskID]*runningTask
+}
+
+// NewTaskQueue creates and starts a task queue executing tasks with executor
+func NewTaskQueue(executor a2asrv.AgentExecutor, config TaskQueueConfig) *TaskQueue {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 16 * config.Workers
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryTaskStore()
+	}
+	if config.Events == nil {
+		config.Events = eventqueue.NewInMemoryManager()
+	}
+	if config.EventRetention <= 0 {
+		config.EventRetention = DefaultTaskEventRetention
+	}
+
+	q := &TaskQueue{
+		executor: executor,
+		config:   config,
+		jobs:     make(chan a2a.TaskID, config.QueueSize),
+		running:  make(map[a2a.TaskID]*runningTask),
+		events:   newTaskEventLog(config.EventRetention),
+	}
+	q.baseCtx, q.stop = context.WithCancel(context.Background())
+	for i := 0; i < config.Workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+	return q
+}
+
+// Store returns the queue's task store
+func (q *TaskQueue) Store() TaskStore {
+	return q.config.Store
+}
+
+// Submit stores msg as a new task, or as a follow-up on the task it
+// references, and queues it for execution. The DID authenticated by
+// DIDAuthMiddleware, if any, is recorded under TaskSubmitterKey and made
+// available to the executor through GetAgentDIDFromContext. A new task
+// submitted with a verified task context (see SetTaskContinuation) takes
+// its context ID and records it under TaskContinuationKey.
+func (q *TaskQueue) Submit(ctx context.Context, msg *a2a.Message) (*a2a.Task, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("message is required: %w", a2a.ErrInvalidRequest)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context error: %w", err)
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return nil, ErrTaskQueueClosed
+	}
+
+	m := *msg
+	var task *a2a.Task
+	var event a2a.Event
+	if m.TaskID != "" {
+		stored, err := q.config.Store.Get(ctx, m.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load task: %w", err)
+		}
+		switch state := stored.Status.State; {
+		case state.Terminal():
+			return nil, fmt.Errorf("task in a terminal state %q: %w", state, a2a.ErrInvalidRequest)
+		case state == a2a.TaskStateSubmitted || state == a2a.TaskStateWorking:
+			return nil, fmt.Errorf("task is %s: %w", state, a2a.ErrInvalidRequest)
+		}
+		// Only the submitter may supply input to its task
+		if submitter, ok := stored.Metadata[TaskSubmitterKey].(string); ok {
+			if agentDID, _ := GetAgentDIDFromContext(ctx); string(agentDID) != submitter {
+				return nil, fmt.Errorf("task was submitted by another agent: %w", a2a.ErrInvalidRequest)
+			}
+		}
+		if m.ContextID != "" && m.ContextID != stored.ContextID {
+			return nil, fmt.Errorf("message contextID different from task contextID: %w", a2a.ErrInvalidRequest)
+		}
+		m.ContextID = stored.ContextID
+		task = stored
+		task.History = append(task.History, &m)
+		task.Status = newTaskStatus(a2a.TaskStateSubmitted, nil)
+		event = &a2a.TaskStatusUpdateEvent{TaskID: task.ID, ContextID: task.ContextID, Status: task.Status}
+	} else {
+		if tc, ok := GetTaskContextFromContext(ctx); ok {
+			if m.ContextID != "" && m.ContextID != tc.ContextID {
+				return nil, fmt.Errorf("message contextID different from task context: %w", a2a.ErrInvalidRequest)
+			}
+			m.ContextID = tc.ContextID
+		}
+		if m.ContextID == "" {
+			m.ContextID = a2a.NewContextID()
+		}
+		m.TaskID = a2a.NewTaskID()
+		task = &a2a.Task{
+			ID:        m.TaskID,
+			ContextID: m.ContextID,
+			History:   []*a2a.Message{&m},
+			Status:    newTaskStatus(a2a.TaskStateSubmitted, nil),
+		}
+		event = task
+	}
+	if agentDID, ok := GetAgentDIDFromContext(ctx); ok {
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]any)
+		}
+		task.Metadata[TaskSubmitterKey] = string(agentDID)
+	}
+	if tc, ok := GetTaskContextFromContext(ctx); ok && msg.TaskID == "" {
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]any)
+		}
+		task.Metadata[TaskContinuationKey] = tc.Token
+	}
+
+	if err := q.config.Store.Save(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+	if _, err := q.config.Events.GetOrCreate(ctx, task.ID); err != nil {
+		return nil, fmt.Errorf("failed to create event queue: %w", err)
+	}
+	q.publish(ctx, task.ID, event)
+
+	select {
+	case q.jobs <- task.ID:
+		return task, nil
+	default:
+	}
+
+	task.Status = newTaskStatus(a2a.TaskStateRejected, agentMessage(task, ErrTaskQueueFull.Error()))
+	if err := q.config.Store.Save(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+	q.publishFinal(ctx, task)
+	return nil, ErrTaskQueueFull
+}
+
+// Resume queues every stored task that was submitted or working, e.g. after
+// a restart, and returns how many were queued. Tasks waiting for input or
+// authorization stay paused until a follow-up message is submitted.
+func (q *TaskQueue) Resume(ctx context.Context) (int, error) {
+	tasks, err := q.config.Store.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return 0, ErrTaskQueueClosed
+	}
+
+	n := 0
+	for _, task := range tasks {
+		if state := task.Status.State; state != a2a.TaskStateSubmitted && state != a2a.TaskStateWorking {
+			continue
+		}
+		if _, running := q.running[task.ID]; running {
+			continue
+		}
+		if _, err := q.config.Events.GetOrCreate(ctx, task.ID); err != nil {
+			return n, fmt.Errorf("failed to create event queue: %w", err)
+		}
+		select {
+		case q.jobs <- task.ID:
+			n++
+		default:
+			return n, ErrTaskQueueFull
+		}
+	}
+	return n, nil
+}
+
+// Subscribe returns the event queue of an unfinished task. Each task's
+// queue has a single reader; events are buffered until read, and a full
+// queue blocks the task's execution.
+func (q *TaskQueue) Subscribe(ctx context.Context, taskID a2a.TaskID) (eventqueue.Reader, error) {
+	task, err := q.config.Store.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if queue, ok := q.config.Events.Get(ctx, taskID); ok {
+		return queue, nil
+	}
+	if task.Status.State.Terminal() {
+		return nil, fmt.Errorf("%w: %s is %s", ErrTaskFinished, taskID, task.Status.State)
+	}
+	return q.config.Events.GetOrCreate(ctx, taskID)
+}
+
+// Cancel cancels a queued or running task. Running tasks are asked to stop
+// through the executor's Cancel method and their context is cancelled.
+func (q *TaskQueue) Cancel(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error) {
+	q.mu.Lock()
+	if rt, ok := q.running[taskID]; ok {
+		rt.canceled = true
+		reqCtx, sink := rt.reqCtx, rt.sink
+		q.mu.Unlock()
+
+		var err error
+		if reqCtx != nil {
+			err = q.executor.Cancel(ctx, reqCtx, sink)
+		}
+		rt.cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to cancel: %w", err)
+		}
+		return q.config.Store.Get(ctx, taskID)
+	}
+	defer q.mu.Unlock()
+
+	task, err := q.config.Store.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status.State.Terminal() {
+		return nil, fmt.Errorf("task in non-cancelable state %s: %w", task.Status.State, a2a.ErrTaskNotCancelable)
+	}
+	task.Status = newTaskStatus(a2a.TaskStateCanceled, nil)
+	if err := q.config.Store.Save(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+	q.publishFinal(ctx, task)
+	return task, nil
+}
+
+// Shutdown stops accepting tasks and waits for running executions to
+// finish until ctx is done, then cancels them. Queued tasks are not started.
+// Unfinished tasks keep their stored state so Resume can pick them up after
+// a restart, and their subscribers receive a final status event carrying
+// TaskInterruptedKey so streams end instead of hanging. Shutdown returns
+// ctx.Err() if running executions had to be cancelled.
+func (q *TaskQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.stop()
+		return nil
+	case <-ctx.Done():
+		q.stop()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting tasks and cancels running executions without
+// waiting for them; see Shutdown
+func (q *TaskQueue) Close() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = q.Shutdown(ctx)
+}
+
+func (q *TaskQueue) worker() {
+	defer q.wg.Done()
+	for taskID := range q.jobs {
+		q.run(taskID)
+	}
+}
+
+// run executes one task
+func (q *TaskQueue) run(taskID a2a.TaskID) {
+	q.mu.RLock()
+	closed := q.closed
+	q.mu.RUnlock()
+	if closed || q.baseCtx.Err() != nil {
+		if task, err := q.config.Store.Get(context.Background(), taskID); err == nil {
+			q.interrupt(context.Background(), task)
+		}
+		return
+	}
+	ctx, cancel := context.WithCancel(q.baseCtx)
+	defer cancel()
+
+	// Register before loading so Cancel either sees the task running or
+	// has already stored it as canceled
+	rt := &runningTask{cancel: cancel}
+	q.mu.Lock()
+	if _, dup := q.running[taskID]; dup {
+		q.mu.Unlock()
+		return
+	}
+	q.running[taskID] = rt
+	q.mu.Unlock()
+	defer func() {
+		q.mu.Lock()
+		delete(q.running, taskID)
+		q.mu.Unlock()
+	}()
+
+	task, err := q.config.Store.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if state := task.Status.State; state != a2a.TaskStateSubmitted && state != a2a.TaskStateWorking {
+		return
+	}
+	if submitter, ok := task.Metadata[TaskSubmitterKey].(string); ok {
+		ctx = context.WithValue(ctx, agentDIDKey, did.AgentDID(submitter))
+	}
+
+	sink := &taskSink{queue: q, task: task}
+	reqCtx := &a2asrv.RequestContext{
+		TaskID:    task.ID,
+		Task:      cloneTask(task),
+		ContextID: task.ContextID,
+	}
+	if n := len(task.History); n > 0 {
+		reqCtx.Message = task.History[n-1]
+		reqCtx.Metadata = reqCtx.Message.Metadata
+	}
+	q.mu.Lock()
+	rt.reqCtx, rt.sink = reqCtx, sink
+	q.mu.Unlock()
+
+	if err := sink.Write(ctx, &a2a.TaskStatusUpdateEvent{
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+		Status:    newTaskStatus(a2a.TaskStateWorking, nil),
+	}); err != nil {
+		return
+	}
+
+	execErr := q.executor.Execute(ctx, reqCtx, sink)
+
+	// Leave the task for Resume when shutting down
+	if q.baseCtx.Err() != nil {
+		q.interrupt(ctx, sink.snapshot())
+		return
+	}
+
+	q.mu.RLock()
+	canceled := rt.canceled
+	q.mu.RUnlock()
+
+	// Tasks the executor finished or paused have already been published
+	final := sink.snapshot()
+	switch state := final.Status.State; {
+	case state.Terminal(), state == a2a.TaskStateInputRequired, state == a2a.TaskStateAuthRequired:
+		return
+	case canceled:
+		final.Status = newTaskStatus(a2a.TaskStateCanceled, nil)
+	case execErr != nil:
+		final.Status = newTaskStatus(a2a.TaskStateFailed, agentMessage(final, execErr.Error()))
+	default:
+		final.Status = newTaskStatus(a2a.TaskStateCompleted, nil)
+	}
+
+	// The execution context may be cancelled; persist the outcome regardless
+	saveCtx := context.WithoutCancel(ctx)
+	if err := q.config.Store.Save(saveCtx, final); err != nil {
+		return
+	}
+	q.publishFinal(saveCtx, final)
+}
+
+// publish records event for pollers and writes it to the task's event
+// queue, if it still exists
+func (q *TaskQueue) publish(ctx context.Context, taskID a2a.TaskID, event a2a.Event) {
+	q.events.append(taskID, event)
+	if queue, ok := q.config.Events.Get(ctx, taskID); ok {
+		_ = queue.Write(ctx, event)
+	}
+}
+
+// publishFinal publishes the final status of task and releases its event
+// queue. Buffered events remain readable until the queue is drained.
+func (q *TaskQueue) publishFinal(ctx context.Context, task *a2a.Task) {
+	if submitter, ok := task.Metadata[TaskSubmitterKey].(string); ok && q.config.Reputation != nil && task.Status.State.Terminal() {
+		q.config.Reputation.RecordTask(did.AgentDID(submitter), task.Status.State)
+	}
+	q.publish(ctx, task.ID, &a2a.TaskStatusUpdateEvent{
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+		Status:    task.Status,
+		Final:     true,
+	})
+	if task.Status.State.Terminal() {
+		_ = q.config.Events.Destroy(ctx, task.ID)
+	}
+}
+
+// interrupt persists the state of a task left unfinished by a shutdown and
+// ends its event stream without destroying the queue
+func (q *TaskQueue) interrupt(ctx context.Context, task *a2a.Task) {
+	if task.Status.State.Terminal() {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	if err := q.config.Store.Save(ctx, task); err != nil {
+		return
+	}
+	q.publish(ctx, task.ID, &a2a.TaskStatusUpdateEvent{
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+		Status:    task.Status,
+		Final:     true,
+		Metadata:  map[string]any{TaskInterruptedKey: true},
+	})
+}
+
+// taskSink is the eventqueue.Queue handed to the executor. Events are
+// applied to the stored task before being published.
+type taskSink struct {
+	queue *TaskQueue
+
+	mu   sync.Mutex
+	task *a2a.Task
+}
+
+// Write implements eventqueue.Writer
+func (s *taskSink) Write(ctx context.Context, event a2a.Event) error {
+	s.mu.Lock()
+	if s.task.Status.State.Terminal() {
+		s.mu.Unlock()
+		return fmt.Errorf("task in a terminal state %q: %w", s.task.Status.State, a2a.ErrInvalidRequest)
+	}
+	applyTaskEvent(s.task, event)
+	err := s.queue.config.Store.Save(ctx, s.task)
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Terminal states from the executor end the stream
+	if ev, ok := event.(*a2a.TaskStatusUpdateEvent); ok && ev.Status.State.Terminal() {
+		s.queue.publishFinal(ctx, s.snapshot())
+		return nil
+	}
+	s.queue.publish(ctx, s.task.ID, event)
+	return nil
+}
+
+// Read implements eventqueue.Reader; executors only write
+func (s *taskSink) Read(ctx context.Context) (a2a.Event, error) {
+	return nil, errors.New("task event sink is write-only")
+}
+
+// Close implements eventqueue.Queue
+func (s *taskSink) Close() error {
+	return nil
+}
+
+func (s *taskSink) snapshot() *a2a.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneTask(s.task)
+}
+
+// applyTaskEvent updates task with an executor event
+func applyTaskEvent(task *a2a.Task, event a2a.Event) {
+	switch ev := event.(type) {
+	case *a2a.Task:
+		history := task.History
+		*task = *cloneTask(ev)
+		if len(task.History) == 0 {
+			task.History = history
+		}
+	case *a2a.Message:
+		task.History = append(task.History, ev)
+	case *a2a.TaskStatusUpdateEvent:
+		task.Status = ev.Status
+		if ev.Status.Message != nil {
+			task.History = append(task.History, ev.Status.Message)
+		}
+	case *a2a.TaskArtifactUpdateEvent:
+		if ev.Artifact == nil {
+			return
+		}
+		i := slices.IndexFunc(task.Artifacts, func(a *a2a.Artifact) bool { return a.ID == ev.Artifact.ID })
+		switch {
+		case i < 0:
+			artifact := *ev.Artifact
+			task.Artifacts = append(task.Artifacts, &artifact)
+		case ev.Append:
+			merged := *task.Artifacts[i]
+			merged.Parts = append(slices.Clone(merged.Parts), ev.Artifact.Parts...)
+			task.Artifacts[i] = &merged
+		default:
+			artifact := *ev.Artifact
+			task.Artifacts[i] = &artifact
+		}
+	}
+}
+
+func newTaskStatus(state a2a.TaskState, msg *a2a.Message) a2a.TaskStatus {
+	now := time.Now()
+	return a2a.TaskStatus{State: state, Message: msg, Timestamp: &now}
+}
+
+// agentMessage creates an agent text message for task
+func agentMessage(task *a2a.Task, text string) *a2a.Message {
+	return a2a.NewMessageForTask(a2a.MessageRoleAgent, task, a2a.TextPart{Text: text})
+}
+
+// cloneTask deep-copies task
+func cloneTask(task *a2a.Task) *a2a.Task {
+	if data, err := json.Marshal(task); err == nil {
+		if clone, err := decodeTask(data); err == nil {
+			return clone
+		}
+	}
+	clone := *task
+	return &clone
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// funcExecutor adapts functions to a2asrv.AgentExecutor
+type funcExecutor struct {
+	execute func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error
+	cancel  func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error
+}
+
+func (e *funcExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return e.execute(ctx, reqCtx, queue)
+}
+
+func (e *funcExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	if e.cancel == nil {
+		return nil
+	}
+	return e.cancel(ctx, reqCtx, queue)
+}
+
+func userMessage(text string) *a2a.Message {
+	return a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: text})
+}
+
+// readUntilFinal collects events until a final status update
+func readUntilFinal(t *testing.T, reader eventqueue.Reader) []a2a.Event {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var events []a2a.Event
+	for {
+		event, err := reader.Read(ctx)
+		require.NoError(t, err)
+		events = append(events, event)
+		if ev, ok := event.(*a2a.TaskStatusUpdateEvent); ok && ev.Final {
+			return events
+		}
+	}
+}
+
+func TestTaskQueue_Execute(t *testing.T) {
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		return queue.Write(ctx, a2a.NewArtifactEvent(reqCtx.Task, a2a.TextPart{Text: "result"}))
+	}}
+	q := NewTaskQueue(executor, TaskQueueConfig{Workers: 2})
+	defer q.Close()
+
+	ctx := context.WithValue(context.Background(), agentDIDKey, did.AgentDID("did:sage:ethereum:0xabc"))
+	task, err := q.Submit(ctx, userMessage("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskStateSubmitted, task.Status.State)
+	assert.Equal(t, "did:sage:ethereum:0xabc", task.Metadata[TaskSubmitterKey])
+
+	reader, err := q.Subscribe(context.Background(), task.ID)
+	require.NoError(t, err)
+	events := readUntilFinal(t, reader)
+	require.Len(t, events, 4)
+	assert.IsType(t, &a2a.Task{}, events[0])
+	assert.Equal(t, a2a.TaskStateWorking, events[1].(*a2a.TaskStatusUpdateEvent).Status.State)
+	assert.IsType(t, &a2a.TaskArtifactUpdateEvent{}, events[2])
+	assert.Equal(t, a2a.TaskStateCompleted, events[3].(*a2a.TaskStatusUpdateEvent).Status.State)
+
+	stored, err := q.Store().Get(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskStateCompleted, stored.Status.State)
+	assert.Len(t, stored.Artifacts, 1)
+
+	_, err = q.Subscribe(context.Background(), task.ID)
+	assert.ErrorIs(t, err, ErrTaskFinished)
+}
+
+func TestTaskQueue_ExecutorError(t *testing.T) {
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		agentDID, ok := GetAgentDIDFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, did.AgentDID("did:sage:ethereum:0xabc"), agentDID)
+		return errors.New("model unavailable")
+	}}
+	q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1})
+	defer q.Close()
+
+	ctx := context.WithValue(context.Background(), agentDIDKey, did.AgentDID("did:sage:ethereum:0xabc"))
+	task, err := q.Submit(ctx, userMessage("hello"))
+	require.NoError(t, err)
+	reader, err := q.Subscribe(ctx, task.ID)
+	require.NoError(t, err)
+
+	events := readUntilFinal(t, reader)
+	final := events[len(events)-1].(*a2a.TaskStatusUpdateEvent)
+	assert.Equal(t, a2a.TaskStateFailed, final.Status.State)
+	assert.Equal(t, "model unavailable", final.Status.Message.Parts[0].(a2a.TextPart).Text)
+}
+
+func TestTaskQueue_ConcurrencyAndCancel(t *testing.T) {
+	started := make(chan a2a.TaskID, 4)
+	executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		started <- reqCtx.TaskID
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1, QueueSize: 1})
+	defer q.Close()
+	ctx := context.Background()
+
+	first, err := q.Submit(ctx, userMessage("one"))
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, <-started)
+
+	// One worker: the second task waits, the third does not fit
+	second, err := q.Submit(ctx, userMessage("two"))
+	require.NoError(t, err)
+	_, err = q.Submit(ctx, userMessage("three"))
+	assert.ErrorIs(t, err, ErrTaskQueueFull)
+
+	// Cancelling a queued task stops it from running
+	canceled, err := q.Cancel(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskStateCanceled, canceled.Status.State)
+
+	// Cancelling the running task ends its execution
+	reader, err := q.Subscribe(ctx, first.ID)
+	require.NoError(t, err)
+	_, err = q.Cancel(ctx, first.ID)
+	require.NoError(t, err)
+	events := readUntilFinal(t, reader)
+	assert.Equal(t, a2a.TaskStateCanceled, events[len(events)-1].(*a2a.TaskStatusUpdateEvent).Status.State)
+
+	_, err = q.Cancel(ctx, first.ID)
+	assert.ErrorIs(t, err, a2a.ErrTaskNotCancelable)
+	select {
+	case id := <-started:
+		t.Fatalf("task %s should not have run", id)
+	default:
+	}
+}
+
+func TestTaskQueue_Resume(t *testing.T) {
+	store := NewMemoryTaskStore()
+	block := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	// Shut down while the task is running
+	q := NewTaskQueue(block, TaskQueueConfig{Workers: 1, Store: store})
+	task, err := q.Submit(context.Background(), userMessage("long job"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		stored, err := store.Get(context.Background(), task.ID)
+		return err == nil && stored.Status.State == a2a.TaskStateWorking
+	}, time.Second, time.Millisecond)
+	q.Close()
+	_, err = q.Submit(context.Background(), userMessage("late"))
+	assert.ErrorIs(t, err, ErrTaskQueueClosed)
+
+	// A new queue over the same store picks the task up again
+	done := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+		assert.Equal(t, "long job", reqCtx.Message.Parts[0].(*a2a.TextPart).Text)
+		return nil
+	}}
+	q = NewTaskQueue(done, TaskQueueConfig{Workers: 1, Store: store})
+	defer q.Close()
+	reader, err := q.Subscribe(context.Background(), task.ID)
+	require.NoError(t, err)
+	n, err := q.Resume(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	events := readUntilFinal(t, reader)
+	assert.Equal(t, a2a.TaskStateCompleted, events[len(events)-1].(*a2a.TaskStatusUpdateEvent).Status.State)
+}
+
+func TestTaskQueue_Shutdown(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("waits for running tasks", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+			close(started)
+			<-release
+			return nil
+		}}
+		q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1})
+		task, err := q.Submit(ctx, userMessage("job"))
+		require.NoError(t, err)
+		reader, err := q.Subscribe(ctx, task.ID)
+		require.NoError(t, err)
+		<-started
+
+		time.AfterFunc(20*time.Millisecond, func() { close(release) })
+		require.NoError(t, q.Shutdown(ctx))
+
+		events := readUntilFinal(t, reader)
+		assert.Equal(t, a2a.TaskStateCompleted, events[len(events)-1].(*a2a.TaskStatusUpdateEvent).Status.State)
+		_, err = q.Submit(ctx, userMessage("late"))
+		assert.ErrorIs(t, err, ErrTaskQueueClosed)
+	})
+
+	t.Run("interrupts tasks at the deadline", func(t *testing.T) {
+		store := NewMemoryTaskStore()
+		executor := &funcExecutor{execute: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+		q := NewTaskQueue(executor, TaskQueueConfig{Workers: 1, Store: store})
+		running, err := q.Submit(ctx, userMessage("running"))
+		require.NoError(t, err)
+		queued, err := q.Submit(ctx, userMessage("queued"))
+		require.NoError(t, err)
+		runningEvents, err := q.Subscribe(ctx, running.ID)
+		require.NoError(t, err)
+		queuedEvents, err := q.Subscribe(ctx, queued.ID)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			stored, err := store.Get(ctx, running.ID)
+			return err == nil && stored.Status.State == a2a.TaskStateWorking
+		}, time.Second, time.Millisecond)
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, q.Shutdown(shutdownCtx), context.DeadlineExceeded)
+
+		// Both streams end with an interrupted status; the stored state is
+		// left for Resume
+		for id, reader := range map[a2a.TaskID]eventqueue.Reader{running.ID: runningEvents, queued.ID: queuedEvents} {
+			events := readUntilFinal(t, reader)
+			final := events[len(events)-1].(*a2a.TaskStatusUpdateEvent)
+			assert.Equal(t, true, final.Metadata[TaskInterruptedKey])
+			assert.False(t, final.Status.State.Terminal())
+
+			stored, err := store.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, final.Status.State, stored.Status.State)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// TaskStore persists tasks for a TaskQueue. It extends a2asrv.TaskStore with
+// listing so unfinished tasks can be resumed after a restart.
+type TaskStore interface {
+	a2asrv.TaskStore
+
+	// List returns all stored tasks
+	List(ctx context.Context) ([]*a2a.Task, error)
+}
+
+// MemoryTaskStore is a TaskStore keeping tasks in memory. Tasks are copied
+// on Save and Get so callers cannot mutate stored state.
+type MemoryTaskStore struct {
+	mu    sync.RWMutex
+	tasks map[a2a.TaskID][]byte
+	order []a2a.TaskID
+}
+
+// NewMemoryTaskStore creates an empty in-memory task store
+func NewMemoryTaskStore() *MemoryTaskStore {
+	return &MemoryTaskStore{tasks: make(map[a2a.TaskID][]byte)}
+}
+
+// Save implements a2asrv.TaskStore
+func (s *MemoryTaskStore) Save(ctx context.Context, task *a2a.Task) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tasks[task.ID]; !ok {
+		s.order = append(s.order, task.ID)
+	}
+	s.tasks[task.ID] = data
+	return nil
+}
+
+// Get implements a2asrv.TaskStore
+func (s *MemoryTaskStore) Get(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error) {
+	s.mu.RLock()
+	data, ok := s.tasks[taskID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, a2a.ErrTaskNotFound
+	}
+	return decodeTask(data)
+}
+
+// List implements TaskStore, returning tasks in the order they were first
+// saved
+func (s *MemoryTaskStore) List(ctx context.Context) ([]*a2a.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tasks := make([]*a2a.Task, 0, len(s.order))
+	for _, id := range s.order {
+		task, err := decodeTask(s.tasks[id])
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func decodeTask(data []byte) (*a2a.Task, error) {
+	var task a2a.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, fmt.Errorf("failed to decode task: %w", err)
+	}
+	return &task, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ErrTrailerVerification is returned by the body of a streamed request
+// (see signer.TrailerDigestHeader) whose trailers do not verify once the
+// body has been read to the end
+var ErrTrailerVerification = errors.New("trailer verification failed")
+
+// streamingSigned reports whether the signature of r announces the body
+// digest as a trailer
+func streamingSigned(r *http.Request) bool {
+	return signedHeaders(r)(signer.TrailerDigestHeader)
+}
+
+// serveStreaming handles a request whose body digest and second signature
+// arrive as trailers. The header signature is verified before the body is
+// read, so "Expect: 100-continue" uploads are rejected without being sent.
+// The body is passed to the handler unbuffered and verified as it is
+// consumed; if the trailers do not verify, the final Read returns an error
+// wrapping ErrTrailerVerification instead of io.EOF. Authorization and
+// quotas see an empty body.
+func (m *middlewareConfig) serveStreaming(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	alg := strings.ToLower(strings.TrimSpace(r.Header.Get(signer.TrailerDigestHeader)))
+	accepted := m.digestAlgorithms
+	if len(accepted) == 0 {
+		accepted = signer.SupportedDigestAlgorithms
+	}
+	digester, err := signer.NewContentDigester(alg)
+	if err != nil || !containsFold(accepted, alg) {
+		m.fail(w, r, r.ContentLength, fmt.Errorf("content digest verification failed: unacceptable trailer digest algorithm %q", alg))
+		return
+	}
+
+	var fp RequestFingerprint
+	parseSignature(r, &fp)
+	if fp.Nonce == "" {
+		m.fail(w, r, r.ContentLength, fmt.Errorf("signature verification failed: streamed request signature has no nonce"))
+		return
+	}
+
+	ctx := verifier.WithResolutionCache(r.Context())
+	agentDID, err := m.verify(ctx, r)
+	if errors.Is(err, ErrPoolSaturated) || errors.Is(err, ErrPoolClosed) {
+		shed(w)
+		return
+	}
+	if err != nil {
+		m.fail(w, r, r.ContentLength, fmt.Errorf("signature verification failed: %w", err))
+		return
+	}
+	spiffeID, err := m.checkSVID(ctx, r, agentDID)
+	if err != nil {
+		m.fail(w, r, r.ContentLength, fmt.Errorf("SPIFFE verification failed: %w", err))
+		return
+	}
+	// The method is unknown until the body arrived
+	if !m.acceptEarlyData(w, r, "") {
+		return
+	}
+	m.notifyVerification(r, agentDID, nil)
+	m.notifyFingerprint(r, r.ContentLength, agentDID, nil)
+	ctx = m.withSignatureParams(ctx, r)
+
+	ctx, err = m.applyCapabilities(ctx, r, agentDID)
+	if err != nil {
+		m.deny(w, r, agentDID, err)
+		return
+	}
+
+	if m.methodCapabilities != nil {
+		if err := m.checkAnyMethod(GetCapabilitiesFromContext(ctx)); err != nil {
+			m.deny(w, r, agentDID, err)
+			return
+		}
+	}
+
+	ctx, err = m.checkAttestations(ctx, r, agentDID, m.allAttestationMethods()...)
+	if err != nil {
+		m.deny(w, r, agentDID, err)
+		return
+	}
+
+	// The body is still streaming, so the message contextID is not checked
+	ctx, err = m.checkTaskContext(ctx, r, agentDID, nil)
+	if err != nil {
+		m.deny(w, r, agentDID, err)
+		return
+	}
+
+	if m.authorizer != nil {
+		if err := m.authorize(ctx, r, agentDID, nil); err != nil {
+			m.deny(w, r, agentDID, err)
+			return
+		}
+	}
+
+	ctx, cancel, ok := applyHints(ctx, w, r, m.requestTimeout)
+	if !ok {
+		return
+	}
+	defer cancel()
+
+	ctx, ok = m.negotiateExtensions(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	ctx, release, ok := m.admit(ctx, w, agentDID, 0, "")
+	if !ok {
+		return
+	}
+	defer release()
+
+	ctx = context.WithValue(ctx, agentDIDKey, agentDID)
+	if spiffeID != "" {
+		ctx = context.WithValue(ctx, spiffeIDKey, spiffeID)
+	}
+	if m.reputation != nil {
+		ctx = context.WithValue(ctx, reputationKey, m.reputation.Reputation(agentDID))
+	}
+	r = r.WithContext(ctx)
+
+	verifyTrailers := func() error {
+		err := m.verifyTrailers(ctx, r, agentDID, fp, digester)
+		if err != nil {
+			m.notifyVerification(r, "", err)
+			m.auditFailure(r, err)
+			return fmt.Errorf("%w: %w", ErrTrailerVerification, err)
+		}
+		return nil
+	}
+	r.Body = &trailerVerifyingBody{ReadCloser: r.Body, digester: digester, verify: verifyTrailers}
+
+	m.serveTracked(w, r, agentDID, next)
+}
+
+// verifyTrailers checks the Content-Digest trailer against the body digest
+// and verifies the trailer signature, which must come from the same DID
+// and carry the header signature's nonce plus signer.TrailerNonceSuffix
+func (m *middlewareConfig) verifyTrailers(ctx context.Context, r *http.Request, agentDID did.AgentDID, header RequestFingerprint, digester *signer.ContentDigester) error {
+	digest := r.Trailer.Get("Content-Digest")
+	if digest == "" {
+		return fmt.Errorf("missing Content-Digest trailer")
+	}
+	if err := digester.Verify(digest); err != nil {
+		return fmt.Errorf("content digest verification failed: %w", err)
+	}
+
+	signed := r.Clone(ctx)
+	signed.Body = http.NoBody
+	for _, name := range []string{"Content-Digest", "Signature-Input", "Signature"} {
+		signed.Header.Set(name, r.Trailer.Get(name))
+	}
+	signed, err := m.selectSignature(signed)
+	if err != nil {
+		return fmt.Errorf("trailer signature verification failed: %w", err)
+	}
+	if !signedHeaders(signed)("Content-Digest") {
+		return fmt.Errorf("trailer signature does not cover content-digest")
+	}
+	var fp RequestFingerprint
+	parseSignature(signed, &fp)
+	if fp.Nonce != header.Nonce+signer.TrailerNonceSuffix {
+		return fmt.Errorf("trailer signature is not bound to the header signature")
+	}
+
+	trailerDID, err := m.verify(ctx, signed)
+	if err != nil {
+		return fmt.Errorf("trailer signature verification failed: %w", err)
+	}
+	if trailerDID != agentDID {
+		return fmt.Errorf("trailer signed by %s, headers by %s", trailerDID, agentDID)
+	}
+	return nil
+}
+
+// trailerVerifyingBody hashes a streamed request body as the handler reads
+// it and verifies the trailers at the end
+type trailerVerifyingBody struct {
+	io.ReadCloser
+	digester *signer.ContentDigester
+	verify   func() error
+	done     bool
+	err      error
+}
+
+// Read implements io.Reader
+func (b *trailerVerifyingBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.digester.Write(p[:n])
+	if err == io.EOF && !b.done {
+		b.done = true
+		if b.err = b.verify(); b.err != nil {
+			return n, b.err
+		}
+	}
+	return n, err
+}
+
+// containsFold reports whether list contains v, ignoring case
+func containsFold(list []string, v string) bool {
+	for _, e := range list {
+		if strings.EqualFold(e, v) {
+			return true
+		}
+	}
+	return false
+}
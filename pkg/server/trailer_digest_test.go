@@ -0,0 +1,156 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingFixture registers a key and returns a middleware verifying it
+func streamingFixture(t *testing.T) (*DIDAuthMiddleware, did.AgentDID, crypto.KeyPair) {
+	t.Helper()
+	testDID := did.AgentDID("did:sage:ethereum:0xstream")
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	reg := registry.NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(context.Background(), testDID, keyPair.PublicKey()))
+	return NewDIDAuthMiddlewareWithVerifier(reg.NewDIDVerifier()), testDID, keyPair
+}
+
+// streamedRequest signs body for streaming and returns the server side view
+// of the request, with trailers as the client sent them
+func streamedRequest(t *testing.T, agentDID did.AgentDID, keyPair crypto.KeyPair, body string) *http.Request {
+	t.Helper()
+	out := httptest.NewRequest("POST", "http://agent.example.com/rpc", strings.NewReader(body))
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequestStreaming(context.Background(), out, agentDID, keyPair, nil))
+	sent, err := io.ReadAll(out.Body)
+	require.NoError(t, err)
+
+	in := httptest.NewRequest("POST", "http://agent.example.com/rpc", bytes.NewReader(sent))
+	in.Header = out.Header.Clone()
+	in.Trailer = out.Trailer.Clone()
+	return in
+}
+
+func TestDIDAuthMiddleware_TrailerDigest(t *testing.T) {
+	middleware, testDID, keyPair := streamingFixture(t)
+
+	serve := func(req *http.Request) (int, string, error) {
+		var got []byte
+		var readErr error
+		handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			agentDID, _ := GetAgentDIDFromContext(r.Context())
+			assert.Equal(t, testDID, agentDID)
+			got, readErr = io.ReadAll(r.Body)
+		}))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code, string(got), readErr
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		code, body, err := serve(streamedRequest(t, testDID, keyPair, `{"method":"message/send"}`))
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"method":"message/send"}`, body)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		req := streamedRequest(t, testDID, keyPair, `{"method":"message/send"}`)
+		req.Body = io.NopCloser(strings.NewReader(`{"method":"tasks/cancel"}`))
+		_, _, err := serve(req)
+		assert.ErrorIs(t, err, ErrTrailerVerification)
+	})
+
+	t.Run("missing trailers", func(t *testing.T) {
+		req := streamedRequest(t, testDID, keyPair, `{}`)
+		req.Trailer = http.Header{}
+		_, _, err := serve(req)
+		assert.ErrorIs(t, err, ErrTrailerVerification)
+	})
+
+	t.Run("trailer from another request", func(t *testing.T) {
+		req := streamedRequest(t, testDID, keyPair, `{}`)
+		req.Trailer = streamedRequest(t, testDID, keyPair, `{}`).Trailer
+		_, _, err := serve(req)
+		assert.ErrorIs(t, err, ErrTrailerVerification)
+	})
+}
+
+func TestDIDAuthMiddleware_TrailerDigestRejectsBeforeBody(t *testing.T) {
+	middleware, testDID, _ := streamingFixture(t)
+	other, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	// Signed with an unregistered key: rejected without touching the body
+	req := streamedRequest(t, testDID, other, `{}`)
+	body := &countingReader{r: strings.NewReader(`{}`)}
+	req.Body = io.NopCloser(body)
+
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be called")
+	}))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Zero(t, body.n)
+}
+
+func TestDIDAuthMiddleware_TrailerDigestOverHTTP(t *testing.T) {
+	middleware, testDID, keyPair := streamingFixture(t)
+	srv := httptest.NewServer(middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.Copy(io.Discard, r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	})))
+	defer srv.Close()
+
+	req, err := http.NewRequest("POST", srv.URL+"/upload", strings.NewReader(strings.Repeat("a", 1<<20)))
+	require.NoError(t, err)
+	req.Header.Set("Expect", "100-continue")
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequestStreaming(context.Background(), req, testDID, keyPair, nil))
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
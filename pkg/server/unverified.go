@@ -0,0 +1,119 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Default headers read by SetUnverifiedHints
+const (
+	// DefaultClaimedDIDHeader carries the DID an unsigned caller claims
+	DefaultClaimedDIDHeader = "A2A-Claimed-DID"
+
+	// DefaultAPIKeyHeader carries an API key
+	DefaultAPIKeyHeader = "X-API-Key"
+)
+
+// APIKeyResolver returns the identity an API key was issued to, and false
+// for unknown keys
+type APIKeyResolver func(ctx context.Context, key string) (identity string, ok bool)
+
+// UnverifiedHintConfig configures the identity hints read from unsigned
+// requests in optional mode
+type UnverifiedHintConfig struct {
+	// ClaimedDIDHeader names the header carrying the DID the caller claims
+	// (default DefaultClaimedDIDHeader)
+	ClaimedDIDHeader string
+
+	// APIKeys resolves API keys; nil ignores them
+	APIKeys APIKeyResolver
+
+	// APIKeyHeader names the header carrying the API key (default
+	// DefaultAPIKeyHeader)
+	APIKeyHeader string
+}
+
+// UnverifiedIdentity is what an unsigned request says about its caller.
+// Nothing of it is proven by a signature: ClaimedDID is whatever the caller
+// sent, and APIKeyIdentity is only as trustworthy as the API key. Use it
+// for progressive trust, such as lower limits for unverified callers,
+// never in place of GetAgentDIDFromContext.
+type UnverifiedIdentity struct {
+	// ClaimedDID is the DID claimed in the ClaimedDIDHeader
+	ClaimedDID did.AgentDID `json:"claimed_did,omitempty"`
+
+	// APIKeyIdentity is the identity the presented API key was issued to
+	APIKeyIdentity string `json:"api_key_identity,omitempty"`
+}
+
+// SetUnverifiedHints makes unsigned requests accepted in optional mode
+// carry the identity hints they present, available through
+// GetUnverifiedIdentityFromContext and AuthzInput.Unverified. Signed
+// requests never carry hints. Pass nil to disable hints.
+func (m *DIDAuthMiddleware) SetUnverifiedHints(cfg *UnverifiedHintConfig) {
+	if cfg != nil {
+		c := *cfg
+		if c.ClaimedDIDHeader == "" {
+			c.ClaimedDIDHeader = DefaultClaimedDIDHeader
+		}
+		if c.APIKeyHeader == "" {
+			c.APIKeyHeader = DefaultAPIKeyHeader
+		}
+		cfg = &c
+	}
+	m.update(func(c *middlewareConfig) {
+		c.unverifiedHints = cfg
+	})
+}
+
+// GetUnverifiedIdentityFromContext returns the identity hints of an
+// unsigned request. They are unverified; see UnverifiedIdentity.
+func GetUnverifiedIdentityFromContext(ctx context.Context) (UnverifiedIdentity, bool) {
+	id, ok := ctx.Value(unverifiedKey).(UnverifiedIdentity)
+	return id, ok
+}
+
+// withUnverifiedIdentity returns the context of the unsigned request r
+// with the identity hints it presents, if any
+func (m *middlewareConfig) withUnverifiedIdentity(r *http.Request) context.Context {
+	ctx := r.Context()
+	if m.unverifiedHints == nil {
+		return ctx
+	}
+
+	var id UnverifiedIdentity
+	if claimed := strings.TrimSpace(r.Header.Get(m.unverifiedHints.ClaimedDIDHeader)); strings.HasPrefix(claimed, "did:") {
+		id.ClaimedDID = did.AgentDID(claimed)
+	}
+	if m.unverifiedHints.APIKeys != nil {
+		if key := r.Header.Get(m.unverifiedHints.APIKeyHeader); key != "" {
+			if identity, ok := m.unverifiedHints.APIKeys(ctx, key); ok {
+				id.APIKeyIdentity = identity
+			}
+		}
+	}
+	if id == (UnverifiedIdentity{}) {
+		return ctx
+	}
+	return context.WithValue(ctx, unverifiedKey, id)
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDAuthMiddleware_UnverifiedHints(t *testing.T) {
+	middleware := NewDIDAuthMiddlewareWithVerifier(&mockDIDVerifier{shouldSucceed: true, extractedDID: "did:sage:ethereum:0xabc"})
+	middleware.SetOptional(true)
+	middleware.SetUnverifiedHints(&UnverifiedHintConfig{
+		APIKeys: func(ctx context.Context, key string) (string, bool) {
+			return "team-a", key == "secret"
+		},
+	})
+
+	var input AuthzInput
+	middleware.SetAuthorizer(AuthorizerFunc(func(ctx context.Context, in AuthzInput) (Decision, error) {
+		input = in
+		return Decision{Allow: true}, nil
+	}))
+
+	var (
+		hints    UnverifiedIdentity
+		hasHints bool
+		verified bool
+	)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hints, hasHints = GetUnverifiedIdentityFromContext(r.Context())
+		_, verified = GetAgentDIDFromContext(r.Context())
+	}))
+	serve := func(req *http.Request) {
+		t.Helper()
+		input = AuthzInput{}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+	unsigned := func(headers map[string]string) *http.Request {
+		req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+
+	// Hints of unsigned requests are exposed as unverified only
+	serve(unsigned(map[string]string{DefaultClaimedDIDHeader: "did:sage:ethereum:0xclaimed", DefaultAPIKeyHeader: "secret"}))
+	require.True(t, hasHints)
+	assert.False(t, verified)
+	assert.Equal(t, UnverifiedIdentity{ClaimedDID: "did:sage:ethereum:0xclaimed", APIKeyIdentity: "team-a"}, hints)
+	require.NotNil(t, input.Unverified)
+	assert.Equal(t, hints, *input.Unverified)
+	assert.Empty(t, input.AgentDID)
+
+	// Unknown keys and malformed DIDs are dropped
+	serve(unsigned(map[string]string{DefaultClaimedDIDHeader: "alice", DefaultAPIKeyHeader: "guess"}))
+	assert.False(t, hasHints)
+	assert.Nil(t, input.Unverified)
+
+	// Signed requests carry no hints
+	req := signedRequest(`{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`)
+	req.Header.Set(DefaultClaimedDIDHeader, "did:sage:ethereum:0xclaimed")
+	serve(req)
+	assert.False(t, hasHints)
+	assert.True(t, verified)
+	assert.Nil(t, input.Unverified)
+
+	// Hints are off unless configured
+	middleware.SetUnverifiedHints(nil)
+	serve(unsigned(map[string]string{DefaultClaimedDIDHeader: "did:sage:ethereum:0xclaimed"}))
+	assert.False(t, hasHints)
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+var (
+	// ErrPoolSaturated is returned when the verification queue is full
+	ErrPoolSaturated = errors.New("verification pool saturated")
+
+	// ErrPoolClosed is returned after the pool has been closed
+	ErrPoolClosed = errors.New("verification pool closed")
+)
+
+// WorkerPoolConfig configures a VerificationPool
+type WorkerPoolConfig struct {
+	// Workers is the number of concurrent verifications (default runtime.NumCPU())
+	Workers int
+
+	// QueueSize is the number of verifications that may wait for a worker
+	// (default 4 * Workers)
+	QueueSize int
+
+	// QueueTimeout is how long a request waits for queue space before being
+	// shed. Zero sheds immediately when the queue is full.
+	QueueTimeout time.Duration
+}
+
+// VerificationPoolStats is a snapshot of pool activity
+type VerificationPoolStats struct {
+	Workers  int
+	Queued   int
+	Rejected uint64
+}
+
+// poolJob is a queued unit of work; done is closed once it ran or was skipped
+type poolJob struct {
+	ctx  context.Context
+	fn   func()
+	done chan struct{}
+}
+
+// VerificationPool runs signature verifications on a bounded set of workers.
+// Requests beyond the queue capacity are rejected with ErrPoolSaturated so
+// bursts of CPU-heavy verifications shed load instead of piling up.
+type VerificationPool struct {
+	config   WorkerPoolConfig
+	queue    chan *poolJob
+	wg       sync.WaitGroup
+	rejected atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewVerificationPool creates and starts a verification pool
+func NewVerificationPool(config WorkerPoolConfig) *VerificationPool {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 4 * config.Workers
+	}
+
+	p := &VerificationPool{
+		config: config,
+		queue:  make(chan *poolJob, config.QueueSize),
+	}
+	for i := 0; i < config.Workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+func (p *VerificationPool) worker() {
+	defer p.wg.Done()
+	for job := range p.queue {
+		// Skip work whose caller has already gone away
+		if job.ctx.Err() == nil {
+			job.fn()
+		}
+		close(job.done)
+	}
+}
+
+// Do runs fn on a worker and waits for it to finish. It returns
+// ErrPoolSaturated if no queue space became available, or the context
+// error if ctx ended before fn started.
+func (p *VerificationPool) Do(ctx context.Context, fn func()) error {
+	return p.do(ctx, fn, true)
+}
+
+// do is Do; without wait a full queue sheds immediately regardless of
+// QueueTimeout
+func (p *VerificationPool) do(ctx context.Context, fn func(), wait bool) error {
+	job := &poolJob{ctx: ctx, fn: fn, done: make(chan struct{})}
+
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrPoolClosed
+	}
+	err := p.enqueue(ctx, job, wait)
+	p.mu.RUnlock()
+	if err != nil {
+		if errors.Is(err, ErrPoolSaturated) {
+			p.rejected.Add(1)
+		}
+		return err
+	}
+
+	<-job.done
+	return ctx.Err()
+}
+
+// enqueue places job on the queue, waiting up to QueueTimeout for space
+func (p *VerificationPool) enqueue(ctx context.Context, job *poolJob, wait bool) error {
+	select {
+	case p.queue <- job:
+		return nil
+	default:
+	}
+	if !wait || p.config.QueueTimeout <= 0 {
+		return ErrPoolSaturated
+	}
+
+	timer := time.NewTimer(p.config.QueueTimeout)
+	defer timer.Stop()
+	select {
+	case p.queue <- job:
+		return nil
+	case <-timer.C:
+		return ErrPoolSaturated
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of pool activity
+func (p *VerificationPool) Stats() VerificationPoolStats {
+	return VerificationPoolStats{
+		Workers:  p.config.Workers,
+		Queued:   len(p.queue),
+		Rejected: p.rejected.Load(),
+	}
+}
+
+// Close stops accepting work and waits for queued verifications to finish
+func (p *VerificationPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// SetVerificationPool offloads signature verification to pool. Requests
+// that cannot be queued receive 503 Service Unavailable. The pool is not
+// closed by the middleware.
+func (m *DIDAuthMiddleware) SetVerificationPool(pool *VerificationPool) {
+	m.update(func(c *middlewareConfig) {
+		c.pool = pool
+	})
+}
+
+// verify runs signature verification, on the worker pool if one is set,
+// followed by the replay check. Low priority requests are shed as soon as
+// the queue is full.
+func (m *middlewareConfig) verify(ctx context.Context, r *http.Request) (did.AgentDID, error) {
+	r = signedTarget(r)
+	agentDID, err := m.verifySignature(ctx, r)
+	if err == nil && m.replay != nil {
+		err = m.checkReplay(ctx, r, agentDID)
+	}
+	return agentDID, err
+}
+
+// verifySignature verifies the request signature
+func (m *middlewareConfig) verifySignature(ctx context.Context, r *http.Request) (did.AgentDID, error) {
+	var metrics *StageMetrics
+	if m.prechecks != nil {
+		metrics = m.prechecks.Metrics
+	}
+	if m.pool == nil {
+		var agentDID did.AgentDID
+		err := metrics.run(StageSignature, func() (err error) {
+			agentDID, err = m.verifier.VerifyHTTPSignatureWithKeyID(ctx, r)
+			return err
+		})
+		return agentDID, err
+	}
+
+	var (
+		agentDID  did.AgentDID
+		verifyErr error
+	)
+	if err := m.pool.do(ctx, func() {
+		verifyErr = metrics.run(StageSignature, func() (err error) {
+			agentDID, err = m.verifier.VerifyHTTPSignatureWithKeyID(ctx, r)
+			return err
+		})
+	}, !lowPriority(r)); err != nil {
+		return "", err
+	}
+	return agentDID, verifyErr
+}
+
+// shed rejects a request that could not be verified for lack of capacity
+func shed(w http.ResponseWriter) {
+	writeUnavailable(w, "verification capacity exceeded", 1)
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingVerifier succeeds once release is closed
+type blockingVerifier struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (v *blockingVerifier) VerifyHTTPSignature(ctx context.Context, req *http.Request, agentDID did.AgentDID) error {
+	return nil
+}
+
+func (v *blockingVerifier) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (stdcrypto.PublicKey, error) {
+	return nil, nil
+}
+
+func (v *blockingVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, req *http.Request) (did.AgentDID, error) {
+	v.started <- struct{}{}
+	<-v.release
+	return "did:sage:ethereum:0xabc", nil
+}
+
+func TestVerificationPool_Do(t *testing.T) {
+	pool := NewVerificationPool(WorkerPoolConfig{Workers: 2, QueueSize: 4})
+	defer pool.Close()
+
+	var n atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pool.Do(context.Background(), func() { n.Add(1) }))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(4), n.Load())
+	assert.Equal(t, 2, pool.Stats().Workers)
+}
+
+func TestVerificationPool_SkipsCancelled(t *testing.T) {
+	pool := NewVerificationPool(WorkerPoolConfig{Workers: 1, QueueSize: 1})
+	defer pool.Close()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go pool.Do(context.Background(), func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := false
+	done := make(chan error)
+	go func() { done <- pool.Do(ctx, func() { ran = true }) }()
+
+	cancel()
+	close(release)
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.False(t, ran)
+}
+
+func TestVerificationPool_Closed(t *testing.T) {
+	pool := NewVerificationPool(WorkerPoolConfig{Workers: 1})
+	pool.Close()
+	pool.Close()
+	assert.ErrorIs(t, pool.Do(context.Background(), func() {}), ErrPoolClosed)
+}
+
+func TestDIDAuthMiddleware_VerificationPoolSheds(t *testing.T) {
+	v := &blockingVerifier{started: make(chan struct{}, 4), release: make(chan struct{})}
+	pool := NewVerificationPool(WorkerPoolConfig{Workers: 1, QueueSize: 1})
+	defer pool.Close()
+
+	middleware := NewDIDAuthMiddlewareWithVerifier(v)
+	middleware.SetVerificationPool(pool)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	newReq := func() *http.Request {
+		req := httptest.NewRequest("POST", "/rpc", nil)
+		req.Header.Set("Signature", "sig1=:AAAA:")
+		req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xabc"`)
+		return req
+	}
+
+	// Occupy the worker, then fill the queue
+	codes := make(chan int, 2)
+	serve := func() {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newReq())
+		codes <- rr.Code
+	}
+	go serve()
+	<-v.started
+	go serve()
+	require.Eventually(t, func() bool { return pool.Stats().Queued == 1 }, time.Second, time.Millisecond)
+
+	// The next request is shed
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, newReq())
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+	assert.Equal(t, uint64(1), pool.Stats().Rejected)
+
+	close(v.release)
+	assert.Equal(t, http.StatusOK, <-codes)
+	assert.Equal(t, http.StatusOK, <-codes)
+}
+
+func TestVerificationPool_QueueTimeout(t *testing.T) {
+	pool := NewVerificationPool(WorkerPoolConfig{Workers: 1, QueueSize: 1, QueueTimeout: 200 * time.Millisecond})
+	defer pool.Close()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go pool.Do(context.Background(), func() { close(started); <-release })
+	<-started
+	go pool.Do(context.Background(), func() {})
+	require.Eventually(t, func() bool { return pool.Stats().Queued == 1 }, time.Second, time.Millisecond)
+
+	// A slot frees up within the timeout
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+	assert.NoError(t, pool.Do(context.Background(), func() {}))
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+// Package sidecar lets services without DID support join a SAGE mesh
+// through a signature-aware reverse proxy.
+//
+// # Inbound
+//
+// NewInbound verifies the DID signature of each request, removes the
+// signature headers and forwards the request to an internal backend. The
+// verified caller is passed in the X-Agent-DID header and its capabilities
+// in X-Agent-Capabilities; values sent by the caller are always dropped, so
+// the backend can trust them as long as it is only reachable through the
+// sidecar:
+//
+//	auth := server.NewDIDAuthMiddleware(resolver, client)
+//	backend, _ := url.Parse("http://127.0.0.1:8080")
+//	inbound, err := sidecar.NewInbound(sidecar.InboundConfig{Auth: auth, Backend: backend})
+//	http.ListenAndServe(":8443", inbound)
+//
+// Requests the middleware rejects never reach the backend.
+//
+// # Outbound
+//
+// NewOutbound accepts unsigned internal traffic and forwards it to a remote
+// agent signed with the sidecar's DID:
+//
+//	target, _ := url.Parse("https://agent.example.com")
+//	outbound, err := sidecar.NewOutbound(sidecar.OutboundConfig{
+//	    Target:   target,
+//	    AgentDID: myDID,
+//	    KeyPair:  myKeyPair,
+//	})
+//	http.ListenAndServe("127.0.0.1:9000", outbound)
+//
+// Signatures and Content-Digest headers sent by internal callers are
+// replaced. Bind the outbound listener to a private interface: anyone who
+// can reach it can send requests as the sidecar's DID.
+//
+// Both proxies stream responses, so message/stream and tasks/resubscribe
+// work through them.
+package sidecar
@@ -0,0 +1,190 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package sidecar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Identity headers set on requests forwarded to the backend
+const (
+	AgentDIDHeader     = "X-Agent-DID"
+	CapabilitiesHeader = "X-Agent-Capabilities"
+)
+
+// DefaultMaxBodySize is the largest request body NewOutbound buffers for
+// signing when OutboundConfig.MaxBodySize is not set
+const DefaultMaxBodySize = 10 << 20
+
+// signatureHeaders are removed from proxied requests
+var signatureHeaders = []string{"Signature", "Signature-Input"}
+
+// InboundConfig configures NewInbound
+type InboundConfig struct {
+	// Auth verifies inbound requests
+	Auth *server.DIDAuthMiddleware
+
+	// Backend is the internal service requests are forwarded to
+	Backend *url.URL
+
+	// Transport sends requests to the backend (default http.DefaultTransport)
+	Transport http.RoundTripper
+}
+
+// NewInbound creates a reverse proxy verifying DID signatures and
+// forwarding verified requests, unsigned, to the backend with identity
+// headers
+func NewInbound(config InboundConfig) (http.Handler, error) {
+	if config.Auth == nil {
+		return nil, errors.New("sidecar: Auth is required")
+	}
+	if config.Backend == nil {
+		return nil, errors.New("sidecar: Backend is required")
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(config.Backend)
+			pr.SetXForwarded()
+			pr.Out.Host = pr.In.Host
+
+			for _, h := range signatureHeaders {
+				pr.Out.Header.Del(h)
+			}
+			pr.Out.Header.Del(AgentDIDHeader)
+			pr.Out.Header.Del(CapabilitiesHeader)
+
+			ctx := pr.In.Context()
+			if agentDID, ok := server.GetAgentDIDFromContext(ctx); ok {
+				pr.Out.Header.Set(AgentDIDHeader, string(agentDID))
+			}
+			if caps := server.GetCapabilitiesFromContext(ctx); len(caps) > 0 {
+				pr.Out.Header.Set(CapabilitiesHeader, strings.Join(caps, ","))
+			}
+		},
+		Transport: config.Transport,
+	}
+	return config.Auth.Wrap(proxy), nil
+}
+
+// OutboundConfig configures NewOutbound
+type OutboundConfig struct {
+	// Target is the remote agent requests are forwarded to
+	Target *url.URL
+
+	// AgentDID and KeyPair sign forwarded requests
+	AgentDID did.AgentDID
+	KeyPair  crypto.KeyPair
+
+	// Signer signs forwarded requests (default signer.NewDefaultA2ASigner())
+	Signer signer.A2ASigner
+
+	// MaxBodySize limits buffered request bodies (default DefaultMaxBodySize)
+	MaxBodySize int64
+
+	// Transport sends signed requests (default http.DefaultTransport)
+	Transport http.RoundTripper
+}
+
+// NewOutbound creates a reverse proxy forwarding unsigned internal requests
+// to the target agent, signed with config.AgentDID
+func NewOutbound(config OutboundConfig) (http.Handler, error) {
+	if config.Target == nil {
+		return nil, errors.New("sidecar: Target is required")
+	}
+	if config.AgentDID == "" || config.KeyPair == nil {
+		return nil, errors.New("sidecar: AgentDID and KeyPair are required")
+	}
+	if config.Signer == nil {
+		config.Signer = signer.NewDefaultA2ASigner()
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultMaxBodySize
+	}
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(config.Target)
+			for _, h := range signatureHeaders {
+				pr.Out.Header.Del(h)
+			}
+			pr.Out.Header.Del("Content-Digest")
+			pr.Out.Header.Del(AgentDIDHeader)
+			pr.Out.Header.Del(CapabilitiesHeader)
+		},
+		Transport: &signingTransport{config: config},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			status := http.StatusBadGateway
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, err.Error(), status)
+		},
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, config.MaxBodySize)
+		}
+		proxy.ServeHTTP(w, r)
+	}), nil
+}
+
+// signingTransport signs requests before sending them. Signing happens
+// after the proxy rewrote the request, so the signature covers the path
+// the target sees.
+type signingTransport struct {
+	config OutboundConfig
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	if err := t.config.Signer.SignRequest(req.Context(), req, t.config.AgentDID, t.config.KeyPair); err != nil {
+		return nil, fmt.Errorf("failed to sign request with DID: %w", err)
+	}
+	return t.config.Transport.RoundTrip(req)
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package sidecar
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backendRequest is what the internal backend received
+type backendRequest struct {
+	path   string
+	header http.Header
+	body   string
+}
+
+// meshFixture chains an outbound sidecar to an inbound sidecar in front of
+// a recording backend, returning the outbound and inbound URLs
+func meshFixture(t *testing.T, got chan<- backendRequest) (string, string) {
+	t.Helper()
+	agentDID := did.AgentDID("did:sage:ethereum:0xsidecar")
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	reg := registry.NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(context.Background(), agentDID, keyPair.PublicKey()))
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- backendRequest{path: r.URL.Path, header: r.Header.Clone(), body: string(body)}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	}))
+	t.Cleanup(backend.Close)
+	backendURL, _ := url.Parse(backend.URL)
+
+	auth := server.NewDIDAuthMiddlewareWithVerifier(reg.NewDIDVerifier())
+	auth.SetCapabilityResolver(func(ctx context.Context, agentDID did.AgentDID) ([]string, error) {
+		return []string{"chat", "task.read"}, nil
+	})
+	inbound, err := NewInbound(InboundConfig{Auth: auth, Backend: backendURL})
+	require.NoError(t, err)
+	inboundSrv := httptest.NewServer(inbound)
+	t.Cleanup(inboundSrv.Close)
+	inboundURL, _ := url.Parse(inboundSrv.URL)
+
+	outbound, err := NewOutbound(OutboundConfig{Target: inboundURL, AgentDID: agentDID, KeyPair: keyPair})
+	require.NoError(t, err)
+	outboundSrv := httptest.NewServer(outbound)
+	t.Cleanup(outboundSrv.Close)
+
+	return outboundSrv.URL, inboundSrv.URL
+}
+
+func TestSidecar_OutboundToInbound(t *testing.T) {
+	got := make(chan backendRequest, 1)
+	outboundURL, _ := meshFixture(t, got)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"message/send"}`
+	req, err := http.NewRequest(http.MethodPost, outboundURL+"/rpc", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AgentDIDHeader, "did:sage:ethereum:0xspoofed")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	r := <-got
+	assert.Equal(t, "/rpc", r.path)
+	assert.Equal(t, body, r.body)
+	assert.Equal(t, "did:sage:ethereum:0xsidecar", r.header.Get(AgentDIDHeader))
+	assert.Equal(t, "chat,task.read", r.header.Get(CapabilitiesHeader))
+	assert.Empty(t, r.header.Get("Signature"))
+	assert.Empty(t, r.header.Get("Signature-Input"))
+}
+
+func TestSidecar_InboundRejectsUnsigned(t *testing.T) {
+	got := make(chan backendRequest, 1)
+	_, inboundURL := meshFixture(t, got)
+
+	req, err := http.NewRequest(http.MethodPost, inboundURL+"/rpc", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set(AgentDIDHeader, "did:sage:ethereum:0xsidecar")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Empty(t, got)
+}
+
+func TestNewInbound_RequiresConfig(t *testing.T) {
+	_, err := NewInbound(InboundConfig{})
+	assert.Error(t, err)
+	_, err = NewOutbound(OutboundConfig{Target: &url.URL{}})
+	assert.Error(t, err)
+}
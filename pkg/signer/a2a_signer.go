@@ -25,6 +25,8 @@ import (
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
+//go:generate mockgen -write_package_comment=false -destination=../mocks/a2a_signer.go -package=mocks . A2ASigner
+
 // A2ASigner signs HTTP messages for A2A protocol with DID identity
 type A2ASigner interface {
 	// SignRequest signs an HTTP request with the agent's key
@@ -45,7 +47,8 @@ type SigningOptions struct {
 	Created int64
 
 	// Expires is the timestamp when the signature expires (Unix timestamp)
-	// If 0, no expiration
+	// If 0, the signature expires at the context deadline or after the
+	// signer's TTL, whichever comes first, and never if neither is set
 	Expires int64
 
 	// Nonce is an optional nonce value for preventing replay attacks
@@ -53,4 +56,21 @@ type SigningOptions struct {
 
 	// Algorithm override (if empty, determined from key type)
 	Algorithm string
+
+	// DigestAlgorithm is the Content-Digest algorithm (DigestSHA256 or
+	// DigestSHA512). If empty, sha-256 is used.
+	DigestAlgorithm string
+
+	// Label is the signature label used in Signature and Signature-Input.
+	// If empty, the signer's label (DefaultSignatureLabel unless set) is used.
+	Label string
+
+	// Tag is the RFC 9421 tag parameter, naming the application profile
+	// of the signature
+	Tag string
+
+	// Params are further Signature-Input parameters, covered by the
+	// signature like the others. Their names must not be those of the
+	// parameters above.
+	Params []SignatureParam
 }
@@ -24,12 +24,15 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"fmt"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -317,6 +320,41 @@ func TestDefaultA2ASigner_SignRequestWithOptions_Expires(t *testing.T) {
 	assert.Contains(t, sigInput, "expires=1618884999")
 }
 
+func TestDefaultA2ASigner_SignRequest_DefaultExpires(t *testing.T) {
+	testDID := did.AgentDID("did:sage:ethereum:0xtest9")
+	keyPair := createMockECDSAKeyPair()
+	signer := NewDefaultA2ASigner()
+	created := time.Now().Unix()
+
+	expires := func(ctx context.Context) string {
+		req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+		require.NoError(t, signer.SignRequestWithOptions(ctx, req, testDID, keyPair, &SigningOptions{Created: created}))
+		_, after, found := strings.Cut(req.Header.Get("Signature-Input"), "expires=")
+		if !found {
+			return ""
+		}
+		value, _, _ := strings.Cut(after, ";")
+		return value
+	}
+	unix := func(sec int64) string { return fmt.Sprint(sec) }
+
+	// No TTL and no deadline: the signature does not expire
+	assert.Empty(t, expires(context.Background()))
+
+	signer.SetTTL(5 * time.Minute)
+	assert.Equal(t, unix(created+300), expires(context.Background()))
+
+	// An earlier context deadline wins
+	ctx, cancel := context.WithDeadline(context.Background(), time.Unix(created+60, 0))
+	defer cancel()
+	assert.Equal(t, unix(created+60), expires(ctx))
+
+	// A later one does not
+	ctx, cancel = context.WithDeadline(context.Background(), time.Unix(created+3600, 0))
+	defer cancel()
+	assert.Equal(t, unix(created+300), expires(ctx))
+}
+
 func TestDefaultA2ASigner_SignRequestWithOptions_Nonce(t *testing.T) {
 	// Test Case 10: Sign with nonce for replay attack prevention
 
@@ -445,6 +483,20 @@ func TestDefaultA2ASigner_GetAlgorithm_Ed25519(t *testing.T) {
 	assert.Equal(t, crypto.KeyTypeEd25519, keyPair.Type())
 }
 
+func TestDefaultA2ASigner_GetAlgorithm_ByPublicKey(t *testing.T) {
+	signer := NewDefaultA2ASigner()
+
+	p256 := createMockECDSAKeyPair()
+	p256.keyType = crypto.KeyType("p256")
+	assert.Equal(t, "ecdsa-p256-sha256", signer.getAlgorithm(p256))
+
+	ed := createMockEd25519KeyPair()
+	ed.keyType = crypto.KeyType("webcrypto-ed25519")
+	assert.Equal(t, "ed25519", signer.getAlgorithm(ed))
+
+	assert.Equal(t, "es256k", signer.getAlgorithm(createMockECDSAKeyPair()))
+}
+
 func TestDefaultA2ASigner_SignRequestWithOptions_NilOptions(t *testing.T) {
 	// Test Case 17: Nil options should use defaults
 
@@ -465,3 +517,51 @@ func TestDefaultA2ASigner_SignRequestWithOptions_NilOptions(t *testing.T) {
 	assert.NotEmpty(t, req.Header.Get("Signature-Input"))
 	assert.NotEmpty(t, req.Header.Get("Signature"))
 }
+
+func TestDefaultA2ASigner_SignatureLabel(t *testing.T) {
+	ctx := context.Background()
+	testDID := did.AgentDID("did:sage:ethereum:0xlabel")
+	keyPair := createMockECDSAKeyPair()
+
+	signer := NewDefaultA2ASigner()
+	req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+	require.NoError(t, signer.SignRequest(ctx, req, testDID, keyPair))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Signature-Input"), DefaultSignatureLabel+"="))
+
+	signer.SetLabel("sage")
+	req = httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+	require.NoError(t, signer.SignRequest(ctx, req, testDID, keyPair))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Signature-Input"), "sage="))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Signature"), "sage=:"))
+
+	// Per-request label wins; invalid labels are rejected
+	req = httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+	require.NoError(t, signer.SignRequestWithOptions(ctx, req, testDID, keyPair, &SigningOptions{Label: "proxy-1"}))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Signature-Input"), "proxy-1="))
+
+	req = httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+	err := signer.SignRequestWithOptions(ctx, req, testDID, keyPair, &SigningOptions{Label: "Sig 1"})
+	assert.ErrorContains(t, err, "invalid signature label")
+}
+
+func TestValidSignatureLabel(t *testing.T) {
+	for _, label := range []string{"sig1", "sage", "a-b_c.d", "*x"} {
+		assert.True(t, ValidSignatureLabel(label), label)
+	}
+	for _, label := range []string{"", "Sig1", "1sig", "sig 1", "sig=1"} {
+		assert.False(t, ValidSignatureLabel(label), label)
+	}
+}
+
+func TestNewDefaultA2ASigner_NotInitialized(t *testing.T) {
+	crypto.SetStorageConstructors(nil)
+	signer, pooled := NewDefaultA2ASigner(), NewPooledSigner()
+	crypto.SetStorageConstructors(func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() })
+
+	for _, s := range []A2ASigner{signer, pooled} {
+		req := httptest.NewRequest("POST", "https://agent.example.com/task", strings.NewReader(`{}`))
+		err := s.SignRequest(context.Background(), req, "did:sage:ethereum:0xtest", createMockEd25519KeyPair())
+		assert.ErrorIs(t, err, cryptoinit.ErrNotInitialized)
+		assert.Empty(t, req.Header.Get("Signature"))
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/internal/percentile"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// BenchOptions configures Measure and Bench
+type BenchOptions struct {
+	// Iterations is the number of measured operations (default 1000)
+	Iterations int
+
+	// Warmup operations run before measuring and are not recorded
+	Warmup int
+
+	// Concurrency is the number of goroutines issuing operations (default 1)
+	Concurrency int
+
+	// BodySize is the request body size used by Bench (default 256 bytes)
+	BodySize int
+}
+
+// BenchResult summarizes operation latencies
+type BenchResult struct {
+	Iterations int
+	Errors     int
+	Total      time.Duration // wall-clock time for all iterations
+	Min        time.Duration
+	Mean       time.Duration
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+	OpsPerSec  float64
+}
+
+// String formats the result on one line
+func (r *BenchResult) String() string {
+	return fmt.Sprintf("%d ops (%d errors) in %s: %.0f ops/s, min=%s mean=%s p50=%s p90=%s p99=%s max=%s",
+		r.Iterations, r.Errors, r.Total, r.OpsPerSec, r.Min, r.Mean, r.P50, r.P90, r.P99, r.Max)
+}
+
+// Measure runs op repeatedly and reports its latency distribution. Use it
+// to measure any signing-related operation, such as an HSM-backed sign or
+// a full verification round trip.
+func Measure(ctx context.Context, opts BenchOptions, op func(ctx context.Context) error) (*BenchResult, error) {
+	if opts.Iterations <= 0 {
+		opts.Iterations = 1000
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	for i := 0; i < opts.Warmup; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("context error: %w", err)
+		}
+		_ = op(ctx)
+	}
+
+	latencies := make([]time.Duration, opts.Iterations)
+	var (
+		mu     sync.Mutex
+		errs   int
+		next   int
+		wg     sync.WaitGroup
+		ctxErr error
+	)
+
+	start := time.Now()
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				if next >= opts.Iterations || ctxErr != nil {
+					mu.Unlock()
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					ctxErr = err
+					mu.Unlock()
+					return
+				}
+				i := next
+				next++
+				mu.Unlock()
+
+				t0 := time.Now()
+				err := op(ctx)
+				latencies[i] = time.Since(t0)
+				if err != nil {
+					mu.Lock()
+					errs++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	total := time.Since(start)
+
+	if ctxErr != nil {
+		return nil, fmt.Errorf("context error: %w", ctxErr)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	n := len(latencies)
+	return &BenchResult{
+		Iterations: n,
+		Errors:     errs,
+		Total:      total,
+		Min:        latencies[0],
+		Mean:       sum / time.Duration(n),
+		P50:        latencies[percentile.Index(n, 50)],
+		P90:        latencies[percentile.Index(n, 90)],
+		P99:        latencies[percentile.Index(n, 99)],
+		Max:        latencies[n-1],
+		OpsPerSec:  float64(n) / total.Seconds(),
+	}, nil
+}
+
+// Bench measures SignRequest latency of s with keyPair on POST requests
+// carrying a BodySize-byte JSON body. Pass a KeyPair backed by your HSM or
+// KMS to measure its real-world signing cost.
+func Bench(ctx context.Context, s A2ASigner, agentDID did.AgentDID, keyPair crypto.KeyPair, opts BenchOptions) (*BenchResult, error) {
+	if opts.BodySize <= 0 {
+		opts.BodySize = 256
+	}
+	body := benchBody(opts.BodySize)
+
+	return Measure(ctx, opts, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", "https://bench.example.com/rpc", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return s.SignRequest(ctx, req, agentDID, keyPair)
+	})
+}
+
+// benchBody returns a JSON object of exactly size bytes (minimum 12)
+func benchBody(size int) []byte {
+	const prefix, suffix = `{"data":"`, `"}`
+	pad := size - len(prefix) - len(suffix)
+	if pad < 1 {
+		pad = 1
+	}
+	return []byte(prefix + string(bytes.Repeat([]byte("x"), pad)) + suffix)
+}
@@ -22,24 +22,75 @@ import (
 	"bytes"
 	"context"
 	gocrypto "crypto"
-	"crypto/sha256"
-	"encoding/base64"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
 	"github.com/sage-x-project/sage/pkg/agent/core/rfc9421"
 	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
+// DefaultSignatureLabel is the signature label emitted unless configured
+const DefaultSignatureLabel = "sig1"
+
+// httpSigner is shared by all signers; rfc9421.HTTPVerifier holds no state
+var httpSigner = rfc9421.NewHTTPVerifier()
+
 // DefaultA2ASigner implements RFC9421-style HTTP Message Signatures.
-type DefaultA2ASigner struct{}
+type DefaultA2ASigner struct {
+	strict bool
+	label  string        // empty uses DefaultSignatureLabel
+	ttl    time.Duration // default signature lifetime; 0 leaves it unbounded
+
+	encoding SignatureEncoding // empty emits RFC 9421 byte sequences
+
+	// contentDigest sets the Content-Digest header; nil uses ensureContentDigestHeader
+	contentDigest func(req *http.Request, alg string) error
+
+	// initErr is the cryptoinit.Check result at construction, returned
+	// by every signing method
+	initErr error
+}
+
+// NewDefaultA2ASigner creates a new signer. If the sage crypto
+// constructors are not registered, its signing methods fail with
+// cryptoinit.ErrNotInitialized.
+func NewDefaultA2ASigner() *DefaultA2ASigner {
+	return &DefaultA2ASigner{initErr: cryptoinit.Check()}
+}
+
+// SetStrict makes SignRequestWithOptions reject options that fail
+// ValidateOptions instead of silently patching them.
+func (s *DefaultA2ASigner) SetStrict(strict bool) { s.strict = strict }
 
-// NewDefaultA2ASigner creates a new signer.
-func NewDefaultA2ASigner() *DefaultA2ASigner { return &DefaultA2ASigner{} }
+// SetLabel sets the signature label emitted when SigningOptions.Label is
+// empty, for peers expecting a label other than "sig1"
+func (s *DefaultA2ASigner) SetLabel(label string) { s.label = label }
+
+// SetTTL sets the default signature lifetime. Signatures without an
+// explicit SigningOptions.Expires expire ttl after their creation, or at
+// the deadline of the signing context if that comes first. Zero, the
+// default, bounds them by the context deadline only.
+func (s *DefaultA2ASigner) SetTTL(ttl time.Duration) { s.ttl = ttl }
+
+// signatureLabel returns the label to sign with
+func (s *DefaultA2ASigner) signatureLabel(opts *SigningOptions) string {
+	switch {
+	case opts.Label != "":
+		return opts.Label
+	case s.label != "":
+		return s.label
+	}
+	return DefaultSignatureLabel
+}
 
 // SignRequest signs an HTTP request with default options.
 // Default components: ["@method", "@path", "@query", "content-digest"]
@@ -51,6 +102,12 @@ func (s *DefaultA2ASigner) SignRequest(ctx context.Context, req *http.Request, a
 	return s.SignRequestWithOptions(ctx, req, agentDID, keyPair, opts)
 }
 
+// SignRequestAs signs an HTTP request with default options as id, using
+// its primary key
+func (s *DefaultA2ASigner) SignRequestAs(ctx context.Context, req *http.Request, id *identity.Identity) error {
+	return s.SignRequest(ctx, req, id.DID, id.KeyPair)
+}
+
 // SignRequestWithOptions signs an HTTP request with custom options,
 // delegating the actual signing to rfc9421.HTTPVerifier.
 func (s *DefaultA2ASigner) SignRequestWithOptions(
@@ -60,6 +117,34 @@ func (s *DefaultA2ASigner) SignRequestWithOptions(
 	keyPair sagecrypto.KeyPair,
 	opts *SigningOptions,
 ) error {
+	if err := checkSignArgs(ctx, req, agentDID, keyPair); err != nil {
+		return err
+	}
+	if s.strict {
+		if err := ValidateOptions(req.Method, opts); err != nil {
+			return err
+		}
+	}
+	opts = defaultOptions(opts)
+
+	if !includes(opts.Components, "content-digest") {
+		opts.Components = append(opts.Components, "content-digest")
+	}
+	if strings.TrimSpace(req.Header.Get("Content-Digest")) == "" {
+		setDigest := s.contentDigest
+		if setDigest == nil {
+			setDigest = ensureContentDigestHeader
+		}
+		if err := setDigest(req, opts.DigestAlgorithm); err != nil {
+			return fmt.Errorf("compute content-digest: %w", err)
+		}
+	}
+
+	return s.sign(ctx, req, agentDID, keyPair, opts)
+}
+
+// checkSignArgs validates the arguments shared by all signing methods
+func checkSignArgs(ctx context.Context, req *http.Request, agentDID did.AgentDID, keyPair sagecrypto.KeyPair) error {
 	if err := ctx.Err(); err != nil {
 		return fmt.Errorf("context error: %w", err)
 	}
@@ -72,24 +157,33 @@ func (s *DefaultA2ASigner) SignRequestWithOptions(
 	if strings.TrimSpace(string(agentDID)) == "" {
 		return fmt.Errorf("DID cannot be empty")
 	}
-	if opts == nil || len(opts.Components) == 0 {
-		opts = &SigningOptions{Components: []string{"@method", "@path", "@query", "content-digest"}}
-	}
+	return nil
+}
 
-	if !includes(opts.Components, "content-digest") {
-		opts.Components = append(opts.Components, "content-digest")
+// defaultOptions returns opts, filling in the default components if opts
+// covers nothing
+func defaultOptions(opts *SigningOptions) *SigningOptions {
+	if opts != nil && len(opts.Components) > 0 {
+		return opts
 	}
-	if strings.TrimSpace(req.Header.Get("Content-Digest")) == "" {
-		if err := ensureContentDigestHeader(req); err != nil {
-			return fmt.Errorf("compute content-digest: %w", err)
-		}
+	o := SigningOptions{}
+	if opts != nil {
+		o = *opts
 	}
+	o.Components = []string{"@method", "@path", "@query", "content-digest"}
+	return &o
+}
 
+// sign sets the Signature and Signature-Input headers of req
+func (s *DefaultA2ASigner) sign(ctx context.Context, req *http.Request, agentDID did.AgentDID, keyPair sagecrypto.KeyPair, opts *SigningOptions) error {
+	if s.initErr != nil {
+		return s.initErr
+	}
 	created := opts.Created
 	if created == 0 {
 		created = time.Now().Unix()
 	}
-	alg := s.getAlgorithm(keyPair.Type())
+	alg := s.getAlgorithm(keyPair)
 	if opts.Algorithm != "" {
 		alg = opts.Algorithm
 	}
@@ -99,7 +193,7 @@ func (s *DefaultA2ASigner) SignRequestWithOptions(
 		KeyID:             string(agentDID),
 		Algorithm:         alg,
 		Created:           created,
-		Expires:           opts.Expires,
+		Expires:           s.expires(ctx, opts, created),
 		Nonce:             opts.Nonce,
 	}
 
@@ -110,15 +204,77 @@ func (s *DefaultA2ASigner) SignRequestWithOptions(
 		return fmt.Errorf("private key does not implement crypto.Signer: %T", priv)
 	}
 
-	// RFC 9421 sign "sig1"
-	httpv := rfc9421.NewHTTPVerifier()
-	if err := httpv.SignRequest(req, "sig1", params, signer); err != nil {
+	label := s.signatureLabel(opts)
+	if !ValidSignatureLabel(label) {
+		return fmt.Errorf("invalid signature label: %q", label)
+	}
+	if opts.Tag != "" || len(opts.Params) > 0 {
+		if err := signWithParams(req, &SignatureParams{
+			Label:      label,
+			Components: unquoteComponents(params.CoveredComponents),
+			Created:    params.Created,
+			Expires:    params.Expires,
+			Nonce:      params.Nonce,
+			Algorithm:  params.Algorithm,
+			KeyID:      params.KeyID,
+			Tag:        opts.Tag,
+			Extra:      opts.Params,
+		}, signer); err != nil {
+			return fmt.Errorf("rfc9421 signing failed: %w", err)
+		}
+	} else if err := httpSigner.SignRequest(req, label, params, signer); err != nil {
 		return fmt.Errorf("rfc9421 signing failed: %w", err)
 	}
+	if s.encoding != "" && s.encoding != SignatureEncodingRFC9421 {
+		header, err := EncodeSignatureHeader(req.Header.Get("Signature"), label, s.encoding)
+		if err != nil {
+			return fmt.Errorf("failed to encode signature: %w", err)
+		}
+		req.Header.Set("Signature", header)
+	}
 
 	return nil
 }
 
+// expires returns the expires parameter of a signature created at created:
+// opts.Expires if set, otherwise the earlier of created plus the signer's
+// TTL and the deadline of ctx, or 0 if neither applies
+func (s *DefaultA2ASigner) expires(ctx context.Context, opts *SigningOptions, created int64) int64 {
+	if opts.Expires != 0 {
+		return opts.Expires
+	}
+	var expires int64
+	if s.ttl > 0 {
+		expires = created + int64((s.ttl+time.Second-1)/time.Second)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		// Never emit a signature that is expired on arrival
+		d := max(deadline.Unix(), created+1)
+		if expires == 0 || d < expires {
+			expires = d
+		}
+	}
+	return expires
+}
+
+// ValidSignatureLabel reports whether label is a valid structured field
+// key (RFC 8941): a lowercase letter or "*" followed by lowercase letters,
+// digits, "_", "-", "." or "*"
+func ValidSignatureLabel(label string) bool {
+	if label == "" {
+		return false
+	}
+	for i, c := range label {
+		switch {
+		case c >= 'a' && c <= 'z', c == '*':
+		case i > 0 && (c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func includes(list []string, v string) bool {
 	lv := strings.ToLower(v)
 	for _, e := range list {
@@ -142,8 +298,20 @@ func quoteComponents(components []string) []string {
 	return out
 }
 
-// Ensure Content-Digest over entire body (sha-256, base64, RFC9421 syntax)
-func ensureContentDigestHeader(req *http.Request) error {
+func unquoteComponents(components []string) []string {
+	out := make([]string, len(components))
+	for i, c := range components {
+		out[i] = strings.Trim(c, `"`)
+	}
+	return out
+}
+
+// Ensure Content-Digest over entire body (sha-256 unless alg says otherwise, RFC9421 syntax)
+func ensureContentDigestHeader(req *http.Request, alg string) error {
+	if alg == "" {
+		alg = DigestSHA256
+	}
+
 	var body []byte
 	if req.Body != nil {
 		var err error
@@ -156,19 +324,30 @@ func ensureContentDigestHeader(req *http.Request) error {
 	req.ContentLength = int64(len(body))
 	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
 
-	h := sha256.Sum256(body)
-	d := base64.StdEncoding.EncodeToString(h[:])
-	req.Header.Set("Content-Digest", "sha-256=:"+d+":")
+	value, err := ComputeContentDigest(alg, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Digest", value)
 	return nil
 }
 
-func (s *DefaultA2ASigner) getAlgorithm(k sagecrypto.KeyType) string {
-	switch k {
+// getAlgorithm returns the RFC 9421 algorithm for keyPair, by key type or,
+// for keys of other types such as WebCrypto P-256 keys, by public key
+func (s *DefaultA2ASigner) getAlgorithm(keyPair sagecrypto.KeyPair) string {
+	switch keyPair.Type() {
 	case sagecrypto.KeyTypeSecp256k1:
 		return "es256k"
 	case sagecrypto.KeyTypeEd25519:
 		return "ed25519"
-	default:
-		return ""
 	}
+	switch pub := keyPair.PublicKey().(type) {
+	case ed25519.PublicKey:
+		return "ed25519"
+	case *ecdsa.PublicKey:
+		if pub.Curve == elliptic.P256() {
+			return "ecdsa-p256-sha256"
+		}
+	}
+	return ""
 }
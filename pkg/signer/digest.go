@@ -0,0 +1,222 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Content digest algorithms (RFC 9530)
+const (
+	DigestSHA256 = "sha-256"
+	DigestSHA512 = "sha-512"
+)
+
+// SupportedDigestAlgorithms lists the digest algorithms this package can
+// compute and verify, most preferred first
+var SupportedDigestAlgorithms = []string{DigestSHA512, DigestSHA256}
+
+// ComputeContentDigest returns a Content-Digest header value for body,
+// e.g. `sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:`
+func ComputeContentDigest(alg string, body []byte) (string, error) {
+	sum, err := digest(alg, body)
+	if err != nil {
+		return "", err
+	}
+	return formatDigest(alg, sum), nil
+}
+
+func formatDigest(alg string, sum []byte) string {
+	return strings.ToLower(alg) + "=:" + base64.StdEncoding.EncodeToString(sum) + ":"
+}
+
+func digest(alg string, body []byte) ([]byte, error) {
+	h, err := newDigestHash(alg)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(body)
+	return h.Sum(nil), nil
+}
+
+func newDigestHash(alg string) (hash.Hash, error) {
+	switch strings.ToLower(alg) {
+	case DigestSHA256:
+		return sha256.New(), nil
+	case DigestSHA512:
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported digest algorithm: %s", alg)
+	}
+}
+
+// ContentDigester computes a Content-Digest incrementally for bodies that
+// are streamed rather than buffered
+type ContentDigester struct {
+	alg string
+	h   hash.Hash
+}
+
+// NewContentDigester creates a digester for alg (DigestSHA256 or DigestSHA512)
+func NewContentDigester(alg string) (*ContentDigester, error) {
+	h, err := newDigestHash(alg)
+	if err != nil {
+		return nil, err
+	}
+	return &ContentDigester{alg: strings.ToLower(alg), h: h}, nil
+}
+
+// Algorithm returns the digest algorithm
+func (d *ContentDigester) Algorithm() string { return d.alg }
+
+// Write adds p to the digest; it never fails
+func (d *ContentDigester) Write(p []byte) (int, error) { return d.h.Write(p) }
+
+// Value returns the Content-Digest header value for the bytes written so far
+func (d *ContentDigester) Value() string { return formatDigest(d.alg, d.h.Sum(nil)) }
+
+// Verify checks a Content-Digest header against the bytes written so far.
+// The header must carry an entry for the digester's algorithm.
+func (d *ContentDigester) Verify(header string) error {
+	sum := d.h.Sum(nil)
+	return verifyContentDigest(header, []string{d.alg}, func(string) ([]byte, error) { return sum, nil })
+}
+
+// FormatWantContentDigest builds a Want-Content-Digest header value from
+// algorithms in order of preference, e.g. "sha-512=10, sha-256=9"
+func FormatWantContentDigest(algs ...string) string {
+	parts := make([]string, 0, len(algs))
+	for i, alg := range algs {
+		weight := 10 - i
+		if weight < 1 {
+			weight = 1
+		}
+		parts = append(parts, fmt.Sprintf("%s=%d", strings.ToLower(alg), weight))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseWantContentDigest returns the acceptable algorithms of a
+// Want-Content-Digest header, highest preference first. Algorithms with
+// weight 0 ("not acceptable") are omitted.
+func ParseWantContentDigest(header string) []string {
+	type pref struct {
+		alg    string
+		weight int
+	}
+	var prefs []pref
+	for _, item := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(item), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		weight := 1
+		if value != "" {
+			w, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				continue
+			}
+			weight = w
+		}
+		if weight > 0 {
+			prefs = append(prefs, pref{name, weight})
+		}
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].weight > prefs[j].weight })
+
+	algs := make([]string, 0, len(prefs))
+	for _, p := range prefs {
+		algs = append(algs, p.alg)
+	}
+	return algs
+}
+
+// NegotiateDigest picks the peer's most preferred algorithm from a
+// Want-Content-Digest header that is also supported locally. It returns
+// DigestSHA256 when the header is empty or nothing matches.
+func NegotiateDigest(wantHeader string) string {
+	for _, alg := range ParseWantContentDigest(wantHeader) {
+		for _, supported := range SupportedDigestAlgorithms {
+			if alg == supported {
+				return alg
+			}
+		}
+	}
+	return DigestSHA256
+}
+
+// VerifyContentDigest checks a Content-Digest header against body.
+// The header may carry several entries (e.g. both sha-256 and sha-512);
+// every entry using an algorithm in accepted must match, and at least one
+// such entry must be present. Entries with other algorithms are ignored.
+// If accepted is empty, SupportedDigestAlgorithms is used.
+func VerifyContentDigest(header string, body []byte, accepted []string) error {
+	if len(accepted) == 0 {
+		accepted = SupportedDigestAlgorithms
+	}
+	return verifyContentDigest(header, accepted, func(alg string) ([]byte, error) { return digest(alg, body) })
+}
+
+// verifyContentDigest checks the entries of header using an accepted
+// algorithm against the digests returned by sum
+func verifyContentDigest(header string, accepted []string, sum func(alg string) ([]byte, error)) error {
+	checked := 0
+	for _, item := range strings.Split(header, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return fmt.Errorf("invalid Content-Digest entry: %q", item)
+		}
+		alg := strings.ToLower(strings.TrimSpace(name))
+		if !includes(accepted, alg) {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || value[0] != ':' || value[len(value)-1] != ':' {
+			return fmt.Errorf("invalid %s digest encoding", alg)
+		}
+		want, err := base64.StdEncoding.DecodeString(value[1 : len(value)-1])
+		if err != nil {
+			return fmt.Errorf("invalid %s digest encoding: %w", alg, err)
+		}
+
+		got, err := sum(alg)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare(want, got) != 1 {
+			return fmt.Errorf("content digest mismatch (%s)", alg)
+		}
+		checked++
+	}
+
+	if checked == 0 {
+		return fmt.Errorf("no acceptable digest algorithm in Content-Digest (accepted: %s)", strings.Join(accepted, ", "))
+	}
+	return nil
+}
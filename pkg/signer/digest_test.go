@@ -0,0 +1,92 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeContentDigest(t *testing.T) {
+	// RFC 9530 Appendix B example: {"hello": "world"}
+	body := []byte(`{"hello": "world"}` + "\n")
+
+	v, err := ComputeContentDigest(DigestSHA256, body)
+	require.NoError(t, err)
+	assert.Equal(t, "sha-256=:RK/0qy18MlBSVnWgjwz6lZEWjP/lF5HF9bvEF8FabDg=:", v)
+
+	v, err = ComputeContentDigest(DigestSHA512, body)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(v, "sha-512=:"))
+
+	_, err = ComputeContentDigest("md5", body)
+	assert.Error(t, err)
+}
+
+func TestWantContentDigestNegotiation(t *testing.T) {
+	assert.Equal(t, "sha-512=10, sha-256=9", FormatWantContentDigest(DigestSHA512, DigestSHA256))
+	assert.Equal(t, []string{"sha-512", "sha-256"}, ParseWantContentDigest("sha-256=3, sha-512=10, md5=0"))
+
+	assert.Equal(t, DigestSHA512, NegotiateDigest("sha-512=10, sha-256=1"))
+	assert.Equal(t, DigestSHA256, NegotiateDigest("sha-256=5, sha-512=0"))
+	assert.Equal(t, DigestSHA256, NegotiateDigest("blake3=10"))
+	assert.Equal(t, DigestSHA256, NegotiateDigest(""))
+}
+
+func TestVerifyContentDigest(t *testing.T) {
+	body := []byte(`{"jsonrpc":"2.0"}`)
+	d256, _ := ComputeContentDigest(DigestSHA256, body)
+	d512, _ := ComputeContentDigest(DigestSHA512, body)
+
+	assert.NoError(t, VerifyContentDigest(d256, body, nil))
+	assert.NoError(t, VerifyContentDigest(d512, body, nil))
+	assert.NoError(t, VerifyContentDigest(d256+", "+d512, body, nil))
+
+	// Unknown algorithms are ignored as long as one accepted entry matches
+	assert.NoError(t, VerifyContentDigest("unixsum=:AAAA:, "+d512, body, nil))
+
+	// Algorithm not accepted
+	err := VerifyContentDigest(d256, body, []string{DigestSHA512})
+	assert.ErrorContains(t, err, "no acceptable digest algorithm")
+
+	// Tampered body
+	err = VerifyContentDigest(d256+", "+d512, []byte("tampered"), nil)
+	assert.ErrorContains(t, err, "content digest mismatch")
+
+	assert.Error(t, VerifyContentDigest("sha-256=AAAA", body, nil))
+}
+
+func TestDefaultA2ASigner_DigestAlgorithm(t *testing.T) {
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+	opts := &SigningOptions{
+		Components:      []string{"@method", "@path", "content-digest"},
+		DigestAlgorithm: DigestSHA512,
+	}
+
+	// Signing itself may fail with mock keys; the digest is computed first
+	_ = NewDefaultA2ASigner().SignRequestWithOptions(context.Background(), req, "did:sage:ethereum:0x1", createMockECDSAKeyPair(), opts)
+
+	digest := req.Header.Get("Content-Digest")
+	assert.True(t, strings.HasPrefix(digest, "sha-512=:"), digest)
+	assert.NoError(t, VerifyContentDigest(digest, []byte(`{}`), nil))
+}
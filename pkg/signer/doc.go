@@ -48,6 +48,22 @@
 //
 //	err := signer.SignRequestWithOptions(ctx, req, agentDID, keyPair, opts)
 //
+// Signatures are labeled "sig1" (DefaultSignatureLabel). Use SetLabel, or
+// SigningOptions.Label per request, for peers expecting another label.
+//
+// Extensions building on RFC 9421 set the tag parameter and parameters of
+// their own with SigningOptions.Tag and SigningOptions.Params. Such
+// signatures are computed by this package over a base it builds itself:
+//
+//	opts := &signer.SigningOptions{
+//	    Tag:    "a2a-delegation",
+//	    Params: []signer.SignatureParam{{Name: "hop", Value: int64(2)}},
+//	}
+//
+// ParseSignatureParams and LookupSignatureParams return the typed
+// parameters of a Signature-Input header, and SignatureBase the base they
+// cover.
+//
 // # Signature Components
 //
 // Common HTTP components to include in signatures:
@@ -59,6 +75,55 @@
 //   - content-digest - Hash of request body
 //   - authorization - Authorization header
 //
+// # Component Policy
+//
+// ValidateOptions rejects configurations that are insecure by construction
+// (no @method, no target, no created, no content-digest on POST):
+//
+//	if err := signer.ValidateOptions("POST", opts); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// SetStrict(true) applies the same check on every SignRequestWithOptions call.
+//
+// # Content Digests
+//
+// Content-Digest uses sha-256 by default; set SigningOptions.DigestAlgorithm
+// to DigestSHA512 for peers that prefer it. NegotiateDigest picks the
+// algorithm from a server's Want-Content-Digest header, and
+// VerifyContentDigest accepts headers carrying several digest entries.
+//
+// # Streamed Bodies and Trailers
+//
+// SignRequestStreaming signs uploads without reading the body first, so
+// they can be streamed or sent with "Expect: 100-continue". The header
+// signature covers TrailerDigestHeader instead of content-digest; the body
+// is sent chunked and, once it has been read, a Content-Digest trailer and a
+// second signature over it are added. The trailer signature's nonce is the
+// header nonce plus TrailerNonceSuffix, binding the two together.
+// ContentDigester computes digests incrementally for such bodies.
+//
+//	req.Header.Set("Expect", "100-continue")
+//	err := signer.SignRequestStreaming(ctx, req, agentDID, keyPair, nil)
+//
+// # Performance
+//
+// NewPooledSigner returns a signer producing the same output as
+// DefaultA2ASigner while reusing body buffers and digest hash state through
+// sync.Pool, which roughly halves allocated bytes for large bodies.
+//
+// Bench measures SignRequest latency for any signer and key pair, so an
+// HSM- or KMS-backed KeyPair can be evaluated before deployment; Measure
+// times arbitrary operations:
+//
+//	result, err := signer.Bench(ctx, signer.NewPooledSigner(), myDID, hsmKeyPair,
+//	    signer.BenchOptions{Iterations: 500, Concurrency: 8})
+//	log.Println(result) // ops/s, mean and p50/p90/p99 latency
+//
+// The package benchmarks compare key types and signers:
+//
+//	go test -bench KeyTypes -benchmem ./pkg/signer ./pkg/verifier
+//
 // # DID Integration
 //
 // The signer includes the agent's DID as the keyid parameter:
@@ -72,6 +137,10 @@
 //  3. Verify the signature cryptographically
 //  4. Authenticate the sender's identity
 //
+// Signing with a RotatingKeyPair lets the key be switched at runtime, as
+// rotation.Manager does during a key rotation, without rebuilding the
+// clients and transports using it.
+//
 // # Supported Algorithms
 //
 // The package supports both ECDSA and Ed25519 signatures:
@@ -79,7 +148,21 @@
 //   - ES256K (ECDSA with secp256k1) for Ethereum/EVM chains
 //   - EdDSA (Ed25519) for Solana and other Ed25519-based chains
 //
-// The algorithm is automatically determined from the key pair type.
+// The algorithm is automatically determined from the key pair type, or
+// from the public key for other key types: P-256 keys sign with
+// "ecdsa-p256-sha256".
+//
+// # Browser Agents
+//
+// In js/wasm builds, GenerateWebCryptoKeyPair creates Ed25519 or P-256 key
+// pairs with the browser's WebCrypto API for use with DefaultA2ASigner.
+// Keys are kept in memory only. Signing waits on WebCrypto promises, so it
+// must run off the JavaScript event loop goroutine:
+//
+//	keyPair, err := signer.GenerateWebCryptoKeyPair(signer.WebCryptoEd25519)
+//	err = signer.NewDefaultA2ASigner().SignRequest(ctx, req, myDID, keyPair)
+//
+// cmd/examples/browser-agent wraps this for fetch from JavaScript.
 //
 // # RFC9421 Compliance
 //
@@ -91,6 +174,10 @@
 //   - Component identifiers (@method, @target-uri, etc.)
 //   - Signature parameters (created, expires, keyid, nonce)
 //
+// Peers that cannot parse RFC 9421 byte sequences can be served with
+// SetSignatureEncoding(SignatureEncodingHex) or SignatureEncodingBase64.
+// This breaks conformance and should be limited to such peers.
+//
 // # Example: Complete Request Flow
 //
 //	// Create request
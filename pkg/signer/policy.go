@@ -0,0 +1,127 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// MaxSignatureComponents is the largest number of covered components
+	// accepted by the component policy
+	MaxSignatureComponents = 32
+
+	// MaxSignatureHeaderSize is the largest accepted size in bytes of the
+	// Signature-Input and Signature headers combined
+	MaxSignatureHeaderSize = 8 * 1024
+
+	// maxCreatedSkew is how far in the future a created timestamp may be
+	maxCreatedSkew = time.Minute
+)
+
+// PolicyError lists every way a signing configuration violates the
+// component policy
+type PolicyError struct {
+	Violations []string
+}
+
+// Error implements error
+func (e *PolicyError) Error() string {
+	return "insecure signature configuration: " + strings.Join(e.Violations, "; ")
+}
+
+// ValidateOptions lints opts for a request with the given method and rejects
+// configurations that are insecure by construction. Call it at startup (or in
+// tests) so misconfigured agents fail fast instead of producing signatures
+// that peers cannot trust.
+//
+// Note that SignRequestWithOptions silently adds content-digest when it is
+// missing; ValidateOptions reports it so the configuration is fixed at the source.
+func ValidateOptions(method string, opts *SigningOptions) error {
+	if opts == nil || len(opts.Components) == 0 {
+		// Defaults are always compliant
+		return nil
+	}
+
+	created := opts.Created
+	if created == 0 {
+		created = time.Now().Unix()
+	}
+	return CheckComponentPolicy(method, opts.Components, created, opts.Expires)
+}
+
+// CheckComponentPolicy checks covered components and timestamps of a
+// signature. It requires:
+//   - @method
+//   - the request target (@target-uri, @request-target or @path)
+//   - a created timestamp not in the future, and expires after created
+//   - content-digest for methods that carry a body (POST, PUT, PATCH), or
+//     the TrailerDigestHeader announcing it as a trailer
+//   - no duplicate components and at most MaxSignatureComponents
+//
+// Components may be given with or without surrounding quotes.
+func CheckComponentPolicy(method string, components []string, created, expires int64) error {
+	var violations []string
+
+	seen := make(map[string]bool, len(components))
+	for _, c := range components {
+		c = normalizeComponent(c)
+		if seen[c] {
+			violations = append(violations, "duplicate component "+c)
+		}
+		seen[c] = true
+	}
+
+	if len(components) > MaxSignatureComponents {
+		violations = append(violations, "too many covered components")
+	}
+	if !seen["@method"] {
+		violations = append(violations, "@method must be covered")
+	}
+	if !seen["@target-uri"] && !seen["@request-target"] && !seen["@path"] {
+		violations = append(violations, "@target-uri (or @path) must be covered")
+	}
+
+	switch {
+	case created <= 0:
+		violations = append(violations, "created timestamp is required")
+	case time.Unix(created, 0).After(time.Now().Add(maxCreatedSkew)):
+		violations = append(violations, "created timestamp is in the future")
+	}
+	if expires != 0 && expires <= created {
+		violations = append(violations, "expires must be after created")
+	}
+
+	switch strings.ToUpper(method) {
+	case "POST", "PUT", "PATCH":
+		if !seen["content-digest"] && !seen[trailerDigestComponent] {
+			violations = append(violations, "content-digest must be covered for "+strings.ToUpper(method)+" requests")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// normalizeComponent lowercases a component identifier and strips quotes
+func normalizeComponent(c string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(c), `"`))
+}
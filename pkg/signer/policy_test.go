@@ -0,0 +1,91 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOptions(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name   string
+		method string
+		opts   *SigningOptions
+		want   []string
+	}{
+		{"defaults", "POST", nil, nil},
+		{"compliant", "POST", &SigningOptions{Components: []string{"@method", "@target-uri", "content-digest"}}, nil},
+		{"quoted components", "GET", &SigningOptions{Components: []string{`"@method"`, `"@path"`}}, nil},
+		{"missing method", "GET", &SigningOptions{Components: []string{"@path"}}, []string{"@method must be covered"}},
+		{"missing target", "GET", &SigningOptions{Components: []string{"@method"}}, []string{"@target-uri (or @path) must be covered"}},
+		{"missing digest on POST", "POST", &SigningOptions{Components: []string{"@method", "@path"}}, []string{"content-digest must be covered for POST requests"}},
+		{"future created", "GET", &SigningOptions{Components: []string{"@method", "@path"}, Created: now + 3600}, []string{"created timestamp is in the future"}},
+		{"expires before created", "GET", &SigningOptions{Components: []string{"@method", "@path"}, Created: now, Expires: now - 1}, []string{"expires must be after created"}},
+		{"duplicate", "GET", &SigningOptions{Components: []string{"@method", "@path", "@METHOD"}}, []string{"duplicate component @method"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOptions(tt.method, tt.opts)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var policyErr *PolicyError
+			require.ErrorAs(t, err, &policyErr)
+			assert.Equal(t, tt.want, policyErr.Violations)
+		})
+	}
+}
+
+func TestCheckComponentPolicy_MissingCreated(t *testing.T) {
+	err := CheckComponentPolicy("GET", []string{"@method", "@path"}, 0, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "created timestamp is required")
+
+	components := make([]string, MaxSignatureComponents+1)
+	for i := range components {
+		components[i] = "x-header-" + strings.Repeat("a", i+1)
+	}
+	err = CheckComponentPolicy("GET", append(components, "@method", "@path"), time.Now().Unix(), 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too many covered components")
+}
+
+func TestDefaultA2ASigner_Strict(t *testing.T) {
+	s := NewDefaultA2ASigner()
+	s.SetStrict(true)
+
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+	err := s.SignRequestWithOptions(context.Background(), req, "did:sage:ethereum:0x1", createMockECDSAKeyPair(),
+		&SigningOptions{Components: []string{"@method"}})
+
+	var policyErr *PolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.Len(t, policyErr.Violations, 2)
+	assert.Empty(t, req.Header.Get("Signature"))
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+)
+
+// maxPooledBufferSize caps the buffers returned to the pool so one large
+// body does not pin memory for the lifetime of the process
+const maxPooledBufferSize = 1 << 20
+
+var (
+	bufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+	sha256Pool = sync.Pool{New: func() any { return sha256.New() }}
+	sha512Pool = sync.Pool{New: func() any { return sha512.New() }}
+)
+
+// PooledSigner is a DefaultA2ASigner tuned for high request rates. Body
+// buffers and digest hash state are reused across requests through
+// sync.Pool, cutting allocations per signed request. Its output is
+// identical to DefaultA2ASigner's.
+type PooledSigner struct {
+	DefaultA2ASigner
+}
+
+// NewPooledSigner creates a PooledSigner
+func NewPooledSigner() *PooledSigner {
+	return &PooledSigner{DefaultA2ASigner{contentDigest: pooledContentDigestHeader, initErr: cryptoinit.Check()}}
+}
+
+// pooledContentDigestHeader is ensureContentDigestHeader using pooled
+// buffers and hashes. The body is hashed while it is read.
+func pooledContentDigestHeader(req *http.Request, alg string) error {
+	if alg == "" {
+		alg = DigestSHA256
+	}
+	alg = strings.ToLower(alg)
+
+	var pool *sync.Pool
+	switch alg {
+	case DigestSHA256:
+		pool = &sha256Pool
+	case DigestSHA512:
+		pool = &sha512Pool
+	default:
+		return fmt.Errorf("unsupported digest algorithm: %s", alg)
+	}
+
+	h := pool.Get().(hash.Hash)
+	h.Reset()
+	defer pool.Put(h)
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if req.Body != nil {
+		if _, err := io.Copy(io.MultiWriter(buf, h), req.Body); err != nil {
+			return err
+		}
+	}
+
+	// The request keeps its own copy; buf goes back to the pool
+	body := bytes.Clone(buf.Bytes())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+
+	var sum [sha512.Size]byte
+	digest := h.Sum(sum[:0])
+
+	var out [128]byte
+	value := append(out[:0], alg...)
+	value = append(value, "=:"...)
+	value = base64.StdEncoding.AppendEncode(value, digest)
+	value = append(value, ':')
+
+	req.Header.Set("Content-Digest", string(value))
+	return nil
+}
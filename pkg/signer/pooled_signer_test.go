@@ -0,0 +1,94 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPooledContentDigestHeader(t *testing.T) {
+	for _, alg := range []string{"", DigestSHA256, DigestSHA512} {
+		t.Run("alg="+alg, func(t *testing.T) {
+			body := strings.Repeat(`{"hello":"world"}`, 100)
+
+			want := httptest.NewRequest("POST", "/rpc", strings.NewReader(body))
+			require.NoError(t, ensureContentDigestHeader(want, alg))
+
+			got := httptest.NewRequest("POST", "/rpc", strings.NewReader(body))
+			require.NoError(t, pooledContentDigestHeader(got, alg))
+
+			assert.Equal(t, want.Header.Get("Content-Digest"), got.Header.Get("Content-Digest"))
+			assert.Equal(t, int64(len(body)), got.ContentLength)
+
+			restored, err := io.ReadAll(got.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(restored))
+
+			rc, err := got.GetBody()
+			require.NoError(t, err)
+			again, _ := io.ReadAll(rc)
+			assert.Equal(t, body, string(again))
+		})
+	}
+
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader("x"))
+	assert.Error(t, pooledContentDigestHeader(req, "md5"))
+}
+
+func TestPooledSigner_SignRequest(t *testing.T) {
+	signer := NewPooledSigner()
+	testDID := did.AgentDID("did:sage:ethereum:0xpooled")
+
+	// Sign several requests so pooled buffers are reused
+	for i := 0; i < 3; i++ {
+		body := strings.Repeat("a", 10*(i+1))
+		req := httptest.NewRequest("POST", "https://agent.example.com/rpc", strings.NewReader(body))
+		require.NoError(t, signer.SignRequest(context.Background(), req, testDID, createMockEd25519KeyPair()))
+
+		digest, _ := ComputeContentDigest(DigestSHA256, []byte(body))
+		assert.Equal(t, digest, req.Header.Get("Content-Digest"))
+		assert.Contains(t, req.Header.Get("Signature-Input"), string(testDID))
+		assert.NotEmpty(t, req.Header.Get("Signature"))
+	}
+}
+
+func TestBench(t *testing.T) {
+	result, err := Bench(context.Background(), NewPooledSigner(), "did:sage:ethereum:0xbench", createMockEd25519KeyPair(),
+		BenchOptions{Iterations: 50, Warmup: 5, Concurrency: 4, BodySize: 512})
+	require.NoError(t, err)
+
+	assert.Equal(t, 50, result.Iterations)
+	assert.Zero(t, result.Errors)
+	assert.True(t, result.Min <= result.P50 && result.P50 <= result.P99 && result.P99 <= result.Max)
+	assert.Greater(t, result.OpsPerSec, 0.0)
+	assert.Contains(t, result.String(), "50 ops (0 errors)")
+	assert.Len(t, benchBody(512), 512)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Measure(ctx, BenchOptions{Iterations: 10}, func(context.Context) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"crypto"
+	"sync/atomic"
+
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+)
+
+// RotatingKeyPair is a KeyPair delegating to a key that can be switched at
+// runtime. Clients and transports created with it sign with the new key
+// as soon as Switch is called, without being rebuilt.
+type RotatingKeyPair struct {
+	current atomic.Pointer[sagecrypto.KeyPair]
+}
+
+var _ sagecrypto.KeyPair = (*RotatingKeyPair)(nil)
+
+// NewRotatingKeyPair creates a RotatingKeyPair starting with keyPair
+func NewRotatingKeyPair(keyPair sagecrypto.KeyPair) *RotatingKeyPair {
+	k := &RotatingKeyPair{}
+	k.current.Store(&keyPair)
+	return k
+}
+
+// Switch makes keyPair the active key, returning the previous one
+func (k *RotatingKeyPair) Switch(keyPair sagecrypto.KeyPair) sagecrypto.KeyPair {
+	return *k.current.Swap(&keyPair)
+}
+
+// Current returns the active key
+func (k *RotatingKeyPair) Current() sagecrypto.KeyPair {
+	return *k.current.Load()
+}
+
+func (k *RotatingKeyPair) ID() string                    { return k.Current().ID() }
+func (k *RotatingKeyPair) PublicKey() crypto.PublicKey   { return k.Current().PublicKey() }
+func (k *RotatingKeyPair) PrivateKey() crypto.PrivateKey { return k.Current().PrivateKey() }
+func (k *RotatingKeyPair) Type() sagecrypto.KeyType      { return k.Current().Type() }
+
+// Sign signs with the active key
+func (k *RotatingKeyPair) Sign(message []byte) ([]byte, error) {
+	return k.Current().Sign(message)
+}
+
+// Verify verifies against the active key
+func (k *RotatingKeyPair) Verify(message, signature []byte) error {
+	return k.Current().Verify(message, signature)
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// benchKeyTypes lists the key types benchmarked for signing and verification
+var benchKeyTypes = []struct {
+	name     string
+	generate func() (crypto.KeyPair, error)
+}{
+	{"Ed25519", keys.GenerateEd25519KeyPair},
+	{"Secp256k1", keys.GenerateSecp256k1KeyPair},
+	{"P256", keys.GenerateP256KeyPair},
+}
+
+// BenchmarkSignRequest_KeyTypes compares default and pooled signers across
+// key types and body sizes; run with -benchmem to see allocation savings
+func BenchmarkSignRequest_KeyTypes(b *testing.B) {
+	ctx := context.Background()
+	testDID := did.AgentDID("did:sage:ethereum:0xbenchmark")
+
+	signers := []struct {
+		name   string
+		signer A2ASigner
+	}{
+		{"Default", NewDefaultA2ASigner()},
+		{"Pooled", NewPooledSigner()},
+	}
+
+	for _, kt := range benchKeyTypes {
+		keyPair, err := kt.generate()
+		if err != nil {
+			b.Fatalf("failed to generate %s key: %v", kt.name, err)
+		}
+		for _, size := range []int{256, 64 * 1024} {
+			body := benchBody(size)
+			for _, s := range signers {
+				b.Run(kt.name+"/"+s.name+"/"+byteSize(size), func(b *testing.B) {
+					b.ReportAllocs()
+					b.SetBytes(int64(size))
+					for i := 0; i < b.N; i++ {
+						req, _ := http.NewRequest("POST", "https://bench.example.com/rpc", bytes.NewReader(body))
+						if err := s.signer.SignRequest(ctx, req, testDID, keyPair); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func byteSize(n int) string {
+	if n >= 1024 {
+		return strconv.Itoa(n/1024) + "KiB"
+	}
+	return strconv.Itoa(n) + "B"
+}
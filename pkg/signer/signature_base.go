@@ -0,0 +1,145 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+// SignatureBase returns the RFC 9421 signature base of req for params
+func SignatureBase(req *http.Request, params *SignatureParams) (string, error) {
+	var b strings.Builder
+	for _, c := range params.Components {
+		value, ok := ComponentValue(req, c)
+		if !ok {
+			return "", fmt.Errorf("covered component %q is not in the request", c)
+		}
+		fmt.Fprintf(&b, "%q: %s\n", c, value)
+	}
+	fmt.Fprintf(&b, "\"@signature-params\": %s", params.String())
+	return b.String(), nil
+}
+
+// ComponentValue returns the value of a covered component in req, as it
+// appears in the signature base. Components with parameters and derived
+// components other than @method, @scheme, @authority, @target-uri,
+// @request-target, @path and @query are not supported.
+func ComponentValue(req *http.Request, name string) (string, bool) {
+	scheme := req.URL.Scheme
+	if scheme == "" {
+		scheme = "http"
+		if req.TLS != nil {
+			scheme = "https"
+		}
+	}
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	switch name {
+	case "@method":
+		return req.Method, true
+	case "@scheme":
+		return scheme, true
+	case "@authority":
+		return strings.ToLower(host), true
+	case "@target-uri":
+		return scheme + "://" + host + req.URL.RequestURI(), true
+	case "@request-target":
+		return req.URL.RequestURI(), true
+	case "@path":
+		path := req.URL.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		return path, true
+	case "@query":
+		return "?" + req.URL.RawQuery, true
+	}
+	if strings.HasPrefix(name, "@") || strings.Contains(name, ";") {
+		// Derived components this diagnosis does not reconstruct
+		return "", false
+	}
+	values := req.Header.Values(name)
+	if len(values) == 0 {
+		return "", false
+	}
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(trimmed, ", "), true
+}
+
+// signWithParams signs req itself rather than through the RFC 9421
+// library, so that parameters it does not know, such as tag, are covered
+func signWithParams(req *http.Request, params *SignatureParams, key gocrypto.Signer) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	base, err := SignatureBase(req, params)
+	if err != nil {
+		return err
+	}
+	sig, err := signBase(key, []byte(base))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Signature-Input", params.Label+"="+params.String())
+	req.Header.Set("Signature", params.Label+"=:"+base64.StdEncoding.EncodeToString(sig)+":")
+	return nil
+}
+
+// signBase signs a signature base: Ed25519 over the base, ECDSA over its
+// SHA-256 digest with r and s concatenated as RFC 9421 requires
+func signBase(key gocrypto.Signer, base []byte) ([]byte, error) {
+	switch pub := key.Public().(type) {
+	case ed25519.PublicKey:
+		return key.Sign(rand.Reader, base, gocrypto.Hash(0))
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(base)
+		sig, err := key.Sign(rand.Reader, digest[:], gocrypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) == 2*size {
+			return sig, nil
+		}
+		var rs struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(sig, &rs); err != nil {
+			return nil, fmt.Errorf("unexpected ECDSA signature encoding: %w", err)
+		}
+		out := make([]byte, 2*size)
+		rs.R.FillBytes(out[:size])
+		rs.S.FillBytes(out[size:])
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", key.Public())
+}
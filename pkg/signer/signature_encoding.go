@@ -0,0 +1,159 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package signer
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SignatureEncoding is the way signature bytes are written in the Signature
+// header. RFC 9421 requires a structured field byte sequence; the other
+// encodings exist only for interoperability with non-conforming peers.
+type SignatureEncoding string
+
+const (
+	// SignatureEncodingRFC9421 is the standard byte sequence, e.g. sig1=:MEUCIQ...=:
+	SignatureEncodingRFC9421 SignatureEncoding = "rfc9421"
+
+	// SignatureEncodingHex is bare hexadecimal, e.g. sig1=3045022100...
+	// An "0x" prefix and upper case digits are accepted when parsing.
+	SignatureEncodingHex SignatureEncoding = "hex"
+
+	// SignatureEncodingBase64 is bare base64 without the surrounding colons.
+	// Standard and URL alphabets, padded or not, are accepted when parsing.
+	SignatureEncodingBase64 SignatureEncoding = "base64"
+)
+
+// SetSignatureEncoding makes the signer emit signatures in enc instead of
+// the RFC 9421 byte sequence. Only use this for peers that cannot parse
+// conforming signatures; conforming verifiers reject other encodings.
+func (s *DefaultA2ASigner) SetSignatureEncoding(enc SignatureEncoding) {
+	s.encoding = enc
+}
+
+// EncodeSignatureHeader rewrites the signature with label in a Signature
+// header from the RFC 9421 byte sequence to enc
+func EncodeSignatureHeader(header, label string, enc SignatureEncoding) (string, error) {
+	entries := strings.Split(header, ",")
+	found := false
+	for i, entry := range entries {
+		entries[i] = strings.TrimSpace(entry)
+		name, value, ok := strings.Cut(entries[i], "=")
+		if !ok || name != label {
+			continue
+		}
+		sig, err := decodeByteSequence(value)
+		if err != nil {
+			return "", err
+		}
+		switch enc {
+		case SignatureEncodingRFC9421, "":
+			value = ":" + base64.StdEncoding.EncodeToString(sig) + ":"
+		case SignatureEncodingHex:
+			value = hex.EncodeToString(sig)
+		case SignatureEncodingBase64:
+			value = base64.StdEncoding.EncodeToString(sig)
+		default:
+			return "", fmt.Errorf("unsupported signature encoding %q", enc)
+		}
+		entries[i] = name + "=" + value
+		found = true
+	}
+	if !found {
+		return "", fmt.Errorf("signature %q not found in Signature header", label)
+	}
+	return strings.Join(entries, ", "), nil
+}
+
+// NormalizeSignatureHeader rewrites signatures in a Signature header that
+// use one of the accepted non-standard encodings, or are wrapped in double
+// quotes, to the RFC 9421 byte sequence. Hex is tried before base64 when
+// both are accepted. Entries already in RFC 9421 form are kept as is.
+func NormalizeSignatureHeader(header string, accepted []SignatureEncoding) (string, error) {
+	entries := strings.Split(header, ",")
+	for i, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return "", fmt.Errorf("invalid Signature entry: %q", entry)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		if _, err := decodeByteSequence(value); err == nil {
+			entries[i] = name + "=" + value
+			continue
+		}
+		sig, err := decodeLenient(value, accepted)
+		if err != nil {
+			return "", fmt.Errorf("signature %s: %w", name, err)
+		}
+		entries[i] = name + "=:" + base64.StdEncoding.EncodeToString(sig) + ":"
+	}
+	return strings.Join(entries, ", "), nil
+}
+
+// DecodeSignatureHeader returns the bytes of the signature labeled label
+// in a Signature header, whichever supported encoding it uses
+func DecodeSignatureHeader(header, label string) ([]byte, error) {
+	for _, entry := range strings.Split(header, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name != label {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		if sig, err := decodeByteSequence(value); err == nil {
+			return sig, nil
+		}
+		return decodeLenient(value, []SignatureEncoding{SignatureEncodingHex, SignatureEncodingBase64})
+	}
+	return nil, fmt.Errorf("signature %q not found in Signature header", label)
+}
+
+// decodeByteSequence decodes a structured field byte sequence (:base64:)
+func decodeByteSequence(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || value[0] != ':' || value[len(value)-1] != ':' {
+		return nil, fmt.Errorf("signature is not a byte sequence")
+	}
+	return base64.StdEncoding.DecodeString(value[1 : len(value)-1])
+}
+
+// decodeLenient decodes value with the first accepted encoding that fits
+func decodeLenient(value string, accepted []SignatureEncoding) ([]byte, error) {
+	if slices.Contains(accepted, SignatureEncodingHex) {
+		digits := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+		if sig, err := hex.DecodeString(digits); err == nil && len(sig) > 0 {
+			return sig, nil
+		}
+	}
+	if slices.Contains(accepted, SignatureEncodingBase64) {
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+			if sig, err := enc.DecodeString(value); err == nil && len(sig) > 0 {
+				return sig, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unrecognized signature encoding")
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package signer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureEncoding_RoundTrip(t *testing.T) {
+	header := "sig1=:3q2+7w==:, proxy=:AAEC:"
+
+	hexHeader, err := EncodeSignatureHeader(header, "sig1", SignatureEncodingHex)
+	require.NoError(t, err)
+	assert.Equal(t, "sig1=deadbeef, proxy=:AAEC:", hexHeader)
+
+	b64Header, err := EncodeSignatureHeader(header, "sig1", SignatureEncodingBase64)
+	require.NoError(t, err)
+	assert.Equal(t, "sig1=3q2+7w==, proxy=:AAEC:", b64Header)
+
+	_, err = EncodeSignatureHeader(header, "missing", SignatureEncodingHex)
+	assert.Error(t, err)
+
+	for _, tc := range []struct {
+		header   string
+		accepted []SignatureEncoding
+	}{
+		{hexHeader, []SignatureEncoding{SignatureEncodingHex}},
+		{"sig1=0xDEADBEEF, proxy=:AAEC:", []SignatureEncoding{SignatureEncodingHex}},
+		{b64Header, []SignatureEncoding{SignatureEncodingBase64}},
+		{`sig1="3q2-7w", proxy=:AAEC:`, []SignatureEncoding{SignatureEncodingBase64}},
+	} {
+		got, err := NormalizeSignatureHeader(tc.header, tc.accepted)
+		require.NoError(t, err, tc.header)
+		assert.Equal(t, header, got, tc.header)
+	}
+
+	// Encodings must be enabled explicitly
+	_, err = NormalizeSignatureHeader("sig1=not-hex!", []SignatureEncoding{SignatureEncodingHex})
+	assert.Error(t, err)
+	_, err = NormalizeSignatureHeader(hexHeader, nil)
+	assert.Error(t, err)
+}
+
+func TestDecodeSignatureHeader(t *testing.T) {
+	for _, header := range []string{
+		"sig1=:3q2+7w==:",
+		"proxy=:AAEC:, sig1=deadbeef",
+		"sig1=0xDEADBEEF",
+		`sig1="3q2-7w"`,
+	} {
+		sig, err := DecodeSignatureHeader(header, "sig1")
+		require.NoError(t, err, header)
+		assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, sig, header)
+	}
+
+	_, err := DecodeSignatureHeader("proxy=:AAEC:", "sig1")
+	assert.Error(t, err)
+	_, err = DecodeSignatureHeader("sig1=not-a-signature!", "sig1")
+	assert.Error(t, err)
+}
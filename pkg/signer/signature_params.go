@@ -0,0 +1,503 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Token is an RFC 8941 token parameter value, serialized without quotes
+type Token string
+
+// SignatureParam is a Signature-Input parameter. Value is a string, int64,
+// float64, bool, Token or []byte.
+type SignatureParam struct {
+	Name  string
+	Value any
+}
+
+// SignatureParams is the typed form of one Signature-Input member. The
+// RFC 9421 parameters have fields of their own; any others are kept in
+// Extra in header order.
+type SignatureParams struct {
+	Label string
+
+	// Components are the covered components, unquoted. Component
+	// parameters follow the name, e.g. `@query-param;name="id"`.
+	Components []string
+
+	Created   int64
+	Expires   int64
+	Nonce     string
+	Algorithm string
+	KeyID     string
+	Tag       string
+
+	Extra []SignatureParam
+}
+
+// knownParams are the parameters with fields in SignatureParams
+var knownParams = []string{"created", "expires", "nonce", "alg", "keyid", "tag"}
+
+// Param returns the value of the parameter name, known or extra
+func (p *SignatureParams) Param(name string) (any, bool) {
+	switch name {
+	case "created":
+		return p.Created, p.Created != 0
+	case "expires":
+		return p.Expires, p.Expires != 0
+	case "nonce":
+		return p.Nonce, p.Nonce != ""
+	case "alg":
+		return p.Algorithm, p.Algorithm != ""
+	case "keyid":
+		return p.KeyID, p.KeyID != ""
+	case "tag":
+		return p.Tag, p.Tag != ""
+	}
+	for _, e := range p.Extra {
+		if e.Name == name {
+			return e.Value, true
+		}
+	}
+	return nil, false
+}
+
+// String serializes the member value: the inner list of components
+// followed by created, expires, nonce, alg, keyid, tag and Extra. It is
+// the value of "@signature-params" in the signature base.
+func (p *SignatureParams) String() string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, c := range p.Components {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		name, params, _ := strings.Cut(c, ";")
+		b.WriteString(strconv.Quote(name))
+		if params != "" {
+			b.WriteByte(';')
+			b.WriteString(params)
+		}
+	}
+	b.WriteByte(')')
+	write := func(name string, value any) {
+		b.WriteByte(';')
+		b.WriteString(name)
+		if v, ok := value.(bool); ok && v {
+			return
+		}
+		b.WriteByte('=')
+		b.WriteString(serializeBareItem(value))
+	}
+	if p.Created != 0 {
+		write("created", p.Created)
+	}
+	if p.Expires != 0 {
+		write("expires", p.Expires)
+	}
+	for _, kv := range [][2]string{{"nonce", p.Nonce}, {"alg", p.Algorithm}, {"keyid", p.KeyID}, {"tag", p.Tag}} {
+		if kv[1] != "" {
+			write(kv[0], kv[1])
+		}
+	}
+	for _, e := range p.Extra {
+		write(e.Name, e.Value)
+	}
+	return b.String()
+}
+
+// Validate checks that p can be serialized: components and string values
+// are printable ASCII, parameter names are valid keys and extra parameters
+// do not repeat or shadow the known ones
+func (p *SignatureParams) Validate() error {
+	for _, c := range p.Components {
+		name, _, _ := strings.Cut(c, ";")
+		if name == "" || !printableASCII(name) {
+			return fmt.Errorf("invalid component %q", c)
+		}
+	}
+	for _, s := range []string{p.Nonce, p.Algorithm, p.KeyID, p.Tag} {
+		if !printableASCII(s) {
+			return fmt.Errorf("invalid parameter value %q", s)
+		}
+	}
+	seen := map[string]bool{}
+	for _, e := range p.Extra {
+		switch {
+		case !ValidSignatureLabel(e.Name):
+			return fmt.Errorf("invalid parameter name %q", e.Name)
+		case slices.Contains(knownParams, e.Name):
+			return fmt.Errorf("parameter %q has a field of its own", e.Name)
+		case seen[e.Name]:
+			return fmt.Errorf("duplicate parameter %q", e.Name)
+		}
+		seen[e.Name] = true
+		if err := validBareItem(e.Value); err != nil {
+			return fmt.Errorf("parameter %s: %w", e.Name, err)
+		}
+	}
+	return nil
+}
+
+// ParseSignatureParams parses every member of a Signature-Input header,
+// in header order
+func ParseSignatureParams(header string) ([]*SignatureParams, error) {
+	p := &sfParser{s: header}
+	var out []*SignatureParams
+	for {
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		member, err := p.member()
+		if err != nil {
+			return nil, fmt.Errorf("invalid Signature-Input: %w", err)
+		}
+		out = append(out, member)
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("invalid Signature-Input: expected ',' at offset %d", p.i)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("invalid Signature-Input: no signatures")
+	}
+	return out, nil
+}
+
+// LookupSignatureParams returns the parameters of the signature labeled
+// label in a Signature-Input header
+func LookupSignatureParams(header, label string) (*SignatureParams, error) {
+	members, err := ParseSignatureParams(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if m.Label == label {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("signature %q not found in Signature-Input", label)
+}
+
+// sfParser parses the RFC 8941 subset used by Signature-Input
+type sfParser struct {
+	s string
+	i int
+}
+
+func (p *sfParser) done() bool { return p.i >= len(p.s) }
+
+func (p *sfParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *sfParser) consume(c byte) bool {
+	if p.peek() == c && !p.done() {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *sfParser) skipSpace() {
+	for !p.done() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// member parses label=(components);params
+func (p *sfParser) member() (*SignatureParams, error) {
+	label, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+	m := &SignatureParams{Label: label}
+	if !p.consume('=') || !p.consume('(') {
+		return nil, fmt.Errorf("signature %q is not an inner list", label)
+	}
+	for {
+		for p.consume(' ') {
+		}
+		if p.consume(')') {
+			break
+		}
+		if p.done() {
+			return nil, fmt.Errorf("unterminated component list of %q", label)
+		}
+		name, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		start := p.i
+		if _, err := p.params(); err != nil {
+			return nil, err
+		}
+		// Component parameters are kept as serialized
+		m.Components = append(m.Components, name+p.s[start:p.i])
+		if c := p.peek(); c != ' ' && c != ')' {
+			return nil, fmt.Errorf("expected ' ' or ')' at offset %d", p.i)
+		}
+	}
+	params, err := p.params()
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range params {
+		if err := m.set(param); err != nil {
+			return nil, fmt.Errorf("signature %q: %w", label, err)
+		}
+	}
+	return m, nil
+}
+
+// set stores param in its field, or in Extra
+func (m *SignatureParams) set(param SignatureParam) error {
+	wrongType := fmt.Errorf("parameter %s has type %T", param.Name, param.Value)
+	switch param.Name {
+	case "created", "expires":
+		v, ok := param.Value.(int64)
+		if !ok {
+			return wrongType
+		}
+		if param.Name == "created" {
+			m.Created = v
+		} else {
+			m.Expires = v
+		}
+	case "nonce", "alg", "keyid", "tag":
+		v, ok := param.Value.(string)
+		if !ok {
+			return wrongType
+		}
+		switch param.Name {
+		case "nonce":
+			m.Nonce = v
+		case "alg":
+			m.Algorithm = v
+		case "keyid":
+			m.KeyID = v
+		default:
+			m.Tag = v
+		}
+	default:
+		m.Extra = append(m.Extra, param)
+	}
+	return nil
+}
+
+// params parses ;key[=value] parameters
+func (p *sfParser) params() ([]SignatureParam, error) {
+	var out []SignatureParam
+	for p.consume(';') {
+		for p.consume(' ') {
+		}
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		var value any = true
+		if p.consume('=') {
+			if value, err = p.bareItem(); err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", name, err)
+			}
+		}
+		out = append(out, SignatureParam{Name: name, Value: value})
+	}
+	return out, nil
+}
+
+// key parses a dictionary or parameter key
+func (p *sfParser) key() (string, error) {
+	start := p.i
+	for !p.done() {
+		c := p.s[p.i]
+		if c >= 'a' && c <= 'z' || c == '*' || p.i > start && (c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.') {
+			p.i++
+			continue
+		}
+		break
+	}
+	if p.i == start {
+		return "", fmt.Errorf("expected key at offset %d", p.i)
+	}
+	return p.s[start:p.i], nil
+}
+
+// bareItem parses a string, integer, decimal, boolean, token or byte sequence
+func (p *sfParser) bareItem() (any, error) {
+	switch c := p.peek(); {
+	case c == '"':
+		return p.str()
+	case c == '-' || c >= '0' && c <= '9':
+		return p.number()
+	case c == '?':
+		p.i++
+		switch {
+		case p.consume('1'):
+			return true, nil
+		case p.consume('0'):
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid boolean at offset %d", p.i)
+	case c == ':':
+		p.i++
+		end := strings.IndexByte(p.s[p.i:], ':')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated byte sequence")
+		}
+		b, err := base64.StdEncoding.DecodeString(p.s[p.i : p.i+end])
+		if err != nil {
+			return nil, fmt.Errorf("invalid byte sequence: %w", err)
+		}
+		p.i += end + 1
+		return b, nil
+	case c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '*':
+		start := p.i
+		for !p.done() && isTokenChar(p.s[p.i]) {
+			p.i++
+		}
+		return Token(p.s[start:p.i]), nil
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", p.peek(), p.i)
+}
+
+// str parses a quoted string
+func (p *sfParser) str() (string, error) {
+	if !p.consume('"') {
+		return "", fmt.Errorf("expected string at offset %d", p.i)
+	}
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.i]
+		p.i++
+		switch {
+		case c == '"':
+			return b.String(), nil
+		case c == '\\':
+			if p.done() || p.s[p.i] != '"' && p.s[p.i] != '\\' {
+				return "", fmt.Errorf("invalid escape at offset %d", p.i)
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		case c < 0x20 || c > 0x7e:
+			return "", fmt.Errorf("invalid character in string at offset %d", p.i-1)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// number parses an integer or decimal
+func (p *sfParser) number() (any, error) {
+	start := p.i
+	p.consume('-')
+	for !p.done() && (p.s[p.i] >= '0' && p.s[p.i] <= '9' || p.s[p.i] == '.') {
+		p.i++
+	}
+	text := p.s[start:p.i]
+	if strings.Contains(text, ".") {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decimal %q", text)
+		}
+		return f, nil
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || len(strings.TrimPrefix(text, "-")) > 15 {
+		return nil, fmt.Errorf("invalid integer %q", text)
+	}
+	return n, nil
+}
+
+// serializeBareItem serializes a parameter value
+func serializeBareItem(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		// Decimals always have a fractional part
+		d := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(d, ".") {
+			d += ".0"
+		}
+		return d
+	case bool:
+		if v {
+			return "?1"
+		}
+		return "?0"
+	case Token:
+		return string(v)
+	case []byte:
+		return ":" + base64.StdEncoding.EncodeToString(v) + ":"
+	}
+	return strconv.Quote(fmt.Sprint(v))
+}
+
+// validBareItem reports whether v is a value serializeBareItem supports
+func validBareItem(v any) error {
+	switch v := v.(type) {
+	case string:
+		if !printableASCII(v) {
+			return fmt.Errorf("string %q is not printable ASCII", v)
+		}
+	case int64, int, float64, bool, []byte:
+	case Token:
+		if v == "" || !(v[0] >= 'A' && v[0] <= 'Z' || v[0] >= 'a' && v[0] <= 'z' || v[0] == '*') || strings.IndexFunc(string(v), func(r rune) bool { return r > 0x7e || !isTokenChar(byte(r)) }) >= 0 {
+			return fmt.Errorf("invalid token %q", v)
+		}
+	default:
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+	return nil
+}
+
+func printableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// isTokenChar reports whether c may appear in a token after its first
+// character: tchar, ":" or "/"
+func isTokenChar(c byte) bool {
+	if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-.^_`|~:/", c) >= 0
+}
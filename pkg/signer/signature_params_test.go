@@ -0,0 +1,174 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paramsDID = did.AgentDID("did:sage:ethereum:0xparams")
+
+func TestParseSignatureParams(t *testing.T) {
+	sig1 := `("@method" "@path" "content-digest");created=1700000000;expires=1700000300;nonce="n-1";alg="ed25519";keyid="did:sage:ethereum:0xabc";tag="a2a";x-prio=5;trace=:AQI=:;weight=0.5;mode=fast;flag`
+	header := `sig1=` + sig1 + `, proxy=("@authority");keyid="did:web:proxy.example"`
+
+	members, err := ParseSignatureParams(header)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	p := members[0]
+	assert.Equal(t, "sig1", p.Label)
+	assert.Equal(t, []string{"@method", "@path", "content-digest"}, p.Components)
+	assert.Equal(t, int64(1700000000), p.Created)
+	assert.Equal(t, int64(1700000300), p.Expires)
+	assert.Equal(t, "n-1", p.Nonce)
+	assert.Equal(t, "ed25519", p.Algorithm)
+	assert.Equal(t, "did:sage:ethereum:0xabc", p.KeyID)
+	assert.Equal(t, "a2a", p.Tag)
+	assert.Equal(t, []SignatureParam{
+		{"x-prio", int64(5)},
+		{"trace", []byte{1, 2}},
+		{"weight", 0.5},
+		{"mode", Token("fast")},
+		{"flag", true},
+	}, p.Extra)
+	assert.Equal(t, sig1, p.String(), "serialization round-trips")
+
+	v, ok := p.Param("x-prio")
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), v)
+	v, ok = p.Param("tag")
+	assert.True(t, ok)
+	assert.Equal(t, "a2a", v)
+	_, ok = p.Param("missing")
+	assert.False(t, ok)
+
+	proxy, err := LookupSignatureParams(header, "proxy")
+	require.NoError(t, err)
+	assert.Equal(t, "did:web:proxy.example", proxy.KeyID)
+	_, err = LookupSignatureParams(header, "other")
+	assert.Error(t, err)
+
+	// Component parameters are kept
+	p, err = LookupSignatureParams(`sig1=("@query-param";name="id" "@method");created=1`, "sig1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{`@query-param;name="id"`, "@method"}, p.Components)
+	assert.Equal(t, `("@query-param";name="id" "@method");created=1`, p.String())
+
+	for _, bad := range []string{
+		"",
+		`sig1="@method"`,
+		`sig1=("@method"`,
+		`sig1=("@method");created="now"`,
+		`sig1=("@method");nonce="a\x01"`,
+		`Sig1=("@method")`,
+		`sig1=("@method") sig2=("@path")`,
+	} {
+		_, err := ParseSignatureParams(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSignatureParams_Validate(t *testing.T) {
+	valid := &SignatureParams{Components: []string{"@method"}, Extra: []SignatureParam{{"ext", "v"}, {"mode", Token("a:b/c")}}}
+	assert.NoError(t, valid.Validate())
+
+	for name, p := range map[string]*SignatureParams{
+		"known name":     {Extra: []SignatureParam{{"created", int64(1)}}},
+		"duplicate":      {Extra: []SignatureParam{{"ext", "a"}, {"ext", "b"}}},
+		"invalid name":   {Extra: []SignatureParam{{"Ext", "a"}}},
+		"invalid type":   {Extra: []SignatureParam{{"ext", []string{"a"}}}},
+		"invalid token":  {Extra: []SignatureParam{{"ext", Token("1a")}}},
+		"invalid string": {Tag: "a\nb"},
+	} {
+		assert.Error(t, p.Validate(), name)
+	}
+}
+
+func TestSignRequest_CustomParams(t *testing.T) {
+	ctx := context.Background()
+	signer := NewDefaultA2ASigner()
+	opts := func() *SigningOptions {
+		return &SigningOptions{
+			Components: []string{"@method", "@path"},
+			Tag:        "a2a-delegation",
+			Params:     []SignatureParam{{"hop", int64(2)}},
+		}
+	}
+
+	t.Run("Ed25519", func(t *testing.T) {
+		keyPair := createMockEd25519KeyPair()
+		req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+		require.NoError(t, signer.SignRequestWithOptions(ctx, req, paramsDID, keyPair, opts()))
+
+		params, err := LookupSignatureParams(req.Header.Get("Signature-Input"), DefaultSignatureLabel)
+		require.NoError(t, err)
+		assert.Equal(t, "a2a-delegation", params.Tag)
+		assert.Equal(t, []SignatureParam{{"hop", int64(2)}}, params.Extra)
+		assert.Equal(t, string(paramsDID), params.KeyID)
+
+		base, err := SignatureBase(req, params)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(keyPair.pubKey.(ed25519.PublicKey), []byte(base), signatureBytes(t, req.Header.Get("Signature"))))
+	})
+
+	t.Run("ECDSA", func(t *testing.T) {
+		keyPair := createMockECDSAKeyPair()
+		req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+		require.NoError(t, signer.SignRequestWithOptions(ctx, req, paramsDID, keyPair, opts()))
+
+		params, err := LookupSignatureParams(req.Header.Get("Signature-Input"), DefaultSignatureLabel)
+		require.NoError(t, err)
+		base, err := SignatureBase(req, params)
+		require.NoError(t, err)
+		sig := signatureBytes(t, req.Header.Get("Signature"))
+		require.Len(t, sig, 64, "r and s are concatenated")
+		digest := sha256.Sum256([]byte(base))
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		assert.True(t, ecdsa.Verify(keyPair.pubKey.(*ecdsa.PublicKey), digest[:], r, s))
+	})
+
+	t.Run("shadowed parameter", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+		err := signer.SignRequestWithOptions(ctx, req, paramsDID, createMockEd25519KeyPair(), &SigningOptions{
+			Params: []SignatureParam{{"keyid", "did:sage:ethereum:0xother"}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+// signatureBytes decodes the single signature of a Signature header
+func signatureBytes(t *testing.T, header string) []byte {
+	_, value, ok := strings.Cut(header, "=")
+	require.True(t, ok)
+	sig, err := base64.StdEncoding.DecodeString(strings.Trim(value, ":"))
+	require.NoError(t, err)
+	return sig
+}
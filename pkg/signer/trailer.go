@@ -0,0 +1,181 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package signer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// TrailerDigestHeader announces that the Content-Digest of a streamed body
+// follows in a trailer, together with a second signature covering it. Its
+// value is the digest algorithm. The header signature covers it in place of
+// content-digest, so it cannot be stripped to skip the body check.
+const TrailerDigestHeader = "A2A-Trailer-Digest"
+
+// TrailerNonceSuffix is appended to the header signature's nonce to form
+// the trailer signature's nonce, binding the two signatures together
+const TrailerNonceSuffix = ".trailer"
+
+// trailerDigestComponent is the covered component naming TrailerDigestHeader
+const trailerDigestComponent = "a2a-trailer-digest"
+
+// SignRequestStreaming signs req without reading its body, for uploads too
+// large to buffer or sent with "Expect: 100-continue". The headers are
+// signed immediately with TrailerDigestHeader covered instead of
+// content-digest. The body is sent chunked and hashed as it is read; once
+// it is exhausted the Content-Digest, Signature and Signature-Input
+// trailers are set, the trailer signature covering the same components plus
+// content-digest. A random nonce is generated if opts has none.
+//
+// Requests without a body are signed with SignRequestWithOptions.
+func (s *DefaultA2ASigner) SignRequestStreaming(
+	ctx context.Context,
+	req *http.Request,
+	agentDID did.AgentDID,
+	keyPair sagecrypto.KeyPair,
+	opts *SigningOptions,
+) error {
+	if req != nil && (req.Body == nil || req.Body == http.NoBody) {
+		return s.SignRequestWithOptions(ctx, req, agentDID, keyPair, opts)
+	}
+	if err := checkSignArgs(ctx, req, agentDID, keyPair); err != nil {
+		return err
+	}
+
+	// Work on a copy; the caller's options are left untouched
+	o := *defaultOptions(opts)
+	o.Components = slices.DeleteFunc(slices.Clone(o.Components), func(c string) bool {
+		return normalizeComponent(c) == "content-digest"
+	})
+	if !includes(o.Components, trailerDigestComponent) {
+		o.Components = append(o.Components, trailerDigestComponent)
+	}
+	if o.DigestAlgorithm == "" {
+		o.DigestAlgorithm = DigestSHA256
+	}
+	if o.Created == 0 {
+		o.Created = time.Now().Unix()
+	}
+	if o.Nonce == "" {
+		nonce, err := randomNonce()
+		if err != nil {
+			return err
+		}
+		o.Nonce = nonce
+	}
+	if s.strict {
+		if err := ValidateOptions(req.Method, &o); err != nil {
+			return err
+		}
+	}
+
+	digester, err := NewContentDigester(o.DigestAlgorithm)
+	if err != nil {
+		return fmt.Errorf("compute content-digest: %w", err)
+	}
+	req.Header.Del("Content-Digest")
+	req.Header.Set(TrailerDigestHeader, digester.Algorithm())
+	if err := s.sign(ctx, req, agentDID, keyPair, &o); err != nil {
+		return err
+	}
+
+	// The trailer signature covers the header components plus the digest
+	trailerOpts := o
+	trailerOpts.Components = append(slices.Clone(o.Components), "content-digest")
+	trailerOpts.Nonce = o.Nonce + TrailerNonceSuffix
+	header := req.Header.Clone()
+
+	if req.Trailer == nil {
+		req.Trailer = make(http.Header)
+	}
+	trailer := req.Trailer
+	for _, name := range []string{"Content-Digest", "Signature-Input", "Signature"} {
+		trailer[name] = nil
+	}
+
+	finish := func(d *ContentDigester) error {
+		signed := &http.Request{Method: req.Method, URL: req.URL, Host: req.Host, Header: header.Clone()}
+		signed.Header.Set("Content-Digest", d.Value())
+		if err := s.sign(ctx, signed, agentDID, keyPair, &trailerOpts); err != nil {
+			return fmt.Errorf("failed to sign trailers: %w", err)
+		}
+		for _, name := range []string{"Content-Digest", "Signature-Input", "Signature"} {
+			trailer.Set(name, signed.Header.Get(name))
+		}
+		return nil
+	}
+
+	req.Body = &trailerSigningBody{ReadCloser: req.Body, digester: digester, finish: finish}
+	if getBody := req.GetBody; getBody != nil {
+		alg := digester.Algorithm()
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			d, err := NewContentDigester(alg)
+			if err != nil {
+				return nil, err
+			}
+			return &trailerSigningBody{ReadCloser: body, digester: d, finish: finish}, nil
+		}
+	}
+	// Trailers require a chunked body
+	req.ContentLength = -1
+	return nil
+}
+
+// trailerSigningBody hashes a request body as it is sent and sets the
+// trailers once it is exhausted
+type trailerSigningBody struct {
+	io.ReadCloser
+	digester *ContentDigester
+	finish   func(*ContentDigester) error
+	done     bool
+}
+
+// Read implements io.Reader
+func (b *trailerSigningBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.digester.Write(p[:n])
+	if err == io.EOF && !b.done {
+		b.done = true
+		if ferr := b.finish(b.digester); ferr != nil {
+			return n, ferr
+		}
+	}
+	return n, err
+}
+
+// randomNonce returns a random signature nonce
+func randomNonce() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package signer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignRequestStreaming_DefersDigestToTrailer(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"method":"message/send"}`)
+	req := httptest.NewRequest("POST", "https://agent.example.com/rpc", bytes.NewReader(body))
+	opts := &SigningOptions{Components: []string{"@method", "@path"}}
+
+	require.NoError(t, NewDefaultA2ASigner().SignRequestStreaming(ctx, req, did.AgentDID("did:sage:ethereum:0xtest"), createMockECDSAKeyPair(), opts))
+	assert.Equal(t, []string{"@method", "@path"}, opts.Components, "caller options are not modified")
+
+	sigInput := req.Header.Get("Signature-Input")
+	assert.Contains(t, sigInput, `"a2a-trailer-digest"`)
+	assert.NotContains(t, sigInput, `"content-digest"`)
+	assert.Contains(t, sigInput, "nonce=")
+	assert.Equal(t, DigestSHA256, req.Header.Get(TrailerDigestHeader))
+	assert.Empty(t, req.Header.Get("Content-Digest"))
+	assert.Equal(t, int64(-1), req.ContentLength)
+	assert.Contains(t, req.Trailer, "Content-Digest")
+	assert.Empty(t, req.Trailer.Get("Content-Digest"), "trailers are set once the body is read")
+
+	got, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, got)
+
+	want, err := ComputeContentDigest(DigestSHA256, body)
+	require.NoError(t, err)
+	assert.Equal(t, want, req.Trailer.Get("Content-Digest"))
+	trailerInput := req.Trailer.Get("Signature-Input")
+	assert.Contains(t, trailerInput, `"content-digest"`)
+	assert.Contains(t, trailerInput, TrailerNonceSuffix+`"`)
+	assert.NotEmpty(t, req.Trailer.Get("Signature"))
+}
+
+func TestSignRequestStreaming_SendsTrailers(t *testing.T) {
+	var trailer http.Header
+	var received []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		trailer = r.Trailer.Clone()
+	}))
+	defer srv.Close()
+
+	body := strings.Repeat("x", 64<<10)
+	req, err := http.NewRequest("POST", srv.URL+"/rpc", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Expect", "100-continue")
+	opts := &SigningOptions{DigestAlgorithm: DigestSHA512}
+	require.NoError(t, NewDefaultA2ASigner().SignRequestStreaming(context.Background(), req, did.AgentDID("did:sage:ethereum:0xtest"), createMockEd25519KeyPair(), opts))
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, body, string(received))
+	require.NoError(t, VerifyContentDigest(trailer.Get("Content-Digest"), received, []string{DigestSHA512}))
+	assert.NotEmpty(t, trailer.Get("Signature"))
+}
+
+func TestSignRequestStreaming_EmptyBody(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://agent.example.com/tasks", nil)
+	require.NoError(t, NewDefaultA2ASigner().SignRequestStreaming(context.Background(), req, did.AgentDID("did:sage:ethereum:0xtest"), createMockECDSAKeyPair(), nil))
+
+	assert.NotEmpty(t, req.Header.Get("Content-Digest"))
+	assert.Empty(t, req.Header.Get(TrailerDigestHeader))
+}
+
+func TestCheckComponentPolicy_TrailerDigest(t *testing.T) {
+	components := []string{"@method", "@path", "a2a-trailer-digest"}
+	assert.NoError(t, CheckComponentPolicy("POST", components, 1, 0))
+}
+
+func TestContentDigester(t *testing.T) {
+	d, err := NewContentDigester(DigestSHA256)
+	require.NoError(t, err)
+	d.Write([]byte("hello "))
+	d.Write([]byte("world"))
+
+	want, err := ComputeContentDigest(DigestSHA256, []byte("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, want, d.Value())
+	assert.NoError(t, d.Verify(want))
+
+	other, err := ComputeContentDigest(DigestSHA256, []byte("hello"))
+	require.NoError(t, err)
+	assert.Error(t, d.Verify(other))
+
+	_, err = NewContentDigester("md5")
+	assert.Error(t, err)
+}
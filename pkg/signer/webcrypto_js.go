@@ -0,0 +1,222 @@
+//go:build js && wasm
+
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"syscall/js"
+
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+)
+
+// WebCrypto algorithms supported by GenerateWebCryptoKeyPair
+const (
+	WebCryptoEd25519 = "Ed25519"
+	WebCryptoP256    = "P-256"
+)
+
+// KeyTypeP256 is the key type of P-256 key pairs generated with WebCrypto.
+// They sign with the RFC 9421 algorithm "ecdsa-p256-sha256".
+const KeyTypeP256 sagecrypto.KeyType = "p256"
+
+// GenerateWebCryptoKeyPair generates a key pair with the browser's
+// WebCrypto API for use with DefaultA2ASigner in js/wasm builds. Keys live
+// in memory only and are never written to storage.
+//
+// Ed25519 private keys are non-extractable: signing happens in WebCrypto.
+// P-256 keys are generated by WebCrypto but signed with in Go, because
+// RFC 9421 signers hand ECDSA keys a precomputed digest and WebCrypto
+// ECDSA only signs whole messages.
+//
+// Signing blocks on WebCrypto promises, so it must not run on the
+// JavaScript event loop goroutine; call it from a new goroutine, as
+// js.FuncOf callbacks returning a Promise do.
+func GenerateWebCryptoKeyPair(algorithm string) (sagecrypto.KeyPair, error) {
+	subtle := js.Global().Get("crypto").Get("subtle")
+	if subtle.IsUndefined() {
+		return nil, errors.New("WebCrypto is not available")
+	}
+	switch algorithm {
+	case WebCryptoEd25519:
+		return generateWebCryptoEd25519(subtle)
+	case WebCryptoP256:
+		return generateWebCryptoP256(subtle)
+	default:
+		return nil, fmt.Errorf("unsupported WebCrypto algorithm: %q", algorithm)
+	}
+}
+
+func generateWebCryptoEd25519(subtle js.Value) (sagecrypto.KeyPair, error) {
+	keys, err := await(subtle.Call("generateKey",
+		map[string]any{"name": "Ed25519"}, false, []any{"sign", "verify"}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+	}
+	raw, err := await(subtle.Call("exportKey", "raw", keys.Get("publicKey")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export Ed25519 public key: %w", err)
+	}
+	public := ed25519.PublicKey(goBytes(raw))
+	return newWebCryptoKeyPair(sagecrypto.KeyTypeEd25519, &webCryptoEd25519Signer{
+		subtle: subtle,
+		key:    keys.Get("privateKey"),
+		public: public,
+	})
+}
+
+func generateWebCryptoP256(subtle js.Value) (sagecrypto.KeyPair, error) {
+	keys, err := await(subtle.Call("generateKey",
+		map[string]any{"name": "ECDSA", "namedCurve": "P-256"}, true, []any{"sign", "verify"}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate P-256 key: %w", err)
+	}
+	pkcs8, err := await(subtle.Call("exportKey", "pkcs8", keys.Get("privateKey")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export P-256 private key: %w", err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(goBytes(pkcs8))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse P-256 private key: %w", err)
+	}
+	private, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected P-256 private key type: %T", key)
+	}
+	return newWebCryptoKeyPair(KeyTypeP256, private)
+}
+
+// webCryptoKeyPair is a sagecrypto.KeyPair over an in-memory crypto.Signer
+type webCryptoKeyPair struct {
+	id      string
+	keyType sagecrypto.KeyType
+	private crypto.Signer
+}
+
+func newWebCryptoKeyPair(keyType sagecrypto.KeyType, private crypto.Signer) (*webCryptoKeyPair, error) {
+	der, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return &webCryptoKeyPair{
+		id:      hex.EncodeToString(sum[:8]),
+		keyType: keyType,
+		private: private,
+	}, nil
+}
+
+func (k *webCryptoKeyPair) ID() string                    { return k.id }
+func (k *webCryptoKeyPair) PublicKey() crypto.PublicKey   { return k.private.Public() }
+func (k *webCryptoKeyPair) PrivateKey() crypto.PrivateKey { return k.private }
+func (k *webCryptoKeyPair) Type() sagecrypto.KeyType      { return k.keyType }
+
+// Sign signs message, hashing it with SHA-256 first for P-256 keys. P-256
+// signatures are ASN.1 encoded.
+func (k *webCryptoKeyPair) Sign(message []byte) ([]byte, error) {
+	if k.keyType == sagecrypto.KeyTypeEd25519 {
+		return k.private.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(message)
+	return k.private.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// Verify checks a signature made by Sign
+func (k *webCryptoKeyPair) Verify(message, signature []byte) error {
+	valid := false
+	switch pub := k.private.Public().(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(pub, message, signature)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		valid = ecdsa.VerifyASN1(pub, digest[:], signature)
+	}
+	if !valid {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+// webCryptoEd25519Signer is a crypto.Signer over a non-extractable
+// WebCrypto Ed25519 private key
+type webCryptoEd25519Signer struct {
+	subtle js.Value
+	key    js.Value
+	public ed25519.PublicKey
+}
+
+func (s *webCryptoEd25519Signer) Public() crypto.PublicKey { return s.public }
+
+func (s *webCryptoEd25519Signer) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != 0 {
+		return nil, errors.New("WebCrypto Ed25519 keys sign messages, not digests")
+	}
+	sig, err := await(s.subtle.Call("sign", "Ed25519", s.key, jsBytes(message)))
+	if err != nil {
+		return nil, fmt.Errorf("WebCrypto signing failed: %w", err)
+	}
+	return goBytes(sig), nil
+}
+
+// await blocks until promise settles, returning its value or rejection
+func await(promise js.Value) (js.Value, error) {
+	type result struct {
+		value js.Value
+		err   error
+	}
+	done := make(chan result, 1)
+	onResolve := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		done <- result{value: args[0]}
+		return nil
+	})
+	defer onResolve.Release()
+	onReject := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		done <- result{err: errors.New(args[0].Call("toString").String())}
+		return nil
+	})
+	defer onReject.Release()
+
+	promise.Call("then", onResolve, onReject)
+	r := <-done
+	return r.value, r.err
+}
+
+// jsBytes copies b into a new Uint8Array
+func jsBytes(b []byte) js.Value {
+	array := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(array, b)
+	return array
+}
+
+// goBytes copies the contents of an ArrayBuffer
+func goBytes(buffer js.Value) []byte {
+	array := js.Global().Get("Uint8Array").New(buffer)
+	b := make([]byte, array.Get("length").Int())
+	js.CopyBytesToGo(b, array)
+	return b
+}
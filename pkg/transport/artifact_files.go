@@ -0,0 +1,294 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+)
+
+// DefaultArtifactChunkSize is the upload chunk size used unless
+// WithArtifactChunkSize is given
+const DefaultArtifactChunkSize = 4 << 20
+
+// Size counter names of artifact file transfers (see SizeStats)
+const (
+	artifactUploadMethod   = "artifact/upload"
+	artifactDownloadMethod = "artifact/download"
+)
+
+// ErrArtifactDigestMismatch is returned when a transferred artifact file
+// does not match the Repr-Digest of the stored file
+var ErrArtifactDigestMismatch = errors.New("artifact digest mismatch")
+
+// WithArtifactChunkSize sets the size of the chunks UploadArtifact sends.
+// Each chunk is buffered and signed as a separate request.
+func WithArtifactChunkSize(n int) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.artifactChunkSize = n
+	}
+}
+
+// UploadArtifact uploads content as the artifact file id and returns its
+// Repr-Digest. If a previous upload of the same content was interrupted,
+// only the missing chunks are sent; a complete file with the same digest
+// is not sent again.
+func (t *DIDHTTPTransport) UploadArtifact(ctx context.Context, id string, content io.ReadSeeker) (string, error) {
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", fmt.Errorf("failed to read artifact content: %w", err)
+	}
+	digester, _ := signer.NewContentDigester(signer.DigestSHA256)
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read artifact content: %w", err)
+	}
+	if _, err := io.Copy(digester, content); err != nil {
+		return "", fmt.Errorf("failed to read artifact content: %w", err)
+	}
+	digest := digester.Value()
+
+	offset, done, err := t.artifactUploadOffset(ctx, id, digester, size)
+	if err != nil || done {
+		return digest, err
+	}
+
+	chunkSize := int64(t.artifactChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = DefaultArtifactChunkSize
+	}
+	buf := make([]byte, min(chunkSize, size))
+	for {
+		n := min(chunkSize, size-offset)
+		if _, err := content.Seek(offset, io.SeekStart); err != nil {
+			return "", fmt.Errorf("failed to read artifact content: %w", err)
+		}
+		if _, err := io.ReadFull(content, buf[:n]); err != nil {
+			return "", fmt.Errorf("failed to read artifact content: %w", err)
+		}
+
+		header := make(http.Header)
+		if offset > 0 || n < size {
+			header.Set("Content-Range", protocol.ContentRange{First: offset, Last: offset + n - 1, Total: size}.String())
+		}
+		if offset+n == size {
+			header.Set(protocol.ReprDigestHeader, digest)
+		}
+		resp, err := t.doArtifactRequest(ctx, http.MethodPut, id, buf[:n], header)
+		if err != nil {
+			return "", err
+		}
+		body, _ := readLimited(resp.Body, maxErrorBodySize)
+		resp.Body.Close()
+
+		stored, _ := strconv.ParseInt(resp.Header.Get(protocol.UploadOffsetHeader), 10, 64)
+		switch resp.StatusCode {
+		case http.StatusCreated:
+			if err := digester.Verify(resp.Header.Get(protocol.ReprDigestHeader)); err != nil {
+				return "", fmt.Errorf("%w: %v", ErrArtifactDigestMismatch, err)
+			}
+			return digest, nil
+		case http.StatusNoContent:
+			offset += n
+		case http.StatusConflict:
+			// Another attempt got further; resume from the stored size
+			if stored == offset || stored > size {
+				return "", newHTTPError(resp, body)
+			}
+			offset = stored
+		default:
+			return "", newHTTPError(resp, body)
+		}
+	}
+}
+
+// artifactUploadOffset asks the server how much of file id it has. done is
+// set if the complete file is already stored.
+func (t *DIDHTTPTransport) artifactUploadOffset(ctx context.Context, id string, digester *signer.ContentDigester, size int64) (offset int64, done bool, err error) {
+	resp, err := t.doArtifactRequest(ctx, http.MethodHead, id, nil, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return 0, false, nil
+	default:
+		return 0, false, newHTTPError(resp, nil)
+	}
+	if resp.Header.Get(protocol.UploadCompleteHeader) == "?1" {
+		// A different complete file is replaced
+		return 0, digester.Verify(resp.Header.Get(protocol.ReprDigestHeader)) == nil, nil
+	}
+	stored, err := strconv.ParseInt(resp.Header.Get(protocol.UploadOffsetHeader), 10, 64)
+	if err != nil || stored < 0 || stored > size {
+		return 0, false, nil
+	}
+	return stored, false, nil
+}
+
+// DownloadArtifact downloads the artifact file id into dst and returns its
+// size. Content already in dst is treated as the start of an interrupted
+// download and only the remainder is requested. The complete file is
+// checked against the server's Repr-Digest; on ErrArtifactDigestMismatch
+// dst should be discarded rather than resumed.
+func (t *DIDHTTPTransport) DownloadArtifact(ctx context.Context, id string, dst io.ReadWriteSeeker) (int64, error) {
+	offset, err := dst.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to seek destination: %w", err)
+	}
+	var resp *http.Response
+	if offset == 0 && t.responseCache != nil {
+		key := t.cacheKey(t.artifactURL(id))
+		resp, _, err = t.responseCache.do(key, false, func(header http.Header) (*http.Response, error) {
+			resp, err := t.doArtifactRequest(ctx, http.MethodGet, id, nil, header)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = t.countResponse(artifactDownloadMethod, resp.Body)
+			return resp, nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		header := make(http.Header)
+		if offset > 0 {
+			header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+		resp, err = t.doArtifactRequest(ctx, http.MethodGet, id, nil, header)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body = t.countResponse(artifactDownloadMethod, resp.Body)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// The server sent the whole file
+		if offset, err = dst.Seek(0, io.SeekStart); err != nil {
+			return 0, fmt.Errorf("failed to seek destination: %w", err)
+		}
+	case http.StatusPartialContent:
+		cr, err := protocol.ParseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil || cr.First != offset {
+			return 0, fmt.Errorf("unexpected Content-Range %q for offset %d", resp.Header.Get("Content-Range"), offset)
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// dst may already hold the complete file
+		cr, err := protocol.ParseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil || cr.Total != offset {
+			body, _ := readLimited(resp.Body, maxErrorBodySize)
+			return 0, newHTTPError(resp, body)
+		}
+	default:
+		body, _ := readLimited(resp.Body, maxErrorBodySize)
+		return 0, newHTTPError(resp, body)
+	}
+
+	want := resp.Header.Get(protocol.ReprDigestHeader)
+	if want == "" {
+		return 0, fmt.Errorf("%w: response has no %s", ErrArtifactDigestMismatch, protocol.ReprDigestHeader)
+	}
+	var copied int64
+	if resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		if copied, err = io.Copy(dst, resp.Body); err != nil {
+			return 0, fmt.Errorf("failed to download artifact: %w", err)
+		}
+	}
+	size := offset + copied
+	if f, ok := dst.(interface{ Truncate(int64) error }); ok {
+		if err := f.Truncate(size); err != nil {
+			return 0, fmt.Errorf("failed to truncate destination: %w", err)
+		}
+	}
+
+	// Check the complete file, including any previously downloaded part
+	if _, err := dst.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek destination: %w", err)
+	}
+	digester, _ := signer.NewContentDigester(signer.DigestSHA256)
+	if _, err := io.CopyN(digester, dst, size); err != nil {
+		return 0, fmt.Errorf("failed to read destination: %w", err)
+	}
+	if err := digester.Verify(want); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrArtifactDigestMismatch, err)
+	}
+	return size, nil
+}
+
+// artifactURL returns the URL of artifact file id
+func (t *DIDHTTPTransport) artifactURL(id string) string {
+	return strings.TrimSuffix(t.baseURL, "/") + protocol.ArtifactFilesPath + url.PathEscape(id)
+}
+
+// doArtifactRequest sends a signed request for artifact file id. Headers in
+// header, and the request hints, are covered by the signature.
+func (t *DIDHTTPTransport) doArtifactRequest(ctx context.Context, method, id string, body []byte, header http.Header) (*http.Response, error) {
+	if t.rateLimiter != nil {
+		if err := t.rateLimiter.Wait(ctx, t.rateLimitKey()); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.artifactURL(id), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if method == http.MethodPut {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	components := []string{"@method", "@path", "@query", "content-digest"}
+	for name, values := range header {
+		req.Header[name] = values
+		components = append(components, strings.ToLower(name))
+	}
+	components = append(components, setRequestHints(ctx, req)...)
+	opts := &signer.SigningOptions{
+		Components:      components,
+		DigestAlgorithm: t.DigestAlgorithm(),
+	}
+	if err := t.signer.SignRequestWithOptions(ctx, req, t.agentDID, t.keyPair, opts); err != nil {
+		return nil, fmt.Errorf("failed to sign request with DID: %w", err)
+	}
+
+	counter := artifactDownloadMethod
+	if method == http.MethodPut {
+		counter = artifactUploadMethod
+	}
+	t.recordRequest(counter, req.ContentLength)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	t.observeDigestPreference(resp)
+	return resp, nil
+}
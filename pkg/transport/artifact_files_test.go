@@ -0,0 +1,154 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// artifactFixture serves artifact files behind a verifying middleware and
+// returns a transport registered with it
+func artifactFixture(t *testing.T, puts *atomic.Int32) (*DIDHTTPTransport, *server.MemoryArtifactFileStore, did.AgentDID) {
+	t.Helper()
+	agentDID := did.AgentDID("did:sage:ethereum:0xuploader")
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	reg := registry.NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(context.Background(), agentDID, keyPair.PublicKey()))
+
+	store := server.NewMemoryArtifactFileStore()
+	handler := server.NewDIDAuthMiddlewareWithVerifier(reg.NewDIDVerifier()).Wrap(
+		server.NewArtifactFileHandler(server.ArtifactFileConfig{Store: store}))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts.Add(1)
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := NewDIDHTTPTransport(srv.URL, agentDID, keyPair, nil, WithArtifactChunkSize(4)).(*DIDHTTPTransport)
+	return tr, store, agentDID
+}
+
+func TestUploadArtifact_Resumes(t *testing.T) {
+	ctx := context.Background()
+	var puts atomic.Int32
+	tr, store, agentDID := artifactFixture(t, &puts)
+	content := "the quick brown fox"
+
+	// An earlier attempt stored the first two chunks
+	_, err := store.Append(ctx, "fox", agentDID, 0, int64(len(content)), []byte(content[:8]))
+	require.NoError(t, err)
+
+	digest, err := tr.UploadArtifact(ctx, "fox", strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), puts.Load(), "only the remaining chunks are sent")
+
+	info, err := store.Stat(ctx, "fox")
+	require.NoError(t, err)
+	assert.True(t, info.Complete)
+	assert.Equal(t, digest, info.Digest)
+
+	// Uploading the same content again is a no-op
+	_, err = tr.UploadArtifact(ctx, "fox", strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), puts.Load())
+}
+
+func TestDownloadArtifact_Resumes(t *testing.T) {
+	ctx := context.Background()
+	var puts atomic.Int32
+	tr, _, _ := artifactFixture(t, &puts)
+	content := "the quick brown fox"
+	_, err := tr.UploadArtifact(ctx, "fox", strings.NewReader(content))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "fox")
+	require.NoError(t, os.WriteFile(path, []byte(content[:10]), 0o600))
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	require.NoError(t, err)
+	defer f.Close()
+
+	n, err := tr.DownloadArtifact(ctx, "fox", f)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	// A corrupt partial download fails the digest check
+	require.NoError(t, os.WriteFile(path, []byte("THE QUICK"), 0o600))
+	_, err = f.Seek(0, 0)
+	require.NoError(t, err)
+	_, err = tr.DownloadArtifact(ctx, "fox", f)
+	assert.ErrorIs(t, err, ErrArtifactDigestMismatch)
+}
+
+func TestDownloadArtifact_NotFound(t *testing.T) {
+	var puts atomic.Int32
+	tr, _, _ := artifactFixture(t, &puts)
+	_, err := tr.DownloadArtifact(context.Background(), "missing", &seekBuffer{})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
+
+// seekBuffer is an in-memory io.ReadWriteSeeker
+type seekBuffer struct {
+	data []byte
+	pos  int
+}
+
+func (b *seekBuffer) Read(p []byte) (int, error) {
+	r := bytes.NewReader(b.data[b.pos:])
+	n, err := r.Read(p)
+	b.pos += n
+	return n, err
+}
+
+func (b *seekBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data[:b.pos], p...)
+	b.pos += len(p)
+	return len(p), nil
+}
+
+func (b *seekBuffer) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case 1:
+		offset += int64(b.pos)
+	case 2:
+		offset += int64(len(b.data))
+	}
+	b.pos = int(offset)
+	return offset, nil
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// ErrArtifactIncomplete is returned by an ArtifactReader when the stream
+// ends before the artifact's last chunk, or joins it after its first
+var ErrArtifactIncomplete = errors.New("artifact stream incomplete")
+
+// ArtifactReader reassembles an artifact streamed as a sequence of
+// TaskArtifactUpdateEvent chunks into an io.Reader of its content. Text
+// parts are read as UTF-8 and file parts as their decoded bytes. Events
+// are pulled from the stream only as content is read, so large artifacts
+// are never held in memory.
+type ArtifactReader struct {
+	id      a2a.ArtifactID
+	onEvent func(a2a.Event) error
+	next    func() (a2a.Event, error, bool)
+	stop    func()
+
+	artifact *a2a.Artifact
+	buf      []byte
+	done     bool
+	err      error
+}
+
+// NewArtifactReader reads the artifact id from events, or the first
+// artifact streamed if id is empty. Other events, including chunks of other
+// artifacts, are passed to onEvent if set; an error from it ends reading.
+// Close the reader to release the stream early.
+func NewArtifactReader(events iter.Seq2[a2a.Event, error], id a2a.ArtifactID, onEvent func(a2a.Event) error) *ArtifactReader {
+	next, stop := iter.Pull2(events)
+	return &ArtifactReader{id: id, onEvent: onEvent, next: next, stop: stop}
+}
+
+// StreamArtifact sends message and returns a reader of the artifact id the
+// agent streams in response, as NewArtifactReader does
+func (t *DIDHTTPTransport) StreamArtifact(ctx context.Context, message *a2a.MessageSendParams, id a2a.ArtifactID, onEvent func(a2a.Event) error) *ArtifactReader {
+	return NewArtifactReader(t.SendStreamingMessage(ctx, message), id, onEvent)
+}
+
+// Artifact returns the artifact as described by its first chunk (name,
+// description, metadata and the parts of that chunk), or nil before the
+// first chunk is read
+func (r *ArtifactReader) Artifact() *a2a.Artifact {
+	return r.artifact
+}
+
+// Read implements io.Reader. It returns io.EOF after the last chunk.
+func (r *ArtifactReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.advance()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close releases the stream
+func (r *ArtifactReader) Close() error {
+	r.stop()
+	r.buf = nil
+	if r.err == nil {
+		r.err = io.ErrClosedPipe
+	}
+	return nil
+}
+
+// advance reads events up to the next chunk of the artifact
+func (r *ArtifactReader) advance() error {
+	if r.done {
+		return io.EOF
+	}
+	for {
+		event, err, ok := r.next()
+		if !ok {
+			if r.artifact == nil {
+				return fmt.Errorf("%w: no artifact streamed", ErrArtifactIncomplete)
+			}
+			return fmt.Errorf("%w: stream ended before the last chunk of artifact %s", ErrArtifactIncomplete, r.id)
+		}
+		if err != nil {
+			r.stop()
+			return err
+		}
+
+		ev, isArtifact := event.(*a2a.TaskArtifactUpdateEvent)
+		if !isArtifact || ev.Artifact == nil || (r.id != "" && ev.Artifact.ID != r.id) {
+			if r.onEvent != nil {
+				if err := r.onEvent(event); err != nil {
+					r.stop()
+					return err
+				}
+			}
+			continue
+		}
+
+		switch {
+		case r.artifact == nil && ev.Append:
+			r.stop()
+			return fmt.Errorf("%w: stream joined artifact %s after its first chunk", ErrArtifactIncomplete, ev.Artifact.ID)
+		case r.artifact == nil:
+			r.id, r.artifact = ev.Artifact.ID, ev.Artifact
+		case !ev.Append:
+			r.stop()
+			return fmt.Errorf("artifact %s was restarted mid-stream", r.id)
+		}
+		content, err := artifactContent(ev.Artifact.Parts)
+		if err != nil {
+			r.stop()
+			return fmt.Errorf("artifact %s: %w", r.id, err)
+		}
+		r.buf = content
+		if ev.LastChunk {
+			r.done = true
+			r.stop()
+		}
+		return nil
+	}
+}
+
+// artifactContent returns the content of the parts of a chunk
+func artifactContent(parts a2a.ContentParts) ([]byte, error) {
+	var content []byte
+	for _, part := range parts {
+		switch p := part.(type) {
+		case a2a.TextPart:
+			content = append(content, p.Text...)
+		case *a2a.TextPart:
+			content = append(content, p.Text...)
+		case a2a.FilePart:
+			data, err := fileContent(p.File)
+			if err != nil {
+				return nil, err
+			}
+			content = append(content, data...)
+		case *a2a.FilePart:
+			data, err := fileContent(p.File)
+			if err != nil {
+				return nil, err
+			}
+			content = append(content, data...)
+		default:
+			return nil, fmt.Errorf("cannot stream %T parts", part)
+		}
+	}
+	return content, nil
+}
+
+// fileContent decodes inline file bytes
+func fileContent(file a2a.FilePartContent) ([]byte, error) {
+	var encoded string
+	switch f := file.(type) {
+	case a2a.FileBytes:
+		encoded = f.Bytes
+	case *a2a.FileBytes:
+		encoded = f.Bytes
+	default:
+		return nil, fmt.Errorf("file parts by URI cannot be streamed")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file bytes: %w", err)
+	}
+	return data, nil
+}
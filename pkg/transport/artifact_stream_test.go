@@ -0,0 +1,134 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventList is an eventqueue.Writer whose events are replayed as a stream
+type eventList struct {
+	events []a2a.Event
+}
+
+func (l *eventList) Write(ctx context.Context, event a2a.Event) error {
+	// Round trip through JSON as events do on the wire
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var decoded a2a.TaskArtifactUpdateEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	l.events = append(l.events, &decoded)
+	return nil
+}
+
+// eventSeq streams events
+func eventSeq(events ...a2a.Event) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for _, event := range events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestArtifactReader(t *testing.T) {
+	ctx := context.Background()
+	reqCtx := &a2asrv.RequestContext{TaskID: "task-1", ContextID: "ctx-1"}
+	content := make([]byte, 10_000)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+
+	list := &eventList{}
+	w := server.NewArtifactStreamWriter(ctx, list, reqCtx, server.ArtifactStreamConfig{ID: "blob", Name: "blob.bin", ChunkSize: 1024})
+	_, err = io.Copy(w, bytes.NewReader(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Len(t, list.events, 10)
+
+	status := &a2a.TaskStatusUpdateEvent{TaskID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	other := &a2a.TaskArtifactUpdateEvent{TaskID: "task-1", Artifact: &a2a.Artifact{ID: "other", Parts: a2a.ContentParts{a2a.TextPart{Text: "x"}}}}
+
+	t.Run("reassembles", func(t *testing.T) {
+		events := append([]a2a.Event{status, other}, list.events...)
+		var seen []a2a.Event
+		r := NewArtifactReader(eventSeq(events...), "blob", func(event a2a.Event) error {
+			seen = append(seen, event)
+			return nil
+		})
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+		assert.Equal(t, "blob.bin", r.Artifact().Name)
+		assert.Equal(t, []a2a.Event{status, other}, seen)
+		require.NoError(t, r.Close())
+	})
+
+	t.Run("first artifact", func(t *testing.T) {
+		got, err := io.ReadAll(NewArtifactReader(eventSeq(list.events...), "", nil))
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+	})
+
+	t.Run("truncated stream", func(t *testing.T) {
+		_, err := io.ReadAll(NewArtifactReader(eventSeq(list.events[:5]...), "blob", nil))
+		assert.ErrorIs(t, err, ErrArtifactIncomplete)
+	})
+
+	t.Run("joined mid-way", func(t *testing.T) {
+		_, err := io.ReadAll(NewArtifactReader(eventSeq(list.events[1:]...), "blob", nil))
+		assert.ErrorIs(t, err, ErrArtifactIncomplete)
+	})
+
+	t.Run("stream error", func(t *testing.T) {
+		failing := func(yield func(a2a.Event, error) bool) {
+			if yield(list.events[0], nil) {
+				yield(nil, errors.New("connection reset"))
+			}
+		}
+		_, err := io.ReadAll(NewArtifactReader(failing, "blob", nil))
+		assert.ErrorContains(t, err, "connection reset")
+	})
+
+	t.Run("close", func(t *testing.T) {
+		r := NewArtifactReader(eventSeq(list.events...), "blob", nil)
+		buf := make([]byte, 10)
+		_, err := r.Read(buf)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		_, err = r.Read(buf)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// DefaultCancelTimeout bounds the best-effort tasks/cancel request sent when
+// a stream's context is cancelled
+const DefaultCancelTimeout = 5 * time.Second
+
+// WithCancelOnContextDone makes streaming calls send a best-effort
+// tasks/cancel to the remote agent when the caller cancels the context
+// before the task reached a terminal state, so server-side work stops too
+// instead of the task being silently abandoned.
+//
+// The cancel request is detached from the cancelled context and bounded by
+// timeout (DefaultCancelTimeout if timeout <= 0). Its outcome is ignored.
+func WithCancelOnContextDone(timeout time.Duration) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		if timeout <= 0 {
+			timeout = DefaultCancelTimeout
+		}
+		t.cancelOnDone = true
+		t.cancelTimeout = timeout
+	}
+}
+
+// streamTaskTracker follows the task a stream belongs to and whether it has
+// finished, so cancellation is only propagated for live tasks
+type streamTaskTracker struct {
+	taskID   a2a.TaskID
+	finished bool
+}
+
+// newStreamTaskTracker seeds the tracker from the request parameters
+// (tasks/resubscribe and messages continuing an existing task)
+func newStreamTaskTracker(params any) *streamTaskTracker {
+	tr := &streamTaskTracker{}
+	switch p := params.(type) {
+	case *a2a.TaskIDParams:
+		if p != nil {
+			tr.taskID = p.ID
+		}
+	case *a2a.MessageSendParams:
+		if p != nil && p.Message != nil {
+			tr.taskID = p.Message.TaskID
+		}
+	}
+	return tr
+}
+
+// observe updates the tracker from a stream event
+func (tr *streamTaskTracker) observe(event a2a.Event) {
+	switch e := event.(type) {
+	case *a2a.Task:
+		tr.taskID = e.ID
+		tr.finished = e.Status.State.Terminal()
+	case *a2a.TaskStatusUpdateEvent:
+		tr.taskID = e.TaskID
+		tr.finished = e.Final || e.Status.State.Terminal()
+	case *a2a.TaskArtifactUpdateEvent:
+		tr.taskID = e.TaskID
+	case *a2a.Message:
+		if e.TaskID != "" {
+			tr.taskID = e.TaskID
+		}
+	}
+}
+
+// propagateCancel sends tasks/cancel for the tracked task if ctx was
+// cancelled while the task was still running
+func (t *DIDHTTPTransport) propagateCancel(ctx context.Context, tr *streamTaskTracker) {
+	if !t.cancelOnDone || ctx.Err() == nil || tr.taskID == "" || tr.finished {
+		return
+	}
+
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), t.cancelTimeout)
+	defer cancel()
+
+	_, _ = t.CancelTask(cancelCtx, &a2a.TaskIDParams{ID: tr.taskID})
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cancelTestHandler streams a working task until the client disconnects and
+// records tasks/cancel calls
+func cancelTestHandler(state a2a.TaskState, cancelled *[]string, mu *sync.Mutex) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req jsonRPCRequest
+		_ = json.Unmarshal(body, &req)
+
+		if req.Method == "tasks/cancel" {
+			var params struct {
+				ID string `json:"id"`
+			}
+			raw, _ := json.Marshal(req.Params)
+			_ = json.Unmarshal(raw, &params)
+			mu.Lock()
+			*cancelled = append(*cancelled, params.ID)
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(mockJSONRPCResponse(&a2a.Task{ID: a2a.TaskID(params.ID), Status: a2a.TaskStatus{State: a2a.TaskStateCanceled}}))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		task := &a2a.Task{ID: "task-42", ContextID: "ctx", Status: a2a.TaskStatus{State: state}}
+		resp, _ := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  map[string]interface{}{"task": task},
+		})
+		fmt.Fprintf(w, "data: %s\n\n", resp)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}
+}
+
+func consumeUntilCancel(t *DIDHTTPTransport) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	params := &a2a.MessageSendParams{Message: &a2a.Message{Role: a2a.MessageRoleUser}}
+	for _, err := range t.SendStreamingMessage(ctx, params) {
+		if err != nil {
+			break
+		}
+		cancel()
+	}
+}
+
+func TestWithCancelOnContextDone_SendsCancel(t *testing.T) {
+	var mu sync.Mutex
+	var cancelled []string
+
+	transport, server := setupTestTransport(t, cancelTestHandler(a2a.TaskStateWorking, &cancelled, &mu))
+	defer server.Close()
+	WithCancelOnContextDone(time.Second)(transport)
+
+	consumeUntilCancel(transport)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"task-42"}, cancelled)
+}
+
+func TestWithCancelOnContextDone_SkipsTerminalAndDisabled(t *testing.T) {
+	var mu sync.Mutex
+	var cancelled []string
+
+	// Terminal task: nothing to cancel
+	transport, server := setupTestTransport(t, cancelTestHandler(a2a.TaskStateCompleted, &cancelled, &mu))
+	WithCancelOnContextDone(0)(transport)
+	assert.Equal(t, DefaultCancelTimeout, transport.cancelTimeout)
+	consumeUntilCancel(transport)
+	server.Close()
+
+	// Option not set: legacy behavior
+	transport, server = setupTestTransport(t, cancelTestHandler(a2a.TaskStateWorking, &cancelled, &mu))
+	consumeUntilCancel(transport)
+	server.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Empty(t, cancelled)
+}
+
+func TestStreamTaskTracker(t *testing.T) {
+	tr := newStreamTaskTracker(&a2a.TaskIDParams{ID: "t1"})
+	assert.Equal(t, a2a.TaskID("t1"), tr.taskID)
+
+	tr.observe(&a2a.TaskStatusUpdateEvent{TaskID: "t1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}})
+	assert.False(t, tr.finished)
+
+	tr.observe(&a2a.TaskStatusUpdateEvent{TaskID: "t1", Final: true})
+	assert.True(t, tr.finished)
+
+	tr = newStreamTaskTracker(&a2a.MessageSendParams{Message: &a2a.Message{}})
+	require.Empty(t, tr.taskID)
+	tr.observe(&a2a.Message{TaskID: "t2"})
+	assert.Equal(t, a2a.TaskID("t2"), tr.taskID)
+}
@@ -0,0 +1,282 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultAgentCardTTL is how long a cached agent card is used without
+// revalidation
+const DefaultAgentCardTTL = 5 * time.Minute
+
+// AgentCardVerifier checks an agent card fetched by the transport, e.g. its
+// signatures. It runs on the first fetch and whenever the card changes.
+type AgentCardVerifier func(ctx context.Context, card *a2a.AgentCard) error
+
+// cachedCard is a fetched agent card and its validators
+type cachedCard struct {
+	body         []byte
+	etag         string
+	lastModified string
+	fetched      time.Time
+}
+
+// AgentCardCache caches agent cards by URL. Share one cache between
+// transports so clients created per request reuse fetched cards.
+type AgentCardCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	cards map[string]*cachedCard
+}
+
+// NewAgentCardCache creates a cache keeping cards fresh for ttl
+// (DefaultAgentCardTTL if ttl <= 0). Stale cards are revalidated with
+// If-None-Match / If-Modified-Since.
+func NewAgentCardCache(ttl time.Duration) *AgentCardCache {
+	if ttl <= 0 {
+		ttl = DefaultAgentCardTTL
+	}
+	return &AgentCardCache{ttl: ttl, now: time.Now, cards: make(map[string]*cachedCard)}
+}
+
+// Invalidate drops the cached card for url
+func (c *AgentCardCache) Invalidate(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cards, url)
+}
+
+func (c *AgentCardCache) get(url string) (cachedCard, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cards[url]
+	if !ok {
+		return cachedCard{}, false
+	}
+	return *entry, true
+}
+
+func (c *AgentCardCache) put(url string, entry cachedCard) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cards[url] = &entry
+}
+
+// WithAgentCardCache makes GetAgentCard serve cards from cache, keyed by
+// the agent card URL
+func WithAgentCardCache(cache *AgentCardCache) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.cardCache = cache
+	}
+}
+
+// WithAgentCardVerifier verifies fetched agent cards with verify. Cards
+// failing verification are returned as errors and never cached.
+func WithAgentCardVerifier(verify AgentCardVerifier) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.cardVerifier = verify
+	}
+}
+
+// WithAgentCardSigner requires fetched agent cards to carry a signature by
+// expectedDID (see protocol.SignA2AAgentCard), resolving its key with
+// resolve. Unsigned or substituted cards are returned as errors and never
+// cached. It applies in addition to WithAgentCardVerifier.
+func WithAgentCardSigner(expectedDID did.AgentDID, resolve protocol.CardKeyResolver) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.cardSignerDID = expectedDID
+		t.cardKeyResolver = resolve
+	}
+}
+
+// RefreshAgentCard fetches the agent card, revalidating any cached copy
+// regardless of its age
+func (t *DIDHTTPTransport) RefreshAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	return t.agentCard(ctx, true)
+}
+
+// agentCard returns the agent card, from cache when fresh unless force is set
+func (t *DIDHTTPTransport) agentCard(ctx context.Context, force bool) (*a2a.AgentCard, error) {
+	// The timeout covers the fetch, not the verification of the card
+	fetchCtx, cancel := t.methodContext(ctx, agentCardMethod)
+	defer cancel()
+
+	url := t.baseURL + "/.well-known/agent-card.json"
+	if t.cardCache == nil && t.responseCache != nil {
+		return t.cachedAgentCard(ctx, fetchCtx, url, force)
+	}
+
+	var cached cachedCard
+	var hasCached bool
+	if t.cardCache != nil {
+		cached, hasCached = t.cardCache.get(url)
+		if hasCached && !force && t.cardCache.now().Sub(cached.fetched) < t.cardCache.ttl {
+			return decodeAgentCard(cached.body)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(fetchCtx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if hasCached {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	// Sign request with DID
+	if err := t.signer.SignRequest(ctx, req, t.agentDID, t.keyPair); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	t.recordRequest(agentCardMethod, 0)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		cached.fetched = t.cardCache.now()
+		t.cardCache.put(url, cached)
+		return decodeAgentCard(cached.body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := readLimited(resp.Body, maxErrorBodySize)
+		return nil, newHTTPError(resp, body)
+	}
+
+	body, err := readLimited(t.countResponse(agentCardMethod, resp.Body), t.maxResponseSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent card: %w", err)
+	}
+	card, err := decodeAgentCard(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify new or changed cards
+	if !(hasCached && bytes.Equal(body, cached.body)) {
+		if err := t.verifyAgentCard(ctx, card); err != nil {
+			return nil, err
+		}
+	}
+
+	if t.cardCache != nil {
+		t.cardCache.put(url, cachedCard{
+			body:         body,
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+			fetched:      t.cardCache.now(),
+		})
+	}
+	return card, nil
+}
+
+// cachedAgentCard returns the agent card through the response cache,
+// fetching it with fetchCtx. Cards are verified whenever they come from
+// the network, and dropped from the cache if verification fails.
+func (t *DIDHTTPTransport) cachedAgentCard(ctx, fetchCtx context.Context, url string, force bool) (*a2a.AgentCard, error) {
+	key := t.cacheKey(url)
+	resp, fromCache, err := t.responseCache.do(key, force, func(header http.Header) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(fetchCtx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		if err := t.signer.SignRequest(ctx, req, t.agentDID, t.keyPair); err != nil {
+			return nil, fmt.Errorf("failed to sign request: %w", err)
+		}
+		t.recordRequest(agentCardMethod, 0)
+
+		resp, err := t.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP request failed: %w", err)
+		}
+		resp.Body = t.countResponse(agentCardMethod, resp.Body)
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := readLimited(resp.Body, maxErrorBodySize)
+		return nil, newHTTPError(resp, body)
+	}
+	body, err := readLimited(resp.Body, t.maxResponseSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent card: %w", err)
+	}
+	card, err := decodeAgentCard(body)
+	if err != nil {
+		t.responseCache.invalidate(key)
+		return nil, err
+	}
+	if !fromCache {
+		if err := t.verifyAgentCard(ctx, card); err != nil {
+			t.responseCache.invalidate(key)
+			return nil, err
+		}
+	}
+	return card, nil
+}
+
+// verifyAgentCard runs the configured card signature check and verifier
+func (t *DIDHTTPTransport) verifyAgentCard(ctx context.Context, card *a2a.AgentCard) error {
+	if t.cardKeyResolver != nil {
+		if err := protocol.VerifyA2AAgentCardFrom(ctx, card, t.cardSignerDID, t.cardKeyResolver); err != nil {
+			return fmt.Errorf("agent card verification failed: %w", err)
+		}
+	}
+	if t.cardVerifier != nil {
+		if err := t.cardVerifier(ctx, card); err != nil {
+			return fmt.Errorf("agent card verification failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// decodeAgentCard decodes a fresh copy of an agent card
+func decodeAgentCard(body []byte) (*a2a.AgentCard, error) {
+	var card a2a.AgentCard
+	if err := json.Unmarshal(body, &card); err != nil {
+		return nil, fmt.Errorf("failed to decode agent card: %w", err)
+	}
+	return &card, nil
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cardServer serves an agent card with an ETag and counts fetches
+type cardServer struct {
+	mu      sync.Mutex
+	card    a2a.AgentCard
+	etag    string
+	fetches int
+	notMod  int
+}
+
+func (s *cardServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches++
+	if r.Header.Get("If-None-Match") == s.etag {
+		s.notMod++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.card)
+}
+
+func (s *cardServer) update(name, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.card.Name = name
+	s.etag = etag
+}
+
+func TestAgentCardCache_ServesFreshCard(t *testing.T) {
+	srv := &cardServer{card: a2a.AgentCard{Name: "v1"}, etag: `"v1"`}
+	transport, server := setupTestTransport(t, srv.handler)
+	defer server.Close()
+
+	cache := NewAgentCardCache(time.Minute)
+	WithAgentCardCache(cache)(transport)
+
+	for i := 0; i < 3; i++ {
+		card, err := transport.GetAgentCard(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "v1", card.Name)
+		card.Name = "mutated"
+	}
+	assert.Equal(t, 1, srv.fetches)
+
+	// Another transport sharing the cache reuses the card
+	other, otherServer := setupTestTransport(t, srv.handler)
+	defer otherServer.Close()
+	other.baseURL = transport.baseURL
+	WithAgentCardCache(cache)(other)
+	_, err := other.GetAgentCard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.fetches)
+}
+
+func TestAgentCardCache_Revalidates(t *testing.T) {
+	srv := &cardServer{card: a2a.AgentCard{Name: "v1"}, etag: `"v1"`}
+	transport, server := setupTestTransport(t, srv.handler)
+	defer server.Close()
+
+	now := time.Now()
+	cache := NewAgentCardCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	WithAgentCardCache(cache)(transport)
+
+	var verified []string
+	WithAgentCardVerifier(func(ctx context.Context, card *a2a.AgentCard) error {
+		verified = append(verified, card.Name)
+		return nil
+	})(transport)
+
+	_, err := transport.GetAgentCard(context.Background())
+	require.NoError(t, err)
+
+	// Stale but unchanged: 304 and no re-verification
+	now = now.Add(2 * time.Minute)
+	card, err := transport.GetAgentCard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v1", card.Name)
+	assert.Equal(t, 1, srv.notMod)
+
+	// Refresh picks up a changed card and verifies it
+	srv.update("v2", `"v2"`)
+	card, err = transport.RefreshAgentCard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v2", card.Name)
+	assert.Equal(t, []string{"v1", "v2"}, verified)
+	assert.Equal(t, 3, srv.fetches)
+}
+
+func TestAgentCardCache_VerificationFailureKeepsCache(t *testing.T) {
+	srv := &cardServer{card: a2a.AgentCard{Name: "v1"}, etag: `"v1"`}
+	transport, server := setupTestTransport(t, srv.handler)
+	defer server.Close()
+
+	cache := NewAgentCardCache(0)
+	assert.Equal(t, DefaultAgentCardTTL, cache.ttl)
+	WithAgentCardCache(cache)(transport)
+
+	errTampered := errors.New("tampered")
+	WithAgentCardVerifier(func(ctx context.Context, card *a2a.AgentCard) error {
+		if card.Name != "v1" {
+			return errTampered
+		}
+		return nil
+	})(transport)
+
+	_, err := transport.GetAgentCard(context.Background())
+	require.NoError(t, err)
+
+	srv.update("evil", `"evil"`)
+	_, err = transport.RefreshAgentCard(context.Background())
+	assert.ErrorIs(t, err, errTampered)
+
+	card, err := transport.GetAgentCard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v1", card.Name)
+
+	cache.Invalidate(transport.baseURL + "/.well-known/agent-card.json")
+	_, err = transport.GetAgentCard(context.Background())
+	assert.ErrorIs(t, err, errTampered)
+}
+
+func TestWithAgentCardSigner(t *testing.T) {
+	const agentDID = did.AgentDID("did:sage:ethereum:0xagent")
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	resolve := func(ctx context.Context, id did.AgentDID, keyType *did.KeyType) (stdcrypto.PublicKey, error) {
+		if id != agentDID {
+			return nil, errors.New("unknown DID")
+		}
+		return kp.PublicKey(), nil
+	}
+
+	signed := a2a.AgentCard{Name: "agent"}
+	require.NoError(t, protocol.SignA2AAgentCard(&signed, agentDID, kp))
+	srv := &cardServer{card: signed, etag: `"v1"`}
+	transport, server := setupTestTransport(t, srv.handler)
+	defer server.Close()
+	WithAgentCardSigner(agentDID, resolve)(transport)
+
+	card, err := transport.GetAgentCard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "agent", card.Name)
+
+	// A substituted card no longer matches the signature
+	srv.update("impostor", `"v2"`)
+	_, err = transport.GetAgentCard(context.Background())
+	assert.ErrorIs(t, err, protocol.ErrCardSignatureInvalid)
+
+	// Cards signed by another DID are rejected
+	WithAgentCardSigner("did:sage:ethereum:0xother", resolve)(transport)
+	srv.update("agent", `"v3"`)
+	_, err = transport.GetAgentCard(context.Background())
+	assert.ErrorIs(t, err, protocol.ErrCardNotSigned)
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/codec"
+)
+
+// WithCodec encodes JSON-RPC requests with c, such as codec.CBOR, and asks
+// the server to encode responses, and event streams if c is a
+// codec.StreamCodec, with it as well. Content-Type is covered by the
+// request signature. The server must decode c, e.g. with
+// server.CodecHandler; responses in JSON are still accepted.
+func WithCodec(c codec.Codec) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.codec = c
+	}
+}
+
+// encodeBody encodes a JSON request body with the transport codec,
+// returning it with its content type
+func (t *DIDHTTPTransport) encodeBody(body []byte) ([]byte, string, error) {
+	if codec.IsJSON(t.codec) {
+		return body, codec.ContentTypeJSON, nil
+	}
+	encoded, err := t.codec.FromJSON(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode request body: %w", err)
+	}
+	return encoded, t.codec.ContentType(), nil
+}
+
+// acceptHeader returns the Accept header for a request whose response is
+// JSON (accept empty) or an event stream (accept text/event-stream),
+// preferring the transport codec
+func (t *DIDHTTPTransport) acceptHeader(accept string) string {
+	if codec.IsJSON(t.codec) {
+		return accept
+	}
+	if accept == "" {
+		return t.codec.ContentType() + ", " + codec.ContentTypeJSON + ";q=0.5"
+	}
+	if sc, ok := t.codec.(codec.StreamCodec); ok {
+		return sc.StreamContentType() + ", " + accept + ";q=0.5"
+	}
+	return accept
+}
+
+// handlerBody returns a JSON request body as the handler behind
+// server.CodecHandler sees it, decoded from the transport codec, so
+// receipts issued over it can be matched
+func (t *DIDHTTPTransport) handlerBody(body []byte) []byte {
+	if codec.IsJSON(t.codec) {
+		return body
+	}
+	encoded, err := t.codec.FromJSON(body)
+	if err != nil {
+		return body
+	}
+	decoded, err := t.codec.ToJSON(encoded)
+	if err != nil {
+		return body
+	}
+	return decoded
+}
+
+// decodeResponseBody converts a response body encoded with a registered
+// codec to JSON
+func decodeResponseBody(resp *http.Response, body []byte) ([]byte, error) {
+	c, ok := codec.ForContentType(resp.Header.Get("Content-Type"))
+	if !ok || codec.IsJSON(c) {
+		return body, nil
+	}
+	decoded, err := c.ToJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", c.ContentType(), err)
+	}
+	return decoded, nil
+}
+
+// parseCodecStream reads the events of a stream encoded with sc, each a
+// JSON-RPC response, like parseSSEStream
+func parseCodecStream(ctx context.Context, resp *http.Response, sc codec.StreamCodec, maxEventSize int64, format EventFormat) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		defer resp.Body.Close()
+
+		events := sc.NewStreamReader(resp.Body, maxEventSize)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			data, err := events.Next()
+			if err == io.EOF {
+				return
+			}
+			if errors.Is(err, codec.ErrTooLarge) {
+				yield(nil, fmt.Errorf("%w: %v", ErrResponseTooLarge, err))
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("error reading %s stream: %w", sc.StreamContentType(), err))
+				return
+			}
+
+			event, err := parseSSEData(data, format)
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/codec"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_Codec_SignsEncodedBody(t *testing.T) {
+	var (
+		contentType    string
+		accept         string
+		signatureInput string
+		digestMatches  bool
+		decodedBody    []byte
+	)
+
+	transport, srv := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		accept = r.Header.Get("Accept")
+		signatureInput = r.Header.Get("Signature-Input")
+
+		raw, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(raw)
+		digestMatches = r.Header.Get("Content-Digest") == "sha-256=:"+base64.StdEncoding.EncodeToString(sum[:])+":"
+		decodedBody, _ = codec.CBOR.ToJSON(raw)
+
+		body, _ := codec.CBOR.FromJSON(mockJSONRPCResponse(map[string]interface{}{"id": "task-1", "contextId": "ctx", "kind": "task", "status": map[string]interface{}{"state": "working"}}))
+		w.Header().Set("Content-Type", codec.ContentTypeCBOR)
+		w.Write(body)
+	})
+	defer srv.Close()
+	WithCodec(codec.CBOR)(transport)
+
+	task, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-1"), task.ID)
+
+	assert.Equal(t, codec.ContentTypeCBOR, contentType)
+	assert.Equal(t, "application/cbor, application/json;q=0.5", accept)
+	assert.Contains(t, signatureInput, `"content-type"`)
+	assert.True(t, digestMatches, "Content-Digest must cover the encoded body")
+	assert.Contains(t, string(decodedBody), `"tasks/get"`)
+}
+
+func TestDIDHTTPTransport_Codec_CodecHandler(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	var received []byte
+	var responseType string
+	handler := server.NewCodecHandler().Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		if !strings.HasPrefix(r.Header.Get("Accept"), codec.ContentTypeCBORSeq) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(mockJSONRPCResponse(task))
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, result := range []any{map[string]any{"task": task}, map[string]any{"statusUpdate": a2a.NewStatusUpdateEvent(task, a2a.TaskStateCompleted, nil)}} {
+			data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(&contentTypeRecorder{ResponseWriter: w, contentType: &responseType}, r)
+	}))
+	defer srv.Close()
+
+	keyPair, err := crypto.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	transport := NewDIDHTTPTransport(srv.URL, "did:sage:ethereum:0x1", keyPair, nil, WithCodec(codec.CBOR)).(*DIDHTTPTransport)
+
+	got, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-1"), got.ID)
+	assert.True(t, json.Valid(received), "handler must see JSON")
+	assert.Equal(t, codec.ContentTypeCBOR, responseType)
+
+	params := &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "hi"})}
+	var events []a2a.Event
+	for event, err := range transport.SendStreamingMessage(context.Background(), params) {
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.IsType(t, &a2a.Task{}, events[0])
+	assert.IsType(t, &a2a.TaskStatusUpdateEvent{}, events[1])
+	assert.Equal(t, codec.ContentTypeCBORSeq, responseType)
+}
+
+// contentTypeRecorder records the Content-Type of the response it writes
+type contentTypeRecorder struct {
+	http.ResponseWriter
+	contentType *string
+}
+
+func (w *contentTypeRecorder) WriteHeader(code int) {
+	*w.contentType = w.Header().Get("Content-Type")
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *contentTypeRecorder) Flush() {
+	w.ResponseWriter.(http.Flusher).Flush()
+}
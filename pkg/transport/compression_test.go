@@ -0,0 +1,120 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_Compression_SignsCompressedBody(t *testing.T) {
+	var (
+		contentEncoding string
+		signatureInput  string
+		digestMatches   bool
+		decodedBody     []byte
+	)
+
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		contentEncoding = r.Header.Get("Content-Encoding")
+		signatureInput = r.Header.Get("Signature-Input")
+
+		raw, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(raw)
+		digestMatches = r.Header.Get("Content-Digest") == "sha-256=:"+base64.StdEncoding.EncodeToString(sum[:])+":"
+		decodedBody, _ = compression.Decompress(contentEncoding, raw)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(map[string]interface{}{"id": "task-1", "contextId": "ctx", "kind": "task", "status": map[string]interface{}{"state": "working"}}))
+	})
+	defer server.Close()
+
+	transport.SetCompression(&compression.Config{MinSize: 1})
+
+	_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gzip", contentEncoding)
+	assert.Contains(t, signatureInput, `"content-encoding"`)
+	assert.True(t, digestMatches, "Content-Digest must cover the compressed body")
+	assert.True(t, strings.Contains(string(decodedBody), `"tasks/get"`))
+}
+
+func TestDIDHTTPTransport_Compression_SmallPayloadUncompressed(t *testing.T) {
+	var contentEncoding, acceptEncoding string
+
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		contentEncoding = r.Header.Get("Content-Encoding")
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(map[string]interface{}{"id": "task-1", "contextId": "ctx", "kind": "task", "status": map[string]interface{}{"state": "working"}}))
+	})
+	defer server.Close()
+
+	transport.SetCompression(compression.DefaultConfig())
+
+	_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+
+	assert.Empty(t, contentEncoding)
+	assert.Contains(t, acceptEncoding, "gzip")
+}
+
+func TestDIDHTTPTransport_Compression_DecodesResponse(t *testing.T) {
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := compression.Compress("deflate", mockJSONRPCResponse(map[string]interface{}{"id": "task-9", "contextId": "ctx", "kind": "task", "status": map[string]interface{}{"state": "completed"}}))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "deflate")
+		w.Write(body)
+	})
+	defer server.Close()
+
+	transport.SetCompression(compression.DefaultConfig())
+
+	task, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-9"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-9"), task.ID)
+}
+
+func TestDIDHTTPTransport_Compression_DisabledByDefault(t *testing.T) {
+	var acceptEncoding, contentEncoding string
+
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		contentEncoding = r.Header.Get("Content-Encoding")
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(map[string]interface{}{"id": "task-1", "contextId": "ctx", "kind": "task", "status": map[string]interface{}{"state": "working"}}))
+	})
+	defer server.Close()
+
+	_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+
+	assert.Empty(t, contentEncoding)
+	assert.NotContains(t, acceptEncoding, "deflate")
+}
@@ -22,13 +22,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"iter"
 	"net/http"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/sage-x-project/sage-a2a-go/pkg/codec"
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
 	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
 	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
@@ -49,9 +55,50 @@ type DIDHTTPTransport struct {
 	keyPair    crypto.KeyPair
 	signer     signer.A2ASigner
 	httpClient *http.Client
-	requestID  uint64 // atomic counter for JSON-RPC request IDs
+	http3      *http3.Transport // set by WithHTTP3; closed by Destroy
+	requestID  uint64           // atomic counter for JSON-RPC request IDs
+
+	compression *compression.Config // nil disables request compression
+	codec       codec.Codec         // nil sends JSON
+
+	cancelOnDone  bool          // send tasks/cancel when a stream's context is cancelled
+	cancelTimeout time.Duration // timeout for the best-effort tasks/cancel
+
+	digestAlg atomic.Value // string; Content-Digest algorithm negotiated with the server
+
+	maxResponseSize int64       // 0 disables the response size limit
+	sizes           sizeMetrics // per-method byte counters
+
+	cardCache    *AgentCardCache   // nil fetches the agent card every time
+	cardVerifier AgentCardVerifier // nil accepts fetched cards as is
+
+	cardSignerDID   did.AgentDID             // DID expected to sign fetched cards
+	cardKeyResolver protocol.CardKeyResolver // nil skips card signature checks
+
+	eventFormat EventFormat // format of streamed event results
+
+	rateLimiter *OutboundLimiter // nil sends requests without rate limiting
+
+	sendDefaults sendDefaults // client preferences for message and task calls
+
+	artifactChunkSize int // upload chunk size; 0 uses DefaultArtifactChunkSize
+
+	responseCache *ResponseCache // nil sends every signed GET
+
+	receiptStore       ReceiptStore             // nil ignores request receipts
+	receiptKeyResolver protocol.CardKeyResolver // resolves receipt issuer keys
+
+	messageDigestAlg string // "" sends no A2A-Message-Digest header
+
+	methodTimeouts map[string]time.Duration // per-method overrides of DefaultMethodTimeouts
+
+	longPollWait  time.Duration // 0 disables the long-polling fallback
+	streamBlocked atomic.Bool   // SSE failed; streaming calls go straight to long polling
 }
 
+// TransportOption configures optional DIDHTTPTransport behavior
+type TransportOption func(*DIDHTTPTransport)
+
 // NewDIDHTTPTransport creates a new DID-authenticated HTTP transport.
 //
 // Parameters:
@@ -59,23 +106,79 @@ type DIDHTTPTransport struct {
 //   - agentDID: Your agent's DID for signing requests
 //   - keyPair: Your agent's private key for signing
 //   - httpClient: Optional HTTP client (nil to use http.DefaultClient)
+//   - opts: Optional behavior such as WithCancelOnContextDone
 func NewDIDHTTPTransport(
 	baseURL string,
 	agentDID did.AgentDID,
 	keyPair crypto.KeyPair,
 	httpClient *http.Client,
+	opts ...TransportOption,
 ) a2aclient.Transport {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 
-	return &DIDHTTPTransport{
+	t := &DIDHTTPTransport{
 		baseURL:    baseURL,
 		agentDID:   agentDID,
 		keyPair:    keyPair,
 		signer:     signer.NewDefaultA2ASigner(),
 		httpClient: httpClient,
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// NewDIDHTTPTransportFromIdentity creates a DID-authenticated HTTP transport
+// signing as id
+func NewDIDHTTPTransportFromIdentity(baseURL string, id *identity.Identity, httpClient *http.Client, opts ...TransportOption) a2aclient.Transport {
+	return NewDIDHTTPTransport(baseURL, id.DID, id.KeyPair, httpClient, opts...)
+}
+
+// NewDIDHTTPTransportForIdentity creates a DID-authenticated HTTP transport
+// signing as the identity stored under name. It fails with
+// cryptoinit.ErrNotInitialized if the sage crypto constructors are not
+// registered.
+func NewDIDHTTPTransportForIdentity(store *identity.Store, name, baseURL string, httpClient *http.Client, opts ...TransportOption) (a2aclient.Transport, error) {
+	if err := cryptoinit.Check(); err != nil {
+		return nil, err
+	}
+	id, err := store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewDIDHTTPTransportFromIdentity(baseURL, id, httpClient, opts...), nil
+}
+
+// WithSigner replaces the default RFC 9421 signer, e.g. with a mock in
+// unit tests. WithSignatureTTL only applies to the default signer.
+func WithSigner(s signer.A2ASigner) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.signer = s
+	}
+}
+
+// WithSignatureTTL bounds the lifetime of every request signature to ttl.
+// Signatures also expire at the deadline of the request context, if
+// earlier, so a signed request cannot be replayed after the caller gave up
+// on it.
+func WithSignatureTTL(ttl time.Duration) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		if s, ok := t.signer.(*signer.DefaultA2ASigner); ok {
+			s.SetTTL(ttl)
+		}
+	}
+}
+
+// SetCompression enables request/response compression.
+// Request bodies at least cfg.MinSize bytes long are compressed before
+// signing, so the Content-Digest covers the compressed representation.
+// Pass nil to disable compression.
+func (t *DIDHTTPTransport) SetCompression(cfg *compression.Config) {
+	t.compression = cfg
 }
 
 // ========================================
@@ -106,7 +209,15 @@ type jsonRPCError struct {
 }
 
 // call makes a JSON-RPC 2.0 call with DID signature and returns the raw result
-func (t *DIDHTTPTransport) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+func (t *DIDHTTPTransport) call(ctx context.Context, method string, params any) (result json.RawMessage, err error) {
+	ctx, cancel := t.methodContext(ctx, method)
+	defer cancel()
+
+	var status, attempts int
+	defer func() {
+		err = t.callError(method, status, attempts, err)
+	}()
+
 	// Create JSON-RPC request with unique ID
 	rpcReq := jsonRPCRequest{
 		JSONRPC: "2.0",
@@ -121,35 +232,28 @@ func (t *DIDHTTPTransport) call(ctx context.Context, method string, params any)
 		return nil, fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
 	}
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", t.baseURL+"/rpc", bytes.NewReader(body))
+	// Sign and execute HTTP request
+	resp, attempts, err := t.doRPC(ctx, method, body, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	// Sign request with DID
-	if err := t.signer.SignRequest(ctx, req, t.agentDID, t.keyPair); err != nil {
-		return nil, fmt.Errorf("failed to sign request with DID: %w", err)
-	}
-
-	// Execute HTTP request
-	resp, err := t.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
+	status = resp.StatusCode
+	t.observeDigestPreference(resp)
+	resp.Body = t.countResponse(method, resp.Body)
 
 	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := readResponseBody(resp, t.maxResponseSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Check HTTP status
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d %s: %s", resp.StatusCode, resp.Status, string(respBody))
+		return nil, newHTTPError(resp, respBody)
+	}
+	if err := t.storeReceipt(ctx, resp, body); err != nil {
+		return nil, err
 	}
 
 	// Parse JSON-RPC response
@@ -160,19 +264,188 @@ func (t *DIDHTTPTransport) call(ctx context.Context, method string, params any)
 
 	// Check for JSON-RPC error
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("JSON-RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		return nil, &RPCError{Code: rpcResp.Error.Code, Message: rpcResp.Error.Message}
 	}
 
 	return rpcResp.Result, nil
 }
 
+// Call makes a JSON-RPC call of any method, for methods without a typed
+// wrapper, and returns the raw result. Errors are *CallError wrapping an
+// *RPCError for JSON-RPC errors or an *HTTPError for non-200 responses.
+func (t *DIDHTTPTransport) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	return t.call(ctx, method, params)
+}
+
+// newRPCRequest creates a signed JSON-RPC POST request, waiting for the
+// outbound rate limit if one is set.
+// The body is encoded with the codec set by WithCodec, then compressed
+// when compression is enabled; non-JSON Content-Type and Content-Encoding
+// headers are included in the signature base.
+func (t *DIDHTTPTransport) newRPCRequest(ctx context.Context, body []byte, accept string) (*http.Request, error) {
+	// Wait for the rate limit before signing so the signature is fresh
+	if t.rateLimiter != nil {
+		if err := t.rateLimiter.Wait(ctx, t.rateLimitKey()); err != nil {
+			return nil, err
+		}
+	}
+
+	body, contentType, err := t.encodeBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := ""
+	if t.compression.ShouldCompress(len(body)) {
+		encoding = t.compression.EncodingName()
+		compressed, err := compression.Compress(encoding, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+		body = compressed
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.baseURL+"/rpc", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	if accept := t.acceptHeader(accept); accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if t.compression != nil {
+		req.Header.Set("Accept-Encoding", compression.EncodingGzip+", "+compression.EncodingDeflate)
+	}
+
+	hints := setRequestHints(ctx, req)
+
+	// Sign request with DID
+	digestAlg := t.DigestAlgorithm()
+	encoded := contentType != codec.ContentTypeJSON
+	if encoding != "" || encoded || digestAlg != signer.DigestSHA256 || len(hints) > 0 {
+		components := []string{"@method", "@path", "@query", "content-digest"}
+		if encoding != "" {
+			req.Header.Set("Content-Encoding", encoding)
+			components = []string{"@method", "@path", "@query", "content-encoding", "content-digest"}
+		}
+		if encoded {
+			components = append(components, "content-type")
+		}
+		components = append(components, hints...)
+		opts := &signer.SigningOptions{
+			Components:      components,
+			DigestAlgorithm: digestAlg,
+		}
+		if err := t.signer.SignRequestWithOptions(ctx, req, t.agentDID, t.keyPair, opts); err != nil {
+			return nil, fmt.Errorf("failed to sign request with DID: %w", err)
+		}
+		return req, nil
+	}
+
+	if err := t.signer.SignRequest(ctx, req, t.agentDID, t.keyPair); err != nil {
+		return nil, fmt.Errorf("failed to sign request with DID: %w", err)
+	}
+
+	return req, nil
+}
+
+// setRequestHints sets the priority, deadline, extension, capability
+// grant, idempotency key, message digest and task context headers
+// requested via protocol.WithPriority, protocol.WithDeadline,
+// protocol.WithExtensions, protocol.WithGrants,
+// protocol.WithIdempotencyKey, protocol.WithMessageDigest and
+// protocol.WithTaskContext, and the budget header when ctx has a deadline,
+// returning the signature components covering them
+func setRequestHints(ctx context.Context, req *http.Request) []string {
+	var components []string
+	if p, ok := protocol.PriorityFromContext(ctx); ok {
+		req.Header.Set(protocol.PriorityHeader, p.String())
+		components = append(components, strings.ToLower(protocol.PriorityHeader))
+	}
+	if d, ok := protocol.DeadlineFromContext(ctx); ok {
+		req.Header.Set(protocol.DeadlineHeader, protocol.FormatDeadline(d))
+		components = append(components, strings.ToLower(protocol.DeadlineHeader))
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		// The remaining budget travels with the request so every hop
+		// of an agent chain stops at the caller's deadline
+		req.Header.Set(protocol.BudgetHeader, protocol.FormatBudget(time.Until(deadline)))
+		components = append(components, strings.ToLower(protocol.BudgetHeader))
+	}
+	if uris, ok := protocol.ExtensionsFromContext(ctx); ok {
+		req.Header.Set(protocol.ExtensionsHeader, protocol.FormatExtensions(uris))
+		components = append(components, strings.ToLower(protocol.ExtensionsHeader))
+	}
+	if grants, ok := protocol.GrantsFromContext(ctx); ok {
+		req.Header.Set(protocol.GrantHeader, protocol.FormatGrants(grants))
+		components = append(components, strings.ToLower(protocol.GrantHeader))
+	}
+	if key, ok := protocol.IdempotencyKeyFromContext(ctx); ok {
+		req.Header.Set(protocol.IdempotencyKeyHeader, key)
+		components = append(components, strings.ToLower(protocol.IdempotencyKeyHeader))
+	}
+	if digest, ok := protocol.MessageDigestFromContext(ctx); ok {
+		req.Header.Set(protocol.MessageDigestHeader, digest)
+		components = append(components, strings.ToLower(protocol.MessageDigestHeader))
+	}
+	if tc, ok := protocol.TaskContextFromContext(ctx); ok {
+		req.Header.Set(protocol.TaskContextHeader, tc.Token)
+		components = append(components, strings.ToLower(protocol.TaskContextHeader))
+	}
+	return components
+}
+
+// SetDigestAlgorithm sets the Content-Digest algorithm used for requests
+// (signer.DigestSHA256 or signer.DigestSHA512). It is updated automatically
+// when the server advertises a preference via Want-Content-Digest.
+func (t *DIDHTTPTransport) SetDigestAlgorithm(alg string) {
+	t.digestAlg.Store(alg)
+}
+
+// DigestAlgorithm returns the Content-Digest algorithm currently in use
+func (t *DIDHTTPTransport) DigestAlgorithm() string {
+	if alg, ok := t.digestAlg.Load().(string); ok && alg != "" {
+		return alg
+	}
+	return signer.DigestSHA256
+}
+
+// observeDigestPreference adopts the server's Want-Content-Digest preference
+// for subsequent requests
+func (t *DIDHTTPTransport) observeDigestPreference(resp *http.Response) {
+	if want := resp.Header.Get("Want-Content-Digest"); want != "" {
+		t.SetDigestAlgorithm(signer.NegotiateDigest(want))
+	}
+}
+
+// readResponseBody reads the full response body, decoding it according to
+// Content-Encoding and, for registered codecs, Content-Type. Go's
+// http.Transport only decodes gzip transparently when it added
+// Accept-Encoding itself, so explicit negotiation is handled here.
+// The limit applies to decoded bytes so compressed responses cannot expand
+// past it.
+func readResponseBody(resp *http.Response, limit int64) ([]byte, error) {
+	reader, err := compression.NewReader(resp.Header.Get("Content-Encoding"), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	body, err := readLimited(reader, limit)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResponseBody(resp, body)
+}
+
 // ========================================
 // A2A Protocol Methods (a2aclient.Transport interface)
 // ========================================
 
 // GetTask implements the 'tasks/get' protocol method.
 func (t *DIDHTTPTransport) GetTask(ctx context.Context, query *a2a.TaskQueryParams) (*a2a.Task, error) {
-	result, err := t.call(ctx, "tasks/get", query)
+	result, err := t.call(ctx, "tasks/get", t.withQueryDefaults(query))
 	if err != nil {
 		return nil, err
 	}
@@ -202,11 +475,19 @@ func (t *DIDHTTPTransport) CancelTask(ctx context.Context, id *a2a.TaskIDParams)
 
 // SendMessage implements the 'message/send' protocol method (non-streaming).
 func (t *DIDHTTPTransport) SendMessage(ctx context.Context, message *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
-	result, err := t.call(ctx, "message/send", message)
+	ctx, err := t.withMessageDigest(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	result, err := t.call(ctx, "message/send", t.withSendDefaults(message))
 	if err != nil {
 		return nil, err
 	}
+	return decodeSendResult(result)
+}
 
+// decodeSendResult decodes the result of message/send
+func decodeSendResult(result json.RawMessage) (a2a.SendMessageResult, error) {
 	// Result can be either Task or Message
 	// Distinguish by checking for "id" (Task) vs "messageId" (Message) field
 	var raw map[string]interface{}
@@ -236,15 +517,29 @@ func (t *DIDHTTPTransport) SendMessage(ctx context.Context, message *a2a.Message
 }
 
 // ResubscribeToTask implements the 'tasks/resubscribe' protocol method.
-// Note: HTTP transport uses Server-Sent Events (SSE) for streaming.
+// Note: HTTP transport uses Server-Sent Events (SSE) for streaming, or long
+// polling when WithLongPollFallback is set and SSE is unavailable.
 func (t *DIDHTTPTransport) ResubscribeToTask(ctx context.Context, id *a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	if t.longPollWait > 0 {
+		return t.resubscribeOrPoll(ctx, id)
+	}
 	return t.callSSE(ctx, "tasks/resubscribe", id)
 }
 
 // SendStreamingMessage implements the 'message/stream' protocol method (streaming).
-// Note: HTTP transport uses Server-Sent Events (SSE) for streaming.
+// Note: HTTP transport uses Server-Sent Events (SSE) for streaming, or long
+// polling when WithLongPollFallback is set and SSE is unavailable.
 func (t *DIDHTTPTransport) SendStreamingMessage(ctx context.Context, message *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
-	return t.callSSE(ctx, "message/stream", message)
+	ctx, err := t.withMessageDigest(ctx, message)
+	if err != nil {
+		return func(yield func(a2a.Event, error) bool) {
+			yield(nil, err)
+		}
+	}
+	if t.longPollWait > 0 {
+		return t.streamOrPoll(ctx, t.withSendDefaults(message))
+	}
+	return t.callSSE(ctx, "message/stream", t.withSendDefaults(message))
 }
 
 // GetTaskPushConfig implements the 'tasks/pushNotificationConfig/get' protocol method.
@@ -326,41 +621,17 @@ func (t *DIDHTTPTransport) ListTasks(ctx context.Context, params *protocol.ListT
 // ========================================
 
 // GetAgentCard implements agent card retrieval.
-// For HTTP transport, this fetches from the well-known URL.
+// For HTTP transport, this fetches from the well-known URL, or serves the
+// card from the agent card cache if one is configured.
 func (t *DIDHTTPTransport) GetAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
-	// Fetch agent card from well-known URL
-	url := t.baseURL + "/.well-known/agent-card.json"
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Sign request with DID
-	if err := t.signer.SignRequest(ctx, req, t.agentDID, t.keyPair); err != nil {
-		return nil, fmt.Errorf("failed to sign request: %w", err)
-	}
-
-	resp, err := t.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
-	}
-
-	var card a2a.AgentCard
-	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
-		return nil, fmt.Errorf("failed to decode agent card: %w", err)
-	}
-
-	return &card, nil
+	return t.agentCard(ctx, false)
 }
 
-// Destroy cleans up resources (HTTP client doesn't need cleanup).
+// Destroy closes the QUIC connections of WithHTTP3; other HTTP clients
+// need no cleanup.
 func (t *DIDHTTPTransport) Destroy() error {
-	// HTTP client doesn't need explicit cleanup
+	if t.http3 != nil {
+		return t.http3.Close()
+	}
 	return nil
 }
@@ -27,39 +27,15 @@ import (
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
 	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
 	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-// init initializes crypto package for tests
-func init() {
-	// Register key generators
-	crypto.SetKeyGenerators(
-		func() (crypto.KeyPair, error) { return keys.GenerateEd25519KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateSecp256k1KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateP256KeyPair() },
-	)
-
-	// Register storage constructors
-	crypto.SetStorageConstructors(
-		func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() },
-	)
-
-	// Register format constructors
-	crypto.SetFormatConstructors(
-		func() crypto.KeyExporter { return formats.NewJWKExporter() },
-		func() crypto.KeyExporter { return formats.NewPEMExporter() },
-		func() crypto.KeyImporter { return formats.NewJWKImporter() },
-		func() crypto.KeyImporter { return formats.NewPEMImporter() },
-	)
-}
-
 // testCallInterceptor is a test implementation of CallInterceptor
 type testCallInterceptor struct {
 	called *bool
@@ -133,6 +109,30 @@ func TestNewDIDHTTPTransport(t *testing.T) {
 	assert.NotNil(t, transport.httpClient)
 }
 
+func TestNewDIDHTTPTransport_NotInitialized(t *testing.T) {
+	keyPair, err := crypto.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	agentDID := did.AgentDID("did:sage:ethereum:0x1234567890abcdef")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unsigned request sent")
+	}))
+	defer server.Close()
+
+	crypto.SetStorageConstructors(nil)
+	defer crypto.SetStorageConstructors(func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() })
+
+	_, err = NewDIDHTTPTransportForIdentity(nil, "agent", server.URL, nil)
+	assert.ErrorIs(t, err, cryptoinit.ErrNotInitialized)
+
+	card := &a2a.AgentCard{URL: server.URL, PreferredTransport: a2a.TransportProtocolJSONRPC}
+	_, err = NewDIDAuthenticatedClient(context.Background(), agentDID, keyPair, card)
+	assert.ErrorIs(t, err, cryptoinit.ErrNotInitialized)
+
+	transport := NewDIDHTTPTransport(server.URL, agentDID, keyPair, nil)
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	assert.ErrorIs(t, err, cryptoinit.ErrNotInitialized)
+}
+
 func TestDIDHTTPTransport_GetTask(t *testing.T) {
 	expectedTask := &a2a.Task{
 		ID: "task-123",
@@ -865,9 +865,12 @@ func TestDIDHTTPTransport_SendStreamingMessage_ContextCancellation(t *testing.T)
 		}
 	}
 
-	// Should have received context.Canceled error
+	// Should have received context.Canceled error, labeled with the method
 	assert.Error(t, lastError)
-	assert.Equal(t, context.Canceled, lastError)
+	assert.ErrorIs(t, lastError, context.Canceled)
+	var callErr *CallError
+	require.ErrorAs(t, lastError, &callErr)
+	assert.Equal(t, "message/stream", callErr.Method)
 	assert.GreaterOrEqual(t, eventCount, 5)
 }
 
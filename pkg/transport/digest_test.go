@@ -0,0 +1,59 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_DigestNegotiation(t *testing.T) {
+	var digests []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		digests = append(digests, r.Header.Get("Content-Digest"))
+		assert.NoError(t, signer.VerifyContentDigest(r.Header.Get("Content-Digest"), body, nil))
+
+		w.Header().Set("Want-Content-Digest", "sha-512=10, sha-256=1")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	}
+
+	transport, server := setupTestTransport(t, handler)
+	defer server.Close()
+
+	assert.Equal(t, signer.DigestSHA256, transport.DigestAlgorithm())
+
+	for i := 0; i < 2; i++ {
+		_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, digests, 2)
+	assert.True(t, strings.HasPrefix(digests[0], "sha-256=:"), digests[0])
+	assert.True(t, strings.HasPrefix(digests[1], "sha-512=:"), digests[1])
+	assert.Equal(t, signer.DigestSHA512, transport.DigestAlgorithm())
+}
@@ -56,6 +56,310 @@
 //	    a2aclient.WithInterceptors(loggingInterceptor),
 //	)
 //
+// # Interface Selection
+//
+// Agent cards may advertise AdditionalInterfaces next to the main URL. Use
+// NewDIDAuthenticatedClientWithFallback to try the PreferredTransport first and
+// fall back to the next reachable JSON-RPC interface:
+//
+//	client, iface, err := transport.NewDIDAuthenticatedClientWithFallback(
+//	    ctx, myDID, myKeyPair, agentCard, nil,
+//	)
+//	log.Printf("using %s", iface.URL)
+//
+// # Client Preferences
+//
+// a2aclient.Client does not pass its Config to transports. WithClientConfig
+// applies its AcceptedOutputModes and first push configuration to
+// message/send and message/stream calls; WithHistoryLength and WithBlocking
+// set the remaining MessageSendConfig fields (history length also applies to
+// tasks/get). Preferences set on a call take precedence:
+//
+//	transport.WithDIDHTTPTransport(myDID, myKeyPair, nil,
+//		transport.WithClientConfig(cfg), transport.WithHistoryLength(10))
+//
+// # Concurrent Streams
+//
+// StreamMultiplexer runs many streaming calls at once over a bounded number
+// of connections, with a channel per stream and aggregated errors:
+//
+//	mux := transport.NewStreamMultiplexer(t, 4)
+//	for _, task := range tasks {
+//	    s, _ := mux.ResubscribeToTask(ctx, &a2a.TaskIDParams{ID: task.ID})
+//	    go consume(s.Events())
+//	}
+//	if err := mux.Wait(); err != nil {
+//	    log.Printf("some streams failed: %v", err)
+//	}
+//
+// # Channel-Based Streaming
+//
+// SendStreamingMessageEvents and ResubscribeToTaskEvents return an EventStream
+// whose bounded channel can be used in select statements. A slow consumer
+// applies backpressure to the connection instead of buffering unboundedly:
+//
+//	stream := t.SendStreamingMessageEvents(ctx, params)
+//	defer stream.Close()
+//	for item := range stream.Events() {
+//	    if item.Err != nil {
+//	        return item.Err
+//	    }
+//	    handle(item.Event)
+//	}
+//
+// Neither the channel nor the caller needs range-over-func. The module
+// still requires Go 1.24, as a2a-go's transport interface is built on
+// iter.Seq2.
+//
+// # Streamed Event Formats
+//
+// Servers identify streamed events either by wrapping them under a key
+// ({"statusUpdate": ...}) or by the "kind" discriminator of the A2A
+// specification ({"kind": "status-update", ...}). The transport detects the
+// format of each event; WithEventFormat holds a strict server to one:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithEventFormat(transport.EventFormatKind))
+//
+// # Long Polling
+//
+// Where proxies block event streams, WithLongPollFallback switches
+// message/stream and tasks/resubscribe to signed long polls of
+// protocol.TaskEventsPath once an SSE request is refused. The message is
+// sent with a non-blocking message/send and the task's events are polled
+// with a cursor, each poll waiting up to the given time:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, httpClient,
+//	    transport.WithLongPollFallback(30*time.Second))
+//
+// PollTaskEvents fetches a single page of events.
+//
+// # Cancellation Propagation
+//
+// By default, cancelling the context of a streaming call only closes the
+// connection. With WithCancelOnContextDone the transport also sends a
+// best-effort tasks/cancel for the task being streamed, so the remote agent
+// stops working on it:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithCancelOnContextDone(0))
+//
+// # SPIFFE SVIDs
+//
+// Inside a service mesh, NewSVIDHTTPClient presents a SPIFFE SVID over mTLS
+// alongside the DID signature. getCertificate is called per handshake, so it
+// can return the latest rotated SVID from a workload API source:
+//
+//	httpClient := transport.NewSVIDHTTPClient(getSVID, bundle, "spiffe://cluster.local/ns/agents/sa/peer")
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, httpClient)
+//
+// # Method Timeouts
+//
+// Each call is bounded by a per-method timeout on top of the caller's
+// context, so leave http.Client.Timeout unset: it would also cut off
+// streams. DefaultMethodTimeouts does not limit message/stream,
+// tasks/resubscribe and long polls, gives tasks/get 5s and agent card
+// fetches 3s, and other methods DefaultMethodTimeout. WithMethodTimeout
+// overrides a method, or with an empty method the fallback:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithMethodTimeout("message/send", 5*time.Minute))
+//
+// The time left until the resulting deadline is sent in the signed
+// X-Request-Budget header, so servers can stop work the caller will no
+// longer wait for and pass the rest of the budget on to further agents.
+//
+// # Response Size Limits
+//
+// WithMaxResponseSize bounds how much a remote agent can make the client
+// read. The limit applies to decoded bytes, so compressed responses cannot
+// expand past it, and to each event of a streaming call. Exceeding it fails
+// with ErrResponseTooLarge:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//		transport.WithMaxResponseSize(10<<20))
+//
+// SizeStats reports requests and wire bytes sent and received per JSON-RPC
+// method.
+//
+// # Outbound Rate Limits
+//
+// WithRateLimiter caps the rate of signed requests per destination with a
+// token bucket, so a buggy loop cannot flood a partner agent. Destinations
+// are keyed by the target's DID when WithAgentCardSigner names it, otherwise
+// by base URL. Requests over the limit fail with ErrRateLimited, or wait
+// for a token when RateLimit.Wait is set; Stats reports per-destination
+// counters:
+//
+//	limiter := transport.NewOutboundLimiter(transport.RateLimit{Rate: 10, Burst: 20, Wait: true})
+//	limiter.SetLimit("https://slow.example.com", transport.RateLimit{Rate: 1})
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//		transport.WithRateLimiter(limiter))
+//
+// # Retrying Errors
+//
+// Non-200 responses are returned as *HTTPError. Structured error bodies
+// from the server package fill its Code, Retryable and RetryAfter fields;
+// IsRetryable tells callers whether and when to retry:
+//
+//	task, err := t.GetTask(ctx, query)
+//	if wait, ok := transport.IsRetryable(err); ok {
+//	    time.Sleep(wait)
+//	    task, err = t.GetTask(ctx, query)
+//	}
+//
+// Every JSON-RPC call error, streaming ones included, is a *CallError
+// naming the method, endpoint, HTTP status, JSON-RPC code and number of
+// requests sent, so failures can be counted per method without parsing
+// messages. The *HTTPError or *RPCError it wraps is still found with
+// errors.As:
+//
+//	var callErr *transport.CallError
+//	if errors.As(err, &callErr) {
+//	    failures.WithLabelValues(callErr.Method, strconv.Itoa(callErr.StatusCode)).Inc()
+//	}
+//
+// # Input-Required and Auth-Required Tasks
+//
+// Agents may pause a task to ask for more input or for credentials.
+// SendMessageInteractive answers such prompts through callbacks and
+// continues the task with the same task ID:
+//
+//	result, err := transport.SendMessageInteractive(ctx, t, params, transport.InputHandlers{
+//	    OnInputRequired: func(ctx context.Context, task *a2a.Task) (*a2a.Message, error) {
+//	        answer := ask(protocol.StatusText(task))
+//	        return a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: answer}), nil
+//	    },
+//	})
+//
+// # Agent Card Caching
+//
+// GetAgentCard fetches the well-known agent card on every call unless an
+// AgentCardCache is configured. A cache may be shared by many transports;
+// stale cards are revalidated with ETag / Last-Modified, and
+// RefreshAgentCard revalidates regardless of age. WithAgentCardVerifier
+// re-checks the card (e.g. its signatures) whenever its content changes:
+//
+//	cards := transport.NewAgentCardCache(10 * time.Minute)
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithAgentCardCache(cards),
+//	    transport.WithAgentCardVerifier(verifyCard))
+//
+// WithAgentCardSigner rejects cards that are not signed by the DID the
+// caller expects, so a substituted card is detected regardless of TLS:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithAgentCardSigner(peerDID, didVerifier.ResolvePublicKey))
+//
+// # Artifact Files
+//
+// UploadArtifact and DownloadArtifact transfer files to and from an agent
+// serving server.NewArtifactFileHandler. Uploads are sent in signed chunks
+// (WithArtifactChunkSize); calling either method again after a failure
+// resumes where the previous attempt stopped. Both check the transferred
+// file against the server's Repr-Digest:
+//
+//	digest, err := t.UploadArtifact(ctx, "report", file)
+//	n, err := t.DownloadArtifact(ctx, "report", dst)
+//
+// # Streamed Artifacts
+//
+// An ArtifactReader turns an artifact streamed in chunks, e.g. by a
+// server.ArtifactStreamWriter, back into an io.Reader of its content.
+// Events are pulled only as the content is read; other events go to a
+// callback. A stream ending before the last chunk fails with
+// ErrArtifactIncomplete:
+//
+//	r := t.StreamArtifact(ctx, params, "dump", func(event a2a.Event) error {
+//	    log.Printf("event: %T", event)
+//	    return nil
+//	})
+//	defer r.Close()
+//	_, err := io.Copy(dst, r)
+//
+// # Response Caching
+//
+// WithResponseCache caches the signed GET requests behind GetAgentCard and
+// DownloadArtifact according to the server's Cache-Control, Expires, ETag
+// and Last-Modified headers. Fresh responses are served without signing or
+// a round trip; only revalidation of stale responses is signed and sent:
+//
+//	responses := transport.NewResponseCache(0, 0)
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithResponseCache(responses))
+//
+// WithAgentCardCache takes precedence for agent cards when both are set.
+//
+// # Request Receipts
+//
+// WithReceiptStore verifies the receipts a server returns for processed
+// requests (see server.ReceiptHandler) and saves them as proof of
+// submission. Receipts are checked against the request body and, when
+// WithAgentCardSigner is set, the expected server DID:
+//
+//	receipts := transport.NewMemoryReceiptStore()
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithReceiptStore(receipts, didVerifier.ResolvePublicKey))
+//
+//	proofs, err := receipts.Receipts(ctx, taskID)
+//
+// # Message Digests
+//
+// WithMessageDigestHeader sends the protocol.MessageDigest of each
+// outgoing message in the signed A2A-Message-Digest header, so the server
+// can keep an integrity proof of the message content rather than only of
+// the HTTP bytes. Seal messages with protocol.MessageSealer for a
+// signature that travels with the message itself.
+//
+// # HTTP/3
+//
+// WithHTTP3 sends requests over HTTP/3 (QUIC) with quic-go; Destroy closes
+// its connections. Any other http.Client works as well. Signed requests
+// refused as 0-RTT early data (425 Too Early) are signed and sent once
+// more after the handshake:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil, transport.WithHTTP3(tlsConfig))
+//	defer t.Destroy()
+//
+// # Payload Codecs
+//
+// WithCodec encodes request bodies with a codec other than JSON, such as
+// codec.CBOR, and asks the server for responses and event streams in the
+// same encoding, falling back to JSON for servers without
+// server.CodecHandler. The signature's content-digest covers the encoded
+// bytes, and the Content-Type is signed with them:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithCodec(codec.CBOR))
+//
+// # Task Continuation
+//
+// ContinueTask delegates a task to the agent behind the transport,
+// presenting the protocol.TaskContext the originating agent exported in
+// the signed A2A-Task-Context header and sending the message under the
+// original context ID:
+//
+//	tc, err := protocol.ExportTaskContext(myDID, myKeyPair, task, peerDID, time.Hour)
+//	result, err := t.ContinueTask(ctx, tc, msg)
+//
+// # Peer Discovery
+//
+// ListPeers calls agents/listPeers on an agent. CrawlPeers discovers agents
+// transitively from seed transports, breadth first and up to MaxDepth
+// hops, verifying every card against its DID and only crawling through
+// peers accepted by Trusted:
+//
+//	found, err := transport.CrawlPeers(ctx, []transport.PeerLister{seed}, transport.PeerCrawlConfig{
+//	    Resolve: resolveKey,
+//	    Dial: func(ctx context.Context, peer protocol.Peer) (transport.PeerLister, error) {
+//	        return transport.NewDIDHTTPTransport(peer.Card.URL, myDID, keyPair, nil).(*transport.DIDHTTPTransport), nil
+//	    },
+//	    Trusted: func(peer protocol.Peer) bool { return allowlist[peer.DID] },
+//	})
+//
+// Peers that fail to list or verify are reported in err alongside those
+// found.
+//
 // # Architecture
 //
 // The transport layer sits between a2a-go's Client and the actual HTTP/HTTPS
@@ -0,0 +1,152 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// EventFormat selects how the result of a streamed JSON-RPC response is
+// decoded into an A2A event
+type EventFormat int
+
+const (
+	// EventFormatAuto detects the format of each event: results carrying a
+	// "kind" discriminator are decoded as EventFormatKind, others as
+	// EventFormatKeyed
+	EventFormatAuto EventFormat = iota
+
+	// EventFormatKeyed expects the event wrapped under a key naming its type:
+	// {"message": ...}, {"task": ...}, {"statusUpdate": ...} or
+	// {"artifactUpdate": ...}
+	EventFormatKeyed
+
+	// EventFormatKind expects the event itself with the "kind" discriminator
+	// of the A2A specification: "message", "task", "status-update" or
+	// "artifact-update"
+	EventFormatKind
+)
+
+// String returns the name of the format
+func (f EventFormat) String() string {
+	switch f {
+	case EventFormatAuto:
+		return "auto"
+	case EventFormatKeyed:
+		return "keyed"
+	case EventFormatKind:
+		return "kind"
+	default:
+		return fmt.Sprintf("EventFormat(%d)", int(f))
+	}
+}
+
+// WithEventFormat forces the format of streamed events. The default,
+// EventFormatAuto, accepts both; strict servers can be held to one so a
+// result in the other format is reported as an error.
+func WithEventFormat(format EventFormat) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.eventFormat = format
+	}
+}
+
+// decodeEvent decodes a streamed JSON-RPC result in the given format
+func decodeEvent(result json.RawMessage, format EventFormat) (a2a.Event, error) {
+	switch format {
+	case EventFormatKeyed:
+		return decodeKeyedEvent(result)
+	case EventFormatKind:
+		return decodeKindEvent(result)
+	}
+
+	var probe struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(result, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse SSE result structure: %w", err)
+	}
+	if probe.Kind != "" {
+		return decodeKindEvent(result)
+	}
+	return decodeKeyedEvent(result)
+}
+
+// decodeKindEvent decodes an event identified by its "kind" field
+func decodeKindEvent(result json.RawMessage) (a2a.Event, error) {
+	var probe struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(result, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse SSE result structure: %w", err)
+	}
+
+	switch probe.Kind {
+	case "message":
+		return decodeEventAs[a2a.Message](result, "Message")
+	case "task":
+		return decodeEventAs[a2a.Task](result, "Task")
+	case "status-update":
+		return decodeEventAs[a2a.TaskStatusUpdateEvent](result, "TaskStatusUpdateEvent")
+	case "artifact-update":
+		return decodeEventAs[a2a.TaskArtifactUpdateEvent](result, "TaskArtifactUpdateEvent")
+	case "":
+		return nil, fmt.Errorf("unknown SSE event type in result: missing kind")
+	default:
+		return nil, fmt.Errorf("unknown SSE event type in result: kind %q", probe.Kind)
+	}
+}
+
+// decodeKeyedEvent decodes an event wrapped under a key naming its type
+func decodeKeyedEvent(result json.RawMessage) (a2a.Event, error) {
+	var keyed struct {
+		Message        json.RawMessage `json:"message"`
+		Task           json.RawMessage `json:"task"`
+		StatusUpdate   json.RawMessage `json:"statusUpdate"`
+		ArtifactUpdate json.RawMessage `json:"artifactUpdate"`
+	}
+	if err := json.Unmarshal(result, &keyed); err != nil {
+		return nil, fmt.Errorf("failed to parse SSE result structure: %w", err)
+	}
+
+	switch {
+	case keyed.Message != nil:
+		return decodeEventAs[a2a.Message](keyed.Message, "Message")
+	case keyed.Task != nil:
+		return decodeEventAs[a2a.Task](keyed.Task, "Task")
+	case keyed.StatusUpdate != nil:
+		return decodeEventAs[a2a.TaskStatusUpdateEvent](keyed.StatusUpdate, "TaskStatusUpdateEvent")
+	case keyed.ArtifactUpdate != nil:
+		return decodeEventAs[a2a.TaskArtifactUpdateEvent](keyed.ArtifactUpdate, "TaskArtifactUpdateEvent")
+	}
+	return nil, fmt.Errorf("unknown SSE event type in result")
+}
+
+// decodeEventAs decodes data into a new T
+func decodeEventAs[T any, P interface {
+	*T
+	a2a.Event
+}](data []byte, name string) (a2a.Event, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse %s from SSE: %w", name, err)
+	}
+	return P(&v), nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withKind adds the A2A "kind" discriminator to the JSON encoding of v
+func withKind(t *testing.T, kind string, v any) map[string]any {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(data, &m))
+	m["kind"] = kind
+	return m
+}
+
+// streamResults serves each result as one SSE event
+func streamResults(results ...any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, result := range results {
+			rpcResp, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+			fmt.Fprintf(w, "data: %s\n\n", rpcResp)
+		}
+	}
+}
+
+func collectEvents(transport *DIDHTTPTransport) ([]a2a.Event, error) {
+	params := &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "Test"})}
+	var events []a2a.Event
+	for event, err := range transport.SendStreamingMessage(context.Background(), params) {
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func TestEventFormat_Auto(t *testing.T) {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	status := &a2a.TaskStatusUpdateEvent{TaskID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}, Final: true}
+	artifact := &a2a.TaskArtifactUpdateEvent{TaskID: "task-1", Artifact: &a2a.Artifact{ID: "a-1"}}
+	msg := &a2a.Message{ID: "msg-1", Role: a2a.MessageRoleAgent, Parts: []a2a.Part{&a2a.TextPart{Text: "hi"}}}
+
+	transport, server := setupTestTransport(t, streamResults(
+		withKind(t, "task", task),
+		withKind(t, "artifact-update", artifact),
+		map[string]any{"message": msg},
+		withKind(t, "status-update", status),
+	))
+	defer server.Close()
+
+	events, err := collectEvents(transport)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, a2a.TaskID("task-1"), events[0].(*a2a.Task).ID)
+	assert.Equal(t, "a-1", string(events[1].(*a2a.TaskArtifactUpdateEvent).Artifact.ID))
+	assert.Equal(t, "msg-1", events[2].(*a2a.Message).ID)
+	assert.True(t, events[3].(*a2a.TaskStatusUpdateEvent).Final)
+}
+
+func TestEventFormat_Forced(t *testing.T) {
+	status := &a2a.TaskStatusUpdateEvent{TaskID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}
+
+	t.Run("kind rejects keyed results", func(t *testing.T) {
+		transport, server := setupTestTransport(t, streamResults(map[string]any{"statusUpdate": status}))
+		defer server.Close()
+		WithEventFormat(EventFormatKind)(transport)
+
+		_, err := collectEvents(transport)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing kind")
+	})
+
+	t.Run("keyed rejects kind results", func(t *testing.T) {
+		transport, server := setupTestTransport(t, streamResults(withKind(t, "status-update", status)))
+		defer server.Close()
+		WithEventFormat(EventFormatKeyed)(transport)
+
+		_, err := collectEvents(transport)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown SSE event type")
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		transport, server := setupTestTransport(t, streamResults(map[string]any{"kind": "heartbeat"}))
+		defer server.Close()
+
+		_, err := collectEvents(transport)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `kind "heartbeat"`)
+	})
+}
+
+func TestEventFormat_String(t *testing.T) {
+	assert.Equal(t, "auto", EventFormatAuto.String())
+	assert.Equal(t, "keyed", EventFormatKeyed.String())
+	assert.Equal(t, "kind", EventFormatKind.String())
+}
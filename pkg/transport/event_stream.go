@@ -0,0 +1,135 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// DefaultEventBufferSize is the channel buffer used by the *Events methods
+const DefaultEventBufferSize = 16
+
+// EventOrError is a single item of an EventStream
+type EventOrError struct {
+	Event a2a.Event
+	Err   error
+}
+
+// EventStream exposes a streaming response as a channel, for code that needs
+// select-based composition or predates range-over-func iterators.
+//
+// The channel is bounded: when the consumer falls behind, the producer stops
+// reading from the connection, so backpressure reaches the server through
+// TCP flow control instead of buffering without limit.
+type EventStream struct {
+	events chan EventOrError
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewEventStream pumps seq into a channel with the given buffer size.
+// A bufferSize of 0 creates an unbuffered channel.
+func NewEventStream(ctx context.Context, seq func(context.Context) iter.Seq2[a2a.Event, error], bufferSize int) *EventStream {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &EventStream{
+		events: make(chan EventOrError, bufferSize),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		defer close(s.events)
+
+		for event, err := range seq(ctx) {
+			select {
+			case s.events <- EventOrError{Event: event, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return s
+}
+
+// Events returns the event channel. It is closed after the last event, after
+// an error has been delivered, or once Close is called.
+func (s *EventStream) Events() <-chan EventOrError {
+	return s.events
+}
+
+// Done is closed once the stream has ended and the connection is released
+func (s *EventStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops the stream, releases the underlying connection and waits for
+// the producer to exit. It is safe to call Close multiple times.
+func (s *EventStream) Close() error {
+	s.once.Do(s.cancel)
+	// Drain so the producer is never blocked on a send
+	for range s.events {
+	}
+	<-s.done
+	return nil
+}
+
+// SendStreamingMessageEvents is the channel-based variant of SendStreamingMessage
+//
+// Example:
+//
+//	stream := t.SendStreamingMessageEvents(ctx, params)
+//	defer stream.Close()
+//	for {
+//	    select {
+//	    case item, ok := <-stream.Events():
+//	        if !ok {
+//	            return nil
+//	        }
+//	        if item.Err != nil {
+//	            return item.Err
+//	        }
+//	        handle(item.Event)
+//	    case <-shutdown:
+//	        return nil
+//	    }
+//	}
+func (t *DIDHTTPTransport) SendStreamingMessageEvents(ctx context.Context, message *a2a.MessageSendParams) *EventStream {
+	return NewEventStream(ctx, func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return t.SendStreamingMessage(ctx, message)
+	}, DefaultEventBufferSize)
+}
+
+// ResubscribeToTaskEvents is the channel-based variant of ResubscribeToTask
+func (t *DIDHTTPTransport) ResubscribeToTaskEvents(ctx context.Context, id *a2a.TaskIDParams) *EventStream {
+	return NewEventStream(ctx, func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return t.ResubscribeToTask(ctx, id)
+	}, DefaultEventBufferSize)
+}
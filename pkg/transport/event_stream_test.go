@@ -0,0 +1,110 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_SendStreamingMessageEvents(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < 3; i++ {
+			resp, _ := json.Marshal(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"result":  map[string]interface{}{"message": &a2a.Message{ID: fmt.Sprintf("msg-%d", i)}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", resp)
+			w.(http.Flusher).Flush()
+		}
+	}
+
+	transport, server := setupTestTransport(t, handler)
+	defer server.Close()
+
+	stream := transport.SendStreamingMessageEvents(context.Background(), &a2a.MessageSendParams{
+		Message: &a2a.Message{Role: a2a.MessageRoleUser},
+	})
+	defer stream.Close()
+
+	var ids []string
+	for item := range stream.Events() {
+		require.NoError(t, item.Err)
+		ids = append(ids, item.Event.(*a2a.Message).ID)
+	}
+	assert.Equal(t, []string{"msg-0", "msg-1", "msg-2"}, ids)
+	<-stream.Done()
+}
+
+func TestEventStream_Backpressure(t *testing.T) {
+	var produced atomic.Int32
+	seq := func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return func(yield func(a2a.Event, error) bool) {
+			for i := 0; i < 100; i++ {
+				produced.Add(1)
+				if !yield(&a2a.Message{}, nil) {
+					return
+				}
+			}
+		}
+	}
+
+	stream := NewEventStream(context.Background(), seq, 2)
+	time.Sleep(20 * time.Millisecond)
+
+	// Buffer of 2 plus one event blocked in the send
+	assert.LessOrEqual(t, produced.Load(), int32(3))
+
+	require.NoError(t, stream.Close())
+	assert.Less(t, produced.Load(), int32(100))
+}
+
+func TestEventStream_ErrorTerminatesStream(t *testing.T) {
+	boom := errors.New("boom")
+	seq := func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return func(yield func(a2a.Event, error) bool) {
+			if !yield(nil, boom) {
+				return
+			}
+			yield(&a2a.Message{}, nil)
+		}
+	}
+
+	stream := NewEventStream(context.Background(), seq, 0)
+
+	item := <-stream.Events()
+	assert.ErrorIs(t, item.Err, boom)
+	_, ok := <-stream.Events()
+	assert.False(t, ok)
+	assert.NoError(t, stream.Close())
+	assert.NoError(t, stream.Close())
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
+	"github.com/sage-x-project/sage-a2a-go/pkg/identity"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
@@ -31,12 +33,15 @@ import (
 // HTTP/JSON-RPC 2.0 transport for a2a-go clients.
 //
 // This transport automatically signs all HTTP requests with the agent's DID
-// using RFC 9421 HTTP Message Signatures.
+// using RFC 9421 HTTP Message Signatures. Creating it fails with
+// cryptoinit.ErrNotInitialized if the sage crypto constructors are not
+// registered.
 //
 // Parameters:
 //   - agentDID: Your agent's DID for signing requests
 //   - keyPair: Your agent's private key for signing
 //   - httpClient: Optional HTTP client (nil to use http.DefaultClient)
+//   - opts: Optional transport behavior applied to every created transport
 //
 // Example:
 //
@@ -56,11 +61,15 @@ func WithDIDHTTPTransport(
 	agentDID did.AgentDID,
 	keyPair crypto.KeyPair,
 	httpClient *http.Client,
+	opts ...TransportOption,
 ) a2aclient.FactoryOption {
 	return a2aclient.WithTransport(
 		a2a.TransportProtocolJSONRPC,
 		a2aclient.TransportFactoryFn(func(ctx context.Context, url string, card *a2a.AgentCard) (a2aclient.Transport, error) {
-			return NewDIDHTTPTransport(url, agentDID, keyPair, httpClient), nil
+			if err := cryptoinit.Check(); err != nil {
+				return nil, err
+			}
+			return NewDIDHTTPTransport(url, agentDID, keyPair, httpClient, opts...), nil
 		}),
 	)
 }
@@ -128,6 +137,16 @@ func NewDIDAuthenticatedClient(
 	)
 }
 
+// NewDIDAuthenticatedClientFromIdentity is like NewDIDAuthenticatedClient
+// but signs as id.
+func NewDIDAuthenticatedClientFromIdentity(
+	ctx context.Context,
+	id *identity.Identity,
+	card *a2a.AgentCard,
+) (*a2aclient.Client, error) {
+	return NewDIDAuthenticatedClient(ctx, id.DID, id.KeyPair, card)
+}
+
 // NewDIDAuthenticatedClientWithConfig is like NewDIDAuthenticatedClient but
 // allows specifying a custom Config.
 func NewDIDAuthenticatedClientWithConfig(
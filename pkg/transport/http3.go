@@ -0,0 +1,69 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+)
+
+// WithHTTP3 sends requests over HTTP/3 (QUIC) with quic-go, replacing the
+// transport of the HTTP client but keeping its timeout. A nil tlsConfig
+// verifies servers against the system roots. Destroy closes the QUIC
+// connections.
+func WithHTTP3(tlsConfig *tls.Config) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		h3 := &http3.Transport{TLSClientConfig: tlsConfig}
+		client := *t.httpClient
+		client.Transport = h3
+		t.httpClient = &client
+		t.http3 = h3
+	}
+}
+
+// doRPC signs and sends a JSON-RPC request for method. HTTP/3 clients may
+// send a request in 0-RTT early data, which servers refuse for signed
+// requests with 425 Too Early (see server.EarlyDataConfig); such a request
+// is signed and sent once more, after the handshake completed (RFC 8470).
+// attempts is the number of requests sent; SizeStats counts the call once.
+func (t *DIDHTTPTransport) doRPC(ctx context.Context, method string, body []byte, accept string) (resp *http.Response, attempts int, err error) {
+	for attempt := 0; ; attempt++ {
+		req, err := t.newRPCRequest(ctx, body, accept)
+		if err != nil {
+			return nil, attempt, err
+		}
+		if attempt == 0 {
+			t.recordRequest(method, req.ContentLength)
+		}
+
+		resp, err := t.httpClient.Do(req)
+		if err != nil {
+			return nil, attempt + 1, fmt.Errorf("HTTP request failed: %w", err)
+		}
+		if resp.StatusCode != http.StatusTooEarly || attempt > 0 {
+			return resp, attempt + 1, nil
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_RetriesTooEarly(t *testing.T) {
+	var (
+		signatures []string
+		refuse     int
+	)
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get("Signature"))
+		if len(signatures) <= refuse {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooEarly)
+			w.Write([]byte(`{"code":"too_early","message":"Too Early","retryable":true}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	})
+	defer server.Close()
+
+	// A request refused as early data is signed and sent again
+	refuse = 1
+	task, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-1"), task.ID)
+	require.Len(t, signatures, 2)
+	assert.Equal(t, uint64(1), transport.SizeStats()["tasks/get"].Requests)
+
+	// but only once
+	signatures, refuse = nil, 2
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusTooEarly, httpErr.StatusCode)
+	_, retryable := IsRetryable(err)
+	assert.True(t, retryable)
+	assert.Len(t, signatures, 2)
+}
+
+func TestDIDHTTPTransport_HTTP3(t *testing.T) {
+	// Borrow the test certificate of httptest, valid for 127.0.0.1
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+
+	var proto, signature string
+	srv := &http3.Server{
+		TLSConfig: tlsServer.TLS,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proto, signature = r.Proto, r.Header.Get("Signature")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+		}),
+	}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(conn)
+	defer srv.Close()
+
+	keyPair, err := crypto.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	clientTLS := tlsServer.Client().Transport.(*http.Transport).TLSClientConfig
+	transport := NewDIDHTTPTransport("https://"+conn.LocalAddr().String(), "did:sage:ethereum:0x1234567890abcdef", keyPair, nil, WithHTTP3(clientTLS))
+	defer transport.Destroy()
+
+	task, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-1"), task.ID)
+	assert.Equal(t, "HTTP/3.0", proto)
+	assert.NotEmpty(t, signature)
+	assert.Nil(t, http.DefaultClient.Transport, "the default client is not modified")
+}
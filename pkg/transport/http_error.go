@@ -0,0 +1,150 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// maxErrorBodySize bounds the error bodies read from streaming and agent
+// card responses
+const maxErrorBodySize = 64 << 10
+
+// HTTPError is returned for non-200 responses. Structured error bodies
+// (protocol.ErrorBody) fill Code, Retryable and RetryAfter; for other
+// bodies 425, 429 and 503 responses are treated as retryable and
+// RetryAfter is taken from the Retry-After header.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Code       string // protocol.ErrorCode* if the server sent one
+	Message    string
+	Retryable  bool
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *HTTPError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("HTTP error: %d %s", e.StatusCode, e.Status)
+	}
+	return fmt.Sprintf("HTTP error: %d %s: %s", e.StatusCode, e.Status, e.Message)
+}
+
+// RPCError is a JSON-RPC error returned by the remote agent
+type RPCError struct {
+	Code    int
+	Message string
+}
+
+// Error implements error
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// CallError wraps every error of a JSON-RPC call with the method and
+// endpoint called, so failures can be aggregated by method. The underlying
+// *HTTPError, *RPCError or network error remains available through
+// errors.As and errors.Is.
+type CallError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int // HTTP status, 0 if no response was received
+	RPCCode    int // JSON-RPC error code, 0 if none was returned
+	Attempts   int // HTTP requests sent
+	Err        error
+}
+
+// Error implements error
+func (e *CallError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Method, e.Endpoint, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *CallError) Unwrap() error {
+	return e.Err
+}
+
+// callError wraps err, if any, in a *CallError for method
+func (t *DIDHTTPTransport) callError(method string, status, attempts int, err error) error {
+	if err == nil {
+		return nil
+	}
+	var callErr *CallError
+	if errors.As(err, &callErr) {
+		return err
+	}
+	callErr = &CallError{
+		Method:     method,
+		Endpoint:   t.baseURL + "/rpc",
+		StatusCode: status,
+		Attempts:   attempts,
+		Err:        err,
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		callErr.RPCCode = rpcErr.Code
+	}
+	return callErr
+}
+
+// IsRetryable reports whether err is an HTTPError the request may be
+// retried after, and how long to wait first
+func IsRetryable(err error) (time.Duration, bool) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || !httpErr.Retryable {
+		return 0, false
+	}
+	return httpErr.RetryAfter, true
+}
+
+// newHTTPError classifies a non-200 response with the given body
+func newHTTPError(resp *http.Response, body []byte) *HTTPError {
+	e := &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	var structured protocol.ErrorBody
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") &&
+		json.Unmarshal(body, &structured) == nil && structured.Code != "" {
+		e.Code = structured.Code
+		e.Message = structured.Message
+		e.Retryable = structured.Retryable
+		e.RetryAfter = time.Duration(structured.RetryAfter) * time.Second
+		return e
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusTooEarly:
+		e.Retryable = true
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			e.RetryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+	return e
+}
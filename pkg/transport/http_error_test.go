@@ -0,0 +1,184 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPError_StructuredBody(t *testing.T) {
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_ = json.NewEncoder(w).Encode(protocol.ErrorBody{
+			Code:       protocol.ErrorCodeRateLimited,
+			Message:    "Too Many Requests: quota exceeded",
+			Retryable:  true,
+			RetryAfter: 7,
+		})
+	})
+	defer server.Close()
+
+	_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr), err)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, protocol.ErrorCodeRateLimited, httpErr.Code)
+	assert.Equal(t, "Too Many Requests: quota exceeded", httpErr.Message)
+	assert.Contains(t, err.Error(), "HTTP error: 429")
+
+	wait, ok := IsRetryable(err)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+}
+
+func TestHTTPError_Classification(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		header    http.Header
+		body      string
+		retryable bool
+		wait      time.Duration
+	}{
+		{"structured not retryable", http.StatusUnauthorized, http.Header{"Content-Type": {"application/json"}},
+			`{"code":"unauthenticated","message":"Unauthorized: bad signature","retryable":false}`, false, 0},
+		{"plain 503 with Retry-After", http.StatusServiceUnavailable, http.Header{"Retry-After": {"3"}}, "busy", true, 3 * time.Second},
+		{"plain 429", http.StatusTooManyRequests, nil, "slow down", true, 0},
+		{"plain 403", http.StatusForbidden, nil, "Forbidden", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status), Header: tt.header}
+			if resp.Header == nil {
+				resp.Header = http.Header{}
+			}
+			err := newHTTPError(resp, []byte(tt.body))
+			wait, ok := IsRetryable(err)
+			assert.Equal(t, tt.retryable, ok)
+			assert.Equal(t, tt.wait, wait)
+		})
+	}
+
+	_, ok := IsRetryable(errors.New("connection refused"))
+	assert.False(t, ok)
+}
+
+func TestHTTPError_Streaming(t *testing.T) {
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(protocol.ErrorBody{Code: protocol.ErrorCodeUnavailable, Retryable: true, RetryAfter: 1})
+	})
+	defer server.Close()
+
+	_, err := collectEvents(transport)
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr), err)
+	assert.Equal(t, protocol.ErrorCodeUnavailable, httpErr.Code)
+	wait, ok := IsRetryable(err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, wait)
+}
+
+func TestCallError(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("JSON-RPC error", func(t *testing.T) {
+		transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"Task not found"}}`))
+		})
+		defer server.Close()
+
+		_, err := transport.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+		var callErr *CallError
+		require.True(t, errors.As(err, &callErr), err)
+		assert.Equal(t, "tasks/get", callErr.Method)
+		assert.Equal(t, server.URL+"/rpc", callErr.Endpoint)
+		assert.Equal(t, http.StatusOK, callErr.StatusCode)
+		assert.Equal(t, -32001, callErr.RPCCode)
+		assert.Equal(t, 1, callErr.Attempts)
+		var rpcErr *RPCError
+		assert.True(t, errors.As(err, &rpcErr))
+		assert.Equal(t, "tasks/get "+server.URL+"/rpc: JSON-RPC error -32001: Task not found", err.Error())
+	})
+
+	t.Run("HTTP error after early data retry", func(t *testing.T) {
+		requests := 0
+		transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.WriteHeader(http.StatusTooEarly)
+				return
+			}
+			w.WriteHeader(http.StatusBadGateway)
+		})
+		defer server.Close()
+
+		_, err := transport.CancelTask(ctx, &a2a.TaskIDParams{ID: "task-1"})
+		var callErr *CallError
+		require.True(t, errors.As(err, &callErr), err)
+		assert.Equal(t, "tasks/cancel", callErr.Method)
+		assert.Equal(t, http.StatusBadGateway, callErr.StatusCode)
+		assert.Zero(t, callErr.RPCCode)
+		assert.Equal(t, 2, callErr.Attempts)
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+	})
+
+	t.Run("streaming HTTP error", func(t *testing.T) {
+		transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		defer server.Close()
+
+		stream := transport.ResubscribeToTaskEvents(ctx, &a2a.TaskIDParams{ID: "task-1"})
+		defer stream.Close()
+		item := <-stream.Events()
+		err := item.Err
+		var callErr *CallError
+		require.True(t, errors.As(err, &callErr), err)
+		assert.Equal(t, "tasks/resubscribe", callErr.Method)
+		assert.Equal(t, http.StatusServiceUnavailable, callErr.StatusCode)
+		_, retryable := IsRetryable(err)
+		assert.True(t, retryable)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {})
+		server.Close()
+
+		_, err := transport.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+		var callErr *CallError
+		require.True(t, errors.As(err, &callErr), err)
+		assert.Zero(t, callErr.StatusCode)
+		assert.Equal(t, 1, callErr.Attempts)
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// DefaultMaxInputTurns bounds how often SendMessageInteractive answers a
+// paused task
+const DefaultMaxInputTurns = 10
+
+// ErrInputTurnsExceeded is returned when a task keeps asking for input
+// beyond InputHandlers.MaxTurns
+var ErrInputTurnsExceeded = errors.New("task still requires input")
+
+// MessageSender sends 'message/send' requests; a2aclient.Transport
+// implementations such as DIDHTTPTransport satisfy it
+type MessageSender interface {
+	SendMessage(ctx context.Context, message *a2a.MessageSendParams) (a2a.SendMessageResult, error)
+}
+
+// InputFunc answers a paused task. The prompt is available through
+// protocol.StatusText(task). The returned message is sent as the follow-up;
+// its task and context IDs are filled in. Returning a nil message stops and
+// leaves the task paused.
+type InputFunc func(ctx context.Context, task *a2a.Task) (*a2a.Message, error)
+
+// InputHandlers answer tasks paused in the input-required and
+// auth-required states
+type InputHandlers struct {
+	// OnInputRequired supplies additional input
+	OnInputRequired InputFunc
+
+	// OnAuthRequired supplies credentials
+	OnAuthRequired InputFunc
+
+	// MaxTurns limits the number of follow-up messages (default DefaultMaxInputTurns)
+	MaxTurns int
+}
+
+// SendMessageInteractive sends params and, while the resulting task is
+// paused for input or authorization, asks the matching handler for a reply
+// and continues the task with it. It returns the first result that is not
+// a paused task, or the paused task when no handler is set or a handler
+// returns a nil message.
+func SendMessageInteractive(ctx context.Context, sender MessageSender, params *a2a.MessageSendParams, handlers InputHandlers) (a2a.SendMessageResult, error) {
+	maxTurns := handlers.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = DefaultMaxInputTurns
+	}
+
+	for turn := 0; ; turn++ {
+		result, err := sender.SendMessage(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		task, ok := result.(*a2a.Task)
+		if !ok || !protocol.NeedsInput(task) {
+			return result, nil
+		}
+
+		handler := handlers.OnInputRequired
+		if task.Status.State == a2a.TaskStateAuthRequired {
+			handler = handlers.OnAuthRequired
+		}
+		if handler == nil {
+			return task, nil
+		}
+		if turn >= maxTurns {
+			return task, fmt.Errorf("%w: %s after %d turns", ErrInputTurnsExceeded, task.ID, maxTurns)
+		}
+
+		reply, err := handler(ctx, task)
+		if err != nil {
+			return task, fmt.Errorf("failed to supply %s: %w", task.Status.State, err)
+		}
+		if reply == nil {
+			return task, nil
+		}
+
+		next := *reply
+		if next.ID == "" {
+			next.ID = a2a.NewMessageID()
+		}
+		if next.Role == "" {
+			next.Role = a2a.MessageRoleUser
+		}
+		next.TaskID = task.ID
+		next.ContextID = task.ContextID
+		params = &a2a.MessageSendParams{Message: &next, Config: params.Config, Metadata: params.Metadata}
+	}
+}
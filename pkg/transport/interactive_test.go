@@ -0,0 +1,115 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedSender returns results in order and records sent messages
+type scriptedSender struct {
+	results []a2a.SendMessageResult
+	sent    []*a2a.Message
+}
+
+func (s *scriptedSender) SendMessage(ctx context.Context, params *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	s.sent = append(s.sent, params.Message)
+	result := s.results[0]
+	s.results = s.results[1:]
+	return result, nil
+}
+
+func pausedTask(state a2a.TaskState, prompt string) *a2a.Task {
+	task := &a2a.Task{ID: "task-1", ContextID: "ctx-1"}
+	task.Status = a2a.TaskStatus{State: state, Message: a2a.NewMessageForTask(a2a.MessageRoleAgent, task, a2a.TextPart{Text: prompt})}
+	return task
+}
+
+func TestSendMessageInteractive(t *testing.T) {
+	done := &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}
+	sender := &scriptedSender{results: []a2a.SendMessageResult{
+		pausedTask(a2a.TaskStateInputRequired, "Which date?"),
+		pausedTask(a2a.TaskStateAuthRequired, "Sign in"),
+		done,
+	}}
+
+	var prompts []string
+	handlers := InputHandlers{
+		OnInputRequired: func(ctx context.Context, task *a2a.Task) (*a2a.Message, error) {
+			prompts = append(prompts, protocol.StatusText(task))
+			return &a2a.Message{Parts: a2a.ContentParts{a2a.TextPart{Text: "May 1st"}}}, nil
+		},
+		OnAuthRequired: func(ctx context.Context, task *a2a.Task) (*a2a.Message, error) {
+			prompts = append(prompts, protocol.StatusText(task))
+			return &a2a.Message{Metadata: map[string]any{"token": "secret"}}, nil
+		},
+	}
+
+	params := &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "book a flight"})}
+	result, err := SendMessageInteractive(context.Background(), sender, params, handlers)
+	require.NoError(t, err)
+	assert.Equal(t, done, result)
+	assert.Equal(t, []string{"Which date?", "Sign in"}, prompts)
+
+	require.Len(t, sender.sent, 3)
+	for _, msg := range sender.sent[1:] {
+		assert.Equal(t, a2a.TaskID("task-1"), msg.TaskID)
+		assert.Equal(t, "ctx-1", msg.ContextID)
+		assert.Equal(t, a2a.MessageRoleUser, msg.Role)
+		assert.NotEmpty(t, msg.ID)
+	}
+}
+
+func TestSendMessageInteractive_Stops(t *testing.T) {
+	params := &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser)}
+
+	// No handler for the state: the paused task is returned
+	sender := &scriptedSender{results: []a2a.SendMessageResult{pausedTask(a2a.TaskStateAuthRequired, "Sign in")}}
+	result, err := SendMessageInteractive(context.Background(), sender, params, InputHandlers{})
+	require.NoError(t, err)
+	assert.True(t, protocol.NeedsInput(result.(*a2a.Task)))
+
+	// Handler errors are returned with the paused task
+	sender = &scriptedSender{results: []a2a.SendMessageResult{pausedTask(a2a.TaskStateInputRequired, "?")}}
+	_, err = SendMessageInteractive(context.Background(), sender, params, InputHandlers{
+		OnInputRequired: func(ctx context.Context, task *a2a.Task) (*a2a.Message, error) {
+			return nil, errors.New("user went away")
+		},
+	})
+	assert.ErrorContains(t, err, "user went away")
+
+	// Endless prompts hit the turn limit
+	sender = &scriptedSender{results: []a2a.SendMessageResult{
+		pausedTask(a2a.TaskStateInputRequired, "?"),
+		pausedTask(a2a.TaskStateInputRequired, "?"),
+	}}
+	_, err = SendMessageInteractive(context.Background(), sender, params, InputHandlers{
+		MaxTurns: 1,
+		OnInputRequired: func(ctx context.Context, task *a2a.Task) (*a2a.Message, error) {
+			return &a2a.Message{}, nil
+		},
+	})
+	assert.ErrorIs(t, err, ErrInputTurnsExceeded)
+}
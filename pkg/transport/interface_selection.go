@@ -0,0 +1,168 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ProbeFunc checks whether an agent interface is reachable.
+// It should only fail on connection-level errors; any HTTP response
+// (including 4xx/5xx) means the endpoint is up.
+type ProbeFunc func(ctx context.Context, iface a2a.AgentInterface) error
+
+// HTTPProbe returns a ProbeFunc that sends an unsigned HEAD request to the
+// interface URL using httpClient (nil to use http.DefaultClient).
+func HTTPProbe(httpClient *http.Client) ProbeFunc {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return func(ctx context.Context, iface a2a.AgentInterface) error {
+		req, err := http.NewRequestWithContext(ctx, "HEAD", iface.URL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create probe request: %w", err)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", iface.URL, err)
+		}
+		resp.Body.Close()
+
+		return nil
+	}
+}
+
+// CardInterfaces returns the interfaces advertised by the card in preference
+// order: the main URL with PreferredTransport (JSON-RPC if unset) first,
+// followed by AdditionalInterfaces. Duplicate entries are dropped.
+func CardInterfaces(card *a2a.AgentCard) []a2a.AgentInterface {
+	if card == nil {
+		return nil
+	}
+
+	preferred := card.PreferredTransport
+	if preferred == "" {
+		preferred = a2a.TransportProtocolJSONRPC
+	}
+
+	ifaces := make([]a2a.AgentInterface, 0, 1+len(card.AdditionalInterfaces))
+	seen := make(map[a2a.AgentInterface]bool)
+
+	add := func(iface a2a.AgentInterface) {
+		if iface.URL == "" || seen[iface] {
+			return
+		}
+		seen[iface] = true
+		ifaces = append(ifaces, iface)
+	}
+
+	add(a2a.AgentInterface{Transport: preferred, URL: card.URL})
+	for _, iface := range card.AdditionalInterfaces {
+		add(iface)
+	}
+
+	return ifaces
+}
+
+// SelectInterface walks the card's JSON-RPC interfaces in preference order
+// and returns the first one that passes probe. If probe is nil,
+// HTTPProbe(nil) is used.
+//
+// Interfaces using other transports are skipped since DIDHTTPTransport
+// only speaks JSON-RPC.
+func SelectInterface(ctx context.Context, card *a2a.AgentCard, probe ProbeFunc) (a2a.AgentInterface, error) {
+	if probe == nil {
+		probe = HTTPProbe(nil)
+	}
+
+	var failures []error
+	for _, iface := range CardInterfaces(card) {
+		if iface.Transport != a2a.TransportProtocolJSONRPC {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return a2a.AgentInterface{}, fmt.Errorf("context error: %w", err)
+		}
+		if err := probe(ctx, iface); err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		return iface, nil
+	}
+
+	if len(failures) == 0 {
+		return a2a.AgentInterface{}, fmt.Errorf("no JSON-RPC interface advertised by agent card")
+	}
+	return a2a.AgentInterface{}, fmt.Errorf("no reachable JSON-RPC interface: %w", errors.Join(failures...))
+}
+
+// NewDIDAuthenticatedClientWithFallback creates a DID-authenticated client
+// that honours the card's PreferredTransport and AdditionalInterfaces.
+//
+// The preferred interface is tried first; if it cannot be reached the next
+// JSON-RPC interface is used instead. The selected interface is returned
+// alongside the client so callers can log or pin it.
+//
+// Example:
+//
+//	client, iface, err := sagea2a.NewDIDAuthenticatedClientWithFallback(
+//	    ctx, myDID, myKeyPair, agentCard, nil,
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	defer client.Destroy()
+//	log.Printf("connected via %s at %s", iface.Transport, iface.URL)
+func NewDIDAuthenticatedClientWithFallback(
+	ctx context.Context,
+	agentDID did.AgentDID,
+	keyPair crypto.KeyPair,
+	card *a2a.AgentCard,
+	httpClient *http.Client,
+) (*a2aclient.Client, a2a.AgentInterface, error) {
+	if card == nil {
+		return nil, a2a.AgentInterface{}, fmt.Errorf("card cannot be nil")
+	}
+
+	iface, err := SelectInterface(ctx, card, HTTPProbe(httpClient))
+	if err != nil {
+		return nil, a2a.AgentInterface{}, err
+	}
+
+	client, err := a2aclient.NewFromEndpoints(
+		ctx,
+		[]a2a.AgentInterface{iface},
+		a2aclient.WithDefaultsDisabled(),
+		WithDIDHTTPTransport(agentDID, keyPair, httpClient),
+	)
+	if err != nil {
+		return nil, a2a.AgentInterface{}, err
+	}
+
+	return client, iface, nil
+}
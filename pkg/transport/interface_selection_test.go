@@ -0,0 +1,160 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardInterfaces_Order(t *testing.T) {
+	card := &a2a.AgentCard{
+		URL: "https://primary.example.com",
+		AdditionalInterfaces: []a2a.AgentInterface{
+			{Transport: a2a.TransportProtocolGRPC, URL: "grpc.example.com:443"},
+			{Transport: a2a.TransportProtocolJSONRPC, URL: "https://backup.example.com"},
+			{Transport: a2a.TransportProtocolJSONRPC, URL: "https://primary.example.com"},
+		},
+	}
+
+	ifaces := CardInterfaces(card)
+
+	require.Len(t, ifaces, 3)
+	assert.Equal(t, a2a.AgentInterface{Transport: a2a.TransportProtocolJSONRPC, URL: "https://primary.example.com"}, ifaces[0])
+	assert.Equal(t, a2a.TransportProtocolGRPC, ifaces[1].Transport)
+	assert.Equal(t, "https://backup.example.com", ifaces[2].URL)
+}
+
+func TestCardInterfaces_NilCard(t *testing.T) {
+	assert.Nil(t, CardInterfaces(nil))
+}
+
+func TestSelectInterface_FallsBackOnProbeFailure(t *testing.T) {
+	card := &a2a.AgentCard{
+		URL:                "https://down.example.com",
+		PreferredTransport: a2a.TransportProtocolJSONRPC,
+		AdditionalInterfaces: []a2a.AgentInterface{
+			{Transport: a2a.TransportProtocolGRPC, URL: "grpc.example.com:443"},
+			{Transport: a2a.TransportProtocolJSONRPC, URL: "https://up.example.com"},
+		},
+	}
+
+	var probed []string
+	probe := func(ctx context.Context, iface a2a.AgentInterface) error {
+		probed = append(probed, iface.URL)
+		if iface.URL == "https://down.example.com" {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	}
+
+	iface, err := SelectInterface(context.Background(), card, probe)
+	require.NoError(t, err)
+	assert.Equal(t, "https://up.example.com", iface.URL)
+	assert.Equal(t, []string{"https://down.example.com", "https://up.example.com"}, probed)
+}
+
+func TestSelectInterface_AllUnreachable(t *testing.T) {
+	card := &a2a.AgentCard{URL: "https://down.example.com"}
+
+	probe := func(ctx context.Context, iface a2a.AgentInterface) error {
+		return fmt.Errorf("connection refused")
+	}
+
+	_, err := SelectInterface(context.Background(), card, probe)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no reachable JSON-RPC interface")
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestSelectInterface_NoJSONRPC(t *testing.T) {
+	card := &a2a.AgentCard{
+		URL:                "grpc.example.com:443",
+		PreferredTransport: a2a.TransportProtocolGRPC,
+	}
+
+	_, err := SelectInterface(context.Background(), card, func(ctx context.Context, iface a2a.AgentInterface) error {
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no JSON-RPC interface")
+}
+
+func TestNewDIDAuthenticatedClientWithFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "HEAD" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(map[string]interface{}{
+			"id":        "task-123",
+			"contextId": "ctx-1",
+			"kind":      "task",
+			"status":    map[string]interface{}{"state": "working"},
+		}))
+	}))
+	defer server.Close()
+
+	// Closed server gives a guaranteed connection failure
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	keyPair, err := crypto.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	agentDID := did.AgentDID("did:sage:test:0x1234")
+
+	card := &a2a.AgentCard{
+		Name:               "Test Agent",
+		URL:                downURL,
+		PreferredTransport: a2a.TransportProtocolJSONRPC,
+		AdditionalInterfaces: []a2a.AgentInterface{
+			{Transport: a2a.TransportProtocolJSONRPC, URL: server.URL},
+		},
+	}
+
+	ctx := context.Background()
+	client, iface, err := NewDIDAuthenticatedClientWithFallback(ctx, agentDID, keyPair, card, nil)
+	require.NoError(t, err)
+	defer client.Destroy()
+
+	assert.Equal(t, server.URL, iface.URL)
+	assert.Equal(t, a2a.TransportProtocolJSONRPC, iface.Transport)
+
+	task, err := client.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-123"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-123"), task.ID)
+}
+
+func TestNewDIDAuthenticatedClientWithFallback_NilCard(t *testing.T) {
+	keyPair, err := crypto.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	_, _, err = NewDIDAuthenticatedClientWithFallback(context.Background(), "did:sage:test:0x1234", keyPair, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+)
+
+// taskEventsMethod is the size counter name of task event polls (see
+// SizeStats)
+const taskEventsMethod = "tasks/events"
+
+// ErrStreamingUnavailable is returned when a streaming call is answered
+// without an SSE stream, e.g. by a proxy that buffers or rewrites it
+var ErrStreamingUnavailable = errors.New("streaming unavailable")
+
+// WithLongPollFallback makes message/stream and tasks/resubscribe fall
+// back to long polling of protocol.TaskEventsPath (see
+// server.NewTaskEventsHandler) when the server cannot be reached over SSE,
+// for networks whose proxies block event streams. Each poll waits up to
+// wait for new events (protocol.DefaultTaskEventsWait if wait <= 0); the
+// http.Client timeout must be longer.
+//
+// Streaming falls back when the SSE request is answered with 404, 405,
+// 406, 415, 501 or 502, or with a body that is not an event stream, before
+// any event was received; later streaming calls then poll directly. A
+// message/stream call that falls back is sent again as a non-blocking
+// message/send, which the server has not processed since it answered
+// without a stream.
+func WithLongPollFallback(wait time.Duration) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		if wait <= 0 {
+			wait = protocol.DefaultTaskEventsWait
+		}
+		t.longPollWait = wait
+	}
+}
+
+// PollTaskEvents fetches the events of a task after cursor, the Cursor of
+// an earlier page or "" for the start, waiting up to wait for new ones
+func (t *DIDHTTPTransport) PollTaskEvents(ctx context.Context, taskID a2a.TaskID, cursor string, wait time.Duration) (*protocol.TaskEventsPage, error) {
+	ctx, cancel := t.methodContext(ctx, taskEventsMethod)
+	defer cancel()
+
+	if t.rateLimiter != nil {
+		if err := t.rateLimiter.Wait(ctx, t.rateLimitKey()); err != nil {
+			return nil, err
+		}
+	}
+
+	query := url.Values{protocol.TaskEventsTaskParam: {string(taskID)}}
+	if cursor != "" {
+		query.Set(protocol.TaskEventsCursorParam, cursor)
+	}
+	if wait > 0 {
+		query.Set(protocol.TaskEventsWaitParam, wait.String())
+	}
+	u := strings.TrimSuffix(t.baseURL, "/") + protocol.TaskEventsPath + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, bytes.NewReader(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	components := []string{"@method", "@path", "@query", "content-digest"}
+	components = append(components, setRequestHints(ctx, req)...)
+	opts := &signer.SigningOptions{
+		Components:      components,
+		DigestAlgorithm: t.DigestAlgorithm(),
+	}
+	if err := t.signer.SignRequestWithOptions(ctx, req, t.agentDID, t.keyPair, opts); err != nil {
+		return nil, fmt.Errorf("failed to sign request with DID: %w", err)
+	}
+	t.recordRequest(taskEventsMethod, req.ContentLength)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	t.observeDigestPreference(resp)
+	resp.Body = t.countResponse(taskEventsMethod, resp.Body)
+
+	body, err := readResponseBody(resp, t.maxResponseSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newHTTPError(resp, body)
+	}
+	var page protocol.TaskEventsPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse task events: %w", err)
+	}
+	return &page, nil
+}
+
+// pollTaskEvents yields the events of a task after cursor until the
+// stream ends
+func (t *DIDHTTPTransport) pollTaskEvents(ctx context.Context, taskID a2a.TaskID, cursor string, yield func(a2a.Event, error) bool) {
+	tracker := &streamTaskTracker{taskID: taskID}
+	defer t.propagateCancel(ctx, tracker)
+
+	for {
+		page, err := t.PollTaskEvents(ctx, taskID, cursor, t.longPollWait)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, e := range page.Events {
+			event := e.Event()
+			if event == nil {
+				continue
+			}
+			tracker.observe(event)
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if page.Final {
+			return
+		}
+		cursor = page.Cursor
+	}
+}
+
+// streamOrPoll sends message/stream over SSE, falling back to message/send
+// and long polling when SSE is unavailable
+func (t *DIDHTTPTransport) streamOrPoll(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		if !t.streamBlocked.Load() {
+			if !t.streamUntilBlocked(ctx, "message/stream", params, yield) {
+				return
+			}
+		}
+		if params == nil || params.Message == nil {
+			yield(nil, fmt.Errorf("message is required"))
+			return
+		}
+
+		// A follow-up continues the event log of its task; skip the
+		// events that preceded it
+		cursor := ""
+		followUp := params.Message.TaskID != ""
+		if followUp {
+			page, err := t.PollTaskEvents(ctx, params.Message.TaskID, "", 0)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			cursor = page.Cursor
+		}
+
+		p := *params
+		cfg := a2a.MessageSendConfig{}
+		if p.Config != nil {
+			cfg = *p.Config
+		}
+		cfg.Blocking = false
+		p.Config = &cfg
+		raw, err := t.call(ctx, "message/send", &p)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		result, err := decodeSendResult(raw)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		task, ok := result.(*a2a.Task)
+		if !ok {
+			if event, ok := result.(a2a.Event); ok {
+				yield(event, nil)
+			}
+			return
+		}
+
+		// A new task's log starts with the task itself
+		if followUp || task.Status.State.Terminal() {
+			if !yield(task, nil) || task.Status.State.Terminal() {
+				return
+			}
+		}
+		t.pollTaskEvents(ctx, task.ID, cursor, yield)
+	}
+}
+
+// resubscribeOrPoll resubscribes to a task over SSE, falling back to long
+// polling when SSE is unavailable. Polling replays the task's events from
+// the start.
+func (t *DIDHTTPTransport) resubscribeOrPoll(ctx context.Context, id *a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		if !t.streamBlocked.Load() {
+			if !t.streamUntilBlocked(ctx, "tasks/resubscribe", id, yield) {
+				return
+			}
+		}
+		if id == nil {
+			yield(nil, fmt.Errorf("task ID is required"))
+			return
+		}
+		t.pollTaskEvents(ctx, id.ID, "", yield)
+	}
+}
+
+// streamUntilBlocked streams method over SSE and reports whether it failed
+// before the first event because SSE is unavailable, in which case the
+// transport switches to long polling
+func (t *DIDHTTPTransport) streamUntilBlocked(ctx context.Context, method string, params any, yield func(a2a.Event, error) bool) bool {
+	started := false
+	for event, err := range t.callSSE(ctx, method, params) {
+		if err != nil && !started && streamingUnavailable(err) {
+			t.streamBlocked.Store(true)
+			return true
+		}
+		started = true
+		if !yield(event, err) {
+			return false
+		}
+	}
+	return false
+}
+
+// streamingUnavailable reports whether err shows that the server, or a
+// proxy in front of it, does not serve event streams
+func streamingUnavailable(err error) bool {
+	if errors.Is(err, ErrStreamingUnavailable) {
+		return true
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	switch httpErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotAcceptable,
+		http.StatusUnsupportedMediaType, http.StatusNotImplemented, http.StatusBadGateway:
+		return true
+	}
+	return false
+}
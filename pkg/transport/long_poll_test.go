@@ -0,0 +1,135 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/registry"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// artifactExecutor answers every task with one artifact
+type artifactExecutor struct{}
+
+func (artifactExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return queue.Write(ctx, a2a.NewArtifactEvent(reqCtx.Task, a2a.TextPart{Text: "result"}))
+}
+
+func (artifactExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	return nil
+}
+
+// longPollFixture serves a task queue behind a proxy that rejects event
+// streams with 406 and returns a transport registered with it
+func longPollFixture(t *testing.T, streams *atomic.Int32) *DIDHTTPTransport {
+	t.Helper()
+	agentDID := did.AgentDID("did:sage:ethereum:0xpoller")
+	keyPair, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	reg := registry.NewMemoryRegistry()
+	require.NoError(t, reg.RegisterKeys(context.Background(), agentDID, keyPair.PublicKey()))
+
+	queue := server.NewTaskQueue(artifactExecutor{}, server.TaskQueueConfig{Workers: 1})
+	t.Cleanup(queue.Close)
+
+	mux := http.NewServeMux()
+	mux.Handle(protocol.TaskEventsPath, server.NewTaskEventsHandler(queue))
+	mux.HandleFunc("/rpc", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any                   `json:"id"`
+			Method string                `json:"method"`
+			Params a2a.MessageSendParams `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "message/send", req.Method)
+		require.False(t, req.Params.Config != nil && req.Params.Config.Blocking)
+		task, err := queue.Submit(r.Context(), req.Params.Message)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": task})
+	})
+	handler := server.NewDIDAuthMiddlewareWithVerifier(reg.NewDIDVerifier()).Wrap(mux)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "text/event-stream" {
+			streams.Add(1)
+			http.Error(w, "event streams are not allowed", http.StatusNotAcceptable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewDIDHTTPTransport(srv.URL, agentDID, keyPair, nil, WithLongPollFallback(2*time.Second)).(*DIDHTTPTransport)
+}
+
+func TestLongPollFallback(t *testing.T) {
+	var streams atomic.Int32
+	tr := longPollFixture(t, &streams)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collect := func(seq func(yield func(a2a.Event, error) bool)) []a2a.Event {
+		var events []a2a.Event
+		for event, err := range seq {
+			require.NoError(t, err)
+			events = append(events, event)
+		}
+		return events
+	}
+	params := &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hello"})}
+
+	events := collect(tr.SendStreamingMessage(ctx, params))
+	require.Len(t, events, 4)
+	task, ok := events[0].(*a2a.Task)
+	require.True(t, ok)
+	assert.IsType(t, &a2a.TaskArtifactUpdateEvent{}, events[2])
+	final := events[3].(*a2a.TaskStatusUpdateEvent)
+	assert.True(t, final.Final)
+	assert.Equal(t, a2a.TaskStateCompleted, final.Status.State)
+	assert.Equal(t, int32(1), streams.Load())
+
+	// Later streaming calls poll without trying SSE again
+	events = collect(tr.SendStreamingMessage(ctx, params))
+	assert.Len(t, events, 4)
+	events = collect(tr.ResubscribeToTask(ctx, &a2a.TaskIDParams{ID: task.ID}))
+	assert.Len(t, events, 4)
+	assert.Equal(t, int32(1), streams.Load())
+}
+
+func TestStreamingUnavailable(t *testing.T) {
+	assert.True(t, streamingUnavailable(ErrStreamingUnavailable))
+	assert.True(t, streamingUnavailable(&HTTPError{StatusCode: http.StatusNotAcceptable}))
+	assert.True(t, streamingUnavailable(&HTTPError{StatusCode: http.StatusBadGateway}))
+	assert.False(t, streamingUnavailable(&HTTPError{StatusCode: http.StatusUnauthorized}))
+	assert.False(t, streamingUnavailable(context.Canceled))
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// WithMessageDigestHeader makes message/send and message/stream requests
+// carry the protocol.MessageDigest of their message, computed with alg
+// ("sha-256" or "sha-512"), in the signed A2A-Message-Digest header. The
+// server can then keep an integrity proof of the message content that
+// outlives the HTTP request. A digest set with protocol.WithMessageDigest
+// takes precedence.
+func WithMessageDigestHeader(alg string) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		if alg == "" {
+			alg = "sha-256"
+		}
+		t.messageDigestAlg = alg
+	}
+}
+
+// withMessageDigest returns ctx carrying the digest of the message in
+// params, if message digests are enabled
+func (t *DIDHTTPTransport) withMessageDigest(ctx context.Context, params *a2a.MessageSendParams) (context.Context, error) {
+	if t.messageDigestAlg == "" || params == nil || params.Message == nil {
+		return ctx, nil
+	}
+	if _, ok := protocol.MessageDigestFromContext(ctx); ok {
+		return ctx, nil
+	}
+	digest, err := protocol.MessageDigest(params.Message, t.messageDigestAlg)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to compute message digest: %w", err)
+	}
+	return protocol.WithMessageDigest(ctx, digest), nil
+}
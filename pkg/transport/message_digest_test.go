@@ -0,0 +1,67 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_MessageDigestHeader(t *testing.T) {
+	var (
+		digest, sigInput string
+		verifyErr        error
+		transport        *DIDHTTPTransport
+	)
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		digest = r.Header.Get(protocol.MessageDigestHeader)
+		sigInput = r.Header.Get("Signature-Input")
+		verifyErr = verifier.NewRFC9421Verifier().VerifyHTTPRequest(r, transport.keyPair.PublicKey())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	})
+	defer server.Close()
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "hello"})
+
+	// Off by default
+	_, err := transport.SendMessage(context.Background(), &a2a.MessageSendParams{Message: msg})
+	require.NoError(t, err)
+	assert.Empty(t, digest)
+
+	WithMessageDigestHeader("sha-512")(transport)
+	_, err = transport.SendMessage(context.Background(), &a2a.MessageSendParams{Message: msg})
+	require.NoError(t, err)
+	assert.Regexp(t, `^sha-512=:`, digest)
+	require.NoError(t, protocol.VerifyMessageDigest(msg, digest))
+	assert.Contains(t, sigInput, `"a2a-message-digest"`)
+	assert.NoError(t, verifyErr)
+
+	// Other methods carry no digest
+	digest = ""
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Empty(t, digest)
+}
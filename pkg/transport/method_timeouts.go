@@ -0,0 +1,91 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultMethodTimeout bounds calls of methods that have no timeout of
+// their own in DefaultMethodTimeouts or WithMethodTimeout
+const DefaultMethodTimeout = 30 * time.Second
+
+// DefaultMethodTimeouts returns the built-in per-method timeouts. Streams
+// and long polls are not limited, since they legitimately stay open;
+// agent card fetches are covered under "agent/card". A zero timeout means
+// the call is only bounded by the caller's context.
+func DefaultMethodTimeouts() map[string]time.Duration {
+	return map[string]time.Duration{
+		"message/stream":    0,
+		"tasks/resubscribe": 0,
+		taskEventsMethod:    0,
+		"message/send":      60 * time.Second,
+		"tasks/get":         5 * time.Second,
+		"tasks/list":        10 * time.Second,
+		"tasks/cancel":      10 * time.Second,
+		agentCardMethod:     3 * time.Second,
+	}
+}
+
+// defaultMethodTimeouts is DefaultMethodTimeouts, shared by transports
+// that do not override any timeout
+var defaultMethodTimeouts = DefaultMethodTimeouts()
+
+// WithMethodTimeout sets the timeout of calls of a JSON-RPC method, or of
+// agent card fetches for "agent/card", applied on top of the caller's
+// context. A zero timeout removes the limit. The empty method sets the
+// timeout of methods without their own (DefaultMethodTimeout by default).
+//
+// Prefer this to http.Client.Timeout, which also cuts off streams:
+//
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//	    transport.WithMethodTimeout("tasks/get", 2*time.Second),
+//	    transport.WithMethodTimeout("message/send", 5*time.Minute))
+func WithMethodTimeout(method string, timeout time.Duration) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		if t.methodTimeouts == nil {
+			t.methodTimeouts = make(map[string]time.Duration)
+		}
+		t.methodTimeouts[method] = max(timeout, 0)
+	}
+}
+
+// MethodTimeout returns the timeout applied to calls of method; zero means
+// none
+func (t *DIDHTTPTransport) MethodTimeout(method string) time.Duration {
+	if timeout, ok := t.methodTimeouts[method]; ok {
+		return timeout
+	}
+	if timeout, ok := defaultMethodTimeouts[method]; ok {
+		return timeout
+	}
+	if timeout, ok := t.methodTimeouts[""]; ok {
+		return timeout
+	}
+	return DefaultMethodTimeout
+}
+
+// methodContext derives the context of a call of method, bounded by its
+// timeout
+func (t *DIDHTTPTransport) methodContext(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	if timeout := t.MethodTimeout(method); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodTimeout_Lookup(t *testing.T) {
+	tr := &DIDHTTPTransport{}
+	assert.Zero(t, tr.MethodTimeout("message/stream"))
+	assert.Equal(t, 5*time.Second, tr.MethodTimeout("tasks/get"))
+	assert.Equal(t, 3*time.Second, tr.MethodTimeout(agentCardMethod))
+	assert.Equal(t, DefaultMethodTimeout, tr.MethodTimeout("tasks/pushNotificationConfig/get"))
+
+	WithMethodTimeout("tasks/get", time.Second)(tr)
+	WithMethodTimeout("message/stream", time.Minute)(tr)
+	WithMethodTimeout("", 0)(tr)
+	assert.Equal(t, time.Second, tr.MethodTimeout("tasks/get"))
+	assert.Equal(t, time.Minute, tr.MethodTimeout("message/stream"))
+	assert.Zero(t, tr.MethodTimeout("tasks/pushNotificationConfig/get"))
+	assert.Equal(t, 60*time.Second, tr.MethodTimeout("message/send"), "built-in timeouts are kept")
+}
+
+func TestMethodTimeout_Applied(t *testing.T) {
+	tr, srv := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		time.Sleep(100 * time.Millisecond)
+		switch req.Method {
+		case "message/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "result": map[string]any{
+				"statusUpdate": a2a.TaskStatusUpdateEvent{TaskID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}, Final: true},
+			}})
+			_, _ = w.Write([]byte(mockSSEResponse([]string{string(data)})))
+		default:
+			_, _ = w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+		}
+	})
+	defer srv.Close()
+	WithMethodTimeout("tasks/get", 20*time.Millisecond)(tr)
+	WithMethodTimeout("", 20*time.Millisecond)(tr)
+	ctx := context.Background()
+
+	_, err := tr.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+
+	// Streams are not limited by default
+	var events int
+	for _, err := range tr.SendStreamingMessage(ctx, &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})}) {
+		require.NoError(t, err)
+		events++
+	}
+	assert.Equal(t, 1, events)
+
+	WithMethodTimeout("tasks/get", time.Second)(tr)
+	task, err := tr.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, a2a.TaskID("task-1"), task.ID)
+}
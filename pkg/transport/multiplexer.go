@@ -0,0 +1,309 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// DefaultMaxConcurrentStreams is the default number of streams a
+// StreamMultiplexer keeps open at once
+const DefaultMaxConcurrentStreams = 8
+
+// Streamer is the subset of a2aclient.Transport used by StreamMultiplexer
+type Streamer interface {
+	SendStreamingMessage(ctx context.Context, message *a2a.MessageSendParams) iter.Seq2[a2a.Event, error]
+	ResubscribeToTask(ctx context.Context, id *a2a.TaskIDParams) iter.Seq2[a2a.Event, error]
+}
+
+// StreamEvent is an event (or error) delivered on a multiplexed stream
+type StreamEvent struct {
+	// Key identifies the stream the event belongs to
+	Key   string
+	Event a2a.Event
+	Err   error
+}
+
+// MuxStream is a single stream managed by a StreamMultiplexer
+type MuxStream struct {
+	key    string
+	events chan StreamEvent
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	err      error
+	canceled bool
+}
+
+// Key returns the stream key
+func (s *MuxStream) Key() string {
+	return s.key
+}
+
+// Events returns the stream's event channel. It is closed when the stream
+// ends, fails or is cancelled. Consumers must drain it; a slow consumer only
+// holds back its own stream.
+func (s *MuxStream) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// Cancel stops the stream and releases its connection slot
+func (s *MuxStream) Cancel() {
+	s.mu.Lock()
+	s.canceled = true
+	s.mu.Unlock()
+	s.cancel()
+}
+
+// Done is closed once the stream has finished
+func (s *MuxStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that terminated the stream, or nil if it completed
+// normally or was cancelled via Cancel. Only valid after Done is closed.
+func (s *MuxStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// MultiStreamError aggregates the errors of failed streams, keyed by stream key
+type MultiStreamError struct {
+	Errors map[string]error
+}
+
+// Error implements error
+func (e *MultiStreamError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for k := range e.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s: %v", k, e.Errors[k]))
+	}
+	return fmt.Sprintf("%d stream(s) failed: %s", len(keys), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the individual stream errors
+func (e *MultiStreamError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// StreamMultiplexer runs many SendStreamingMessage/ResubscribeToTask streams
+// concurrently over a bounded number of connections.
+//
+// At most maxConcurrent streams are open at once; further streams wait for
+// a free slot in the order they were opened, so no task is starved. Each
+// stream delivers events on its own channel, can be cancelled individually,
+// and failures are collected into a MultiStreamError returned by Wait.
+//
+// To bound the underlying TCP connections as well, give the transport an
+// http.Client whose http.Transport has MaxConnsPerHost >= maxConcurrent.
+type StreamMultiplexer struct {
+	streamer   Streamer
+	slots      chan struct{}
+	bufferSize int
+
+	mu      sync.Mutex
+	streams map[string]*MuxStream
+	errs    map[string]error
+	wg      sync.WaitGroup
+}
+
+// NewStreamMultiplexer creates a multiplexer over streamer (typically a
+// DIDHTTPTransport). maxConcurrent <= 0 uses DefaultMaxConcurrentStreams.
+func NewStreamMultiplexer(streamer Streamer, maxConcurrent int) *StreamMultiplexer {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentStreams
+	}
+	return &StreamMultiplexer{
+		streamer:   streamer,
+		slots:      make(chan struct{}, maxConcurrent),
+		bufferSize: 16,
+		streams:    make(map[string]*MuxStream),
+		errs:       make(map[string]error),
+	}
+}
+
+// SendStreamingMessage opens a message/stream stream identified by key
+func (m *StreamMultiplexer) SendStreamingMessage(ctx context.Context, key string, message *a2a.MessageSendParams) (*MuxStream, error) {
+	return m.open(ctx, key, func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return m.streamer.SendStreamingMessage(ctx, message)
+	})
+}
+
+// ResubscribeToTask opens a tasks/resubscribe stream keyed by the task ID
+func (m *StreamMultiplexer) ResubscribeToTask(ctx context.Context, id *a2a.TaskIDParams) (*MuxStream, error) {
+	if id == nil {
+		return nil, fmt.Errorf("task id cannot be nil")
+	}
+	return m.open(ctx, string(id.ID), func(ctx context.Context) iter.Seq2[a2a.Event, error] {
+		return m.streamer.ResubscribeToTask(ctx, id)
+	})
+}
+
+// Stream returns the active stream for key
+func (m *StreamMultiplexer) Stream(key string) (*MuxStream, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.streams[key]
+	return s, ok
+}
+
+// Active returns the number of streams that have not finished yet
+// (both running and waiting for a slot)
+func (m *StreamMultiplexer) Active() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.streams)
+}
+
+// Cancel cancels the stream identified by key. It reports whether the stream existed.
+func (m *StreamMultiplexer) Cancel(key string) bool {
+	s, ok := m.Stream(key)
+	if ok {
+		s.Cancel()
+	}
+	return ok
+}
+
+// Close cancels every active stream and waits for them to finish
+func (m *StreamMultiplexer) Close() error {
+	m.mu.Lock()
+	for _, s := range m.streams {
+		s.Cancel()
+	}
+	m.mu.Unlock()
+	return m.Wait()
+}
+
+// Wait blocks until all streams have finished and returns a
+// *MultiStreamError if any failed
+func (m *StreamMultiplexer) Wait() error {
+	m.wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.errs) == 0 {
+		return nil
+	}
+	errs := make(map[string]error, len(m.errs))
+	for k, v := range m.errs {
+		errs[k] = v
+	}
+	return &MultiStreamError{Errors: errs}
+}
+
+func (m *StreamMultiplexer) open(ctx context.Context, key string, start func(context.Context) iter.Seq2[a2a.Event, error]) (*MuxStream, error) {
+	if key == "" {
+		return nil, fmt.Errorf("stream key cannot be empty")
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	s := &MuxStream{
+		key:    key,
+		events: make(chan StreamEvent, m.bufferSize),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	m.mu.Lock()
+	if _, exists := m.streams[key]; exists {
+		m.mu.Unlock()
+		cancel()
+		return nil, fmt.Errorf("stream %q already active", key)
+	}
+	m.streams[key] = s
+	delete(m.errs, key)
+	m.wg.Add(1)
+	m.mu.Unlock()
+
+	go m.run(streamCtx, s, start)
+
+	return s, nil
+}
+
+func (m *StreamMultiplexer) run(ctx context.Context, s *MuxStream, start func(context.Context) iter.Seq2[a2a.Event, error]) {
+	var streamErr error
+	defer func() {
+		s.cancel()
+
+		s.mu.Lock()
+		if s.canceled && errors.Is(streamErr, context.Canceled) {
+			streamErr = nil
+		}
+		s.err = streamErr
+		s.mu.Unlock()
+
+		m.mu.Lock()
+		delete(m.streams, s.key)
+		if streamErr != nil {
+			m.errs[s.key] = streamErr
+		}
+		m.mu.Unlock()
+
+		close(s.events)
+		close(s.done)
+		m.wg.Done()
+	}()
+
+	// Wait for a connection slot; blocked senders are served in FIFO order
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		streamErr = ctx.Err()
+		return
+	}
+	defer func() { <-m.slots }()
+
+	for event, err := range start(ctx) {
+		if err != nil {
+			streamErr = err
+		}
+		select {
+		case s.events <- StreamEvent{Key: s.key, Event: event, Err: err}:
+		case <-ctx.Done():
+			streamErr = ctx.Err()
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	if streamErr == nil {
+		streamErr = ctx.Err()
+	}
+}
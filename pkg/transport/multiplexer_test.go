@@ -0,0 +1,169 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStreamer emits n messages per stream, or blocks until cancelled if n < 0
+type mockStreamer struct {
+	n       int
+	fail    map[string]error
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (m *mockStreamer) stream(ctx context.Context, key string) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		cur := m.running.Add(1)
+		defer m.running.Add(-1)
+		for {
+			peak := m.peak.Load()
+			if cur <= peak || m.peak.CompareAndSwap(peak, cur) {
+				break
+			}
+		}
+
+		if err, ok := m.fail[key]; ok {
+			yield(nil, err)
+			return
+		}
+		if m.n < 0 {
+			<-ctx.Done()
+			yield(nil, ctx.Err())
+			return
+		}
+		for i := 0; i < m.n; i++ {
+			time.Sleep(time.Millisecond)
+			if !yield(&a2a.Message{ID: key}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (m *mockStreamer) SendStreamingMessage(ctx context.Context, message *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return m.stream(ctx, message.Message.ID)
+}
+
+func (m *mockStreamer) ResubscribeToTask(ctx context.Context, id *a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return m.stream(ctx, string(id.ID))
+}
+
+func muxParams(id string) *a2a.MessageSendParams {
+	return &a2a.MessageSendParams{Message: &a2a.Message{ID: id}}
+}
+
+func TestStreamMultiplexer_BoundedConcurrency(t *testing.T) {
+	streamer := &mockStreamer{n: 3}
+	mux := NewStreamMultiplexer(streamer, 2)
+	ctx := context.Background()
+
+	var streams []*MuxStream
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		s, err := mux.SendStreamingMessage(ctx, key, muxParams(key))
+		require.NoError(t, err)
+		streams = append(streams, s)
+	}
+
+	for _, s := range streams {
+		count := 0
+		for ev := range s.Events() {
+			require.NoError(t, ev.Err)
+			assert.Equal(t, s.Key(), ev.Key)
+			count++
+		}
+		assert.Equal(t, 3, count)
+	}
+
+	require.NoError(t, mux.Wait())
+	assert.LessOrEqual(t, streamer.peak.Load(), int32(2))
+	assert.Equal(t, 0, mux.Active())
+}
+
+func TestStreamMultiplexer_CancelIndividualStream(t *testing.T) {
+	mux := NewStreamMultiplexer(&mockStreamer{n: -1}, 4)
+	ctx := context.Background()
+
+	a, err := mux.ResubscribeToTask(ctx, &a2a.TaskIDParams{ID: "task-a"})
+	require.NoError(t, err)
+	b, err := mux.ResubscribeToTask(ctx, &a2a.TaskIDParams{ID: "task-b"})
+	require.NoError(t, err)
+
+	assert.True(t, mux.Cancel("task-a"))
+	<-a.Done()
+	assert.NoError(t, a.Err())
+
+	select {
+	case <-b.Done():
+		t.Fatal("cancelling one stream must not stop the others")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	require.NoError(t, mux.Close())
+	assert.False(t, mux.Cancel("task-a"))
+}
+
+func TestStreamMultiplexer_AggregateErrors(t *testing.T) {
+	boom := errors.New("boom")
+	mux := NewStreamMultiplexer(&mockStreamer{n: 1, fail: map[string]error{"bad": boom}}, 0)
+	ctx := context.Background()
+
+	for _, key := range []string{"good", "bad"} {
+		s, err := mux.SendStreamingMessage(ctx, key, muxParams(key))
+		require.NoError(t, err)
+		go func() {
+			for range s.Events() {
+			}
+		}()
+	}
+
+	err := mux.Wait()
+	require.Error(t, err)
+
+	var multiErr *MultiStreamError
+	require.ErrorAs(t, err, &multiErr)
+	assert.Len(t, multiErr.Errors, 1)
+	assert.ErrorIs(t, err, boom)
+	assert.Contains(t, err.Error(), "bad: boom")
+}
+
+func TestStreamMultiplexer_DuplicateKey(t *testing.T) {
+	mux := NewStreamMultiplexer(&mockStreamer{n: -1}, 1)
+	ctx := context.Background()
+
+	_, err := mux.SendStreamingMessage(ctx, "dup", muxParams("dup"))
+	require.NoError(t, err)
+	_, err = mux.SendStreamingMessage(ctx, "dup", muxParams("dup"))
+	assert.Error(t, err)
+
+	_, err = mux.SendStreamingMessage(ctx, "", muxParams(""))
+	assert.Error(t, err)
+
+	require.NoError(t, mux.Close())
+}
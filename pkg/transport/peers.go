@@ -0,0 +1,166 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Defaults for PeerCrawlConfig
+const (
+	DefaultPeerCrawlDepth = 2
+	DefaultPeerCrawlLimit = 1000
+)
+
+// ListPeers calls agents/listPeers, listing the agents known to the peer
+func (t *DIDHTTPTransport) ListPeers(ctx context.Context, params *protocol.ListPeersParams) (*protocol.ListPeersResult, error) {
+	if params == nil {
+		params = &protocol.ListPeersParams{}
+	}
+	result, err := t.call(ctx, protocol.ListPeersMethod, params)
+	if err != nil {
+		return nil, err
+	}
+	var listResult protocol.ListPeersResult
+	if err := json.Unmarshal(result, &listResult); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ListPeersResult: %w", err)
+	}
+	return &listResult, nil
+}
+
+// PeerLister lists the peers of one agent. DIDHTTPTransport implements it.
+type PeerLister interface {
+	ListPeers(ctx context.Context, params *protocol.ListPeersParams) (*protocol.ListPeersResult, error)
+}
+
+// PeerCrawlConfig configures CrawlPeers
+type PeerCrawlConfig struct {
+	// Resolve resolves the keys verifying each peer's card. Peers whose
+	// card is not signed by their DID are dropped.
+	Resolve protocol.CardKeyResolver
+
+	// Dial returns a lister for a discovered peer, typically a
+	// DIDHTTPTransport to its card's URL. Nil only lists the seeds' peers.
+	Dial func(ctx context.Context, peer protocol.Peer) (PeerLister, error)
+
+	// Trusted reports whether the peers of peer are crawled in turn. Nil
+	// crawls through every verified peer.
+	Trusted func(peer protocol.Peer) bool
+
+	// MaxDepth is how many hops from the seeds are crawled; peers listed
+	// by a seed are at depth 1 (default DefaultPeerCrawlDepth)
+	MaxDepth int
+
+	// MaxPeers stops the crawl once this many peers are discovered
+	// (default DefaultPeerCrawlLimit)
+	MaxPeers int
+}
+
+// DiscoveredPeer is a verified peer found by CrawlPeers
+type DiscoveredPeer struct {
+	protocol.Peer
+
+	// Depth is the number of hops from the seeds
+	Depth int
+
+	// Via is the DID of the agent that listed the peer, empty for seeds
+	Via did.AgentDID
+}
+
+// CrawlPeers discovers agents transitively from seeds, breadth first. Each
+// listed peer's card signature is verified before it is reported or
+// crawled. Failures to list or dial individual peers do not stop the
+// crawl; they are joined into the returned error alongside the peers
+// found.
+func CrawlPeers(ctx context.Context, seeds []PeerLister, config PeerCrawlConfig) ([]DiscoveredPeer, error) {
+	if config.Resolve == nil {
+		return nil, fmt.Errorf("card key resolver is required")
+	}
+	if config.MaxDepth <= 0 {
+		config.MaxDepth = DefaultPeerCrawlDepth
+	}
+	if config.MaxPeers <= 0 {
+		config.MaxPeers = DefaultPeerCrawlLimit
+	}
+
+	type source struct {
+		lister PeerLister
+		did    did.AgentDID
+	}
+	level := make([]source, len(seeds))
+	for i, seed := range seeds {
+		level[i] = source{lister: seed}
+	}
+
+	var (
+		found []DiscoveredPeer
+		errs  []error
+		seen  = map[did.AgentDID]bool{}
+	)
+	for depth := 1; depth <= config.MaxDepth && len(level) > 0; depth++ {
+		var next []source
+		for _, src := range level {
+			if err := ctx.Err(); err != nil {
+				return found, errors.Join(append(errs, err)...)
+			}
+			result, err := src.lister.ListPeers(ctx, &protocol.ListPeersParams{})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("list peers of %s: %w", sourceName(src.did), err))
+				continue
+			}
+			for _, peer := range result.Peers {
+				if seen[peer.DID] {
+					continue
+				}
+				seen[peer.DID] = true
+				if err := peer.Verify(ctx, config.Resolve); err != nil {
+					errs = append(errs, fmt.Errorf("peer %s listed by %s: %w", peer.DID, sourceName(src.did), err))
+					continue
+				}
+				found = append(found, DiscoveredPeer{Peer: peer, Depth: depth, Via: src.did})
+				if len(found) == config.MaxPeers {
+					return found, errors.Join(errs...)
+				}
+				if depth == config.MaxDepth || config.Dial == nil || config.Trusted != nil && !config.Trusted(peer) {
+					continue
+				}
+				lister, err := config.Dial(ctx, peer)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("dial peer %s: %w", peer.DID, err))
+					continue
+				}
+				next = append(next, source{lister: lister, did: peer.DID})
+			}
+		}
+		level = next
+	}
+	return found, errors.Join(errs...)
+}
+
+func sourceName(agentDID did.AgentDID) string {
+	if agentDID == "" {
+		return "seed"
+	}
+	return string(agentDID)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_ListPeers(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req jsonRPCRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+		assert.Equal(t, protocol.ListPeersMethod, req.Method)
+		w.Write(mockJSONRPCResponse(protocol.ListPeersResult{Peers: []protocol.Peer{
+			{DID: "did:sage:ethereum:0xpeer", Card: &a2a.AgentCard{Name: "peer"}},
+		}}))
+	}
+	transport, server := setupTestTransport(t, handler)
+	defer server.Close()
+
+	result, err := transport.ListPeers(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, result.Peers, 1)
+	assert.Equal(t, "peer", result.Peers[0].Card.Name)
+}
+
+// peerMesh is a set of agents listing each other, keyed by DID
+type peerMesh struct {
+	keys  map[did.AgentDID]crypto.KeyPair
+	links map[did.AgentDID][]protocol.Peer
+	calls map[did.AgentDID]int
+}
+
+func (m *peerMesh) peer(t *testing.T, agentDID did.AgentDID, signed bool) protocol.Peer {
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	m.keys[agentDID] = kp
+	card := &a2a.AgentCard{Name: string(agentDID)}
+	if signed {
+		require.NoError(t, protocol.SignA2AAgentCard(card, agentDID, kp))
+	}
+	return protocol.Peer{DID: agentDID, Card: card}
+}
+
+func (m *peerMesh) resolve(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (stdcrypto.PublicKey, error) {
+	kp, ok := m.keys[agentDID]
+	if !ok {
+		return nil, errors.New("unknown DID")
+	}
+	return kp.PublicKey(), nil
+}
+
+type meshLister struct {
+	mesh *peerMesh
+	did  did.AgentDID
+}
+
+func (l meshLister) ListPeers(ctx context.Context, params *protocol.ListPeersParams) (*protocol.ListPeersResult, error) {
+	l.mesh.calls[l.did]++
+	return &protocol.ListPeersResult{Peers: l.mesh.links[l.did]}, nil
+}
+
+func TestCrawlPeers(t *testing.T) {
+	mesh := &peerMesh{keys: map[did.AgentDID]crypto.KeyPair{}, links: map[did.AgentDID][]protocol.Peer{}, calls: map[did.AgentDID]int{}}
+	a := mesh.peer(t, "did:sage:ethereum:0xa", true)
+	b := mesh.peer(t, "did:sage:ethereum:0xb", true)
+	c := mesh.peer(t, "did:sage:ethereum:0xc", true)
+	d := mesh.peer(t, "did:sage:ethereum:0xd", true)
+	forged := mesh.peer(t, "did:sage:ethereum:0xforged", false)
+	mesh.links["seed"] = []protocol.Peer{a, b, forged}
+	mesh.links[a.DID] = []protocol.Peer{b, c}
+	mesh.links[b.DID] = []protocol.Peer{a}
+	mesh.links[c.DID] = []protocol.Peer{d}
+
+	config := PeerCrawlConfig{
+		Resolve: mesh.resolve,
+		Dial: func(ctx context.Context, peer protocol.Peer) (PeerLister, error) {
+			return meshLister{mesh: mesh, did: peer.DID}, nil
+		},
+	}
+	found, err := CrawlPeers(context.Background(), []PeerLister{meshLister{mesh: mesh, did: "seed"}}, config)
+	assert.ErrorIs(t, err, protocol.ErrCardNotSigned, "unsigned cards are reported")
+
+	got := map[did.AgentDID]DiscoveredPeer{}
+	for _, p := range found {
+		got[p.DID] = p
+	}
+	assert.Len(t, got, 3, "d is beyond the default depth and the forged card is dropped")
+	assert.Equal(t, 1, got[a.DID].Depth)
+	assert.Equal(t, did.AgentDID(""), got[a.DID].Via)
+	assert.Equal(t, 2, got[c.DID].Depth)
+	assert.Equal(t, a.DID, got[c.DID].Via)
+	assert.Zero(t, mesh.calls[c.DID], "peers at the maximum depth are not crawled")
+	assert.Zero(t, mesh.calls[forged.DID])
+
+	// Untrusted peers are reported but not crawled through
+	config.Trusted = func(peer protocol.Peer) bool { return peer.DID != a.DID }
+	config.MaxDepth = 3
+	found, _ = CrawlPeers(context.Background(), []PeerLister{meshLister{mesh: mesh, did: "seed"}}, config)
+	assert.Len(t, found, 2)
+
+	// MaxPeers bounds the crawl
+	config.Trusted = nil
+	config.MaxPeers = 1
+	found, _ = CrawlPeers(context.Background(), []PeerLister{meshLister{mesh: mesh, did: "seed"}}, config)
+	assert.Len(t, found, 1)
+}
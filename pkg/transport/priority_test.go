@@ -0,0 +1,134 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_PriorityAndDeadlineSigned(t *testing.T) {
+	var (
+		priority, deadline, sigInput string
+		verifyErr                    error
+		transport                    *DIDHTTPTransport
+	)
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		priority = r.Header.Get(protocol.PriorityHeader)
+		deadline = r.Header.Get(protocol.DeadlineHeader)
+		sigInput = r.Header.Get("Signature-Input")
+		verifyErr = verifier.NewRFC9421Verifier().VerifyHTTPRequest(r, transport.keyPair.PublicKey())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	})
+	defer server.Close()
+
+	due := time.Now().Add(time.Minute)
+	ctx := protocol.WithDeadline(protocol.WithPriority(context.Background(), protocol.PriorityHigh), due)
+	_, err := transport.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "high", priority)
+	assert.Equal(t, protocol.FormatDeadline(due), deadline)
+	assert.Contains(t, sigInput, `"a2a-priority"`)
+	assert.Contains(t, sigInput, `"a2a-deadline"`)
+	assert.NoError(t, verifyErr)
+}
+
+func TestDIDHTTPTransport_BudgetFromContextDeadline(t *testing.T) {
+	var (
+		budget, sigInput string
+		verifyErr        error
+		transport        *DIDHTTPTransport
+	)
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		budget = r.Header.Get(protocol.BudgetHeader)
+		sigInput = r.Header.Get("Signature-Input")
+		verifyErr = verifier.NewRFC9421Verifier().VerifyHTTPRequest(r, transport.keyPair.PublicKey())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := transport.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+
+	remaining, err := protocol.ParseBudget(budget)
+	require.NoError(t, err)
+	assert.True(t, remaining > 4*time.Second && remaining <= 5*time.Second, "budget %s", remaining)
+	assert.Contains(t, sigInput, `"x-request-budget"`)
+	assert.NoError(t, verifyErr)
+
+	// The method timeout bounds the budget of calls without a deadline
+	WithMethodTimeout("tasks/get", 2*time.Second)(transport)
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	remaining, err = protocol.ParseBudget(budget)
+	require.NoError(t, err)
+	assert.True(t, remaining > time.Second && remaining <= 2*time.Second, "budget %s", remaining)
+}
+
+func TestDIDHTTPTransport_NoHintsByDefault(t *testing.T) {
+	var priority, sigInput string
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		priority = r.Header.Get(protocol.PriorityHeader)
+		sigInput = r.Header.Get("Signature-Input")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	})
+	defer server.Close()
+
+	_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Empty(t, priority)
+	assert.NotContains(t, sigInput, "a2a-priority")
+}
+
+func TestDIDHTTPTransport_ExtensionsSigned(t *testing.T) {
+	var (
+		extensions, sigInput string
+		verifyErr            error
+		transport            *DIDHTTPTransport
+	)
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		extensions = r.Header.Get(protocol.ExtensionsHeader)
+		sigInput = r.Header.Get("Signature-Input")
+		verifyErr = verifier.NewRFC9421Verifier().VerifyHTTPRequest(r, transport.keyPair.PublicKey())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	})
+	defer server.Close()
+
+	ctx := protocol.WithExtensions(context.Background(), protocol.SessionKeyExtensionURI, protocol.DelegationExtensionURI)
+	_, err := transport.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, protocol.SessionKeyExtensionURI+", "+protocol.DelegationExtensionURI, extensions)
+	assert.Contains(t, sigInput, `"x-a2a-extensions"`)
+	assert.NoError(t, verifyErr)
+}
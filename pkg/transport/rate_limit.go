@@ -0,0 +1,206 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when an outbound request would exceed the
+// destination's rate limit and the limit does not queue requests, or the
+// request would have to wait longer than RateLimit.MaxWait
+var ErrRateLimited = errors.New("outbound rate limit exceeded")
+
+// RateLimit configures a token bucket for one destination
+type RateLimit struct {
+	// Rate is the sustained number of requests per second; zero or less
+	// disables the limit
+	Rate float64
+
+	// Burst is the number of requests that may be sent at once
+	// (default Rate rounded up, at least 1)
+	Burst int
+
+	// Wait queues requests until a token is available instead of failing
+	// them with ErrRateLimited. Queued requests give up when their context
+	// is done.
+	Wait bool
+
+	// MaxWait, if set, fails requests that would be queued for longer
+	MaxWait time.Duration
+}
+
+// RateLimitStats holds the outbound request counters of one destination
+type RateLimitStats struct {
+	Allowed  uint64        // sent without waiting
+	Queued   uint64        // sent after waiting for a token
+	Rejected uint64        // failed with ErrRateLimited or a context error
+	Waited   time.Duration // total time spent queued
+}
+
+// tokenBucket is the state of one destination's limit
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+// OutboundLimiter caps the rate of signed requests sent to each destination,
+// so a runaway loop cannot flood a partner agent. Share one limiter between
+// the transports of an agent to cap its total traffic per destination. It
+// is safe for concurrent use.
+type OutboundLimiter struct {
+	defaults RateLimit
+	now      func() time.Time
+
+	mu      sync.Mutex
+	limits  map[string]RateLimit
+	buckets map[string]*tokenBucket
+}
+
+// NewOutboundLimiter creates a limiter applying defaults to every
+// destination without a limit of its own
+func NewOutboundLimiter(defaults RateLimit) *OutboundLimiter {
+	return &OutboundLimiter{
+		defaults: defaults,
+		now:      time.Now,
+		limits:   make(map[string]RateLimit),
+		buckets:  make(map[string]*tokenBucket),
+	}
+}
+
+// SetLimit overrides the limit of one destination, a base URL or DID
+func (l *OutboundLimiter) SetLimit(dest string, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits[dest] = limit
+	if b, ok := l.buckets[dest]; ok {
+		b.limit = normalizeRateLimit(limit)
+		b.tokens = math.Min(b.tokens, float64(b.limit.Burst))
+	}
+}
+
+// Wait takes a token for dest, queueing or failing with ErrRateLimited as
+// the destination's limit dictates
+func (l *OutboundLimiter) Wait(ctx context.Context, dest string) error {
+	delay, err := l.reserve(dest)
+	if err != nil || delay == 0 {
+		return err
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		b := l.buckets[dest]
+		b.stats.Queued++
+		b.stats.Waited += delay
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		// Return the reserved token
+		l.mu.Lock()
+		b := l.buckets[dest]
+		b.tokens = math.Min(b.tokens+1, float64(b.limit.Burst))
+		b.stats.Rejected++
+		l.mu.Unlock()
+		return fmt.Errorf("waiting for outbound rate limit: %w", ctx.Err())
+	}
+}
+
+// reserve takes a token for dest, returning how long to wait before using it
+func (l *OutboundLimiter) reserve(dest string) (time.Duration, error) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[dest]
+	if !ok {
+		limit, ok := l.limits[dest]
+		if !ok {
+			limit = l.defaults
+		}
+		limit = normalizeRateLimit(limit)
+		b = &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		l.buckets[dest] = b
+	}
+	if b.limit.Rate <= 0 {
+		b.stats.Allowed++
+		return 0, nil
+	}
+
+	b.tokens = math.Min(b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate, float64(b.limit.Burst))
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		b.stats.Allowed++
+		return 0, nil
+	}
+
+	delay := time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+	if !b.limit.Wait || (b.limit.MaxWait > 0 && delay > b.limit.MaxWait) {
+		b.stats.Rejected++
+		return 0, fmt.Errorf("%w: %s (retry in %s)", ErrRateLimited, dest, delay.Round(time.Millisecond))
+	}
+	// Tokens go negative so later requests queue behind this one
+	b.tokens--
+	return delay, nil
+}
+
+// Stats returns the counters of every destination seen so far
+func (l *OutboundLimiter) Stats() map[string]RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := make(map[string]RateLimitStats, len(l.buckets))
+	for dest, b := range l.buckets {
+		stats[dest] = b.stats
+	}
+	return stats
+}
+
+// normalizeRateLimit fills in the default burst
+func normalizeRateLimit(limit RateLimit) RateLimit {
+	if limit.Burst <= 0 {
+		limit.Burst = max(1, int(math.Ceil(limit.Rate)))
+	}
+	return limit
+}
+
+// WithRateLimiter rate-limits the signed requests of the transport with
+// limiter. Requests are keyed by the target agent's DID when it is known
+// (see WithAgentCardSigner), otherwise by the base URL.
+func WithRateLimiter(limiter *OutboundLimiter) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.rateLimiter = limiter
+	}
+}
+
+// rateLimitKey returns the destination key used for rate limiting
+func (t *DIDHTTPTransport) rateLimitKey() string {
+	if t.cardSignerDID != "" {
+		return string(t.cardSignerDID)
+	}
+	return t.baseURL
+}
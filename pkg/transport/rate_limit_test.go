@@ -0,0 +1,112 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboundLimiter_FastFail(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewOutboundLimiter(RateLimit{Rate: 2, Burst: 2})
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Wait(ctx, "a"))
+	require.NoError(t, limiter.Wait(ctx, "a"))
+	assert.ErrorIs(t, limiter.Wait(ctx, "a"), ErrRateLimited)
+
+	// Destinations have separate buckets
+	require.NoError(t, limiter.Wait(ctx, "b"))
+
+	// Tokens refill at Rate per second
+	now = now.Add(500 * time.Millisecond)
+	require.NoError(t, limiter.Wait(ctx, "a"))
+	assert.ErrorIs(t, limiter.Wait(ctx, "a"), ErrRateLimited)
+
+	stats := limiter.Stats()
+	assert.Equal(t, RateLimitStats{Allowed: 3, Rejected: 2}, stats["a"])
+	assert.Equal(t, RateLimitStats{Allowed: 1}, stats["b"])
+}
+
+func TestOutboundLimiter_Queue(t *testing.T) {
+	limiter := NewOutboundLimiter(RateLimit{Rate: 50, Burst: 1, Wait: true})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.Wait(ctx, "a"))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	stats := limiter.Stats()["a"]
+	assert.Equal(t, uint64(1), stats.Allowed)
+	assert.Equal(t, uint64(2), stats.Queued)
+	assert.Positive(t, stats.Waited)
+}
+
+func TestOutboundLimiter_QueueLimits(t *testing.T) {
+	limiter := NewOutboundLimiter(RateLimit{Rate: 1, Burst: 1, Wait: true})
+	limiter.SetLimit("capped", RateLimit{Rate: 1, Burst: 1, Wait: true, MaxWait: 10 * time.Millisecond})
+
+	require.NoError(t, limiter.Wait(context.Background(), "capped"))
+	assert.ErrorIs(t, limiter.Wait(context.Background(), "capped"), ErrRateLimited)
+
+	require.NoError(t, limiter.Wait(context.Background(), "a"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx, "a"), context.DeadlineExceeded)
+	assert.Equal(t, uint64(1), limiter.Stats()["a"].Rejected)
+}
+
+func TestOutboundLimiter_Unlimited(t *testing.T) {
+	limiter := NewOutboundLimiter(RateLimit{})
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), "a"))
+	}
+}
+
+func TestDIDHTTPTransport_RateLimiter(t *testing.T) {
+	var calls atomic.Int32
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(taskResult("task-1")))
+	})
+	defer server.Close()
+
+	limiter := NewOutboundLimiter(RateLimit{Rate: 0.001, Burst: 2})
+	WithRateLimiter(limiter)(transport)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := transport.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+		require.NoError(t, err)
+	}
+	_, err := transport.GetTask(ctx, &a2a.TaskQueryParams{ID: "task-1"})
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, int32(2), calls.Load(), "rate-limited requests are not sent")
+	assert.Equal(t, uint64(1), limiter.Stats()[server.URL].Rejected)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// ReceiptStore keeps the request receipts returned by servers as proof of
+// submission
+type ReceiptStore interface {
+	// SaveReceipt stores a verified receipt
+	SaveReceipt(ctx context.Context, receipt *protocol.Receipt) error
+
+	// Receipts returns the stored receipts for taskID, oldest first
+	Receipts(ctx context.Context, taskID a2a.TaskID) ([]*protocol.Receipt, error)
+}
+
+// MemoryReceiptStore is a ReceiptStore keeping receipts in memory
+type MemoryReceiptStore struct {
+	mu       sync.Mutex
+	receipts map[a2a.TaskID][]*protocol.Receipt
+}
+
+// NewMemoryReceiptStore creates an empty MemoryReceiptStore
+func NewMemoryReceiptStore() *MemoryReceiptStore {
+	return &MemoryReceiptStore{receipts: make(map[a2a.TaskID][]*protocol.Receipt)}
+}
+
+// SaveReceipt implements ReceiptStore
+func (s *MemoryReceiptStore) SaveReceipt(ctx context.Context, receipt *protocol.Receipt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.receipts[receipt.TaskID] = append(s.receipts[receipt.TaskID], receipt)
+	return nil
+}
+
+// Receipts implements ReceiptStore
+func (s *MemoryReceiptStore) Receipts(ctx context.Context, taskID a2a.TaskID) ([]*protocol.Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*protocol.Receipt(nil), s.receipts[taskID]...), nil
+}
+
+// WithReceiptStore verifies the receipts servers return in the
+// A2A-Receipt header (see server.ReceiptHandler) and saves them to store.
+// The issuer key is resolved with resolve; when WithAgentCardSigner is
+// set, receipts must also be issued by its DID. A receipt that fails
+// verification fails the call even though the server processed the
+// request. Responses without a receipt are accepted.
+func WithReceiptStore(store ReceiptStore, resolve protocol.CardKeyResolver) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.receiptStore = store
+		t.receiptKeyResolver = resolve
+	}
+}
+
+// storeReceipt verifies and saves the receipt of resp, if any, for a
+// request with the given body
+func (t *DIDHTTPTransport) storeReceipt(ctx context.Context, resp *http.Response, body []byte) error {
+	token := resp.Header.Get(protocol.ReceiptHeader)
+	if t.receiptStore == nil || token == "" {
+		return nil
+	}
+	receipt, err := protocol.VerifyReceiptFrom(ctx, token, t.receiptKeyResolver)
+	if err != nil {
+		return fmt.Errorf("failed to verify receipt: %w", err)
+	}
+	if err := receipt.Matches(t.cardSignerDID, t.agentDID, t.handlerBody(body)); err != nil {
+		return fmt.Errorf("failed to verify receipt: %w", err)
+	}
+	if err := t.receiptStore.SaveReceipt(ctx, receipt); err != nil {
+		return fmt.Errorf("failed to save receipt: %w", err)
+	}
+	return nil
+}
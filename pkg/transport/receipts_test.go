@@ -0,0 +1,71 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithReceiptStore(t *testing.T) {
+	const issuer = did.AgentDID("did:sage:ethereum:0xserver")
+	serverKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	var tamper bool
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if tamper {
+			body = []byte("{}")
+		}
+		receipt, err := protocol.IssueReceipt(issuer, serverKey, "did:sage:ethereum:0x1234567890abcdef", body, "task-1")
+		require.NoError(t, err)
+		w.Header().Set(protocol.ReceiptHeader, receipt.Token)
+		_, _ = w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-1"}))
+	})
+	defer server.Close()
+
+	store := NewMemoryReceiptStore()
+	WithReceiptStore(store, func(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (stdcrypto.PublicKey, error) {
+		return serverKey.PublicKey(), nil
+	})(transport)
+
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	receipts, err := store.Receipts(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	assert.Equal(t, issuer, receipts[0].Issuer)
+
+	// A receipt for another request is rejected
+	tamper = true
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	assert.ErrorIs(t, err, protocol.ErrReceiptMismatch)
+	receipts, _ = store.Receipts(context.Background(), "task-1")
+	assert.Len(t, receipts, 1)
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for NewResponseCache
+const (
+	DefaultResponseCacheEntries   = 256
+	DefaultResponseCacheEntrySize = 1 << 20
+)
+
+// cachedResponse is a stored GET response
+type cachedResponse struct {
+	header  http.Header
+	body    []byte
+	expires time.Time // fresh until; zero means revalidate on every use
+	used    time.Time
+}
+
+// ResponseCache is a private HTTP cache for the signed GET requests of
+// DIDHTTPTransport (agent cards and artifact downloads). Responses are kept
+// fresh as allowed by Cache-Control max-age or Expires and served without
+// signing or sending a request. Stale responses with an ETag or
+// Last-Modified are revalidated with a newly signed conditional request.
+// Responses marked no-store are never cached. Entries are keyed by URL and
+// the requesting DID, so share one cache between transports freely.
+type ResponseCache struct {
+	maxEntries   int
+	maxEntrySize int
+	now          func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+// NewResponseCache creates a cache holding up to maxEntries responses of at
+// most maxEntrySize bytes each (DefaultResponseCacheEntries and
+// DefaultResponseCacheEntrySize if <= 0). The least recently used entry
+// is evicted when the cache is full.
+func NewResponseCache(maxEntries, maxEntrySize int) *ResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultResponseCacheEntries
+	}
+	if maxEntrySize <= 0 {
+		maxEntrySize = DefaultResponseCacheEntrySize
+	}
+	return &ResponseCache{
+		maxEntries:   maxEntries,
+		maxEntrySize: maxEntrySize,
+		now:          time.Now,
+		entries:      make(map[string]*cachedResponse),
+	}
+}
+
+// WithResponseCache caches signed GET responses in cache. GetAgentCard
+// uses it unless WithAgentCardCache is also set; DownloadArtifact uses it
+// for downloads starting at offset zero.
+func WithResponseCache(cache *ResponseCache) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.responseCache = cache
+	}
+}
+
+// Len returns the number of cached responses
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// invalidate drops the entry for key
+func (c *ResponseCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// cacheKey returns the cache key of a GET of url by this transport
+func (t *DIDHTTPTransport) cacheKey(url string) string {
+	return string(t.agentDID) + " " + url
+}
+
+// do serves the GET for key from cache while fresh. Otherwise it calls
+// send with the conditional request headers to add (and sign), answers 304
+// responses from cache and stores cacheable 200 responses once their body
+// has been read to the end. fromCache reports whether resp came from the
+// cache, including after revalidation. force revalidates fresh entries.
+func (c *ResponseCache) do(key string, force bool, send func(header http.Header) (*http.Response, error)) (resp *http.Response, fromCache bool, err error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	var stored cachedResponse
+	if ok {
+		entry.used = c.now()
+		stored = *entry
+	}
+	c.mu.Unlock()
+
+	if ok && !force && c.now().Before(stored.expires) {
+		return stored.response(), true, nil
+	}
+
+	header := make(http.Header)
+	if ok {
+		if etag := stored.header.Get("ETag"); etag != "" {
+			header.Set("If-None-Match", etag)
+		}
+		if lm := stored.header.Get("Last-Modified"); lm != "" {
+			header.Set("If-Modified-Since", lm)
+		}
+	}
+	resp, err = send(header)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		resp.Body.Close()
+		stored.header = stored.header.Clone()
+		for _, name := range []string{"Cache-Control", "Date", "ETag", "Expires", "Last-Modified"} {
+			if v := resp.Header.Values(name); len(v) > 0 {
+				stored.header[name] = v
+			}
+		}
+		if c.storable(stored.header) {
+			stored.expires = c.expiry(stored.header)
+			stored.used = c.now()
+			c.put(key, stored)
+		} else {
+			c.invalidate(key)
+		}
+		return stored.response(), true, nil
+	case resp.StatusCode == http.StatusOK && c.storable(resp.Header):
+		if resp.ContentLength <= int64(c.maxEntrySize) {
+			resp.Body = &cachingBody{ReadCloser: resp.Body, cache: c, key: key, header: resp.Header.Clone()}
+		}
+	default:
+		c.invalidate(key)
+	}
+	return resp, false, nil
+}
+
+// response returns a synthesized 200 response for the entry
+func (e *cachedResponse) response() *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+	}
+}
+
+func (c *ResponseCache) put(key string, entry cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		var oldest string
+		for k, e := range c.entries {
+			if oldest == "" || e.used.Before(c.entries[oldest].used) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = &entry
+}
+
+// storable reports whether a response with header may be cached: not
+// no-store, and either fresh for a while or revalidatable
+func (c *ResponseCache) storable(header http.Header) bool {
+	if _, noStore := cacheControl(header)["no-store"]; noStore {
+		return false
+	}
+	return c.expiry(header).After(c.now()) || header.Get("ETag") != "" || header.Get("Last-Modified") != ""
+}
+
+// expiry returns when a response with header becomes stale
+func (c *ResponseCache) expiry(header http.Header) time.Time {
+	now := c.now()
+	directives := cacheControl(header)
+	if _, noCache := directives["no-cache"]; noCache {
+		return time.Time{}
+	}
+	if v, ok := directives["max-age"]; ok {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil && secs > 0 {
+			return now.Add(time.Duration(secs) * time.Second)
+		}
+		return time.Time{}
+	}
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		if date, err := http.ParseTime(header.Get("Date")); err == nil {
+			return now.Add(expires.Sub(date))
+		}
+		return expires
+	}
+	return time.Time{}
+}
+
+// cacheControl parses the Cache-Control directives of header
+func cacheControl(header http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, value := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name != "" {
+				directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+			}
+		}
+	}
+	return directives
+}
+
+// cachingBody stores a response in the cache once it has been read
+// completely and fits in an entry
+type cachingBody struct {
+	io.ReadCloser
+	cache  *ResponseCache
+	key    string
+	header http.Header
+	buf    bytes.Buffer
+	over   bool
+}
+
+// Read implements io.Reader
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if !b.over {
+		if b.buf.Len()+n > b.cache.maxEntrySize {
+			b.over = true
+			b.buf = bytes.Buffer{}
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !b.over {
+		b.over = true // store once
+		now := b.cache.now()
+		b.cache.put(b.key, cachedResponse{
+			header:  b.header,
+			body:    bytes.Clone(b.buf.Bytes()),
+			expires: b.cache.expiry(b.header),
+			used:    now,
+		})
+	}
+	return n, err
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache_AgentCard(t *testing.T) {
+	var cacheControl string
+	var fetches, signed, notModified int
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if r.Header.Get("Signature") != "" {
+			signed++
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Cache-Control", cacheControl)
+		w.Header().Set("ETag", `"v1"`)
+		_ = json.NewEncoder(w).Encode(a2a.AgentCard{Name: "v1"})
+	})
+	defer server.Close()
+
+	now := time.Now()
+	cache := NewResponseCache(0, 0)
+	cache.now = func() time.Time { return now }
+	WithResponseCache(cache)(transport)
+	ctx := context.Background()
+
+	// Fresh responses are served without signing or sending a request
+	cacheControl = "max-age=60"
+	for i := 0; i < 3; i++ {
+		card, err := transport.GetAgentCard(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", card.Name)
+	}
+	assert.Equal(t, 1, fetches)
+	assert.Equal(t, 1, signed)
+
+	// Stale responses are revalidated with a signed conditional request
+	now = now.Add(2 * time.Minute)
+	card, err := transport.GetAgentCard(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", card.Name)
+	assert.Equal(t, 2, signed)
+	assert.Equal(t, 1, notModified)
+
+	// no-store responses are never cached
+	cache = NewResponseCache(0, 0)
+	WithResponseCache(cache)(transport)
+	cacheControl = "no-store"
+	_, err = transport.GetAgentCard(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, cache.Len())
+}
+
+func TestResponseCache_VerificationFailureNotCached(t *testing.T) {
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		_ = json.NewEncoder(w).Encode(a2a.AgentCard{Name: "forged"})
+	})
+	defer server.Close()
+
+	cache := NewResponseCache(0, 0)
+	WithResponseCache(cache)(transport)
+	WithAgentCardVerifier(func(ctx context.Context, card *a2a.AgentCard) error {
+		return assert.AnError
+	})(transport)
+
+	_, err := transport.GetAgentCard(context.Background())
+	require.Error(t, err)
+	assert.Zero(t, cache.Len())
+}
+
+func TestResponseCache_Eviction(t *testing.T) {
+	cache := NewResponseCache(2, 4)
+	send := func(body string) func(http.Header) (*http.Response, error) {
+		return func(http.Header) (*http.Response, error) {
+			resp := (&cachedResponse{header: http.Header{"Cache-Control": {"max-age=60"}}, body: []byte(body)}).response()
+			return resp, nil
+		}
+	}
+	read := func(key, body string) {
+		resp, _, err := cache.do(key, false, send(body))
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(resp.Body)
+	}
+
+	read("a", "1")
+	read("b", "2")
+	read("c", "3")
+	assert.Equal(t, 2, cache.Len())
+	read("d", "too large")
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestResponseCache_ArtifactDownload(t *testing.T) {
+	ctx := context.Background()
+	var puts atomic.Int32
+	tr, _, _ := artifactFixture(t, &puts)
+	cache := NewResponseCache(0, 0)
+	WithResponseCache(cache)(tr)
+
+	content := "cached artifact"
+	_, err := tr.UploadArtifact(ctx, "doc", strings.NewReader(content))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		f, err := os.Create(filepath.Join(t.TempDir(), "doc"))
+		require.NoError(t, err)
+		n, err := tr.DownloadArtifact(ctx, "doc", f)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(content)), n)
+		data, _ := os.ReadFile(f.Name())
+		f.Close()
+		assert.Equal(t, content, string(data))
+		assert.Equal(t, 1, cache.Len())
+	}
+}
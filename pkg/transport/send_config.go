@@ -0,0 +1,116 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"slices"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+)
+
+// sendDefaults holds client preferences applied to outbound calls that
+// leave them unset
+type sendDefaults struct {
+	acceptedOutputModes []string
+	historyLength       *int
+	blocking            bool
+	pushConfig          *a2a.PushConfig
+}
+
+// WithClientConfig applies the preferences of an a2aclient.Config to
+// message/send and message/stream calls: its AcceptedOutputModes and the
+// first of its PushConfigs. The a2aclient.Client does not pass its config
+// to transports, so give the same config to both:
+//
+//	a2aclient.NewFromCard(ctx, card,
+//		a2aclient.WithConfig(cfg),
+//		transport.WithDIDHTTPTransport(myDID, myKeyPair, nil, transport.WithClientConfig(cfg)))
+func WithClientConfig(cfg a2aclient.Config) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.sendDefaults.acceptedOutputModes = slices.Clone(cfg.AcceptedOutputModes)
+		if len(cfg.PushConfigs) > 0 {
+			push := cfg.PushConfigs[0]
+			t.sendDefaults.pushConfig = &push
+		}
+	}
+}
+
+// WithAcceptedOutputModes sets the output MIME types declared on
+// message/send and message/stream calls that do not declare their own
+func WithAcceptedOutputModes(modes ...string) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.sendDefaults.acceptedOutputModes = slices.Clone(modes)
+	}
+}
+
+// WithHistoryLength sets the number of history messages requested by
+// message/send, message/stream and tasks/get calls that do not set one
+func WithHistoryLength(n int) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.sendDefaults.historyLength = &n
+	}
+}
+
+// WithBlocking makes message/send calls wait for the task to complete by
+// default. A false Blocking in the call's own configuration cannot be told
+// apart from an unset one, so it does not override this default.
+func WithBlocking(blocking bool) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.sendDefaults.blocking = blocking
+	}
+}
+
+// withSendDefaults returns params with unset configuration filled from the
+// transport's defaults. params is not modified.
+func (t *DIDHTTPTransport) withSendDefaults(params *a2a.MessageSendParams) *a2a.MessageSendParams {
+	d := t.sendDefaults
+	if params == nil || (d.acceptedOutputModes == nil && d.historyLength == nil && !d.blocking && d.pushConfig == nil) {
+		return params
+	}
+
+	p := *params
+	var cfg a2a.MessageSendConfig
+	if p.Config != nil {
+		cfg = *p.Config
+	}
+	if len(cfg.AcceptedOutputModes) == 0 {
+		cfg.AcceptedOutputModes = d.acceptedOutputModes
+	}
+	if cfg.HistoryLength == nil {
+		cfg.HistoryLength = d.historyLength
+	}
+	if !cfg.Blocking {
+		cfg.Blocking = d.blocking
+	}
+	if cfg.PushConfig == nil {
+		cfg.PushConfig = d.pushConfig
+	}
+	p.Config = &cfg
+	return &p
+}
+
+// withQueryDefaults returns query with the default history length if it
+// sets none. query is not modified.
+func (t *DIDHTTPTransport) withQueryDefaults(query *a2a.TaskQueryParams) *a2a.TaskQueryParams {
+	if query == nil || query.HistoryLength != nil || t.sendDefaults.historyLength == nil {
+		return query
+	}
+	q := *query
+	q.HistoryLength = t.sendDefaults.historyLength
+	return &q
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureParams returns a handler recording the params of each call
+func captureParams(params *json.RawMessage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			Params json.RawMessage `json:"params"`
+		}
+		_ = json.Unmarshal(body, &req)
+		*params = req.Params
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(taskResult("task-1")))
+	}
+}
+
+func TestDIDHTTPTransport_ClientConfig(t *testing.T) {
+	var params json.RawMessage
+	transport, server := setupTestTransport(t, captureParams(&params))
+	defer server.Close()
+
+	WithClientConfig(a2aclient.Config{
+		AcceptedOutputModes: []string{"application/json"},
+		PushConfigs:         []a2a.PushConfig{{URL: "https://client.example.com/push"}},
+	})(transport)
+	WithHistoryLength(5)(transport)
+	WithBlocking(true)(transport)
+
+	msg := &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})}
+	_, err := transport.SendMessage(context.Background(), msg)
+	require.NoError(t, err)
+	assert.Nil(t, msg.Config, "caller params are not modified")
+
+	var sent a2a.MessageSendParams
+	require.NoError(t, json.Unmarshal(params, &sent))
+	require.NotNil(t, sent.Config)
+	assert.Equal(t, []string{"application/json"}, sent.Config.AcceptedOutputModes)
+	assert.True(t, sent.Config.Blocking)
+	require.NotNil(t, sent.Config.HistoryLength)
+	assert.Equal(t, 5, *sent.Config.HistoryLength)
+	require.NotNil(t, sent.Config.PushConfig)
+	assert.Equal(t, "https://client.example.com/push", sent.Config.PushConfig.URL)
+
+	// The call's own preferences win
+	two := 2
+	msg.Config = &a2a.MessageSendConfig{AcceptedOutputModes: []string{"text/plain"}, HistoryLength: &two}
+	_, err = transport.SendMessage(context.Background(), msg)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(params, &sent))
+	assert.Equal(t, []string{"text/plain"}, sent.Config.AcceptedOutputModes)
+	assert.Equal(t, 2, *sent.Config.HistoryLength)
+
+	// tasks/get inherits the history length
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	var query a2a.TaskQueryParams
+	require.NoError(t, json.Unmarshal(params, &query))
+	require.NotNil(t, query.HistoryLength)
+	assert.Equal(t, 5, *query.HistoryLength)
+}
+
+func TestDIDHTTPTransport_NoClientConfig(t *testing.T) {
+	var params json.RawMessage
+	transport, server := setupTestTransport(t, captureParams(&params))
+	defer server.Close()
+
+	_, err := transport.SendMessage(context.Background(), &a2a.MessageSendParams{
+		Message: a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"}),
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, string(params), "configuration")
+}
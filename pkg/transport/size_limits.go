@@ -0,0 +1,146 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// agentCardMethod labels agent card fetches in SizeStats
+const agentCardMethod = "agent/card"
+
+// ErrResponseTooLarge is returned when a response body, or a single event of
+// a streaming response, exceeds the configured maximum size
+var ErrResponseTooLarge = errors.New("response too large")
+
+// WithMaxResponseSize limits decoded response bodies to maxBytes. For
+// streaming calls the limit applies to each event. Zero (the default)
+// disables the limit.
+func WithMaxResponseSize(maxBytes int64) TransportOption {
+	return func(t *DIDHTTPTransport) {
+		t.maxResponseSize = maxBytes
+	}
+}
+
+// MethodSizeStats holds byte counters for one JSON-RPC method. Bytes are
+// counted on the wire, i.e. after compression.
+type MethodSizeStats struct {
+	Requests uint64
+	BytesOut uint64
+	BytesIn  uint64
+}
+
+// methodCounters is the mutable form of MethodSizeStats
+type methodCounters struct {
+	requests atomic.Uint64
+	bytesOut atomic.Uint64
+	bytesIn  atomic.Uint64
+}
+
+// sizeMetrics tracks per-method byte counters
+type sizeMetrics struct {
+	methods sync.Map // string -> *methodCounters
+}
+
+func (m *sizeMetrics) counters(method string) *methodCounters {
+	if c, ok := m.methods.Load(method); ok {
+		return c.(*methodCounters)
+	}
+	c, _ := m.methods.LoadOrStore(method, &methodCounters{})
+	return c.(*methodCounters)
+}
+
+// SizeStats returns bytes sent and received per JSON-RPC method since the
+// transport was created. Agent card fetches are reported as "agent/card".
+func (t *DIDHTTPTransport) SizeStats() map[string]MethodSizeStats {
+	stats := make(map[string]MethodSizeStats)
+	t.sizes.methods.Range(func(k, v any) bool {
+		c := v.(*methodCounters)
+		stats[k.(string)] = MethodSizeStats{
+			Requests: c.requests.Load(),
+			BytesOut: c.bytesOut.Load(),
+			BytesIn:  c.bytesIn.Load(),
+		}
+		return true
+	})
+	return stats
+}
+
+// recordRequest counts an outgoing request body of n bytes
+func (t *DIDHTTPTransport) recordRequest(method string, n int64) {
+	c := t.sizes.counters(method)
+	c.requests.Add(1)
+	if n > 0 {
+		c.bytesOut.Add(uint64(n))
+	}
+}
+
+// countingReadCloser counts bytes read into a method's BytesIn counter
+type countingReadCloser struct {
+	io.ReadCloser
+	counter *atomic.Uint64
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.counter.Add(uint64(n))
+	return n, err
+}
+
+// countResponse wraps body so bytes read are attributed to method
+func (t *DIDHTTPTransport) countResponse(method string, body io.ReadCloser) io.ReadCloser {
+	return &countingReadCloser{ReadCloser: body, counter: &t.sizes.counters(method).bytesIn}
+}
+
+// readLimited reads all of r, failing with ErrResponseTooLarge beyond limit
+// bytes. A limit of zero or less reads without bound.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, limit)
+	}
+	return data, nil
+}
+
+// readSSELine reads one line without buffering more than limit bytes.
+// A limit of zero or less reads without bound.
+func readSSELine(r *bufio.Reader, limit int64) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		line = append(line, chunk...)
+		if limit > 0 && int64(len(line)) > limit {
+			return nil, fmt.Errorf("%w: SSE event exceeds %d bytes", ErrResponseTooLarge, limit)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		return line, err
+	}
+}
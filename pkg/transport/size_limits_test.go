@@ -0,0 +1,149 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func taskResult(id string) map[string]interface{} {
+	return map[string]interface{}{"id": id, "contextId": "ctx", "kind": "task", "status": map[string]interface{}{"state": "working"}}
+}
+
+func TestDIDHTTPTransport_MaxResponseSize(t *testing.T) {
+	body := mockJSONRPCResponse(taskResult(strings.Repeat("x", 4096)))
+
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+	defer server.Close()
+
+	WithMaxResponseSize(1024)(transport)
+	_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+
+	WithMaxResponseSize(int64(len(body)))(transport)
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+}
+
+func TestDIDHTTPTransport_MaxResponseSize_CompressedBomb(t *testing.T) {
+	// A small compressed body that expands far beyond the limit
+	compressed, err := compression.Compress("gzip", mockJSONRPCResponse(taskResult(strings.Repeat("a", 1<<20))))
+	require.NoError(t, err)
+	require.Less(t, len(compressed), 8192)
+
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed)
+	})
+	defer server.Close()
+
+	transport.SetCompression(compression.DefaultConfig())
+	WithMaxResponseSize(64 * 1024)(transport)
+
+	_, err = transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+func TestDIDHTTPTransport_MaxResponseSize_AgentCard(t *testing.T) {
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"name":"agent","description":%q}`, strings.Repeat("d", 4096))
+	})
+	defer server.Close()
+
+	WithMaxResponseSize(1024)(transport)
+	_, err := transport.GetAgentCard(context.Background())
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+func TestDIDHTTPTransport_MaxResponseSize_SSEEvent(t *testing.T) {
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		small := mockJSONRPCResponse(map[string]interface{}{"message": &a2a.Message{ID: "small"}})
+		fmt.Fprintf(w, "data: %s\n\n", small)
+		fmt.Fprintf(w, "data: %s\n\n", strings.Repeat("z", 8192))
+	})
+	defer server.Close()
+
+	WithMaxResponseSize(1024)(transport)
+
+	var (
+		events int
+		errs   []error
+	)
+	for event, err := range transport.SendStreamingMessage(context.Background(), &a2a.MessageSendParams{
+		Message: &a2a.Message{Role: a2a.MessageRoleUser},
+	}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if event != nil {
+			events++
+		}
+	}
+	assert.Equal(t, 1, events)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrResponseTooLarge)
+}
+
+func TestDIDHTTPTransport_SizeStats(t *testing.T) {
+	response := mockJSONRPCResponse(taskResult("task-1"))
+
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/.well-known/agent-card.json" {
+			w.Write([]byte(`{"name":"agent"}`))
+			return
+		}
+		w.Write(response)
+	})
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err := transport.GetTask(context.Background(), &a2a.TaskQueryParams{ID: "task-1"})
+		require.NoError(t, err)
+	}
+	_, err := transport.GetAgentCard(context.Background())
+	require.NoError(t, err)
+
+	stats := transport.SizeStats()
+	get := stats["tasks/get"]
+	assert.Equal(t, uint64(2), get.Requests)
+	assert.Greater(t, get.BytesOut, uint64(0))
+	assert.Equal(t, uint64(2*len(response)), get.BytesIn)
+
+	card := stats["agent/card"]
+	assert.Equal(t, uint64(1), card.Requests)
+	assert.Equal(t, uint64(0), card.BytesOut)
+	assert.Equal(t, uint64(len(`{"name":"agent"}`)), card.BytesIn)
+}
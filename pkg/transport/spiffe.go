@@ -0,0 +1,98 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// NewSVIDTLSConfig returns a TLS configuration that presents a SPIFFE SVID
+// as the client certificate and authenticates the server by its SVID.
+//
+// getCertificate is called on every handshake so rotated SVIDs are picked
+// up. The server chain is verified against bundle; SPIFFE certificates
+// carry no DNS names, so hostname verification is replaced by a check on
+// the server's SPIFFE ID. If serverID is empty, any SPIFFE ID is accepted.
+func NewSVIDTLSConfig(getCertificate func() (*tls.Certificate, error), bundle *x509.CertPool, serverID string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return getCertificate()
+		},
+		// Verification is done in VerifyPeerCertificate below
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyServerSVID(rawCerts, bundle, serverID)
+		},
+	}
+}
+
+// NewSVIDHTTPClient returns an HTTP client presenting a SPIFFE SVID over
+// mTLS. Pass it to NewDIDHTTPTransport to authenticate with both the SVID
+// and a DID signature.
+func NewSVIDHTTPClient(getCertificate func() (*tls.Certificate, error), bundle *x509.CertPool, serverID string) *http.Client {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = NewSVIDTLSConfig(getCertificate, bundle, serverID)
+	return &http.Client{Transport: tr}
+}
+
+// verifyServerSVID verifies the server's certificate chain against bundle
+// and checks its SPIFFE ID
+func verifyServerSVID(rawCerts [][]byte, bundle *x509.CertPool, serverID string) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("server presented no certificate")
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse server certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         bundle,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err != nil {
+		return fmt.Errorf("failed to verify server SVID: %w", err)
+	}
+
+	var ids []string
+	for _, uri := range certs[0].URIs {
+		if uri.Scheme == "spiffe" {
+			ids = append(ids, uri.String())
+		}
+	}
+	if len(ids) != 1 {
+		return fmt.Errorf("server certificate must carry exactly one SPIFFE ID, got %d", len(ids))
+	}
+	if serverID != "" && ids[0] != serverID {
+		return fmt.Errorf("unexpected server SPIFFE ID %s (want %s)", ids[0], serverID)
+	}
+	return nil
+}
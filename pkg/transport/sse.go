@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"iter"
@@ -29,6 +30,8 @@ import (
 	"strings"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/codec"
+	"github.com/sage-x-project/sage-a2a-go/pkg/compression"
 )
 
 // sseEvent represents a single Server-Sent Event
@@ -60,7 +63,10 @@ type sseEvent struct {
 //   - Event IDs for resumption
 //   - Context cancellation
 //   - Connection errors
-func parseSSEStream(ctx context.Context, resp *http.Response) iter.Seq2[a2a.Event, error] {
+//
+// A positive maxEventSize bounds the data of a single event; format selects
+// how event results are decoded.
+func parseSSEStream(ctx context.Context, resp *http.Response, maxEventSize int64, format EventFormat) iter.Seq2[a2a.Event, error] {
 	return func(yield func(a2a.Event, error) bool) {
 		defer resp.Body.Close()
 
@@ -78,8 +84,12 @@ func parseSSEStream(ctx context.Context, resp *http.Response) iter.Seq2[a2a.Even
 			}
 
 			// Read a line from the stream
-			line, err := reader.ReadBytes('\n')
+			line, err := readSSELine(reader, maxEventSize)
 			if err != nil {
+				if errors.Is(err, ErrResponseTooLarge) {
+					yield(nil, err)
+					return
+				}
 				if err == io.EOF {
 					// Stream ended normally
 					return
@@ -98,7 +108,7 @@ func parseSSEStream(ctx context.Context, resp *http.Response) iter.Seq2[a2a.Even
 					dataBuffer.Reset()
 
 					// Parse the JSON-RPC response from the SSE data
-					event, err := parseSSEData(currentEvent.Data)
+					event, err := parseSSEData(currentEvent.Data, format)
 					if err != nil {
 						if !yield(nil, err) {
 							return
@@ -144,6 +154,10 @@ func parseSSEStream(ctx context.Context, resp *http.Response) iter.Seq2[a2a.Even
 					dataBuffer.WriteByte('\n')
 				}
 				dataBuffer.Write(value)
+				if maxEventSize > 0 && int64(dataBuffer.Len()) > maxEventSize {
+					yield(nil, fmt.Errorf("%w: SSE event exceeds %d bytes", ErrResponseTooLarge, maxEventSize))
+					return
+				}
 			case "id":
 				currentEvent.ID = string(value)
 			case "retry":
@@ -163,7 +177,9 @@ func parseSSEStream(ctx context.Context, resp *http.Response) iter.Seq2[a2a.Even
 //   - Task
 //   - TaskStatusUpdateEvent
 //   - TaskArtifactUpdateEvent
-func parseSSEData(data []byte) (a2a.Event, error) {
+//
+// format selects how the result identifies its type (see EventFormat).
+func parseSSEData(data []byte, format EventFormat) (a2a.Event, error) {
 	// Parse JSON-RPC response wrapper
 	var rpcResp struct {
 		JSONRPC string          `json:"jsonrpc"`
@@ -185,61 +201,21 @@ func parseSSEData(data []byte) (a2a.Event, error) {
 			rpcResp.Error.Code, rpcResp.Error.Message)
 	}
 
-	// The result can be Message, Task, TaskStatusUpdateEvent, or TaskArtifactUpdateEvent
-	// We need to determine which type it is by trying to unmarshal
-
-	// Try to determine the type by looking for discriminating fields
-	var typeCheck struct {
-		Message        json.RawMessage `json:"message"`
-		Task           json.RawMessage `json:"task"`
-		StatusUpdate   json.RawMessage `json:"statusUpdate"`
-		ArtifactUpdate json.RawMessage `json:"artifactUpdate"`
-	}
-
-	if err := json.Unmarshal(rpcResp.Result, &typeCheck); err != nil {
-		return nil, fmt.Errorf("failed to parse SSE result structure: %w", err)
-	}
-
-	// Determine which field is present and parse accordingly
-	if typeCheck.Message != nil {
-		var msg a2a.Message
-		if err := json.Unmarshal(typeCheck.Message, &msg); err != nil {
-			return nil, fmt.Errorf("failed to parse Message from SSE: %w", err)
-		}
-		return &msg, nil
-	}
-
-	if typeCheck.Task != nil {
-		var task a2a.Task
-		if err := json.Unmarshal(typeCheck.Task, &task); err != nil {
-			return nil, fmt.Errorf("failed to parse Task from SSE: %w", err)
-		}
-		return &task, nil
-	}
-
-	if typeCheck.StatusUpdate != nil {
-		var statusEvent a2a.TaskStatusUpdateEvent
-		if err := json.Unmarshal(typeCheck.StatusUpdate, &statusEvent); err != nil {
-			return nil, fmt.Errorf("failed to parse TaskStatusUpdateEvent from SSE: %w", err)
-		}
-		return &statusEvent, nil
-	}
-
-	if typeCheck.ArtifactUpdate != nil {
-		var artifactEvent a2a.TaskArtifactUpdateEvent
-		if err := json.Unmarshal(typeCheck.ArtifactUpdate, &artifactEvent); err != nil {
-			return nil, fmt.Errorf("failed to parse TaskArtifactUpdateEvent from SSE: %w", err)
-		}
-		return &artifactEvent, nil
-	}
-
-	return nil, fmt.Errorf("unknown SSE event type in result")
+	return decodeEvent(rpcResp.Result, format)
 }
 
 // callSSE makes an HTTP request expecting an SSE stream response.
 // It returns an iterator of A2A events.
 func (t *DIDHTTPTransport) callSSE(ctx context.Context, method string, params any) iter.Seq2[a2a.Event, error] {
 	return func(yield func(a2a.Event, error) bool) {
+		ctx, cancel := t.methodContext(ctx, method)
+		defer cancel()
+
+		var status, attempts int
+		fail := func(err error) {
+			yield(nil, t.callError(method, status, attempts, err))
+		}
+
 		// Create JSON-RPC request
 		rpcReq := jsonRPCRequest{
 			JSONRPC: "2.0",
@@ -251,50 +227,71 @@ func (t *DIDHTTPTransport) callSSE(ctx context.Context, method string, params an
 		// Marshal request body
 		body, err := json.Marshal(rpcReq)
 		if err != nil {
-			yield(nil, fmt.Errorf("failed to marshal JSON-RPC request: %w", err))
+			fail(fmt.Errorf("failed to marshal JSON-RPC request: %w", err))
 			return
 		}
 
-		// Create HTTP request
-		req, err := http.NewRequestWithContext(ctx, "POST", t.baseURL+"/rpc", bytes.NewReader(body))
+		// Propagate caller cancellation of a live task to the server
+		tracker := newStreamTaskTracker(params)
+		defer t.propagateCancel(ctx, tracker)
+
+		// Sign and execute HTTP request
+		resp, attempts, err := t.doRPC(ctx, method, body, "text/event-stream")
 		if err != nil {
-			yield(nil, fmt.Errorf("failed to create HTTP request: %w", err))
+			fail(err)
 			return
 		}
+		status = resp.StatusCode
+		t.observeDigestPreference(resp)
+		resp.Body = t.countResponse(method, resp.Body)
 
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Accept", "text/event-stream")
-
-		// Sign request with DID
-		if err := t.signer.SignRequest(ctx, req, t.agentDID, t.keyPair); err != nil {
-			yield(nil, fmt.Errorf("failed to sign request with DID: %w", err))
+		// Check HTTP status
+		if resp.StatusCode != http.StatusOK {
+			body, _ := readLimited(resp.Body, maxErrorBodySize)
+			resp.Body.Close()
+			fail(newHTTPError(resp, body))
 			return
 		}
-
-		// Execute HTTP request
-		resp, err := t.httpClient.Do(req)
-		if err != nil {
-			yield(nil, fmt.Errorf("HTTP request failed: %w", err))
+		if err := t.storeReceipt(ctx, resp, body); err != nil {
+			resp.Body.Close()
+			fail(err)
 			return
 		}
 
-		// Verify Content-Type is text/event-stream
+		// Verify Content-Type is text/event-stream or a stream codec
+		// negotiated with WithCodec
 		contentType := resp.Header.Get("Content-Type")
-		if !strings.HasPrefix(contentType, "text/event-stream") {
+		streamCodec, encoded := codec.ForStreamContentType(contentType)
+		if !encoded && !strings.HasPrefix(contentType, "text/event-stream") {
 			resp.Body.Close()
-			yield(nil, fmt.Errorf("unexpected Content-Type: %s, expected text/event-stream", contentType))
+			fail(fmt.Errorf("%w: unexpected Content-Type: %s, expected text/event-stream", ErrStreamingUnavailable, contentType))
 			return
 		}
 
-		// Check HTTP status
-		if resp.StatusCode != http.StatusOK {
+		// Decode compressed stream if the server negotiated an encoding
+		reader, err := compression.NewReader(resp.Header.Get("Content-Encoding"), resp.Body)
+		if err != nil {
 			resp.Body.Close()
-			yield(nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status))
+			fail(err)
 			return
 		}
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{reader, resp.Body}
 
 		// Parse SSE stream
-		for event, err := range parseSSEStream(ctx, resp) {
+		events := parseSSEStream(ctx, resp, t.maxResponseSize, t.eventFormat)
+		if encoded {
+			events = parseCodecStream(ctx, resp, streamCodec, t.maxResponseSize, t.eventFormat)
+		}
+		for event, err := range events {
+			if event != nil {
+				tracker.observe(event)
+			}
+			if err != nil {
+				err = t.callError(method, status, attempts, err)
+			}
 			if !yield(event, err) {
 				return
 			}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+)
+
+// ContinueTask delegates the task tc was exported for (see
+// protocol.ExportTaskContext) to the agent behind the transport: msg is
+// sent with message/send under the task's context ID, presenting tc in
+// the signed A2A-Task-Context header so the agent can link the task it
+// creates to the original one. msg must not reference a task of its own.
+func (t *DIDHTTPTransport) ContinueTask(ctx context.Context, tc *protocol.TaskContext, msg *a2a.Message) (a2a.SendMessageResult, error) {
+	if tc == nil || tc.Token == "" {
+		return nil, fmt.Errorf("task context cannot be nil or unsigned")
+	}
+	if msg == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+	if msg.TaskID != "" {
+		return nil, fmt.Errorf("a continued task cannot reference task %s", msg.TaskID)
+	}
+	if msg.ContextID != "" && msg.ContextID != tc.ContextID {
+		return nil, fmt.Errorf("message contextID %s differs from task context %s", msg.ContextID, tc.ContextID)
+	}
+
+	m := *msg
+	m.ContextID = tc.ContextID
+	if !slices.Contains(m.ReferenceTasks, tc.TaskID) {
+		m.ReferenceTasks = append(append([]a2a.TaskID(nil), m.ReferenceTasks...), tc.TaskID)
+	}
+	return t.SendMessage(protocol.WithTaskContext(ctx, tc), &a2a.MessageSendParams{Message: &m})
+}
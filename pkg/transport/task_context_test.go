@@ -0,0 +1,85 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDHTTPTransport_ContinueTask(t *testing.T) {
+	var (
+		token, sigInput string
+		sent            *a2a.Message
+		verifyErr       error
+		transport       *DIDHTTPTransport
+	)
+	transport, server := setupTestTransport(t, func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get(protocol.TaskContextHeader)
+		sigInput = r.Header.Get("Signature-Input")
+		verifyErr = verifier.NewRFC9421Verifier().VerifyHTTPRequest(r, transport.keyPair.PublicKey())
+		var req struct {
+			Params a2a.MessageSendParams `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sent = req.Params.Message
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockJSONRPCResponse(&a2a.Task{ID: "task-2", ContextID: sent.ContextID}))
+	})
+	defer server.Close()
+
+	originKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	original := &a2a.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		History:   []*a2a.Message{a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "book a flight"})},
+	}
+	tc, err := protocol.ExportTaskContext(transport.agentDID, originKey, original, "", 0)
+	require.NoError(t, err)
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, &a2a.TextPart{Text: "find a hotel too"})
+	result, err := transport.ContinueTask(context.Background(), tc, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-1", result.(*a2a.Task).ContextID)
+
+	assert.Equal(t, tc.Token, token)
+	assert.Contains(t, sigInput, `"a2a-task-context"`)
+	assert.NoError(t, verifyErr)
+	require.NotNil(t, sent)
+	assert.Equal(t, "ctx-1", sent.ContextID)
+	assert.Equal(t, []a2a.TaskID{"task-1"}, sent.ReferenceTasks)
+	assert.Empty(t, msg.ContextID, "the caller's message is not modified")
+
+	// Messages for another task or context are rejected
+	_, err = transport.ContinueTask(context.Background(), tc, &a2a.Message{TaskID: "task-3"})
+	assert.Error(t, err)
+	_, err = transport.ContinueTask(context.Background(), tc, &a2a.Message{ContextID: "ctx-2"})
+	assert.Error(t, err)
+	_, err = transport.ContinueTask(context.Background(), &protocol.TaskContext{}, msg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultReconnectDelay is the initial delay between reconnection attempts.
+// It doubles after every failed attempt, up to maxReconnectDelay.
+const DefaultReconnectDelay = time.Second
+
+const maxReconnectDelay = time.Minute
+
+// Connector is the agent side of a tunnel. It opens an outbound, DID-signed
+// WebSocket to a relay and serves the requests forwarded over it with a
+// local http.Handler, so an agent behind NAT needs no inbound port.
+type Connector struct {
+	relayURL       string
+	agentDID       did.AgentDID
+	keyPair        crypto.KeyPair
+	handler        http.Handler
+	signer         signer.A2ASigner
+	dialer         *websocket.Dialer
+	reconnectDelay time.Duration
+}
+
+// ConnectorOption configures optional Connector behavior
+type ConnectorOption func(*Connector)
+
+// WithDialer sets the WebSocket dialer, e.g. to configure TLS or a proxy
+func WithDialer(dialer *websocket.Dialer) ConnectorOption {
+	return func(c *Connector) {
+		c.dialer = dialer
+	}
+}
+
+// WithReconnectDelay sets the initial delay between reconnection attempts
+func WithReconnectDelay(d time.Duration) ConnectorOption {
+	return func(c *Connector) {
+		c.reconnectDelay = d
+	}
+}
+
+// NewConnector creates a connector that registers agentDID with the relay
+// tunnel endpoint at relayURL (ws:// or wss://) and serves forwarded
+// requests with handler. The handler sees requests exactly as the relay
+// received them, signature headers included, so it is typically the DID
+// middleware wrapping the agent's JSON-RPC handler.
+func NewConnector(relayURL string, agentDID did.AgentDID, keyPair crypto.KeyPair, handler http.Handler, opts ...ConnectorOption) *Connector {
+	c := &Connector{
+		relayURL:       relayURL,
+		agentDID:       agentDID,
+		keyPair:        keyPair,
+		handler:        handler,
+		signer:         signer.NewDefaultA2ASigner(),
+		dialer:         websocket.DefaultDialer,
+		reconnectDelay: DefaultReconnectDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run keeps a tunnel open until ctx is done, reconnecting with exponential
+// backoff whenever it closes. It returns ctx.Err().
+func (c *Connector) Run(ctx context.Context) error {
+	delay := c.reconnectDelay
+	for {
+		start := time.Now()
+		_ = c.Connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// A tunnel that stayed up for a while resets the backoff
+		if time.Since(start) > maxReconnectDelay {
+			delay = c.reconnectDelay
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// Connect opens one tunnel and serves requests until the tunnel closes or
+// ctx is done
+func (c *Connector) Connect(ctx context.Context) error {
+	header, err := c.signedHeader(ctx)
+	if err != nil {
+		return err
+	}
+	conn, resp, err := c.dialer.DialContext(ctx, c.relayURL, header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("failed to open tunnel: %s: %w", resp.Status, err)
+		}
+		return fmt.Errorf("failed to open tunnel: %w", err)
+	}
+	return c.serve(ctx, conn)
+}
+
+// signedHeader signs the tunnel handshake request with the agent's DID and
+// a random nonce
+func (c *Connector) signedHeader(ctx context.Context) (http.Header, error) {
+	url := c.relayURL
+	if rest, ok := strings.CutPrefix(url, "ws"); ok {
+		url = "http" + rest
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tunnel request: %w", err)
+	}
+	// The relay rejects handshakes without a fresh nonce, so a captured
+	// handshake cannot be replayed to take over the tunnel
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate handshake nonce: %w", err)
+	}
+	opts := &signer.SigningOptions{
+		Components: []string{"@method", "@path", "@query", "content-digest"},
+		Nonce:      hex.EncodeToString(nonce[:]),
+	}
+	if err := c.signer.SignRequestWithOptions(ctx, req, c.agentDID, c.keyPair, opts); err != nil {
+		return nil, fmt.Errorf("failed to sign tunnel request with DID: %w", err)
+	}
+	return req.Header, nil
+}
+
+// serve dispatches request frames to the handler, each in its own
+// goroutine, until the connection fails or ctx is done
+func (c *Connector) serve(ctx context.Context, conn *websocket.Conn) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	var writeMu sync.Mutex
+	write := func(f frame) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(f)
+	}
+
+	var mu sync.Mutex
+	inflight := make(map[uint64]context.CancelFunc)
+	for {
+		var f frame
+		if err := conn.ReadJSON(&f); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("tunnel closed: %w", err)
+		}
+
+		switch f.Type {
+		case frameRequest:
+			reqCtx, reqCancel := context.WithCancel(ctx)
+			mu.Lock()
+			inflight[f.ID] = reqCancel
+			mu.Unlock()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.serveRequest(reqCtx, f, write)
+				reqCancel()
+				mu.Lock()
+				delete(inflight, f.ID)
+				mu.Unlock()
+			}()
+		case frameCancel:
+			mu.Lock()
+			if reqCancel, ok := inflight[f.ID]; ok {
+				reqCancel()
+			}
+			mu.Unlock()
+		}
+	}
+}
+
+func (c *Connector) serveRequest(ctx context.Context, f frame, write func(frame) error) {
+	req, err := http.NewRequestWithContext(ctx, f.Method, f.URI, bytes.NewReader(f.Body))
+	if err != nil {
+		_ = write(frame{ID: f.ID, Type: frameEnd, Error: err.Error()})
+		return
+	}
+	if f.Header != nil {
+		req.Header = f.Header
+	}
+	req.Host = f.Host
+	req.RemoteAddr = f.RemoteAddr
+	req.RequestURI = f.URI
+
+	w := &tunnelResponseWriter{id: f.ID, write: write, header: make(http.Header)}
+	c.handler.ServeHTTP(w, req)
+	w.WriteHeader(http.StatusOK)
+	_ = write(frame{ID: f.ID, Type: frameEnd})
+}
+
+// tunnelResponseWriter sends a handler's response as head and body frames.
+// Every Write is sent immediately, so it also implements http.Flusher.
+type tunnelResponseWriter struct {
+	id          uint64
+	write       func(frame) error
+	header      http.Header
+	wroteHeader bool
+}
+
+func (w *tunnelResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *tunnelResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	_ = w.write(frame{ID: w.id, Type: frameHead, Status: status, Header: w.header.Clone()})
+}
+
+func (w *tunnelResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := w.write(frame{ID: w.id, Type: frameBody, Body: bytes.Clone(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush implements http.Flusher; writes are never buffered
+func (w *tunnelResponseWriter) Flush() {}
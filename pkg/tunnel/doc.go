@@ -0,0 +1,58 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package tunnel lets agents behind NAT receive A2A requests through a relay.
+//
+// The agent opens an outbound WebSocket to the relay. The handshake is
+// signed with the agent's DID using RFC 9421, and the relay only routes
+// requests for that DID over the tunnel. Inbound JSON-RPC requests,
+// including their signature headers, are forwarded unchanged, so the agent
+// still verifies every caller itself.
+//
+// # Agent Side
+//
+//	auth := server.NewDIDAuthMiddleware(resolver, client)
+//	connector := tunnel.NewConnector("wss://relay.example.com/tunnel",
+//	    myDID, myKeyPair, auth.Wrap(rpcHandler))
+//	go connector.Run(ctx) // reconnects with backoff until ctx is done
+//
+// The handler sees the path the caller used on the relay (for example
+// /agents/did:sage:ethereum:0x.../rpc). Signatures cover that path, so strip
+// the prefix inside the DID middleware, not outside it.
+//
+// # Relay Side
+//
+//	relay := tunnel.NewRelay(didVerifier)
+//	mux.Handle("/tunnel", relay.TunnelHandler())
+//	mux.Handle(tunnel.DefaultRoutePrefix, relay)
+//
+// Requests to /agents/<did>/... are forwarded to the tunnel of <did>;
+// WithRouter selects agents differently, e.g. by host name. Callers get
+// 502 Bad Gateway while the agent is not connected.
+//
+// Handshakes must be signed within DefaultHandshakeMaxAge and carry a
+// nonce, which the relay records in its replay store; relays behind a
+// load balancer should share one with WithReplayStore. A reconnecting
+// agent replaces its previous tunnel, but a handshake signed before the
+// live tunnel's is rejected with 409 Conflict.
+//
+// # Streaming
+//
+// Response bodies are forwarded chunk by chunk, so message/stream and
+// tasks/resubscribe work through the tunnel. When the caller disconnects,
+// the agent's request context is cancelled.
+package tunnel
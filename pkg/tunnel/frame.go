@@ -0,0 +1,83 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package tunnel
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Frame types exchanged over a tunnel
+const (
+	// frameRequest carries an inbound HTTP request from the relay to the agent
+	frameRequest = "request"
+
+	// frameHead carries the response status and headers
+	frameHead = "head"
+
+	// frameBody carries a chunk of the response body
+	frameBody = "body"
+
+	// frameEnd ends a response, with Error set if the agent failed to serve it
+	frameEnd = "end"
+
+	// frameCancel tells the agent the caller went away
+	frameCancel = "cancel"
+)
+
+var (
+	// ErrAgentNotConnected is returned when no tunnel is open for a DID
+	ErrAgentNotConnected = errors.New("agent not connected")
+
+	// ErrTunnelClosed is returned for requests in flight when a tunnel closes
+	ErrTunnelClosed = errors.New("tunnel closed")
+
+	// errStaleHandshake rejects a tunnel whose handshake is older than the
+	// live tunnel's
+	errStaleHandshake = errors.New("a tunnel with a newer handshake is open")
+)
+
+// frame is one JSON message on a tunnel. Requests and responses are
+// matched by ID so many requests can be in flight at once; response bodies
+// are streamed as body frames so SSE works through the tunnel.
+type frame struct {
+	ID         uint64      `json:"id"`
+	Type       string      `json:"type"`
+	Method     string      `json:"method,omitempty"`
+	URI        string      `json:"uri,omitempty"`
+	Host       string      `json:"host,omitempty"`
+	RemoteAddr string      `json:"remoteAddr,omitempty"`
+	Status     int         `json:"status,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// hopHeaders are connection-specific headers that are not forwarded
+var hopHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization",
+	"Te", "Trailer", "Transfer-Encoding", "Upgrade",
+}
+
+func forwardHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range hopHeaders {
+		h.Del(name)
+	}
+	return h
+}
@@ -0,0 +1,473 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sage-x-project/sage-a2a-go/pkg/server"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultRoutePrefix is the path prefix routed by the default Router:
+// requests to /agents/<did>/... go to the tunnel of <did>
+const DefaultRoutePrefix = "/agents/"
+
+// DefaultMaxBodySize limits the size of forwarded request bodies
+const DefaultMaxBodySize = 10 << 20
+
+// DefaultResponseTimeout bounds how long the relay waits for an agent to
+// start responding
+const DefaultResponseTimeout = 30 * time.Second
+
+// DefaultHandshakeMaxAge is how far the created time of a tunnel
+// handshake signature may be from the relay's clock
+const DefaultHandshakeMaxAge = time.Minute
+
+// Router selects the agent an inbound request is forwarded to
+type Router func(r *http.Request) (did.AgentDID, bool)
+
+// PathRouter routes requests whose path is prefix followed by a DID and an
+// optional sub-path, e.g. /agents/did:sage:ethereum:0x.../rpc
+func PathRouter(prefix string) Router {
+	return func(r *http.Request) (did.AgentDID, bool) {
+		rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+		if !ok {
+			return "", false
+		}
+		agentDID, _, _ := strings.Cut(rest, "/")
+		if !strings.HasPrefix(agentDID, "did:") {
+			return "", false
+		}
+		return did.AgentDID(agentDID), true
+	}
+}
+
+// RelayOption configures optional Relay behavior
+type RelayOption func(*Relay)
+
+// WithRouter sets how inbound requests are mapped to agents
+// (default PathRouter(DefaultRoutePrefix))
+func WithRouter(router Router) RelayOption {
+	return func(r *Relay) {
+		r.router = router
+	}
+}
+
+// WithTunnelAuthorizer restricts which verified DIDs may open a tunnel
+func WithTunnelAuthorizer(authorize func(ctx context.Context, agentDID did.AgentDID) error) RelayOption {
+	return func(r *Relay) {
+		r.authorize = authorize
+	}
+}
+
+// WithMaxBodySize sets the maximum forwarded request body size
+func WithMaxBodySize(n int64) RelayOption {
+	return func(r *Relay) {
+		r.maxBodySize = n
+	}
+}
+
+// WithResponseTimeout sets how long the relay waits for the response head.
+// Streaming bodies are not limited.
+func WithResponseTimeout(d time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.responseTimeout = d
+	}
+}
+
+// WithReplayStore sets where handshake nonces are remembered (default
+// server.NewMemoryReplayStore()). Relays sharing agents must share it.
+func WithReplayStore(store server.ReplayStore) RelayOption {
+	return func(r *Relay) {
+		r.replay = store
+	}
+}
+
+// WithHandshakeMaxAge sets how old, or how far in the future, a handshake
+// signature may be (default DefaultHandshakeMaxAge)
+func WithHandshakeMaxAge(d time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.handshakeMaxAge = d
+	}
+}
+
+// WithUpgrader sets the WebSocket upgrader, e.g. to check origins
+func WithUpgrader(upgrader *websocket.Upgrader) RelayOption {
+	return func(r *Relay) {
+		r.upgrader = upgrader
+	}
+}
+
+// Relay is a reference relay server. Agents connect to TunnelHandler with
+// a DID-signed WebSocket handshake; the Relay itself is the http.Handler
+// for public traffic and forwards each request over the tunnel of the
+// agent its Router selects. Only the DID that signed the handshake can
+// receive requests routed to it.
+type Relay struct {
+	verifier        verifier.DIDVerifier
+	router          Router
+	authorize       func(ctx context.Context, agentDID did.AgentDID) error
+	maxBodySize     int64
+	responseTimeout time.Duration
+	handshakeMaxAge time.Duration
+	replay          server.ReplayStore
+	upgrader        *websocket.Upgrader
+
+	mu      sync.Mutex
+	tunnels map[did.AgentDID]*tunnel
+}
+
+// NewRelay creates a relay authenticating agents with v
+func NewRelay(v verifier.DIDVerifier, opts ...RelayOption) *Relay {
+	r := &Relay{
+		verifier:        v,
+		router:          PathRouter(DefaultRoutePrefix),
+		maxBodySize:     DefaultMaxBodySize,
+		responseTimeout: DefaultResponseTimeout,
+		handshakeMaxAge: DefaultHandshakeMaxAge,
+		replay:          server.NewMemoryReplayStore(),
+		upgrader:        &websocket.Upgrader{},
+		tunnels:         make(map[did.AgentDID]*tunnel),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Agents returns the DIDs with an open tunnel
+func (r *Relay) Agents() []did.AgentDID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	agents := make([]did.AgentDID, 0, len(r.tunnels))
+	for agentDID := range r.tunnels {
+		agents = append(agents, agentDID)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i] < agents[j] })
+	return agents
+}
+
+// TunnelHandler accepts tunnels from agents. The handshake must carry a
+// valid DID signature with a created time within the handshake max age
+// and a nonce not seen before. A new tunnel for a DID replaces the
+// previous one only if its handshake was created no earlier, so a
+// handshake captured before the live one cannot take the DID over.
+func (r *Relay) TunnelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		agentDID, err := r.verifier.VerifyHTTPSignatureWithKeyID(req.Context(), req)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("DID verification failed: %v", err), http.StatusUnauthorized)
+			return
+		}
+		created, err := r.checkHandshake(req, agentDID)
+		if err != nil {
+			status := http.StatusUnauthorized
+			if errors.Is(err, server.ErrReplayCheckFailed) {
+				status = http.StatusServiceUnavailable
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		if r.authorize != nil {
+			if err := r.authorize(req.Context(), agentDID); err != nil {
+				http.Error(w, fmt.Sprintf("tunnel not allowed: %v", err), http.StatusForbidden)
+				return
+			}
+		}
+		if live := r.tunnel(agentDID); live != nil && created < live.created {
+			http.Error(w, errStaleHandshake.Error(), http.StatusConflict)
+			return
+		}
+
+		conn, err := r.upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return // the upgrader has replied
+		}
+		t := newTunnel(conn, created)
+
+		// A newer handshake may have won while upgrading
+		r.mu.Lock()
+		previous := r.tunnels[agentDID]
+		if previous != nil && created < previous.created {
+			r.mu.Unlock()
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, errStaleHandshake.Error()), time.Now().Add(time.Second))
+			t.close()
+			return
+		}
+		r.tunnels[agentDID] = t
+		r.mu.Unlock()
+		if previous != nil {
+			previous.close()
+		}
+
+		t.readLoop()
+
+		r.mu.Lock()
+		if r.tunnels[agentDID] == t {
+			delete(r.tunnels, agentDID)
+		}
+		r.mu.Unlock()
+	})
+}
+
+// checkHandshake requires the verified handshake signature of req to be
+// fresh and its nonce unused, returning its created time
+func (r *Relay) checkHandshake(req *http.Request, agentDID did.AgentDID) (int64, error) {
+	var params *signer.SignatureParams
+	var err error
+	if reader, ok := r.verifier.(verifier.SignatureParamsReader); ok {
+		params, err = reader.SignatureParams(req)
+	} else {
+		params, err = verifier.SelectSignatureParams(req.Header.Get("Signature-Input"), verifier.SignatureSelector{})
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid handshake signature: %w", err)
+	}
+	if params.Nonce == "" {
+		return 0, errors.New("handshake signature has no nonce")
+	}
+	if params.Created == 0 {
+		return 0, errors.New("handshake signature has no created time")
+	}
+	if age := time.Since(time.Unix(params.Created, 0)); age > r.handshakeMaxAge || age < -r.handshakeMaxAge {
+		return 0, fmt.Errorf("handshake signature created %s from now, beyond %s", age.Round(time.Second), r.handshakeMaxAge)
+	}
+
+	// Nonces are remembered until their handshake can no longer be fresh
+	seen, err := r.replay.CheckAndStore(req.Context(), "tunnel:"+string(agentDID)+"\n"+params.Nonce, 2*r.handshakeMaxAge)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", server.ErrReplayCheckFailed, err)
+	}
+	if seen {
+		return 0, fmt.Errorf("handshake: %w", server.ErrReplayedRequest)
+	}
+	return params.Created, nil
+}
+
+func (r *Relay) tunnel(agentDID did.AgentDID) *tunnel {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tunnels[agentDID]
+}
+
+// ServeHTTP forwards a public request to the agent selected by the router
+func (r *Relay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	agentDID, ok := r.router(req)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	t := r.tunnel(agentDID)
+	if t == nil {
+		http.Error(w, ErrAgentNotConnected.Error(), http.StatusBadGateway)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, r.maxBodySize+1))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > r.maxBodySize {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	p, err := t.open()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer t.release(p)
+
+	err = t.send(frame{
+		ID:         p.id,
+		Type:       frameRequest,
+		Method:     req.Method,
+		URI:        req.URL.RequestURI(),
+		Host:       req.Host,
+		RemoteAddr: req.RemoteAddr,
+		Header:     forwardHeader(req.Header),
+		Body:       body,
+	})
+	if err != nil {
+		http.Error(w, ErrTunnelClosed.Error(), http.StatusBadGateway)
+		return
+	}
+
+	timeout := time.NewTimer(r.responseTimeout)
+	defer timeout.Stop()
+	headTimeout := timeout.C
+	wroteHead := false
+	// handle applies one response frame, reporting whether the response is complete
+	handle := func(f frame) bool {
+		switch f.Type {
+		case frameHead:
+			for name, values := range forwardHeader(f.Header) {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(f.Status)
+			wroteHead = true
+			headTimeout = nil
+		case frameBody:
+			if _, err := w.Write(f.Body); err != nil {
+				_ = t.send(frame{ID: p.id, Type: frameCancel})
+				return true
+			}
+			_ = http.NewResponseController(w).Flush()
+		case frameEnd:
+			if !wroteHead {
+				http.Error(w, "agent failed to serve request: "+f.Error, http.StatusBadGateway)
+			}
+			return true
+		}
+		return false
+	}
+
+	for {
+		select {
+		case f := <-p.frames:
+			if handle(f) {
+				return
+			}
+		case <-t.done:
+			// Deliver frames received before the tunnel closed
+			for drained := false; !drained; {
+				select {
+				case f := <-p.frames:
+					if handle(f) {
+						return
+					}
+				default:
+					drained = true
+				}
+			}
+			if !wroteHead {
+				http.Error(w, ErrTunnelClosed.Error(), http.StatusBadGateway)
+			}
+			return
+		case <-headTimeout:
+			_ = t.send(frame{ID: p.id, Type: frameCancel})
+			http.Error(w, "agent did not respond in time", http.StatusGatewayTimeout)
+			return
+		case <-req.Context().Done():
+			_ = t.send(frame{ID: p.id, Type: frameCancel})
+			return
+		}
+	}
+}
+
+// pending is a request in flight on a tunnel
+type pending struct {
+	id     uint64
+	frames chan frame
+	done   chan struct{} // closed when the caller stops reading frames
+}
+
+// tunnel is the relay side of one agent connection
+type tunnel struct {
+	conn    *websocket.Conn
+	created int64 // of the handshake signature
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]*pending
+	done    chan struct{}
+	closed  bool
+}
+
+func newTunnel(conn *websocket.Conn, created int64) *tunnel {
+	return &tunnel{
+		conn:    conn,
+		created: created,
+		pending: make(map[uint64]*pending),
+		done:    make(chan struct{}),
+	}
+}
+
+func (t *tunnel) send(f frame) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	return t.conn.WriteJSON(f)
+}
+
+func (t *tunnel) open() (*pending, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil, ErrTunnelClosed
+	}
+	t.nextID++
+	p := &pending{id: t.nextID, frames: make(chan frame, 16), done: make(chan struct{})}
+	t.pending[p.id] = p
+	return p, nil
+}
+
+func (t *tunnel) release(p *pending) {
+	t.mu.Lock()
+	delete(t.pending, p.id)
+	t.mu.Unlock()
+	close(p.done)
+}
+
+// readLoop routes response frames to their requests until the connection
+// fails. A slow caller applies backpressure to the whole tunnel.
+func (t *tunnel) readLoop() {
+	defer t.close()
+	for {
+		var f frame
+		if err := t.conn.ReadJSON(&f); err != nil {
+			return
+		}
+		t.mu.Lock()
+		p := t.pending[f.ID]
+		t.mu.Unlock()
+		if p == nil {
+			continue
+		}
+		select {
+		case p.frames <- f:
+		case <-p.done:
+		}
+	}
+}
+
+func (t *tunnel) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	close(t.done)
+	_ = t.conn.Close()
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	stdcrypto "crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	"github.com/sage-x-project/sage/pkg/agent/core/rfc9421"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDID = did.AgentDID("did:sage:ethereum:0xagent")
+
+// keyVerifier verifies RFC 9421 signatures against a fixed DID to key map
+type keyVerifier struct {
+	keys map[did.AgentDID]stdcrypto.PublicKey
+}
+
+func (v *keyVerifier) VerifyHTTPSignature(ctx context.Context, req *http.Request, agentDID did.AgentDID) error {
+	pub, ok := v.keys[agentDID]
+	if !ok {
+		return errors.New("unknown DID")
+	}
+	return verifier.NewRFC9421Verifier().VerifyHTTPRequest(req, pub)
+}
+
+func (v *keyVerifier) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (stdcrypto.PublicKey, error) {
+	return v.keys[agentDID], nil
+}
+
+func (v *keyVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, req *http.Request) (did.AgentDID, error) {
+	params, err := rfc9421.ParseSignatureInput(req.Header.Get("Signature-Input"))
+	if err != nil {
+		return "", err
+	}
+	sig, ok := params["sig1"]
+	if !ok {
+		return "", errors.New("no signature")
+	}
+	agentDID := did.AgentDID(sig.KeyID)
+	return agentDID, v.VerifyHTTPSignature(ctx, req, agentDID)
+}
+
+// agentHandler echoes requests and streams events on /stream
+func agentHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stream") {
+			w.Header().Set("Content-Type", "text/event-stream")
+			for i := range 3 {
+				fmt.Fprintf(w, "data: event %d\n\n", i)
+				w.(http.Flusher).Flush()
+			}
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Echo-Header", r.Header.Get("X-Test"))
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.Path, body)
+	})
+}
+
+func startRelay(t *testing.T) (*Relay, *httptest.Server) {
+	t.Helper()
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	return startRelayWithKey(t, kp)
+}
+
+func startRelayWithKey(t *testing.T, kp sagecrypto.KeyPair) (*Relay, *httptest.Server) {
+	t.Helper()
+	relay := NewRelay(&keyVerifier{keys: map[did.AgentDID]stdcrypto.PublicKey{testDID: kp.PublicKey()}})
+	mux := http.NewServeMux()
+	mux.Handle("/tunnel", relay.TunnelHandler())
+	mux.Handle("/", relay)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	connector := NewConnector("ws"+strings.TrimPrefix(srv.URL, "http")+"/tunnel", testDID, kp, agentHandler(),
+		WithReconnectDelay(10*time.Millisecond))
+	go func() { done <- connector.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	require.Eventually(t, func() bool { return len(relay.Agents()) == 1 }, 5*time.Second, 5*time.Millisecond)
+	return relay, srv
+}
+
+func TestRelay_Forward(t *testing.T) {
+	relay, srv := startRelay(t)
+	assert.Equal(t, []did.AgentDID{testDID}, relay.Agents())
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+DefaultRoutePrefix+string(testDID)+"/rpc", strings.NewReader(`{"jsonrpc":"2.0"}`))
+	req.Header.Set("X-Test", "forwarded")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "forwarded", resp.Header.Get("X-Echo-Header"))
+	assert.Equal(t, `POST /agents/`+string(testDID)+`/rpc {"jsonrpc":"2.0"}`, string(body))
+
+	resp, err = http.Get(srv.URL + DefaultRoutePrefix + "did:sage:ethereum:0xother/rpc")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "/elsewhere")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestRelay_Streaming(t *testing.T) {
+	_, srv := startRelay(t)
+
+	resp, err := http.Get(srv.URL + DefaultRoutePrefix + string(testDID) + "/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			events = append(events, data)
+		}
+	}
+	assert.Equal(t, []string{"event 0", "event 1", "event 2"}, events)
+}
+
+func TestRelay_RejectsUnsignedTunnel(t *testing.T) {
+	relay := NewRelay(&keyVerifier{})
+	srv := httptest.NewServer(relay.TunnelHandler())
+	defer srv.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Empty(t, relay.Agents())
+}
+
+// handshake signs a tunnel handshake for testDID with opts
+func handshake(t *testing.T, kp sagecrypto.KeyPair, url string, opts *signer.SigningOptions) http.Header {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "http"+strings.TrimPrefix(url, "ws"), nil)
+	require.NoError(t, err)
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequestWithOptions(context.Background(), req, testDID, kp, opts))
+	return req.Header
+}
+
+func TestRelay_HandshakeFreshness(t *testing.T) {
+	kp, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	relay, srv := startRelayWithKey(t, kp)
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/tunnel"
+	components := []string{"@method", "@path", "@query", "content-digest"}
+
+	dial := func(header http.Header) int {
+		conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+		if err == nil {
+			conn.Close()
+		}
+		require.NotNil(t, resp)
+		return resp.StatusCode
+	}
+
+	// A handshake without a nonce is rejected
+	status := dial(handshake(t, kp, url, &signer.SigningOptions{Components: components}))
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// A handshake signed before the live tunnel's cannot replace it
+	stale := handshake(t, kp, url, &signer.SigningOptions{
+		Components: components,
+		Created:    time.Now().Add(-10 * time.Second).Unix(),
+		Nonce:      "stale",
+	})
+	assert.Equal(t, http.StatusConflict, dial(stale))
+
+	// A handshake outside the max age is rejected
+	old := handshake(t, kp, url, &signer.SigningOptions{
+		Components: components,
+		Created:    time.Now().Add(-2 * DefaultHandshakeMaxAge).Unix(),
+		Nonce:      "old",
+	})
+	assert.Equal(t, http.StatusUnauthorized, dial(old))
+	assert.Equal(t, []did.AgentDID{testDID}, relay.Agents())
+
+	// A fresh handshake replaces the tunnel, but only once
+	fresh := handshake(t, kp, url, &signer.SigningOptions{Components: components, Nonce: "fresh"})
+	assert.Equal(t, http.StatusSwitchingProtocols, dial(fresh))
+	assert.Equal(t, http.StatusUnauthorized, dial(fresh))
+}
+
+func TestPathRouter(t *testing.T) {
+	router := PathRouter("/agents/")
+	agentDID, ok := router(httptest.NewRequest(http.MethodPost, "/agents/did:sage:kaia:0x1/rpc", nil))
+	assert.True(t, ok)
+	assert.Equal(t, did.AgentDID("did:sage:kaia:0x1"), agentDID)
+
+	_, ok = router(httptest.NewRequest(http.MethodPost, "/agents/not-a-did/rpc", nil))
+	assert.False(t, ok)
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package vectors
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
+	"github.com/sage-x-project/sage-a2a-go/pkg/verifier"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Check runs every vector in corpus against this implementation and
+// returns one error per vector whose outcome differs from the expected one
+func Check(ctx context.Context, corpus *Corpus) []error {
+	var failures []error
+	fail := func(kind, name string, err error) {
+		failures = append(failures, fmt.Errorf("%s %s: %w", kind, name, err))
+	}
+
+	for _, v := range corpus.Requests {
+		pub, err := corpus.publicKey(v.Key)
+		if err != nil {
+			fail("request", v.Name, err)
+			continue
+		}
+		if err := expect(v.Valid, checkRequest(v, pub)); err != nil {
+			fail("request", v.Name, err)
+		}
+	}
+
+	for _, v := range corpus.Cards {
+		pub, err := corpus.publicKey(v.Key)
+		if err != nil {
+			fail("card", v.Name, err)
+			continue
+		}
+		if err := expect(v.Valid, checkCard(v, corpus.did(v.Key), pub)); err != nil {
+			fail("card", v.Name, err)
+		}
+	}
+
+	for _, v := range corpus.Streams {
+		if err := checkStream(ctx, v); err != nil {
+			fail("stream", v.Name, err)
+		}
+	}
+	return failures
+}
+
+// expect compares a verification outcome with the expected validity
+func expect(valid bool, err error) error {
+	switch {
+	case valid && err != nil:
+		return fmt.Errorf("expected valid: %w", err)
+	case !valid && err == nil:
+		return fmt.Errorf("expected verification to fail")
+	}
+	return nil
+}
+
+func (c *Corpus) publicKey(name string) (crypto.PublicKey, error) {
+	for _, k := range c.Keys {
+		if k.Name == name {
+			pub, err := formats.NewJWKImporter().ImportPublic(k.PublicJWK, sagecrypto.KeyFormatJWK)
+			if err != nil {
+				return nil, fmt.Errorf("failed to import key %s: %w", name, err)
+			}
+			return pub, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key %q", name)
+}
+
+func (c *Corpus) did(name string) did.AgentDID {
+	for _, k := range c.Keys {
+		if k.Name == name {
+			return k.DID
+		}
+	}
+	return ""
+}
+
+func checkRequest(v RequestVector, pub crypto.PublicKey) error {
+	req, err := http.NewRequest(v.Method, v.URL, strings.NewReader(v.Body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range v.Header {
+		req.Header.Set(name, value)
+	}
+	if err := signer.VerifyContentDigest(req.Header.Get("Content-Digest"), []byte(v.Body), nil); err != nil {
+		return err
+	}
+	return verifier.NewRFC9421Verifier(verifier.WithMaxAge(0)).VerifyHTTPRequest(req, pub)
+}
+
+func checkCard(v CardVector, agentDID did.AgentDID, pub crypto.PublicKey) error {
+	var card a2a.AgentCard
+	if err := json.Unmarshal(v.Card, &card); err != nil {
+		return fmt.Errorf("failed to parse card: %w", err)
+	}
+	return protocol.VerifyA2AAgentCard(&card, agentDID, pub)
+}
+
+// checkStream decodes the stream with the DID HTTP transport's SSE client
+// and compares the events with the expected results
+func checkStream(ctx context.Context, v StreamVector) error {
+	kp, err := keys.GenerateEd25519KeyPair()
+	if err != nil {
+		return fmt.Errorf("failed to generate client key: %w", err)
+	}
+	client := &http.Client{Transport: streamRoundTripper(v.Stream)}
+	t := transport.NewDIDHTTPTransport("http://vectors.invalid", "did:sage:vectors:client", kp, client)
+
+	i := 0
+	for event, err := range t.SendStreamingMessage(ctx, &a2a.MessageSendParams{Message: a2a.NewMessage(a2a.MessageRoleUser)}) {
+		if err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
+		}
+		if i >= len(v.Events) {
+			return fmt.Errorf("unexpected event %d", i)
+		}
+		got, err := marshalEvent(event)
+		if err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
+		}
+		if !jsonEqual(got, v.Events[i]) {
+			return fmt.Errorf("event %d: got %s, want %s", i, got, v.Events[i])
+		}
+		i++
+	}
+	if i != len(v.Events) {
+		return fmt.Errorf("got %d events, want %d", i, len(v.Events))
+	}
+	return nil
+}
+
+// streamRoundTripper answers every request with stream as an SSE body
+type streamRoundTripper string
+
+// RoundTrip implements http.RoundTripper
+func (s streamRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(string(s))),
+		Request:    req,
+	}, nil
+}
+
+// jsonEqual reports whether a and b encode the same JSON value
+func jsonEqual(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	ca, _ := json.Marshal(va)
+	cb, _ := json.Marshal(vb)
+	return bytes.Equal(ca, cb)
+}
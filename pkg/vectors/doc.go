@@ -0,0 +1,53 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+// Package vectors generates and checks a cross-language test vector corpus.
+//
+// The SAGE Python and TypeScript implementations validate themselves
+// against vectors produced by this Go implementation: signed JSON-RPC
+// requests (RFC 9421), signed agent cards and SSE event streams, together
+// with the keys that signed them. Each vector records whether it is
+// expected to verify, so tampered inputs are covered too.
+//
+// # Generating
+//
+// cmd/gen-vectors writes a corpus to a file:
+//
+//	go run ./cmd/gen-vectors -o vectors.json
+//
+// or from Go:
+//
+//	corpus, err := vectors.Generate(vectors.Options{Created: time.Unix(1700000000, 0)})
+//	data, err := json.MarshalIndent(corpus, "", "  ")
+//
+// Keys are generated afresh for every corpus; private keys are included as
+// JWKs so other implementations can also check signing, not only
+// verification.
+//
+// # Checking
+//
+// Check runs every vector against this implementation and returns the
+// failures, so a corpus can be replayed as a Go test:
+//
+//	corpus, err := vectors.Load("testdata/vectors.json")
+//	require.NoError(t, err)
+//	for _, failure := range vectors.Check(ctx, corpus) {
+//	    t.Error(failure)
+//	}
+//
+// Signature timestamps are not checked against the clock, so corpora stay
+// valid after they are generated.
+package vectors
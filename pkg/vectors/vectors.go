@@ -0,0 +1,357 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package vectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Version is the corpus format version
+const Version = "1"
+
+// Corpus is a set of test vectors and the keys that signed them
+type Corpus struct {
+	Version  string          `json:"version"`
+	Keys     []Key           `json:"keys"`
+	Requests []RequestVector `json:"requests"`
+	Cards    []CardVector    `json:"cards"`
+	Streams  []StreamVector  `json:"streams"`
+}
+
+// Key is a signing key used by the corpus
+type Key struct {
+	Name       string          `json:"name"`
+	DID        did.AgentDID    `json:"did"`
+	Type       string          `json:"type"`
+	PrivateJWK json.RawMessage `json:"privateJwk"`
+	PublicJWK  json.RawMessage `json:"publicJwk"`
+}
+
+// RequestVector is an HTTP request signed with RFC 9421 HTTP Message
+// Signatures
+type RequestVector struct {
+	Name   string            `json:"name"`
+	Key    string            `json:"key"`
+	Method string            `json:"method"`
+	URL    string            `json:"url"`
+	Header map[string]string `json:"header"`
+	Body   string            `json:"body"`
+	Valid  bool              `json:"valid"`
+}
+
+// CardVector is an agent card carrying a JWS signature (see
+// protocol.SignA2AAgentCard)
+type CardVector struct {
+	Name  string          `json:"name"`
+	Key   string          `json:"key"`
+	Card  json.RawMessage `json:"card"`
+	Valid bool            `json:"valid"`
+}
+
+// StreamVector is a raw SSE response body and the event results it must
+// decode to, in order
+type StreamVector struct {
+	Name   string            `json:"name"`
+	Stream string            `json:"stream"`
+	Events []json.RawMessage `json:"events"`
+}
+
+// Options configures Generate
+type Options struct {
+	// Created is the signature creation time (default time.Now())
+	Created time.Time
+}
+
+// Load reads a corpus from a JSON file
+func Load(path string) (*Corpus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %w", err)
+	}
+	var corpus Corpus
+	if err := json.Unmarshal(data, &corpus); err != nil {
+		return nil, fmt.Errorf("failed to parse corpus: %w", err)
+	}
+	if corpus.Version != Version {
+		return nil, fmt.Errorf("unsupported corpus version %q", corpus.Version)
+	}
+	return &corpus, nil
+}
+
+// generator accumulates a corpus
+type generator struct {
+	corpus  *Corpus
+	created int64
+	pairs   map[string]crypto.KeyPair
+}
+
+// Generate creates a corpus signed with freshly generated Ed25519 and
+// secp256k1 keys
+func Generate(opts Options) (*Corpus, error) {
+	if opts.Created.IsZero() {
+		opts.Created = time.Now()
+	}
+	g := &generator{
+		corpus:  &Corpus{Version: Version},
+		created: opts.Created.Unix(),
+		pairs:   make(map[string]crypto.KeyPair),
+	}
+
+	ed, err := keys.GenerateEd25519KeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+	}
+	k1, err := keys.GenerateSecp256k1KeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secp256k1 key: %w", err)
+	}
+	if err := g.addKey("ed25519", "did:sage:solana:vector-ed25519", ed); err != nil {
+		return nil, err
+	}
+	if err := g.addKey("secp256k1", "did:sage:ethereum:0x00000000000000000000000000000000000a2a01", k1); err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"ed25519", "secp256k1"} {
+		if err := g.addRequests(name); err != nil {
+			return nil, err
+		}
+		if err := g.addCards(name); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.addStreams(); err != nil {
+		return nil, err
+	}
+	return g.corpus, nil
+}
+
+func (g *generator) addKey(name string, agentDID did.AgentDID, kp crypto.KeyPair) error {
+	exporter := formats.NewJWKExporter()
+	private, err := exporter.Export(kp, crypto.KeyFormatJWK)
+	if err != nil {
+		return fmt.Errorf("failed to export %s key: %w", name, err)
+	}
+	public, err := exporter.ExportPublic(kp, crypto.KeyFormatJWK)
+	if err != nil {
+		return fmt.Errorf("failed to export %s public key: %w", name, err)
+	}
+	g.pairs[name] = kp
+	g.corpus.Keys = append(g.corpus.Keys, Key{
+		Name:       name,
+		DID:        agentDID,
+		Type:       string(kp.Type()),
+		PrivateJWK: private,
+		PublicJWK:  public,
+	})
+	return nil
+}
+
+func (g *generator) key(name string) (did.AgentDID, crypto.KeyPair) {
+	for _, k := range g.corpus.Keys {
+		if k.Name == name {
+			return k.DID, g.pairs[name]
+		}
+	}
+	return "", nil
+}
+
+func (g *generator) addRequests(keyName string) error {
+	agentDID, kp := g.key(keyName)
+	body := `{"jsonrpc":"2.0","id":1,"method":"message/send","params":{"message":{"kind":"message","messageId":"m-1","role":"user","parts":[{"kind":"text","text":"hello"}]}}}`
+
+	cases := []struct {
+		name   string
+		url    string
+		opts   *signer.SigningOptions
+		tamper func(v *RequestVector)
+	}{
+		{name: "default", url: "https://agent.example.com/rpc"},
+		{name: "query-nonce", url: "https://agent.example.com/rpc?tenant=a&page=2", opts: &signer.SigningOptions{
+			Components: []string{"@method", "@authority", "@path", "@query", "content-type", "content-digest"},
+			Nonce:      "vector-nonce-1",
+		}},
+		{name: "sha512-digest", url: "https://agent.example.com/rpc", opts: &signer.SigningOptions{
+			Components:      []string{"@method", "@path", "@query", "content-digest"},
+			DigestAlgorithm: signer.DigestSHA512,
+		}},
+		{name: "label", url: "https://agent.example.com/rpc", opts: &signer.SigningOptions{
+			Components: []string{"@method", "@path", "content-digest"},
+			Label:      "sage",
+		}},
+		{name: "tampered-body", url: "https://agent.example.com/rpc", tamper: func(v *RequestVector) {
+			v.Body = strings.Replace(v.Body, "hello", "hullo", 1)
+		}},
+		{name: "tampered-path", url: "https://agent.example.com/rpc", tamper: func(v *RequestVector) {
+			v.URL = "https://agent.example.com/admin"
+		}},
+	}
+
+	for _, c := range cases {
+		req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewBufferString(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		opts := c.opts
+		if opts == nil {
+			opts = &signer.SigningOptions{}
+		}
+		opts.Created = g.created
+		if err := signer.NewDefaultA2ASigner().SignRequestWithOptions(context.Background(), req, agentDID, kp, opts); err != nil {
+			return fmt.Errorf("failed to sign %s request: %w", c.name, err)
+		}
+
+		v := RequestVector{
+			Name:   keyName + "/" + c.name,
+			Key:    keyName,
+			Method: req.Method,
+			URL:    c.url,
+			Header: make(map[string]string),
+			Body:   body,
+			Valid:  c.tamper == nil,
+		}
+		for name := range req.Header {
+			v.Header[name] = req.Header.Get(name)
+		}
+		if c.tamper != nil {
+			c.tamper(&v)
+		}
+		g.corpus.Requests = append(g.corpus.Requests, v)
+	}
+	return nil
+}
+
+func (g *generator) addCards(keyName string) error {
+	agentDID, kp := g.key(keyName)
+	card := &a2a.AgentCard{
+		Name:               "Vector Agent",
+		Description:        "Agent card test vector",
+		URL:                "https://agent.example.com/rpc",
+		PreferredTransport: a2a.TransportProtocolJSONRPC,
+		ProtocolVersion:    "0.3.0",
+		Version:            "1.0.0",
+		Capabilities:       a2a.AgentCapabilities{Streaming: true},
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		Skills: []a2a.AgentSkill{
+			{ID: "echo", Name: "Echo", Description: "Echoes messages", Tags: []string{"test"}},
+		},
+	}
+	if err := protocol.SignA2AAgentCard(card, agentDID, kp); err != nil {
+		return fmt.Errorf("failed to sign card: %w", err)
+	}
+	signed, err := json.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("failed to marshal card: %w", err)
+	}
+
+	card.Description = "Tampered description"
+	tampered, err := json.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("failed to marshal card: %w", err)
+	}
+
+	g.corpus.Cards = append(g.corpus.Cards,
+		CardVector{Name: keyName + "/signed", Key: keyName, Card: signed, Valid: true},
+		CardVector{Name: keyName + "/tampered", Key: keyName, Card: tampered, Valid: false},
+	)
+	return nil
+}
+
+func (g *generator) addStreams() error {
+	ts := time.Unix(g.created, 0).UTC()
+	events := []a2a.Event{
+		&a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted, Timestamp: &ts}},
+		&a2a.TaskStatusUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking, Timestamp: &ts}},
+		&a2a.TaskArtifactUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Artifact: &a2a.Artifact{
+			ID:    "artifact-1",
+			Parts: a2a.ContentParts{a2a.TextPart{Text: "hello"}},
+		}, LastChunk: true},
+		&a2a.TaskStatusUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Final: true, Status: a2a.TaskStatus{State: a2a.TaskStateCompleted, Timestamp: &ts}},
+	}
+
+	var results []json.RawMessage
+	for _, event := range events {
+		data, err := marshalEvent(event)
+		if err != nil {
+			return err
+		}
+		results = append(results, data)
+	}
+
+	// The same events framed in the ways a conforming client must accept
+	var plain, crlf, split bytes.Buffer
+	for i, result := range results {
+		line := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":%s}`, result)
+		fmt.Fprintf(&plain, "data: %s\n\n", line)
+		fmt.Fprintf(&crlf, ": keep-alive\r\nevent: message\r\nid: %d\r\ndata:%s\r\n\r\n", i+1, line)
+		fmt.Fprintf(&split, "retry: 1000\ndata: {\"jsonrpc\":\"2.0\",\"id\":1,\ndata: \"result\":%s}\n\n", result)
+	}
+
+	g.corpus.Streams = append(g.corpus.Streams,
+		StreamVector{Name: "plain", Stream: plain.String(), Events: results},
+		StreamVector{Name: "crlf-comments-ids", Stream: crlf.String(), Events: results},
+		StreamVector{Name: "multiline-data", Stream: split.String(), Events: results},
+	)
+	return nil
+}
+
+// marshalEvent encodes an A2A event with its "kind" discriminator, as it
+// appears in a JSON-RPC result
+func marshalEvent(event a2a.Event) ([]byte, error) {
+	var kind string
+	switch event.(type) {
+	case *a2a.Message:
+		kind = "message"
+	case *a2a.Task:
+		kind = "task"
+	case *a2a.TaskStatusUpdateEvent:
+		kind = "status-update"
+	case *a2a.TaskArtifactUpdateEvent:
+		kind = "artifact-update"
+	default:
+		return nil, fmt.Errorf("unsupported event type %T", event)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	fields["kind"], _ = json.Marshal(kind)
+	return json.Marshal(fields)
+}
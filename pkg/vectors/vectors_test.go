@@ -0,0 +1,67 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package vectors
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAndCheck(t *testing.T) {
+	corpus, err := Generate(Options{Created: time.Unix(1700000000, 0)})
+	require.NoError(t, err)
+	assert.Len(t, corpus.Keys, 2)
+	assert.NotEmpty(t, corpus.Requests)
+	assert.NotEmpty(t, corpus.Cards)
+	assert.NotEmpty(t, corpus.Streams)
+
+	data, err := json.Marshal(corpus)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "vectors.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, Check(context.Background(), loaded))
+}
+
+func TestCheck_DetectsMismatch(t *testing.T) {
+	corpus, err := Generate(Options{})
+	require.NoError(t, err)
+	corpus.Requests[0].Valid = false
+	corpus.Cards[1].Valid = true
+	corpus.Streams[0].Events = corpus.Streams[0].Events[1:]
+
+	assert.Len(t, Check(context.Background(), corpus), 3)
+}
+
+// TestCorpus runs the checked-in corpus shared with the other SAGE
+// implementations
+func TestCorpus(t *testing.T) {
+	corpus, err := Load(filepath.Join("testdata", "vectors.json"))
+	require.NoError(t, err)
+	for _, failure := range Check(context.Background(), corpus) {
+		t.Error(failure)
+	}
+}
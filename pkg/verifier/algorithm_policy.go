@@ -0,0 +1,191 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ErrAlgorithmNotPermitted is matched by the errors of signatures an
+// AlgorithmPolicy rejects
+var ErrAlgorithmNotPermitted = errors.New("signature algorithm not permitted")
+
+// AlgorithmNotPermittedError details why an AlgorithmPolicy rejected a
+// signature. It matches ErrAlgorithmNotPermitted.
+type AlgorithmNotPermittedError struct {
+	AgentDID  did.AgentDID
+	Algorithm string      // alg parameter of the signature, if any
+	KeyType   did.KeyType // type of the resolved key
+	Reason    string
+}
+
+// Error implements error
+func (e *AlgorithmNotPermittedError) Error() string {
+	return fmt.Sprintf("%v for %s: %s", ErrAlgorithmNotPermitted, e.AgentDID, e.Reason)
+}
+
+// Unwrap returns ErrAlgorithmNotPermitted
+func (e *AlgorithmNotPermittedError) Unwrap() error {
+	return ErrAlgorithmNotPermitted
+}
+
+// AlgorithmPolicy restricts the signature algorithms and key types a
+// verifier accepts. It is consulted after the signer's key is resolved and
+// before the signature is checked cryptographically.
+type AlgorithmPolicy struct {
+	// Algorithms lists the permitted alg parameters, compared case
+	// insensitively; empty permits every supported algorithm
+	Algorithms []string
+
+	// KeyTypes lists the permitted key types; empty permits all
+	KeyTypes []did.KeyType
+
+	// RequireAlgorithm rejects signatures without an alg parameter
+	RequireAlgorithm bool
+
+	// RequireLowS rejects ECDSA signatures whose s value exceeds half the
+	// curve order. Such signatures are malleable copies of valid ones;
+	// secp256k1 signers following Ethereum conventions never emit them.
+	RequireLowS bool
+
+	// Overrides apply other policies to some agents, e.g. to require
+	// Ed25519 from internal ones. The first override whose pattern matches
+	// the signer's DID replaces this policy.
+	Overrides []AlgorithmOverride
+}
+
+// AlgorithmOverride applies Policy to the DIDs matching DIDPattern
+type AlgorithmOverride struct {
+	DIDPattern *regexp.Regexp
+	Policy     *AlgorithmPolicy
+}
+
+// For returns the policy applying to agentDID
+func (p *AlgorithmPolicy) For(agentDID did.AgentDID) *AlgorithmPolicy {
+	for _, o := range p.Overrides {
+		if o.DIDPattern != nil && o.Policy != nil && o.DIDPattern.MatchString(string(agentDID)) {
+			return o.Policy
+		}
+	}
+	return p
+}
+
+// Check returns an *AlgorithmNotPermittedError unless the policy for
+// agentDID permits a signature with alg under pub. sig holds the signature
+// bytes, which are only examined by RequireLowS.
+func (p *AlgorithmPolicy) Check(agentDID did.AgentDID, alg string, pub crypto.PublicKey, sig []byte) error {
+	policy := p.For(agentDID)
+	keyType := publicKeyType(pub)
+	deny := func(format string, args ...any) error {
+		return &AlgorithmNotPermittedError{AgentDID: agentDID, Algorithm: alg, KeyType: keyType, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	switch {
+	case alg == "" && policy.RequireAlgorithm:
+		return deny("signature has no alg parameter")
+	case alg != "" && len(policy.Algorithms) > 0 && !slices.ContainsFunc(policy.Algorithms, func(a string) bool { return strings.EqualFold(a, alg) }):
+		return deny("algorithm %q is not allowed", alg)
+	case len(policy.KeyTypes) > 0 && !slices.Contains(policy.KeyTypes, keyType):
+		return deny("%s keys are not allowed", keyType)
+	}
+
+	if ecKey, ok := pub.(*ecdsa.PublicKey); ok && policy.RequireLowS {
+		s, err := ecdsaSignatureS(sig)
+		if err != nil {
+			return deny("%v", err)
+		}
+		halfOrder := new(big.Int).Rsh(ecKey.Curve.Params().N, 1)
+		if s.Cmp(halfOrder) > 0 {
+			return deny("ECDSA signature is not in low-S form")
+		}
+	}
+	return nil
+}
+
+// ecdsaSignatureS returns the s value of a raw r||s (optionally followed
+// by a recovery byte) or ASN.1 DER encoded ECDSA signature
+func ecdsaSignatureS(sig []byte) (*big.Int, error) {
+	switch len(sig) {
+	case 64, 65:
+		return new(big.Int).SetBytes(sig[32:64]), nil
+	}
+	var der struct{ R, S *big.Int }
+	if rest, err := asn1.Unmarshal(sig, &der); err != nil || len(rest) > 0 || der.S == nil {
+		return nil, fmt.Errorf("undecodable ECDSA signature")
+	}
+	return der.S, nil
+}
+
+// signatureBytes returns the bytes of the signature with label in a
+// Signature header, or of its first signature if label is empty
+func signatureBytes(header, label string) ([]byte, error) {
+	// Decoding leniently only extracts the bytes; the encoding itself is
+	// still checked by the signature verifier
+	normalized, err := signer.NormalizeSignatureHeader(header, []signer.SignatureEncoding{signer.SignatureEncodingHex, signer.SignatureEncodingBase64})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range strings.Split(normalized, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if label != "" && name != label {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || value[0] != ':' || value[len(value)-1] != ':' {
+			return nil, fmt.Errorf("signature %s is not a byte sequence", name)
+		}
+		return base64.StdEncoding.DecodeString(value[1 : len(value)-1])
+	}
+	return nil, fmt.Errorf("signature %q not found in Signature header", label)
+}
+
+// SetAlgorithmPolicy makes the verifier reject signatures policy does not
+// permit with an *AlgorithmNotPermittedError, before checking them
+// cryptographically. A nil policy permits every supported algorithm.
+func (v *DefaultDIDVerifier) SetAlgorithmPolicy(policy *AlgorithmPolicy) {
+	v.algorithmPolicy = policy
+}
+
+// checkAlgorithmPolicy applies the algorithm policy to the signature with
+// label, resolved to pub
+func (v *DefaultDIDVerifier) checkAlgorithmPolicy(req *http.Request, label string, agentDID did.AgentDID, alg string, pub crypto.PublicKey) error {
+	if v.algorithmPolicy == nil {
+		return nil
+	}
+	var sig []byte
+	if _, ok := pub.(*ecdsa.PublicKey); ok && v.algorithmPolicy.For(agentDID).RequireLowS {
+		var err error
+		if sig, err = signatureBytes(req.Header.Get("Signature"), label); err != nil {
+			return &AlgorithmNotPermittedError{AgentDID: agentDID, Algorithm: alg, KeyType: publicKeyType(pub), Reason: err.Error()}
+		}
+	}
+	return v.algorithmPolicy.Check(agentDID, alg, pub, sig)
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDIDVerifier_AlgorithmPolicy(t *testing.T) {
+	ctx := context.Background()
+	pub := createEd25519Key()
+	resolver := resolverFunc(func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+		return &did.AgentMetadataV4{
+			DID:      did.AgentDID(didStr),
+			IsActive: true,
+			Keys:     []did.AgentKey{{Type: did.KeyTypeEd25519, KeyData: pub, Verified: true}},
+		}, nil
+	})
+	capture := &keyCapture{}
+	v := NewDefaultDIDVerifier(nil, NewDefaultKeySelector(resolver), capture)
+	request := func(keyID, alg string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/rpc", nil)
+		req.Header.Set("Signature", "sig1=:AAAA:")
+		input := `sig1=("@method");keyid="` + keyID + `"`
+		if alg != "" {
+			input += `;alg="` + alg + `"`
+		}
+		req.Header.Set("Signature-Input", input)
+		return req
+	}
+	verify := func(keyID, alg string) error {
+		capture.key = nil
+		return v.VerifyHTTPSignature(ctx, request(keyID, alg), did.AgentDID(keyID))
+	}
+
+	v.SetAlgorithmPolicy(&AlgorithmPolicy{
+		Algorithms:       []string{"ed25519", "es256k"},
+		RequireAlgorithm: true,
+		Overrides: []AlgorithmOverride{{
+			DIDPattern: regexp.MustCompile(`^did:sage:solana:internal-`),
+			Policy:     &AlgorithmPolicy{KeyTypes: []did.KeyType{did.KeyTypeECDSA}},
+		}},
+	})
+
+	require.NoError(t, verify("did:sage:solana:alice", "ED25519"))
+	assert.Equal(t, pub, capture.key)
+
+	err := verify("did:sage:solana:alice", "EdDSA")
+	assert.ErrorIs(t, err, ErrAlgorithmNotPermitted)
+	assert.Nil(t, capture.key, "rejected before cryptographic verification")
+
+	err = verify("did:sage:solana:alice", "")
+	var denied *AlgorithmNotPermittedError
+	require.True(t, errors.As(err, &denied), "%v", err)
+	assert.Equal(t, did.AgentDID("did:sage:solana:alice"), denied.AgentDID)
+	assert.Equal(t, did.KeyTypeEd25519, denied.KeyType)
+	assert.Contains(t, denied.Reason, "no alg parameter")
+
+	// The override replaces the global policy for internal agents
+	err = verify("did:sage:solana:internal-billing", "")
+	require.True(t, errors.As(err, &denied), "%v", err)
+	assert.Contains(t, denied.Reason, "keys are not allowed")
+
+	v.SetAlgorithmPolicy(nil)
+	require.NoError(t, verify("did:sage:solana:alice", ""))
+}
+
+func TestAlgorithmPolicy_RequireLowS(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("signature base"))
+	r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+	require.NoError(t, err)
+
+	n := priv.Curve.Params().N
+	low, high := s, new(big.Int).Sub(n, s)
+	if low.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		low, high = high, low
+	}
+	raw := func(s *big.Int) []byte {
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	}
+	der := func(s *big.Int) []byte {
+		sig, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		require.NoError(t, err)
+		return sig
+	}
+
+	policy := &AlgorithmPolicy{RequireLowS: true}
+	const signer = did.AgentDID("did:sage:ethereum:0xabc")
+	assert.NoError(t, policy.Check(signer, "es256", &priv.PublicKey, raw(low)))
+	assert.NoError(t, policy.Check(signer, "es256", &priv.PublicKey, der(low)))
+	assert.ErrorIs(t, policy.Check(signer, "es256", &priv.PublicKey, raw(high)), ErrAlgorithmNotPermitted)
+	assert.ErrorIs(t, policy.Check(signer, "es256", &priv.PublicKey, der(high)), ErrAlgorithmNotPermitted)
+	assert.ErrorIs(t, policy.Check(signer, "es256", &priv.PublicKey, []byte("junk")), ErrAlgorithmNotPermitted)
+
+	// Ed25519 signatures are not malleable this way
+	assert.NoError(t, policy.Check(signer, "ed25519", createEd25519Key(), []byte("junk")))
+	assert.NoError(t, (&AlgorithmPolicy{}).Check(signer, "es256", &priv.PublicKey, raw(high)))
+}
+
+func TestSignatureBytes(t *testing.T) {
+	a, b := []byte{1, 2, 3}, []byte{4, 5, 6}
+	header := "sig1=:" + base64.StdEncoding.EncodeToString(a) + ":, sig2=" + base64.StdEncoding.EncodeToString(b)
+
+	got, err := signatureBytes(header, "")
+	require.NoError(t, err)
+	assert.Equal(t, a, got)
+	got, err = signatureBytes(header, "sig2")
+	require.NoError(t, err)
+	assert.Equal(t, b, got)
+	_, err = signatureBytes(header, "sig3")
+	assert.Error(t, err)
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/internal/fsutil"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultSnapshotMaxAge is how old a restored cache entry may be
+const DefaultSnapshotMaxAge = time.Hour
+
+// snapshotVersion is the version of the snapshot file format
+const snapshotVersion = 1
+
+// snapshotAAD binds encrypted snapshots to their purpose
+var snapshotAAD = []byte("sage-a2a-go resolver cache snapshot v1")
+
+// ErrSnapshotCorrupted is returned when a snapshot fails its integrity
+// check, was encrypted under another key or cannot be parsed
+var ErrSnapshotCorrupted = errors.New("cache snapshot corrupted")
+
+// SnapshotConfig configures saving and restoring a resolution cache, so a
+// restarted server starts warm instead of resolving every peer on chain
+// at once
+type SnapshotConfig struct {
+	// Path is the snapshot file. It is replaced atomically on save.
+	Path string
+
+	// Key, if set, is a 32-byte AES-256 key encrypting and authenticating
+	// the snapshot. Without it the snapshot is plain JSON guarded by a
+	// SHA-256 checksum, which detects corruption but not tampering.
+	Key []byte
+
+	// MaxAge drops restored entries resolved longer ago than this
+	// (default DefaultSnapshotMaxAge)
+	MaxAge time.Duration
+}
+
+// snapshotFile is the on-disk form of a snapshot
+type snapshotFile struct {
+	Version  int       `json:"version"`
+	SavedAt  time.Time `json:"savedAt"`
+	Nonce    []byte    `json:"nonce,omitempty"`    // set when encrypted
+	Checksum string    `json:"checksum,omitempty"` // SHA-256 of Payload when not encrypted
+	Payload  []byte    `json:"payload"`
+}
+
+// snapshotEntry is a cached value in a snapshot
+type snapshotEntry struct {
+	Key     string    `json:"key"`
+	Type    string    `json:"type"`
+	Data    []byte    `json:"data"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// SaveSnapshot writes the cached metadata to config.Path
+func (c *CachedResolver) SaveSnapshot(config SnapshotConfig) error {
+	return c.cache.save(config, encodeSnapshotMetadata)
+}
+
+// LoadSnapshot restores metadata saved by SaveSnapshot and returns the
+// number of entries restored. A missing file restores nothing. Restored
+// entries keep their original resolution time, so expired ones are served
+// stale and refreshed in the background when MaxStale allows it.
+func (c *CachedResolver) LoadSnapshot(config SnapshotConfig) (int, error) {
+	return c.cache.load(config, decodeSnapshotMetadata)
+}
+
+// SaveSnapshot writes the cached keys to config.Path
+func (c *CachedPublicKeyClient) SaveSnapshot(config SnapshotConfig) error {
+	return c.cache.save(config, encodeSnapshotKey)
+}
+
+// LoadSnapshot restores keys saved by SaveSnapshot and returns the number
+// of entries restored, as CachedResolver.LoadSnapshot does
+func (c *CachedPublicKeyClient) LoadSnapshot(config SnapshotConfig) (int, error) {
+	return c.cache.load(config, decodeSnapshotKey)
+}
+
+// save writes the entries encode supports to a snapshot
+func (c *resolutionCache) save(config SnapshotConfig, encode func(v interface{}) (string, []byte, error)) error {
+	c.mu.Lock()
+	entries := make([]snapshotEntry, 0, len(c.entries))
+	for key, e := range c.entries {
+		typ, data, err := encode(e.value)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, snapshotEntry{Key: key, Type: typ, Data: data, Fetched: e.fetched})
+	}
+	c.mu.Unlock()
+
+	payload, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache snapshot: %w", err)
+	}
+	file := snapshotFile{Version: snapshotVersion, SavedAt: c.now()}
+	if len(config.Key) > 0 {
+		aead, err := snapshotAEAD(config.Key)
+		if err != nil {
+			return err
+		}
+		file.Nonce = make([]byte, aead.NonceSize())
+		if _, err := rand.Read(file.Nonce); err != nil {
+			return fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		file.Payload = aead.Seal(nil, file.Nonce, payload, snapshotAAD)
+	} else {
+		sum := sha256.Sum256(payload)
+		file.Checksum = hex.EncodeToString(sum[:])
+		file.Payload = payload
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache snapshot: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(config.Path, data); err != nil {
+		return fmt.Errorf("failed to write cache snapshot: %w", err)
+	}
+	return nil
+}
+
+// load restores the entries of a snapshot that are recent enough and not
+// older than entries already cached
+func (c *resolutionCache) load(config SnapshotConfig, decode func(typ string, data []byte) (interface{}, error)) (int, error) {
+	if config.MaxAge <= 0 {
+		config.MaxAge = DefaultSnapshotMaxAge
+	}
+	data, err := os.ReadFile(config.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache snapshot: %w", err)
+	}
+	var file snapshotFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrSnapshotCorrupted, err)
+	}
+	if file.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported cache snapshot version %d", file.Version)
+	}
+
+	payload := file.Payload
+	switch {
+	case len(config.Key) > 0:
+		if file.Nonce == nil {
+			return 0, fmt.Errorf("%w: snapshot is not encrypted", ErrSnapshotCorrupted)
+		}
+		aead, err := snapshotAEAD(config.Key)
+		if err != nil {
+			return 0, err
+		}
+		if len(file.Nonce) != aead.NonceSize() {
+			return 0, fmt.Errorf("%w: invalid nonce", ErrSnapshotCorrupted)
+		}
+		if payload, err = aead.Open(nil, file.Nonce, file.Payload, snapshotAAD); err != nil {
+			return 0, fmt.Errorf("%w: wrong key or modified snapshot", ErrSnapshotCorrupted)
+		}
+	case file.Nonce != nil:
+		return 0, fmt.Errorf("cache snapshot is encrypted but no key is configured")
+	default:
+		sum := sha256.Sum256(payload)
+		if file.Checksum != hex.EncodeToString(sum[:]) {
+			return 0, fmt.Errorf("%w: checksum mismatch", ErrSnapshotCorrupted)
+		}
+	}
+	var entries []snapshotEntry
+	if err := json.Unmarshal(payload, &entries); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrSnapshotCorrupted, err)
+	}
+
+	now := c.now()
+	restored := 0
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range entries {
+		// Entries from the future are treated as just resolved
+		if entry.Fetched.After(now) {
+			entry.Fetched = now
+		}
+		if now.Sub(entry.Fetched) > config.MaxAge {
+			continue
+		}
+		if e, ok := c.entries[entry.Key]; ok && !e.fetched.Before(entry.Fetched) {
+			continue
+		}
+		value, err := decode(entry.Type, entry.Data)
+		if err != nil {
+			continue
+		}
+		c.entries[entry.Key] = &cacheEntry{value: value, fetched: entry.Fetched}
+		restored++
+	}
+	return restored, nil
+}
+
+// snapshotAEAD returns AES-256-GCM under key
+func snapshotAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("snapshot key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encodeSnapshotMetadata encodes cached agent metadata
+func encodeSnapshotMetadata(v interface{}) (string, []byte, error) {
+	meta, ok := v.(*did.AgentMetadataV4)
+	if !ok || meta == nil {
+		return "", nil, fmt.Errorf("unsupported cache value: %T", v)
+	}
+	data, err := json.Marshal(meta)
+	return "agent", data, err
+}
+
+// decodeSnapshotMetadata decodes agent metadata encoded by
+// encodeSnapshotMetadata
+func decodeSnapshotMetadata(typ string, data []byte) (interface{}, error) {
+	if typ != "agent" {
+		return nil, fmt.Errorf("unsupported snapshot entry type %q", typ)
+	}
+	var meta did.AgentMetadataV4
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// encodeSnapshotKey encodes a cached public key
+func encodeSnapshotKey(v interface{}) (string, []byte, error) {
+	switch pk := v.(type) {
+	case *ecdsa.PublicKey:
+		data, err := MarshalPublicKey(pk, KeyEncodingDER)
+		return "ecdsa", data, err
+	case ed25519.PublicKey:
+		return "ed25519", pk, nil
+	case *ecdh.PublicKey:
+		if pk.Curve() != ecdh.X25519() {
+			return "", nil, fmt.Errorf("unsupported ECDH curve")
+		}
+		return "ecdh-x25519", pk.Bytes(), nil
+	case []byte:
+		return "x25519", pk, nil
+	}
+	return "", nil, fmt.Errorf("unsupported cache value: %T", v)
+}
+
+// decodeSnapshotKey decodes a key encoded by encodeSnapshotKey into the
+// type it was cached as
+func decodeSnapshotKey(typ string, data []byte) (interface{}, error) {
+	var pub crypto.PublicKey
+	var err error
+	switch typ {
+	case "ecdsa":
+		pub, err = NormalizePublicKey(data, did.KeyTypeECDSA)
+	case "ed25519":
+		pub, err = NormalizePublicKey(data, did.KeyTypeEd25519)
+	case "ecdh-x25519":
+		pub, err = ecdh.X25519().NewPublicKey(data)
+	case "x25519":
+		pub, err = NormalizePublicKey(data, did.KeyTypeX25519)
+	default:
+		err = fmt.Errorf("unsupported snapshot entry type %q", typ)
+	}
+	return pub, err
+}
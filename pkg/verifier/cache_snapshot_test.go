@@ -0,0 +1,194 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticKeyClient returns fixed keys and counts lookups
+type staticKeyClient struct {
+	keys  map[did.AgentDID]interface{}
+	kem   interface{}
+	calls atomic.Int32
+}
+
+func (c *staticKeyClient) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	c.calls.Add(1)
+	return c.keys[agentDID], nil
+}
+
+func (c *staticKeyClient) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	c.calls.Add(1)
+	return c.kem, nil
+}
+
+func TestCachedPublicKeyClient_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	kem, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	inner := &staticKeyClient{
+		keys: map[did.AgentDID]interface{}{
+			"did:sage:ethereum:0xa": &ecKey.PublicKey,
+			"did:sage:solana:b":     createEd25519Key(),
+		},
+		kem: kem.PublicKey(),
+	}
+	warm := func() *CachedPublicKeyClient {
+		client := NewCachedPublicKeyClient(inner, CacheConfig{TTL: time.Minute, MaxStale: time.Hour})
+		for agentDID := range inner.keys {
+			_, err := client.ResolvePublicKey(ctx, agentDID)
+			require.NoError(t, err)
+		}
+		_, err := client.ResolveKEMKey(ctx, "did:sage:ethereum:0xa")
+		require.NoError(t, err)
+		return client
+	}
+
+	for _, key := range [][]byte{nil, make([]byte, 32)} {
+		config := SnapshotConfig{Path: filepath.Join(t.TempDir(), "keys.snapshot"), Key: key}
+		require.NoError(t, warm().SaveSnapshot(config))
+
+		// A restarted client serves restored keys without chain lookups
+		inner.calls.Store(0)
+		restarted := NewCachedPublicKeyClient(inner, CacheConfig{TTL: time.Minute})
+		n, err := restarted.LoadSnapshot(config)
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		for agentDID, want := range inner.keys {
+			got, err := restarted.ResolvePublicKey(ctx, agentDID)
+			require.NoError(t, err)
+			assert.True(t, want.(interface{ Equal(crypto.PublicKey) bool }).Equal(got))
+		}
+		got, err := restarted.ResolveKEMKey(ctx, "did:sage:ethereum:0xa")
+		require.NoError(t, err)
+		assert.True(t, kem.PublicKey().Equal(got))
+		assert.Zero(t, inner.calls.Load())
+	}
+}
+
+func TestResolutionCache_SnapshotIntegrity(t *testing.T) {
+	ctx := context.Background()
+	inner := &staticKeyClient{keys: map[did.AgentDID]interface{}{"did:sage:solana:b": createEd25519Key()}}
+	client := NewCachedPublicKeyClient(inner, CacheConfig{})
+	_, err := client.ResolvePublicKey(ctx, "did:sage:solana:b")
+	require.NoError(t, err)
+
+	key := make([]byte, 32)
+	config := SnapshotConfig{Path: filepath.Join(t.TempDir(), "keys.snapshot"), Key: key}
+	require.NoError(t, client.SaveSnapshot(config))
+
+	t.Run("wrong key", func(t *testing.T) {
+		other := config
+		other.Key = make([]byte, 32)
+		other.Key[0] = 1
+		_, err := NewCachedPublicKeyClient(inner, CacheConfig{}).LoadSnapshot(other)
+		assert.ErrorIs(t, err, ErrSnapshotCorrupted)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		plain := config
+		plain.Key = nil
+		_, err := NewCachedPublicKeyClient(inner, CacheConfig{}).LoadSnapshot(plain)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered checksum", func(t *testing.T) {
+		plain := SnapshotConfig{Path: filepath.Join(t.TempDir(), "keys.snapshot")}
+		require.NoError(t, client.SaveSnapshot(plain))
+		data, err := os.ReadFile(plain.Path)
+		require.NoError(t, err)
+		var file snapshotFile
+		require.NoError(t, json.Unmarshal(data, &file))
+		file.Payload[len(file.Payload)-2] ^= 1
+		data, err = json.Marshal(file)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(plain.Path, data, 0o600))
+
+		_, err = NewCachedPublicKeyClient(inner, CacheConfig{}).LoadSnapshot(plain)
+		assert.ErrorIs(t, err, ErrSnapshotCorrupted)
+	})
+
+	t.Run("staleness bound", func(t *testing.T) {
+		restarted := NewCachedPublicKeyClient(inner, CacheConfig{})
+		restarted.cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		n, err := restarted.LoadSnapshot(config)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		bounded := config
+		bounded.MaxAge = 3 * time.Hour
+		n, err = restarted.LoadSnapshot(bounded)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		n, err := NewCachedPublicKeyClient(inner, CacheConfig{}).LoadSnapshot(SnapshotConfig{Path: filepath.Join(t.TempDir(), "none")})
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
+
+func TestCachedResolver_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	pub := createEd25519Key()
+	calls := 0
+	resolver := resolverFunc(func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+		calls++
+		return &did.AgentMetadataV4{
+			DID:      did.AgentDID(didStr),
+			Name:     "agent",
+			IsActive: true,
+			Keys:     []did.AgentKey{{Type: did.KeyTypeEd25519, KeyData: pub, Verified: true}},
+		}, nil
+	})
+	cached := NewCachedResolver(resolver, CacheConfig{})
+	want, err := cached.GetAgentByDID(ctx, "did:sage:solana:b")
+	require.NoError(t, err)
+
+	config := SnapshotConfig{Path: filepath.Join(t.TempDir(), "agents.snapshot")}
+	require.NoError(t, cached.SaveSnapshot(config))
+
+	restarted := NewCachedResolver(resolver, CacheConfig{})
+	n, err := restarted.LoadSnapshot(config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got, err := restarted.GetAgentByDID(ctx, "did:sage:solana:b")
+	require.NoError(t, err)
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.Keys[0].KeyData, got.Keys[0].KeyData)
+	assert.Equal(t, 1, calls)
+}
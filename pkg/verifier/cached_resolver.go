@@ -0,0 +1,217 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Default cache settings
+const (
+	DefaultCacheTTL       = 5 * time.Minute
+	DefaultRefreshTimeout = 10 * time.Second
+)
+
+// CacheConfig configures cross-request caching of DID resolution results
+type CacheConfig struct {
+	// TTL is how long a result is fresh (default DefaultCacheTTL)
+	TTL time.Duration
+
+	// MaxStale enables stale-while-revalidate: for this long after TTL
+	// expires, the stale result is returned immediately while a background
+	// refresh runs. Zero disables it and expired entries are resolved
+	// synchronously.
+	MaxStale time.Duration
+
+	// RefreshTimeout bounds background refreshes (default DefaultRefreshTimeout)
+	RefreshTimeout time.Duration
+
+	// OnRefreshError is called when a background refresh fails. The stale
+	// entry remains usable until MaxStale elapses.
+	OnRefreshError func(key string, err error)
+}
+
+// cacheEntry is a cached result and its background refresh state
+type cacheEntry struct {
+	value      interface{}
+	fetched    time.Time
+	refreshing bool
+}
+
+// resolutionCache is a TTL cache with stale-while-revalidate shared by
+// CachedResolver and CachedPublicKeyClient
+type resolutionCache struct {
+	config CacheConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]*cacheEntry
+	inflight map[string]*memoEntry
+}
+
+func newResolutionCache(config CacheConfig) *resolutionCache {
+	if config.TTL <= 0 {
+		config.TTL = DefaultCacheTTL
+	}
+	if config.RefreshTimeout <= 0 {
+		config.RefreshTimeout = DefaultRefreshTimeout
+	}
+	return &resolutionCache{
+		config:   config,
+		now:      time.Now,
+		entries:  make(map[string]*cacheEntry),
+		inflight: make(map[string]*memoEntry),
+	}
+}
+
+// get returns the cached value for key, resolving it when missing or too
+// stale. Errors are never cached.
+func (c *resolutionCache) get(ctx context.Context, key string, resolve func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		age := c.now().Sub(e.fetched)
+		if age < c.config.TTL {
+			c.mu.Unlock()
+			return e.value, nil
+		}
+		if age < c.config.TTL+c.config.MaxStale {
+			if !e.refreshing {
+				e.refreshing = true
+				go c.refresh(ctx, key, resolve)
+			}
+			c.mu.Unlock()
+			return e.value, nil
+		}
+	}
+
+	// Coalesce concurrent synchronous resolutions of the same key
+	if pending, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-pending.ready:
+			return pending.value, pending.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	pending := &memoEntry{ready: make(chan struct{})}
+	c.inflight[key] = pending
+	c.mu.Unlock()
+
+	pending.value, pending.err = resolve(ctx)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if pending.err == nil {
+		c.entries[key] = &cacheEntry{value: pending.value, fetched: c.now()}
+	}
+	c.mu.Unlock()
+	close(pending.ready)
+
+	return pending.value, pending.err
+}
+
+// refresh re-resolves key in the background, detached from the request
+func (c *resolutionCache) refresh(ctx context.Context, key string, resolve func(ctx context.Context) (interface{}, error)) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.config.RefreshTimeout)
+	defer cancel()
+
+	value, err := resolve(ctx)
+
+	c.mu.Lock()
+	if err == nil {
+		c.entries[key] = &cacheEntry{value: value, fetched: c.now()}
+	} else if e, ok := c.entries[key]; ok {
+		e.refreshing = false
+	}
+	c.mu.Unlock()
+
+	if err != nil && c.config.OnRefreshError != nil {
+		c.config.OnRefreshError(key, err)
+	}
+}
+
+// invalidate drops every lookup kind cached for agentDID
+func (c *resolutionCache) invalidate(agentDID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, kind := range []string{"agent:", "pubkey:", "kem:"} {
+		delete(c.entries, kind+agentDID)
+	}
+}
+
+// CachedResolver wraps a DIDResolver with a cross-request TTL cache
+type CachedResolver struct {
+	resolver DIDResolver
+	cache    *resolutionCache
+}
+
+// NewCachedResolver creates a DIDResolver caching GetAgentByDID results
+// according to config
+func NewCachedResolver(resolver DIDResolver, config CacheConfig) *CachedResolver {
+	return &CachedResolver{resolver: resolver, cache: newResolutionCache(config)}
+}
+
+// GetAgentByDID implements DIDResolver
+func (c *CachedResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	v, err := c.cache.get(ctx, "agent:"+didStr, func(ctx context.Context) (interface{}, error) {
+		return c.resolver.GetAgentByDID(ctx, didStr)
+	})
+	meta, _ := v.(*did.AgentMetadataV4)
+	return meta, err
+}
+
+// Invalidate drops the cached metadata for agentDID, e.g. after a key rotation
+func (c *CachedResolver) Invalidate(agentDID did.AgentDID) {
+	c.cache.invalidate(string(agentDID))
+}
+
+// CachedPublicKeyClient wraps a PublicKeyClient with a cross-request TTL cache
+type CachedPublicKeyClient struct {
+	client PublicKeyClient
+	cache  *resolutionCache
+}
+
+// NewCachedPublicKeyClient creates a PublicKeyClient caching key lookups
+// according to config
+func NewCachedPublicKeyClient(client PublicKeyClient, config CacheConfig) *CachedPublicKeyClient {
+	return &CachedPublicKeyClient{client: client, cache: newResolutionCache(config)}
+}
+
+// ResolvePublicKey implements PublicKeyClient
+func (c *CachedPublicKeyClient) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return c.cache.get(ctx, "pubkey:"+string(agentDID), func(ctx context.Context) (interface{}, error) {
+		return c.client.ResolvePublicKey(ctx, agentDID)
+	})
+}
+
+// ResolveKEMKey implements PublicKeyClient
+func (c *CachedPublicKeyClient) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return c.cache.get(ctx, "kem:"+string(agentDID), func(ctx context.Context) (interface{}, error) {
+		return c.client.ResolveKEMKey(ctx, agentDID)
+	})
+}
+
+// Invalidate drops the cached keys for agentDID, e.g. after a key rotation
+func (c *CachedPublicKeyClient) Invalidate(agentDID did.AgentDID) {
+	c.cache.invalidate(string(agentDID))
+}
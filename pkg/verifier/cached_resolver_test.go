@@ -0,0 +1,175 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedKeyClient returns a new key version on every lookup
+type versionedKeyClient struct {
+	calls atomic.Int32
+	fail  atomic.Bool
+}
+
+func (c *versionedKeyClient) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	n := c.calls.Add(1)
+	if c.fail.Load() {
+		return nil, errors.New("chain unavailable")
+	}
+	return fmt.Sprintf("key-%d", n), nil
+}
+
+func (c *versionedKeyClient) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return nil, errors.New("not implemented")
+}
+
+// fakeClock is a manually advanced clock
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCachedClient(config CacheConfig) (*CachedPublicKeyClient, *versionedKeyClient, *fakeClock) {
+	inner := &versionedKeyClient{}
+	client := NewCachedPublicKeyClient(inner, config)
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	client.cache.now = clock.Now
+	return client, inner, clock
+}
+
+func TestCachedPublicKeyClient_TTL(t *testing.T) {
+	client, inner, clock := newTestCachedClient(CacheConfig{TTL: time.Minute})
+	ctx := context.Background()
+	const agentDID = did.AgentDID("did:sage:ethereum:0xa")
+
+	for i := 0; i < 3; i++ {
+		key, err := client.ResolvePublicKey(ctx, agentDID)
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", key)
+	}
+
+	// Without MaxStale, expired entries are resolved synchronously
+	clock.Advance(2 * time.Minute)
+	key, err := client.ResolvePublicKey(ctx, agentDID)
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", key)
+
+	client.Invalidate(agentDID)
+	key, _ = client.ResolvePublicKey(ctx, agentDID)
+	assert.Equal(t, "key-3", key)
+	assert.Equal(t, int32(3), inner.calls.Load())
+}
+
+func TestCachedPublicKeyClient_StaleWhileRevalidate(t *testing.T) {
+	client, inner, clock := newTestCachedClient(CacheConfig{TTL: time.Minute, MaxStale: time.Hour})
+	ctx := context.Background()
+	const agentDID = did.AgentDID("did:sage:ethereum:0xa")
+
+	key, _ := client.ResolvePublicKey(ctx, agentDID)
+	assert.Equal(t, "key-1", key)
+
+	// Expired but within MaxStale: the stale key is served immediately
+	clock.Advance(2 * time.Minute)
+	key, err := client.ResolvePublicKey(ctx, agentDID)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", key)
+
+	// The background refresh replaces it
+	require.Eventually(t, func() bool {
+		key, _ := client.ResolvePublicKey(ctx, agentDID)
+		return key == "key-2"
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), inner.calls.Load())
+
+	// Beyond MaxStale the lookup is synchronous again
+	clock.Advance(2 * time.Hour)
+	key, _ = client.ResolvePublicKey(ctx, agentDID)
+	assert.Equal(t, "key-3", key)
+}
+
+func TestCachedPublicKeyClient_RefreshError(t *testing.T) {
+	var refreshErr atomic.Value
+	client, inner, clock := newTestCachedClient(CacheConfig{
+		TTL:      time.Minute,
+		MaxStale: time.Hour,
+		OnRefreshError: func(key string, err error) {
+			refreshErr.Store(key + ": " + err.Error())
+		},
+	})
+	ctx := context.Background()
+	const agentDID = did.AgentDID("did:sage:ethereum:0xa")
+
+	_, _ = client.ResolvePublicKey(ctx, agentDID)
+	inner.fail.Store(true)
+	clock.Advance(2 * time.Minute)
+
+	key, err := client.ResolvePublicKey(ctx, agentDID)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", key)
+	require.Eventually(t, func() bool { return refreshErr.Load() != nil }, time.Second, time.Millisecond)
+	assert.Equal(t, "pubkey:did:sage:ethereum:0xa: chain unavailable", refreshErr.Load())
+
+	// The stale key stays usable and the next lookup retries the refresh
+	key, _ = client.ResolvePublicKey(ctx, agentDID)
+	assert.Equal(t, "key-1", key)
+	require.Eventually(t, func() bool { return inner.calls.Load() == 3 }, time.Second, time.Millisecond)
+
+	// Errors are not cached once the entry is too stale
+	clock.Advance(2 * time.Hour)
+	_, err = client.ResolvePublicKey(ctx, agentDID)
+	assert.Error(t, err)
+}
+
+func TestCachedResolver(t *testing.T) {
+	inner := &countingResolver{}
+	resolver := NewCachedResolver(inner, CacheConfig{})
+
+	for i := 0; i < 3; i++ {
+		meta, err := resolver.GetAgentByDID(context.Background(), "did:sage:ethereum:0xa")
+		require.NoError(t, err)
+		assert.Equal(t, did.AgentDID("did:sage:ethereum:0xa"), meta.DID)
+	}
+	assert.Equal(t, int32(1), inner.calls.Load())
+
+	resolver.Invalidate("did:sage:ethereum:0xa")
+	_, _ = resolver.GetAgentByDID(context.Background(), "did:sage:ethereum:0xa")
+	assert.Equal(t, int32(2), inner.calls.Load())
+}
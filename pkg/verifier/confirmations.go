@@ -0,0 +1,267 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultFinalityDepth is the depth after which key blocks are no longer
+// checked for reorgs when ConfirmationConfig.FinalityDepth is zero
+const DefaultFinalityDepth = 128
+
+// ErrKeyUnconfirmed is returned for keys registered or rotated in a block
+// without enough confirmations, or in a block that left the canonical chain
+var ErrKeyUnconfirmed = errors.New("key not confirmed on chain")
+
+// BlockRef identifies a block
+type BlockRef struct {
+	Number uint64
+	Hash   [32]byte
+}
+
+// ChainReader reads the canonical chain, e.g. an ethclient.Client adapter
+type ChainReader interface {
+	// BlockNumber returns the number of the latest block
+	BlockNumber(ctx context.Context) (uint64, error)
+
+	// BlockHash returns the hash of the canonical block at number
+	BlockHash(ctx context.Context, number uint64) ([32]byte, error)
+}
+
+// KeyBlockResolver returns the block in which the keys of agentDID were
+// last registered or rotated, e.g. from the registry's key events
+type KeyBlockResolver func(ctx context.Context, agentDID did.AgentDID) (BlockRef, error)
+
+// Invalidator drops cached resolution results for a DID. CachedResolver and
+// CachedPublicKeyClient implement it.
+type Invalidator interface {
+	Invalidate(agentDID did.AgentDID)
+}
+
+// ConfirmationConfig configures a ConfirmationGuard
+type ConfirmationConfig struct {
+	// Chain reads the canonical chain
+	Chain ChainReader
+
+	// KeyBlocks locates the block that last changed a DID's keys
+	KeyBlocks KeyBlockResolver
+
+	// Confirmations is the number of blocks required on top of the key
+	// block before its keys are trusted
+	Confirmations uint64
+
+	// FinalityDepth is how deep a key block must be before reorgs are no
+	// longer checked for (default DefaultFinalityDepth)
+	FinalityDepth uint64
+
+	// OnReorg is called with the DIDs whose key blocks left the canonical
+	// chain, after their cached results were invalidated
+	OnReorg func(affected []did.AgentDID)
+}
+
+// ConfirmationGuard refuses keys that are not yet confirmed on chain and
+// evicts cached keys whose block is reorganized away. Resolvers and key
+// clients wrapped by it check confirmations before every lookup; place
+// caches outside the wrappers and register them with InvalidateOnReorg.
+type ConfirmationGuard struct {
+	config ConfirmationConfig
+
+	mu          sync.Mutex
+	tracked     map[did.AgentDID]BlockRef
+	invalidated []Invalidator
+}
+
+// NewConfirmationGuard creates a guard using config.Chain and config.KeyBlocks
+func NewConfirmationGuard(config ConfirmationConfig) (*ConfirmationGuard, error) {
+	if config.Chain == nil || config.KeyBlocks == nil {
+		return nil, fmt.Errorf("chain reader and key block resolver are required")
+	}
+	if config.FinalityDepth == 0 {
+		config.FinalityDepth = DefaultFinalityDepth
+	}
+	return &ConfirmationGuard{config: config, tracked: make(map[did.AgentDID]BlockRef)}, nil
+}
+
+// InvalidateOnReorg registers caches to evict DIDs from when their key
+// block leaves the canonical chain
+func (g *ConfirmationGuard) InvalidateOnReorg(caches ...Invalidator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.invalidated = append(g.invalidated, caches...)
+}
+
+// Check returns an error wrapping ErrKeyUnconfirmed unless the keys of
+// agentDID were set in a canonical block with enough confirmations
+func (g *ConfirmationGuard) Check(ctx context.Context, agentDID did.AgentDID) error {
+	ref, err := g.config.KeyBlocks(ctx, agentDID)
+	if err != nil {
+		return fmt.Errorf("failed to locate key block: %w", err)
+	}
+	head, err := g.config.Chain.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read chain head: %w", err)
+	}
+	if head < ref.Number || head-ref.Number < g.config.Confirmations {
+		return fmt.Errorf("%w: %s keys set in block %d, head is %d, %d confirmations required",
+			ErrKeyUnconfirmed, agentDID, ref.Number, head, g.config.Confirmations)
+	}
+
+	canonical, err := g.config.Chain.BlockHash(ctx, ref.Number)
+	if err != nil {
+		return fmt.Errorf("failed to read block %d: %w", ref.Number, err)
+	}
+	if canonical != ref.Hash {
+		g.evict([]did.AgentDID{agentDID})
+		return fmt.Errorf("%w: %s key block %d is not canonical", ErrKeyUnconfirmed, agentDID, ref.Number)
+	}
+
+	if head-ref.Number < g.config.FinalityDepth {
+		g.mu.Lock()
+		g.tracked[agentDID] = ref
+		g.mu.Unlock()
+	}
+	return nil
+}
+
+// CheckReorgs re-checks the key blocks of recently confirmed DIDs and
+// evicts those no longer on the canonical chain, returning them
+func (g *ConfirmationGuard) CheckReorgs(ctx context.Context) ([]did.AgentDID, error) {
+	head, err := g.config.Chain.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain head: %w", err)
+	}
+
+	g.mu.Lock()
+	tracked := make(map[did.AgentDID]BlockRef, len(g.tracked))
+	for agentDID, ref := range g.tracked {
+		if head >= ref.Number && head-ref.Number >= g.config.FinalityDepth {
+			delete(g.tracked, agentDID)
+			continue
+		}
+		tracked[agentDID] = ref
+	}
+	g.mu.Unlock()
+
+	var affected []did.AgentDID
+	for agentDID, ref := range tracked {
+		canonical, err := g.config.Chain.BlockHash(ctx, ref.Number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block %d: %w", ref.Number, err)
+		}
+		if canonical != ref.Hash {
+			affected = append(affected, agentDID)
+		}
+	}
+	if len(affected) > 0 {
+		g.evict(affected)
+	}
+	return affected, nil
+}
+
+// Watch calls CheckReorgs whenever the chain head changes, polling every
+// interval, until ctx is done. Errors are retried at the next poll.
+func (g *ConfirmationGuard) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		head, err := g.config.Chain.BlockNumber(ctx)
+		if err != nil || head == last {
+			continue
+		}
+		if _, err := g.CheckReorgs(ctx); err == nil {
+			last = head
+		}
+	}
+}
+
+// evict stops tracking agentDIDs and invalidates their cached results
+func (g *ConfirmationGuard) evict(agentDIDs []did.AgentDID) {
+	g.mu.Lock()
+	for _, agentDID := range agentDIDs {
+		delete(g.tracked, agentDID)
+	}
+	caches := append([]Invalidator(nil), g.invalidated...)
+	g.mu.Unlock()
+
+	for _, agentDID := range agentDIDs {
+		for _, cache := range caches {
+			cache.Invalidate(agentDID)
+		}
+	}
+	if g.config.OnReorg != nil {
+		g.config.OnReorg(agentDIDs)
+	}
+}
+
+// WrapResolver returns a DIDResolver that checks confirmations before
+// returning metadata
+func (g *ConfirmationGuard) WrapResolver(resolver DIDResolver) DIDResolver {
+	return &confirmedResolver{guard: g, resolver: resolver}
+}
+
+// WrapPublicKeyClient returns a PublicKeyClient that checks confirmations
+// before returning keys
+func (g *ConfirmationGuard) WrapPublicKeyClient(client PublicKeyClient) PublicKeyClient {
+	return &confirmedPublicKeyClient{guard: g, client: client}
+}
+
+type confirmedResolver struct {
+	guard    *ConfirmationGuard
+	resolver DIDResolver
+}
+
+// GetAgentByDID implements DIDResolver
+func (r *confirmedResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	if err := r.guard.Check(ctx, did.AgentDID(didStr)); err != nil {
+		return nil, err
+	}
+	return r.resolver.GetAgentByDID(ctx, didStr)
+}
+
+type confirmedPublicKeyClient struct {
+	guard  *ConfirmationGuard
+	client PublicKeyClient
+}
+
+// ResolvePublicKey implements PublicKeyClient
+func (c *confirmedPublicKeyClient) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	if err := c.guard.Check(ctx, agentDID); err != nil {
+		return nil, err
+	}
+	return c.client.ResolvePublicKey(ctx, agentDID)
+}
+
+// ResolveKEMKey implements PublicKeyClient
+func (c *confirmedPublicKeyClient) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	if err := c.guard.Check(ctx, agentDID); err != nil {
+		return nil, err
+	}
+	return c.client.ResolveKEMKey(ctx, agentDID)
+}
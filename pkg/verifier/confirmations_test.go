@@ -0,0 +1,104 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package verifier
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain is a chain whose blocks can be replaced to simulate reorgs
+type fakeChain struct {
+	mu     sync.Mutex
+	head   uint64
+	hashes map[uint64][32]byte
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head, nil
+}
+
+func (c *fakeChain) BlockHash(ctx context.Context, number uint64) ([32]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hashes[number], nil
+}
+
+func (c *fakeChain) set(head uint64, number uint64, hash byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head = head
+	c.hashes[number] = [32]byte{hash}
+}
+
+func TestConfirmationGuard(t *testing.T) {
+	ctx := context.Background()
+	const agentDID = did.AgentDID("did:sage:ethereum:0xa")
+	chain := &fakeChain{hashes: make(map[uint64][32]byte)}
+	chain.set(101, 100, 1)
+
+	var reorged []did.AgentDID
+	guard, err := NewConfirmationGuard(ConfirmationConfig{
+		Chain: chain,
+		KeyBlocks: func(ctx context.Context, agentDID did.AgentDID) (BlockRef, error) {
+			return BlockRef{Number: 100, Hash: [32]byte{1}}, nil
+		},
+		Confirmations: 3,
+		OnReorg:       func(affected []did.AgentDID) { reorged = affected },
+	})
+	require.NoError(t, err)
+
+	inner := &versionedKeyClient{}
+	cached := NewCachedPublicKeyClient(guard.WrapPublicKeyClient(inner), CacheConfig{TTL: time.Hour})
+	guard.InvalidateOnReorg(cached)
+
+	_, err = cached.ResolvePublicKey(ctx, agentDID)
+	assert.ErrorIs(t, err, ErrKeyUnconfirmed, "one confirmation is not enough")
+
+	chain.set(103, 100, 1)
+	key, err := cached.ResolvePublicKey(ctx, agentDID)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", key)
+
+	affected, err := guard.CheckReorgs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, affected)
+
+	// The key block is replaced; the cached key is evicted
+	chain.set(104, 100, 2)
+	affected, err = guard.CheckReorgs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []did.AgentDID{agentDID}, affected)
+	assert.Equal(t, affected, reorged)
+
+	_, err = cached.ResolvePublicKey(ctx, agentDID)
+	assert.ErrorIs(t, err, ErrKeyUnconfirmed)
+	assert.Equal(t, int32(1), inner.calls.Load())
+}
+
+func TestNewConfirmationGuard_RequiresChain(t *testing.T) {
+	_, err := NewConfirmationGuard(ConfirmationConfig{})
+	assert.Error(t, err)
+}
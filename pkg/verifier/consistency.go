@@ -0,0 +1,306 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// DefaultMaxPingAge is how old the receipt of a consistency ping may be
+const DefaultMaxPingAge = 5 * time.Minute
+
+// agentCardPath is where agents publish their card
+const agentCardPath = "/.well-known/agent-card.json"
+
+// ConsistencyIssueCode identifies an inconsistency found by a
+// ConsistencyAuditor
+type ConsistencyIssueCode string
+
+const (
+	// IssueDIDNotFound: the DID is not registered on chain
+	IssueDIDNotFound ConsistencyIssueCode = "did_not_found"
+
+	// IssueDIDInactive: the DID is registered but deactivated
+	IssueDIDInactive ConsistencyIssueCode = "did_inactive"
+
+	// IssueNoChainKeys: no on-chain key of the DID could be decoded
+	IssueNoChainKeys ConsistencyIssueCode = "no_chain_keys"
+
+	// IssueCardUnavailable: the published card could not be fetched
+	IssueCardUnavailable ConsistencyIssueCode = "card_unavailable"
+
+	// IssueCardUnsigned: the card carries no signature by the DID
+	IssueCardUnsigned ConsistencyIssueCode = "card_unsigned"
+
+	// IssueCardKeyNotOnChain: the card is signed by the DID, but under a
+	// key that is not on chain
+	IssueCardKeyNotOnChain ConsistencyIssueCode = "card_key_not_on_chain"
+
+	// IssueEndpointMismatch: the card URL differs from the on-chain endpoint
+	IssueEndpointMismatch ConsistencyIssueCode = "endpoint_mismatch"
+
+	// IssuePingFailed: the live ping failed or returned no valid receipt
+	IssuePingFailed ConsistencyIssueCode = "ping_failed"
+
+	// IssuePingKeyNotOnChain: the ping receipt is signed under a key that
+	// is not on chain
+	IssuePingKeyNotOnChain ConsistencyIssueCode = "ping_key_not_on_chain"
+)
+
+// ConsistencyIssue is one inconsistency of a ConsistencyReport
+type ConsistencyIssue struct {
+	Code    ConsistencyIssueCode `json:"code"`
+	Message string               `json:"message"`
+}
+
+// ConsistencyReport is the outcome of VerifyAgentConsistency
+type ConsistencyReport struct {
+	DID       did.AgentDID `json:"did"`
+	CheckedAt time.Time    `json:"checkedAt"`
+
+	// On-chain state
+	Active    bool   `json:"active"`
+	ChainKeys int    `json:"chainKeys"`
+	Endpoint  string `json:"endpoint,omitempty"`
+
+	// CardURL is the URL advertised by the published card
+	CardURL string `json:"cardUrl,omitempty"`
+
+	// Pinged is set when a live ping was attempted
+	Pinged bool `json:"pinged"`
+
+	Issues []ConsistencyIssue `json:"issues,omitempty"`
+}
+
+// OK reports whether no inconsistency was found
+func (r *ConsistencyReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Has reports whether the report contains an issue with code
+func (r *ConsistencyReport) Has(code ConsistencyIssueCode) bool {
+	for _, issue := range r.Issues {
+		if issue.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ConsistencyReport) issue(code ConsistencyIssueCode, format string, args ...any) {
+	r.Issues = append(r.Issues, ConsistencyIssue{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// PingFunc sends a live request to the agent at endpoint and returns the
+// request body and the receipt (protocol.ReceiptHeader) the agent signed
+// for it. The body should be unique, e.g. carry a fresh message ID, so the
+// receipt proves the agent is live.
+type PingFunc func(ctx context.Context, endpoint string) (body []byte, receipt string, err error)
+
+// ConsistencyConfig configures a ConsistencyAuditor
+type ConsistencyConfig struct {
+	// Resolver returns the on-chain metadata of agents
+	Resolver DIDResolver
+
+	// FetchCard returns the published card of an agent. By default it is
+	// fetched from /.well-known/agent-card.json under the on-chain
+	// endpoint with HTTPClient.
+	FetchCard func(ctx context.Context, endpoint string) (*a2a.AgentCard, error)
+
+	// HTTPClient fetches cards by default (http.DefaultClient when nil)
+	HTTPClient *http.Client
+
+	// Ping, when set, pings the agent at its on-chain endpoint
+	Ping PingFunc
+
+	// MaxPingAge is how old a ping receipt may be (default
+	// DefaultMaxPingAge)
+	MaxPingAge time.Duration
+}
+
+// ConsistencyAuditor cross-checks an agent's on-chain registration, its
+// published signed card and its live responses, for operator tooling
+type ConsistencyAuditor struct {
+	config ConsistencyConfig
+	now    func() time.Time
+}
+
+// NewConsistencyAuditor creates an auditor resolving agents with
+// config.Resolver
+func NewConsistencyAuditor(config ConsistencyConfig) (*ConsistencyAuditor, error) {
+	if config.Resolver == nil {
+		return nil, fmt.Errorf("DID resolver is required")
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.MaxPingAge <= 0 {
+		config.MaxPingAge = DefaultMaxPingAge
+	}
+	a := &ConsistencyAuditor{config: config, now: time.Now}
+	if a.config.FetchCard == nil {
+		a.config.FetchCard = a.fetchCard
+	}
+	return a, nil
+}
+
+// VerifyAgentConsistency checks that agentDID is active on chain, that its
+// published card is signed under one of its on-chain keys and advertises
+// its on-chain endpoint, and, with a Ping, that the agent answers with a
+// receipt signed under an on-chain key. Inconsistencies are listed in the
+// report; an error is only returned when the chain cannot be queried.
+func (a *ConsistencyAuditor) VerifyAgentConsistency(ctx context.Context, agentDID did.AgentDID) (*ConsistencyReport, error) {
+	report := &ConsistencyReport{DID: agentDID, CheckedAt: a.now()}
+
+	meta, err := a.config.Resolver.GetAgentByDID(ctx, string(agentDID))
+	// did.DIDError holds a map, so errors.Is cannot match its sentinels
+	var didErr did.DIDError
+	if (errors.As(err, &didErr) && didErr.Code == did.ErrDIDNotFound.Code) || (err == nil && meta == nil) {
+		report.issue(IssueDIDNotFound, "%s is not registered", agentDID)
+		return report, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", agentDID, err)
+	}
+	report.Active = meta.IsActive
+	report.Endpoint = meta.Endpoint
+	if !meta.IsActive {
+		report.issue(IssueDIDInactive, "%s is deactivated", agentDID)
+	}
+
+	var keys []crypto.PublicKey
+	for _, k := range meta.Keys {
+		if pub, err := NormalizePublicKey(k.KeyData, k.Type); err == nil {
+			keys = append(keys, pub)
+		}
+	}
+	report.ChainKeys = len(keys)
+	if len(keys) == 0 {
+		report.issue(IssueNoChainKeys, "no usable key is registered for %s", agentDID)
+	}
+	if meta.Endpoint == "" {
+		report.issue(IssueCardUnavailable, "no endpoint is registered for %s", agentDID)
+		return report, nil
+	}
+
+	a.checkCard(ctx, report, keys)
+	if a.config.Ping != nil {
+		a.checkPing(ctx, report, keys)
+	}
+	return report, nil
+}
+
+// checkCard checks the published card against the on-chain keys and endpoint
+func (a *ConsistencyAuditor) checkCard(ctx context.Context, report *ConsistencyReport, keys []crypto.PublicKey) {
+	card, err := a.config.FetchCard(ctx, report.Endpoint)
+	if err != nil {
+		report.issue(IssueCardUnavailable, "failed to fetch card: %v", err)
+		return
+	}
+	report.CardURL = card.URL
+	if !sameEndpoint(card.URL, report.Endpoint) {
+		report.issue(IssueEndpointMismatch, "card URL %q differs from on-chain endpoint %q", card.URL, report.Endpoint)
+	}
+
+	signed := false
+	for _, key := range keys {
+		err := protocol.VerifyA2AAgentCard(card, report.DID, key)
+		if err == nil {
+			return
+		}
+		if !errors.Is(err, protocol.ErrCardNotSigned) {
+			signed = true
+		}
+	}
+	switch {
+	case signed:
+		report.issue(IssueCardKeyNotOnChain, "card signature by %s does not verify under any on-chain key", report.DID)
+	case len(keys) > 0 || len(card.Signatures) == 0:
+		report.issue(IssueCardUnsigned, "card carries no signature by %s", report.DID)
+	}
+}
+
+// checkPing pings the agent and checks the receipt it signed
+func (a *ConsistencyAuditor) checkPing(ctx context.Context, report *ConsistencyReport, keys []crypto.PublicKey) {
+	report.Pinged = true
+	body, token, err := a.config.Ping(ctx, report.Endpoint)
+	if err != nil {
+		report.issue(IssuePingFailed, "ping failed: %v", err)
+		return
+	}
+	receipt, err := protocol.ParseReceipt(token)
+	if err != nil {
+		report.issue(IssuePingFailed, "invalid ping receipt: %v", err)
+		return
+	}
+	switch {
+	case receipt.Issuer != report.DID:
+		report.issue(IssuePingFailed, "ping answered by %s", receipt.Issuer)
+		return
+	case receipt.RequestDigest != protocol.ReceiptDigest(body):
+		report.issue(IssuePingFailed, "ping receipt does not cover the ping request")
+		return
+	case a.now().Sub(time.Unix(receipt.IssuedAt, 0)) > a.config.MaxPingAge:
+		report.issue(IssuePingFailed, "ping receipt issued at %s is stale", time.Unix(receipt.IssuedAt, 0).UTC())
+		return
+	}
+	for _, key := range keys {
+		if _, err := protocol.VerifyReceipt(token, key); err == nil {
+			return
+		}
+	}
+	report.issue(IssuePingKeyNotOnChain, "ping receipt does not verify under any on-chain key")
+}
+
+// fetchCard fetches the card published under endpoint
+func (a *ConsistencyAuditor) fetchCard(ctx context.Context, endpoint string) (*a2a.AgentCard, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(endpoint, "/")+agentCardPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("card request returned %s", resp.Status)
+	}
+	var card a2a.AgentCard
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&card); err != nil {
+		return nil, fmt.Errorf("failed to decode card: %w", err)
+	}
+	return &card, nil
+}
+
+// sameEndpoint compares endpoints ignoring case and a trailing slash
+func sameEndpoint(a, b string) bool {
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
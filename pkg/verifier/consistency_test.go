@@ -0,0 +1,215 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAgentConsistency(t *testing.T) {
+	ctx := context.Background()
+	const agentDID = did.AgentDID("did:sage:ethereum:0xagent")
+	chainKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	rogueKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	var card *a2a.AgentCard
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != agentCardPath {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(card)
+	}))
+	defer srv.Close()
+
+	meta := &did.AgentMetadataV4{
+		DID:      agentDID,
+		Endpoint: srv.URL,
+		IsActive: true,
+		Keys:     []did.AgentKey{{Type: did.KeyTypeEd25519, KeyData: chainKey.PublicKey().(ed25519.PublicKey), Verified: true}},
+	}
+	resolver := resolverFunc(func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+		if didStr != string(agentDID) {
+			return nil, did.ErrDIDNotFound
+		}
+		return meta, nil
+	})
+	pingKey := chainKey
+	ping := func(ctx context.Context, endpoint string) ([]byte, string, error) {
+		body := []byte(`{"jsonrpc":"2.0","method":"message/send","id":"ping-1"}`)
+		r, err := protocol.IssueReceipt(agentDID, pingKey, "", body, "")
+		if err != nil {
+			return nil, "", err
+		}
+		return body, r.Token, nil
+	}
+
+	auditor, err := NewConsistencyAuditor(ConsistencyConfig{Resolver: resolver, Ping: ping})
+	require.NoError(t, err)
+
+	t.Run("consistent", func(t *testing.T) {
+		card = &a2a.AgentCard{Name: "agent", URL: srv.URL + "/"}
+		require.NoError(t, protocol.SignA2AAgentCard(card, agentDID, chainKey))
+
+		report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+		require.NoError(t, err)
+		assert.True(t, report.OK(), "%+v", report.Issues)
+		assert.True(t, report.Active)
+		assert.True(t, report.Pinged)
+		assert.Equal(t, 1, report.ChainKeys)
+		assert.Equal(t, srv.URL+"/", report.CardURL)
+	})
+
+	t.Run("card key not on chain and endpoint mismatch", func(t *testing.T) {
+		card = &a2a.AgentCard{Name: "agent", URL: "https://elsewhere.example.com"}
+		require.NoError(t, protocol.SignA2AAgentCard(card, agentDID, rogueKey))
+
+		report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+		require.NoError(t, err)
+		assert.True(t, report.Has(IssueCardKeyNotOnChain))
+		assert.True(t, report.Has(IssueEndpointMismatch))
+		assert.False(t, report.Has(IssueCardUnsigned))
+	})
+
+	t.Run("unsigned card", func(t *testing.T) {
+		card = &a2a.AgentCard{Name: "agent", URL: srv.URL}
+
+		report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+		require.NoError(t, err)
+		assert.Equal(t, []ConsistencyIssue{{Code: IssueCardUnsigned, Message: "card carries no signature by " + string(agentDID)}}, report.Issues)
+	})
+
+	t.Run("ping signed by rogue key", func(t *testing.T) {
+		card = &a2a.AgentCard{Name: "agent", URL: srv.URL}
+		require.NoError(t, protocol.SignA2AAgentCard(card, agentDID, chainKey))
+		pingKey = rogueKey
+		defer func() { pingKey = chainKey }()
+
+		report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+		require.NoError(t, err)
+		assert.True(t, report.Has(IssuePingKeyNotOnChain))
+		assert.Len(t, report.Issues, 1)
+	})
+
+	t.Run("inactive DID", func(t *testing.T) {
+		meta.IsActive = false
+		defer func() { meta.IsActive = true }()
+
+		report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+		require.NoError(t, err)
+		assert.False(t, report.Active)
+		assert.True(t, report.Has(IssueDIDInactive))
+	})
+
+	t.Run("card unavailable", func(t *testing.T) {
+		meta.Endpoint = srv.URL + "/missing"
+		defer func() { meta.Endpoint = srv.URL }()
+
+		report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+		require.NoError(t, err)
+		assert.True(t, report.Has(IssueCardUnavailable))
+	})
+
+	t.Run("unknown DID", func(t *testing.T) {
+		report, err := auditor.VerifyAgentConsistency(ctx, "did:sage:ethereum:0xnobody")
+		require.NoError(t, err)
+		assert.True(t, report.Has(IssueDIDNotFound))
+	})
+}
+
+func TestVerifyAgentConsistencyPing(t *testing.T) {
+	ctx := context.Background()
+	const agentDID = did.AgentDID("did:sage:ethereum:0xagent")
+	key, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	card := &a2a.AgentCard{Name: "agent", URL: "https://agent.example.com"}
+	require.NoError(t, protocol.SignA2AAgentCard(card, agentDID, key))
+
+	resolver := resolverFunc(func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+		return &did.AgentMetadataV4{
+			DID:      agentDID,
+			Endpoint: "https://agent.example.com",
+			IsActive: true,
+			Keys:     []did.AgentKey{{Type: did.KeyTypeEd25519, KeyData: key.PublicKey().(ed25519.PublicKey), Verified: true}},
+		}, nil
+	})
+	audit := func(ping PingFunc) *ConsistencyReport {
+		auditor, err := NewConsistencyAuditor(ConsistencyConfig{
+			Resolver:  resolver,
+			FetchCard: func(ctx context.Context, endpoint string) (*a2a.AgentCard, error) { return card, nil },
+			Ping:      ping,
+		})
+		require.NoError(t, err)
+		report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+		require.NoError(t, err)
+		return report
+	}
+	body := []byte(`{"id":"ping-2"}`)
+	receipt := func(issuer did.AgentDID, body []byte) string {
+		r, err := protocol.IssueReceipt(issuer, key, "", body, "")
+		require.NoError(t, err)
+		return r.Token
+	}
+
+	report := audit(func(ctx context.Context, endpoint string) ([]byte, string, error) {
+		return nil, "", errors.New("connection refused")
+	})
+	assert.True(t, report.Has(IssuePingFailed))
+
+	report = audit(func(ctx context.Context, endpoint string) ([]byte, string, error) {
+		return body, receipt(agentDID, []byte("other")), nil
+	})
+	assert.True(t, report.Has(IssuePingFailed), "receipt for another request")
+
+	report = audit(func(ctx context.Context, endpoint string) ([]byte, string, error) {
+		return body, receipt("did:sage:ethereum:0xproxy", body), nil
+	})
+	assert.True(t, report.Has(IssuePingFailed), "receipt by another agent")
+
+	auditor, err := NewConsistencyAuditor(ConsistencyConfig{
+		Resolver:  resolver,
+		FetchCard: func(ctx context.Context, endpoint string) (*a2a.AgentCard, error) { return card, nil },
+		Ping: func(ctx context.Context, endpoint string) ([]byte, string, error) {
+			return body, receipt(agentDID, body), nil
+		},
+	})
+	require.NoError(t, err)
+	auditor.now = func() time.Time { return time.Now().Add(time.Hour) }
+	report, err = auditor.VerifyAgentConsistency(ctx, agentDID)
+	require.NoError(t, err)
+	assert.True(t, report.Has(IssuePingFailed), "stale receipt")
+
+	_, err = NewConsistencyAuditor(ConsistencyConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Context errors returned by DefaultDIDVerifier. They wrap the context's own
+// error, so errors.Is(err, context.DeadlineExceeded) keeps working, and let
+// callers tell infrastructure slowness from bad signatures.
+var (
+	// ErrResolutionTimeout is returned when the deadline passed while
+	// resolving the signer's key
+	ErrResolutionTimeout = errors.New("key resolution timed out")
+
+	// ErrVerificationTimeout is returned when the deadline passed outside
+	// key resolution
+	ErrVerificationTimeout = errors.New("verification deadline exceeded")
+
+	// ErrVerificationCanceled is returned when the caller canceled the
+	// verification
+	ErrVerificationCanceled = errors.New("verification canceled")
+)
+
+// Verification stages reported in context errors
+const (
+	stageParse   = "parsing"
+	stageResolve = "key resolution"
+	stageCrypto  = "signature check"
+)
+
+// IsContextError reports whether err was caused by a canceled or expired
+// context rather than by the request or signature itself
+func IsContextError(err error) bool {
+	return errors.Is(err, ErrResolutionTimeout) ||
+		errors.Is(err, ErrVerificationTimeout) ||
+		errors.Is(err, ErrVerificationCanceled)
+}
+
+// contextError wraps the error of a done ctx with the sentinel matching
+// stage. It returns nil while ctx is live.
+func contextError(ctx context.Context, stage string) error {
+	err := ctx.Err()
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("%w during %s: %w", ErrVerificationCanceled, stage, err)
+	case stage == stageResolve:
+		return fmt.Errorf("%w: %w", ErrResolutionTimeout, err)
+	default:
+		return fmt.Errorf("%w during %s: %w", ErrVerificationTimeout, stage, err)
+	}
+}
+
+// resolveWithContext runs resolve and returns as soon as ctx ends, so
+// resolvers that ignore ctx cannot hold the caller past its deadline.
+// Failures caused by ctx are reported as context errors.
+func resolveWithContext[T any](ctx context.Context, resolve func() (T, error)) (T, error) {
+	var zero T
+	if err := contextError(ctx, stageResolve); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := resolve()
+		done <- result{value, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			if ctxErr := contextError(ctx, stageResolve); ctxErr != nil {
+				return zero, ctxErr
+			}
+		}
+		return res.value, res.err
+	case <-ctx.Done():
+		return zero, contextError(ctx, stageResolve)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowResolver ignores ctx and blocks until release is closed
+type slowResolver struct {
+	release chan struct{}
+}
+
+func (s *slowResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	<-s.release
+	return nil, did.ErrDIDNotFound
+}
+
+func TestDefaultDIDVerifier_ResolutionTimeout(t *testing.T) {
+	resolver := &slowResolver{release: make(chan struct{})}
+	defer close(resolver.release)
+	verifier := NewDefaultDIDVerifier(&mockEthereumClient{}, NewDefaultKeySelector(resolver), &mockSignatureVerifier{})
+
+	req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:ethereum:0xslow"`)
+	req.Header.Set("Signature", "sig1=:dGVzdA==:")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := verifier.VerifyHTTPSignatureWithKeyID(ctx, req)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.ErrorIs(t, err, ErrResolutionTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, IsContextError(err))
+}
+
+func TestDefaultDIDVerifier_ContextErrors(t *testing.T) {
+	verifier := NewDefaultDIDVerifier(&mockEthereumClient{}, NewDefaultKeySelector(&mockEthereumClient{}), &mockSignatureVerifier{})
+	req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := verifier.VerifyHTTPSignature(ctx, req, "did:sage:ethereum:0xabc")
+	assert.ErrorIs(t, err, ErrVerificationCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = verifier.VerifyHTTPSignatureWithKeyID(ctx, req)
+	assert.ErrorIs(t, err, ErrVerificationTimeout)
+	assert.NotErrorIs(t, err, ErrResolutionTimeout)
+
+	// Bad signatures are not context errors
+	assert.False(t, IsContextError(errors.New("signature verification failed")))
+}
+
+func TestMemoize_WaiterHonorsContext(t *testing.T) {
+	ctx := WithResolutionCache(context.Background())
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_, _ = Memoize(ctx, "k", func() (interface{}, error) {
+			close(started)
+			<-release
+			return "v", nil
+		})
+	}()
+	<-started
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := Memoize(waitCtx, "k", func() (interface{}, error) { return nil, nil })
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrResolutionTimeout)
+	close(release)
+}
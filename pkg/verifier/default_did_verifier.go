@@ -21,6 +21,8 @@ package verifier
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"fmt"
 	"log"
 	"net/http"
@@ -30,6 +32,8 @@ import (
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
+//go:generate mockgen -write_package_comment=false -destination=../mocks/public_key_client.go -package=mocks . PublicKeyClient
+
 // PublicKeyClient is satisfied by pkg/agent/did/ethereum.EthereumClient
 // (it has ResolvePublicKey and ResolveKEMKey that return interface{}).
 type PublicKeyClient interface {
@@ -37,7 +41,10 @@ type PublicKeyClient interface {
 	ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error)
 }
 
-// SignatureVerifier as before …
+//go:generate mockgen -write_package_comment=false -destination=../mocks/signature_verifier.go -package=mocks . SignatureVerifier
+
+// SignatureVerifier verifies the RFC 9421 signature of a request under a
+// resolved public key. It is satisfied by RFC9421Verifier.
 type SignatureVerifier interface {
 	VerifyHTTPRequest(req *http.Request, pubKey interface{}) error
 }
@@ -49,9 +56,16 @@ type DefaultDIDVerifier struct {
 	client            PublicKeyClient // *ethereum.EthereumClient
 	selector          KeySelector     // NewDefaultKeySelector(AgentCardClient)
 	signatureVerifier SignatureVerifier
+	algorithmPolicy   *AlgorithmPolicy
 }
 
+// NewDefaultDIDVerifier creates a DID verifier. client may be nil, in which
+// case every key is chosen by selector; a nil selector resolves through the
+// methods added with RegisterDIDMethod only.
 func NewDefaultDIDVerifier(client PublicKeyClient, selector KeySelector, signatureVerifier SignatureVerifier) *DefaultDIDVerifier {
+	if selector == nil {
+		selector = NewDefaultKeySelector(nil)
+	}
 	return &DefaultDIDVerifier{
 		client:            client,
 		selector:          selector,
@@ -61,12 +75,24 @@ func NewDefaultDIDVerifier(client PublicKeyClient, selector KeySelector, signatu
 
 // ResolvePublicKey picks a key either by explicit KeyType or via selector policy.
 func (v *DefaultDIDVerifier) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+	// Registered DID methods are not served by the chain client; their keys
+	// are picked from the resolved metadata
+	if _, registered := lookupDIDMethod(string(agentDID)); keyType != nil && (registered || v.client == nil) {
+		pk, err := v.selectKey(ctx, agentDID, keyTypeProtocol(*keyType))
+		if err != nil {
+			return nil, fmt.Errorf("select %s key: %w", keyTypeProtocol(*keyType), err)
+		}
+		return pk, nil
+	}
+
 	// If the caller requests a specific key type, try a fast path.
 	if keyType != nil {
 		switch *keyType {
 		case did.KeyTypeX25519:
 			// HPKE/KEM key from chain (X25519 32 bytes)
-			pk, err := v.client.ResolveKEMKey(ctx, agentDID)
+			pk, err := resolveWithContext(ctx, func() (interface{}, error) {
+				return v.client.ResolveKEMKey(ctx, agentDID)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("resolve x25519 key: %w", err)
 			}
@@ -74,7 +100,9 @@ func (v *DefaultDIDVerifier) ResolvePublicKey(ctx context.Context, agentDID did.
 
 		case did.KeyTypeECDSA:
 			// Default signing key on Ethereum (secp256k1)
-			pk, err := v.client.ResolvePublicKey(ctx, agentDID)
+			pk, err := resolveWithContext(ctx, func() (interface{}, error) {
+				return v.client.ResolvePublicKey(ctx, agentDID)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("resolve ecdsa key: %w", err)
 			}
@@ -82,7 +110,7 @@ func (v *DefaultDIDVerifier) ResolvePublicKey(ctx context.Context, agentDID did.
 
 		case did.KeyTypeEd25519:
 			// Ask selector to pick Ed25519 from V4 metadata
-			pk, _, err := v.selector.SelectKey(ctx, agentDID, "solana")
+			pk, err := v.selectKey(ctx, agentDID, "solana")
 			if err != nil {
 				return nil, fmt.Errorf("select ed25519: %w", err)
 			}
@@ -93,17 +121,40 @@ func (v *DefaultDIDVerifier) ResolvePublicKey(ctx context.Context, agentDID did.
 	}
 
 	// Generic policy: selector decides (Ed25519 > ECDSA > first verified)
-	pk, _, err := v.selector.SelectKey(ctx, agentDID, "")
+	pk, err := v.selectKey(ctx, agentDID, "")
 	if err != nil {
 		return nil, fmt.Errorf("select key: %w", err)
 	}
 	return pk, nil
 }
 
+// keyTypeProtocol returns the key selector protocol choosing keyType
+func keyTypeProtocol(keyType did.KeyType) string {
+	switch keyType {
+	case did.KeyTypeX25519:
+		return "hpke"
+	case did.KeyTypeECDSA:
+		return "ethereum"
+	case did.KeyTypeEd25519:
+		return "solana"
+	}
+	return ""
+}
+
+// selectKey runs the key selector within ctx
+func (v *DefaultDIDVerifier) selectKey(ctx context.Context, agentDID did.AgentDID, protocol string) (crypto.PublicKey, error) {
+	return resolveWithContext(ctx, func() (crypto.PublicKey, error) {
+		pk, _, err := v.selector.SelectKey(ctx, agentDID, protocol)
+		return pk, err
+	})
+}
+
 // VerifyHTTPSignature verifies the HTTP signature in the request.
+// The context is honored at every stage; when it ends, the error wraps
+// ErrResolutionTimeout, ErrVerificationTimeout or ErrVerificationCanceled.
 func (v *DefaultDIDVerifier) VerifyHTTPSignature(ctx context.Context, req *http.Request, agentDID did.AgentDID) error {
-	if err := ctx.Err(); err != nil {
-		return fmt.Errorf("context error: %w", err)
+	if err := contextError(ctx, stageParse); err != nil {
+		return err
 	}
 
 	signatureInput := req.Header.Get("Signature-Input")
@@ -112,7 +163,7 @@ func (v *DefaultDIDVerifier) VerifyHTTPSignature(ctx context.Context, req *http.
 		return fmt.Errorf("missing signature headers")
 	}
 
-	keyID, err := extractKeyID(signatureInput)
+	label, keyID, alg, err := v.signatureKey(signatureInput)
 	if err != nil {
 		return fmt.Errorf("failed to extract keyid: %w", err)
 	}
@@ -123,30 +174,88 @@ func (v *DefaultDIDVerifier) VerifyHTTPSignature(ctx context.Context, req *http.
 		return fmt.Errorf("keyid mismatch: expected %s, got %s", agentDID, keyID)
 	}
 
-	pubKey, err := v.ResolvePublicKey(ctx, agentDID, nil) // defaults to ECDSA
+	// An alg parameter selects the key of its type directly
+	keyType, err := algorithmKeyType(alg)
+	if err != nil {
+		return err
+	}
+	pubKey, err := v.ResolvePublicKey(ctx, agentDID, keyType)
 	if err != nil {
 		return fmt.Errorf("failed to resolve public key: %w", err)
 	}
+	if keyType != nil {
+		if err := checkKeyAlgorithm(pubKey, alg, *keyType); err != nil {
+			return err
+		}
+	}
+	if err := v.checkAlgorithmPolicy(req, label, agentDID, alg, pubKey); err != nil {
+		return err
+	}
 	if v.signatureVerifier == nil {
 		return fmt.Errorf("signature verifier not configured")
 	}
+	if err := contextError(ctx, stageCrypto); err != nil {
+		return err
+	}
 	if err := v.signatureVerifier.VerifyHTTPRequest(req, pubKey); err != nil {
-		return fmt.Errorf("signature verification failed: %w", err)
+		if !v.verifyWithOtherKeys(ctx, req, agentDID, pubKey) {
+			return fmt.Errorf("signature verification failed: %w", err)
+		}
+		if s, ok := v.signatureVerifier.(warningSource); ok {
+			reportWarnings(ctx, s.WarningObserver(), Warning{
+				Code:    WarningKeyRotation,
+				KeyID:   keyID,
+				Message: fmt.Sprintf("signature verified by a key other than the current key of %s", agentDID),
+			})
+		}
 	}
 	log.Println(("✅ Success verify"))
 	return nil
 }
 
+// verifyWithOtherKeys tries the agent's other keys of tried's type, so
+// signatures by either key verify while a rotation overlaps old and new
+// keys on chain
+func (v *DefaultDIDVerifier) verifyWithOtherKeys(ctx context.Context, req *http.Request, agentDID did.AgentDID, tried crypto.PublicKey) bool {
+	lister, ok := v.selector.(KeyLister)
+	if !ok {
+		return false
+	}
+	var keyType did.KeyType
+	switch tried.(type) {
+	case *ecdsa.PublicKey:
+		keyType = did.KeyTypeECDSA
+	case ed25519.PublicKey:
+		keyType = did.KeyTypeEd25519
+	default:
+		return false
+	}
+	keys, err := lister.ListKeys(ctx, agentDID, keyType)
+	if err != nil {
+		return false
+	}
+	triedKey, _ := tried.(interface{ Equal(crypto.PublicKey) bool })
+	for _, pub := range keys {
+		if triedKey != nil && triedKey.Equal(pub) {
+			continue
+		}
+		if v.signatureVerifier.VerifyHTTPRequest(req, pub) == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // VerifyHTTPSignatureWithKeyID extracts DID from keyid and verifies the signature.
 func (v *DefaultDIDVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, req *http.Request) (did.AgentDID, error) {
-	if err := ctx.Err(); err != nil {
-		return "", fmt.Errorf("context error: %w", err)
+	if err := contextError(ctx, stageParse); err != nil {
+		return "", err
 	}
 	sigInput := req.Header.Get("Signature-Input")
 	if sigInput == "" {
 		return "", fmt.Errorf("missing Signature-Input header")
 	}
-	keyID, err := extractKeyID(sigInput)
+	_, keyID, _, err := v.signatureKey(sigInput)
 	if err != nil {
 		return "", fmt.Errorf("failed to extract keyid: %w", err)
 	}
@@ -160,6 +269,26 @@ func (v *DefaultDIDVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, r
 	return agentDID, nil
 }
 
+// signatureKey returns the label and the keyid and alg parameters of the
+// signature the signature verifier will check, so requests carrying
+// several signatures resolve the right key
+func (v *DefaultDIDVerifier) signatureKey(signatureInput string) (label, keyID, alg string, err error) {
+	var sel SignatureSelector
+	if s, ok := v.signatureVerifier.(signatureSelection); ok {
+		sel = s.SignatureSelection()
+	}
+	label, params, err := SelectSignature(signatureInput, sel)
+	if err != nil && (sel.Label != "" || sel.KeyIDPattern != nil) {
+		return "", "", "", err
+	}
+	if err != nil || params.KeyID == "" {
+		// Fall back to the first keyid for inputs the parser rejects
+		keyID, err := extractKeyID(signatureInput)
+		return "", keyID, "", err
+	}
+	return label, params.KeyID, params.Algorithm, nil
+}
+
 // extractKeyID parses keyid from the Signature-Input header: sig1=(...);keyid="did:sage:ethereum:0x...";...
 func extractKeyID(signatureInput string) (string, error) {
 	re := regexp.MustCompile(`keyid="([^"]+)"`)
@@ -170,9 +299,14 @@ func extractKeyID(signatureInput string) (string, error) {
 	return m[1], nil
 }
 
-// isValidDID does a basic shape check. Tighten if needed.
+// isValidDID does a basic shape check: SAGE DIDs and DIDs of registered
+// methods are accepted
 func isValidDID(didStr string) bool {
-	return strings.HasPrefix(didStr, "did:sage:")
+	if strings.HasPrefix(didStr, "did:sage:") {
+		return true
+	}
+	_, registered := lookupDIDMethod(didStr)
+	return registered
 }
 
 // --- convenience ctor usage (example) ---
@@ -27,25 +27,34 @@ import (
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
+//go:generate mockgen -write_package_comment=false -destination=../mocks/did_resolver.go -package=mocks . DIDResolver
+
+// DIDResolver resolves agent metadata by DID. It is satisfied by
+// pkg/agent/did/ethereum.AgentCardClient.
 type DIDResolver interface {
 	GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error)
 }
 
+// DefaultKeySelector picks keys from the metadata returned by a DIDResolver
 type DefaultKeySelector struct {
 	resolver DIDResolver
 }
 
+// NewDefaultKeySelector creates a key selector resolving agents through
+// resolver. DIDs of methods added with RegisterDIDMethod are resolved by
+// their registered resolvers; resolver may be nil if every method is
+// registered.
 func NewDefaultKeySelector(resolver DIDResolver) *DefaultKeySelector {
-	return &DefaultKeySelector{resolver: resolver}
+	return &DefaultKeySelector{resolver: NewMethodResolver(resolver)}
 }
 
-// - "ethereum"/"eth": ECDSA(secp256k1)
+// - "ethereum"/"eth"/"kaia": ECDSA(secp256k1)
 // - "solana"/"sol": Ed25519
 // - "hpke"/"kem"/"x25519": X25519(32바이트) — HPKE용
 // - 그 외: (1) Ed25519, (2) ECDSA, (3) 첫 검증된 키 순
 func (s *DefaultKeySelector) SelectKey(ctx context.Context, agentDID did.AgentDID, protocol string) (crypto.PublicKey, did.KeyType, error) {
-	if err := ctx.Err(); err != nil {
-		return nil, 0, fmt.Errorf("context error: %w", err)
+	if err := contextError(ctx, stageResolve); err != nil {
+		return nil, 0, err
 	}
 
 	meta, err := s.resolver.GetAgentByDID(ctx, string(agentDID))
@@ -70,7 +79,7 @@ func (s *DefaultKeySelector) SelectKey(ctx context.Context, agentDID did.AgentDI
 		}
 		return nil, 0, errors.New("no X25519 (HPKE) key registered")
 
-	case "ethereum", "eth", "eip155":
+	case "ethereum", "eth", "eip155", "kaia", "klay":
 		if k, ok := firstByType(meta.Keys, did.KeyTypeECDSA); ok {
 			return unmarshalByKeyType(k.KeyData, did.KeyTypeECDSA)
 		}
@@ -101,6 +110,35 @@ func (s *DefaultKeySelector) SelectKey(ctx context.Context, agentDID did.AgentDI
 	return firstAnyVerified(meta.Keys)
 }
 
+// ListKeys implements KeyLister
+func (s *DefaultKeySelector) ListKeys(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) ([]crypto.PublicKey, error) {
+	if err := contextError(ctx, stageResolve); err != nil {
+		return nil, err
+	}
+	meta, err := s.resolver.GetAgentByDID(ctx, string(agentDID))
+	if err != nil {
+		return nil, fmt.Errorf("resolve agent: %w", err)
+	}
+	if meta == nil || !meta.IsActive {
+		return nil, fmt.Errorf("agent inactive or not found: %s", agentDID)
+	}
+
+	var keys []crypto.PublicKey
+	for _, k := range meta.Keys {
+		if !k.Verified || k.Type != keyType {
+			continue
+		}
+		if keyType == did.KeyTypeX25519 {
+			keys = append(keys, crypto.PublicKey(k.KeyData))
+			continue
+		}
+		if pk, _, err := unmarshalByKeyType(k.KeyData, keyType); err == nil {
+			keys = append(keys, pk)
+		}
+	}
+	return keys, nil
+}
+
 func firstByType(keys []did.AgentKey, t did.KeyType) (did.AgentKey, bool) {
 	for _, k := range keys {
 		if k.Verified && k.Type == t {
@@ -133,26 +171,17 @@ func firstAnyVerified(keys []did.AgentKey) (crypto.PublicKey, did.KeyType, error
 }
 
 func unmarshalByKeyType(raw []byte, kt did.KeyType) (crypto.PublicKey, did.KeyType, error) {
-	switch kt {
-	case did.KeyTypeECDSA:
-		pk, err := did.UnmarshalPublicKey(raw, "secp256k1")
-		if err != nil {
+	// Keys arrive compressed, uncompressed, DER or as hex/base64 text
+	// depending on the chain and marshaler; see NormalizePublicKey
+	pk, err := NormalizePublicKey(raw, kt)
+	if err != nil {
+		switch kt {
+		case did.KeyTypeECDSA:
 			return nil, 0, fmt.Errorf("unmarshal secp256k1: %w", err)
-		}
-		return pk.(crypto.PublicKey), did.KeyTypeECDSA, nil
-	case did.KeyTypeEd25519:
-		pk, err := did.UnmarshalPublicKey(raw, "ed25519")
-		if err != nil {
+		case did.KeyTypeEd25519:
 			return nil, 0, fmt.Errorf("unmarshal ed25519: %w", err)
 		}
-		return pk.(crypto.PublicKey), did.KeyTypeEd25519, nil
-	case did.KeyTypeX25519:
-		// X25519는 32바이트 raw 반환 (HPKE 용도)
-		if len(raw) != 32 {
-			return nil, 0, fmt.Errorf("x25519: want 32 bytes, got %d", len(raw))
-		}
-		return crypto.PublicKey(raw), did.KeyTypeX25519, nil
-	default:
-		return nil, 0, fmt.Errorf("unknown key type: %d", kt)
+		return nil, 0, err
 	}
+	return pk, kt, nil
 }
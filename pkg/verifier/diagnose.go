@@ -0,0 +1,248 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// Diagnosis stages, in the order they are checked
+const (
+	DiagnosisHeaders       = "headers"        // signature headers present and parseable
+	DiagnosisComponents    = "components"     // covered components present in the request
+	DiagnosisTimestamp     = "timestamp"      // created and expires acceptable
+	DiagnosisContentDigest = "content-digest" // body matches Content-Digest
+	DiagnosisResolve       = "resolve"        // signer key resolved
+	DiagnosisSignature     = "signature"      // signature verifies over the base
+)
+
+// diagnosisMaxAge is the signature age beyond which Diagnose reports the
+// created parameter, matching the default verifier options
+const diagnosisMaxAge = 5 * time.Minute
+
+// ComponentValue is a covered component and its value in the request
+type ComponentValue struct {
+	Name    string `json:"name"`
+	Value   string `json:"value,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// Diagnosis explains why a request signature did or did not verify. It
+// exposes the reconstructed signature base and the resolved key's
+// fingerprint, so it should only be shown to trusted parties.
+type Diagnosis struct {
+	Verified bool `json:"verified"`
+
+	// Stage is the first stage that failed (see the Diagnosis* stages)
+	Stage string `json:"stage,omitempty"`
+
+	// Error is the verifier's error, or the Content-Digest mismatch of a
+	// signature that verified
+	Error string `json:"error,omitempty"`
+
+	// Problems lists everything found wrong, in stage order
+	Problems []string `json:"problems,omitempty"`
+
+	// Parameters of the verified signature
+	Label      string           `json:"label,omitempty"`
+	KeyID      string           `json:"keyid,omitempty"`
+	Algorithm  string           `json:"alg,omitempty"`
+	Created    int64            `json:"created,omitempty"`
+	Expires    int64            `json:"expires,omitempty"`
+	Components []ComponentValue `json:"components,omitempty"`
+
+	// SignatureBase is the RFC 9421 signature base reconstructed from the
+	// request as received
+	SignatureBase string `json:"signatureBase,omitempty"`
+
+	// ExpectedBase is the signer's signature base, if supplied to
+	// CompareBase, and BaseMismatches its lines differing from ours
+	ExpectedBase   string   `json:"expectedBase,omitempty"`
+	BaseMismatches []string `json:"baseMismatches,omitempty"`
+
+	// KeyType and KeyFingerprint describe the resolved key (see
+	// KeyFingerprint)
+	KeyType        string `json:"keyType,omitempty"`
+	KeyFingerprint string `json:"keyFingerprint,omitempty"`
+}
+
+// problem records a problem found at stage
+func (d *Diagnosis) problem(stage, format string, args ...any) {
+	if d.Stage == "" {
+		d.Stage = stage
+	}
+	d.Problems = append(d.Problems, fmt.Sprintf(format, args...))
+}
+
+// Diagnose verifies the signature of req with v, like
+// VerifyHTTPSignatureWithKeyID, and explains the outcome. The request body
+// is read and restored. Diagnose resolves the signer key itself, so it
+// costs more than a plain verification.
+func Diagnose(ctx context.Context, v DIDVerifier, req *http.Request) *Diagnosis {
+	d := &Diagnosis{}
+
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	restore := func() { req.Body = io.NopCloser(bytes.NewReader(body)) }
+	restore()
+
+	sigInput := req.Header.Get("Signature-Input")
+	if sigInput == "" || req.Header.Get("Signature") == "" {
+		d.problem(DiagnosisHeaders, "missing Signature or Signature-Input header")
+		d.Error = "missing signature headers"
+		return d
+	}
+	label, params, err := SelectSignature(sigInput, SignatureSelector{})
+	if err != nil {
+		d.problem(DiagnosisHeaders, "%v", err)
+		d.Error = err.Error()
+		return d
+	}
+	d.Label = label
+	d.KeyID = params.KeyID
+	d.Algorithm = params.Algorithm
+	d.Created = params.Created
+	d.Expires = params.Expires
+
+	// Components and the signature base
+	var base strings.Builder
+	for _, c := range params.CoveredComponents {
+		name := strings.Trim(c, `"`)
+		value, ok := signer.ComponentValue(req, name)
+		d.Components = append(d.Components, ComponentValue{Name: name, Value: value, Missing: !ok})
+		if !ok {
+			d.problem(DiagnosisComponents, "covered component %q is not in the request", name)
+		}
+		fmt.Fprintf(&base, "%q: %s\n", name, value)
+	}
+	fmt.Fprintf(&base, "\"@signature-params\": %s", signatureInputMember(sigInput, label))
+	d.SignatureBase = base.String()
+
+	now := time.Now()
+	if d.Created != 0 && now.Sub(time.Unix(d.Created, 0)) > diagnosisMaxAge {
+		d.problem(DiagnosisTimestamp, "signature created %s ago", now.Sub(time.Unix(d.Created, 0)).Round(time.Second))
+	}
+	if d.Created != 0 && time.Unix(d.Created, 0).After(now) {
+		d.problem(DiagnosisTimestamp, "signature created %s in the future; check the signer's clock", time.Unix(d.Created, 0).Sub(now).Round(time.Second))
+	}
+	if d.Expires != 0 && now.After(time.Unix(d.Expires, 0)) {
+		d.problem(DiagnosisTimestamp, "signature expired %s ago", now.Sub(time.Unix(d.Expires, 0)).Round(time.Second))
+	}
+
+	digestMismatch := false
+	if header := req.Header.Get("Content-Digest"); header != "" {
+		if err := signer.VerifyContentDigest(header, body, nil); err != nil {
+			digestMismatch = true
+			d.problem(DiagnosisContentDigest, "Content-Digest does not match the body: %v", err)
+		}
+	}
+
+	keyType, err := algorithmKeyType(d.Algorithm)
+	if err != nil {
+		d.problem(DiagnosisResolve, "%v", err)
+	} else if pub, err := v.ResolvePublicKey(ctx, did.AgentDID(d.KeyID), keyType); err != nil {
+		d.problem(DiagnosisResolve, "failed to resolve key of %s: %v", d.KeyID, err)
+	} else if d.KeyType, d.KeyFingerprint, err = KeyFingerprint(pub); err != nil {
+		d.problem(DiagnosisResolve, "%v", err)
+	}
+
+	restore()
+	_, err = v.VerifyHTTPSignatureWithKeyID(ctx, req)
+	restore()
+	if err == nil {
+		// The verifier does not check Content-Digest, so a body that does
+		// not match it fails the diagnosis even when the signature verifies
+		d.Verified = !digestMismatch
+		if digestMismatch {
+			d.Error = "Content-Digest does not match the body"
+		}
+		return d
+	}
+	d.Error = err.Error()
+	if d.Stage == "" {
+		d.problem(DiagnosisSignature, "signature does not verify over the signature base; compare it with the signer's")
+	}
+	return d
+}
+
+// CompareBase compares the reconstructed signature base with the signer's
+// and records the lines that differ, naming the mismatched components
+func (d *Diagnosis) CompareBase(expected string) {
+	d.ExpectedBase = expected
+	got := strings.Split(d.SignatureBase, "\n")
+	want := strings.Split(strings.TrimRight(strings.ReplaceAll(expected, "\r\n", "\n"), "\n"), "\n")
+	for i := 0; i < len(got) || i < len(want); i++ {
+		var g, w string
+		if i < len(got) {
+			g = got[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if g == w {
+			continue
+		}
+		d.BaseMismatches = append(d.BaseMismatches, fmt.Sprintf("line %d: received %q, signed %q", i+1, g, w))
+		if name, _, ok := strings.Cut(w, ": "); ok {
+			d.problem(DiagnosisSignature, "component %s differs from what was signed", name)
+		}
+	}
+}
+
+// signatureInputMember returns the serialized value of the label member of
+// a Signature-Input header
+func signatureInputMember(header, label string) string {
+	depth, quoted, start := 0, false, 0
+	for i := 0; i <= len(header); i++ {
+		if i < len(header) {
+			switch c := header[i]; {
+			case c == '"' && (i == 0 || header[i-1] != '\\'):
+				quoted = !quoted
+				continue
+			case quoted:
+				continue
+			case c == '(':
+				depth++
+				continue
+			case c == ')':
+				depth--
+				continue
+			case c != ',' || depth > 0:
+				continue
+			}
+		}
+		member := strings.TrimSpace(header[start:i])
+		if name, value, ok := strings.Cut(member, "="); ok && name == label {
+			return value
+		}
+		start = i + 1
+	}
+	return ""
+}
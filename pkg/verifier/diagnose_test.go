@@ -0,0 +1,136 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signatureResult is a SignatureVerifier returning err for every request
+type signatureResult struct {
+	err error
+}
+
+func (s signatureResult) VerifyHTTPRequest(req *http.Request, pubKey interface{}) error {
+	return s.err
+}
+
+func diagnoseVerifier(sigErr error) *DefaultDIDVerifier {
+	pub := createEd25519Key()
+	resolver := resolverFunc(func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+		return &did.AgentMetadataV4{
+			DID:      did.AgentDID(didStr),
+			IsActive: true,
+			Keys:     []did.AgentKey{{Type: did.KeyTypeEd25519, KeyData: pub, Verified: true}},
+		}, nil
+	})
+	return NewDefaultDIDVerifier(nil, NewDefaultKeySelector(resolver), signatureResult{err: sigErr})
+}
+
+func diagnoseRequest(body string, created time.Time) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "https://agent.example.com/rpc?x=1", strings.NewReader(body))
+	digest, _ := signer.ComputeContentDigest(signer.DigestSHA256, []byte(`{"signed":true}`))
+	req.Header.Set("Content-Digest", digest)
+	req.Header.Set("Signature", "sig1=:AAAA:")
+	req.Header.Set("Signature-Input", `sig1=("@method" "@authority" "@query" "content-digest" "x-missing");keyid="did:sage:solana:alice";alg="ed25519";created=`+strconv.FormatInt(created.Unix(), 10))
+	return req
+}
+
+func TestDiagnose(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("verified", func(t *testing.T) {
+		d := Diagnose(ctx, diagnoseVerifier(nil), diagnoseRequest(`{"signed":true}`, time.Now()))
+		assert.True(t, d.Verified)
+		assert.Equal(t, "ed25519", d.KeyType)
+		assert.Len(t, d.KeyFingerprint, 64)
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		// The signature verifies, but the body is not the one signed
+		d := Diagnose(ctx, diagnoseVerifier(nil), diagnoseRequest(`{"tampered":true}`, time.Now()))
+		assert.False(t, d.Verified)
+		assert.Contains(t, d.Error, "Content-Digest")
+		assert.Contains(t, d.Problems[len(d.Problems)-1], "Content-Digest does not match")
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		req := diagnoseRequest(`{"tampered":true}`, time.Now().Add(-time.Hour))
+		d := Diagnose(ctx, diagnoseVerifier(errors.New("bad signature")), req)
+		assert.False(t, d.Verified)
+		assert.Equal(t, DiagnosisComponents, d.Stage)
+		assert.Contains(t, d.Error, "bad signature")
+		require.Len(t, d.Problems, 3)
+		assert.Contains(t, d.Problems[0], `"x-missing"`)
+		assert.Contains(t, d.Problems[1], "created 1h")
+		assert.Contains(t, d.Problems[2], "Content-Digest does not match")
+
+		assert.Equal(t, "sig1", d.Label)
+		assert.Equal(t, "did:sage:solana:alice", d.KeyID)
+		lines := strings.Split(d.SignatureBase, "\n")
+		require.Len(t, lines, 6)
+		assert.Equal(t, `"@method": POST`, lines[0])
+		assert.Equal(t, `"@authority": agent.example.com`, lines[1])
+		assert.Equal(t, `"@query": ?x=1`, lines[2])
+		assert.True(t, strings.HasPrefix(lines[5], `"@signature-params": ("@method" "@authority"`), lines[5])
+
+		// The body is restored for the handler
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"tampered":true}`, string(body))
+	})
+
+	t.Run("signature only", func(t *testing.T) {
+		req := diagnoseRequest(`{"signed":true}`, time.Now())
+		req.Header.Set("X-Missing", "present")
+		d := Diagnose(ctx, diagnoseVerifier(errors.New("bad signature")), req)
+		assert.Equal(t, DiagnosisSignature, d.Stage)
+
+		signed := strings.Replace(d.SignatureBase, `"@query": ?x=1`, `"@query": ?x=2`, 1)
+		d.CompareBase(signed)
+		require.Len(t, d.BaseMismatches, 1)
+		assert.Contains(t, d.BaseMismatches[0], "line 3")
+		assert.Contains(t, d.Problems[len(d.Problems)-1], `"@query"`)
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		d := Diagnose(ctx, diagnoseVerifier(nil), httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.False(t, d.Verified)
+		assert.Equal(t, DiagnosisHeaders, d.Stage)
+	})
+}
+
+func TestSignatureInputMember(t *testing.T) {
+	header := `sig0=("@method");keyid="did:sage:a,b", sig1=("@method" "@path");created=1;nonce="x"`
+	assert.Equal(t, `("@method");keyid="did:sage:a,b"`, signatureInputMember(header, "sig0"))
+	assert.Equal(t, `("@method" "@path");created=1;nonce="x"`, signatureInputMember(header, "sig1"))
+	assert.Empty(t, signatureInputMember(header, "sig2"))
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ErrUnknownDIDMethod is returned when no resolver handles a DID's method
+var ErrUnknownDIDMethod = errors.New("unknown DID method")
+
+// DIDResolverFactory creates the resolver of a DID method. It is called on
+// the first resolution of a DID of the method, and again after a failure.
+type DIDResolverFactory func() (DIDResolver, error)
+
+// didMethod is a registered DID method
+type didMethod struct {
+	factory DIDResolverFactory
+
+	mu       sync.Mutex
+	resolver DIDResolver
+}
+
+var (
+	didMethodsMu sync.RWMutex
+	didMethods   = make(map[string]*didMethod)
+)
+
+// RegisterDIDMethod registers the resolver factory for DIDs of method,
+// i.e. DIDs starting with "did:<method>:". method may include a network,
+// as in "sage:ethereum"; a DID is handled by the longest registered method
+// it matches. Key selectors, DefaultDIDVerifier and everything built on
+// them, such as DIDAuthMiddleware and agent card verification, resolve
+// registered methods through their factories before falling back to their
+// configured resolver. Registering a method again replaces its factory.
+func RegisterDIDMethod(method string, factory DIDResolverFactory) error {
+	method = strings.TrimSuffix(strings.TrimPrefix(method, "did:"), ":")
+	if method == "" {
+		return errors.New("DID method is required")
+	}
+	if factory == nil {
+		return fmt.Errorf("resolver factory for DID method %q is required", method)
+	}
+	didMethodsMu.Lock()
+	defer didMethodsMu.Unlock()
+	didMethods[method] = &didMethod{factory: factory}
+	return nil
+}
+
+// UnregisterDIDMethod removes a method added with RegisterDIDMethod
+func UnregisterDIDMethod(method string) {
+	method = strings.TrimSuffix(strings.TrimPrefix(method, "did:"), ":")
+	didMethodsMu.Lock()
+	defer didMethodsMu.Unlock()
+	delete(didMethods, method)
+}
+
+// DIDMethods returns the registered methods in sorted order
+func DIDMethods() []string {
+	didMethodsMu.RLock()
+	defer didMethodsMu.RUnlock()
+	methods := make([]string, 0, len(didMethods))
+	for method := range didMethods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// lookupDIDMethod returns the registered method handling didStr, if any
+func lookupDIDMethod(didStr string) (*didMethod, bool) {
+	rest, ok := strings.CutPrefix(didStr, "did:")
+	if !ok {
+		return nil, false
+	}
+	didMethodsMu.RLock()
+	defer didMethodsMu.RUnlock()
+	for {
+		i := strings.LastIndexByte(rest, ':')
+		if i <= 0 {
+			return nil, false
+		}
+		rest = rest[:i]
+		if m, ok := didMethods[rest]; ok {
+			return m, true
+		}
+	}
+}
+
+// get returns the method's resolver, creating it on first use
+func (m *didMethod) get() (DIDResolver, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.resolver == nil {
+		resolver, err := m.factory()
+		if err != nil {
+			return nil, err
+		}
+		if resolver == nil {
+			return nil, errors.New("resolver factory returned nil")
+		}
+		m.resolver = resolver
+	}
+	return m.resolver, nil
+}
+
+// methodResolver dispatches to registered DID methods
+type methodResolver struct {
+	fallback DIDResolver
+}
+
+// NewMethodResolver returns a DIDResolver resolving DIDs of registered
+// methods through their factories and all others through fallback. With a
+// nil fallback, unregistered methods fail with ErrUnknownDIDMethod.
+func NewMethodResolver(fallback DIDResolver) DIDResolver {
+	if r, ok := fallback.(*methodResolver); ok {
+		return r
+	}
+	return &methodResolver{fallback: fallback}
+}
+
+// GetAgentByDID implements DIDResolver
+func (r *methodResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	if m, ok := lookupDIDMethod(didStr); ok {
+		resolver, err := m.get()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create resolver for %s: %w", didStr, err)
+		}
+		return resolver.GetAgentByDID(ctx, didStr)
+	}
+	if r.fallback == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDIDMethod, didStr)
+	}
+	return r.fallback.GetAgentByDID(ctx, didStr)
+}
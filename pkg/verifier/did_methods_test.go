@@ -0,0 +1,89 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resolverFunc adapts a function to DIDResolver
+type resolverFunc func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error)
+
+func (f resolverFunc) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	return f(ctx, didStr)
+}
+
+func TestRegisterDIDMethod(t *testing.T) {
+	ctx := context.Background()
+	pub := createEd25519Key()
+	created := 0
+	require.NoError(t, RegisterDIDMethod("corp:hr", func() (DIDResolver, error) {
+		created++
+		return resolverFunc(func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+			return &did.AgentMetadataV4{
+				DID:      did.AgentDID(didStr),
+				IsActive: true,
+				Keys:     []did.AgentKey{{Type: did.KeyTypeEd25519, KeyData: pub, Verified: true}},
+			}, nil
+		}), nil
+	}))
+	require.NoError(t, RegisterDIDMethod("did:corp", func() (DIDResolver, error) {
+		return nil, errors.New("registry offline")
+	}))
+	t.Cleanup(func() {
+		UnregisterDIDMethod("corp:hr")
+		UnregisterDIDMethod("corp")
+	})
+	assert.Error(t, RegisterDIDMethod("", nil))
+	assert.Contains(t, DIDMethods(), "corp:hr")
+
+	// The longest registered method wins; the factory runs once
+	sigVerifier := &mockSignatureVerifier{}
+	v := NewDefaultDIDVerifier(nil, nil, sigVerifier)
+	req := httptest.NewRequest(http.MethodPost, "/rpc", nil)
+	req.Header.Set("Signature", "sig1=:AAAA:")
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:corp:hr:alice"`)
+	agentDID, err := v.VerifyHTTPSignatureWithKeyID(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, did.AgentDID("did:corp:hr:alice"), agentDID)
+	assert.True(t, sigVerifier.verified)
+
+	keyType := did.KeyTypeEd25519
+	key, err := v.ResolvePublicKey(ctx, "did:corp:hr:bob", &keyType)
+	require.NoError(t, err)
+	assert.Equal(t, pub, key)
+	assert.Equal(t, 1, created)
+
+	_, err = v.ResolvePublicKey(ctx, "did:corp:sales:carol", nil)
+	assert.ErrorContains(t, err, "registry offline")
+
+	// Unregistered methods fall back to the configured resolver, if any
+	_, err = NewMethodResolver(nil).GetAgentByDID(ctx, "did:web:example.com")
+	assert.ErrorIs(t, err, ErrUnknownDIDMethod)
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:web:example.com"`)
+	_, err = v.VerifyHTTPSignatureWithKeyID(ctx, req)
+	assert.ErrorContains(t, err, "invalid DID format")
+}
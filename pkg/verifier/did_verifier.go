@@ -25,6 +25,8 @@ import (
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
+//go:generate mockgen -write_package_comment=false -destination=../mocks/did_verifier.go -package=mocks . DIDVerifier
+
 // DIDVerifier verifies HTTP signatures using SAGE DIDs
 type DIDVerifier interface {
 	// VerifyHTTPSignature verifies the HTTP signature in the request
@@ -58,10 +58,27 @@
 //
 // Different blockchain protocols require different cryptographic algorithms:
 //
-//   - ethereum → ECDSA (secp256k1)
+//   - ethereum, kaia → ECDSA (secp256k1)
 //   - solana → Ed25519
 //   - unknown/empty → First available verified key
 //
+// # Custom DID Methods
+//
+// RegisterDIDMethod adds a resolver for another DID method, e.g. a
+// corporate registry or a consortium chain. Key selectors and
+// DefaultDIDVerifier, and through them DIDAuthMiddleware and agent card
+// verification, resolve DIDs of registered methods without further
+// configuration:
+//
+//	verifier.RegisterDIDMethod("acme", func() (verifier.DIDResolver, error) {
+//	    return acmeregistry.Dial(os.Getenv("ACME_REGISTRY"))
+//	})
+//	didVerifier := verifier.NewDefaultDIDVerifier(nil, nil, verifier.NewRFC9421Verifier())
+//
+// A method may name a network ("sage:ethereum"); the longest registered
+// method matching a DID is used, and unregistered methods fall back to the
+// resolver the selector was created with.
+//
 // # RFC9421 HTTP Signature Verification
 //
 // The RFC9421Verifier implements HTTP Message Signatures verification:
@@ -71,6 +88,35 @@
 //
 // This wraps SAGE's RFC9421 implementation with the SignatureVerifier interface.
 //
+// StrictComponents additionally rejects signatures that violate
+// signer.CheckComponentPolicy and oversized signature headers:
+//
+//	sigVerifier := verifier.NewRFC9421Verifier(verifier.StrictComponents())
+//
+// Requests may carry several signatures, e.g. one added by a proxy. By
+// default the "sig1" label is verified, or the first label in sorted order.
+// WithSignatureSelector picks one by label or keyid pattern; a
+// DefaultDIDVerifier resolves the key for the same signature:
+//
+//	sigVerifier := verifier.NewRFC9421Verifier(verifier.WithSignatureSelector(
+//	    verifier.SignatureSelector{KeyIDPattern: regexp.MustCompile(`^did:sage:`)},
+//	))
+//
+// Some non-Go peers send hex or bare base64 signatures instead of RFC 9421
+// byte sequences. WithSignatureCompatibility accepts them explicitly:
+//
+//	sigVerifier := verifier.NewRFC9421Verifier(
+//	    verifier.WithSignatureCompatibility(signer.SignatureEncodingHex))
+//
+// VerifyHTTPSignatureWithResult also returns the typed parameters of the
+// verified signature, including tag and parameters RFC 9421 does not
+// define, so extensions need not parse Signature-Input again:
+//
+//	result, err := didVerifier.VerifyHTTPSignatureWithResult(ctx, req)
+//	if err == nil && result.Params.Tag == "a2a-delegation" {
+//	    hop, _ := result.Params.Param("hop")
+//	}
+//
 // # Multi-Key Support
 //
 // Agents can register multiple cryptographic keys for different purposes:
@@ -81,6 +127,119 @@
 //
 // The KeySelector automatically selects the appropriate key based on context.
 //
+// When the verified signature carries an alg parameter ("es256k",
+// "ed25519", or the JOSE names "ES256K" and "EdDSA"), DefaultDIDVerifier
+// resolves the registered key of that type instead of applying the
+// selection policy. A resolved key of another type fails with
+// *AlgorithmMismatchError, and unknown algorithms are rejected.
+//
+// An agent may register several keys of one type, e.g. old and new keys
+// while a rotation overlaps them (see the rotation package). When the
+// selected key does not verify a signature, DefaultDIDVerifier tries the
+// agent's other keys of that type if its KeySelector implements KeyLister,
+// as DefaultKeySelector does.
+//
+// # Algorithm Policy
+//
+// SetAlgorithmPolicy restricts the algorithms and key types a
+// DefaultDIDVerifier accepts. The policy is consulted once the signer's key
+// is resolved, before any cryptographic work, and rejections wrap
+// ErrAlgorithmNotPermitted in an *AlgorithmNotPermittedError naming the
+// reason. Overrides apply stricter policies to matching DIDs:
+//
+//	v.SetAlgorithmPolicy(&verifier.AlgorithmPolicy{
+//	    Algorithms:  []string{"es256k", "ed25519"},
+//	    RequireLowS: true,
+//	    Overrides: []verifier.AlgorithmOverride{{
+//	        DIDPattern: regexp.MustCompile(`^did:sage:solana:internal-`),
+//	        Policy:     &verifier.AlgorithmPolicy{KeyTypes: []did.KeyType{did.KeyTypeEd25519}},
+//	    }},
+//	})
+//
+// # Key Encodings
+//
+// Registered key data is normalized before use: ECDSA keys may be
+// compressed, uncompressed, raw X||Y or DER, and any key may be stored as
+// hex, base64 or PEM text. NormalizePublicKey exposes the same parsing, and
+// MarshalPublicKey converts between encodings:
+//
+//	compressed, err := verifier.MarshalPublicKey(pub, verifier.KeyEncodingCompressed)
+//	pub, err := verifier.NormalizePublicKey(keyData, did.KeyTypeECDSA)
+//
+// # Caching Resolution Results
+//
+// NewCachedResolver and NewCachedPublicKeyClient cache lookups across
+// requests. With MaxStale set, an expired entry is still returned
+// immediately while a background refresh fetches the current value, taking
+// chain latency off the hot path entirely:
+//
+//	cfg := verifier.CacheConfig{TTL: 5 * time.Minute, MaxStale: time.Hour}
+//	keys := verifier.NewCachedPublicKeyClient(ethClient, cfg)
+//	selector := verifier.NewDefaultKeySelector(verifier.NewCachedResolver(cardClient, cfg))
+//	v := verifier.NewDefaultDIDVerifier(keys, selector, verifier.NewRFC9421Verifier())
+//
+// MaxStale bounds how long a revoked key may still be accepted; call
+// Invalidate when a rotation is known.
+//
+// SaveSnapshot and LoadSnapshot persist a cache across restarts, so a busy
+// server does not resolve every peer on chain at once when it comes back.
+// Snapshots are AES-256-GCM encrypted when a Key is set, checksummed
+// otherwise, and entries older than MaxAge are dropped on load:
+//
+//	snap := verifier.SnapshotConfig{Path: "/var/lib/agent/keys.snapshot", Key: snapshotKey}
+//	if _, err := keys.LoadSnapshot(snap); err != nil {
+//	    log.Printf("starting cold: %v", err)
+//	}
+//	defer keys.SaveSnapshot(snap)
+//
+// # Chain Confirmations and Reorgs
+//
+// A ConfirmationGuard refuses keys registered or rotated fewer than
+// Confirmations blocks ago, or in a block that is no longer canonical, with
+// ErrKeyUnconfirmed. Wrap the uncached resolver and key client with it and
+// register the caches around them, so a reorg detected by Watch evicts keys
+// that disappeared from the chain:
+//
+//	guard, err := verifier.NewConfirmationGuard(verifier.ConfirmationConfig{
+//	    Chain:         chain,     // ChainReader
+//	    KeyBlocks:     keyBlocks, // block of the DID's last key change
+//	    Confirmations: 12,
+//	})
+//	keys := verifier.NewCachedPublicKeyClient(guard.WrapPublicKeyClient(ethClient), cfg)
+//	cards := verifier.NewCachedResolver(guard.WrapResolver(cardClient), cfg)
+//	guard.InvalidateOnReorg(keys, cards)
+//	go guard.Watch(ctx, 15*time.Second)
+//
+// # Key Pinning
+//
+// NewPinningVerifier pins the first key resolved for each peer DID (trust
+// on first use) and fails later resolutions returning another key with
+// ErrKeyPinMismatch, so a compromised registry cannot silently swap a
+// high-value peer's key. Pins are kept in a PinStore; FilePinStore persists
+// them across restarts. Set PinConfig.WarnOnly to only report changes
+// through OnChange, and call Unpin to accept a planned rotation:
+//
+//	store, err := verifier.NewFilePinStore("pins.json")
+//	pinned := verifier.NewPinningVerifier(v, verifier.PinConfig{Store: store, OnChange: alert})
+//	t := transport.NewDIDHTTPTransport(url, myDID, myKeyPair, nil,
+//		transport.WithAgentCardSigner(peerDID, pinned.ResolvePublicKey))
+//
+// # Verification Warnings
+//
+// WithWarnings reports non-fatal anomalies of accepted signatures to a
+// WarningObserver, so operators see problems before they turn into
+// rejections: clock skew close to the maximum age (WarningClockSkew),
+// deprecated algorithms (WarningDeprecatedAlgorithm), signature headers
+// close to the size limit (WarningOversizedSignature) and, through
+// DefaultDIDVerifier, signatures by a key being rotated out
+// (WarningKeyRotation). The request is accepted either way:
+//
+//	sigVerifier := verifier.NewRFC9421Verifier(verifier.WithWarnings(verifier.WarningConfig{
+//	    Observer: func(ctx context.Context, w verifier.Warning) {
+//	        log.Printf("verification warning %s for %s: %s", w.Code, w.KeyID, w.Message)
+//	    },
+//	}))
+//
 // # Error Handling
 //
 // Common verification errors:
@@ -91,6 +250,44 @@
 //   - Missing headers: required signature headers not present
 //   - Invalid DID format: malformed DID string
 //
+// DefaultDIDVerifier honors the context at every stage and does not wait
+// on resolvers that ignore it. When the context ends the error wraps
+// ErrResolutionTimeout, ErrVerificationTimeout or ErrVerificationCanceled
+// (and the context's own error), so slow infrastructure can be told apart
+// from bad signatures:
+//
+//	if verifier.IsContextError(err) {
+//	    // retry later; the signature itself was not rejected
+//	}
+//
+// # Diagnosing Failures
+//
+// Diagnose verifies a request and explains the outcome: the first failing
+// stage, covered components missing from the request, timestamp and
+// Content-Digest problems, the resolved key's fingerprint and the
+// reconstructed signature base. CompareBase lines it up against the
+// signer's base to name the component that differs:
+//
+//	d := verifier.Diagnose(ctx, didVerifier, req)
+//	d.CompareBase(signerBase)
+//	fmt.Println(d.Stage, d.Problems)
+//
+// # Consistency Audits
+//
+// A ConsistencyAuditor cross-checks what an agent claims in three places:
+// its on-chain registration, its published signed Agent Card and, with a
+// Ping, a live receipt signed for a fresh request. The report lists every
+// inconsistency, such as a card key not on chain, a card URL differing
+// from the on-chain endpoint or a deactivated DID:
+//
+//	auditor, _ := verifier.NewConsistencyAuditor(verifier.ConsistencyConfig{Resolver: client})
+//	report, err := auditor.VerifyAgentConsistency(ctx, agentDID)
+//	if err == nil && !report.OK() {
+//	    for _, issue := range report.Issues {
+//	        log.Printf("%s: %s", issue.Code, issue.Message)
+//	    }
+//	}
+//
 // # Security Considerations
 //
 //   - Always verify signatures before processing requests
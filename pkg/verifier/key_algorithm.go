@@ -0,0 +1,90 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// AlgorithmMismatchError is returned when the alg parameter of a signature
+// names a different key type than the key resolved for its signer
+type AlgorithmMismatchError struct {
+	Algorithm string
+	Expected  did.KeyType // key type named by Algorithm
+	Resolved  did.KeyType // key type of the resolved key
+}
+
+// Error implements error
+func (e *AlgorithmMismatchError) Error() string {
+	return fmt.Sprintf("signature algorithm %q requires a %s key, resolved a %s key", e.Algorithm, e.Expected, e.Resolved)
+}
+
+// KeyTypeForAlgorithm returns the key type signing with alg, which may be
+// an RFC 9421 algorithm name ("ed25519", "es256k", "ecdsa-p256-sha256") or
+// a JOSE name ("EdDSA", "ES256K", "ES256"). P-256 keys, used by browser
+// agents, are ECDSA keys.
+func KeyTypeForAlgorithm(alg string) (did.KeyType, bool) {
+	switch strings.ToLower(alg) {
+	case "es256k", "ecdsa-secp256k1", "ecdsa-secp256k1-sha256", "es256", "ecdsa-p256-sha256":
+		return did.KeyTypeECDSA, true
+	case "eddsa", "ed25519":
+		return did.KeyTypeEd25519, true
+	default:
+		return 0, false
+	}
+}
+
+// algorithmKeyType returns the key type to resolve for a signature with
+// alg, or nil if the signature does not name an algorithm
+func algorithmKeyType(alg string) (*did.KeyType, error) {
+	if alg == "" {
+		return nil, nil
+	}
+	keyType, ok := KeyTypeForAlgorithm(alg)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+	return &keyType, nil
+}
+
+// checkKeyAlgorithm verifies that pub is a key of keyType, the type named
+// by alg
+func checkKeyAlgorithm(pub crypto.PublicKey, alg string, keyType did.KeyType) error {
+	if resolved := publicKeyType(pub); resolved != keyType {
+		return &AlgorithmMismatchError{Algorithm: alg, Expected: keyType, Resolved: resolved}
+	}
+	return nil
+}
+
+// publicKeyType returns the key type of pub
+func publicKeyType(pub crypto.PublicKey) did.KeyType {
+	switch pub.(type) {
+	case *ecdsa.PublicKey:
+		return did.KeyTypeECDSA
+	case ed25519.PublicKey:
+		return did.KeyTypeEd25519
+	default:
+		return did.KeyTypeX25519
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keyCapture records the key a signature is checked with
+type keyCapture struct {
+	key crypto.PublicKey
+}
+
+func (c *keyCapture) VerifyHTTPRequest(req *http.Request, pubKey interface{}) error {
+	c.key = pubKey
+	return nil
+}
+
+func TestDefaultDIDVerifier_AlgorithmKeyType(t *testing.T) {
+	ctx := context.Background()
+	pub := createEd25519Key()
+	resolver := resolverFunc(func(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+		return &did.AgentMetadataV4{
+			DID:      did.AgentDID(didStr),
+			IsActive: true,
+			Keys:     []did.AgentKey{{Type: did.KeyTypeEd25519, KeyData: pub, Verified: true}},
+		}, nil
+	})
+	capture := &keyCapture{}
+	v := NewDefaultDIDVerifier(nil, NewDefaultKeySelector(resolver), capture)
+
+	request := func(alg string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/rpc", nil)
+		req.Header.Set("Signature", "sig1=:AAAA:")
+		req.Header.Set("Signature-Input", `sig1=("@method");keyid="did:sage:solana:alice";alg="`+alg+`"`)
+		return req
+	}
+
+	for _, alg := range []string{"ed25519", "EdDSA"} {
+		capture.key = nil
+		require.NoError(t, v.VerifyHTTPSignature(ctx, request(alg), "did:sage:solana:alice"), alg)
+		assert.Equal(t, pub, capture.key)
+	}
+
+	// The agent has no secp256k1 key; the fallback key must not be used
+	capture.key = nil
+	err := v.VerifyHTTPSignature(ctx, request("es256k"), "did:sage:solana:alice")
+	var mismatch *AlgorithmMismatchError
+	require.True(t, errors.As(err, &mismatch), "%v", err)
+	assert.Equal(t, did.KeyTypeECDSA, mismatch.Expected)
+	assert.Equal(t, did.KeyTypeEd25519, mismatch.Resolved)
+	assert.Nil(t, capture.key)
+
+	err = v.VerifyHTTPSignature(ctx, request("rsa-pss-sha512"), "did:sage:solana:alice")
+	assert.ErrorContains(t, err, "unsupported signature algorithm")
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// KeyEncoding selects the byte encoding produced by MarshalPublicKey
+type KeyEncoding int
+
+const (
+	// KeyEncodingRaw is the on-chain form: 64-byte X||Y for secp256k1,
+	// 0x04||X||Y for other curves and 32 bytes for Ed25519
+	KeyEncodingRaw KeyEncoding = iota
+
+	// KeyEncodingCompressed is the SEC1 compressed point (0x02/0x03||X)
+	KeyEncodingCompressed
+
+	// KeyEncodingUncompressed is the SEC1 uncompressed point (0x04||X||Y)
+	KeyEncodingUncompressed
+
+	// KeyEncodingDER is a PKIX SubjectPublicKeyInfo
+	KeyEncodingDER
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// NormalizePublicKey parses key data of the given type regardless of how it
+// was encoded. ECDSA keys may be compressed, uncompressed, raw X||Y or DER;
+// any of these may additionally be hex (with or without 0x), base64 or PEM
+// text, as produced by different chains and marshalers.
+func NormalizePublicKey(data []byte, keyType did.KeyType) (crypto.PublicKey, error) {
+	pub, err := parseBinaryKey(data, keyType)
+	if err == nil {
+		return pub, nil
+	}
+	if decoded, ok := decodeKeyText(data); ok {
+		if pub, textErr := parseBinaryKey(decoded, keyType); textErr == nil {
+			return pub, nil
+		}
+	}
+	return nil, err
+}
+
+// parseBinaryKey parses binary key encodings
+func parseBinaryKey(data []byte, keyType did.KeyType) (crypto.PublicKey, error) {
+	switch keyType {
+	case did.KeyTypeECDSA:
+		switch {
+		case len(data) == 33 && (data[0] == 0x02 || data[0] == 0x03),
+			len(data) == 65 && data[0] == 0x04,
+			len(data) == 64:
+			pk, err := did.UnmarshalPublicKey(data, "secp256k1")
+			if err != nil {
+				return nil, err
+			}
+			return pk.(crypto.PublicKey), nil
+		case len(data) > 0 && data[0] == 0x30:
+			return parseECDSADER(data)
+		}
+		return nil, fmt.Errorf("unrecognized ECDSA key encoding (%d bytes)", len(data))
+
+	case did.KeyTypeEd25519:
+		if len(data) == ed25519.PublicKeySize {
+			return ed25519.PublicKey(data), nil
+		}
+		if len(data) > 0 && data[0] == 0x30 {
+			pub, err := x509.ParsePKIXPublicKey(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse Ed25519 DER key: %w", err)
+			}
+			if edKey, ok := pub.(ed25519.PublicKey); ok {
+				return edKey, nil
+			}
+			return nil, fmt.Errorf("DER key is %T, not Ed25519", pub)
+		}
+		return nil, fmt.Errorf("invalid Ed25519 public key size: %d", len(data))
+
+	case did.KeyTypeX25519:
+		if len(data) != 32 {
+			return nil, fmt.Errorf("x25519: want 32 bytes, got %d", len(data))
+		}
+		return crypto.PublicKey(data), nil
+
+	default:
+		return nil, fmt.Errorf("unknown key type: %d", keyType)
+	}
+}
+
+// parseECDSADER parses a SubjectPublicKeyInfo. secp256k1 is handled
+// separately because crypto/x509 does not support the curve.
+func parseECDSADER(data []byte) (crypto.PublicKey, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(data, &spki); err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("failed to parse DER public key")
+	}
+
+	var curve asn1.ObjectIdentifier
+	if spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		_, _ = asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curve)
+	}
+	if curve.Equal(oidCurveSecp256k1) {
+		pk, err := did.UnmarshalPublicKey(spki.PublicKey.RightAlign(), "secp256k1")
+		if err != nil {
+			return nil, err
+		}
+		return pk.(crypto.PublicKey), nil
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DER public key: %w", err)
+	}
+	if _, ok := pub.(*ecdsa.PublicKey); !ok {
+		return nil, fmt.Errorf("DER key is %T, not ECDSA", pub)
+	}
+	return pub, nil
+}
+
+// decodeKeyText decodes PEM, hex or base64 text key data
+func decodeKeyText(data []byte) ([]byte, bool) {
+	if block, _ := pem.Decode(data); block != nil {
+		return block.Bytes, true
+	}
+
+	s := strings.TrimSpace(string(data))
+	if s == "" {
+		return nil, false
+	}
+	if h := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"); len(h)%2 == 0 {
+		if decoded, err := hex.DecodeString(h); err == nil {
+			return decoded, true
+		}
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if decoded, err := enc.DecodeString(s); err == nil {
+			return decoded, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalPublicKey encodes pub in the requested encoding. Ed25519 keys
+// support KeyEncodingRaw and KeyEncodingDER only.
+func MarshalPublicKey(pub crypto.PublicKey, encoding KeyEncoding) ([]byte, error) {
+	switch pk := pub.(type) {
+	case ed25519.PublicKey:
+		switch encoding {
+		case KeyEncodingRaw:
+			return bytes.Clone(pk), nil
+		case KeyEncodingDER:
+			return x509.MarshalPKIXPublicKey(pk)
+		}
+		return nil, fmt.Errorf("encoding %d not supported for Ed25519", encoding)
+
+	case *ecdsa.PublicKey:
+		byteLen := (pk.Curve.Params().BitSize + 7) / 8
+		isSecp256k1 := pk.Curve.Params().Name == "secp256k1"
+
+		switch encoding {
+		case KeyEncodingRaw:
+			if isSecp256k1 {
+				out := make([]byte, 2*byteLen)
+				pk.X.FillBytes(out[:byteLen])
+				pk.Y.FillBytes(out[byteLen:])
+				return out, nil
+			}
+			return MarshalPublicKey(pk, KeyEncodingUncompressed)
+		case KeyEncodingUncompressed:
+			out := make([]byte, 1+2*byteLen)
+			out[0] = 0x04
+			pk.X.FillBytes(out[1 : 1+byteLen])
+			pk.Y.FillBytes(out[1+byteLen:])
+			return out, nil
+		case KeyEncodingCompressed:
+			out := make([]byte, 1+byteLen)
+			out[0] = 0x02 | byte(pk.Y.Bit(0))
+			pk.X.FillBytes(out[1:])
+			return out, nil
+		case KeyEncodingDER:
+			if isSecp256k1 {
+				return marshalSecp256k1DER(pk)
+			}
+			return x509.MarshalPKIXPublicKey(pk)
+		}
+		return nil, fmt.Errorf("unknown key encoding: %d", encoding)
+
+	default:
+		return nil, fmt.Errorf("unsupported public key type: %T", pub)
+	}
+}
+
+// marshalSecp256k1DER builds a SubjectPublicKeyInfo for a secp256k1 key
+func marshalSecp256k1DER(pk *ecdsa.PublicKey) ([]byte, error) {
+	point, err := MarshalPublicKey(pk, KeyEncodingUncompressed)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(oidCurveSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// textForms returns data as binary and in every supported text encoding
+func textForms(data []byte) map[string][]byte {
+	return map[string][]byte{
+		"binary":    data,
+		"hex":       []byte(hex.EncodeToString(data)),
+		"0xhex":     []byte("0x" + hex.EncodeToString(data)),
+		"base64":    []byte(base64.StdEncoding.EncodeToString(data)),
+		"base64url": []byte(base64.RawURLEncoding.EncodeToString(data)),
+		"pem":       pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: data}),
+	}
+}
+
+func TestNormalizePublicKey_Secp256k1(t *testing.T) {
+	kp, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	pub := kp.PublicKey().(*ecdsa.PublicKey)
+
+	for _, enc := range []KeyEncoding{KeyEncodingRaw, KeyEncodingCompressed, KeyEncodingUncompressed, KeyEncodingDER} {
+		data, err := MarshalPublicKey(pub, enc)
+		require.NoError(t, err)
+
+		for name, form := range textForms(data) {
+			got, err := NormalizePublicKey(form, did.KeyTypeECDSA)
+			require.NoError(t, err, "encoding %d as %s", enc, name)
+			gotKey := got.(*ecdsa.PublicKey)
+			assert.Equal(t, 0, pub.X.Cmp(gotKey.X), "encoding %d as %s", enc, name)
+			assert.Equal(t, 0, pub.Y.Cmp(gotKey.Y), "encoding %d as %s", enc, name)
+		}
+	}
+
+	compressed, _ := MarshalPublicKey(pub, KeyEncodingCompressed)
+	assert.Len(t, compressed, 33)
+	raw, _ := MarshalPublicKey(pub, KeyEncodingRaw)
+	assert.Len(t, raw, 64)
+}
+
+func TestNormalizePublicKey_P256DER(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := MarshalPublicKey(&priv.PublicKey, KeyEncodingDER)
+	require.NoError(t, err)
+	got, err := NormalizePublicKey(der, did.KeyTypeECDSA)
+	require.NoError(t, err)
+	assert.True(t, priv.PublicKey.Equal(got))
+}
+
+func TestNormalizePublicKey_Ed25519(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, enc := range []KeyEncoding{KeyEncodingRaw, KeyEncodingDER} {
+		data, err := MarshalPublicKey(pub, enc)
+		require.NoError(t, err)
+		for name, form := range textForms(data) {
+			got, err := NormalizePublicKey(form, did.KeyTypeEd25519)
+			require.NoError(t, err, "encoding %d as %s", enc, name)
+			assert.Equal(t, pub, got)
+		}
+	}
+
+	_, err = MarshalPublicKey(pub, KeyEncodingCompressed)
+	assert.Error(t, err)
+}
+
+func TestNormalizePublicKey_Invalid(t *testing.T) {
+	_, err := NormalizePublicKey([]byte("not a key"), did.KeyTypeECDSA)
+	assert.Error(t, err)
+
+	_, err = NormalizePublicKey(make([]byte, 31), did.KeyTypeEd25519)
+	assert.Error(t, err)
+
+	_, err = NormalizePublicKey(make([]byte, 32), did.KeyType(99))
+	assert.Error(t, err)
+}
+
+// staticResolver returns fixed metadata
+type staticResolver struct {
+	meta *did.AgentMetadataV4
+}
+
+func (r *staticResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	return r.meta, nil
+}
+
+func TestDefaultKeySelector_NormalizesKeyData(t *testing.T) {
+	kp, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	pub := kp.PublicKey().(*ecdsa.PublicKey)
+	compressed, err := MarshalPublicKey(pub, KeyEncodingCompressed)
+	require.NoError(t, err)
+
+	// Compressed point stored as hex text, as some marshalers do
+	selector := NewDefaultKeySelector(&staticResolver{meta: &did.AgentMetadataV4{
+		IsActive: true,
+		Keys: []did.AgentKey{{
+			Type:     did.KeyTypeECDSA,
+			KeyData:  []byte("0x" + hex.EncodeToString(compressed)),
+			Verified: true,
+		}},
+	}})
+
+	got, kt, err := selector.SelectKey(context.Background(), "did:sage:ethereum:0xa", "ethereum")
+	require.NoError(t, err)
+	assert.Equal(t, did.KeyTypeECDSA, kt)
+	assert.True(t, pub.Equal(got))
+}
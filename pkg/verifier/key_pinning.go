@@ -0,0 +1,341 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// ErrKeyPinMismatch is returned when a peer's resolved public key differs
+// from the key pinned on first use
+var ErrKeyPinMismatch = errors.New("public key does not match pinned key")
+
+// KeyPin records the public key first seen for a DID
+type KeyPin struct {
+	AgentDID    did.AgentDID `json:"agentDid"`
+	KeyType     string       `json:"keyType"` // "ecdsa", "ed25519" or "x25519"
+	Fingerprint string       `json:"fingerprint"`
+	PinnedAt    time.Time    `json:"pinnedAt"`
+}
+
+// KeyPinChange describes a resolved key that differs from its pin
+type KeyPinChange struct {
+	Pin      KeyPin
+	Resolved string // fingerprint of the newly resolved key
+}
+
+// PinStore persists key pins
+type PinStore interface {
+	// LoadPin returns the pin for agentDID and keyType, or nil if none
+	LoadPin(ctx context.Context, agentDID did.AgentDID, keyType string) (*KeyPin, error)
+
+	// SavePin stores pin, replacing any pin for the same DID and key type
+	SavePin(ctx context.Context, pin *KeyPin) error
+
+	// DeletePins removes every pin of agentDID
+	DeletePins(ctx context.Context, agentDID did.AgentDID) error
+}
+
+// PinConfig configures a PinningVerifier
+type PinConfig struct {
+	// Store persists pins (default NewMemoryPinStore())
+	Store PinStore
+
+	// WarnOnly reports changed keys through OnChange but still uses them,
+	// instead of failing with ErrKeyPinMismatch
+	WarnOnly bool
+
+	// OnChange, if set, is called whenever a resolved key differs from its pin
+	OnChange func(ctx context.Context, change KeyPinChange)
+}
+
+// PinningVerifier wraps a DIDVerifier with trust-on-first-use key pinning:
+// the first key resolved for each DID and key type is pinned, and later
+// resolutions returning a different key fail with ErrKeyPinMismatch. This
+// protects high-value peer relationships against a compromised registry.
+// Intentional rotations are accepted by calling Unpin.
+//
+// Signature verification checks the pin before delegating to the wrapped
+// verifier, which resolves the key again; share a per-request resolution
+// cache (see WithResolutionCache) so both see the same key.
+type PinningVerifier struct {
+	inner  DIDVerifier
+	config PinConfig
+	now    func() time.Time
+
+	mu sync.Mutex // serializes first-use pinning
+}
+
+// NewPinningVerifier creates a pinning wrapper around inner
+func NewPinningVerifier(inner DIDVerifier, config PinConfig) *PinningVerifier {
+	if config.Store == nil {
+		config.Store = NewMemoryPinStore()
+	}
+	return &PinningVerifier{inner: inner, config: config, now: time.Now}
+}
+
+// ResolvePublicKey implements DIDVerifier, checking the resolved key
+// against its pin. It can be used as a protocol.CardKeyResolver.
+func (v *PinningVerifier) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+	pub, err := v.inner.ResolvePublicKey(ctx, agentDID, keyType)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.checkPin(ctx, agentDID, pub); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// VerifyHTTPSignature implements DIDVerifier
+func (v *PinningVerifier) VerifyHTTPSignature(ctx context.Context, req *http.Request, agentDID did.AgentDID) error {
+	// Pin the key the inner verifier will resolve for the signature's alg
+	var keyType *did.KeyType
+	if _, params, err := SelectSignature(req.Header.Get("Signature-Input"), SignatureSelector{}); err == nil {
+		keyType, _ = algorithmKeyType(params.Algorithm)
+	}
+	if _, err := v.ResolvePublicKey(ctx, agentDID, keyType); err != nil {
+		return fmt.Errorf("failed to resolve public key: %w", err)
+	}
+	return v.inner.VerifyHTTPSignature(ctx, req, agentDID)
+}
+
+// VerifyHTTPSignatureWithKeyID implements DIDVerifier
+func (v *PinningVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, req *http.Request) (did.AgentDID, error) {
+	keyID, err := extractKeyID(req.Header.Get("Signature-Input"))
+	if err != nil {
+		return "", fmt.Errorf("failed to extract keyid: %w", err)
+	}
+	if err := v.VerifyHTTPSignature(ctx, req, did.AgentDID(keyID)); err != nil {
+		return "", fmt.Errorf("signature verification failed: %w", err)
+	}
+	return did.AgentDID(keyID), nil
+}
+
+// Unpin forgets the pinned keys of agentDID, accepting the next resolved
+// key, e.g. after a planned key rotation
+func (v *PinningVerifier) Unpin(ctx context.Context, agentDID did.AgentDID) error {
+	return v.config.Store.DeletePins(ctx, agentDID)
+}
+
+// checkPin pins pub on first use or compares it with the existing pin
+func (v *PinningVerifier) checkPin(ctx context.Context, agentDID did.AgentDID, pub crypto.PublicKey) error {
+	keyType, fingerprint, err := KeyFingerprint(pub)
+	if err != nil {
+		return fmt.Errorf("failed to fingerprint public key: %w", err)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	pin, err := v.config.Store.LoadPin(ctx, agentDID, keyType)
+	if err != nil {
+		return fmt.Errorf("failed to load key pin: %w", err)
+	}
+	if pin == nil {
+		pin = &KeyPin{AgentDID: agentDID, KeyType: keyType, Fingerprint: fingerprint, PinnedAt: v.now().UTC()}
+		if err := v.config.Store.SavePin(ctx, pin); err != nil {
+			return fmt.Errorf("failed to save key pin: %w", err)
+		}
+		return nil
+	}
+	if pin.Fingerprint == fingerprint {
+		return nil
+	}
+
+	if v.config.OnChange != nil {
+		v.config.OnChange(ctx, KeyPinChange{Pin: *pin, Resolved: fingerprint})
+	}
+	if v.config.WarnOnly {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s key pinned at %s", ErrKeyPinMismatch, agentDID, keyType, pin.PinnedAt.Format(time.RFC3339))
+}
+
+// KeyFingerprint returns the key type ("ecdsa", "ed25519" or "x25519") and
+// the hex SHA-256 fingerprint of pub's DER encoding (raw bytes for X25519)
+func KeyFingerprint(pub crypto.PublicKey) (keyType, fingerprint string, err error) {
+	var data []byte
+	switch pk := pub.(type) {
+	case *ecdsa.PublicKey:
+		keyType = "ecdsa"
+		data, err = MarshalPublicKey(pk, KeyEncodingDER)
+	case ed25519.PublicKey:
+		keyType = "ed25519"
+		data, err = MarshalPublicKey(pk, KeyEncodingDER)
+	case *ecdh.PublicKey:
+		keyType, data = "x25519", pk.Bytes()
+	case []byte:
+		keyType, data = "x25519", pk
+	default:
+		return "", "", fmt.Errorf("unsupported public key type: %T", pub)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256(data)
+	return keyType, hex.EncodeToString(sum[:]), nil
+}
+
+// MemoryPinStore is an in-memory PinStore
+type MemoryPinStore struct {
+	mu   sync.RWMutex
+	pins map[string]KeyPin
+}
+
+// NewMemoryPinStore creates an empty in-memory pin store
+func NewMemoryPinStore() *MemoryPinStore {
+	return &MemoryPinStore{pins: make(map[string]KeyPin)}
+}
+
+// LoadPin implements PinStore
+func (s *MemoryPinStore) LoadPin(_ context.Context, agentDID did.AgentDID, keyType string) (*KeyPin, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	pin, ok := s.pins[pinKey(agentDID, keyType)]
+	if !ok {
+		return nil, nil
+	}
+	return &pin, nil
+}
+
+// SavePin implements PinStore
+func (s *MemoryPinStore) SavePin(_ context.Context, pin *KeyPin) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pins[pinKey(pin.AgentDID, pin.KeyType)] = *pin
+	return nil
+}
+
+// DeletePins implements PinStore
+func (s *MemoryPinStore) DeletePins(_ context.Context, agentDID did.AgentDID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, pin := range s.pins {
+		if pin.AgentDID == agentDID {
+			delete(s.pins, key)
+		}
+	}
+	return nil
+}
+
+// Pins returns every stored pin, ordered by DID and key type
+func (s *MemoryPinStore) Pins() []KeyPin {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := slices.Sorted(maps.Keys(s.pins))
+	pins := make([]KeyPin, 0, len(keys))
+	for _, key := range keys {
+		pins = append(pins, s.pins[key])
+	}
+	return pins
+}
+
+// FilePinStore is a PinStore persisted as a JSON file, so pins survive
+// restarts. The file is rewritten atomically on every change.
+type FilePinStore struct {
+	path string
+	mem  *MemoryPinStore
+	mu   sync.Mutex // serializes writes
+}
+
+// NewFilePinStore opens the pin store at path, creating it on first save
+func NewFilePinStore(path string) (*FilePinStore, error) {
+	s := &FilePinStore{path: path, mem: NewMemoryPinStore()}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pin store: %w", err)
+	}
+	var pins []KeyPin
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return nil, fmt.Errorf("failed to parse pin store: %w", err)
+	}
+	for i := range pins {
+		s.mem.pins[pinKey(pins[i].AgentDID, pins[i].KeyType)] = pins[i]
+	}
+	return s, nil
+}
+
+// LoadPin implements PinStore
+func (s *FilePinStore) LoadPin(ctx context.Context, agentDID did.AgentDID, keyType string) (*KeyPin, error) {
+	return s.mem.LoadPin(ctx, agentDID, keyType)
+}
+
+// SavePin implements PinStore
+func (s *FilePinStore) SavePin(ctx context.Context, pin *KeyPin) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.mem.SavePin(ctx, pin)
+	return s.flush()
+}
+
+// DeletePins implements PinStore
+func (s *FilePinStore) DeletePins(ctx context.Context, agentDID did.AgentDID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.mem.DeletePins(ctx, agentDID)
+	return s.flush()
+}
+
+// flush writes all pins to a temporary file and renames it into place
+func (s *FilePinStore) flush() error {
+	data, err := json.MarshalIndent(s.mem.Pins(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pins: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".pins-*")
+	if err != nil {
+		return fmt.Errorf("failed to write pin store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write pin store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write pin store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write pin store: %w", err)
+	}
+	return nil
+}
+
+func pinKey(agentDID did.AgentDID, keyType string) string {
+	return string(agentDID) + "#" + keyType
+}
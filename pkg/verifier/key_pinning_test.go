@@ -0,0 +1,141 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingVerifier resolves whatever key is currently set
+type rotatingVerifier struct {
+	key      crypto.PublicKey
+	verified int
+}
+
+func (v *rotatingVerifier) VerifyHTTPSignature(ctx context.Context, req *http.Request, agentDID did.AgentDID) error {
+	v.verified++
+	return nil
+}
+
+func (v *rotatingVerifier) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID, keyType *did.KeyType) (crypto.PublicKey, error) {
+	return v.key, nil
+}
+
+func (v *rotatingVerifier) VerifyHTTPSignatureWithKeyID(ctx context.Context, req *http.Request) (did.AgentDID, error) {
+	return "", nil
+}
+
+func newEd25519Key(t *testing.T) ed25519.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return pub
+}
+
+func TestPinningVerifier(t *testing.T) {
+	ctx := context.Background()
+	agentDID := did.AgentDID("did:sage:ethereum:0xpeer")
+	first := newEd25519Key(t)
+	inner := &rotatingVerifier{key: first}
+
+	var changes []KeyPinChange
+	v := NewPinningVerifier(inner, PinConfig{
+		OnChange: func(_ context.Context, change KeyPinChange) { changes = append(changes, change) },
+	})
+
+	// First use pins the key
+	pub, err := v.ResolvePublicKey(ctx, agentDID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, pub)
+
+	// A changed key is rejected and reported
+	inner.key = newEd25519Key(t)
+	_, err = v.ResolvePublicKey(ctx, agentDID, nil)
+	require.ErrorIs(t, err, ErrKeyPinMismatch)
+	require.Len(t, changes, 1)
+	assert.Equal(t, agentDID, changes[0].Pin.AgentDID)
+	assert.Equal(t, "ed25519", changes[0].Pin.KeyType)
+
+	req := httptest.NewRequest("POST", "https://agent.example.com/rpc", nil)
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="`+string(agentDID)+`"`)
+	_, err = v.VerifyHTTPSignatureWithKeyID(ctx, req)
+	require.ErrorIs(t, err, ErrKeyPinMismatch)
+	assert.Zero(t, inner.verified, "signatures are not checked against unpinned keys")
+
+	// Unpinning accepts the rotation
+	require.NoError(t, v.Unpin(ctx, agentDID))
+	got, err := v.VerifyHTTPSignatureWithKeyID(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, agentDID, got)
+	assert.Equal(t, 1, inner.verified)
+}
+
+func TestPinningVerifier_WarnOnly(t *testing.T) {
+	ctx := context.Background()
+	inner := &rotatingVerifier{key: newEd25519Key(t)}
+	changed := 0
+	v := NewPinningVerifier(inner, PinConfig{
+		WarnOnly: true,
+		OnChange: func(context.Context, KeyPinChange) { changed++ },
+	})
+
+	_, err := v.ResolvePublicKey(ctx, "did:sage:ethereum:0xpeer", nil)
+	require.NoError(t, err)
+	inner.key = newEd25519Key(t)
+	pub, err := v.ResolvePublicKey(ctx, "did:sage:ethereum:0xpeer", nil)
+	require.NoError(t, err)
+	assert.Equal(t, inner.key, pub)
+	assert.Equal(t, 1, changed)
+}
+
+func TestFilePinStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "pins.json")
+	agentDID := did.AgentDID("did:sage:ethereum:0xpeer")
+	inner := &rotatingVerifier{key: newEd25519Key(t)}
+
+	store, err := NewFilePinStore(path)
+	require.NoError(t, err)
+	_, err = NewPinningVerifier(inner, PinConfig{Store: store}).ResolvePublicKey(ctx, agentDID, nil)
+	require.NoError(t, err)
+
+	// Pins survive reopening the store
+	reopened, err := NewFilePinStore(path)
+	require.NoError(t, err)
+	pin, err := reopened.LoadPin(ctx, agentDID, "ed25519")
+	require.NoError(t, err)
+	require.NotNil(t, pin)
+
+	inner.key = newEd25519Key(t)
+	_, err = NewPinningVerifier(inner, PinConfig{Store: reopened}).ResolvePublicKey(ctx, agentDID, nil)
+	assert.ErrorIs(t, err, ErrKeyPinMismatch)
+
+	require.NoError(t, reopened.DeletePins(ctx, agentDID))
+	pin, err = reopened.LoadPin(ctx, agentDID, "ed25519")
+	require.NoError(t, err)
+	assert.Nil(t, pin)
+}
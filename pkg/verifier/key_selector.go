@@ -24,6 +24,8 @@ import (
 	"github.com/sage-x-project/sage/pkg/agent/did"
 )
 
+//go:generate mockgen -write_package_comment=false -destination=../mocks/key_selector.go -package=mocks . KeySelector
+
 // KeySelector selects the appropriate cryptographic key for an agent
 // based on the protocol or explicit preference
 type KeySelector interface {
@@ -32,3 +34,11 @@ type KeySelector interface {
 	// Returns: public key, key type, error
 	SelectKey(ctx context.Context, agentDID did.AgentDID, protocol string) (crypto.PublicKey, did.KeyType, error)
 }
+
+// KeyLister lists every verified key of a type registered for an agent.
+// When the key selector of a DefaultDIDVerifier implements it, signatures
+// by any of those keys are accepted, e.g. by the old and the new key during
+// a rotation overlap.
+type KeyLister interface {
+	ListKeys(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) ([]crypto.PublicKey, error)
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+type memoContextKey struct{}
+
+// resolutionMemo stores DID resolution results for the lifetime of one request
+type resolutionMemo struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry
+}
+
+// memoEntry is a single memoized result; ready is closed once value/err are set
+type memoEntry struct {
+	ready chan struct{}
+	value interface{}
+	err   error
+}
+
+// WithResolutionCache returns a context that memoizes DID resolution results.
+// Every memoizing resolver called with the returned context (or a context
+// derived from it) queries the chain at most once per DID and lookup kind.
+//
+// DIDAuthMiddleware installs this automatically for every request, so
+// handlers performing additional verifications for the same DID (delegation
+// tokens, card checks) reuse the results instead of hitting the chain again.
+func WithResolutionCache(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(memoContextKey{}).(*resolutionMemo); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, memoContextKey{}, &resolutionMemo{
+		entries: make(map[string]*memoEntry),
+	})
+}
+
+// Memoize runs resolve at most once per key within a context created by
+// WithResolutionCache. Errors are memoized too, so a failing lookup is not
+// retried within the same request. Without a cache in ctx, resolve is
+// called directly.
+func Memoize(ctx context.Context, key string, resolve func() (interface{}, error)) (interface{}, error) {
+	memo, ok := ctx.Value(memoContextKey{}).(*resolutionMemo)
+	if !ok {
+		return resolve()
+	}
+
+	memo.mu.Lock()
+	if entry, found := memo.entries[key]; found {
+		memo.mu.Unlock()
+		select {
+		case <-entry.ready:
+			return entry.value, entry.err
+		case <-ctx.Done():
+			return nil, contextError(ctx, stageResolve)
+		}
+	}
+	entry := &memoEntry{ready: make(chan struct{})}
+	memo.entries[key] = entry
+	memo.mu.Unlock()
+
+	func() {
+		defer close(entry.ready)
+		entry.value, entry.err = resolve()
+	}()
+
+	return entry.value, entry.err
+}
+
+// MemoizedResolver wraps a DIDResolver with per-request memoization
+type MemoizedResolver struct {
+	resolver DIDResolver
+}
+
+// NewMemoizedResolver creates a DIDResolver that memoizes GetAgentByDID
+// results in contexts created by WithResolutionCache
+func NewMemoizedResolver(resolver DIDResolver) *MemoizedResolver {
+	return &MemoizedResolver{resolver: resolver}
+}
+
+// GetAgentByDID implements DIDResolver
+func (m *MemoizedResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	v, err := Memoize(ctx, "agent:"+didStr, func() (interface{}, error) {
+		return m.resolver.GetAgentByDID(ctx, didStr)
+	})
+	meta, _ := v.(*did.AgentMetadataV4)
+	return meta, err
+}
+
+// MemoizedPublicKeyClient wraps a PublicKeyClient with per-request memoization
+type MemoizedPublicKeyClient struct {
+	client PublicKeyClient
+}
+
+// NewMemoizedPublicKeyClient creates a PublicKeyClient that memoizes key
+// lookups in contexts created by WithResolutionCache
+func NewMemoizedPublicKeyClient(client PublicKeyClient) *MemoizedPublicKeyClient {
+	return &MemoizedPublicKeyClient{client: client}
+}
+
+// ResolvePublicKey implements PublicKeyClient
+func (m *MemoizedPublicKeyClient) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return Memoize(ctx, "pubkey:"+string(agentDID), func() (interface{}, error) {
+		return m.client.ResolvePublicKey(ctx, agentDID)
+	})
+}
+
+// ResolveKEMKey implements PublicKeyClient
+func (m *MemoizedPublicKeyClient) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	return Memoize(ctx, "kem:"+string(agentDID), func() (interface{}, error) {
+		return m.client.ResolveKEMKey(ctx, agentDID)
+	})
+}
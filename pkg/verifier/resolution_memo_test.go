@@ -0,0 +1,153 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingResolver counts GetAgentByDID calls
+type countingResolver struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (r *countingResolver) GetAgentByDID(ctx context.Context, didStr string) (*did.AgentMetadataV4, error) {
+	r.calls.Add(1)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &did.AgentMetadataV4{DID: did.AgentDID(didStr), IsActive: true}, nil
+}
+
+// countingKeyClient counts PublicKeyClient calls
+type countingKeyClient struct {
+	pubCalls atomic.Int32
+	kemCalls atomic.Int32
+}
+
+func (c *countingKeyClient) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	c.pubCalls.Add(1)
+	return "pub-" + string(agentDID), nil
+}
+
+func (c *countingKeyClient) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	c.kemCalls.Add(1)
+	return "kem-" + string(agentDID), nil
+}
+
+func TestMemoizedResolver_OncePerDIDPerRequest(t *testing.T) {
+	inner := &countingResolver{}
+	resolver := NewMemoizedResolver(inner)
+	ctx := WithResolutionCache(context.Background())
+
+	for i := 0; i < 3; i++ {
+		meta, err := resolver.GetAgentByDID(ctx, "did:sage:ethereum:0xa")
+		require.NoError(t, err)
+		assert.Equal(t, did.AgentDID("did:sage:ethereum:0xa"), meta.DID)
+	}
+	_, err := resolver.GetAgentByDID(ctx, "did:sage:ethereum:0xb")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), inner.calls.Load())
+
+	// A new request gets a fresh cache
+	_, err = resolver.GetAgentByDID(WithResolutionCache(context.Background()), "did:sage:ethereum:0xa")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), inner.calls.Load())
+}
+
+func TestMemoizedResolver_NoCacheInContext(t *testing.T) {
+	inner := &countingResolver{}
+	resolver := NewMemoizedResolver(inner)
+
+	for i := 0; i < 3; i++ {
+		_, err := resolver.GetAgentByDID(context.Background(), "did:sage:ethereum:0xa")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(3), inner.calls.Load())
+}
+
+func TestMemoizedResolver_ErrorsAreMemoized(t *testing.T) {
+	inner := &countingResolver{err: fmt.Errorf("rpc unavailable")}
+	resolver := NewMemoizedResolver(inner)
+	ctx := WithResolutionCache(context.Background())
+
+	_, err1 := resolver.GetAgentByDID(ctx, "did:sage:ethereum:0xa")
+	_, err2 := resolver.GetAgentByDID(ctx, "did:sage:ethereum:0xa")
+
+	assert.Error(t, err1)
+	assert.Equal(t, err1, err2)
+	assert.Equal(t, int32(1), inner.calls.Load())
+}
+
+func TestMemoizedPublicKeyClient(t *testing.T) {
+	inner := &countingKeyClient{}
+	client := NewMemoizedPublicKeyClient(inner)
+	ctx := WithResolutionCache(context.Background())
+	agentDID := did.AgentDID("did:sage:ethereum:0xa")
+
+	for i := 0; i < 2; i++ {
+		pk, err := client.ResolvePublicKey(ctx, agentDID)
+		require.NoError(t, err)
+		assert.Equal(t, "pub-did:sage:ethereum:0xa", pk)
+
+		kem, err := client.ResolveKEMKey(ctx, agentDID)
+		require.NoError(t, err)
+		assert.Equal(t, "kem-did:sage:ethereum:0xa", kem)
+	}
+
+	assert.Equal(t, int32(1), inner.pubCalls.Load())
+	assert.Equal(t, int32(1), inner.kemCalls.Load())
+}
+
+func TestWithResolutionCache_Idempotent(t *testing.T) {
+	ctx := WithResolutionCache(context.Background())
+	assert.Equal(t, ctx, WithResolutionCache(ctx))
+}
+
+func TestMemoize_ConcurrentCallers(t *testing.T) {
+	ctx := WithResolutionCache(context.Background())
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := Memoize(ctx, "k", func() (interface{}, error) {
+				calls.Add(1)
+				return "v", nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "v", v)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
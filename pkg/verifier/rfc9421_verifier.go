@@ -19,8 +19,11 @@ package verifier
 
 import (
 	"crypto"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
 	"github.com/sage-x-project/sage/pkg/agent/core/rfc9421"
 )
 
@@ -28,18 +31,78 @@ import (
 type RFC9421Verifier struct {
 	verifier *rfc9421.HTTPVerifier
 	options  *rfc9421.HTTPVerificationOptions
+
+	strictComponents bool
+	maxHeaderSize    int
+	selector         SignatureSelector
+	encodings        []signer.SignatureEncoding // non-standard signature encodings accepted
+	warnings         *WarningConfig
+}
+
+// RFC9421Option configures an RFC9421Verifier
+type RFC9421Option func(*RFC9421Verifier)
+
+// StrictComponents rejects signatures whose Signature-Input violates
+// signer.CheckComponentPolicy (missing @method, target, created or body
+// digest) or whose signature headers exceed signer.MaxSignatureHeaderSize.
+func StrictComponents() RFC9421Option {
+	return func(v *RFC9421Verifier) {
+		v.strictComponents = true
+		if v.maxHeaderSize == 0 {
+			v.maxHeaderSize = signer.MaxSignatureHeaderSize
+		}
+	}
+}
+
+// WithMaxSignatureHeaderSize rejects requests whose Signature-Input and
+// Signature headers together exceed size bytes
+func WithMaxSignatureHeaderSize(size int) RFC9421Option {
+	return func(v *RFC9421Verifier) {
+		v.maxHeaderSize = size
+	}
+}
+
+// WithMaxAge sets how old a signature's created parameter may be (five
+// minutes by default). Zero disables the check, e.g. to verify recorded
+// requests or test vectors; expires is still enforced.
+func WithMaxAge(maxAge time.Duration) RFC9421Option {
+	return func(v *RFC9421Verifier) {
+		v.options.MaxAge = maxAge
+	}
+}
+
+// WithSignatureCompatibility accepts signatures encoded as hex or bare
+// base64 (see signer.NormalizeSignatureHeader), as emitted by some
+// non-Go peers, in addition to RFC 9421 byte sequences. Enable it only for
+// such peers; the signature itself is verified as usual.
+func WithSignatureCompatibility(encodings ...signer.SignatureEncoding) RFC9421Option {
+	return func(v *RFC9421Verifier) {
+		v.encodings = encodings
+	}
 }
 
 // NewRFC9421Verifier creates a new RFC9421Verifier with default options
-func NewRFC9421Verifier() *RFC9421Verifier {
-	return &RFC9421Verifier{
+func NewRFC9421Verifier(opts ...RFC9421Option) *RFC9421Verifier {
+	v := &RFC9421Verifier{
 		verifier: rfc9421.NewHTTPVerifier(),
 		options:  rfc9421.DefaultHTTPVerificationOptions(),
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
-// VerifyHTTPRequest verifies an HTTP request signature using RFC9421
+// VerifyHTTPRequest verifies an HTTP request signature using RFC9421.
+// With several signatures present, the one chosen by the signature
+// selector is verified.
 func (v *RFC9421Verifier) VerifyHTTPRequest(req *http.Request, pubKey interface{}) error {
+	label, params, err := v.checkPolicy(req)
+	if err != nil {
+		return err
+	}
+	warnings := v.collectWarnings(req, label, params)
+
 	// Convert interface{} to crypto.PublicKey
 	cryptoPubKey, ok := pubKey.(crypto.PublicKey)
 	if !ok {
@@ -47,6 +110,67 @@ func (v *RFC9421Verifier) VerifyHTTPRequest(req *http.Request, pubKey interface{
 		cryptoPubKey = pubKey
 	}
 
+	opts := *v.options
+	if label != "" {
+		opts.SignatureName = label
+	}
+
+	if len(v.encodings) > 0 {
+		if req, err = v.normalizeSignature(req); err != nil {
+			return err
+		}
+	}
+
 	// Use SAGE's RFC9421 HTTP verifier
-	return v.verifier.VerifyRequest(req, cryptoPubKey, v.options)
+	if err := v.verifier.VerifyRequest(req, cryptoPubKey, &opts); err != nil {
+		return err
+	}
+	reportWarnings(req.Context(), v.WarningObserver(), warnings...)
+	return nil
+}
+
+// checkPolicy enforces header size limits and, in strict mode, the
+// component policy for the selected signature, whose label and parameters
+// it returns
+func (v *RFC9421Verifier) checkPolicy(req *http.Request) (string, *rfc9421.SignatureInputParams, error) {
+	sigInput := req.Header.Get("Signature-Input")
+
+	if v.maxHeaderSize > 0 {
+		if size := len(sigInput) + len(req.Header.Get("Signature")); size > v.maxHeaderSize {
+			return "", nil, fmt.Errorf("signature headers too large: %d bytes (max %d)", size, v.maxHeaderSize)
+		}
+	}
+
+	if sigInput == "" {
+		// Reported by the RFC 9421 verifier
+		return "", nil, nil
+	}
+	label, params, err := SelectSignature(sigInput, v.selector)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if v.strictComponents {
+		if err := signer.CheckComponentPolicy(req.Method, params.CoveredComponents, params.Created, params.Expires); err != nil {
+			return "", nil, fmt.Errorf("signature %s rejected: %w", label, err)
+		}
+	}
+	return label, params, nil
+}
+
+// normalizeSignature returns a shallow copy of req whose Signature header
+// is in RFC 9421 form, leaving req itself untouched
+func (v *RFC9421Verifier) normalizeSignature(req *http.Request) (*http.Request, error) {
+	header := req.Header.Get("Signature")
+	if header == "" {
+		return req, nil
+	}
+	normalized, err := signer.NormalizeSignatureHeader(header, v.encodings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Signature header: %w", err)
+	}
+	out := *req
+	out.Header = req.Header.Clone()
+	out.Header.Set("Signature", normalized)
+	return &out, nil
 }
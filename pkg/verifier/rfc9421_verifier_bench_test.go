@@ -0,0 +1,70 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+)
+
+// BenchmarkVerifyHTTPRequest_KeyTypes measures RFC 9421 verification of a
+// signed JSON-RPC request for each supported key type
+func BenchmarkVerifyHTTPRequest_KeyTypes(b *testing.B) {
+	keyTypes := []struct {
+		name     string
+		generate func() (crypto.KeyPair, error)
+	}{
+		{"Ed25519", keys.GenerateEd25519KeyPair},
+		{"Secp256k1", keys.GenerateSecp256k1KeyPair},
+		{"P256", keys.GenerateP256KeyPair},
+	}
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"message/send","params":{}}`)
+	v := NewRFC9421Verifier()
+
+	for _, kt := range keyTypes {
+		b.Run(kt.name, func(b *testing.B) {
+			keyPair, err := kt.generate()
+			if err != nil {
+				b.Fatalf("failed to generate key: %v", err)
+			}
+			req, _ := http.NewRequest("POST", "https://bench.example.com/rpc", bytes.NewReader(body))
+			if err := signer.NewDefaultA2ASigner().SignRequest(context.Background(), req, "did:sage:ethereum:0xbench", keyPair); err != nil {
+				b.Fatalf("failed to sign: %v", err)
+			}
+			if err := v.VerifyHTTPRequest(req, keyPair.PublicKey()); err != nil {
+				b.Fatalf("failed to verify: %v", err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				req.Body = io.NopCloser(bytes.NewReader(body))
+				if err := v.VerifyHTTPRequest(req, keyPair.PublicKey()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
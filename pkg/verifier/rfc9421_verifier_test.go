@@ -0,0 +1,89 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRFC9421Verifier_StrictComponents(t *testing.T) {
+	v := NewRFC9421Verifier(StrictComponents())
+	created := time.Now().Unix()
+
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+	req.Header.Set("Signature-Input", fmt.Sprintf(`sig1=("@path");keyid="did:sage:ethereum:0x1";created=%d`, created))
+	req.Header.Set("Signature", "sig1=:AAAA:")
+
+	err := v.VerifyHTTPRequest(req, nil)
+	require.Error(t, err)
+	var policyErr *signer.PolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.Contains(t, policyErr.Violations, "@method must be covered")
+	assert.Contains(t, policyErr.Violations, "content-digest must be covered for POST requests")
+
+	// A compliant Signature-Input passes the policy and fails later on the key
+	req.Header.Set("Signature-Input", fmt.Sprintf(`sig1=("@method" "@path" "content-digest");keyid="did:sage:ethereum:0x1";created=%d`, created))
+	err = v.VerifyHTTPRequest(req, nil)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &policyErr))
+}
+
+func TestRFC9421Verifier_MaxSignatureHeaderSize(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Signature-Input", `sig1=("@method");keyid="`+strings.Repeat("a", 100)+`"`)
+	req.Header.Set("Signature", "sig1=:AAAA:")
+
+	err := NewRFC9421Verifier(WithMaxSignatureHeaderSize(64)).VerifyHTTPRequest(req, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signature headers too large")
+
+	// Not enforced by default
+	err = NewRFC9421Verifier().VerifyHTTPRequest(req, nil)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "signature headers too large")
+}
+
+func TestRFC9421Verifier_SignatureCompatibility(t *testing.T) {
+	keyPair, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	for _, enc := range []signer.SignatureEncoding{signer.SignatureEncodingHex, signer.SignatureEncodingBase64} {
+		t.Run(string(enc), func(t *testing.T) {
+			s := signer.NewDefaultA2ASigner()
+			s.SetSignatureEncoding(enc)
+			req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+			require.NoError(t, s.SignRequest(context.Background(), req, "did:sage:ethereum:0x1", keyPair))
+			assert.NotContains(t, req.Header.Get("Signature"), ":")
+
+			assert.Error(t, NewRFC9421Verifier().VerifyHTTPRequest(req, keyPair.PublicKey()))
+			v := NewRFC9421Verifier(WithSignatureCompatibility(enc))
+			assert.NoError(t, v.VerifyHTTPRequest(req, keyPair.PublicKey()))
+		})
+	}
+}
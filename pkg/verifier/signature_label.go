@@ -0,0 +1,99 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/core/rfc9421"
+)
+
+// SignatureSelector chooses which signature of a request to verify when
+// Signature-Input carries several labels
+type SignatureSelector struct {
+	// Label selects the signature with this label. Empty accepts any label.
+	Label string
+
+	// KeyIDPattern, when set, only accepts signatures whose keyid matches
+	KeyIDPattern *regexp.Regexp
+}
+
+// SelectSignature returns the label and parameters of the signature in
+// sigInput chosen by sel. Without a Label, the candidates matching
+// KeyIDPattern are considered in label order, preferring
+// signer.DefaultSignatureLabel.
+func SelectSignature(sigInput string, sel SignatureSelector) (string, *rfc9421.SignatureInputParams, error) {
+	signatures, err := rfc9421.ParseSignatureInput(sigInput)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse Signature-Input: %w", err)
+	}
+
+	matches := func(params *rfc9421.SignatureInputParams) bool {
+		return sel.KeyIDPattern == nil || sel.KeyIDPattern.MatchString(params.KeyID)
+	}
+
+	if sel.Label != "" {
+		params, ok := signatures[sel.Label]
+		if !ok {
+			return "", nil, fmt.Errorf("signature %q not found in Signature-Input", sel.Label)
+		}
+		if !matches(params) {
+			return "", nil, fmt.Errorf("signature %q keyid %q does not match %s", sel.Label, params.KeyID, sel.KeyIDPattern)
+		}
+		return sel.Label, params, nil
+	}
+
+	var candidates []string
+	for label, params := range signatures {
+		if matches(params) {
+			candidates = append(candidates, label)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil, fmt.Errorf("no signature in Signature-Input matches the selector")
+	}
+	slices.Sort(candidates)
+	label := candidates[0]
+	if slices.Contains(candidates, signer.DefaultSignatureLabel) {
+		label = signer.DefaultSignatureLabel
+	}
+	return label, signatures[label], nil
+}
+
+// WithSignatureSelector verifies the signature chosen by sel instead of
+// the default choice. A DefaultDIDVerifier using this verifier takes the
+// keyid from the same signature.
+func WithSignatureSelector(sel SignatureSelector) RFC9421Option {
+	return func(v *RFC9421Verifier) {
+		v.selector = sel
+	}
+}
+
+// SignatureSelection returns the selector used to choose the signature
+func (v *RFC9421Verifier) SignatureSelection() SignatureSelector {
+	return v.selector
+}
+
+// signatureSelection is implemented by signature verifiers that choose
+// among several signatures
+type signatureSelection interface {
+	SignatureSelection() SignatureSelector
+}
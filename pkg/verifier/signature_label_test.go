@@ -0,0 +1,116 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doublySignedRequest returns a request signed by proxyKey under label
+// "proxy" and by agentKey under label "sage"
+func doublySignedRequest(t *testing.T, proxyKey, agentKey crypto.KeyPair) *http.Request {
+	s := signer.NewDefaultA2ASigner()
+	newReq := func() *http.Request {
+		return httptest.NewRequest("POST", "https://agent.example.com/rpc", strings.NewReader(`{"jsonrpc":"2.0"}`))
+	}
+
+	proxyReq := newReq()
+	require.NoError(t, s.SignRequestWithOptions(context.Background(), proxyReq, "did:web:proxy.example", proxyKey, &signer.SigningOptions{Label: "proxy"}))
+	agentReq := newReq()
+	require.NoError(t, s.SignRequestWithOptions(context.Background(), agentReq, "did:sage:ethereum:0xagent", agentKey, &signer.SigningOptions{Label: "sage"}))
+
+	req := newReq()
+	req.Header.Set("Content-Digest", agentReq.Header.Get("Content-Digest"))
+	req.Header.Set("Signature-Input", proxyReq.Header.Get("Signature-Input")+", "+agentReq.Header.Get("Signature-Input"))
+	req.Header.Set("Signature", proxyReq.Header.Get("Signature")+", "+agentReq.Header.Get("Signature"))
+	return req
+}
+
+func TestSelectSignature(t *testing.T) {
+	input := `b=("@method");keyid="did:web:b", sig1=("@method");keyid="did:web:x", a=("@method");keyid="did:sage:ethereum:0xa"`
+
+	label, _, err := SelectSignature(input, SignatureSelector{})
+	require.NoError(t, err)
+	assert.Equal(t, "sig1", label)
+
+	label, params, err := SelectSignature(input, SignatureSelector{KeyIDPattern: regexp.MustCompile(`^did:sage:`)})
+	require.NoError(t, err)
+	assert.Equal(t, "a", label)
+	assert.Equal(t, "did:sage:ethereum:0xa", params.KeyID)
+
+	label, _, err = SelectSignature(input, SignatureSelector{KeyIDPattern: regexp.MustCompile(`^did:web:b`)})
+	require.NoError(t, err)
+	assert.Equal(t, "b", label)
+
+	_, _, err = SelectSignature(input, SignatureSelector{Label: "missing"})
+	assert.Error(t, err)
+	_, _, err = SelectSignature(input, SignatureSelector{Label: "b", KeyIDPattern: regexp.MustCompile(`^did:sage:`)})
+	assert.Error(t, err)
+}
+
+func TestRFC9421Verifier_MultipleLabels(t *testing.T) {
+	proxyKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	agentKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	req := doublySignedRequest(t, proxyKey, agentKey)
+
+	byLabel := NewRFC9421Verifier(WithSignatureSelector(SignatureSelector{Label: "sage"}))
+	assert.NoError(t, byLabel.VerifyHTTPRequest(req, agentKey.PublicKey()))
+	assert.Error(t, byLabel.VerifyHTTPRequest(req, proxyKey.PublicKey()))
+
+	byProxy := NewRFC9421Verifier(WithSignatureSelector(SignatureSelector{Label: "proxy"}))
+	assert.NoError(t, byProxy.VerifyHTTPRequest(req, proxyKey.PublicKey()))
+}
+
+func TestDefaultDIDVerifier_KeyIDFromSelectedSignature(t *testing.T) {
+	proxyKey, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	agentKey, err := keys.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	agentDID := did.AgentDID("did:sage:ethereum:0xagent")
+	client := &mockEthereumClient{
+		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{
+			agentDID: {did.KeyTypeECDSA: agentKey.PublicKey()},
+		},
+	}
+	sigVerifier := NewRFC9421Verifier(WithSignatureSelector(SignatureSelector{KeyIDPattern: regexp.MustCompile(`^did:sage:`)}))
+	v := NewDefaultDIDVerifier(client, NewDefaultKeySelector(client), sigVerifier)
+
+	got, err := v.VerifyHTTPSignatureWithKeyID(context.Background(), doublySignedRequest(t, proxyKey, agentKey))
+	require.NoError(t, err)
+	assert.Equal(t, agentDID, got)
+
+	// By default the first label, "proxy", is chosen, whose keyid is not a SAGE DID
+	v = NewDefaultDIDVerifier(client, NewDefaultKeySelector(client), NewRFC9421Verifier())
+	_, err = v.VerifyHTTPSignatureWithKeyID(context.Background(), doublySignedRequest(t, proxyKey, agentKey))
+	assert.ErrorContains(t, err, "invalid DID format")
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+)
+
+// VerificationResult describes a verified request signature
+type VerificationResult struct {
+	AgentDID did.AgentDID
+
+	// Params are the parameters of the verified signature, including
+	// tag and any parameters RFC 9421 does not define
+	Params *signer.SignatureParams
+}
+
+// SignatureParamsReader is implemented by DIDVerifiers that can report the
+// parameters of the signature they check on a request
+type SignatureParamsReader interface {
+	SignatureParams(req *http.Request) (*signer.SignatureParams, error)
+}
+
+// SelectSignatureParams returns the typed parameters of the signature in
+// sigInput chosen by sel
+func SelectSignatureParams(sigInput string, sel SignatureSelector) (*signer.SignatureParams, error) {
+	label, _, err := SelectSignature(sigInput, sel)
+	if err != nil {
+		return nil, err
+	}
+	return signer.LookupSignatureParams(sigInput, label)
+}
+
+// SignatureParams returns the typed parameters of the signature on req
+// that this verifier checks
+func (v *DefaultDIDVerifier) SignatureParams(req *http.Request) (*signer.SignatureParams, error) {
+	var sel SignatureSelector
+	if s, ok := v.signatureVerifier.(signatureSelection); ok {
+		sel = s.SignatureSelection()
+	}
+	return SelectSignatureParams(req.Header.Get("Signature-Input"), sel)
+}
+
+// VerifyHTTPSignatureWithResult is VerifyHTTPSignatureWithKeyID also
+// returning the parameters of the verified signature
+func (v *DefaultDIDVerifier) VerifyHTTPSignatureWithResult(ctx context.Context, req *http.Request) (*VerificationResult, error) {
+	agentDID, err := v.VerifyHTTPSignatureWithKeyID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	params, err := v.SignatureParams(req)
+	if err != nil {
+		return nil, err
+	}
+	return &VerificationResult{AgentDID: agentDID, Params: params}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDIDVerifier_VerifyHTTPSignatureWithResult(t *testing.T) {
+	testDID := did.AgentDID("did:sage:ethereum:0xresult")
+	client := &mockEthereumClient{
+		publicKeys: map[did.AgentDID]map[did.KeyType]interface{}{
+			testDID: {did.KeyTypeECDSA: createECDSAKey()},
+		},
+		keys: map[did.AgentDID][]did.AgentKey{
+			testDID: {{Type: did.KeyTypeECDSA, KeyData: []byte("dummy"), Verified: true, CreatedAt: time.Now()}},
+		},
+	}
+	verifier := NewDefaultDIDVerifier(client, NewDefaultKeySelector(client), &mockSignatureVerifier{})
+
+	req := httptest.NewRequest("POST", "https://agent.example.com/task", nil)
+	req.Header.Set("Signature-Input", `sig1=("@method" "@target-uri");created=1618884473;keyid="did:sage:ethereum:0xresult";tag="a2a";hop=2`)
+	req.Header.Set("Signature", "sig1=:dGVzdA==:")
+
+	result, err := verifier.VerifyHTTPSignatureWithResult(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, testDID, result.AgentDID)
+	assert.Equal(t, "sig1", result.Params.Label)
+	assert.Equal(t, int64(1618884473), result.Params.Created)
+	assert.Equal(t, "a2a", result.Params.Tag)
+	assert.Equal(t, []signer.SignatureParam{{Name: "hop", Value: int64(2)}}, result.Params.Extra)
+
+	req.Header.Del("Signature-Input")
+	_, err = verifier.VerifyHTTPSignatureWithResult(context.Background(), req)
+	assert.Error(t, err)
+}
+
+func TestSelectSignatureParams(t *testing.T) {
+	input := `proxy=("@method");keyid="did:web:proxy.example", sage=("@method" "@path");keyid="did:sage:ethereum:0xagent";tag="a2a"`
+
+	params, err := SelectSignatureParams(input, SignatureSelector{Label: "sage"})
+	require.NoError(t, err)
+	assert.Equal(t, "did:sage:ethereum:0xagent", params.KeyID)
+	assert.Equal(t, []string{"@method", "@path"}, params.Components)
+	assert.Equal(t, "a2a", params.Tag)
+
+	_, err = SelectSignatureParams(input, SignatureSelector{Label: "missing"})
+	assert.Error(t, err)
+}
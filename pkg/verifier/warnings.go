@@ -0,0 +1,172 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	"github.com/sage-x-project/sage/pkg/agent/core/rfc9421"
+)
+
+// WarningCode identifies the kind of a verification warning
+type WarningCode string
+
+const (
+	// WarningClockSkew: the signature was created in the future, or its
+	// age is close to the maximum accepted
+	WarningClockSkew WarningCode = "clock_skew"
+
+	// WarningDeprecatedAlgorithm: the signature names a deprecated
+	// algorithm
+	WarningDeprecatedAlgorithm WarningCode = "deprecated_algorithm"
+
+	// WarningKeyRotation: the signature only verified under a key other
+	// than the agent's current one, as while a rotation overlaps keys
+	WarningKeyRotation WarningCode = "key_rotation"
+
+	// WarningOversizedSignature: the signature headers are close to the
+	// maximum accepted size
+	WarningOversizedSignature WarningCode = "oversized_signature"
+)
+
+// Warning is a non-fatal anomaly found while verifying a request whose
+// signature was accepted
+type Warning struct {
+	Code    WarningCode
+	KeyID   string // keyid of the signature, if known
+	Label   string // label of the signature, if known
+	Message string
+}
+
+// WarningObserver receives verification warnings. It is called
+// synchronously and must not block.
+type WarningObserver func(ctx context.Context, w Warning)
+
+// DefaultDeprecatedAlgorithms are the signature algorithms reported as
+// deprecated by default: the secp256k1 aliases that predate "es256k"
+var DefaultDeprecatedAlgorithms = []string{"ecdsa-secp256k1", "ecdsa-secp256k1-sha256"}
+
+// defaultWarningThreshold is the default WarningConfig.Threshold
+const defaultWarningThreshold = 0.8
+
+// maxFutureSkew is how far in the future a signature may be created
+// before it is reported
+const maxFutureSkew = time.Second
+
+// WarningConfig configures verification warnings
+type WarningConfig struct {
+	// Observer receives the warnings
+	Observer WarningObserver
+
+	// Threshold is the fraction of a limit, the maximum signature age or
+	// header size, beyond which accepted signatures are reported
+	// (default 0.8)
+	Threshold float64
+
+	// DeprecatedAlgorithms are reported when named by a signature's alg
+	// parameter, compared case-insensitively (default
+	// DefaultDeprecatedAlgorithms)
+	DeprecatedAlgorithms []string
+}
+
+// WithWarnings reports non-fatal anomalies of accepted signatures to
+// config.Observer: clock skew near the maximum age, deprecated
+// algorithms and signature headers near the size limit. A
+// DefaultDIDVerifier using this verifier also reports signatures verified
+// by a key being rotated out. Rejected requests are not reported.
+func WithWarnings(config WarningConfig) RFC9421Option {
+	return func(v *RFC9421Verifier) {
+		if config.Threshold <= 0 {
+			config.Threshold = defaultWarningThreshold
+		}
+		if config.DeprecatedAlgorithms == nil {
+			config.DeprecatedAlgorithms = DefaultDeprecatedAlgorithms
+		}
+		v.warnings = &config
+	}
+}
+
+// WarningObserver returns the observer set with WithWarnings, if any
+func (v *RFC9421Verifier) WarningObserver() WarningObserver {
+	if v.warnings == nil {
+		return nil
+	}
+	return v.warnings.Observer
+}
+
+// warningSource is implemented by signature verifiers that report
+// warnings
+type warningSource interface {
+	WarningObserver() WarningObserver
+}
+
+// collectWarnings returns the warnings for the signature labeled label
+// with params on req
+func (v *RFC9421Verifier) collectWarnings(req *http.Request, label string, params *rfc9421.SignatureInputParams) []Warning {
+	config := v.warnings
+	if config == nil || config.Observer == nil || params == nil {
+		return nil
+	}
+	var warnings []Warning
+	warn := func(code WarningCode, format string, args ...any) {
+		warnings = append(warnings, Warning{Code: code, KeyID: params.KeyID, Label: label, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if params.Created != 0 {
+		now := time.Now()
+		created := time.Unix(params.Created, 0)
+		age := now.Sub(created)
+		switch {
+		case created.After(now.Add(maxFutureSkew)):
+			warn(WarningClockSkew, "signature created %s in the future", (-age).Round(time.Second))
+		case v.options.MaxAge > 0 && float64(age) > config.Threshold*float64(v.options.MaxAge):
+			warn(WarningClockSkew, "signature age %s is close to the maximum of %s", age.Round(time.Second), v.options.MaxAge)
+		}
+	}
+
+	if params.Algorithm != "" && slices.ContainsFunc(config.DeprecatedAlgorithms, func(alg string) bool {
+		return strings.EqualFold(alg, params.Algorithm)
+	}) {
+		warn(WarningDeprecatedAlgorithm, "signature algorithm %q is deprecated", params.Algorithm)
+	}
+
+	limit := v.maxHeaderSize
+	if limit == 0 {
+		limit = signer.MaxSignatureHeaderSize
+	}
+	if size := len(req.Header.Get("Signature-Input")) + len(req.Header.Get("Signature")); float64(size) > config.Threshold*float64(limit) {
+		warn(WarningOversizedSignature, "signature headers are %d bytes, close to the maximum of %d", size, limit)
+	}
+	return warnings
+}
+
+// reportWarnings passes warnings to observer
+func reportWarnings(ctx context.Context, observer WarningObserver, warnings ...Warning) {
+	if observer == nil {
+		return
+	}
+	for _, w := range warnings {
+		observer(ctx, w)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2025 SAGE-X Project
+//
+// This file is part of sage-a2a-go.
+//
+// sage-a2a-go is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// sage-a2a-go is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with sage-a2a-go.  If not, see <https://www.gnu.org/licenses/>.
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-a2a-go/pkg/signer"
+	sagecrypto "github.com/sage-x-project/sage/pkg/agent/crypto"
+	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
+	"github.com/sage-x-project/sage/pkg/agent/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedWarningRequest(t *testing.T, keyPair sagecrypto.KeyPair, opts *signer.SigningOptions) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/rpc", strings.NewReader(`{}`))
+	require.NoError(t, signer.NewDefaultA2ASigner().SignRequestWithOptions(context.Background(), req, "did:sage:ethereum:0x1", keyPair, opts))
+	return req
+}
+
+func TestRFC9421Verifier_Warnings(t *testing.T) {
+	keyPair, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	components := []string{"@method", "@path", "content-digest"}
+
+	var got []Warning
+	observe := func(ctx context.Context, w Warning) { got = append(got, w) }
+
+	t.Run("none", func(t *testing.T) {
+		got = nil
+		req := signedWarningRequest(t, keyPair, &signer.SigningOptions{Components: components})
+		v := NewRFC9421Verifier(WithWarnings(WarningConfig{Observer: observe}))
+		require.NoError(t, v.VerifyHTTPRequest(req, keyPair.PublicKey()))
+		assert.Empty(t, got)
+	})
+
+	t.Run("clock skew", func(t *testing.T) {
+		got = nil
+		created := time.Now().Add(-270 * time.Second).Unix()
+		req := signedWarningRequest(t, keyPair, &signer.SigningOptions{Components: components, Created: created})
+		v := NewRFC9421Verifier(WithWarnings(WarningConfig{Observer: observe}))
+		require.NoError(t, v.VerifyHTTPRequest(req, keyPair.PublicKey()))
+		require.Len(t, got, 1)
+		assert.Equal(t, WarningClockSkew, got[0].Code)
+		assert.Equal(t, "did:sage:ethereum:0x1", got[0].KeyID)
+		assert.Equal(t, signer.DefaultSignatureLabel, got[0].Label)
+	})
+
+	t.Run("deprecated algorithm", func(t *testing.T) {
+		got = nil
+		req := signedWarningRequest(t, keyPair, &signer.SigningOptions{Components: components})
+		v := NewRFC9421Verifier(WithWarnings(WarningConfig{Observer: observe, DeprecatedAlgorithms: []string{"ED25519"}}))
+		require.NoError(t, v.VerifyHTTPRequest(req, keyPair.PublicKey()))
+		require.Len(t, got, 1)
+		assert.Equal(t, WarningDeprecatedAlgorithm, got[0].Code)
+	})
+
+	t.Run("oversized signature", func(t *testing.T) {
+		got = nil
+		req := signedWarningRequest(t, keyPair, &signer.SigningOptions{Components: components})
+		size := len(req.Header.Get("Signature-Input")) + len(req.Header.Get("Signature"))
+		v := NewRFC9421Verifier(WithMaxSignatureHeaderSize(size+1), WithWarnings(WarningConfig{Observer: observe}))
+		require.NoError(t, v.VerifyHTTPRequest(req, keyPair.PublicKey()))
+		require.Len(t, got, 1)
+		assert.Equal(t, WarningOversizedSignature, got[0].Code)
+	})
+
+	t.Run("rejected requests are not reported", func(t *testing.T) {
+		got = nil
+		other, err := keys.GenerateEd25519KeyPair()
+		require.NoError(t, err)
+		created := time.Now().Add(-270 * time.Second).Unix()
+		req := signedWarningRequest(t, keyPair, &signer.SigningOptions{Components: components, Created: created})
+		v := NewRFC9421Verifier(WithWarnings(WarningConfig{Observer: observe}))
+		require.Error(t, v.VerifyHTTPRequest(req, other.PublicKey()))
+		assert.Empty(t, got)
+	})
+}
+
+// listingSelector selects the first of its keys and lists all of them
+type listingSelector struct {
+	keys []crypto.PublicKey
+}
+
+func (s *listingSelector) SelectKey(ctx context.Context, agentDID did.AgentDID, protocol string) (crypto.PublicKey, did.KeyType, error) {
+	return s.keys[0], did.KeyTypeEd25519, nil
+}
+
+func (s *listingSelector) ListKeys(ctx context.Context, agentDID did.AgentDID, keyType did.KeyType) ([]crypto.PublicKey, error) {
+	return s.keys, nil
+}
+
+func TestDefaultDIDVerifier_KeyRotationWarning(t *testing.T) {
+	current, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+	previous, err := keys.GenerateEd25519KeyPair()
+	require.NoError(t, err)
+
+	var got []Warning
+	sigVerifier := NewRFC9421Verifier(WithWarnings(WarningConfig{
+		Observer: func(ctx context.Context, w Warning) { got = append(got, w) },
+	}))
+	selector := &listingSelector{keys: []crypto.PublicKey{current.PublicKey(), previous.PublicKey()}}
+	v := NewDefaultDIDVerifier(nil, selector, sigVerifier)
+	components := []string{"@method", "@path", "content-digest"}
+
+	req := signedWarningRequest(t, current, &signer.SigningOptions{Components: components})
+	require.NoError(t, v.VerifyHTTPSignature(context.Background(), req, "did:sage:ethereum:0x1"))
+	assert.Empty(t, got)
+
+	req = signedWarningRequest(t, previous, &signer.SigningOptions{Components: components})
+	require.NoError(t, v.VerifyHTTPSignature(context.Background(), req, "did:sage:ethereum:0x1"))
+	require.Len(t, got, 1)
+	assert.Equal(t, WarningKeyRotation, got[0].Code)
+	assert.Equal(t, "did:sage:ethereum:0x1", got[0].KeyID)
+}
@@ -28,35 +28,15 @@ import (
 	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
+	_ "github.com/sage-x-project/sage-a2a-go/pkg/cryptoinit"
 	"github.com/sage-x-project/sage-a2a-go/pkg/protocol"
 	"github.com/sage-x-project/sage-a2a-go/pkg/transport"
 	"github.com/sage-x-project/sage/pkg/agent/crypto"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/formats"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/keys"
-	"github.com/sage-x-project/sage/pkg/agent/crypto/storage"
 	"github.com/sage-x-project/sage/pkg/agent/did"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func init() {
-	// Initialize crypto subsystem
-	crypto.SetKeyGenerators(
-		func() (crypto.KeyPair, error) { return keys.GenerateEd25519KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateSecp256k1KeyPair() },
-		func() (crypto.KeyPair, error) { return keys.GenerateP256KeyPair() },
-	)
-	crypto.SetStorageConstructors(
-		func() crypto.KeyStorage { return storage.NewMemoryKeyStorage() },
-	)
-	crypto.SetFormatConstructors(
-		func() crypto.KeyExporter { return formats.NewJWKExporter() },
-		func() crypto.KeyExporter { return formats.NewPEMExporter() },
-		func() crypto.KeyImporter { return formats.NewJWKImporter() },
-		func() crypto.KeyImporter { return formats.NewPEMImporter() },
-	)
-}
-
 // TestE2E_FullHTTPCycle tests the complete HTTP request/response cycle with DID authentication
 func TestE2E_FullHTTPCycle(t *testing.T) {
 	// Setup: Create client DID and keypair